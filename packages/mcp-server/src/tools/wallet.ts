@@ -14,7 +14,7 @@ export const walletTools: ToolDefinition[] = [
       properties: {
         walletAddress: { type: 'string', description: 'Wallet address' },
         chain: { type: 'string', enum: ['BASE'], description: 'Blockchain network (default: BASE)' },
-        type: { type: 'string', enum: ['API', 'SCANNER'], description: 'Data source type (default: API)' },
+        type: { type: 'string', enum: ['API', 'DB', 'HYBRID', 'SCANNER'], description: 'Data source: API reads Moralis and the chain, DB (formerly SCANNER) the stored wallet, HYBRID the stored wallet refreshed in the background when stale (default: API)' },
         tokenAddresses: {
           type: 'array',
           items: { type: 'string' },
@@ -41,7 +41,7 @@ export const walletTools: ToolDefinition[] = [
       properties: {
         walletAddress: { type: 'string', description: 'Wallet address' },
         chain: { type: 'string', enum: ['BASE'], description: 'Blockchain network (default: BASE)' },
-        type: { type: 'string', enum: ['API', 'SCANNER'], description: 'Data source type (default: API)' },
+        type: { type: 'string', enum: ['API', 'DB', 'HYBRID', 'SCANNER'], description: 'Data source: API reads Moralis and the chain, DB (formerly SCANNER) the stored wallet, HYBRID the stored wallet refreshed in the background when stale (default: API)' },
         tokenAddresses: {
           type: 'array',
           items: { type: 'string' },
@@ -70,7 +70,7 @@ export const walletTools: ToolDefinition[] = [
       properties: {
         walletAddress: { type: 'string', description: 'Wallet address' },
         chain: { type: 'string', enum: ['BASE'], description: 'Blockchain network (default: BASE)' },
-        type: { type: 'string', enum: ['API', 'SCANNER'], description: 'Data source type (default: API)' },
+        type: { type: 'string', enum: ['API', 'DB', 'HYBRID', 'SCANNER'], description: 'Data source: API reads Moralis and the chain, DB (formerly SCANNER) the stored wallet, HYBRID the stored wallet refreshed in the background when stale (default: API)' },
         tokenAddresses: {
           type: 'array',
           items: { type: 'string' },
@@ -0,0 +1,250 @@
+// Package integration boots tokendata and walletdata against dockerized Postgres, a scripted
+// mock Base node and stubbed external APIs, so the pipeline from adding a token to valuing a
+// wallet can be exercised end to end. Tests need Docker and are skipped without it or with -short.
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	token "walletdata/proto/token"
+	wallet "walletdata/proto/wallet"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	postgresImage  = "postgres:16-alpine"
+	startupTimeout = 2 * time.Minute
+)
+
+// Environment is a running tokendata and walletdata pair wired to a MockChain and APIStubs, each
+// service with its own Postgres container.
+type Environment struct {
+	Chain   *MockChain
+	APIs    *APIStubs
+	Tokens  token.ScannerTokenClient
+	Wallets wallet.ScannerWalletClient
+}
+
+// Options tune the services started by Start.
+type Options struct {
+	// AnchorTokens is passed to both services as ANCHOR_TOKENS.
+	AnchorTokens string
+	// Setup runs after the mocks are created and before the services boot, for scripting what
+	// the services read at startup.
+	Setup func(env *Environment)
+}
+
+// Start boots both services and stops them when the test ends. Service logs are printed if the
+// test fails.
+func Start(t *testing.T, opts Options) *Environment {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("integration tests are skipped with -short")
+	}
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), startupTimeout)
+	defer cancel()
+
+	chain, err := NewMockChain()
+	if err != nil {
+		t.Fatalf("start mock chain: %v", err)
+	}
+	t.Cleanup(chain.Close)
+	apis := NewAPIStubs()
+	t.Cleanup(apis.Close)
+	env := &Environment{Chain: chain, APIs: apis}
+	if opts.Setup != nil {
+		opts.Setup(env)
+	}
+
+	tokendataDB := startPostgres(ctx, t, "tokendata")
+	walletdataDB := startPostgres(ctx, t, "walletdata")
+	migrate(ctx, t, "tokendata", tokendataDB)
+	migrate(ctx, t, "walletdata", walletdataDB)
+
+	tokendataPort, tokendataHTTPPort := freePort(t), freePort(t)
+	startService(ctx, t, "tokendata", append(apis.Env(),
+		"DATABASE_URL="+tokendataDB,
+		fmt.Sprintf("PORT=%d", tokendataPort),
+		fmt.Sprintf("HTTP_PORT=%d", tokendataHTTPPort),
+		"RPC_SOCKET_URL="+chain.WSURL(),
+		"ANCHOR_TOKENS="+opts.AnchorTokens,
+		"IMAGE_CACHE_DIR="+t.TempDir(),
+	))
+	tokendataAddr := fmt.Sprintf("127.0.0.1:%d", tokendataPort)
+	waitForPort(ctx, t, "tokendata", tokendataAddr)
+
+	walletdataPort := freePort(t)
+	startService(ctx, t, "walletdata", append(apis.Env(),
+		"DATABASE_URL="+walletdataDB,
+		fmt.Sprintf("PORT=%d", walletdataPort),
+		"RPC_URL="+chain.HTTPURL(),
+		"RPC_WS_URL="+chain.WSURL(),
+		"TOKEN_GRPC_URL="+tokendataAddr,
+		"ANCHOR_TOKENS="+opts.AnchorTokens,
+	))
+	walletdataAddr := fmt.Sprintf("127.0.0.1:%d", walletdataPort)
+	waitForPort(ctx, t, "walletdata", walletdataAddr)
+
+	env.Tokens = token.NewScannerTokenClient(dial(t, tokendataAddr))
+	env.Wallets = wallet.NewScannerWalletClient(dial(t, walletdataAddr))
+	return env
+}
+
+// Eventually polls check until it returns nil, failing the test with the last error once the
+// timeout passes.
+func Eventually(t *testing.T, timeout time.Duration, check func() error) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for {
+		err := check()
+		if err == nil {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("condition not met after %s: %v", timeout, err)
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+}
+
+// servicesDir is the directory holding the Go services, the parent of this package.
+func servicesDir(t *testing.T) string {
+	_, file, _, ok := runtime.Caller(0)
+	if !ok {
+		t.Fatal("could not locate the integration package")
+	}
+	return filepath.Dir(filepath.Dir(file))
+}
+
+func startPostgres(ctx context.Context, t *testing.T, database string) string {
+	t.Helper()
+	container, err := postgres.Run(ctx, postgresImage,
+		postgres.WithDatabase(database),
+		postgres.WithUsername("postgres"),
+		postgres.WithPassword("postgres"),
+		postgres.BasicWaitStrategies(),
+	)
+	testcontainers.CleanupContainer(t, container)
+	if err != nil {
+		t.Fatalf("start %s postgres: %v", database, err)
+	}
+	url, err := container.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		t.Fatalf("%s postgres connection string: %v", database, err)
+	}
+	return url
+}
+
+// migrate applies the Prisma migrations of a service the same way `make db-migrate` does.
+func migrate(ctx context.Context, t *testing.T, service string, databaseURL string) {
+	t.Helper()
+	cmd := exec.CommandContext(ctx, "go", "run", "github.com/steebchen/prisma-client-go", "migrate", "deploy")
+	cmd.Dir = filepath.Join(servicesDir(t), service)
+	cmd.Env = append(os.Environ(), "DATABASE_URL="+databaseURL)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("migrate %s: %v\n%s", service, err, out)
+	}
+}
+
+// startService builds a service and runs it with env on top of the current environment. DOCKER
+// keeps the service from loading .env files, so only the given configuration applies.
+func startService(ctx context.Context, t *testing.T, service string, env []string) {
+	t.Helper()
+	dir := filepath.Join(servicesDir(t), service)
+	binary := filepath.Join(t.TempDir(), service)
+	build := exec.CommandContext(ctx, "go", "build", "-o", binary, ".")
+	build.Dir = dir
+	if out, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("build %s: %v\n%s", service, err, out)
+	}
+
+	logPath := filepath.Join(t.TempDir(), service+".log")
+	logFile, err := os.Create(logPath)
+	if err != nil {
+		t.Fatalf("create %s log: %v", service, err)
+	}
+	cmd := exec.Command(binary)
+	cmd.Dir = dir
+	cmd.Env = append(append(os.Environ(), "DOCKER=true"), env...)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("start %s: %v", service, err)
+	}
+
+	t.Cleanup(func() {
+		stop(cmd)
+		logFile.Close()
+		if t.Failed() {
+			if logs, err := os.ReadFile(logPath); err == nil {
+				t.Logf("%s logs:\n%s", service, logs)
+			}
+		}
+	})
+}
+
+// stop interrupts a service so it disconnects from its database, killing it if it hangs.
+func stop(cmd *exec.Cmd) {
+	done := make(chan struct{})
+	go func() {
+		cmd.Wait()
+		close(done)
+	}()
+	cmd.Process.Signal(os.Interrupt)
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		cmd.Process.Kill()
+		<-done
+	}
+}
+
+func freePort(t *testing.T) int {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("find free port: %v", err)
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+func waitForPort(ctx context.Context, t *testing.T, service string, addr string) {
+	t.Helper()
+	for {
+		conn, err := net.DialTimeout("tcp", addr, time.Second)
+		if err == nil {
+			conn.Close()
+			return
+		}
+		select {
+		case <-ctx.Done():
+			t.Fatalf("%s did not listen on %s: %v", service, addr, err)
+		case <-time.After(250 * time.Millisecond):
+		}
+	}
+}
+
+func dial(t *testing.T, addr string) *grpc.ClientConn {
+	t.Helper()
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("dial %s: %v", addr, err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
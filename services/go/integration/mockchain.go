@@ -0,0 +1,427 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// baseChainID is the chain id the mock reports, Base mainnet.
+const baseChainID = 8453
+
+var (
+	swapEventTopic     = crypto.Keccak256Hash([]byte("Swap(address,address,int256,int256,uint160,uint128,int24)"))
+	transferEventTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+)
+
+// MockChain is a scripted Base node. It answers the JSON-RPC calls the services make over HTTP
+// and WebSocket from registered contract results, and pushes the swaps a test emits to log and
+// mined transaction subscribers.
+type MockChain struct {
+	server *httptest.Server
+	rpc    *rpc.Server
+	ws     http.Handler
+
+	mu       sync.Mutex
+	block    uint64
+	calls    map[contractCall][]byte
+	balances map[common.Address]*big.Int
+	receipts map[common.Hash]*types.Receipt
+	logSubs  map[rpc.ID]*logSubscription
+	txSubs   map[rpc.ID]*minedTxSubscription
+	changed  chan struct{}
+}
+
+// contractCall identifies an eth_call by contract and 4 byte selector; arguments are ignored.
+type contractCall struct {
+	to       common.Address
+	selector [4]byte
+}
+
+type logSubscription struct {
+	notifier *rpc.Notifier
+	id       rpc.ID
+	filter   logFilter
+}
+
+type minedTxSubscription struct {
+	notifier *rpc.Notifier
+	id       rpc.ID
+	filter   minedTxFilter
+}
+
+// NewMockChain starts a mock node on a local port. Close stops it.
+func NewMockChain() (*MockChain, error) {
+	chain := &MockChain{
+		rpc:      rpc.NewServer(),
+		block:    1,
+		calls:    map[contractCall][]byte{},
+		balances: map[common.Address]*big.Int{},
+		receipts: map[common.Hash]*types.Receipt{},
+		logSubs:  map[rpc.ID]*logSubscription{},
+		txSubs:   map[rpc.ID]*minedTxSubscription{},
+		changed:  make(chan struct{}),
+	}
+	if err := chain.rpc.RegisterName("eth", &ethAPI{chain: chain}); err != nil {
+		return nil, err
+	}
+	chain.ws = chain.rpc.WebsocketHandler([]string{"*"})
+	chain.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+			chain.ws.ServeHTTP(w, r)
+			return
+		}
+		chain.rpc.ServeHTTP(w, r)
+	}))
+	return chain, nil
+}
+
+// HTTPURL is the JSON-RPC endpoint of the mock.
+func (c *MockChain) HTTPURL() string {
+	return c.server.URL
+}
+
+// WSURL is the WebSocket endpoint of the mock, which also serves subscriptions.
+func (c *MockChain) WSURL() string {
+	return "ws" + strings.TrimPrefix(c.server.URL, "http")
+}
+
+func (c *MockChain) Close() {
+	c.rpc.Stop()
+	c.server.Close()
+}
+
+// SetCall makes eth_call to the function with the given signature, e.g. "decimals()", on the
+// contract return result.
+func (c *MockChain) SetCall(contract common.Address, signature string, result []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls[contractCall{to: contract, selector: selector(signature)}] = result
+}
+
+// AddToken registers an ERC-20 token so decimals() and totalSupply() calls succeed.
+func (c *MockChain) AddToken(token common.Address, decimals int, totalSupply *big.Int) {
+	c.SetCall(token, "decimals()", word(big.NewInt(int64(decimals))))
+	c.SetCall(token, "totalSupply()", word(totalSupply))
+}
+
+// AddPool registers a Uniswap V3 pool so token0() and token1() calls succeed.
+func (c *MockChain) AddPool(pool common.Address, token0 common.Address, token1 common.Address) {
+	c.SetCall(pool, "token0()", common.LeftPadBytes(token0.Bytes(), 32))
+	c.SetCall(pool, "token1()", common.LeftPadBytes(token1.Bytes(), 32))
+}
+
+// SetBalance sets the native balance eth_getBalance reports for an account.
+func (c *MockChain) SetBalance(account common.Address, wei *big.Int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.balances[account] = new(big.Int).Set(wei)
+}
+
+// WaitForLogSubscription blocks until a log subscription for the contract exists.
+func (c *MockChain) WaitForLogSubscription(ctx context.Context, contract common.Address) error {
+	return c.waitFor(ctx, func() bool {
+		for _, sub := range c.logSubs {
+			if len(sub.filter.Addresses) == 0 || slices.Contains(sub.filter.Addresses, contract) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// WaitForWalletSubscription blocks until a mined transaction subscription for the wallet exists.
+func (c *MockChain) WaitForWalletSubscription(ctx context.Context, wallet common.Address) error {
+	return c.waitFor(ctx, func() bool {
+		for _, sub := range c.txSubs {
+			if sub.filter.matches(wallet, nil) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func (c *MockChain) waitFor(ctx context.Context, ready func() bool) error {
+	for {
+		c.mu.Lock()
+		ok := ready()
+		changed := c.changed
+		c.mu.Unlock()
+		if ok {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// notifyChanged wakes up waiters after the subscriptions changed. c.mu must be held.
+func (c *MockChain) notifyChanged() {
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+// Swap is a Uniswap V3 swap in which Trader sends the transaction and receives Bought of Token
+// from the pool.
+type Swap struct {
+	Pool         common.Address
+	Token        common.Address
+	Trader       common.Address
+	Amount0      *big.Int
+	Amount1      *big.Int
+	SqrtPriceX96 *big.Int
+	Tick         int64
+	Bought       *big.Int
+}
+
+// EmitSwap mines a transaction holding the swap and the token transfer to the trader. Its Swap
+// log goes to matching log subscribers and the transaction to the trader's mined transaction
+// subscribers.
+func (c *MockChain) EmitSwap(swap Swap) common.Hash {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.block++
+	txHash := crypto.Keccak256Hash(swap.Pool.Bytes(), new(big.Int).SetUint64(c.block).Bytes())
+	blockHash := crypto.Keccak256Hash(txHash.Bytes())
+
+	swapData := append(word(swap.Amount0), word(swap.Amount1)...)
+	swapData = append(swapData, word(swap.SqrtPriceX96)...)
+	swapData = append(swapData, word(big.NewInt(1))...)
+	swapData = append(swapData, word(big.NewInt(swap.Tick))...)
+	swapLog := &types.Log{
+		Address:     swap.Pool,
+		Topics:      []common.Hash{swapEventTopic, addressTopic(swap.Trader), addressTopic(swap.Trader)},
+		Data:        swapData,
+		BlockNumber: c.block,
+		TxHash:      txHash,
+		BlockHash:   blockHash,
+		Index:       0,
+	}
+	transferLog := &types.Log{
+		Address:     swap.Token,
+		Topics:      []common.Hash{transferEventTopic, addressTopic(swap.Pool), addressTopic(swap.Trader)},
+		Data:        word(swap.Bought),
+		BlockNumber: c.block,
+		TxHash:      txHash,
+		BlockHash:   blockHash,
+		Index:       1,
+	}
+	c.receipts[txHash] = &types.Receipt{
+		Status:            types.ReceiptStatusSuccessful,
+		CumulativeGasUsed: 150000,
+		GasUsed:           150000,
+		Logs:              []*types.Log{swapLog, transferLog},
+		TxHash:            txHash,
+		BlockHash:         blockHash,
+		BlockNumber:       new(big.Int).SetUint64(c.block),
+	}
+
+	for _, sub := range c.logSubs {
+		if sub.filter.matches(swapLog) {
+			sub.notifier.Notify(sub.id, swapLog)
+		}
+	}
+	pool := swap.Pool
+	tx := minedTransaction{
+		Hash:  txHash,
+		From:  swap.Trader,
+		To:    &pool,
+		Value: (*hexutil.Big)(big.NewInt(0)),
+		Gas:   200000,
+		Input: hexutil.Bytes{},
+	}
+	for _, sub := range c.txSubs {
+		if sub.filter.matches(tx.From, tx.To) {
+			sub.notifier.Notify(sub.id, tx)
+		}
+	}
+	return txHash
+}
+
+// Mine adds n empty blocks, confirming the swaps emitted before them.
+func (c *MockChain) Mine(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.block += uint64(n)
+}
+
+// ethAPI is the eth namespace of the mock node.
+type ethAPI struct {
+	chain *MockChain
+}
+
+type callArgs struct {
+	To    *common.Address `json:"to"`
+	Input hexutil.Bytes   `json:"input"`
+	Data  hexutil.Bytes   `json:"data"`
+}
+
+func (api *ethAPI) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(baseChainID))
+}
+
+func (api *ethAPI) BlockNumber() hexutil.Uint64 {
+	api.chain.mu.Lock()
+	defer api.chain.mu.Unlock()
+	return hexutil.Uint64(api.chain.block)
+}
+
+func (api *ethAPI) Call(args callArgs, _ *rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	input := args.Input
+	if len(input) == 0 {
+		input = args.Data
+	}
+	if args.To == nil || len(input) < 4 {
+		return nil, errors.New("execution reverted")
+	}
+	key := contractCall{to: *args.To}
+	copy(key.selector[:], input[:4])
+
+	api.chain.mu.Lock()
+	defer api.chain.mu.Unlock()
+	result, ok := api.chain.calls[key]
+	if !ok {
+		return nil, errors.New("execution reverted")
+	}
+	return result, nil
+}
+
+func (api *ethAPI) GetBalance(account common.Address, _ *rpc.BlockNumberOrHash) *hexutil.Big {
+	api.chain.mu.Lock()
+	defer api.chain.mu.Unlock()
+	balance, ok := api.chain.balances[account]
+	if !ok {
+		balance = big.NewInt(0)
+	}
+	return (*hexutil.Big)(new(big.Int).Set(balance))
+}
+
+func (api *ethAPI) GetTransactionReceipt(hash common.Hash) *types.Receipt {
+	api.chain.mu.Lock()
+	defer api.chain.mu.Unlock()
+	return api.chain.receipts[hash]
+}
+
+// logFilter is the filter of an eth_subscribe("logs") call.
+type logFilter struct {
+	Addresses []common.Address `json:"address"`
+	Topics    [][]common.Hash  `json:"topics"`
+}
+
+func (f logFilter) matches(vLog *types.Log) bool {
+	if len(f.Addresses) > 0 && !slices.Contains(f.Addresses, vLog.Address) {
+		return false
+	}
+	for i, alternatives := range f.Topics {
+		if len(alternatives) == 0 {
+			continue
+		}
+		if i >= len(vLog.Topics) || !slices.Contains(alternatives, vLog.Topics[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (api *ethAPI) Logs(ctx context.Context, filter logFilter) (*rpc.Subscription, error) {
+	notifier, ok := rpc.NotifierFromContext(ctx)
+	if !ok {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	sub := notifier.CreateSubscription()
+	api.chain.subscribe(sub, func() { api.chain.logSubs[sub.ID] = &logSubscription{notifier: notifier, id: sub.ID, filter: filter} })
+	return sub, nil
+}
+
+// minedTxFilter is the filter of an eth_subscribe("alchemy_minedTransactions") call.
+type minedTxFilter struct {
+	Addresses []struct {
+		To   *common.Address `json:"to"`
+		From *common.Address `json:"from"`
+	} `json:"addresses"`
+}
+
+func (f minedTxFilter) matches(from common.Address, to *common.Address) bool {
+	for _, address := range f.Addresses {
+		if address.From != nil && *address.From == from {
+			return true
+		}
+		if address.To != nil && to != nil && *address.To == *to {
+			return true
+		}
+	}
+	return false
+}
+
+// minedTransaction is the transaction payload of an alchemy_minedTransactions notification.
+type minedTransaction struct {
+	Hash  common.Hash     `json:"hash"`
+	From  common.Address  `json:"from"`
+	To    *common.Address `json:"to"`
+	Value *hexutil.Big    `json:"value"`
+	Nonce hexutil.Uint64  `json:"nonce"`
+	Gas   hexutil.Uint64  `json:"gas"`
+	Input hexutil.Bytes   `json:"input"`
+}
+
+func (api *ethAPI) Alchemy_minedTransactions(ctx context.Context, filter minedTxFilter) (*rpc.Subscription, error) {
+	notifier, ok := rpc.NotifierFromContext(ctx)
+	if !ok {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	sub := notifier.CreateSubscription()
+	api.chain.subscribe(sub, func() {
+		api.chain.txSubs[sub.ID] = &minedTxSubscription{notifier: notifier, id: sub.ID, filter: filter}
+	})
+	return sub, nil
+}
+
+// subscribe registers a subscription with add and removes it once the client unsubscribes or
+// disconnects.
+func (c *MockChain) subscribe(sub *rpc.Subscription, add func()) {
+	c.mu.Lock()
+	add()
+	c.notifyChanged()
+	c.mu.Unlock()
+
+	go func() {
+		<-sub.Err()
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		delete(c.logSubs, sub.ID)
+		delete(c.txSubs, sub.ID)
+		c.notifyChanged()
+	}()
+}
+
+func selector(signature string) [4]byte {
+	var s [4]byte
+	copy(s[:], crypto.Keccak256([]byte(signature))[:4])
+	return s
+}
+
+// word encodes a value as a 32 byte ABI word, two's complement for negative values.
+func word(value *big.Int) []byte {
+	return math.U256Bytes(new(big.Int).Set(value))
+}
+
+func addressTopic(address common.Address) common.Hash {
+	return common.BytesToHash(address.Bytes())
+}
@@ -0,0 +1,137 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	token "walletdata/proto/token"
+	wallet "walletdata/proto/wallet"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	weth        = common.HexToAddress("0x4200000000000000000000000000000000000006")
+	testToken   = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	testPool    = common.HexToAddress("0x2222222222222222222222222222222222222222")
+	testTrader  = common.HexToAddress("0x3333333333333333333333333333333333333333")
+	ether       = new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+	anchorPrice = 2000.0
+)
+
+// TestTokenSwapWalletPipeline adds a token, swaps it on the mock chain and checks that tokendata
+// reprices it from the Swap log and walletdata revalues the trader's wallet from the mined
+// transaction.
+func TestTokenSwapWalletPipeline(t *testing.T) {
+	env := Start(t, Options{
+		// A fixed WETH price makes the swap price independent of the price APIs.
+		AnchorTokens: fmt.Sprintf("WETH=%s@%v,USDC=0x833589fcd6edb6e08f4c7c32d4f71b54bda02913@1", weth.Hex(), anchorPrice),
+		Setup: func(env *Environment) {
+			env.Chain.AddToken(weth, 18, new(big.Int).Mul(big.NewInt(1_000_000), ether))
+			env.Chain.AddToken(testToken, 18, new(big.Int).Mul(big.NewInt(1_000_000_000), ether))
+			env.Chain.AddPool(testPool, weth, testToken)
+			env.APIs.AddPair(DexscreenerPair{
+				DexID:       "uniswap",
+				PairAddress: strings.ToLower(testPool.Hex()),
+				BaseToken:   DexscreenerToken{Address: strings.ToLower(testToken.Hex()), Name: "Test Token", Symbol: "TEST"},
+				QuoteToken:  DexscreenerToken{Address: strings.ToLower(weth.Hex()), Name: "Wrapped Ether", Symbol: "WETH"},
+				PriceUSD:    "400",
+				Liquidity:   DexscreenerLiquidity{USD: 250000},
+			})
+		},
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	tokenAddress := strings.ToLower(testToken.Hex())
+	traderAddress := strings.ToLower(testTrader.Hex())
+
+	// Token add: the Dexscreener pair gives the pool, and the watcher subscribes to its swaps.
+	reason := "integration_test"
+	added, err := env.Tokens.AddTokens(ctx, &token.AddTokensRequest{Tokens: []*token.AddTokenRequest{{TokenAddress: tokenAddress, Reason: &reason}}})
+	if err != nil {
+		t.Fatalf("AddTokens: %v", err)
+	}
+	if len(added.Results) != 1 || !added.Results[0].Success {
+		t.Fatalf("AddTokens results = %+v, want one success", added.Results)
+	}
+	if err := env.Chain.WaitForLogSubscription(ctx, testPool); err != nil {
+		t.Fatalf("pool watcher did not subscribe: %v", err)
+	}
+	assertTokenPrice(t, env, tokenAddress, 400)
+
+	// The trader's wallet is watched before it swaps.
+	if _, err := env.Wallets.AddWallet(ctx, &wallet.AddWalletRequest{WalletAddress: traderAddress}); err != nil {
+		t.Fatalf("AddWallet: %v", err)
+	}
+	if err := env.Chain.WaitForWalletSubscription(ctx, testTrader); err != nil {
+		t.Fatalf("wallet watcher did not subscribe: %v", err)
+	}
+
+	// Swap: the trader buys 2 tokens for 0.5 WETH. sqrtPriceX96 = 2^95 is 0.25 WETH per token,
+	// 500 USD at the fixed WETH price.
+	const swapPrice = 500.0
+	bought := new(big.Int).Mul(big.NewInt(2), ether)
+	env.APIs.SetHoldings(traderAddress, []Holding{{
+		TokenAddress:     tokenAddress,
+		Name:             "Test Token",
+		Symbol:           "TEST",
+		Decimals:         18,
+		Balance:          bought.String(),
+		BalanceFormatted: "2",
+		USDPrice:         swapPrice,
+		USDValue:         2 * swapPrice,
+	}})
+	env.Chain.EmitSwap(Swap{
+		Pool:         testPool,
+		Token:        testToken,
+		Trader:       testTrader,
+		Amount0:      new(big.Int).Div(ether, big.NewInt(2)),
+		Amount1:      new(big.Int).Neg(bought),
+		SqrtPriceX96: new(big.Int).Lsh(big.NewInt(1), 95),
+		Tick:         -13863,
+		Bought:       bought,
+	})
+	// Swap prices are applied once SWAP_CONFIRMATIONS (2 by default) blocks are on top of theirs.
+	env.Chain.Mine(2)
+
+	// Price update.
+	assertTokenPrice(t, env, tokenAddress, swapPrice)
+
+	// Wallet valuation.
+	Eventually(t, 30*time.Second, func() error {
+		resp, err := env.Wallets.GetWallet(ctx, &wallet.GetWalletRequest{WalletAddress: traderAddress})
+		if err != nil {
+			return err
+		}
+		data := resp.WalletData
+		if !slices.Contains(data.TokenAddresses, tokenAddress) {
+			return fmt.Errorf("wallet tokens = %v, want %s", data.TokenAddresses, tokenAddress)
+		}
+		value, err := strconv.ParseFloat(data.TotalDollarValue, 64)
+		if err != nil || value != 2*swapPrice {
+			return fmt.Errorf("wallet value = %q, want %v", data.TotalDollarValue, 2*swapPrice)
+		}
+		return nil
+	})
+}
+
+func assertTokenPrice(t *testing.T, env *Environment, tokenAddress string, want float64) {
+	t.Helper()
+	Eventually(t, 30*time.Second, func() error {
+		resp, err := env.Tokens.GetToken(context.Background(), &token.GetTokenRequest{TokenAddress: tokenAddress})
+		if err != nil {
+			return err
+		}
+		price, err := strconv.ParseFloat(resp.Token.GetPrice(), 64)
+		if err != nil || price != want {
+			return fmt.Errorf("token price = %q, want %v", resp.Token.GetPrice(), want)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,166 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+// DexscreenerToken is the base or quote token of a Dexscreener pair.
+type DexscreenerToken struct {
+	Address string `json:"address"`
+	Name    string `json:"name"`
+	Symbol  string `json:"symbol"`
+}
+
+type DexscreenerLiquidity struct {
+	USD float64 `json:"usd"`
+}
+
+// DexscreenerPair is a pair as returned by the Dexscreener token and pair endpoints.
+type DexscreenerPair struct {
+	ChainID     string               `json:"chainId"`
+	DexID       string               `json:"dexId"`
+	PairAddress string               `json:"pairAddress"`
+	BaseToken   DexscreenerToken     `json:"baseToken"`
+	QuoteToken  DexscreenerToken     `json:"quoteToken"`
+	PriceUSD    string               `json:"priceUsd"`
+	Liquidity   DexscreenerLiquidity `json:"liquidity"`
+}
+
+// Holding is a wallet token as returned by the Moralis wallet tokens endpoint.
+type Holding struct {
+	TokenAddress     string  `json:"token_address"`
+	Name             string  `json:"name"`
+	Symbol           string  `json:"symbol"`
+	Decimals         int     `json:"decimals"`
+	Balance          string  `json:"balance"`
+	BalanceFormatted string  `json:"balance_formatted"`
+	USDPrice         float64 `json:"usd_price"`
+	USDValue         float64 `json:"usd_value"`
+}
+
+// APIStubs serves the Dexscreener, CoinGecko, Moralis, Etherscan and Clanker endpoints the
+// services call, each under its own path prefix. Dexscreener pairs and Moralis holdings come from
+// the test; every other lookup gets the empty answer the real APIs give for unknown tokens.
+type APIStubs struct {
+	server *httptest.Server
+
+	mu       sync.Mutex
+	pairs    map[string][]DexscreenerPair
+	holdings map[string][]Holding
+}
+
+func NewAPIStubs() *APIStubs {
+	stubs := &APIStubs{
+		pairs:    map[string][]DexscreenerPair{},
+		holdings: map[string][]Holding{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /dexscreener/token-pairs/v1/base/{token}", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, stubs.pairsOf(r.PathValue("token")))
+	})
+	mux.HandleFunc("GET /dexscreener/tokens/v1/base/{tokens}", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, stubs.pairsOf(strings.Split(r.PathValue("tokens"), ",")...))
+	})
+	mux.HandleFunc("GET /dexscreener/latest/dex/pairs/base/{pair}", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{"pairs": stubs.pair(r.PathValue("pair"))})
+	})
+	mux.HandleFunc("GET /dexscreener/token-profiles/latest/v1", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, []any{})
+	})
+	mux.HandleFunc("/coingecko/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"errors":[{"status":"404","title":"Not Found"}]}`, http.StatusNotFound)
+	})
+	mux.HandleFunc("GET /moralis/erc20/metadata", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, []any{})
+	})
+	mux.HandleFunc("GET /moralis/erc20/{token}/owners", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{"result": []any{}})
+	})
+	mux.HandleFunc("GET /moralis/wallets/{wallet}/tokens", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{"result": stubs.holdingsOf(r.PathValue("wallet"))})
+	})
+	mux.HandleFunc("/etherscan", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{"status": "0", "message": "No transactions found", "result": []any{}})
+	})
+	mux.HandleFunc("GET /clanker/tokens", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{"data": []any{}})
+	})
+	stubs.server = httptest.NewServer(mux)
+	return stubs
+}
+
+func (s *APIStubs) Close() {
+	s.server.Close()
+}
+
+// Env returns the environment variables pointing a service at the stubs.
+func (s *APIStubs) Env() []string {
+	return []string{
+		"DEXSCREENER_API_URL=" + s.server.URL + "/dexscreener",
+		"COINGECKO_API_URL=" + s.server.URL + "/coingecko",
+		"MORALIS_API_URL=" + s.server.URL + "/moralis",
+		"ETHERSCAN_API_URL=" + s.server.URL + "/etherscan",
+		"CLANKER_API_URL=" + s.server.URL + "/clanker",
+	}
+}
+
+// AddPair lists a pair on the Dexscreener stub under its base token.
+func (s *APIStubs) AddPair(pair DexscreenerPair) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if pair.ChainID == "" {
+		pair.ChainID = "base"
+	}
+	base := strings.ToLower(pair.BaseToken.Address)
+	s.pairs[base] = append(s.pairs[base], pair)
+}
+
+// SetHoldings sets the tokens the Moralis stub reports for a wallet.
+func (s *APIStubs) SetHoldings(wallet string, holdings []Holding) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.holdings[strings.ToLower(wallet)] = holdings
+}
+
+func (s *APIStubs) pairsOf(tokens ...string) []DexscreenerPair {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pairs := []DexscreenerPair{}
+	for _, token := range tokens {
+		pairs = append(pairs, s.pairs[strings.ToLower(token)]...)
+	}
+	return pairs
+}
+
+func (s *APIStubs) pair(address string) []DexscreenerPair {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, pairs := range s.pairs {
+		for _, pair := range pairs {
+			if strings.EqualFold(pair.PairAddress, address) {
+				return []DexscreenerPair{pair}
+			}
+		}
+	}
+	return []DexscreenerPair{}
+}
+
+func (s *APIStubs) holdingsOf(wallet string) []Holding {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	holdings := s.holdings[strings.ToLower(wallet)]
+	if holdings == nil {
+		return []Holding{}
+	}
+	return holdings
+}
+
+func writeJSON(w http.ResponseWriter, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(body)
+}
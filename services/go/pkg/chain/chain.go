@@ -0,0 +1,130 @@
+// Package chain holds the ids and contract addresses of the chain the services run against.
+// CHAIN_ID picks one of the built-in chains; every value can be overridden from the CHAIN_*
+// variables, which is how chains without a built-in config are set up.
+package chain
+
+import (
+	"log"
+	"samterminal/pkg/config"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const BaseChainID int64 = 8453
+
+// Config is a chain and the contracts the services call or recognize on it. Addresses are
+// lowercase.
+type Config struct {
+	ID int64
+	// DexscreenerID names the chain in Dexscreener URLs and pairs.
+	DexscreenerID string
+	// MoralisID names the chain in Moralis requests, which also take the hex chain id.
+	MoralisID string
+
+	WETH string
+	USDC string
+
+	// Uniswap V4 PoolManager, the singleton holding every V4 pool.
+	PoolManager string
+	// Uniswap V4 StateView, which reads pool state out of the PoolManager.
+	StateView string
+	// Uniswap V4 PositionManager, which keeps the key of every pool it added liquidity to.
+	PositionManager string
+	QuoterV2        string
+	V4Quoter        string
+
+	// BankrFactory deploys the Bankr tokens watched for launches.
+	BankrFactory string
+
+	// Routers, Permit2 and the canonical bridge, seeded into the walletdata address book so that
+	// they are not counted as holders or counterparties.
+	UniversalRouter string
+	SwapRouter02    string
+	V2Router        string
+	AerodromeRouter string
+	Permit2         string
+	StandardBridge  string
+}
+
+// chains are the built-in configs by chain id.
+var chains = map[int64]Config{
+	BaseChainID: {
+		ID:              BaseChainID,
+		DexscreenerID:   "base",
+		MoralisID:       "base",
+		WETH:            "0x4200000000000000000000000000000000000006",
+		USDC:            "0x833589fcd6edb6e08f4c7c32d4f71b54bda02913",
+		PoolManager:     "0x498581ff718922c3f8e6a244956af099b2652b2b",
+		StateView:       "0xa3c0c9b65bad0b08107aa264b0f3db444b867a71",
+		PositionManager: "0x7c5f5a4bbd8fd63184577525326123b519429bdc",
+		QuoterV2:        "0x3d4e44eb1374240ce5f1b871ab261cd16335b76a",
+		V4Quoter:        "0x0d5e0f971ed27fbff6c2837bf31316121532048d",
+		BankrFactory:    "0x660eaaedebc968f8f3694354fa8ec0b4c5ba8d12",
+		UniversalRouter: "0x6ff5693b99212da76ad316178a184ab56d299b43",
+		SwapRouter02:    "0x2626664c2603336e57b271c5c0b26f421741e481",
+		V2Router:        "0x4752ba5dbc23f44d87826276bf6fd6b1c372ad24",
+		AerodromeRouter: "0xcf77a3ba9a5ca399b7c97c74d54e5b1beb874e43",
+		Permit2:         "0x000000000022d473030f116ddee9f6b43ac78ba3",
+		StandardBridge:  "0x4200000000000000000000000000000000000010",
+	},
+}
+
+var (
+	loadOnce sync.Once
+	loaded   Config
+)
+
+// Get returns the config of CHAIN_ID, Base by default, with the CHAIN_* overrides applied.
+func Get() Config {
+	loadOnce.Do(func() {
+		loaded = load(config.CHAIN_ID.GetEnvAsNumberOrDefault(BaseChainID), config.Key.GetEnv)
+	})
+	return loaded
+}
+
+// load builds the config of a chain from its built-in config and the overrides getenv returns.
+func load(id int64, getenv func(key config.Key) string) Config {
+	c, ok := chains[id]
+	if !ok {
+		log.Printf("No built-in config for chain %d, using the CHAIN_* variables", id)
+		c = Config{ID: id, DexscreenerID: strconv.FormatInt(id, 10), MoralisID: "0x" + strconv.FormatInt(id, 16)}
+	}
+	if value := strings.TrimSpace(getenv(config.CHAIN_DEXSCREENER_ID)); value != "" {
+		c.DexscreenerID = value
+	}
+	if value := strings.TrimSpace(getenv(config.CHAIN_MORALIS_ID)); value != "" {
+		c.MoralisID = value
+	}
+	for key, address := range map[config.Key]*string{
+		config.CHAIN_WETH:             &c.WETH,
+		config.CHAIN_USDC:             &c.USDC,
+		config.CHAIN_POOL_MANAGER:     &c.PoolManager,
+		config.CHAIN_STATE_VIEW:       &c.StateView,
+		config.CHAIN_POSITION_MANAGER: &c.PositionManager,
+		config.CHAIN_QUOTER_V2:        &c.QuoterV2,
+		config.CHAIN_V4_QUOTER:        &c.V4Quoter,
+		config.CHAIN_BANKR_FACTORY:    &c.BankrFactory,
+		config.CHAIN_UNIVERSAL_ROUTER: &c.UniversalRouter,
+		config.CHAIN_SWAP_ROUTER02:    &c.SwapRouter02,
+		config.CHAIN_V2_ROUTER:        &c.V2Router,
+		config.CHAIN_AERODROME_ROUTER: &c.AerodromeRouter,
+		config.CHAIN_PERMIT2:          &c.Permit2,
+		config.CHAIN_STANDARD_BRIDGE:  &c.StandardBridge,
+	} {
+		value := strings.TrimSpace(getenv(key))
+		switch {
+		case value == "":
+		case common.IsHexAddress(value):
+			*address = strings.ToLower(value)
+		default:
+			log.Printf("Ignoring invalid address %q in %s", value, key)
+		}
+		if *address == "" {
+			log.Printf("%s is not set for chain %d", key, id)
+		}
+	}
+	return c
+}
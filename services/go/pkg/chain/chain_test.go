@@ -0,0 +1,36 @@
+package chain
+
+import (
+	"samterminal/pkg/config"
+	"testing"
+)
+
+func TestLoadBase(t *testing.T) {
+	c := load(BaseChainID, func(config.Key) string { return "" })
+	if c != chains[BaseChainID] {
+		t.Errorf("load(base) = %+v, want the built-in config", c)
+	}
+}
+
+func TestLoadOverrides(t *testing.T) {
+	overrides := map[config.Key]string{
+		config.CHAIN_WETH:           "0x4200000000000000000000000000000000000006",
+		config.CHAIN_POOL_MANAGER:   "0x05E73354cFDd6745C338b50BcFDfA3Aa6fA03408",
+		config.CHAIN_USDC:           "not an address",
+		config.CHAIN_DEXSCREENER_ID: "basesepolia",
+	}
+	c := load(84532, func(key config.Key) string { return overrides[key] })
+
+	if c.ID != 84532 || c.DexscreenerID != "basesepolia" || c.MoralisID != "0x14a34" {
+		t.Errorf("ids = %d, %s, %s", c.ID, c.DexscreenerID, c.MoralisID)
+	}
+	if c.PoolManager != "0x05e73354cfdd6745c338b50bcfdfa3aa6fa03408" {
+		t.Errorf("PoolManager = %s, want the lowercased override", c.PoolManager)
+	}
+	if c.WETH != "0x4200000000000000000000000000000000000006" {
+		t.Errorf("WETH = %s", c.WETH)
+	}
+	if c.USDC != "" || c.BankrFactory != "" {
+		t.Errorf("USDC = %q, BankrFactory = %q; want them unset", c.USDC, c.BankrFactory)
+	}
+}
@@ -0,0 +1,230 @@
+// Package config reads the settings of the Go services from the environment and the env file.
+package config
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// Key is the name of an environment variable.
+type Key string
+
+// Keys read by the shared packages.
+const (
+	DATABASE_URL Key = "DATABASE_URL"
+	// Database pool: at most DB_CONNECTION_LIMIT connections, waited for up to DB_POOL_TIMEOUT,
+	// and statements cancelled after DB_STATEMENT_TIMEOUT. Parameters already set in DATABASE_URL
+	// are kept. Repository calls slower than DB_SLOW_QUERY_THRESHOLD are logged.
+	DB_CONNECTION_LIMIT     Key = "DB_CONNECTION_LIMIT"
+	DB_POOL_TIMEOUT         Key = "DB_POOL_TIMEOUT"
+	DB_CONNECT_TIMEOUT      Key = "DB_CONNECT_TIMEOUT"
+	DB_STATEMENT_TIMEOUT    Key = "DB_STATEMENT_TIMEOUT"
+	DB_SLOW_QUERY_THRESHOLD Key = "DB_SLOW_QUERY_THRESHOLD"
+
+	// Tracing is exported over OTLP when set.
+	OTEL_EXPORTER_OTLP_ENDPOINT Key = "OTEL_EXPORTER_OTLP_ENDPOINT"
+
+	// API_DAILY_BUDGETS holds soft daily call budgets of the external APIs by provider, e.g.
+	// moralis=30000,coingecko=10000. Providers without a budget are only counted.
+	API_DAILY_BUDGETS Key = "API_DAILY_BUDGETS"
+
+	// ENV_FILE is an env file loaded with DOCKER=true, e.g. one mounted into the container, and
+	// loaded again on reload. Without it the container environment, which a running process
+	// cannot read again, is the only source and reloading is refused.
+	ENV_FILE Key = "ENV_FILE"
+
+	// Chain the services run against, Base by default. Chains without a built-in config set
+	// their contracts with the other CHAIN_* keys, which also override the built-in addresses.
+	CHAIN_ID               Key = "CHAIN_ID"
+	CHAIN_DEXSCREENER_ID   Key = "CHAIN_DEXSCREENER_ID"
+	CHAIN_MORALIS_ID       Key = "CHAIN_MORALIS_ID"
+	CHAIN_WETH             Key = "CHAIN_WETH"
+	CHAIN_USDC             Key = "CHAIN_USDC"
+	CHAIN_POOL_MANAGER     Key = "CHAIN_POOL_MANAGER"
+	CHAIN_STATE_VIEW       Key = "CHAIN_STATE_VIEW"
+	CHAIN_POSITION_MANAGER Key = "CHAIN_POSITION_MANAGER"
+	CHAIN_QUOTER_V2        Key = "CHAIN_QUOTER_V2"
+	CHAIN_V4_QUOTER        Key = "CHAIN_V4_QUOTER"
+	CHAIN_BANKR_FACTORY    Key = "CHAIN_BANKR_FACTORY"
+	CHAIN_UNIVERSAL_ROUTER Key = "CHAIN_UNIVERSAL_ROUTER"
+	CHAIN_SWAP_ROUTER02    Key = "CHAIN_SWAP_ROUTER02"
+	CHAIN_V2_ROUTER        Key = "CHAIN_V2_ROUTER"
+	CHAIN_AERODROME_ROUTER Key = "CHAIN_AERODROME_ROUTER"
+	CHAIN_PERMIT2          Key = "CHAIN_PERMIT2"
+	CHAIN_STANDARD_BRIDGE  Key = "CHAIN_STANDARD_BRIDGE"
+)
+
+var (
+	loadMu sync.Mutex
+	// fileEnvVars are the variables set from an env file rather than the environment, which
+	// loading the file again may change.
+	fileEnvVars = map[string]bool{}
+	// mappedEnvVars are the standard names set from a prefixed variable, which loading again
+	// updates.
+	mappedEnvVars = map[string]bool{}
+)
+
+func loadEnvFile(filename string) error {
+	values, err := godotenv.Read(filename)
+	if err != nil {
+		return err
+	}
+	for key, value := range values {
+		if _, set := os.LookupEnv(key); set && !fileEnvVars[key] {
+			continue
+		}
+		os.Setenv(key, value)
+		fileEnvVars[key] = true
+	}
+	return nil
+}
+
+// mapPrefixedEnvVars sets the standard names from the service prefixed variables of the root
+// .env, e.g. TOKENDATA_PORT to PORT, unless the environment sets them.
+func mapPrefixedEnvVars(mappings map[string]string) {
+	for prefixed, standard := range mappings {
+		if val := os.Getenv(prefixed); val != "" && (os.Getenv(standard) == "" || mappedEnvVars[standard]) {
+			os.Setenv(standard, val)
+			mappedEnvVars[standard] = true
+		}
+	}
+}
+
+// Load sets the variables of the first env file found that are not set in the environment: the
+// root .env of the repository, looked up from the executable and then the working directory, or
+// the local file at path. With DOCKER=true only ENV_FILE is loaded, if set. mappings maps
+// prefixed variables to the standard names. Loading again picks up changes to the file, such as
+// rotated API keys: the values it set are updated while the environment still wins.
+func Load(path string, mappings map[string]string) {
+	loadMu.Lock()
+	defer loadMu.Unlock()
+	defer mapPrefixedEnvVars(mappings)
+	if os.Getenv("DOCKER") == "true" {
+		if file := ENV_FILE.GetEnv(); file != "" {
+			if err := loadEnvFile(file); err != nil {
+				log.Printf("could not load ENV_FILE %s: %+v", file, err)
+			} else {
+				log.Println("ENV_FILE loaded")
+			}
+		}
+		return
+	}
+
+	execPath, err := os.Executable()
+	if err == nil {
+		rootEnv := filepath.Join(filepath.Dir(execPath), "..", "..", "..", "..", ".env")
+		if err := loadEnvFile(rootEnv); err == nil {
+			log.Println("root .env file loaded")
+			return
+		}
+	}
+
+	rootEnv := filepath.Join("..", "..", "..", ".env")
+	if err := loadEnvFile(rootEnv); err == nil {
+		log.Println("root .env file loaded (relative path)")
+		return
+	}
+
+	if err := loadEnvFile(path); err != nil {
+		log.Println("env file not found, will read from environment variables")
+	} else {
+		log.Println("local env file loaded")
+	}
+}
+
+// Reloadable reports whether loading again can pick up changes, which it cannot with DOCKER=true
+// and no ENV_FILE.
+func Reloadable() bool {
+	return os.Getenv("DOCKER") != "true" || ENV_FILE.GetEnv() != ""
+}
+
+// ForSource returns the per-source variant of key, e.g. BANKR_DISCOVERY_INTERVAL.
+func (key Key) ForSource(source string) Key {
+	return Key(strings.ToUpper(source) + "_" + string(key))
+}
+
+func (key Key) GetEnv() string {
+	return os.Getenv(string(key))
+}
+
+// GetEnvOrDefault returns the value, or def when it is unset.
+func (key Key) GetEnvOrDefault(def string) string {
+	if val := key.GetEnv(); val != "" {
+		return val
+	}
+	return def
+}
+
+func (key Key) GetEnvAsNumber() int64 {
+	val, err := strconv.ParseInt(key.GetEnv(), 10, 64)
+	if err != nil {
+		log.Fatal(err)
+		return 0
+	}
+	return val
+}
+
+// GetEnvAsNumberOrDefault returns the value as a number, or def when it is unset or invalid.
+func (key Key) GetEnvAsNumberOrDefault(def int64) int64 {
+	raw := key.GetEnv()
+	if raw == "" {
+		return def
+	}
+	val, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		log.Printf("invalid value for %s: %q, using default %d", key, raw, def)
+		return def
+	}
+	return val
+}
+
+// GetEnvAsFloatOrDefault returns the value as a float, or def when it is unset or invalid.
+func (key Key) GetEnvAsFloatOrDefault(def float64) float64 {
+	raw := key.GetEnv()
+	if raw == "" {
+		return def
+	}
+	val, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		log.Printf("invalid value for %s: %q, using default %v", key, raw, def)
+		return def
+	}
+	return val
+}
+
+// GetEnvAsDurationOrDefault parses values such as "5s" or "1m", returning def when the value is
+// unset or invalid.
+func (key Key) GetEnvAsDurationOrDefault(def time.Duration) time.Duration {
+	raw := key.GetEnv()
+	if raw == "" {
+		return def
+	}
+	val, err := time.ParseDuration(raw)
+	if err != nil {
+		log.Printf("invalid value for %s: %q, using default %s", key, raw, def)
+		return def
+	}
+	return val
+}
+
+// GetEnvAsBoolOrDefault parses values such as "true" or "0", returning def when the value is
+// unset or invalid.
+func (key Key) GetEnvAsBoolOrDefault(def bool) bool {
+	raw := key.GetEnv()
+	if raw == "" {
+		return def
+	}
+	val, err := strconv.ParseBool(raw)
+	if err != nil {
+		log.Printf("invalid value for %s: %q, using default %t", key, raw, def)
+		return def
+	}
+	return val
+}
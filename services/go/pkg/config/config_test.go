@@ -0,0 +1,59 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadEnvFileAgain(t *testing.T) {
+	file := filepath.Join(t.TempDir(), ".env")
+	write := func(content string) {
+		if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("TEST_FROM_ENV", "environment")
+	t.Setenv("TEST_FROM_FILE", "")
+	os.Unsetenv("TEST_FROM_FILE")
+
+	write("TEST_FROM_ENV=file\nTEST_FROM_FILE=old\n")
+	if err := loadEnvFile(file); err != nil {
+		t.Fatal(err)
+	}
+	write("TEST_FROM_ENV=file\nTEST_FROM_FILE=rotated\n")
+	if err := loadEnvFile(file); err != nil {
+		t.Fatal(err)
+	}
+	if value := os.Getenv("TEST_FROM_FILE"); value != "rotated" {
+		t.Errorf("TEST_FROM_FILE = %q after a reload, want the new value", value)
+	}
+	if value := os.Getenv("TEST_FROM_ENV"); value != "environment" {
+		t.Errorf("TEST_FROM_ENV = %q, want the environment to win over the file", value)
+	}
+}
+
+func TestLoadInDocker(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "mounted.env")
+	if err := os.WriteFile(file, []byte("TEST_MOUNTED=mounted\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("DOCKER", "true")
+	t.Setenv("ENV_FILE", "")
+	t.Setenv("TEST_MOUNTED", "")
+	os.Unsetenv("TEST_MOUNTED")
+
+	if Reloadable() {
+		t.Error("reloadable in Docker without ENV_FILE")
+	}
+	Load(file, nil)
+	if value := os.Getenv("TEST_MOUNTED"); value != "" {
+		t.Errorf("TEST_MOUNTED = %q, want the local file skipped in Docker", value)
+	}
+
+	t.Setenv("ENV_FILE", file)
+	Load("", nil)
+	if value := os.Getenv("TEST_MOUNTED"); value != "mounted" || !Reloadable() {
+		t.Errorf("TEST_MOUNTED = %q, reloadable = %v; want ENV_FILE loaded and reloadable", value, Reloadable())
+	}
+}
@@ -0,0 +1,69 @@
+// Package dbconn holds the database lifecycle shared by the Prisma clients of the services.
+package dbconn
+
+import (
+	"log"
+	"net/url"
+	"samterminal/pkg/config"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// poolSettings are the connection pool and timeout parameters added to the datasource URL. Zero
+// values leave the Prisma defaults.
+type poolSettings struct {
+	connectionLimit  int64
+	poolTimeout      time.Duration
+	connectTimeout   time.Duration
+	statementTimeout time.Duration
+}
+
+// DatasourceURL is DATABASE_URL with the pool settings of the env added.
+func DatasourceURL() string {
+	return withPoolSettings(config.DATABASE_URL.GetEnv(), poolSettings{
+		connectionLimit:  config.DB_CONNECTION_LIMIT.GetEnvAsNumberOrDefault(0),
+		poolTimeout:      config.DB_POOL_TIMEOUT.GetEnvAsDurationOrDefault(0),
+		connectTimeout:   config.DB_CONNECT_TIMEOUT.GetEnvAsDurationOrDefault(0),
+		statementTimeout: config.DB_STATEMENT_TIMEOUT.GetEnvAsDurationOrDefault(0),
+	})
+}
+
+// withPoolSettings adds the settings to a Postgres URL as the connection_limit, pool_timeout and
+// connect_timeout parameters Prisma reads and a statement_timeout server option. Parameters the
+// URL already sets are kept.
+func withPoolSettings(raw string, settings poolSettings) string {
+	if raw == "" {
+		return raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		log.Printf("Invalid database URL, pool settings not applied: %v", err)
+		return raw
+	}
+	query := u.Query()
+	set := func(name, value string) {
+		if !query.Has(name) {
+			query.Set(name, value)
+		}
+	}
+	if settings.connectionLimit > 0 {
+		set("connection_limit", strconv.FormatInt(settings.connectionLimit, 10))
+	}
+	if settings.poolTimeout > 0 {
+		set("pool_timeout", seconds(settings.poolTimeout))
+	}
+	if settings.connectTimeout > 0 {
+		set("connect_timeout", seconds(settings.connectTimeout))
+	}
+	if options := query.Get("options"); settings.statementTimeout > 0 && !strings.Contains(options, "statement_timeout") {
+		query.Set("options", strings.TrimSpace(options+" -c statement_timeout="+strconv.FormatInt(settings.statementTimeout.Milliseconds(), 10)))
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// seconds rounds a timeout up to whole seconds, the unit of the Prisma timeouts.
+func seconds(d time.Duration) string {
+	return strconv.FormatInt(int64((d+time.Second-1)/time.Second), 10)
+}
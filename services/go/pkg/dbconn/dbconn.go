@@ -0,0 +1,36 @@
+package dbconn
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// connectAttempts is how often connecting is tried, waiting a second longer after every failure.
+const connectAttempts = 10
+
+// Connect connects a Prisma client, retrying while the database starts, and runs ping to open
+// the first connection of the pool. The service exits when the database stays unreachable.
+func Connect(connect func() error, ping func(ctx context.Context)) bool {
+	for attempt := 1; attempt <= connectAttempts; attempt++ {
+		if err := connect(); err != nil {
+			log.Printf("Database connect error (attempt %d/%d): %v", attempt, connectAttempts, err)
+			time.Sleep(time.Duration(attempt) * time.Second)
+			continue
+		}
+		ping(context.Background())
+		log.Println("Connected to Database")
+		return true
+	}
+	log.Fatal("Could not connect to Database after retries")
+	return false
+}
+
+// Disconnect closes the connections of a Prisma client.
+func Disconnect(disconnect func() error) {
+	if err := disconnect(); err != nil {
+		log.Printf("Database disconnect error: %v", err)
+		return
+	}
+	log.Println("Disconnected from Database")
+}
@@ -0,0 +1,48 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+)
+
+func TestIsPublic(t *testing.T) {
+	for address, want := range map[string]bool{
+		"8.8.8.8":             true,
+		"2606:4700::1111":     true,
+		"127.0.0.1":           false,
+		"10.1.2.3":            false,
+		"172.16.0.1":          false,
+		"192.168.1.1":         false,
+		"169.254.169.254":     false,
+		"100.64.0.1":          false,
+		"0.0.0.0":             false,
+		"::1":                 false,
+		"fe80::1":             false,
+		"fd00::1":             false,
+		"::ffff:127.0.0.1":    false,
+		"::ffff:93.184.216.1": true,
+	} {
+		if got := IsPublic(netip.MustParseAddr(address)); got != want {
+			t.Errorf("IsPublic(%s) = %v, want %v", address, got, want)
+		}
+	}
+}
+
+func TestNewPublicRefusesLoopback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	if _, err := NewPublic().R().Get(server.URL); !errors.Is(err, ErrNotPublic) {
+		t.Errorf("request to %s: err = %v, want ErrNotPublic", server.URL, err)
+	}
+	if err := CheckPublicURL(context.Background(), server.URL); !errors.Is(err, ErrNotPublic) {
+		t.Errorf("CheckPublicURL(%s) = %v, want ErrNotPublic", server.URL, err)
+	}
+	if err := CheckPublicURL(context.Background(), "file:///etc/passwd"); err == nil {
+		t.Error("a file URL was accepted")
+	}
+}
@@ -0,0 +1,110 @@
+// Package multicall batches contract view calls into one eth_call through Multicall3.
+package multicall
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Address of Multicall3, deployed at the same address on every chain.
+var Address = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+
+const multicall3ABI = `[{
+	"inputs": [{"components": [
+		{"name": "target", "type": "address"},
+		{"name": "allowFailure", "type": "bool"},
+		{"name": "callData", "type": "bytes"}
+	], "name": "calls", "type": "tuple[]"}],
+	"name": "aggregate3",
+	"outputs": [{"components": [
+		{"name": "success", "type": "bool"},
+		{"name": "returnData", "type": "bytes"}
+	], "name": "returnData", "type": "tuple[]"}],
+	"stateMutability": "payable",
+	"type": "function"
+}]`
+
+// BatchSize bounds the calls sent in one eth_call, keeping it under the gas cap of the node.
+const BatchSize = 200
+
+var contract = mustParseABI(multicall3ABI)
+
+func mustParseABI(definition string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+var ErrCallFailed = errors.New("multicall: call reverted")
+
+// Call is a view call of a contract.
+type Call struct {
+	Target   common.Address
+	CallData []byte
+}
+
+// Result is the outcome of a Call. A reverted call has Success unset, without failing the batch.
+type Result struct {
+	Success    bool
+	ReturnData []byte
+}
+
+type aggregateCall struct {
+	Target       common.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+// NewCall packs a call of method on target with the ABI of the contract.
+func NewCall(contract abi.ABI, target common.Address, method string, args ...any) (Call, error) {
+	data, err := contract.Pack(method, args...)
+	if err != nil {
+		return Call{}, err
+	}
+	return Call{Target: target, CallData: data}, nil
+}
+
+// Unpack decodes the return values of method, failing when the call reverted or returned nothing.
+func (r Result) Unpack(contract abi.ABI, method string) ([]any, error) {
+	if !r.Success || len(r.ReturnData) == 0 {
+		return nil, ErrCallFailed
+	}
+	return contract.Unpack(method, r.ReturnData)
+}
+
+// Aggregate runs calls through Multicall3 in batches of BatchSize and returns their results in
+// order. It only fails when a batch cannot be sent; a reverted call only fails its own result.
+func Aggregate(ctx context.Context, caller ethereum.ContractCaller, calls []Call) ([]Result, error) {
+	results := make([]Result, 0, len(calls))
+	for start := 0; start < len(calls); start += BatchSize {
+		batch := calls[start:min(start+BatchSize, len(calls))]
+		aggregate := make([]aggregateCall, 0, len(batch))
+		for _, call := range batch {
+			aggregate = append(aggregate, aggregateCall{Target: call.Target, AllowFailure: true, CallData: call.CallData})
+		}
+		input, err := contract.Pack("aggregate3", aggregate)
+		if err != nil {
+			return nil, err
+		}
+		output, err := caller.CallContract(ctx, ethereum.CallMsg{To: &Address, Data: input}, nil)
+		if err != nil {
+			return nil, err
+		}
+		var batchResults []Result
+		if err := contract.UnpackIntoInterface(&batchResults, "aggregate3", output); err != nil {
+			return nil, err
+		}
+		if len(batchResults) != len(batch) {
+			return nil, errors.New("multicall: result count does not match calls")
+		}
+		results = append(results, batchResults...)
+	}
+	return results, nil
+}
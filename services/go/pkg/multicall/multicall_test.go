@@ -0,0 +1,94 @@
+package multicall
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var erc20 = mustParseABI(`[{"inputs":[],"name":"decimals","outputs":[{"name":"","type":"uint8"}],"stateMutability":"view","type":"function"}]`)
+
+// fakeMulticall answers aggregate3 with the decimals of each target, reverting for unknown ones.
+type fakeMulticall struct {
+	decimals map[common.Address]uint8
+	requests int
+}
+
+func (f *fakeMulticall) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	f.requests++
+	args, err := contract.Methods["aggregate3"].Inputs.Unpack(msg.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+	var calls []aggregateCall
+	if err := contract.Methods["aggregate3"].Inputs.Copy(&calls, args); err != nil {
+		return nil, err
+	}
+	results := make([]Result, 0, len(calls))
+	for _, call := range calls {
+		decimals, ok := f.decimals[call.Target]
+		if !ok {
+			results = append(results, Result{})
+			continue
+		}
+		data, _ := erc20.Methods["decimals"].Outputs.Pack(decimals)
+		results = append(results, Result{Success: true, ReturnData: data})
+	}
+	return contract.Methods["aggregate3"].Outputs.Pack(results)
+}
+
+func (f *fakeMulticall) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return nil, nil
+}
+
+func TestAggregateBatchesAndKeepsOrder(t *testing.T) {
+	known := common.HexToAddress("0x1")
+	unknown := common.HexToAddress("0x2")
+	caller := &fakeMulticall{decimals: map[common.Address]uint8{known: 6}}
+
+	calls := []Call{}
+	for i := 0; i < BatchSize+1; i++ {
+		target := known
+		if i%2 == 1 {
+			target = unknown
+		}
+		call, err := NewCall(erc20, target, "decimals")
+		if err != nil {
+			t.Fatal(err)
+		}
+		calls = append(calls, call)
+	}
+
+	results, err := Aggregate(context.Background(), caller, calls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if caller.requests != 2 {
+		t.Fatalf("requests = %d, want 2", caller.requests)
+	}
+	if len(results) != len(calls) {
+		t.Fatalf("results = %d, want %d", len(results), len(calls))
+	}
+	out, err := results[BatchSize].Unpack(erc20, "decimals")
+	if err != nil || out[0].(uint8) != 6 {
+		t.Fatalf("last result = %v, %v, want 6", out, err)
+	}
+	if _, err := results[1].Unpack(erc20, "decimals"); err != ErrCallFailed {
+		t.Fatalf("reverted call error = %v, want %v", err, ErrCallFailed)
+	}
+}
+
+func TestMulticallABI(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(multicall3ABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := common.Bytes2Hex(parsed.Methods["aggregate3"].ID); got != "82ad56cb" {
+		t.Fatalf("aggregate3 selector = %s, want 82ad56cb", got)
+	}
+}
@@ -0,0 +1,88 @@
+// Package numeric holds the decimal arithmetic and the canonical string forms of the prices,
+// volumes and values the services store and return.
+//
+// Every number is fixed point, never in exponent form. Prices keep PriceSignificantDigits
+// significant digits however many leading zeros they have, without trailing zeros and with "0"
+// for zero. USD values (volumes, balances, valuations) have exactly USDDecimals decimals.
+package numeric
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// PriceSignificantDigits is how many significant digits a price keeps, more than a float64
+	// holds so that prices read back from storage multiply out the same.
+	PriceSignificantDigits = 24
+	// USDDecimals is how many decimals a USD value keeps.
+	USDDecimals = 2
+)
+
+// Parse reads a number in fixed point or exponent form, as the APIs send them. An empty or
+// blank string is zero.
+func Parse(v string) (decimal.Decimal, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return decimal.Zero, nil
+	}
+	return decimal.NewFromString(v)
+}
+
+// ParseOrZero reads a number like Parse, with zero for a string that is not one.
+func ParseOrZero(v string) decimal.Decimal {
+	d, err := Parse(v)
+	if err != nil {
+		return decimal.Zero
+	}
+	return d
+}
+
+// FromBigFloat converts a big.Float to a decimal without going through a float64, keeping
+// PriceSignificantDigits of it. Nil and infinities are zero.
+func FromBigFloat(f *big.Float) decimal.Decimal {
+	if f == nil || f.IsInf() {
+		return decimal.Zero
+	}
+	d, err := decimal.NewFromString(f.Text('e', PriceSignificantDigits-1))
+	if err != nil {
+		return decimal.Zero
+	}
+	return d
+}
+
+// RoundSignificant rounds a number to the given significant digits.
+func RoundSignificant(d decimal.Decimal, digits int) decimal.Decimal {
+	if d.IsZero() || digits <= 0 {
+		return d
+	}
+	// The integer digits of the number: the digits of its coefficient shifted by its exponent.
+	integerDigits := len(new(big.Int).Abs(d.Coefficient()).String()) + int(d.Exponent())
+	places := digits - integerDigits
+	if places >= int(-d.Exponent()) {
+		return d
+	}
+	return d.Round(int32(places))
+}
+
+// FormatPrice returns the canonical form of a price.
+func FormatPrice(d decimal.Decimal) string {
+	return RoundSignificant(d, PriceSignificantDigits).String()
+}
+
+// FormatUSD returns the canonical form of a USD value.
+func FormatUSD(d decimal.Decimal) string {
+	return d.StringFixed(USDDecimals)
+}
+
+// NormalizePrice returns the canonical form of a price string, or the string itself when it is
+// not a number. A blank string is "0".
+func NormalizePrice(v string) string {
+	d, err := Parse(v)
+	if err != nil {
+		return v
+	}
+	return FormatPrice(d)
+}
@@ -0,0 +1,65 @@
+package numeric
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestFormatPrice(t *testing.T) {
+	cases := map[string]string{
+		"":                                 "0",
+		"0":                                "0",
+		"0.000":                            "0",
+		"1.50":                             "1.5",
+		"3200":                             "3200",
+		"1.2e-13":                          "0.00000000000012",
+		"0.000000000000123456789012345678": "0.000000000000123456789012345678",
+		"0.0000000000001234567890123456789012345": "0.000000000000123456789012345678901235",
+		"123456789012345678901234567.89":          "123456789012345678901235000",
+		"-0.5":                                    "-0.5",
+	}
+	for in, want := range cases {
+		if got := NormalizePrice(in); got != want {
+			t.Errorf("NormalizePrice(%q) = %q, want %q", in, got, want)
+		}
+	}
+	if got := NormalizePrice("n/a"); got != "n/a" {
+		t.Errorf("NormalizePrice of a non-number = %q, want it unchanged", got)
+	}
+}
+
+func TestFormatUSD(t *testing.T) {
+	cases := map[string]string{
+		"0":        "0.00",
+		"12.5":     "12.50",
+		"0.004":    "0.00",
+		"0.005":    "0.01",
+		"1e3":      "1000.00",
+		"-41.2349": "-41.23",
+	}
+	for in, want := range cases {
+		if got := FormatUSD(decimal.RequireFromString(in)); got != want {
+			t.Errorf("FormatUSD(%s) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestFromBigFloatKeepsMicroCapPrices(t *testing.T) {
+	// 1 / 7e12 carries more significant digits than a float64 can.
+	price := new(big.Float).SetPrec(256).Quo(big.NewFloat(1), new(big.Float).SetPrec(256).SetInt64(7_000_000_000_000))
+	got := FormatPrice(FromBigFloat(price))
+	if want := "0.000000000000142857142857142857142857"; got != want {
+		t.Errorf("FormatPrice(1/7e12) = %q, want %q", got, want)
+	}
+	if got := FormatPrice(FromBigFloat(nil)); got != "0" {
+		t.Errorf("FormatPrice(nil) = %q, want 0", got)
+	}
+
+	// The product of a price and a pair price stays exact to the digits a price keeps.
+	pair := decimal.RequireFromString("3456.789")
+	if got := FormatPrice(FromBigFloat(price).Mul(pair)); got != "0.000000000493827" {
+		t.Errorf("price in USD = %q", got)
+	}
+}
@@ -0,0 +1,117 @@
+// Package telemetry exports OpenTelemetry traces over OTLP. Tracing is off unless
+// OTEL_EXPORTER_OTLP_ENDPOINT is set; the exporter reads the other standard OTEL_* variables.
+package telemetry
+
+import (
+	"context"
+	"log"
+	"runtime"
+	"samterminal/pkg/config"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+// tracer is named after the service once Init ran.
+var tracer = otel.Tracer("samterminal")
+
+// Init installs the OTLP trace exporter for a service. The returned function flushes pending
+// spans and is a no-op when tracing is off.
+func Init(serviceName string) func() {
+	tracer = otel.Tracer(serviceName)
+	if config.OTEL_EXPORTER_OTLP_ENDPOINT.GetEnv() == "" {
+		return func() {}
+	}
+	ctx := context.Background()
+	exporter, err := otlptracegrpc.New(ctx)
+	if err != nil {
+		log.Printf("Error creating OTLP exporter, tracing disabled: %+v", err)
+		return func() {}
+	}
+	// OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES override the defaults.
+	res, err := resource.Merge(
+		resource.NewSchemaless(attribute.String("service.name", serviceName)),
+		resource.Environment(),
+	)
+	if err != nil {
+		log.Printf("Error building trace resource: %+v", err)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	log.Printf("Tracing enabled, exporting to %s", config.OTEL_EXPORTER_OTLP_ENDPOINT.GetEnv())
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := provider.Shutdown(ctx); err != nil {
+			log.Printf("Error flushing traces: %+v", err)
+		}
+	}
+}
+
+// GRPCServerOption traces incoming calls and continues the trace of the caller.
+func GRPCServerOption() grpc.ServerOption {
+	return grpc.StatsHandler(otelgrpc.NewServerHandler())
+}
+
+// GRPCDialOption traces outgoing calls and passes the trace context to the callee.
+func GRPCDialOption() grpc.DialOption {
+	return grpc.WithStatsHandler(otelgrpc.NewClientHandler())
+}
+
+// StartSpan starts a span for work that is not triggered by an incoming call, such as a wallet
+// watcher event, so the calls it makes share one trace.
+func StartSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name)
+}
+
+// slowQueryThreshold is how long a repository function may take before it is logged; 0 logs none.
+var slowQueryThreshold = sync.OnceValue(func() time.Duration {
+	return config.DB_SLOW_QUERY_THRESHOLD.GetEnvAsDurationOrDefault(0)
+})
+
+// StartDBSpan returns the context of a repository function with a span named after it. The span
+// ends when the returned cancel func is called, which also logs the function if it was slow.
+func StartDBSpan(ctx context.Context) (context.Context, context.CancelFunc) {
+	name := callerName()
+	ctx, span := tracer.Start(ctx, "db "+name, trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("db.system", "postgresql")))
+	ctx, cancel := context.WithCancel(ctx)
+	start := time.Now()
+	return ctx, func() {
+		cancel()
+		span.End()
+		if threshold, took := slowQueryThreshold(), time.Since(start); threshold > 0 && took > threshold {
+			log.Printf("Slow database call %s took %s", name, took.Round(time.Millisecond))
+		}
+	}
+}
+
+// callerName is the function that called the caller of StartDBSpan, i.e. the repository function
+// calling its getCtx helper.
+func callerName() string {
+	pc, _, _, ok := runtime.Caller(3)
+	if !ok {
+		return "query"
+	}
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return "query"
+	}
+	name := fn.Name()
+	return name[strings.LastIndex(name, "/")+1:]
+}
@@ -0,0 +1,345 @@
+// Package usage counts the calls the services make to external APIs, by provider, endpoint and
+// calling function, and holds the soft daily budgets of the providers. Counts are kept per UTC
+// day; the totals of the providers are shared by the replicas of a service through a Store, the
+// paths are per process.
+package usage
+
+import (
+	"cmp"
+	"context"
+	"log"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"samterminal/pkg/config"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	Moralis     = "moralis"
+	Coingecko   = "coingecko"
+	Dexscreener = "dexscreener"
+)
+
+// syncPeriod is how often the calls of a replica are added to the Store and the totals read back.
+const syncPeriod = 10 * time.Second
+
+// Store keeps the daily call counts of the providers where all replicas of a service see them.
+type Store interface {
+	// Add adds calls to the count of provider on day.
+	Add(ctx context.Context, day string, provider string, calls int64) error
+	// Counts returns the count of every provider called on day.
+	Counts(ctx context.Context, day string) (map[string]int64, error)
+}
+
+type callKey struct {
+	provider string
+	endpoint string
+	caller   string
+}
+
+var (
+	mu    sync.Mutex
+	day   string
+	calls = map[callKey]int64{}
+	now   = time.Now
+
+	store Store
+	// pending is the calls of this replica not added to the store yet, shared the counts of the
+	// store when it was last read.
+	pending  = map[string]int64{}
+	shared   = map[string]int64{}
+	exceeded = map[string]bool{}
+)
+
+// Path is the calls made to one endpoint of a provider from one function.
+type Path struct {
+	// Endpoint is the client function that made the request, e.g. apis.GetTopTokenHolders.
+	Endpoint string
+	// Caller is the function that called into the client package.
+	Caller string
+	Calls  int64
+}
+
+// ProviderUsage is the calls made to a provider today, by all replicas when they share a Store.
+// Budget is 0 when the provider has none.
+type ProviderUsage struct {
+	Provider   string
+	Calls      int64
+	Budget     int64
+	OverBudget bool
+	// Paths are the calls of this replica, ordered by calls, most first.
+	Paths []Path
+}
+
+// Report is the usage of every provider called today, and of those with a budget.
+type Report struct {
+	Day       string
+	Providers []ProviderUsage
+}
+
+var invalidBudgetsLogged sync.Map
+
+// budgets reads API_DAILY_BUDGETS on every use, so new budgets loaded on SIGHUP apply at once.
+func budgets() map[string]int64 {
+	parsed := map[string]int64{}
+	for _, entry := range strings.Split(config.API_DAILY_BUDGETS.GetEnv(), ",") {
+		provider, value, ok := strings.Cut(entry, "=")
+		provider = strings.ToLower(strings.TrimSpace(provider))
+		if !ok || provider == "" {
+			continue
+		}
+		budget, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil || budget <= 0 {
+			if _, logged := invalidBudgetsLogged.LoadOrStore(entry, true); !logged {
+				log.Printf("Ignoring invalid API budget %q", entry)
+			}
+			continue
+		}
+		parsed[provider] = budget
+	}
+	return parsed
+}
+
+// rolloverLocked drops the counts of a previous day. mu must be held.
+func rolloverLocked() {
+	today := now().UTC().Format(time.DateOnly)
+	if today != day {
+		day = today
+		calls = map[callKey]int64{}
+		pending, shared, exceeded = map[string]int64{}, map[string]int64{}, map[string]bool{}
+	}
+}
+
+// providerCallsLocked is the calls made to provider today: by all replicas, as far as they were
+// synced, with a store, and by this one without.
+func providerCallsLocked(provider string) int64 {
+	if store != nil {
+		return shared[provider] + pending[provider]
+	}
+	total := int64(0)
+	for key, count := range calls {
+		if key.provider == provider {
+			total += count
+		}
+	}
+	return total
+}
+
+// Record counts a call to provider made from endpoint on behalf of caller.
+func Record(provider string, endpoint string, caller string) {
+	mu.Lock()
+	defer mu.Unlock()
+	rolloverLocked()
+	calls[callKey{provider: provider, endpoint: endpoint, caller: caller}]++
+	pending[provider]++
+	if budget, ok := budgets()[provider]; ok && !exceeded[provider] && providerCallsLocked(provider) > budget {
+		exceeded[provider] = true
+		log.Printf("API budget of %s exceeded (%d calls today), degrading to cheaper providers", provider, budget)
+	}
+}
+
+// StartSync shares the counts of the providers with the other replicas through s: the calls of
+// this replica are added to it every syncPeriod, and budgets apply to the total of all replicas.
+func StartSync(s Store) {
+	mu.Lock()
+	store = s
+	mu.Unlock()
+	go func() {
+		ticker := time.NewTicker(syncPeriod)
+		defer ticker.Stop()
+		for {
+			ctx, cancel := context.WithTimeout(context.Background(), syncPeriod)
+			if err := Sync(ctx); err != nil {
+				log.Printf("Error syncing API usage: %v", err)
+			}
+			cancel()
+			<-ticker.C
+		}
+	}()
+}
+
+// Sync adds the calls made since the last sync to the store and reads back the counts of all
+// replicas. Calls that could not be added are kept for the next sync.
+func Sync(ctx context.Context) error {
+	mu.Lock()
+	rolloverLocked()
+	s, syncDay, unsynced := store, day, pending
+	pending = map[string]int64{}
+	mu.Unlock()
+	if s == nil {
+		return nil
+	}
+
+	var err error
+	for provider, count := range unsynced {
+		if err = s.Add(ctx, syncDay, provider, count); err != nil {
+			break
+		}
+		delete(unsynced, provider)
+	}
+	var counts map[string]int64
+	if err == nil {
+		counts, err = s.Counts(ctx, syncDay)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if day != syncDay {
+		return err
+	}
+	for provider, count := range unsynced {
+		pending[provider] += count
+	}
+	if err != nil {
+		return err
+	}
+	shared = counts
+	return nil
+}
+
+// OverBudget reports whether provider was called more often today than its budget allows.
+// Callers with a cheaper provider switch to it; the others keep calling, as the budget is soft.
+func OverBudget(provider string) bool {
+	budget, ok := budgets()[provider]
+	if !ok {
+		return false
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	rolloverLocked()
+	return providerCallsLocked(provider) > budget
+}
+
+// Current returns the usage of today.
+func Current() Report {
+	mu.Lock()
+	defer mu.Unlock()
+	rolloverLocked()
+	byProvider := map[string]*ProviderUsage{}
+	for provider, budget := range budgets() {
+		byProvider[provider] = &ProviderUsage{Provider: provider, Budget: budget}
+	}
+	for key, count := range calls {
+		usage, ok := byProvider[key.provider]
+		if !ok {
+			usage = &ProviderUsage{Provider: key.provider}
+			byProvider[key.provider] = usage
+		}
+		if store == nil {
+			usage.Calls += count
+		}
+		usage.Paths = append(usage.Paths, Path{Endpoint: key.endpoint, Caller: key.caller, Calls: count})
+	}
+
+	if store != nil {
+		for _, counts := range []map[string]int64{shared, pending} {
+			for provider := range counts {
+				usage, ok := byProvider[provider]
+				if !ok {
+					usage = &ProviderUsage{Provider: provider}
+					byProvider[provider] = usage
+				}
+				usage.Calls = providerCallsLocked(provider)
+			}
+		}
+	}
+
+	report := Report{Day: day, Providers: []ProviderUsage{}}
+	for _, usage := range byProvider {
+		usage.OverBudget = usage.Budget > 0 && usage.Calls > usage.Budget
+		slices.SortFunc(usage.Paths, func(a, b Path) int {
+			if a.Calls != b.Calls {
+				return cmp.Compare(b.Calls, a.Calls)
+			}
+			return strings.Compare(a.Endpoint+a.Caller, b.Endpoint+b.Caller)
+		})
+		report.Providers = append(report.Providers, *usage)
+	}
+	slices.SortFunc(report.Providers, func(a, b ProviderUsage) int {
+		return strings.Compare(a.Provider, b.Provider)
+	})
+	return report
+}
+
+// Track counts every request made with client against provider, including retries, under the
+// client function that sent it and the function that called into the client package.
+func Track(client *resty.Client, provider string) *resty.Client {
+	return client.OnBeforeRequest(func(c *resty.Client, req *resty.Request) error {
+		endpoint, caller := callPath()
+		Record(provider, endpoint, caller)
+		return nil
+	})
+}
+
+// libraryPrefixes are the packages between a resty request and the client package sending it.
+var libraryPrefixes = []string{"github.com/", "go.opentelemetry.io/", "golang.org/", "net/", "runtime.", "sync.", "samterminal/pkg/"}
+
+func isLibrary(function string) bool {
+	return slices.ContainsFunc(libraryPrefixes, func(prefix string) bool { return strings.HasPrefix(function, prefix) })
+}
+
+// callPath returns the client function the current request was sent through and its caller.
+func callPath() (endpoint string, caller string) {
+	pcs := make([]uintptr, 128)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	functions := []string{}
+	for {
+		frame, more := frames.Next()
+		functions = append(functions, frame.Function)
+		if !more {
+			break
+		}
+	}
+	return pathOf(functions)
+}
+
+// pathOf finds the endpoint and caller in the functions of a stack, innermost first. The client
+// package is the first one outside the libraries. Calls through its helpers and caches count
+// under the outermost client function and the function that called it, the code path spending
+// the quota.
+func pathOf(functions []string) (endpoint string, caller string) {
+	clientPackage := ""
+	inClient := false
+	for _, name := range functions {
+		switch {
+		case name == "" || isLibrary(name):
+		case clientPackage == "" || packageOf(name) == clientPackage:
+			clientPackage, endpoint, inClient = packageOf(name), shortName(name), true
+		case inClient:
+			caller, inClient = shortName(name), false
+		}
+	}
+	if endpoint == "" {
+		endpoint = "unknown"
+	}
+	if caller == "" {
+		caller = "unknown"
+	}
+	return endpoint, caller
+}
+
+// packageOf returns the import path of the package of a function name as runtime reports it.
+func packageOf(function string) string {
+	slash := strings.LastIndex(function, "/")
+	dot := strings.Index(function[slash+1:], ".")
+	if dot < 0 {
+		return function
+	}
+	return function[:slash+1+dot]
+}
+
+// shortName drops the import path of a function name, e.g. apis.GetTopTokenHolders.
+func shortName(function string) string {
+	name := function[strings.LastIndex(function, "/")+1:]
+	// Closures are counted under the function they are declared in.
+	if i := strings.Index(name, ".func"); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
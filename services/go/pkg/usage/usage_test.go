@@ -0,0 +1,129 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPathOf(t *testing.T) {
+	endpoint, caller := pathOf([]string{
+		"samterminal/pkg/usage.Track.func1",
+		"github.com/go-resty/resty/v2.(*Request).Execute",
+		"tokendata/lib/dex.coingeckoGet.func1",
+		"tokendata/lib/cache.(*Cache[...]).Get",
+		"tokendata/lib/dex.coingeckoGet",
+		"tokendata/lib/dex.GetTokenDataAsString",
+		"tokendata/database/repositories/token.getTokenDataAsStringWithFallback",
+		"tokendata/database/repositories/token.SaveTokenPrice",
+		"runtime.goexit",
+	})
+	if endpoint != "dex.GetTokenDataAsString" || caller != "token.getTokenDataAsStringWithFallback" {
+		t.Errorf("path = %s from %s, want the outermost client function and its caller", endpoint, caller)
+	}
+
+	endpoint, caller = pathOf([]string{"net/http.(*Client).Do", "runtime.goexit"})
+	if endpoint != "unknown" || caller != "unknown" {
+		t.Errorf("path = %s from %s without a client package", endpoint, caller)
+	}
+}
+
+func TestBudgets(t *testing.T) {
+	t.Setenv("API_DAILY_BUDGETS", "moralis=2, Coingecko = 5,dexscreener=none")
+	current := time.Date(2026, 3, 1, 23, 0, 0, 0, time.UTC)
+	now = func() time.Time { return current }
+	defer func() { now = time.Now }()
+
+	for i := 0; i < 3; i++ {
+		Record(Moralis, "apis.GetTopTokenHolders", "token.CheckTokensRisk")
+	}
+	Record(Moralis, "apis.GetTokenImageURL", "token.AddToken")
+	Record(Dexscreener, "apis.GetDexscreenerTokenDataAsString", "token.SaveTokenPrice")
+	if !OverBudget(Moralis) || OverBudget(Coingecko) || OverBudget(Dexscreener) {
+		t.Error("only moralis should be over its budget")
+	}
+
+	report := Current()
+	if report.Day != "2026-03-01" || len(report.Providers) != 3 {
+		t.Fatalf("report = %+v, want the three providers of the day", report)
+	}
+	coingecko, dexscreener, moralis := report.Providers[0], report.Providers[1], report.Providers[2]
+	if coingecko.Provider != Coingecko || coingecko.Budget != 5 || coingecko.Calls != 0 {
+		t.Errorf("coingecko = %+v, want its budget without calls", coingecko)
+	}
+	if dexscreener.Budget != 0 || dexscreener.Calls != 1 {
+		t.Errorf("dexscreener = %+v, want a call without a budget", dexscreener)
+	}
+	if moralis.Calls != 4 || !moralis.OverBudget || len(moralis.Paths) != 2 || moralis.Paths[0].Calls != 3 {
+		t.Errorf("moralis = %+v, want 4 calls over budget, the busiest path first", moralis)
+	}
+
+	current = current.Add(2 * time.Hour)
+	if report := Current(); OverBudget(Moralis) || len(report.Providers) != 2 || report.Providers[1].Calls != 0 {
+		t.Errorf("report = %+v, want the counts of the previous day dropped", report)
+	}
+}
+
+type memoryStore struct {
+	counts map[string]int64
+	fail   bool
+}
+
+func (s *memoryStore) Add(ctx context.Context, day string, provider string, calls int64) error {
+	if s.fail {
+		return errors.New("store unavailable")
+	}
+	s.counts[day+"/"+provider] += calls
+	return nil
+}
+
+func (s *memoryStore) Counts(ctx context.Context, day string) (map[string]int64, error) {
+	counts := map[string]int64{}
+	for key, count := range s.counts {
+		if provider, ok := strings.CutPrefix(key, day+"/"); ok {
+			counts[provider] = count
+		}
+	}
+	return counts, nil
+}
+
+func TestSharedBudgets(t *testing.T) {
+	t.Setenv("API_DAILY_BUDGETS", "moralis=5")
+	current := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return current }
+	// Another replica made 4 calls today.
+	shared := &memoryStore{counts: map[string]int64{"2026-03-02/moralis": 4}}
+	store = shared
+	defer func() { now, store = time.Now, nil }()
+
+	Record(Moralis, "apis.GetWalletTokens", "api.GetTokenStatus")
+	if OverBudget(Moralis) {
+		t.Error("over budget before the calls of the other replica were read")
+	}
+	if err := Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	Record(Moralis, "apis.GetWalletTokens", "api.GetTokenStatus")
+	if !OverBudget(Moralis) {
+		t.Error("not over budget with 6 calls across replicas")
+	}
+
+	// Calls that could not be added are added with the next sync.
+	shared.fail = true
+	if err := Sync(context.Background()); err == nil {
+		t.Fatal("sync did not fail")
+	}
+	shared.fail = false
+	if err := Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := shared.counts["2026-03-02/moralis"]; got != 6 {
+		t.Errorf("store counts %d calls, want 6", got)
+	}
+	report := Current()
+	if len(report.Providers) != 1 || report.Providers[0].Calls != 6 || len(report.Providers[0].Paths) != 1 || report.Providers[0].Paths[0].Calls != 2 {
+		t.Errorf("report = %+v, want the 6 calls of all replicas and the path of this one", report)
+	}
+}
@@ -4,73 +4,68 @@ import (
 	"context"
 	"log"
 	"strings"
-	"sync"
 	"time"
 	db_dto "tokendata/database/dto"
+	"tokendata/database/repositories/discovery"
 	tokenRepository "tokendata/database/repositories/token"
 	db "tokendata/generated/prisma"
+	"tokendata/lib/anchors"
+	"tokendata/lib/degrade"
 	"tokendata/lib/ws/factory"
 )
 
-// StartBankrListener subscribes to Bankr factory Create events via WebSocket,
-// buffers new tokens for batchInterval, then batch-processes them
-// (DexScreener metadata + DB insert + pool watching).
-func StartBankrListener(batchInterval time.Duration) {
-	log.Printf("Starting Bankr factory listener with %s batch interval", batchInterval)
+// StartBankrListener subscribes to Bankr factory Create events via WebSocket and
+// persists new tokens to the discovery queue. Processing (DexScreener metadata +
+// DB insert + pool watching) is done in batches by the discovery consumer.
+func StartBankrListener() {
+	log.Printf("Starting Bankr factory listener")
 
-	dedup := newTokenDedup(10 * time.Minute)
-	eventCh := make(chan factory.BankrCreateEvent, 100)
+	dedup := newTokenDedup(discoverySourceBankr, discoveryDedupTTL())
+	eventCh := make(chan factory.BankrCreateEvent)
 
 	ctx := context.Background()
 	factory.SubscribeBankrFactory(ctx, eventCh)
 
-	var mu sync.Mutex
-	var pending []factory.BankrCreateEvent
+	cleanupTicker := time.NewTicker(dedupCleanupInterval)
+	defer cleanupTicker.Stop()
 
-	// Collect events from WSS
-	go func() {
-		for ev := range eventCh {
+	for {
+		select {
+		case ev := <-eventCh:
+			if degrade.DiscoveryDisabled() {
+				continue
+			}
 			if dedup.has(ev.TokenAddress) {
 				continue
 			}
-			existing, _ := tokenRepository.GetToken(db_dto.TokenAddress(ev.TokenAddress))
+			existing, _ := tokenRepository.GetToken(context.Background(), db_dto.TokenAddress(ev.TokenAddress))
 			if existing != nil {
 				dedup.add(ev.TokenAddress)
 				continue
 			}
-			mu.Lock()
-			pending = append(pending, ev)
-			mu.Unlock()
-		}
-	}()
-
-	batchTicker := time.NewTicker(batchInterval)
-	defer batchTicker.Stop()
-
-	cleanupTicker := time.NewTicker(10 * time.Minute)
-	defer cleanupTicker.Stop()
-
-	for {
-		select {
-		case <-batchTicker.C:
-			mu.Lock()
-			batch := pending
-			pending = nil
-			mu.Unlock()
-			if len(batch) > 0 {
-				processBankrBatch(ctx, batch, dedup)
-			}
+			enqueueDiscoveryEvent(discovery.Event{
+				Source:       discoverySourceBankr,
+				TokenAddress: ev.TokenAddress,
+				PairAddress:  ev.PairAddress,
+				PoolAddress:  ev.PoolAddress,
+				DeployedAt:   ev.DeployedAt,
+			})
+			dedup.add(ev.TokenAddress)
 		case <-cleanupTicker.C:
 			dedup.cleanup()
 		}
 	}
 }
 
-func processBankrBatch(ctx context.Context, events []factory.BankrCreateEvent, dedup *tokenDedup) {
+// processBankrBatch creates the tokens of a batch of queued Bankr events and returns the
+// addresses that could not be created.
+func processBankrBatch(ctx context.Context, events []db.DiscoveryEventModel, config discoveryConfig) []string {
 	// Deduplicate within batch
 	type pendingToken struct {
-		addr string
-		pair string
+		addr  string
+		pair  string
+		pool  string
+		event db.DiscoveryEventModel
 	}
 	seen := make(map[string]bool)
 	var tokens []pendingToken
@@ -79,7 +74,9 @@ func processBankrBatch(ctx context.Context, events []factory.BankrCreateEvent, d
 			continue
 		}
 		seen[ev.TokenAddress] = true
-		tokens = append(tokens, pendingToken{addr: ev.TokenAddress, pair: ev.PairAddress})
+		pair, _ := ev.PairAddress()
+		pool, _ := ev.PoolAddress()
+		tokens = append(tokens, pendingToken{addr: ev.TokenAddress, pair: pair, pool: pool, event: ev})
 	}
 
 	// Parallel RPC: batch read name+symbol for all tokens concurrently
@@ -90,11 +87,12 @@ func processBankrBatch(ctx context.Context, events []factory.BankrCreateEvent, d
 	metaMap := factory.BatchReadERC20Meta(ctx, addresses)
 
 	// Batch DexScreener fetch (chunked)
-	dexData := batchFetchDexScreener(addresses)
+	dexData := batchFetchDexScreener(addresses, config.DexscreenerChunkSize)
 
 	// Deduplicate SaveTokenPrice calls per pair
 	pairsSaved := make(map[string]bool)
 
+	var failed []string
 	newCount := 0
 	for _, t := range tokens {
 		meta := metaMap[t.addr]
@@ -113,7 +111,7 @@ func processBankrBatch(ctx context.Context, events []factory.BankrCreateEvent, d
 		supply := "0"
 		circulatedSupply := "0"
 		imgURL := ""
-		poolAddress := ""
+		poolAddress := t.pool
 		pairAddress := t.pair
 		poolType := db.DexPoolTypeUniswapV4
 
@@ -134,17 +132,21 @@ func processBankrBatch(ctx context.Context, events []factory.BankrCreateEvent, d
 				if ds.TokenData.Symbol != "" {
 					symbol = ds.TokenData.Symbol
 				}
-				if ds.Pool.Address != "" {
-					poolAddress = ds.Pool.Address
-				}
-				if ds.Pool.PairAddress != "" {
-					pairAddress = ds.Pool.PairAddress
+				// The pool created with the token is taken over the one Dexscreener ranks first, and
+				// its pair with it.
+				if t.pool == "" {
+					if ds.Pool.Address != "" {
+						poolAddress = ds.Pool.Address
+					}
+					if ds.Pool.PairAddress != "" {
+						pairAddress = ds.Pool.PairAddress
+					}
 				}
 			}
 		}
 
 		if pairAddress == "" {
-			pairAddress = "0x4200000000000000000000000000000000000006"
+			pairAddress = anchors.Native().Address
 		}
 
 		token := tokenRepository.GetOrCreateToken(
@@ -155,10 +157,23 @@ func processBankrBatch(ctx context.Context, events []factory.BankrCreateEvent, d
 		)
 		if token == nil {
 			log.Printf("Bankr: failed to create token %s (%s)", symbol, t.addr)
-			dedup.add(t.addr)
+			failed = append(failed, t.addr)
 			continue
 		}
 
+		recordDeployment(token, t.event)
+		if _, checked := token.DeployerCheckedAt(); !checked {
+			tokenRepository.QueueDeployerAnalysis(db_dto.TokenAddress(t.addr))
+		}
+		if _, checked := token.ContractCheckedAt(); !checked {
+			tokenRepository.QueueContractInspection(db_dto.TokenAddress(t.addr))
+		}
+		if ds, ok := dexData[t.addr]; ok {
+			if err := tokenRepository.SaveTokenProfile(db_dto.TokenAddress(t.addr), ds.Profile); err != nil {
+				log.Printf("Bankr: failed to save profile for %s: %v", t.addr, err)
+			}
+		}
+
 		if pairAddress != "" && !pairsSaved[pairAddress] {
 			pairsSaved[pairAddress] = true
 			go tokenRepository.SaveTokenPrice(db_dto.TokenAddress(strings.ToLower(pairAddress)))
@@ -171,7 +186,6 @@ func processBankrBatch(ctx context.Context, events []factory.BankrCreateEvent, d
 			}
 		}
 
-		dedup.add(t.addr)
 		newCount++
 		log.Printf("Bankr: new token %s (%s) price=%s pair=%s", symbol, t.addr, price, pairAddress)
 	}
@@ -179,4 +193,5 @@ func processBankrBatch(ctx context.Context, events []factory.BankrCreateEvent, d
 	if newCount > 0 {
 		log.Printf("Bankr batch: added %d new tokens", newCount)
 	}
+	return failed
 }
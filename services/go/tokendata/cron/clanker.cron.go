@@ -1,103 +1,167 @@
 package cron
 
 import (
+	"context"
 	"log"
 	"strings"
 	"time"
 	db_dto "tokendata/database/dto"
+	"tokendata/database/repositories/discovery"
 	tokenRepository "tokendata/database/repositories/token"
+	"tokendata/env"
 	db "tokendata/generated/prisma"
+	"tokendata/lib/anchors"
 	"tokendata/lib/apis"
+	"tokendata/lib/degrade"
 )
 
-func StartClankerPoller(interval time.Duration) {
-	log.Printf("Starting Clanker poller with %s interval", interval)
+const (
+	defaultClankerPollLimit       = 20
+	maxClankerPollLimit           = 100
+	defaultClankerCatchUpMaxAge   = 24 * time.Hour
+	defaultClankerCatchUpMaxPages = 50
+)
+
+func StartClankerPoller() {
+	interval := loadDiscoveryConfig(discoverySourceClanker).Interval
+	limit := int(env.CLANKER_POLL_LIMIT.GetEnvAsNumberOrDefault(defaultClankerPollLimit))
+	if limit < 1 || limit > maxClankerPollLimit {
+		log.Printf("Clanker poll limit %d out of range [1, %d], using %d", limit, maxClankerPollLimit, defaultClankerPollLimit)
+		limit = defaultClankerPollLimit
+	}
+	log.Printf("Starting Clanker poller with %s interval and %d tokens per poll", interval, limit)
 
-	dedup := newTokenDedup(10 * time.Minute)
-	cleanupTicker := time.NewTicker(10 * time.Minute)
+	dedup := newTokenDedup(discoverySourceClanker, discoveryDedupTTL())
+	cleanupTicker := time.NewTicker(dedupCleanupInterval)
 	defer cleanupTicker.Stop()
 
 	pollTicker := time.NewTicker(interval)
 	defer pollTicker.Stop()
 
-	pollClanker(dedup)
+	catchUpClanker(dedup, limit)
 
 	for {
 		select {
 		case <-pollTicker.C:
-			pollClanker(dedup)
+			pollClanker(dedup, limit)
 		case <-cleanupTicker.C:
 			dedup.cleanup()
 		}
 	}
 }
 
-func pollClanker(dedup *tokenDedup) {
-	tokens, err := apis.GetLatestClankerTokens(20)
+func pollClanker(dedup *tokenDedup, limit int) {
+	if degrade.DiscoveryDisabled() {
+		return
+	}
+	tokens, err := apis.GetLatestClankerTokens(limit)
 	if err != nil {
 		log.Printf("Clanker poll error: %v", err)
 		return
 	}
+	for _, t := range tokens {
+		queueClankerToken(dedup, t)
+	}
+}
 
-	// Filter new tokens (not in dedup cache, not in DB)
-	type newToken struct {
-		addr  string
-		token apis.ClankerToken
+// catchUpClanker queues the tokens launched while the service was down, walking back from the
+// latest page until a known token, a token past CLANKER_CATCHUP_MAX_AGE or the page limit.
+func catchUpClanker(dedup *tokenDedup, limit int) {
+	if degrade.DiscoveryDisabled() {
+		return
 	}
-	var newTokens []newToken
+	maxPages := int(max(env.CLANKER_CATCHUP_MAX_PAGES.GetEnvAsNumberOrDefault(defaultClankerCatchUpMaxPages), 1))
+	cutoff := time.Now().Add(-env.CLANKER_CATCHUP_MAX_AGE.GetEnvAsDurationOrDefault(defaultClankerCatchUpMaxAge))
 
-	for _, t := range tokens {
-		addr := strings.ToLower(strings.TrimSpace(t.ContractAddress))
-		if addr == "" {
-			continue
+	queued := 0
+	pages, err := apis.WalkClankerTokens(limit, cutoff, maxPages, func(t apis.ClankerToken) bool {
+		if queueClankerToken(dedup, t) {
+			return false
 		}
-		if dedup.has(addr) {
-			continue
+		if strings.TrimSpace(t.ContractAddress) != "" {
+			queued++
 		}
-		existing, _ := tokenRepository.GetToken(db_dto.TokenAddress(addr))
-		if existing != nil {
-			dedup.add(addr)
-			continue
-		}
-		newTokens = append(newTokens, newToken{addr: addr, token: t})
+		return true
+	})
+	if err != nil {
+		log.Printf("Clanker catch-up error on page %d: %v", pages, err)
 	}
+	log.Printf("Clanker catch-up: queued %d tokens from %d pages", queued, pages)
+}
 
-	if len(newTokens) == 0 {
-		return
+// queueClankerToken queues a Clanker token for creation, reporting whether it was already known.
+func queueClankerToken(dedup *tokenDedup, t apis.ClankerToken) bool {
+	addr := strings.ToLower(strings.TrimSpace(t.ContractAddress))
+	if addr == "" {
+		return false
+	}
+	if dedup.has(addr) {
+		return true
+	}
+	existing, _ := tokenRepository.GetToken(context.Background(), db_dto.TokenAddress(addr))
+	if existing != nil {
+		dedup.add(addr)
+		return true
+	}
+
+	poolType := db.DexPoolTypeUniswapV3
+	if strings.Contains(t.Type, "v4") {
+		poolType = db.DexPoolTypeUniswapV4
+	}
+	pairAddress := ""
+	if anchor, ok := anchors.BySymbol(t.Pair); ok {
+		pairAddress = anchor.Address
 	}
+	enqueueDiscoveryEvent(discovery.Event{
+		Source:       discoverySourceClanker,
+		TokenAddress: addr,
+		PairAddress:  pairAddress,
+		PoolAddress:  t.PoolAddress,
+		PoolType:     &poolType,
+		Name:         t.Name,
+		Symbol:       t.Symbol,
+		ImageURL:     t.ImageURL,
+		DeployedAt:   t.DeployedTime(),
+	})
+	dedup.add(addr)
+	return false
+}
 
+// processClankerBatch creates the tokens of a batch of queued Clanker events and returns the
+// addresses that could not be created.
+func processClankerBatch(events []db.DiscoveryEventModel, config discoveryConfig) []string {
 	// Batch fetch from DexScreener for price/volume/pool data (chunked)
-	addresses := make([]string, len(newTokens))
-	for i, nt := range newTokens {
-		addresses[i] = nt.addr
+	addresses := make([]string, len(events))
+	for i, ev := range events {
+		addresses[i] = ev.TokenAddress
 	}
-	dexData := batchFetchDexScreener(addresses)
+	dexData := batchFetchDexScreener(addresses, config.DexscreenerChunkSize)
 
 	// Collect unique pair addresses for a single SaveTokenPrice call per pair
 	pairsSaved := make(map[string]bool)
 
+	var failed []string
 	newCount := 0
-	for _, nt := range newTokens {
+	for _, ev := range events {
 		reason := "clanker"
-		name := nt.token.Name
-		symbol := nt.token.Symbol
-		imgURL := nt.token.ImageURL
-		poolAddress := nt.token.PoolAddress
-		pair := nt.token.Pair
+		name, _ := ev.Name()
+		symbol, _ := ev.Symbol()
+		imgURL, _ := ev.ImageURL()
+		poolAddress, _ := ev.PoolAddress()
+		queuedPairAddress, _ := ev.PairAddress()
 
 		price := "0"
 		volume := "0"
 		supply := "0"
 		circulatedSupply := "0"
-		poolType := db.DexPoolTypeUniswapV3
-
-		if strings.Contains(nt.token.Type, "v4") {
-			poolType = db.DexPoolTypeUniswapV4
+		poolType, ok := ev.PoolType()
+		if !ok {
+			poolType = db.DexPoolTypeUniswapV3
 		}
 
 		pairAddress := ""
 		if dexData != nil {
-			if ds, ok := dexData[nt.addr]; ok {
+			if ds, ok := dexData[ev.TokenAddress]; ok {
 				if ds.TokenData.Price != "" && ds.TokenData.Price != "0" {
 					price = ds.TokenData.Price
 				}
@@ -116,22 +180,35 @@ func pollClanker(dedup *tokenDedup) {
 			}
 		}
 
-		if pairAddress == "" && strings.EqualFold(pair, "WETH") {
-			pairAddress = "0x4200000000000000000000000000000000000006"
+		if pairAddress == "" {
+			pairAddress = queuedPairAddress
 		}
 
 		token := tokenRepository.GetOrCreateToken(
-			db_dto.TokenAddress(nt.addr),
+			db_dto.TokenAddress(ev.TokenAddress),
 			&name, &supply, &circulatedSupply, &symbol, &imgURL,
 			&price, &volume, &poolType, &poolAddress, &pairAddress,
 			&reason, &price, false,
 		)
 		if token == nil {
-			log.Printf("Clanker: failed to create token %s (%s)", symbol, nt.addr)
-			dedup.add(nt.addr)
+			log.Printf("Clanker: failed to create token %s (%s)", symbol, ev.TokenAddress)
+			failed = append(failed, ev.TokenAddress)
 			continue
 		}
 
+		recordDeployment(token, ev)
+		if _, checked := token.DeployerCheckedAt(); !checked {
+			tokenRepository.QueueDeployerAnalysis(db_dto.TokenAddress(ev.TokenAddress))
+		}
+		if _, checked := token.ContractCheckedAt(); !checked {
+			tokenRepository.QueueContractInspection(db_dto.TokenAddress(ev.TokenAddress))
+		}
+		if ds, ok := dexData[ev.TokenAddress]; ok {
+			if err := tokenRepository.SaveTokenProfile(db_dto.TokenAddress(ev.TokenAddress), ds.Profile); err != nil {
+				log.Printf("Clanker: failed to save profile for %s: %v", ev.TokenAddress, err)
+			}
+		}
+
 		// Save pair price once per unique pair address
 		if pairAddress != "" && !pairsSaved[pairAddress] {
 			pairsSaved[pairAddress] = true
@@ -143,12 +220,12 @@ func pollClanker(dedup *tokenDedup) {
 			log.Printf("Clanker: failed to watch pool for %s: %v", symbol, err)
 		}
 
-		dedup.add(nt.addr)
 		newCount++
-		log.Printf("Clanker: new token %s (%s) price=%s at %s", symbol, nt.addr, price, nt.token.DeployedAt)
+		log.Printf("Clanker: new token %s (%s) price=%s", symbol, ev.TokenAddress, price)
 	}
 
 	if newCount > 0 {
-		log.Printf("Clanker poll: added %d new tokens", newCount)
+		log.Printf("Clanker batch: added %d new tokens", newCount)
 	}
+	return failed
 }
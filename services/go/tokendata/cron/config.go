@@ -0,0 +1,76 @@
+package cron
+
+import (
+	"log"
+	"time"
+	"tokendata/env"
+	"tokendata/lib/jobs"
+)
+
+const (
+	defaultDiscoveryInterval  = 5 * time.Second
+	defaultDiscoveryBatchSize = 50
+	// Dexscreener accepts at most 30 addresses per /tokens request.
+	defaultDexscreenerChunkSize = 20
+	maxDexscreenerChunkSize     = 30
+)
+
+// discoveryConfig controls how fast a discovery source is polled and processed.
+type discoveryConfig struct {
+	Interval             time.Duration
+	BatchSize            int
+	DexscreenerChunkSize int
+}
+
+// loadDiscoveryConfig reads the global discovery settings and applies the per-source overrides.
+// Out of range values are logged and replaced by the defaults.
+func loadDiscoveryConfig(source string) discoveryConfig {
+	config := discoveryConfig{
+		Interval:             env.DISCOVERY_INTERVAL.GetEnvAsDurationOrDefault(defaultDiscoveryInterval),
+		BatchSize:            int(env.DISCOVERY_BATCH_SIZE.GetEnvAsNumberOrDefault(defaultDiscoveryBatchSize)),
+		DexscreenerChunkSize: int(env.DEXSCREENER_CHUNK_SIZE.GetEnvAsNumberOrDefault(defaultDexscreenerChunkSize)),
+	}
+	config.Interval = env.DISCOVERY_INTERVAL.ForSource(source).GetEnvAsDurationOrDefault(config.Interval)
+	config.BatchSize = int(env.DISCOVERY_BATCH_SIZE.ForSource(source).GetEnvAsNumberOrDefault(int64(config.BatchSize)))
+	config.DexscreenerChunkSize = int(env.DEXSCREENER_CHUNK_SIZE.ForSource(source).GetEnvAsNumberOrDefault(int64(config.DexscreenerChunkSize)))
+
+	if config.Interval < time.Second || config.Interval > 10*time.Minute {
+		log.Printf("%s discovery interval %s out of range [1s, 10m], using %s", source, config.Interval, defaultDiscoveryInterval)
+		config.Interval = defaultDiscoveryInterval
+	}
+	if config.BatchSize < 1 || config.BatchSize > 500 {
+		log.Printf("%s discovery batch size %d out of range [1, 500], using %d", source, config.BatchSize, defaultDiscoveryBatchSize)
+		config.BatchSize = defaultDiscoveryBatchSize
+	}
+	if config.DexscreenerChunkSize < 1 || config.DexscreenerChunkSize > maxDexscreenerChunkSize {
+		log.Printf("%s dexscreener chunk size %d out of range [1, %d], using %d", source, config.DexscreenerChunkSize, maxDexscreenerChunkSize, defaultDexscreenerChunkSize)
+		config.DexscreenerChunkSize = defaultDexscreenerChunkSize
+	}
+	return config
+}
+
+// loadJobConfig applies the <JOB>_CRON_INTERVAL, <JOB>_CRON_JITTER and <JOB>_CRON_TIMEOUT
+// overrides to a job, e.g. DETECT_STALE_PRICES_CRON_INTERVAL. An interval of 0 leaves the job
+// to on-demand runs. Unless set, jitter is a tenth of the interval and the timeout is the
+// interval itself.
+func loadJobConfig(job jobs.Job) jobs.Job {
+	job.Interval = env.CRON_INTERVAL.ForSource(job.Name).GetEnvAsDurationOrDefault(job.Interval)
+	if job.Interval < 0 {
+		log.Printf("%s cron interval %s is negative, only running it on demand", job.Name, job.Interval)
+		job.Interval = 0
+	}
+	if job.Jitter == 0 {
+		job.Jitter = job.Interval / 10
+	}
+	job.Jitter = env.CRON_JITTER.ForSource(job.Name).GetEnvAsDurationOrDefault(job.Jitter)
+	if job.Jitter < 0 || job.Jitter > job.Interval {
+		log.Printf("%s cron jitter %s out of range [0, %s], using %s", job.Name, job.Jitter, job.Interval, job.Interval/10)
+		job.Jitter = job.Interval / 10
+	}
+	if job.Timeout == 0 {
+		job.Timeout = job.Interval
+	}
+	job.Timeout = env.CRON_TIMEOUT.ForSource(job.Name).GetEnvAsDurationOrDefault(job.Timeout)
+	log.Printf("Scheduling %s: interval=%s jitter=%s timeout=%s", job.Name, job.Interval, job.Jitter, job.Timeout)
+	return job
+}
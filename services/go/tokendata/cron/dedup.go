@@ -1,42 +1,75 @@
 package cron
 
 import (
+	"strings"
 	"sync"
 	"time"
+	"tokendata/database/repositories/discovery"
+	"tokendata/env"
 )
 
-// tokenDedup is a shared in-memory deduplication cache used by
-// both Clanker and Bankr discovery pipelines.
+// defaultDiscoveryDedupTTL matches the window of the Clanker catch-up, so that tokens read again
+// after a restart are not queued twice.
+const defaultDiscoveryDedupTTL = 24 * time.Hour
+
+// dedupCleanupInterval is how often expired entries are dropped from memory.
+const dedupCleanupInterval = 10 * time.Minute
+
+func discoveryDedupTTL() time.Duration {
+	ttl := env.DISCOVERY_DEDUP_TTL.GetEnvAsDurationOrDefault(defaultDiscoveryDedupTTL)
+	if ttl <= 0 {
+		return defaultDiscoveryDedupTTL
+	}
+	return ttl
+}
+
+// tokenDedup is the deduplication cache shared by the Clanker and Bankr discovery pipelines.
+// Entries expire after ttl. A token missing from memory is looked up in the discovery queue,
+// which keeps every event at least ttl, so a restart does not queue recent tokens again.
 type tokenDedup struct {
 	mu     sync.RWMutex
+	source string
 	seen   map[string]time.Time
-	maxAge time.Duration
+	ttl    time.Duration
 }
 
-func newTokenDedup(maxAge time.Duration) *tokenDedup {
+func newTokenDedup(source string, ttl time.Duration) *tokenDedup {
 	return &tokenDedup{
+		source: source,
 		seen:   make(map[string]time.Time),
-		maxAge: maxAge,
+		ttl:    ttl,
 	}
 }
 
 func (d *tokenDedup) has(address string) bool {
+	address = strings.ToLower(address)
 	d.mu.RLock()
-	defer d.mu.RUnlock()
-	_, ok := d.seen[address]
-	return ok
+	seenAt, ok := d.seen[address]
+	d.mu.RUnlock()
+	if ok && time.Since(seenAt) < d.ttl {
+		return true
+	}
+	queuedAt, ok := discovery.QueuedAt(d.source, address)
+	if !ok || time.Since(queuedAt) >= d.ttl {
+		return false
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.seen[address] = queuedAt
+	return true
 }
 
 func (d *tokenDedup) add(address string) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
-	d.seen[address] = time.Now()
+	d.seen[strings.ToLower(address)] = time.Now()
 }
 
+// cleanup drops the expired entries.
 func (d *tokenDedup) cleanup() {
 	d.mu.Lock()
 	defer d.mu.Unlock()
-	cutoff := time.Now().Add(-d.maxAge)
+	cutoff := time.Now().Add(-d.ttl)
 	for addr, t := range d.seen {
 		if t.Before(cutoff) {
 			delete(d.seen, addr)
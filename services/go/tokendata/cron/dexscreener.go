@@ -5,19 +5,17 @@ import (
 	"tokendata/lib/apis"
 )
 
-const dexscreenerBatchSize = 20
-
 // batchFetchDexScreener fetches DexScreener data for addresses in chunks
-// of dexscreenerBatchSize to avoid URL length limits, then merges all results.
-func batchFetchDexScreener(addresses []string) map[string]apis.DexscreenerBatchResult {
+// of chunkSize to avoid URL length limits, then merges all results.
+func batchFetchDexScreener(addresses []string, chunkSize int) map[string]apis.DexscreenerBatchResult {
 	if len(addresses) == 0 {
 		return nil
 	}
 
 	merged := make(map[string]apis.DexscreenerBatchResult, len(addresses))
 
-	for i := 0; i < len(addresses); i += dexscreenerBatchSize {
-		end := i + dexscreenerBatchSize
+	for i := 0; i < len(addresses); i += chunkSize {
+		end := i + chunkSize
 		if end > len(addresses) {
 			end = len(addresses)
 		}
@@ -36,5 +34,16 @@ func batchFetchDexScreener(addresses []string) map[string]apis.DexscreenerBatchR
 	if len(merged) == 0 {
 		return nil
 	}
+
+	// Descriptions only come from the latest profiles list, which is one request for the batch.
+	profiles, err := apis.GetDexscreenerLatestTokenProfiles()
+	if err != nil {
+		log.Printf("DexScreener token profiles error: %v", err)
+		return merged
+	}
+	for addr, result := range merged {
+		result.Profile = apis.MergeTokenProfiles(result.Profile, profiles[addr])
+		merged[addr] = result
+	}
 	return merged
 }
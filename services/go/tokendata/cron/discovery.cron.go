@@ -0,0 +1,150 @@
+package cron
+
+import (
+	"context"
+	"log"
+	"slices"
+	"time"
+	db_dto "tokendata/database/dto"
+	"tokendata/database/repositories/discovery"
+	tokenRepository "tokendata/database/repositories/token"
+	db "tokendata/generated/prisma"
+	"tokendata/lib/hub"
+)
+
+const (
+	discoverySourceClanker = "clanker"
+	discoverySourceBankr   = "bankr"
+
+	// discoveryQueueMaxPending bounds the persisted queue; producers wait while it is full.
+	discoveryQueueMaxPending = 10000
+	discoveryRetention       = 24 * time.Hour
+)
+
+// enqueueDiscoveryEvent persists an event, waiting while the queue is full so that a launch
+// storm slows producers down instead of dropping events.
+func enqueueDiscoveryEvent(event discovery.Event) {
+	for discovery.IsFull(discoveryQueueMaxPending) {
+		log.Printf("Discovery queue is full, waiting to enqueue %s token %s", event.Source, event.TokenAddress)
+		time.Sleep(time.Second)
+	}
+	if err := discovery.Enqueue(event); err != nil {
+		log.Printf("Error enqueueing %s discovery event for %s: %v", event.Source, event.TokenAddress, err)
+	}
+}
+
+// StartDiscoveryConsumers starts one consumer per discovery source, each with its own interval
+// and batch size.
+func StartDiscoveryConsumers() {
+	discovery.RequeueProcessing()
+
+	go startDiscoveryConsumer(discoverySourceClanker, processClankerBatch)
+	go startDiscoveryConsumer(discoverySourceBankr, func(events []db.DiscoveryEventModel, config discoveryConfig) []string {
+		return processBankrBatch(context.Background(), events, config)
+	})
+
+	cleanupTicker := time.NewTicker(time.Hour)
+	defer cleanupTicker.Stop()
+	for range cleanupTicker.C {
+		// Events are the persisted dedup set, so they outlive the dedup TTL.
+		discovery.PurgeProcessed(max(discoveryRetention, discoveryDedupTTL()))
+	}
+}
+
+// recordDeployment stores the deployment time the source of a discovered token reported, so the
+// token has an age before, or without, its deployer analysis.
+func recordDeployment(token *db.TokenModel, ev db.DiscoveryEventModel) {
+	deployedAt, ok := ev.DeployedAt()
+	if !ok {
+		return
+	}
+	if _, known := token.DeployedAt(); known {
+		return
+	}
+	if err := tokenRepository.SetTokenDeployedAt(db_dto.TokenAddress(token.Address), deployedAt); err != nil {
+		log.Printf("Error saving deployment time of %s: %+v", token.Address, err)
+	}
+}
+
+// discoveryProcessor creates the tokens of a batch and returns the addresses that failed.
+type discoveryProcessor func(events []db.DiscoveryEventModel, config discoveryConfig) []string
+
+func startDiscoveryConsumer(source string, process discoveryProcessor) {
+	config := loadDiscoveryConfig(source)
+	log.Printf("Starting %s discovery consumer: interval=%s batch=%d dexscreenerChunk=%d", source, config.Interval, config.BatchSize, config.DexscreenerChunkSize)
+
+	ticker := time.NewTicker(config.Interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		processDiscoveryQueue(source, config, process)
+	}
+}
+
+// discoveredTokenMessage is pushed to discovery subscribers. Cursor is the feed position of the
+// token, so clients can resume the discovery feed from the last token they received.
+type discoveredTokenMessage struct {
+	Source       string `json:"source"`
+	TokenAddress string `json:"tokenAddress"`
+	PairAddress  string `json:"pairAddress,omitempty"`
+	PoolAddress  string `json:"poolAddress,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Symbol       string `json:"symbol,omitempty"`
+	ImageURL     string `json:"imageUrl,omitempty"`
+	DiscoveredAt int64  `json:"discoveredAt"`
+	Cursor       string `json:"cursor"`
+}
+
+func publishDiscoveredToken(ev db.DiscoveryEventModel, discoveredAt time.Time) {
+	message := discoveredTokenMessage{
+		Source:       ev.Source,
+		TokenAddress: ev.TokenAddress,
+		DiscoveredAt: discoveredAt.Unix(),
+		Cursor:       discovery.EncodeFeedCursor(discoveredAt, ev.ID),
+	}
+	message.PairAddress, _ = ev.PairAddress()
+	message.PoolAddress, _ = ev.PoolAddress()
+	message.Name, _ = ev.Name()
+	message.Symbol, _ = ev.Symbol()
+	message.ImageURL, _ = ev.ImageURL()
+	hub.Publish(hub.TopicDiscovery, "token", message)
+}
+
+// processDiscoveryQueue drains the queue of a source batch by batch.
+func processDiscoveryQueue(source string, config discoveryConfig, process discoveryProcessor) {
+	for {
+		events, err := discovery.Claim(source, config.BatchSize)
+		if err != nil {
+			log.Printf("Error claiming %s discovery events: %v", source, err)
+			return
+		}
+		if len(events) == 0 {
+			return
+		}
+
+		failed := process(events, config)
+
+		var done []string
+		var doneEvents []db.DiscoveryEventModel
+		for _, ev := range events {
+			if slices.Contains(failed, ev.TokenAddress) {
+				discovery.MarkFailed(ev)
+				continue
+			}
+			done = append(done, ev.ID)
+			doneEvents = append(doneEvents, ev)
+		}
+		if len(done) > 0 {
+			if discoveredAt, err := discovery.MarkDone(done); err == nil {
+				for _, ev := range doneEvents {
+					publishDiscoveredToken(ev, discoveredAt)
+				}
+				discovery.NotifyLaunches(doneEvents, discoveredAt)
+			}
+		}
+
+		if len(events) < config.BatchSize {
+			return
+		}
+	}
+}
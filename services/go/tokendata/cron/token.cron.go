@@ -2,62 +2,64 @@ package cron
 
 import (
 	"log"
+	"samterminal/pkg/usage"
+	"strings"
+	"time"
 	db_dto "tokendata/database/dto"
 	tokenRepository "tokendata/database/repositories/token"
 	"tokendata/lib/apis"
-
-	cron "github.com/jasonlvhit/gocron"
+	"tokendata/lib/jobs"
 )
 
-func RemoveFalseTokensCron() {
-	tokenRepository.RemoveFalseTokens()
-}
-
 func RemoveUnsecureTokensCron() {
+	// Every token costs a Moralis call; the check waits for the next day's budget.
+	if usage.OverBudget(usage.Moralis) {
+		log.Printf("Moralis is over budget, skipping the security check of tracked tokens")
+		return
+	}
 
 	tokenAddresses, _ := tokenRepository.GetAllTokensAddresses()
 
 	unsecureTokens := apis.GetUnsecureTokens(tokenAddresses)
+	pairs := tokenRepository.PairAddresses()
 	for _, tokenAddress := range unsecureTokens {
+		if pairs[strings.ToLower(tokenAddress)] {
+			continue
+		}
 		bypass := true
 		tokenRepository.RemoveFromTokenList(db_dto.TokenAddress(tokenAddress), &bypass)
 	}
 }
 
-func UpdateZeroPricedTokens() {
-	tokenRepository.UpdateZeroPricedTokens()
-}
-
-func RemoveUnReasonedTokens() {
-	tokenRepository.RemoveUnReasonedTokens()
+// tokenJobs are the maintenance jobs with their default schedule. Jobs without an interval only
+// run when triggered through the runCronJob RPC.
+var tokenJobs = []jobs.Job{
+	{Name: "update_zero_priced_tokens", Interval: 10 * time.Minute, RunOnStart: true, Run: tokenRepository.UpdateZeroPricedTokens},
+	{Name: "remove_unreasoned_tokens", Interval: time.Hour, RunOnStart: true, Run: tokenRepository.RemoveUnReasonedTokens},
+	{Name: "remove_unused_tokens", Interval: 5 * time.Minute, RunOnStart: true, Run: tokenRepository.RemoveUnusedTokens},
+	{Name: "purge_archived_tokens", Interval: 24 * time.Hour, Run: tokenRepository.PurgeArchivedTokens},
+	{Name: "purge_token_price_history", Interval: 24 * time.Hour, Run: tokenRepository.PurgeTokenPriceHistory},
+	{Name: "purge_token_changes", Interval: 24 * time.Hour, Run: tokenRepository.PurgeTokenChanges},
+	{Name: "cache_token_images", Interval: 10 * time.Minute, Run: tokenRepository.CacheTokenImages},
+	{Name: "detect_delisted_tokens", Interval: time.Hour, Run: tokenRepository.DetectDelistedTokens},
+	{Name: "refresh_token_supplies", Interval: time.Hour, Run: tokenRepository.RefreshTokenSupplies},
+	{Name: "check_tokens_trading", Interval: time.Hour, Run: tokenRepository.CheckTokensTrading},
+	{Name: "check_tokens_risk", Interval: time.Hour, Run: tokenRepository.CheckTokensRisk},
+	{Name: "refresh_farcaster_momentum", Interval: 10 * time.Minute, Run: tokenRepository.RefreshFarcasterMomentum},
+	{Name: "update_trending_tokens", Interval: 5 * time.Minute, RunOnStart: true, Run: tokenRepository.UpdateTrendingTokens},
+	{Name: "detect_stale_prices", Interval: 5 * time.Minute, Run: tokenRepository.DetectStalePrices},
+	{Name: "detect_pool_migrations", Interval: 15 * time.Minute, Run: tokenRepository.DetectPoolMigrations},
+	{Name: "remove_unsecure_tokens", Timeout: 30 * time.Minute, Run: RemoveUnsecureTokensCron},
+	{Name: "backfill_deployed_at", Timeout: 24 * time.Hour, Run: tokenRepository.BackfillDeployedAt},
+	{Name: "backfill_token_tags", Timeout: time.Hour, Run: tokenRepository.BackfillTokenTags},
+	{Name: "reconcile_pair_tokens", Interval: 30 * time.Minute, Timeout: 30 * time.Minute, Run: tokenRepository.ReconcilePairTokens},
 }
 
+// StartCron registers the token maintenance jobs, with the overrides from the environment, and
+// starts their schedules.
 func StartCron() {
-
-	t := cron.Every(10).Minutes().Do(
-		UpdateZeroPricedTokens,
-	)
-	u := cron.Every(1).Hours().Do(
-		RemoveUnReasonedTokens,
-	)
-	removeUnusedTokens := cron.Every(30).Minutes().Do(
-		tokenRepository.RemoveUnusedTokens,
-	)
-	if t != nil || u != nil || removeUnusedTokens != nil {
-		log.Printf("Error starting cron")
-	}
-	RemoveUnReasonedTokens()
-	UpdateZeroPricedTokens()
-	tokenRepository.RemoveUnusedTokens()
-	<-cron.Start()
-}
-
-func AddNotAddedPairAddresses() {
-	addPairAddresses := cron.Every(1).Hours().Do(
-		tokenRepository.AddNotAddedPairAddresses,
-	)
-	if addPairAddresses == nil {
-		log.Printf("Error starting add not added pair addresses cron")
+	for _, job := range tokenJobs {
+		jobs.Register(loadJobConfig(job))
 	}
-	tokenRepository.AddNotAddedPairAddresses()
+	jobs.Start()
 }
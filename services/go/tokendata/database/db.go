@@ -2,9 +2,8 @@ package database
 
 import (
 	"context"
-	"log"
+	"samterminal/pkg/dbconn"
 	"sync"
-	"time"
 	"tokendata/env"
 	db "tokendata/generated/prisma"
 )
@@ -18,7 +17,7 @@ func init() {
 }
 
 func CreateClient() {
-	Client = db.NewClient(db.WithDatasourceURL(env.DATABASE_URL.GetEnv()))
+	Client = db.NewClient(db.WithDatasourceURL(dbconn.DatasourceURL()))
 }
 
 func InitDatabase() {
@@ -29,22 +28,9 @@ func InitDatabase() {
 func ConnectToDB() bool {
 	var result = false
 	once.Do(func() {
-		const maxAttempts = 10
-		for attempt := 1; attempt <= maxAttempts; attempt++ {
-			if err := Client.Prisma.Connect(); err != nil {
-				log.Printf("Database connect error (attempt %d/%d): %v", attempt, maxAttempts, err)
-				time.Sleep(time.Duration(attempt) * time.Second)
-				continue
-			}
-			ctx := context.Background()
+		result = dbconn.Connect(Client.Prisma.Connect, func(ctx context.Context) {
 			_, _ = Client.Token.FindMany().Take(0).Exec(ctx)
-			log.Println("Connected to Database")
-			result = true
-			break
-		}
-		if !result {
-			log.Fatal("Could not connect to Database after retries")
-		}
+		})
 	})
 	return result
 }
@@ -53,9 +39,5 @@ func DisconnectFromDB() {
 	if Client == nil || Client.Prisma == nil {
 		return
 	}
-	if err := Client.Prisma.Disconnect(); err != nil {
-		log.Printf("Database disconnect error: %v", err)
-		return
-	}
-	log.Println("Disconnected from Database")
+	dbconn.Disconnect(Client.Prisma.Disconnect)
 }
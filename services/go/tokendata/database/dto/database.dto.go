@@ -10,3 +10,15 @@ type ResponseType struct {
 }
 
 type TokenAddress string
+
+// PriceSource is where a stored token price came from.
+type PriceSource string
+
+const (
+	// PriceSourceSwap prices come from swaps on the watched pool of the token.
+	PriceSourceSwap        PriceSource = "swap"
+	PriceSourceDexscreener PriceSource = "dexscreener"
+	PriceSourceCoingecko   PriceSource = "coingecko"
+	// PriceSourceFixed prices are configured, e.g. for stablecoin anchors.
+	PriceSourceFixed PriceSource = "fixed"
+)
@@ -3,7 +3,9 @@ package blacklist
 import (
 	"context"
 	"log"
+	"samterminal/pkg/telemetry"
 	"slices"
+	"strings"
 	"tokendata/database"
 	db "tokendata/generated/prisma"
 )
@@ -19,13 +21,12 @@ func getDB() *db.PrismaClient {
 	return client
 }
 
-func getCtx() (context.Context, context.CancelFunc) {
-	ctx, cancel := context.WithCancel(context.Background())
-	return ctx, cancel
+func getCtx(ctx context.Context) (context.Context, context.CancelFunc) {
+	return telemetry.StartDBSpan(ctx)
 }
 
-func GetAllBlacklistAddresses() ([]string, error) {
-	var ctx, cancel = getCtx()
+func GetAllBlacklistAddresses(ctx context.Context) ([]string, error) {
+	ctx, cancel := getCtx(ctx)
 	var tx = getDB()
 	defer cancel()
 	var blacklist []db.BlacklistsModel
@@ -38,7 +39,7 @@ func GetAllBlacklistAddresses() ([]string, error) {
 }
 
 func GetUnsecureTokensBlacklistAddresses() ([]string, error) {
-	var ctx, cancel = getCtx()
+	var ctx, cancel = getCtx(context.Background())
 	var tx = getDB()
 	defer cancel()
 
@@ -49,8 +50,24 @@ func GetUnsecureTokensBlacklistAddresses() ([]string, error) {
 	return blacklist.Addresses, nil
 }
 
-func AddToBlacklist(addresses []string) error {
-	var ctx, cancel = getCtx()
+// normalizeAddresses lowercases and dedupes addresses, dropping blank ones, as the blacklists
+// keep them.
+func normalizeAddresses(addresses []string) []string {
+	normalized := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		if address = strings.ToLower(strings.TrimSpace(address)); address != "" && !slices.Contains(normalized, address) {
+			normalized = append(normalized, address)
+		}
+	}
+	return normalized
+}
+
+func AddToBlacklist(ctx context.Context, addresses []string) error {
+	addresses = normalizeAddresses(addresses)
+	if len(addresses) == 0 {
+		return nil
+	}
+	ctx, cancel := getCtx(ctx)
 	var tx = getDB()
 	defer cancel()
 	_, err := tx.Blacklists.UpsertOne(db.Blacklists.Name.Equals(UnsecureTokensBlacklistName)).Create(db.Blacklists.Name.Set(UnsecureTokensBlacklistName), db.Blacklists.Addresses.Set(addresses)).Update(db.Blacklists.Addresses.Push(addresses)).Exec(ctx)
@@ -60,24 +77,80 @@ func AddToBlacklist(addresses []string) error {
 		return err
 	}
 	log.Printf("Tokens added to blacklist: %+v", addresses)
+	notify(Change{Type: ChangeAdded, Addresses: addresses})
+	return nil
+}
+
+// removeFromBlacklistsQuery drops addresses, matched case-insensitively, from every blacklist in
+// one statement and returns what it dropped from each list, comma-separated. The lists are read
+// and written under a row lock, so concurrent additions are not lost.
+const removeFromBlacklistsQuery = `WITH "old" AS (
+	SELECT "id", "addresses" FROM "Blacklists"
+	WHERE EXISTS (SELECT 1 FROM unnest("addresses") AS "address" WHERE lower("address") = ANY(string_to_array($1, ',')))
+	FOR UPDATE
+)
+UPDATE "Blacklists" AS "list" SET
+	"addresses" = ARRAY(SELECT "address" FROM unnest("old"."addresses") AS "address" WHERE lower("address") <> ALL(string_to_array($1, ','))),
+	"updatedAt" = now()
+FROM "old" WHERE "list"."id" = "old"."id"
+RETURNING array_to_string(ARRAY(SELECT "address" FROM unnest("old"."addresses") AS "address" WHERE lower("address") = ANY(string_to_array($1, ','))), ',') AS "removed"`
+
+// RemoveFromBlacklist takes tokens off every blacklist.
+func RemoveFromBlacklist(ctx context.Context, addresses []string) error {
+	ctx, cancel := getCtx(ctx)
+	var tx = getDB()
+	defer cancel()
+	keys := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		if address = strings.ToLower(strings.TrimSpace(address)); address != "" && !strings.Contains(address, ",") {
+			keys = append(keys, address)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	var rows []struct {
+		Removed db.RawString `json:"removed"`
+	}
+	if err := tx.Prisma.QueryRaw(removeFromBlacklistsQuery, strings.Join(keys, ",")).Exec(ctx, &rows); err != nil {
+		log.Printf("Error removing from blacklist: %+v", err)
+		return err
+	}
+	removed := []string{}
+	for _, row := range rows {
+		for _, address := range strings.Split(string(row.Removed), ",") {
+			if address != "" && !slices.Contains(removed, address) {
+				removed = append(removed, address)
+			}
+		}
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+	log.Printf("Tokens removed from blacklist: %+v", removed)
+	notify(Change{Type: ChangeRemoved, Addresses: removed})
 	return nil
 }
 
 func IsTokenInBlacklist(tokenAddress string) bool {
-	var ctx, cancel = getCtx()
+	var ctx, cancel = getCtx(context.Background())
 	var tx = getDB()
 	defer cancel()
 	blacklist, _ := tx.Blacklists.FindUnique(db.Blacklists.Name.Equals(UnsecureTokensBlacklistName)).Exec(ctx)
 	if blacklist == nil {
 		return false
 	}
-	return slices.Contains(blacklist.Addresses, tokenAddress)
+	return slices.Contains(blacklist.Addresses, strings.ToLower(tokenAddress))
 }
 
 func AddTokenToBlacklist(tokenAddress string) error {
-	var ctx, cancel = getCtx()
+	tokenAddress = strings.ToLower(strings.TrimSpace(tokenAddress))
+	var ctx, cancel = getCtx(context.Background())
 	var tx = getDB()
 	defer cancel()
 	_, err := tx.Blacklists.UpsertOne(db.Blacklists.Name.Equals(UnsecureTokensBlacklistName)).Create(db.Blacklists.Name.Set(UnsecureTokensBlacklistName), db.Blacklists.Addresses.Set([]string{tokenAddress})).Update(db.Blacklists.Addresses.Push([]string{tokenAddress})).Exec(ctx)
+	if err == nil {
+		notify(Change{Type: ChangeAdded, Addresses: []string{tokenAddress}})
+	}
 	return err
 }
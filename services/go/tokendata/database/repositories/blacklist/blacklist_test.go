@@ -0,0 +1,13 @@
+package blacklist
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNormalizeAddresses(t *testing.T) {
+	got := normalizeAddresses([]string{" 0xABCdef ", "0xabcdef", "", "0x1234"})
+	if want := []string{"0xabcdef", "0x1234"}; !slices.Equal(got, want) {
+		t.Errorf("normalizeAddresses = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,83 @@
+package blacklist
+
+import (
+	"log"
+	"sync"
+)
+
+// watcherBufferSize is how many changes a watcher may fall behind before it is dropped.
+const watcherBufferSize = 256
+
+type ChangeType int
+
+const (
+	ChangeAdded ChangeType = iota + 1
+	ChangeRemoved
+)
+
+// Change is an addition to or removal from the blacklist.
+type Change struct {
+	Type      ChangeType
+	Addresses []string
+}
+
+// Watcher receives the changes of the blacklist until it is closed.
+type Watcher struct {
+	changes   chan Change
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+var (
+	watchersMu sync.RWMutex
+	watchers   = map[*Watcher]bool{}
+)
+
+// Watch starts receiving blacklist changes. Callers must Close the watcher when done.
+func Watch() *Watcher {
+	w := &Watcher{changes: make(chan Change, watcherBufferSize), done: make(chan struct{})}
+	watchersMu.Lock()
+	watchers[w] = true
+	watchersMu.Unlock()
+	return w
+}
+
+// Changes returns the changes in the order they were made.
+func (w *Watcher) Changes() <-chan Change {
+	return w.changes
+}
+
+// Done is closed when the watcher was closed or dropped for falling behind.
+func (w *Watcher) Done() <-chan struct{} {
+	return w.done
+}
+
+func (w *Watcher) Close() {
+	watchersMu.Lock()
+	delete(watchers, w)
+	watchersMu.Unlock()
+	w.closeOnce.Do(func() { close(w.done) })
+}
+
+// notify sends a change to every watcher without blocking; a watcher whose buffer is full is
+// dropped, as its mirror can no longer be kept complete.
+func notify(change Change) {
+	if len(change.Addresses) == 0 {
+		return
+	}
+	watchersMu.RLock()
+	receivers := make([]*Watcher, 0, len(watchers))
+	for w := range watchers {
+		receivers = append(receivers, w)
+	}
+	watchersMu.RUnlock()
+	for _, w := range receivers {
+		select {
+		case w.changes <- change:
+		case <-w.done:
+		default:
+			log.Println("Dropping slow blacklist watcher")
+			w.Close()
+		}
+	}
+}
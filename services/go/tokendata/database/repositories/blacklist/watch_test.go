@@ -0,0 +1,35 @@
+package blacklist
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestWatchBlacklistChanges(t *testing.T) {
+	w := Watch()
+	defer w.Close()
+
+	notify(Change{Type: ChangeAdded, Addresses: []string{"0xa"}})
+	notify(Change{Type: ChangeRemoved})
+	notify(Change{Type: ChangeRemoved, Addresses: []string{"0xa"}})
+
+	if change := <-w.Changes(); change.Type != ChangeAdded || !slices.Equal(change.Addresses, []string{"0xa"}) {
+		t.Errorf("first change = %+v", change)
+	}
+	if change := <-w.Changes(); change.Type != ChangeRemoved {
+		t.Errorf("second change = %+v, empty changes must not be sent", change)
+	}
+}
+
+func TestSlowBlacklistWatcherIsDropped(t *testing.T) {
+	w := Watch()
+	defer w.Close()
+	for i := 0; i <= watcherBufferSize; i++ {
+		notify(Change{Type: ChangeAdded, Addresses: []string{"0xa"}})
+	}
+	select {
+	case <-w.Done():
+	default:
+		t.Error("watcher that fell behind was not dropped")
+	}
+}
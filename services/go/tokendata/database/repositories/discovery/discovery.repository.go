@@ -0,0 +1,308 @@
+package discovery
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"samterminal/pkg/telemetry"
+	"strconv"
+	"strings"
+	"time"
+	"tokendata/database"
+	db "tokendata/generated/prisma"
+)
+
+// maxAttempts is how many times an event is retried before it is left as FAILED.
+const maxAttempts = 3
+
+const (
+	DefaultFeedLimit = 50
+	MaxFeedLimit     = 200
+	// feedSettleDelay holds back just processed events so that a batch committed concurrently
+	// with a slightly earlier time cannot appear behind a cursor a client already read.
+	feedSettleDelay = 2 * time.Second
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+type Event struct {
+	Source       string
+	TokenAddress string
+	PairAddress  string
+	PoolAddress  string
+	PoolType     *db.DexPoolType
+	Name         string
+	Symbol       string
+	ImageURL     string
+	// When the token was deployed; zero when the source does not tell.
+	DeployedAt time.Time
+}
+
+func getDB() *db.PrismaClient {
+	var client = database.Client
+	if client == nil {
+		database.CreateClient()
+		client = database.Client
+	}
+	return client
+}
+
+func getCtx(ctx context.Context) (context.Context, context.CancelFunc) {
+	return telemetry.StartDBSpan(ctx)
+}
+
+func optional(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return &v
+}
+
+// IsFull reports whether at least maxPending events are waiting to be processed.
+func IsFull(maxPending int) bool {
+	var ctx, cancel = getCtx(context.Background())
+	var tx = getDB()
+	defer cancel()
+	events, err := tx.DiscoveryEvent.FindMany(
+		db.DiscoveryEvent.Status.Equals(db.DiscoveryEventStatusPending),
+	).OrderBy(
+		db.DiscoveryEvent.CreatedAt.Order(db.SortOrderAsc),
+	).Skip(maxPending - 1).Take(1).Exec(ctx)
+	if err != nil {
+		log.Printf("Error checking discovery queue size: %+v", err)
+		return false
+	}
+	return len(events) > 0
+}
+
+// Enqueue persists a discovery event. An event for a token that was already queued by the same
+// source is ignored.
+func Enqueue(event Event) error {
+	var ctx, cancel = getCtx(context.Background())
+	var tx = getDB()
+	defer cancel()
+	tokenAddress := strings.ToLower(event.TokenAddress)
+	var deployedAt *db.DateTime
+	if !event.DeployedAt.IsZero() {
+		deployedAt = &event.DeployedAt
+	}
+	_, err := tx.DiscoveryEvent.UpsertOne(
+		db.DiscoveryEvent.SourceTokenAddress(
+			db.DiscoveryEvent.Source.Equals(event.Source),
+			db.DiscoveryEvent.TokenAddress.Equals(tokenAddress),
+		),
+	).Create(
+		db.DiscoveryEvent.Source.Set(event.Source),
+		db.DiscoveryEvent.TokenAddress.Set(tokenAddress),
+		db.DiscoveryEvent.PairAddress.SetOptional(optional(strings.ToLower(event.PairAddress))),
+		db.DiscoveryEvent.PoolAddress.SetOptional(optional(strings.ToLower(event.PoolAddress))),
+		db.DiscoveryEvent.PoolType.SetOptional(event.PoolType),
+		db.DiscoveryEvent.Name.SetOptional(optional(event.Name)),
+		db.DiscoveryEvent.Symbol.SetOptional(optional(event.Symbol)),
+		db.DiscoveryEvent.ImageURL.SetOptional(optional(event.ImageURL)),
+		db.DiscoveryEvent.DeployedAt.SetOptional(deployedAt),
+	).Update().Exec(ctx)
+	return err
+}
+
+// QueuedAt returns when a source queued a token, whatever became of the event, and whether it
+// did since events were last purged.
+func QueuedAt(source string, tokenAddress string) (time.Time, bool) {
+	var ctx, cancel = getCtx(context.Background())
+	var tx = getDB()
+	defer cancel()
+	event, err := tx.DiscoveryEvent.FindUnique(
+		db.DiscoveryEvent.SourceTokenAddress(
+			db.DiscoveryEvent.Source.Equals(source),
+			db.DiscoveryEvent.TokenAddress.Equals(strings.ToLower(tokenAddress)),
+		),
+	).Exec(ctx)
+	if err != nil {
+		if !errors.Is(err, db.ErrNotFound) {
+			log.Printf("Error checking discovery event of %s: %+v", tokenAddress, err)
+		}
+		return time.Time{}, false
+	}
+	return event.CreatedAt, true
+}
+
+// Claim marks up to limit of the oldest pending events of a source as processing and returns them.
+func Claim(source string, limit int) ([]db.DiscoveryEventModel, error) {
+	var ctx, cancel = getCtx(context.Background())
+	var tx = getDB()
+	defer cancel()
+	events, err := tx.DiscoveryEvent.FindMany(
+		db.DiscoveryEvent.Source.Equals(source),
+		db.DiscoveryEvent.Status.Equals(db.DiscoveryEventStatusPending),
+	).OrderBy(
+		db.DiscoveryEvent.CreatedAt.Order(db.SortOrderAsc),
+	).Take(limit).Exec(ctx)
+	if err != nil || len(events) == 0 {
+		return nil, err
+	}
+	ids := make([]string, len(events))
+	for i, event := range events {
+		ids[i] = event.ID
+	}
+	_, err = tx.DiscoveryEvent.FindMany(
+		db.DiscoveryEvent.ID.In(ids),
+	).Update(
+		db.DiscoveryEvent.Status.Set(db.DiscoveryEventStatusProcessing),
+		db.DiscoveryEvent.Attempts.Increment(1),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// MarkDone marks events as processed and returns the time they entered the discovery feed.
+func MarkDone(ids []string) (time.Time, error) {
+	var ctx, cancel = getCtx(context.Background())
+	var tx = getDB()
+	defer cancel()
+	// Stored with millisecond precision, so cursors built from it match the database.
+	discoveredAt := time.Now().UTC().Truncate(time.Millisecond)
+	_, err := tx.DiscoveryEvent.FindMany(
+		db.DiscoveryEvent.ID.In(ids),
+	).Update(
+		db.DiscoveryEvent.Status.Set(db.DiscoveryEventStatusDone),
+		db.DiscoveryEvent.DiscoveredAt.Set(discoveredAt),
+	).Exec(ctx)
+	if err != nil {
+		log.Printf("Error marking discovery events done: %+v", err)
+	}
+	return discoveredAt, err
+}
+
+// MarkFailed puts an event back in the queue, or leaves it FAILED once it ran out of attempts.
+func MarkFailed(event db.DiscoveryEventModel) {
+	var ctx, cancel = getCtx(context.Background())
+	var tx = getDB()
+	defer cancel()
+	status := db.DiscoveryEventStatusPending
+	// attempts was incremented when the event was claimed.
+	if event.Attempts+1 >= maxAttempts {
+		status = db.DiscoveryEventStatusFailed
+	}
+	_, err := tx.DiscoveryEvent.FindUnique(
+		db.DiscoveryEvent.ID.Equals(event.ID),
+	).Update(
+		db.DiscoveryEvent.Status.Set(status),
+	).Exec(ctx)
+	if err != nil {
+		log.Printf("Error marking discovery event failed: %+v", err)
+	}
+}
+
+// RequeueProcessing returns events that were being processed when the service stopped to the queue.
+func RequeueProcessing() {
+	var ctx, cancel = getCtx(context.Background())
+	var tx = getDB()
+	defer cancel()
+	result, err := tx.DiscoveryEvent.FindMany(
+		db.DiscoveryEvent.Status.Equals(db.DiscoveryEventStatusProcessing),
+	).Update(
+		db.DiscoveryEvent.Status.Set(db.DiscoveryEventStatusPending),
+	).Exec(ctx)
+	if err != nil {
+		log.Printf("Error requeueing discovery events: %+v", err)
+		return
+	}
+	if result.Count > 0 {
+		log.Printf("Requeued %d interrupted discovery events", result.Count)
+	}
+}
+
+// PurgeProcessed deletes done and failed events older than maxAge.
+func PurgeProcessed(maxAge time.Duration) {
+	var ctx, cancel = getCtx(context.Background())
+	var tx = getDB()
+	defer cancel()
+	_, err := tx.DiscoveryEvent.FindMany(
+		db.DiscoveryEvent.Status.In([]db.DiscoveryEventStatus{db.DiscoveryEventStatusDone, db.DiscoveryEventStatusFailed}),
+		db.DiscoveryEvent.UpdatedAt.Lt(time.Now().Add(-maxAge)),
+	).Delete().Exec(ctx)
+	if err != nil {
+		log.Printf("Error purging discovery events: %+v", err)
+	}
+}
+
+// EncodeFeedCursor returns the opaque cursor of a feed position.
+func EncodeFeedCursor(discoveredAt time.Time, id string) string {
+	raw := strconv.FormatInt(discoveredAt.UnixMilli(), 10) + ":" + id
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeFeedCursor(cursor string) (time.Time, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, "", ErrInvalidCursor
+	}
+	millis, id, ok := strings.Cut(string(raw), ":")
+	if !ok || id == "" {
+		return time.Time{}, "", ErrInvalidCursor
+	}
+	value, err := strconv.ParseInt(millis, 10, 64)
+	if err != nil {
+		return time.Time{}, "", ErrInvalidCursor
+	}
+	return time.UnixMilli(value).UTC(), id, nil
+}
+
+// GetFeed returns processed events after cursor, oldest first, and the cursor of the last one.
+// An empty cursor starts at the oldest retained event; when no events follow, the returned
+// cursor is the one passed in so clients can poll with it.
+func GetFeed(ctx context.Context, cursor string, limit int, sources []string) ([]db.DiscoveryEventModel, string, bool, error) {
+	ctx, cancel := getCtx(ctx)
+	var tx = getDB()
+	defer cancel()
+
+	if limit <= 0 {
+		limit = DefaultFeedLimit
+	}
+	limit = min(limit, MaxFeedLimit)
+	params := []db.DiscoveryEventWhereParam{
+		db.DiscoveryEvent.Status.Equals(db.DiscoveryEventStatusDone),
+		db.DiscoveryEvent.DiscoveredAt.Lt(time.Now().Add(-feedSettleDelay)),
+	}
+	if cursor != "" {
+		after, id, err := decodeFeedCursor(cursor)
+		if err != nil {
+			return nil, "", false, err
+		}
+		params = append(params, db.DiscoveryEvent.Or(
+			db.DiscoveryEvent.DiscoveredAt.Gt(after),
+			db.DiscoveryEvent.And(
+				db.DiscoveryEvent.DiscoveredAt.Equals(after),
+				db.DiscoveryEvent.ID.Gt(id),
+			),
+		))
+	}
+	if len(sources) > 0 {
+		params = append(params, db.DiscoveryEvent.Source.In(sources))
+	}
+
+	// One extra row tells whether another page follows.
+	events, err := tx.DiscoveryEvent.FindMany(params...).OrderBy(
+		db.DiscoveryEvent.DiscoveredAt.Order(db.SortOrderAsc),
+	).OrderBy(
+		db.DiscoveryEvent.ID.Order(db.SortOrderAsc),
+	).Take(limit + 1).Exec(ctx)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("error getting discovery feed: %w", err)
+	}
+	hasMore := len(events) > limit
+	if hasMore {
+		events = events[:limit]
+	}
+	nextCursor := cursor
+	if len(events) > 0 {
+		last := events[len(events)-1]
+		discoveredAt, _ := last.DiscoveredAt()
+		nextCursor = EncodeFeedCursor(discoveredAt, last.ID)
+	}
+	return events, nextCursor, hasMore, nil
+}
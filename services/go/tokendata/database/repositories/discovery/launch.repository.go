@@ -0,0 +1,289 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	db "tokendata/generated/prisma"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	DefaultLaunchesLimit = 50
+	MaxLaunchesLimit     = 200
+	// Newest tokens considered when launches are filtered by their all-time high.
+	maxAthCandidates = 1000
+)
+
+// Launch is a discovered token together with its stored token data.
+type Launch struct {
+	Event db.DiscoveryEventModel
+	Token db.TokenModel
+}
+
+// PriceMultiple is the current price of the token divided by its price when it was added, or
+// zero when either is unknown.
+func (l Launch) PriceMultiple() float64 {
+	initialPrice, ok := l.Token.InitialPrice()
+	if !ok {
+		return 0
+	}
+	initial, err := decimal.NewFromString(initialPrice)
+	if err != nil || !initial.IsPositive() {
+		return 0
+	}
+	price, err := decimal.NewFromString(l.Token.Price)
+	if err != nil {
+		return 0
+	}
+	multiple, _ := price.Div(initial).Float64()
+	return multiple
+}
+
+// GetRecentLaunches returns the tokens discovered after since, newest first. An empty source
+// includes every discovery pipeline; a positive minAthMultiple keeps the launches whose all-time
+// high reached that multiple of their initial price.
+func GetRecentLaunches(ctx context.Context, source string, since time.Time, limit int, minAthMultiple float64) ([]Launch, error) {
+	ctx, cancel := getCtx(ctx)
+	var tx = getDB()
+	defer cancel()
+
+	if limit <= 0 {
+		limit = DefaultLaunchesLimit
+	}
+	limit = min(limit, MaxLaunchesLimit)
+	params := []db.DiscoveryEventWhereParam{
+		db.DiscoveryEvent.Status.Equals(db.DiscoveryEventStatusDone),
+		db.DiscoveryEvent.DiscoveredAt.Gt(since),
+	}
+	if source != "" {
+		params = append(params, db.DiscoveryEvent.Source.Equals(strings.ToLower(source)))
+	}
+	if minAthMultiple > 0 {
+		performers, err := tx.Token.FindMany(
+			db.Token.AthMultiple.Gte(minAthMultiple),
+			db.Token.CreatedAt.Gt(since),
+		).OrderBy(
+			db.Token.CreatedAt.Order(db.SortOrderDesc),
+		).Take(maxAthCandidates).Exec(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error getting top performing tokens: %w", err)
+		}
+		addresses := make([]string, 0, len(performers))
+		for _, token := range performers {
+			addresses = append(addresses, token.Address)
+		}
+		params = append(params, db.DiscoveryEvent.TokenAddress.In(addresses))
+	}
+	events, err := tx.DiscoveryEvent.FindMany(params...).OrderBy(
+		db.DiscoveryEvent.DiscoveredAt.Order(db.SortOrderDesc),
+	).OrderBy(
+		db.DiscoveryEvent.ID.Order(db.SortOrderDesc),
+	).Take(limit).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting recent launches: %w", err)
+	}
+
+	addresses := make([]string, 0, len(events))
+	for _, event := range events {
+		addresses = append(addresses, strings.ToLower(event.TokenAddress))
+	}
+	tokens, err := tx.Token.FindMany(db.Token.Address.In(addresses)).Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting launched tokens: %w", err)
+	}
+	byAddress := make(map[string]db.TokenModel, len(tokens))
+	for _, token := range tokens {
+		byAddress[token.Address] = token
+	}
+
+	// Events whose token was removed since are left out.
+	launches := make([]Launch, 0, len(events))
+	for _, event := range events {
+		token, ok := byAddress[strings.ToLower(event.TokenAddress)]
+		if !ok {
+			continue
+		}
+		launches = append(launches, Launch{Event: event, Token: token})
+	}
+	return launches, nil
+}
+
+// launchWatcherBufferSize is how many launches a watcher may fall behind before launches are
+// skipped.
+const launchWatcherBufferSize = 64
+
+// LaunchWatcher receives the launches discovered after it was created until it is closed.
+type LaunchWatcher struct {
+	launches  chan Launch
+	closeOnce sync.Once
+}
+
+var (
+	launchWatchersMu sync.RWMutex
+	launchWatchers   = map[*LaunchWatcher]bool{}
+)
+
+// WatchLaunches starts receiving discovered launches. Callers must Close the watcher when done.
+func WatchLaunches() *LaunchWatcher {
+	w := &LaunchWatcher{launches: make(chan Launch, launchWatcherBufferSize)}
+	launchWatchersMu.Lock()
+	defer launchWatchersMu.Unlock()
+	launchWatchers[w] = true
+	return w
+}
+
+func (w *LaunchWatcher) Launches() <-chan Launch {
+	return w.launches
+}
+
+func (w *LaunchWatcher) Close() {
+	w.closeOnce.Do(func() {
+		launchWatchersMu.Lock()
+		defer launchWatchersMu.Unlock()
+		delete(launchWatchers, w)
+	})
+}
+
+func hasLaunchWatchers() bool {
+	launchWatchersMu.RLock()
+	defer launchWatchersMu.RUnlock()
+	return len(launchWatchers) > 0
+}
+
+// NotifyLaunches sends the launches of events just marked done to the watchers without blocking;
+// watchers that are behind skip them. The tokens are only read when someone watches.
+func NotifyLaunches(events []db.DiscoveryEventModel, discoveredAt time.Time) {
+	if len(events) == 0 || !hasLaunchWatchers() {
+		return
+	}
+	var ctx, cancel = getCtx(context.Background())
+	var tx = getDB()
+	defer cancel()
+	addresses := make([]string, 0, len(events))
+	for _, event := range events {
+		addresses = append(addresses, strings.ToLower(event.TokenAddress))
+	}
+	tokens, err := tx.Token.FindMany(db.Token.Address.In(addresses)).Exec(ctx)
+	if err != nil {
+		log.Printf("Error getting launched tokens: %+v", err)
+		return
+	}
+	byAddress := make(map[string]db.TokenModel, len(tokens))
+	for _, token := range tokens {
+		byAddress[token.Address] = token
+	}
+
+	launchWatchersMu.RLock()
+	defer launchWatchersMu.RUnlock()
+	for _, event := range events {
+		token, ok := byAddress[strings.ToLower(event.TokenAddress)]
+		if !ok {
+			continue
+		}
+		event.InnerDiscoveryEvent.DiscoveredAt = &discoveredAt
+		for w := range launchWatchers {
+			select {
+			case w.launches <- Launch{Event: event, Token: token}:
+			default:
+				log.Printf("Skipping launch %s for a slow watcher", event.TokenAddress)
+			}
+		}
+	}
+}
+
+// LaunchFilter holds the criteria of a filtered launch subscription. Unset criteria match every
+// launch; keywords match when any of them is in the name or symbol, ignoring case.
+type LaunchFilter struct {
+	Sources               []string
+	Keywords              []string
+	MinLiquidityUSD       *float64
+	MaxDeployerRiskScore  *int
+	MinFarcasterCasts1H   *int
+	MinFarcasterCasters1H *int
+}
+
+type FilterResult int
+
+const (
+	FilterRejected FilterResult = iota
+	FilterMatched
+	// FilterPending is the result while a launch passes the criteria that are known but a check
+	// the others depend on, like the risk or Farcaster check, has not run yet.
+	FilterPending
+)
+
+// LaunchChecks are the checks whose results a pending launch waits for.
+type LaunchChecks struct {
+	Risk      bool
+	Deployer  bool
+	Farcaster bool
+}
+
+// Missing returns the checks a launch waits for before the filter can decide on it.
+func (f LaunchFilter) Missing(launch Launch) LaunchChecks {
+	var missing LaunchChecks
+	if f.MinLiquidityUSD != nil {
+		_, ok := launch.Token.LiquidityUSD()
+		missing.Risk = !ok
+	}
+	if f.MaxDeployerRiskScore != nil {
+		_, ok := launch.Token.DeployerRiskScore()
+		missing.Deployer = !ok
+	}
+	if f.MinFarcasterCasts1H != nil || f.MinFarcasterCasters1H != nil {
+		_, casts := launch.Token.FarcasterCasts1H()
+		_, casters := launch.Token.FarcasterCasters1H()
+		missing.Farcaster = (f.MinFarcasterCasts1H != nil && !casts) || (f.MinFarcasterCasters1H != nil && !casters)
+	}
+	return missing
+}
+
+// Check matches a launch against the filter.
+func (f LaunchFilter) Check(launch Launch) FilterResult {
+	if len(f.Sources) > 0 && !slices.ContainsFunc(f.Sources, func(source string) bool {
+		return strings.EqualFold(source, launch.Event.Source)
+	}) {
+		return FilterRejected
+	}
+	if len(f.Keywords) > 0 && !slices.ContainsFunc(f.Keywords, func(keyword string) bool {
+		keyword = strings.ToLower(keyword)
+		return strings.Contains(strings.ToLower(launch.Token.Name), keyword) || strings.Contains(strings.ToLower(launch.Token.Symbol), keyword)
+	}) {
+		return FilterRejected
+	}
+
+	result := FilterMatched
+	check := func(known bool, passes bool) {
+		switch {
+		case !known:
+			if result == FilterMatched {
+				result = FilterPending
+			}
+		case !passes:
+			result = FilterRejected
+		}
+	}
+	if f.MinLiquidityUSD != nil {
+		liquidity, ok := launch.Token.LiquidityUSD()
+		check(ok, liquidity >= *f.MinLiquidityUSD)
+	}
+	if f.MaxDeployerRiskScore != nil {
+		score, ok := launch.Token.DeployerRiskScore()
+		check(ok, score <= *f.MaxDeployerRiskScore)
+	}
+	if f.MinFarcasterCasts1H != nil {
+		casts, ok := launch.Token.FarcasterCasts1H()
+		check(ok, casts >= *f.MinFarcasterCasts1H)
+	}
+	if f.MinFarcasterCasters1H != nil {
+		casters, ok := launch.Token.FarcasterCasters1H()
+		check(ok, casters >= *f.MinFarcasterCasters1H)
+	}
+	return result
+}
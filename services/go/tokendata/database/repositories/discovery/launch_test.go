@@ -0,0 +1,49 @@
+package discovery
+
+import (
+	"testing"
+	db "tokendata/generated/prisma"
+)
+
+func TestLaunchFilterCheck(t *testing.T) {
+	liquidity, riskScore := 20000.0, 30
+	launch := func(source string, name string, liquidityUSD *float64, deployerRiskScore *int) Launch {
+		return Launch{
+			Event: db.DiscoveryEventModel{InnerDiscoveryEvent: db.InnerDiscoveryEvent{Source: source}},
+			Token: db.TokenModel{InnerToken: db.InnerToken{Name: name, Symbol: "TKN", LiquidityUSD: liquidityUSD, DeployerRiskScore: deployerRiskScore}},
+		}
+	}
+	minLiquidity, maxRisk := 10000.0, 50
+	lowRisk := 20
+	filter := LaunchFilter{Sources: []string{"clanker"}, Keywords: []string{"pepe"}, MinLiquidityUSD: &minLiquidity, MaxDeployerRiskScore: &maxRisk}
+
+	tests := []struct {
+		name   string
+		launch Launch
+		want   FilterResult
+	}{
+		{"matching", launch("Clanker", "Based PEPE", &liquidity, &riskScore), FilterMatched},
+		{"other source", launch("bankr", "Based PEPE", &liquidity, &riskScore), FilterRejected},
+		{"no keyword", launch("clanker", "Doge", &liquidity, &riskScore), FilterRejected},
+		{"risk not checked", launch("clanker", "pepe", &liquidity, nil), FilterPending},
+		{"failing while another is not checked", launch("clanker", "pepe", new(float64), nil), FilterRejected},
+		{"too little liquidity", launch("clanker", "pepe", new(float64), &lowRisk), FilterRejected},
+	}
+	for _, test := range tests {
+		if got := filter.Check(test.launch); got != test.want {
+			t.Errorf("%s: Check = %d, want %d", test.name, got, test.want)
+		}
+	}
+	if (LaunchFilter{}).Check(launch("zora", "anything", nil, nil)) != FilterMatched {
+		t.Error("empty filter did not match")
+	}
+
+	if missing := filter.Missing(launch("clanker", "pepe", nil, &riskScore)); missing != (LaunchChecks{Risk: true}) {
+		t.Errorf("missing = %+v, want the risk check", missing)
+	}
+	minCasts := 5
+	farcasterFilter := LaunchFilter{MinFarcasterCasts1H: &minCasts}
+	if missing := farcasterFilter.Missing(launch("clanker", "pepe", nil, nil)); missing != (LaunchChecks{Farcaster: true}) {
+		t.Errorf("missing = %+v, want the Farcaster check only", missing)
+	}
+}
@@ -0,0 +1,167 @@
+package localization
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"samterminal/pkg/telemetry"
+	"strings"
+	"tokendata/database"
+	db "tokendata/generated/prisma"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrInvalidTokenAddress = errors.New("invalid token address")
+	ErrInvalidLocale       = errors.New("invalid locale")
+	ErrEmptyLocalization   = errors.New("name or description is required")
+)
+
+// localePattern accepts BCP 47 style tags such as "tr", "pt-br" or "zh-hant-tw".
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+func getDB() *db.PrismaClient {
+	var client = database.Client
+	if client == nil {
+		database.CreateClient()
+		client = database.Client
+	}
+	return client
+}
+
+func getCtx(ctx context.Context) (context.Context, context.CancelFunc) {
+	return telemetry.StartDBSpan(ctx)
+}
+
+// NormalizeLocale lowercases a language tag and accepts "_" as separator, so "pt_BR" and "pt-BR"
+// are stored as "pt-br".
+func NormalizeLocale(locale string) (string, bool) {
+	locale = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(locale)), "_", "-")
+	return locale, localePattern.MatchString(locale)
+}
+
+// localeCandidates expands preferred locales into the tags to look up, most preferred first. Each
+// regional tag is followed by its base language, so "pt-BR" also matches a "pt" translation.
+func localeCandidates(locales []string) []string {
+	candidates := []string{}
+	seen := map[string]bool{}
+	add := func(locale string) {
+		if !seen[locale] {
+			seen[locale] = true
+			candidates = append(candidates, locale)
+		}
+	}
+	for _, locale := range locales {
+		locale, ok := NormalizeLocale(locale)
+		if !ok {
+			continue
+		}
+		add(locale)
+		if base, _, found := strings.Cut(locale, "-"); found {
+			add(base)
+		}
+	}
+	return candidates
+}
+
+// SetTokenLocalization replaces the translation of a token for a locale. A field left out falls
+// back to the token's default name or description.
+func SetTokenLocalization(ctx context.Context, tokenAddress string, locale string, name *string, description *string) (*db.TokenLocalizationModel, error) {
+	ctx, cancel := getCtx(ctx)
+	defer cancel()
+	var tx = getDB()
+
+	tokenAddress = strings.ToLower(strings.TrimSpace(tokenAddress))
+	if !common.IsHexAddress(tokenAddress) {
+		return nil, ErrInvalidTokenAddress
+	}
+	locale, ok := NormalizeLocale(locale)
+	if !ok {
+		return nil, ErrInvalidLocale
+	}
+	if name == nil && description == nil {
+		return nil, ErrEmptyLocalization
+	}
+
+	return tx.TokenLocalization.UpsertOne(
+		db.TokenLocalization.TokenAddressLocale(
+			db.TokenLocalization.TokenAddress.Equals(tokenAddress),
+			db.TokenLocalization.Locale.Equals(locale),
+		),
+	).Create(
+		db.TokenLocalization.TokenAddress.Set(tokenAddress),
+		db.TokenLocalization.Locale.Set(locale),
+		db.TokenLocalization.Name.SetIfPresent(name),
+		db.TokenLocalization.Description.SetIfPresent(description),
+	).Update(
+		db.TokenLocalization.Name.SetOptional(name),
+		db.TokenLocalization.Description.SetOptional(description),
+	).Exec(ctx)
+}
+
+func RemoveTokenLocalization(ctx context.Context, tokenAddress string, locale string) error {
+	ctx, cancel := getCtx(ctx)
+	defer cancel()
+	var tx = getDB()
+
+	locale, ok := NormalizeLocale(locale)
+	if !ok {
+		return ErrInvalidLocale
+	}
+	_, err := tx.TokenLocalization.FindMany(
+		db.TokenLocalization.TokenAddress.Equals(strings.ToLower(strings.TrimSpace(tokenAddress))),
+		db.TokenLocalization.Locale.Equals(locale),
+	).Delete().Exec(ctx)
+	return err
+}
+
+func ListTokenLocalizations(ctx context.Context, tokenAddress string) ([]db.TokenLocalizationModel, error) {
+	ctx, cancel := getCtx(ctx)
+	defer cancel()
+	var tx = getDB()
+
+	return tx.TokenLocalization.FindMany(
+		db.TokenLocalization.TokenAddress.Equals(strings.ToLower(strings.TrimSpace(tokenAddress))),
+	).OrderBy(
+		db.TokenLocalization.Locale.Order(db.SortOrderAsc),
+	).Exec(ctx)
+}
+
+// GetTokenLocalizations returns the best matching translation of each token for the preferred
+// locales. Tokens without a matching translation are left out.
+func GetTokenLocalizations(ctx context.Context, tokenAddresses []string, locales []string) (map[string]db.TokenLocalizationModel, error) {
+	results := map[string]db.TokenLocalizationModel{}
+	candidates := localeCandidates(locales)
+	if len(candidates) == 0 || len(tokenAddresses) == 0 {
+		return results, nil
+	}
+
+	ctx, cancel := getCtx(ctx)
+	defer cancel()
+	var tx = getDB()
+
+	addresses := make([]string, len(tokenAddresses))
+	for i, address := range tokenAddresses {
+		addresses[i] = strings.ToLower(address)
+	}
+	localizations, err := tx.TokenLocalization.FindMany(
+		db.TokenLocalization.TokenAddress.In(addresses),
+		db.TokenLocalization.Locale.In(candidates),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rank := map[string]int{}
+	for i, locale := range candidates {
+		rank[locale] = i
+	}
+	for _, localization := range localizations {
+		best, ok := results[localization.TokenAddress]
+		if !ok || rank[localization.Locale] < rank[best.Locale] {
+			results[localization.TokenAddress] = localization
+		}
+	}
+	return results, nil
+}
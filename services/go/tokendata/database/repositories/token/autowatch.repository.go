@@ -0,0 +1,231 @@
+package tokenRepository
+
+import (
+	"cmp"
+	"context"
+	"log"
+	"slices"
+	"sync"
+	"time"
+	"tokendata/env"
+	wallet_client "tokendata/lib/grpc/client/wallet"
+	websocket "tokendata/lib/ws"
+	proto "tokendata/proto/token"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// autoWatchTag tags the wallets walletdata watches because they bought a hot token.
+	autoWatchTag    = "auto-discovered"
+	autoWatchWindow = time.Hour
+	// autoWatchCooldown is how long a token is left alone once its buyers were sent.
+	autoWatchCooldown = 6 * time.Hour
+
+	defaultAutoWatchTopBuyers        = 5
+	maxAutoWatchTopBuyers            = 20
+	defaultAutoWatchMinBuyUSD        = 1000.0
+	defaultAutoWatchMaxWalletsPerDay = 200
+	maxAutoWatchMaxWalletsPerDay     = 1000
+)
+
+type autoWatchConfig struct {
+	volume1hUSD      float64
+	minBuyUSD        float64
+	topBuyers        int
+	maxWalletsPerDay int
+}
+
+func (c autoWatchConfig) enabled() bool {
+	return c.volume1hUSD > 0
+}
+
+var (
+	autoWatchConfigOnce sync.Once
+	autoWatchSettings   autoWatchConfig
+)
+
+func getAutoWatchConfig() autoWatchConfig {
+	autoWatchConfigOnce.Do(func() {
+		autoWatchSettings = autoWatchConfig{
+			volume1hUSD:      env.AUTO_WATCH_VOLUME_1H_USD.GetEnvAsFloatOrDefault(0),
+			minBuyUSD:        env.AUTO_WATCH_MIN_BUY_USD.GetEnvAsFloatOrDefault(defaultAutoWatchMinBuyUSD),
+			topBuyers:        int(min(max(env.AUTO_WATCH_TOP_BUYERS.GetEnvAsNumberOrDefault(defaultAutoWatchTopBuyers), 1), maxAutoWatchTopBuyers)),
+			maxWalletsPerDay: int(min(max(env.AUTO_WATCH_MAX_WALLETS_PER_DAY.GetEnvAsNumberOrDefault(defaultAutoWatchMaxWalletsPerDay), 0), maxAutoWatchMaxWalletsPerDay)),
+		}
+	})
+	return autoWatchSettings
+}
+
+type tapeTrade struct {
+	at        time.Time
+	maker     string
+	amountUSD float64
+	buy       bool
+}
+
+// autoWatchSentTTL is how long a wallet sent to walletdata is not sent again.
+const autoWatchSentTTL = 7 * 24 * time.Hour
+
+// tradeTape keeps the last hour of trades of every watched token to find the top buyers of the
+// tokens whose volume takes off.
+type tradeTape struct {
+	config autoWatchConfig
+
+	mu        sync.Mutex
+	trades    map[string][]tapeTrade
+	sentAt    map[string]time.Time
+	sent      map[string]time.Time
+	day       time.Time
+	sentToday int
+	lastSweep time.Time
+}
+
+func newTradeTape(config autoWatchConfig) *tradeTape {
+	return &tradeTape{config: config, trades: map[string][]tapeTrade{}, sentAt: map[string]time.Time{}, sent: map[string]time.Time{}}
+}
+
+var (
+	autoWatchTapeOnce sync.Once
+	autoWatchTape     *tradeTape
+)
+
+func getTradeTape() *tradeTape {
+	autoWatchTapeOnce.Do(func() {
+		autoWatchTape = newTradeTape(getAutoWatchConfig())
+	})
+	return autoWatchTape
+}
+
+// record adds a trade of trader to the tape and returns the wallets to watch when it takes the 1h
+// volume of its token over the threshold: the largest buyers of the hour, new ones only, within
+// what is left of the daily cap. Wallets only count as sent once added.
+func (t *tradeTape) record(trade *proto.TokenTrade, trader string, now time.Time) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.sweep(now)
+
+	token := trade.TokenAddress
+	trades := t.trades[token]
+	start := 0
+	for start < len(trades) && now.Sub(trades[start].at) > autoWatchWindow {
+		start++
+	}
+	trades = append(trades[start:], tapeTrade{at: now, maker: trader, amountUSD: trade.AmountUsd, buy: trade.Side == proto.TradeSide_TRADE_BUY})
+	t.trades[token] = trades
+
+	volume := 0.0
+	for _, trade := range trades {
+		volume += trade.amountUSD
+	}
+	if volume < t.config.volume1hUSD || now.Sub(t.sentAt[token]) < autoWatchCooldown {
+		return nil
+	}
+	t.sentAt[token] = now
+
+	bought := map[string]float64{}
+	for _, trade := range trades {
+		if trade.buy && trade.maker != "" && trade.maker != zeroAddress {
+			bought[trade.maker] += trade.amountUSD
+		}
+	}
+	buyers := []string{}
+	for maker, amountUSD := range bought {
+		if _, sent := t.sent[maker]; amountUSD >= t.config.minBuyUSD && !sent {
+			buyers = append(buyers, maker)
+		}
+	}
+	slices.SortFunc(buyers, func(a, b string) int {
+		return cmp.Or(cmp.Compare(bought[b], bought[a]), cmp.Compare(a, b))
+	})
+	return buyers[:max(min(len(buyers), t.config.topBuyers, t.left(now)), 0)]
+}
+
+// left returns how many wallets can still be sent today.
+func (t *tradeTape) left(now time.Time) int {
+	if day := now.Truncate(24 * time.Hour); !day.Equal(t.day) {
+		t.day, t.sentToday = day, 0
+	}
+	return t.config.maxWalletsPerDay - t.sentToday
+}
+
+// canSend reports whether a wallet is new and fits in the daily cap.
+func (t *tradeTape) canSend(wallet string, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, sent := t.sent[wallet]
+	return !sent && t.left(now) > 0
+}
+
+// markSent counts a wallet walletdata added towards the daily cap.
+func (t *tradeTape) markSent(wallet string, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.left(now)
+	t.sent[wallet] = now
+	t.sentToday++
+}
+
+// sweep drops the tokens that stopped trading, the cooldowns that ended and the wallets sent
+// longer than autoWatchSentTTL ago, once per window.
+func (t *tradeTape) sweep(now time.Time) {
+	if now.Sub(t.lastSweep) < autoWatchWindow {
+		return
+	}
+	t.lastSweep = now
+	for token, trades := range t.trades {
+		if len(trades) == 0 || now.Sub(trades[len(trades)-1].at) > autoWatchWindow {
+			delete(t.trades, token)
+		}
+	}
+	for token, at := range t.sentAt {
+		if now.Sub(at) >= autoWatchCooldown {
+			delete(t.sentAt, token)
+		}
+	}
+	for wallet, at := range t.sent {
+		if now.Sub(at) >= autoWatchSentTTL {
+			delete(t.sent, wallet)
+		}
+	}
+}
+
+var zeroAddress = common.Address{}.Hex()
+
+// recordAutoWatchTrade feeds a trade of trader to the tape and has walletdata watch the buyers it
+// finds.
+func recordAutoWatchTrade(trade *proto.TokenTrade, trader string) {
+	if !getAutoWatchConfig().enabled() {
+		return
+	}
+	tape := getTradeTape()
+	if buyers := tape.record(trade, trader, time.Now()); len(buyers) > 0 {
+		go watchBuyers(tape, trade.TokenAddress, buyers)
+	}
+}
+
+// watchBuyers sends buyers to walletdata. Contracts are no traders worth watching and are skipped.
+// A wallet walletdata fails to add can be found again with the next token.
+func watchBuyers(tape *tradeTape, tokenAddress string, buyers []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	for _, buyer := range buyers {
+		if !tape.canSend(buyer, time.Now()) {
+			continue
+		}
+		code, err := websocket.GetEthClient().CodeAt(ctx, common.HexToAddress(buyer), nil)
+		if err != nil {
+			log.Printf("Error checking auto-discovered wallet %s: %+v", buyer, err)
+			continue
+		}
+		if len(code) > 0 {
+			continue
+		}
+		if _, err := wallet_client.AddWallet(ctx, buyer, autoWatchTag); err != nil {
+			log.Printf("Error adding auto-discovered wallet %s: %+v", buyer, err)
+			continue
+		}
+		tape.markSent(buyer, time.Now())
+		log.Printf("Watching %s, a top buyer of %s", buyer, tokenAddress)
+	}
+}
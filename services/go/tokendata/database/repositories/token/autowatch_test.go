@@ -0,0 +1,66 @@
+package tokenRepository
+
+import (
+	"slices"
+	"testing"
+	"time"
+	proto "tokendata/proto/token"
+)
+
+func TestTradeTapeFindsTopBuyers(t *testing.T) {
+	tape := newTradeTape(autoWatchConfig{volume1hUSD: 10000, minBuyUSD: 1000, topBuyers: 2, maxWalletsPerDay: 3})
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	trade := func(amountUSD float64, side proto.TradeSide) *proto.TokenTrade {
+		// The maker of routed swaps is the router; buyers are the traders.
+		return &proto.TokenTrade{TokenAddress: testToken, Maker: "0xrouter", AmountUsd: amountUSD, Side: side}
+	}
+
+	// Trades that left the window do not count towards the volume.
+	tape.record(trade(9000, proto.TradeSide_TRADE_BUY), "0xold", now.Add(-2*time.Hour))
+	for _, tr := range []struct {
+		trade  *proto.TokenTrade
+		trader string
+	}{
+		{trade(3000, proto.TradeSide_TRADE_BUY), "0xa"},
+		{trade(500, proto.TradeSide_TRADE_BUY), "0xb"},
+		{trade(2000, proto.TradeSide_TRADE_BUY), "0xc"},
+		{trade(4000, proto.TradeSide_TRADE_SELL), "0xd"},
+	} {
+		if buyers := tape.record(tr.trade, tr.trader, now); buyers != nil {
+			t.Fatalf("buyers below the volume threshold: %v", buyers)
+		}
+	}
+	buyers := tape.record(trade(1000, proto.TradeSide_TRADE_BUY), "0xa", now)
+	if !slices.Equal(buyers, []string{"0xa", "0xc"}) {
+		t.Errorf("buyers = %v, want the two largest buyers over the minimum", buyers)
+	}
+	if buyers := tape.record(trade(50000, proto.TradeSide_TRADE_BUY), "0xe", now.Add(time.Minute)); buyers != nil {
+		t.Errorf("buyers during the cooldown = %v", buyers)
+	}
+
+	// Only 0xa was added; 0xc, which failed, can be found again.
+	tape.markSent("0xa", now)
+	if tape.canSend("0xa", now) || !tape.canSend("0xc", now) {
+		t.Error("canSend does not follow the added wallets")
+	}
+	other := func(token string, trader string) []string {
+		tr := trade(20000, proto.TradeSide_TRADE_BUY)
+		tr.TokenAddress = token
+		return tape.record(tr, trader, now)
+	}
+	if buyers := other("0x2222222222222222222222222222222222222222", "0xc"); !slices.Equal(buyers, []string{"0xc"}) {
+		t.Errorf("buyers = %v, want the wallet that failed to be added", buyers)
+	}
+	tape.markSent("0xc", now)
+	tape.markSent("0xf", now)
+	if buyers := other("0x3333333333333333333333333333333333333333", "0xg"); len(buyers) != 0 || tape.canSend("0xg", now) {
+		t.Errorf("buyers past the daily cap = %v", buyers)
+	}
+
+	// The tape forgets tokens, cooldowns and sent wallets once they are old enough.
+	later := now.Add(autoWatchSentTTL)
+	tape.record(trade(1, proto.TradeSide_TRADE_BUY), "0xh", later)
+	if len(tape.trades) != 1 || len(tape.sentAt) != 0 || len(tape.sent) != 0 {
+		t.Errorf("left %d tokens, %d cooldowns and %d sent wallets", len(tape.trades), len(tape.sentAt), len(tape.sent))
+	}
+}
@@ -0,0 +1,110 @@
+package tokenRepository
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync/atomic"
+	dto "tokendata/database/dto"
+	"tokendata/database/repositories/blacklist"
+	db "tokendata/generated/prisma"
+	wsDexManager "tokendata/lib/ws/dex"
+)
+
+// blacklistedWatchersPrevented counts the pool watchers of blacklisted tokens stopped or not
+// started since startup, logged with the stale price stats.
+var blacklistedWatchersPrevented atomic.Int64
+
+// StartBlacklistSync keeps the blacklisted flag of tokens in line with the unsecure tokens
+// blacklist: blacklisted tokens stop being watched and drop out of default queries, removed
+// ones are watched again. A watcher dropped for falling behind is replaced after a full sync.
+func StartBlacklistSync() {
+	for {
+		watcher := blacklist.Watch()
+		syncBlacklistedTokens()
+	changes:
+		for {
+			select {
+			case <-watcher.Done():
+				log.Println("Blacklist watcher dropped, syncing blacklisted tokens again")
+				break changes
+			case change := <-watcher.Changes():
+				setBlacklisted(change.Addresses, change.Type == blacklist.ChangeAdded)
+			}
+		}
+	}
+}
+
+// syncBlacklistedTokens flags the tokens on the blacklist and clears the flag of the others.
+func syncBlacklistedTokens() {
+	addresses, err := blacklist.GetUnsecureTokensBlacklistAddresses()
+	if err != nil {
+		log.Printf("Error getting blacklist: %+v", err)
+		return
+	}
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	lower := lowerAddresses(addresses)
+	cleared, err := getDB().Token.FindMany(
+		db.Token.Blacklisted.Equals(true),
+		db.Token.Address.NotIn(lower),
+	).Exec(ctx)
+	if err != nil {
+		log.Printf("Error getting blacklisted tokens: %+v", err)
+		return
+	}
+	setBlacklisted(lower, true)
+	removed := make([]string, 0, len(cleared))
+	for _, token := range cleared {
+		removed = append(removed, token.Address)
+	}
+	setBlacklisted(removed, false)
+}
+
+// setBlacklisted flags or unflags tokens. Flagged tokens stop being watched; unflagged ones are
+// watched again unless something else keeps them unwatched.
+func setBlacklisted(addresses []string, blacklisted bool) {
+	if len(addresses) == 0 {
+		return
+	}
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	lower := lowerAddresses(addresses)
+	_, err := getDB().Token.FindMany(
+		db.Token.Address.In(lower),
+		db.Token.Blacklisted.Equals(!blacklisted),
+	).Update(
+		db.Token.Blacklisted.Set(blacklisted),
+	).Exec(ctx)
+	invalidateTokens(lower...)
+	if err != nil {
+		log.Printf("Error flagging blacklisted tokens: %+v", err)
+		return
+	}
+
+	manager := wsDexManager.GetManager()
+	for _, address := range lower {
+		if blacklisted {
+			if manager.IsWatching(address) {
+				blacklistedWatchersPrevented.Add(1)
+			}
+			manager.StopWatching(address)
+			continue
+		}
+		token := getToken(dto.TokenAddress(address))
+		if token == nil || !token.WatchEnabled || token.Archived || token.IsFixedPrice {
+			continue
+		}
+		if err := StartWatchingForPool(token); err != nil {
+			log.Printf("Error watching %s again after its removal from the blacklist: %+v", address, err)
+		}
+	}
+}
+
+func lowerAddresses(addresses []string) []string {
+	lower := make([]string, len(addresses))
+	for i, address := range addresses {
+		lower[i] = strings.ToLower(address)
+	}
+	return lower
+}
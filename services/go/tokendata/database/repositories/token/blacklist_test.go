@@ -0,0 +1,29 @@
+package tokenRepository
+
+import (
+	"context"
+	"testing"
+	"tokendata/database/store/mock"
+)
+
+func TestGetAllTokensLeavesOutBlacklisted(t *testing.T) {
+	const otherToken = "0x2222222222222222222222222222222222222222"
+	blacklisted := mock.NewToken(otherToken, "5")
+	blacklisted.Blacklisted = true
+	defer SetTokenStore(mock.NewTokenStore(mock.NewToken(testToken, "1"), blacklisted))()
+	previous := tokenReadModel
+	tokenReadModel = newReadModel()
+	defer func() { tokenReadModel = previous }()
+	if err := tokenReadModel.reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	listed, err := GetAllTokens(context.Background(), nil, nil, false, nil)
+	if err != nil || len(listed) != 1 || listed[0].Address != testToken {
+		t.Errorf("default tokens = %+v, %v; want the blacklisted token left out", listed, err)
+	}
+	include := false
+	if listed, _ := GetAllTokens(context.Background(), nil, &include, false, nil); len(listed) != 2 {
+		t.Errorf("tokens including unsecure ones = %+v", listed)
+	}
+}
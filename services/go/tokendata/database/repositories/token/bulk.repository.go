@@ -0,0 +1,212 @@
+package tokenRepository
+
+import (
+	"context"
+	"log"
+	"strings"
+	dto "tokendata/database/dto"
+	"tokendata/database/repositories/blacklist"
+	db "tokendata/generated/prisma"
+	"tokendata/lib/apis"
+	"tokendata/lib/degrade"
+	proto "tokendata/proto/token"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Dexscreener accepts at most 30 addresses per /tokens request.
+const bulkDexscreenerChunkSize = 30
+
+func addTokenError(message string) *proto.AddTokenResponse {
+	return &proto.AddTokenResponse{Success: false, Type: proto.TokenAddingType_ADD_ERROR, Message: message}
+}
+
+func addTokenResponse(response *dto.ResponseType) *proto.AddTokenResponse {
+	return &proto.AddTokenResponse{Success: response.Success, Type: *response.AddingType, Message: response.Message}
+}
+
+// AddTokensToList adds many tokens at once. Requests are deduplicated by address, market data for
+// new tokens comes from batched Dexscreener calls and all new rows are created in one
+// transaction, so either every new token is added or none is. Tokens Dexscreener does not know are
+// added one by one through the regular AddToTokenList path. Responses are returned in request
+// order; duplicates share the response of the first request for the address.
+func AddTokensToList(requests []*proto.AddTokenRequest) []*proto.AddTokenResponse {
+	responses := make([]*proto.AddTokenResponse, len(requests))
+	byAddress := map[string]*proto.AddTokenRequest{}
+	order := []string{}
+	for i, req := range requests {
+		address := strings.ToLower(strings.TrimSpace(req.GetTokenAddress()))
+		switch {
+		case !common.IsHexAddress(address):
+			responses[i] = addTokenError("Invalid token address")
+		case req.GetReason() == "":
+			responses[i] = addTokenError("Reason is required")
+		default:
+			if _, ok := byAddress[address]; !ok {
+				byAddress[address] = req
+				order = append(order, address)
+			}
+		}
+	}
+	if len(order) == 0 {
+		return responses
+	}
+
+	results := addTokensByAddress(order, byAddress)
+	for i, req := range requests {
+		if responses[i] == nil {
+			responses[i] = results[strings.ToLower(strings.TrimSpace(req.GetTokenAddress()))]
+		}
+	}
+	return responses
+}
+
+// addTokensByAddress adds deduplicated, validated token requests and returns a response per
+// address.
+func addTokensByAddress(order []string, byAddress map[string]*proto.AddTokenRequest) map[string]*proto.AddTokenResponse {
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	var tx = getDB()
+	results := map[string]*proto.AddTokenResponse{}
+
+	existing, err := tx.Token.FindMany(db.Token.Address.In(order)).Exec(ctx)
+	if err != nil {
+		log.Printf("Error getting existing tokens: %+v", err)
+		for _, address := range order {
+			results[address] = addTokenError("Could not add token to list")
+		}
+		return results
+	}
+	existingByAddress := map[string]db.TokenModel{}
+	for _, token := range existing {
+		existingByAddress[token.Address] = token
+	}
+
+	newAddresses := []string{}
+	for _, address := range order {
+		token, ok := existingByAddress[address]
+		switch {
+		case !ok:
+			newAddresses = append(newAddresses, address)
+		case token.Archived:
+			// Restoring also restarts the pool watcher, which the single-token path handles.
+			req := byAddress[address]
+			results[address] = addTokenResponse(AddToTokenList(dto.TokenAddress(address), req.Name, req.CirculatedSupply, req.Symbol, req.Image, req.PoolAddress, req.PairAddress, req.Reason, req.InitialPrice))
+		default:
+			incrementUsingend(dto.TokenAddress(address))
+			results[address] = &proto.AddTokenResponse{Success: true, Type: proto.TokenAddingType_DUPLICATE, Message: "Token already in list. Increment using ends"}
+		}
+	}
+	if len(newAddresses) == 0 {
+		return results
+	}
+	if degrade.EnrichmentDisabled() {
+		for _, address := range newAddresses {
+			results[address] = addTokenError("Token enrichment is disabled")
+		}
+		return results
+	}
+
+	marketData := map[string]apis.DexscreenerBatchResult{}
+	for i := 0; i < len(newAddresses); i += bulkDexscreenerChunkSize {
+		chunk := newAddresses[i:min(i+bulkDexscreenerChunkSize, len(newAddresses))]
+		data, err := apis.GetDexscreenerBatchTokenData(chunk)
+		if err != nil {
+			log.Printf("Error getting Dexscreener batch data: %+v", err)
+			continue
+		}
+		for address, result := range data {
+			marketData[address] = result
+		}
+	}
+
+	var creates []db.PrismaTransaction
+	var created []string
+	for _, address := range newAddresses {
+		req := byAddress[address]
+		data, ok := marketData[address]
+		name := firstNonEmpty(req.GetName(), data.TokenData.Name)
+		poolAddress := firstNonEmpty(req.GetPoolAddress(), data.Pool.Address)
+		if !ok || name == "" || poolAddress == "" {
+			results[address] = addTokenResponse(AddToTokenList(dto.TokenAddress(address), req.Name, req.CirculatedSupply, req.Symbol, req.Image, req.PoolAddress, req.PairAddress, req.Reason, req.InitialPrice))
+			continue
+		}
+		poolType := db.DexPoolTypeUniswapV3
+		if data.Pool.IsV4 {
+			poolType = db.DexPoolTypeUniswapV4
+		}
+		creates = append(creates, tx.Token.CreateOne(
+			db.Token.Address.Set(address),
+			db.Token.Volume24H.Set(firstNonEmpty(data.TokenData.Volume24H, "0")),
+			db.Token.Price.Set(firstNonEmpty(req.GetInitialPrice(), data.TokenData.Price, "0")),
+			db.Token.Supply.Set(firstNonEmpty(data.TokenData.Supply, "0")),
+			db.Token.ImageURL.Set(firstNonEmpty(req.GetImage(), data.TokenData.ImageURL)),
+			db.Token.Name.Set(name),
+			db.Token.Symbol.Set(firstNonEmpty(req.GetSymbol(), data.TokenData.Symbol)),
+			db.Token.UsingEnds.Set(1),
+			db.Token.PoolType.Set(poolType),
+			db.Token.PoolAddress.Set(poolAddress),
+			db.Token.PairAddress.Set(strings.ToLower(firstNonEmpty(req.GetPairAddress(), data.Pool.PairAddress))),
+			db.Token.PoolABI.Set(""),
+			db.Token.WatchEnabled.Set(true),
+			db.Token.CirculatedSupply.Set(firstNonEmpty(req.GetCirculatedSupply(), data.TokenData.CirculatedSupply, "0")),
+			db.Token.Reason.Set(req.GetReason()),
+			db.Token.Tags.Set(newTokenTags(req.GetReason(), firstNonEmpty(req.GetSymbol(), data.TokenData.Symbol))),
+		).Tx())
+		created = append(created, address)
+	}
+	if len(creates) == 0 {
+		return results
+	}
+
+	if err := tx.Prisma.Transaction(creates...).Exec(ctx); err != nil {
+		log.Printf("Error creating tokens in bulk: %+v", err)
+		for _, address := range created {
+			results[address] = addTokenError("Could not add token to list")
+		}
+		return results
+	}
+	invalidateTokens(created...)
+
+	pairsSaved := map[string]bool{}
+	for _, address := range created {
+		results[address] = &proto.AddTokenResponse{Success: true, Type: proto.TokenAddingType_FIRST_TIME, Message: "Added token to list"}
+		go afterBulkCreate(dto.TokenAddress(address), marketData[address].Profile)
+
+		pairAddress := strings.ToLower(firstNonEmpty(byAddress[address].GetPairAddress(), marketData[address].Pool.PairAddress))
+		if pairAddress != "" && !pairsSaved[pairAddress] {
+			pairsSaved[pairAddress] = true
+			go SaveTokenPrice(dto.TokenAddress(pairAddress))
+		}
+	}
+	return results
+}
+
+// afterBulkCreate does the per-token work that is too slow to do before answering: the security
+// check, pool watching, storing socials, reading the supply and checking trading.
+func afterBulkCreate(tokenAddress dto.TokenAddress, profile apis.TokenProfile) {
+	if !apis.GetIsTokenSecure(string(tokenAddress)) {
+		if err := blacklist.AddTokenToBlacklist(string(tokenAddress)); err != nil {
+			log.Printf("Error adding token to blacklist: %+v", err)
+		}
+	}
+	if token := getToken(tokenAddress); token != nil {
+		if err := StartWatchingForPool(token); err != nil {
+			log.Printf("Error starting watching for pool: %+v", err)
+		}
+	}
+	if err := SaveTokenProfile(tokenAddress, profile); err != nil {
+		log.Printf("Error saving token profile for %s: %+v", tokenAddress, err)
+	}
+	RefreshTokenSupply(tokenAddress)
+	CheckTokenTrading(tokenAddress)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,176 @@
+package tokenRepository
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+	"tokendata/env"
+	db "tokendata/generated/prisma"
+)
+
+const defaultTokenChangeRetentionDays = 30
+
+var ErrInvalidChangeCursor = errors.New("invalid cursor")
+
+// TokenChange is a token at its latest change. Token is nil when the token was deleted.
+type TokenChange struct {
+	Cursor       string
+	TokenAddress string
+	ChangedAt    time.Time
+	Token        *db.TokenModel
+}
+
+// tokenChangesQuery pages through the change log in the order of the transactions that wrote it.
+// Changes of transactions at or above the oldest one still running are held back: only those can
+// still commit, and they all sort after the changes returned, so a cursor never skips a change.
+// A long transaction, or any other long-running transaction of the database, holds back the
+// changes committed after it started until it ends.
+const tokenChangesQuery = `SELECT "tokenAddress", "txid"::text AS "txid", "changedAt" FROM "TokenChange"
+WHERE "txid" < pg_snapshot_xmin(pg_current_snapshot())::text::bigint
+AND ("txid", "tokenAddress") > ($1::bigint, $2::text)
+ORDER BY "txid", "tokenAddress"
+LIMIT $3`
+
+type tokenChangeRow struct {
+	TokenAddress db.RawString `json:"tokenAddress"`
+	// Raw queries return bigints as strings.
+	Txid      db.RawString   `json:"txid"`
+	ChangedAt db.RawDateTime `json:"changedAt"`
+}
+
+// encodeChangeCursor returns the opaque cursor of a change log position.
+func encodeChangeCursor(txid int64, tokenAddress string) string {
+	raw := strconv.FormatInt(txid, 10) + ":" + tokenAddress
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeChangeCursor(cursor string) (int64, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, "", ErrInvalidChangeCursor
+	}
+	txid, tokenAddress, ok := strings.Cut(string(raw), ":")
+	if !ok || tokenAddress == "" {
+		return 0, "", ErrInvalidChangeCursor
+	}
+	value, err := strconv.ParseInt(txid, 10, 64)
+	if err != nil || value < 0 {
+		return 0, "", ErrInvalidChangeCursor
+	}
+	return value, tokenAddress, nil
+}
+
+// GetTokenChanges returns up to limit changes after cursor, in log order, with the current state
+// of their tokens, and whether more follow. An empty cursor starts at the oldest change.
+func GetTokenChanges(ctx context.Context, cursor string, limit int) ([]TokenChange, bool, error) {
+	ctx, cancel := getCtx(ctx)
+	defer cancel()
+
+	// Before every position.
+	afterTxid, afterAddress := int64(-1), ""
+	if cursor != "" {
+		var err error
+		afterTxid, afterAddress, err = decodeChangeCursor(cursor)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	// One extra row tells whether another page follows.
+	var rows []tokenChangeRow
+	if err := getDB().Prisma.QueryRaw(tokenChangesQuery, afterTxid, afterAddress, limit+1).Exec(ctx, &rows); err != nil {
+		return nil, false, fmt.Errorf("error getting token changes: %w", err)
+	}
+	hasMore := len(rows) > limit
+	if hasMore {
+		rows = rows[:limit]
+	}
+
+	addresses := make([]string, 0, len(rows))
+	for _, row := range rows {
+		addresses = append(addresses, string(row.TokenAddress))
+	}
+	tokens := map[string]*db.TokenModel{}
+	if len(addresses) > 0 {
+		models, err := tokenStore.ListTokens(ctx, addresses)
+		if err != nil {
+			return nil, false, fmt.Errorf("error getting changed tokens: %w", err)
+		}
+		for i := range models {
+			tokens[models[i].Address] = &models[i]
+		}
+	}
+	changes := make([]TokenChange, 0, len(rows))
+	for _, row := range rows {
+		tokenAddress := string(row.TokenAddress)
+		txid, err := strconv.ParseInt(string(row.Txid), 10, 64)
+		if err != nil {
+			return nil, false, fmt.Errorf("error parsing token change txid %q: %w", row.Txid, err)
+		}
+		changes = append(changes, TokenChange{
+			Cursor:       encodeChangeCursor(txid, tokenAddress),
+			TokenAddress: tokenAddress,
+			ChangedAt:    row.ChangedAt.Time,
+			Token:        tokens[tokenAddress],
+		})
+	}
+	return changes, hasMore, nil
+}
+
+// PurgeTokenChanges deletes the changes of deleted tokens older than TOKEN_CHANGE_RETENTION_DAYS.
+// Consumers away for longer miss those deletions.
+func PurgeTokenChanges() {
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	retentionDays := env.TOKEN_CHANGE_RETENTION_DAYS.GetEnvAsNumberOrDefault(defaultTokenChangeRetentionDays)
+	cutoff := time.Now().Add(-time.Duration(retentionDays) * 24 * time.Hour)
+	rows, err := getDB().TokenChange.FindMany(db.TokenChange.ChangedAt.Lt(cutoff)).Exec(ctx)
+	if err != nil {
+		log.Printf("Error getting old token changes: %+v", err)
+		return
+	}
+	if len(rows) == 0 {
+		return
+	}
+	addresses := make([]string, 0, len(rows))
+	for _, row := range rows {
+		addresses = append(addresses, row.TokenAddress)
+	}
+	existing, err := tokenStore.ListTokens(ctx, addresses)
+	if err != nil {
+		log.Printf("Error getting tokens of old changes: %+v", err)
+		return
+	}
+	deleted := deletedTokenAddresses(addresses, existing)
+	if len(deleted) == 0 {
+		return
+	}
+	result, err := getDB().TokenChange.FindMany(
+		db.TokenChange.TokenAddress.In(deleted),
+		db.TokenChange.ChangedAt.Lt(cutoff),
+	).Delete().Exec(ctx)
+	if err != nil {
+		log.Printf("Error purging token changes: %+v", err)
+		return
+	}
+	log.Printf("Purged %d changes of deleted tokens older than %d days", result.Count, retentionDays)
+}
+
+// deletedTokenAddresses returns the addresses that have no token.
+func deletedTokenAddresses(addresses []string, existing []db.TokenModel) []string {
+	found := make(map[string]bool, len(existing))
+	for _, token := range existing {
+		found[token.Address] = true
+	}
+	deleted := []string{}
+	for _, address := range addresses {
+		if !found[address] {
+			deleted = append(deleted, address)
+		}
+	}
+	return deleted
+}
@@ -0,0 +1,31 @@
+package tokenRepository
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"tokendata/database/store/mock"
+	db "tokendata/generated/prisma"
+)
+
+func TestChangeCursor(t *testing.T) {
+	cursor := encodeChangeCursor(123456, testToken)
+	txid, tokenAddress, err := decodeChangeCursor(cursor)
+	if err != nil || txid != 123456 || tokenAddress != testToken {
+		t.Errorf("decoded %d %s %v, want 123456 %s", txid, tokenAddress, err, testToken)
+	}
+	// "123", "abc:0x11" and "-1:0x11".
+	for _, invalid := range []string{"not base64!", "MTIz", "YWJjOjB4MTE", "LTE6MHgxMQ"} {
+		if _, _, err := decodeChangeCursor(invalid); !errors.Is(err, ErrInvalidChangeCursor) {
+			t.Errorf("cursor %q decoded, err = %v", invalid, err)
+		}
+	}
+}
+
+func TestDeletedTokenAddresses(t *testing.T) {
+	const gone = "0x2222222222222222222222222222222222222222"
+	existing := []db.TokenModel{mock.NewToken(testToken, "1")}
+	if deleted := deletedTokenAddresses([]string{testToken, gone}, existing); !slices.Equal(deleted, []string{gone}) {
+		t.Errorf("deleted = %v, want [%s]", deleted, gone)
+	}
+}
@@ -0,0 +1,59 @@
+package tokenRepository
+
+import (
+	"math"
+	"time"
+	dto "tokendata/database/dto"
+	db "tokendata/generated/prisma"
+
+	"github.com/shopspring/decimal"
+)
+
+// priceSourceTrust is the confidence in a fresh price of each source. Swaps on the watched pool
+// are the market itself; the APIs lag it, Coingecko more than Dexscreener.
+var priceSourceTrust = map[dto.PriceSource]float64{
+	dto.PriceSourceSwap:        1,
+	dto.PriceSourceFixed:       1,
+	dto.PriceSourceDexscreener: 0.9,
+	dto.PriceSourceCoingecko:   0.75,
+}
+
+// unknownPriceSourceTrust is the confidence in prices stored before sources were recorded, such
+// as the price a token was created with.
+const unknownPriceSourceTrust = 0.5
+
+// PriceSourceOf returns where the price of a token came from and when it was set. Prices stored
+// before sources were recorded have no source and are dated by the last update of the token.
+func PriceSourceOf(token *db.TokenModel) (dto.PriceSource, time.Time) {
+	source, _ := token.PriceSource()
+	updatedAt, ok := token.PriceUpdatedAt()
+	if !ok {
+		updatedAt = token.LastUpdatedAt
+	}
+	if token.IsFixedPrice {
+		source = string(dto.PriceSourceFixed)
+	}
+	return dto.PriceSource(source), updatedAt
+}
+
+// PriceConfidence scores the price of a token from 0 to 1: the trust in its source, halved for
+// every StalePriceAfterFor(tier) of age. Fixed prices do not age and zero prices score 0.
+func PriceConfidence(token *db.TokenModel, now time.Time) float64 {
+	return priceConfidence(token, now, StalePriceAfterFor(tokenTier(token)))
+}
+
+func priceConfidence(token *db.TokenModel, now time.Time, staleAfter time.Duration) float64 {
+	if price, err := decimal.NewFromString(token.Price); err != nil || !price.IsPositive() {
+		return 0
+	}
+	source, updatedAt := PriceSourceOf(token)
+	trust, ok := priceSourceTrust[source]
+	if !ok {
+		trust = unknownPriceSourceTrust
+	}
+	if source == dto.PriceSourceFixed || staleAfter <= 0 {
+		return trust
+	}
+	age := max(now.Sub(updatedAt), 0)
+	return trust * math.Pow(0.5, float64(age)/float64(staleAfter))
+}
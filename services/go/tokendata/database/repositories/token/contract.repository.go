@@ -0,0 +1,91 @@
+package tokenRepository
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+	"time"
+	dto "tokendata/database/dto"
+	db "tokendata/generated/prisma"
+	"tokendata/lib/contract"
+	"tokendata/lib/degrade"
+)
+
+const (
+	// Inspections make one Etherscan call each, spaced out like deployer analyses to stay within
+	// the free tier rate limit.
+	contractInspectionInterval = time.Second
+	contractInspectionTimeout  = 30 * time.Second
+	contractQueueSize          = 500
+)
+
+var (
+	contractQueue     = make(chan dto.TokenAddress, contractQueueSize)
+	contractQueueOnce sync.Once
+)
+
+// QueueContractInspection schedules the inspection of the contract of a newly discovered token.
+// Tokens are dropped when the queue is full; they can be inspected again later.
+func QueueContractInspection(tokenAddress dto.TokenAddress) {
+	contractQueueOnce.Do(func() {
+		go func() {
+			ticker := time.NewTicker(contractInspectionInterval)
+			defer ticker.Stop()
+			for tokenAddress := range contractQueue {
+				<-ticker.C
+				if err := InspectContract(tokenAddress); err != nil {
+					log.Printf("Error inspecting contract of %s: %+v", tokenAddress, err)
+				}
+			}
+		}()
+	})
+	select {
+	case contractQueue <- tokenAddress:
+	default:
+		log.Printf("Contract inspection queue is full, skipping %s", tokenAddress)
+	}
+}
+
+// InspectContract checks whether the contract of a token is verified and whether it is a proxy,
+// and stores the result on the token.
+func InspectContract(tokenAddress dto.TokenAddress) error {
+	if degrade.EnrichmentDisabled() {
+		return nil
+	}
+	address := strings.ToLower(string(tokenAddress))
+	inspectCtx, cancelInspect := context.WithTimeout(context.Background(), contractInspectionTimeout)
+	defer cancelInspect()
+	inspection, err := contract.Inspect(inspectCtx, address)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	var tx = getDB()
+	_, err = tx.Token.FindUnique(
+		db.Token.Address.Equals(address),
+	).Update(
+		db.Token.ContractVerified.Set(inspection.Verified),
+		db.Token.ContractName.SetOptional(optional(inspection.Name)),
+		db.Token.CompilerVersion.SetOptional(optional(inspection.CompilerVersion)),
+		db.Token.SourceHash.SetOptional(optional(inspection.SourceHash)),
+		db.Token.ContractIsProxy.Set(inspection.Proxy),
+		db.Token.Implementation.SetOptional(optional(inspection.Implementation)),
+		db.Token.ContractCheckedAt.Set(time.Now()),
+	).Exec(ctx)
+	invalidateTokens(address)
+	if err != nil {
+		return err
+	}
+	log.Printf("Contract of %s: verified=%t proxy=%t", address, inspection.Verified, inspection.Proxy)
+	return nil
+}
+
+func optional(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return &v
+}
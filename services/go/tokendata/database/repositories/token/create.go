@@ -0,0 +1,34 @@
+package tokenRepository
+
+import (
+	"strings"
+	dto "tokendata/database/dto"
+	db "tokendata/generated/prisma"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// tokenCreations has concurrent adds of the same token, e.g. a wallet refresh and the Clanker
+// poll finding it at once, wait for one creation instead of racing on the address.
+var tokenCreations singleflight.Group
+
+// createTokenOnce creates a token unless it exists, with one creation per address in flight.
+// created reports whether this call created it; callers that joined the creation of another or
+// found the token once it was their turn get the token with created false.
+func createTokenOnce(tokenAddress dto.TokenAddress, find func() *db.TokenModel, create func() error) (token *db.TokenModel, created bool) {
+	v, err, _ := tokenCreations.Do(strings.ToLower(string(tokenAddress)), func() (any, error) {
+		if token := find(); token != nil {
+			return token, nil
+		}
+		if err := create(); err != nil {
+			return nil, err
+		}
+		created = true
+		return find(), nil
+	})
+	if err != nil {
+		return nil, false
+	}
+	token, _ = v.(*db.TokenModel)
+	return token, created && token != nil
+}
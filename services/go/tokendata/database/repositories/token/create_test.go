@@ -0,0 +1,65 @@
+package tokenRepository
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+	dto "tokendata/database/dto"
+	db "tokendata/generated/prisma"
+)
+
+func TestCreateTokenOnceCreatesOnce(t *testing.T) {
+	var mu sync.Mutex
+	var stored *db.TokenModel
+	find := func() *db.TokenModel {
+		mu.Lock()
+		defer mu.Unlock()
+		return stored
+	}
+	var creates atomic.Int32
+	create := func() error {
+		creates.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		defer mu.Unlock()
+		stored = &db.TokenModel{InnerToken: db.InnerToken{Address: testToken}}
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	var created atomic.Int32
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, ok := createTokenOnce(dto.TokenAddress(testToken), find, create)
+			if token == nil {
+				t.Error("token = nil")
+			}
+			if ok {
+				created.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := creates.Load(); n != 1 {
+		t.Errorf("creates = %d, want 1", n)
+	}
+	if n := created.Load(); n != 1 {
+		t.Errorf("created = %d, want 1", n)
+	}
+	if token, ok := createTokenOnce(dto.TokenAddress(testToken), find, create); token == nil || ok {
+		t.Errorf("existing token = %v, created %v; want it found", token, ok)
+	}
+}
+
+func TestCreateTokenOnceFails(t *testing.T) {
+	find := func() *db.TokenModel { return nil }
+	create := func() error { return errors.New("unique constraint") }
+	if token, ok := createTokenOnce(dto.TokenAddress(testToken), find, create); token != nil || ok {
+		t.Errorf("createTokenOnce = %v, %v; want nil, false", token, ok)
+	}
+}
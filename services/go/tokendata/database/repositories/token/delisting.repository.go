@@ -0,0 +1,208 @@
+package tokenRepository
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+	dto "tokendata/database/dto"
+	"tokendata/env"
+	db "tokendata/generated/prisma"
+	"tokendata/lib/apis"
+	"tokendata/lib/hub"
+	"tokendata/lib/liquidity"
+	wsDexManager "tokendata/lib/ws/dex"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	defaultDelistStaleAfter      = 6 * time.Hour
+	defaultDelistMinLiquidityUSD = 10
+	// Tokens checked per run, stalest first.
+	delistingBatchSize = 200
+	// Dexscreener accepts up to 30 addresses per batch request.
+	delistingChunkSize    = 30
+	delistingCheckTimeout = 10 * time.Second
+)
+
+type tokenDelistedMessage struct {
+	TokenAddress string `json:"tokenAddress"`
+	Price        string `json:"price"`
+	DelistedAt   int64  `json:"delistedAt"`
+}
+
+// listedOnDexscreener returns, for every address Dexscreener answered for, whether it lists a
+// pool with at least minLiquidityUSD. Addresses of failed requests are left out, so a provider
+// outage never delists anything.
+func listedOnDexscreener(addresses []string, minLiquidityUSD float64) map[string]bool {
+	listed := make(map[string]bool, len(addresses))
+	for i := 0; i < len(addresses); i += delistingChunkSize {
+		chunk := addresses[i:min(i+delistingChunkSize, len(addresses))]
+		data, err := apis.GetDexscreenerBatchTokenData(chunk)
+		if err != nil {
+			log.Printf("Error checking listings on Dexscreener: %+v", err)
+			continue
+		}
+		for _, address := range chunk {
+			result, ok := data[strings.ToLower(address)]
+			// Dexscreener keeps pairs whose liquidity was pulled, so those count as gone too.
+			listed[strings.ToLower(address)] = ok && result.LiquidityUSD >= minLiquidityUSD
+		}
+	}
+	return listed
+}
+
+// hasOnChainLiquidity reports whether the pool of a token still holds liquidity. V3 pools are
+// valued by their pair token balance; a V3 pool whose pair token has no known price is assumed
+// to have liquidity.
+func hasOnChainLiquidity(token *db.TokenModel, minLiquidityUSD float64) (bool, error) {
+	poolAddress, _ := token.PoolAddress()
+	pairAddress, _ := token.PairAddress()
+	ctx, cancel := context.WithTimeout(context.Background(), delistingCheckTimeout)
+	defer cancel()
+
+	if token.PoolType == db.DexPoolTypeUniswapV4 {
+		poolLiquidity, err := liquidity.V4Liquidity(ctx, poolAddress)
+		if err != nil {
+			return false, err
+		}
+		return poolLiquidity.Sign() > 0, nil
+	}
+
+	pairPrice := decimal.Zero
+	if pair := getToken(dto.TokenAddress(pairAddress)); pair != nil {
+		pairPrice, _ = decimal.NewFromString(pair.Price)
+	}
+	if !pairPrice.IsPositive() {
+		return true, nil
+	}
+	balance, err := liquidity.PairBalance(ctx, poolAddress, pairAddress)
+	if err != nil {
+		return false, err
+	}
+	decimals, err := wsDexManager.GetTokenDecimals(ctx, "", pairAddress)
+	if err != nil {
+		return false, err
+	}
+	value := decimal.NewFromBigInt(balance, -int32(decimals)).Mul(pairPrice)
+	return value.GreaterThanOrEqual(decimal.NewFromFloat(minLiquidityUSD)), nil
+}
+
+// DetectDelistedTokens checks tokens that stopped receiving updates and delists those that no
+// provider lists anymore and whose pool has no liquidity left. Delisted tokens keep their last
+// price, are no longer watched and are relisted once Dexscreener lists a liquid pool again.
+func DetectDelistedTokens() {
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	var tx = getDB()
+	staleAfter := env.DELIST_STALE_AFTER.GetEnvAsDurationOrDefault(defaultDelistStaleAfter)
+	minLiquidityUSD := env.DELIST_MIN_LIQUIDITY_USD.GetEnvAsFloatOrDefault(defaultDelistMinLiquidityUSD)
+
+	tokens, err := tx.Token.FindMany(
+		db.Token.Delisted.Equals(false),
+		db.Token.Archived.Equals(false),
+		db.Token.IsFixedPrice.Equals(false),
+		db.Token.LastUpdatedAt.Lt(time.Now().Add(-staleAfter)),
+	).OrderBy(
+		db.Token.LastUpdatedAt.Order(db.SortOrderAsc),
+	).Take(delistingBatchSize).Exec(ctx)
+	if err != nil {
+		log.Printf("Error getting stale tokens: %+v", err)
+		return
+	}
+	addresses := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		addresses = append(addresses, token.Address)
+	}
+	listed := listedOnDexscreener(addresses, minLiquidityUSD)
+
+	for i := range tokens {
+		token := &tokens[i]
+		poolAddress, _ := token.PoolAddress()
+		pairAddress, _ := token.PairAddress()
+		isListed, checked := listed[token.Address]
+		if !checked || isListed || poolAddress == "" || pairAddress == "" {
+			continue
+		}
+		hasLiquidity, err := hasOnChainLiquidity(token, minLiquidityUSD)
+		if err != nil {
+			log.Printf("Error checking liquidity of %s: %+v", token.Address, err)
+			continue
+		}
+		if !hasLiquidity {
+			delistToken(token)
+		}
+	}
+
+	relistTokens(minLiquidityUSD)
+}
+
+func delistToken(token *db.TokenModel) {
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	var tx = getDB()
+	delistedAt := time.Now()
+	_, err := tx.Token.FindUnique(
+		db.Token.Address.Equals(token.Address),
+	).Update(
+		db.Token.Delisted.Set(true),
+		db.Token.DelistedAt.Set(delistedAt),
+		db.Token.WatchEnabled.Set(false),
+	).Exec(ctx)
+	invalidateTokens(token.Address)
+	if err != nil {
+		log.Printf("Error delisting token: %+v", err)
+		return
+	}
+	log.Printf("Delisted %s (%s) at price %s", token.Symbol, token.Address, token.Price)
+	go wsDexManager.GetManager().StopWatching(token.Address)
+	hub.PublishToken(token.Address, "delisted", tokenDelistedMessage{
+		TokenAddress: token.Address,
+		Price:        token.Price,
+		DelistedAt:   delistedAt.UnixMilli(),
+	})
+}
+
+// relistTokens clears the flag of delisted tokens that Dexscreener lists with liquidity again
+// and resumes watching their pool.
+func relistTokens(minLiquidityUSD float64) {
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	var tx = getDB()
+	tokens, err := tx.Token.FindMany(
+		db.Token.Delisted.Equals(true),
+		db.Token.Archived.Equals(false),
+	).Exec(ctx)
+	if err != nil {
+		log.Printf("Error getting delisted tokens: %+v", err)
+		return
+	}
+	addresses := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		addresses = append(addresses, token.Address)
+	}
+	listed := listedOnDexscreener(addresses, minLiquidityUSD)
+	for _, token := range tokens {
+		if !listed[token.Address] {
+			continue
+		}
+		relisted, err := tx.Token.FindUnique(
+			db.Token.Address.Equals(token.Address),
+		).Update(
+			db.Token.Delisted.Set(false),
+			db.Token.DelistedAt.SetOptional(nil),
+			db.Token.WatchEnabled.Set(true),
+		).Exec(ctx)
+		invalidateTokens(token.Address)
+		if err != nil {
+			log.Printf("Error relisting token: %+v", err)
+			continue
+		}
+		log.Printf("Relisted %s (%s)", relisted.Symbol, relisted.Address)
+		if err := StartWatchingForPool(relisted); err != nil {
+			log.Printf("Error starting watching for pool: %+v", err)
+		}
+		go SaveTokenPrice(dto.TokenAddress(relisted.Address))
+	}
+}
@@ -0,0 +1,256 @@
+package tokenRepository
+
+import (
+	"context"
+	"log"
+	"math"
+	"strings"
+	"sync"
+	"time"
+	dto "tokendata/database/dto"
+	db "tokendata/generated/prisma"
+	"tokendata/lib/apis"
+	"tokendata/lib/degrade"
+)
+
+const (
+	// A previous launch whose best pair has less liquidity than this is counted as rugged.
+	deployerRugLiquidityUSD = 1000.0
+	// Only the most recent launches are checked for liquidity to bound Dexscreener requests.
+	deployerMaxCheckedLaunches = 30
+	// Each analysis makes several Etherscan calls, so analyses are spaced out to stay within the
+	// free tier rate limit.
+	deployerAnalysisInterval = 2 * time.Second
+	deployerQueueSize        = 500
+)
+
+var (
+	deployerQueue     = make(chan dto.TokenAddress, deployerQueueSize)
+	deployerQueueOnce sync.Once
+)
+
+// QueueDeployerAnalysis schedules a deployer risk analysis for a newly discovered token. Tokens are
+// dropped when the queue is full; they can be analysed again later.
+func QueueDeployerAnalysis(tokenAddress dto.TokenAddress) {
+	deployerQueueOnce.Do(func() {
+		go func() {
+			ticker := time.NewTicker(deployerAnalysisInterval)
+			defer ticker.Stop()
+			for tokenAddress := range deployerQueue {
+				<-ticker.C
+				if err := AnalyzeDeployer(tokenAddress); err != nil {
+					log.Printf("Error analyzing deployer of %s: %+v", tokenAddress, err)
+				}
+			}
+		}()
+	})
+	select {
+	case deployerQueue <- tokenAddress:
+	default:
+		log.Printf("Deployer analysis queue is full, skipping %s", tokenAddress)
+	}
+}
+
+// deployerRiskScore rates a deployer from 0 (no red flags) to 100. Fresh wallets, serial launchers
+// and wallets whose earlier tokens lost their liquidity score higher.
+func deployerRiskScore(firstSeen time.Time, launchCount int, checkedLaunches int, rugCount int) int {
+	score := 0.0
+	age := time.Since(firstSeen)
+	switch {
+	case firstSeen.IsZero() || age < 24*time.Hour:
+		score += 30
+	case age < 7*24*time.Hour:
+		score += 15
+	}
+	if launchCount >= 10 {
+		score += 20
+	} else if launchCount >= 3 {
+		score += 10
+	}
+	if checkedLaunches > 0 {
+		score += 50 * float64(rugCount) / float64(checkedLaunches)
+	}
+	return int(math.Min(100, math.Round(score)))
+}
+
+// previousLaunches returns the other tokens launched by a deployer that we know of, newest first.
+func previousLaunches(tokenAddress string, deployer string, deployed []string) []string {
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	var tx = getDB()
+
+	launches := []string{}
+	seen := map[string]bool{tokenAddress: true}
+	for i := len(deployed) - 1; i >= 0; i-- {
+		if !seen[deployed[i]] {
+			seen[deployed[i]] = true
+			launches = append(launches, deployed[i])
+		}
+	}
+	tokens, err := tx.Token.FindMany(
+		db.Token.DeployerAddress.Equals(deployer),
+	).OrderBy(
+		db.Token.CreatedAt.Order(db.SortOrderDesc),
+	).Take(deployerMaxCheckedLaunches).Exec(ctx)
+	if err != nil {
+		log.Printf("Error getting deployer tokens: %+v", err)
+	}
+	for _, token := range tokens {
+		if !seen[token.Address] {
+			seen[token.Address] = true
+			launches = append(launches, token.Address)
+		}
+	}
+	if len(launches) > deployerMaxCheckedLaunches {
+		launches = launches[:deployerMaxCheckedLaunches]
+	}
+	return launches
+}
+
+// countRugs counts the launches whose liquidity is gone. Launches without any Dexscreener pair
+// are counted too.
+func countRugs(launches []string) int {
+	if len(launches) == 0 {
+		return 0
+	}
+	data, err := apis.GetDexscreenerBatchTokenData(launches)
+	if err != nil {
+		log.Printf("Error getting deployer launches liquidity: %+v", err)
+		return 0
+	}
+	rugs := 0
+	for _, launch := range launches {
+		result, ok := data[launch]
+		if !ok || result.LiquidityUSD < deployerRugLiquidityUSD {
+			rugs++
+		}
+	}
+	return rugs
+}
+
+// AnalyzeDeployer looks up who deployed a token and when, checks the deployer's history and
+// stores the resulting risk score on the token.
+func AnalyzeDeployer(tokenAddress dto.TokenAddress) error {
+	if degrade.EnrichmentDisabled() {
+		return nil
+	}
+	address := strings.ToLower(string(tokenAddress))
+	deployment, err := apis.GetContractDeployment(address)
+	if err != nil {
+		return err
+	}
+	history, err := apis.GetDeployerHistory(deployment.Deployer, deployment.Creator)
+	if err != nil {
+		return err
+	}
+	launches := previousLaunches(address, deployment.Deployer, history.DeployedContracts)
+	rugCount := countRugs(launches)
+	// The token being analysed is one of the counted launches.
+	launchCount := max(history.LaunchCount-1, len(launches))
+	score := deployerRiskScore(history.FirstSeen, launchCount, len(launches), rugCount)
+
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	var tx = getDB()
+	params := []db.TokenSetParam{
+		db.Token.DeployerAddress.Set(deployment.Deployer),
+		db.Token.DeployerRiskScore.Set(score),
+		db.Token.DeployerLaunchCount.Set(launchCount),
+		db.Token.DeployerRugCount.Set(rugCount),
+		db.Token.DeployerCheckedAt.Set(time.Now()),
+	}
+	if !history.FirstSeen.IsZero() {
+		params = append(params, db.Token.DeployerFirstSeenAt.Set(history.FirstSeen))
+	}
+	if !deployment.DeployedAt.IsZero() {
+		params = append(params,
+			db.Token.DeployedAt.Set(deployment.DeployedAt),
+			db.Token.DeployedBlock.Set(int(deployment.Block)),
+		)
+	}
+	_, err = tx.Token.FindUnique(
+		db.Token.Address.Equals(address),
+	).Update(params...).Exec(ctx)
+	invalidateTokens(address)
+	if err != nil {
+		return err
+	}
+	log.Printf("Deployer %s of %s: score=%d launches=%d rugs=%d", deployment.Deployer, address, score, launchCount, rugCount)
+	return nil
+}
+
+// SetTokenDeployedAt stores when a token was deployed, as its discovery source reported it. The
+// deployer analysis replaces it with the time of the creation transaction.
+func SetTokenDeployedAt(tokenAddress dto.TokenAddress, deployedAt time.Time) error {
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	var tx = getDB()
+	address := strings.ToLower(string(tokenAddress))
+	_, err := tx.Token.FindUnique(
+		db.Token.Address.Equals(address),
+	).Update(
+		db.Token.DeployedAt.Set(deployedAt),
+	).Exec(ctx)
+	invalidateTokens(address)
+	return err
+}
+
+// deployedAtBackfillBatch is how many tokens BackfillDeployedAt reads at once.
+const deployedAtBackfillBatch = 100
+
+// BackfillDeployedAt looks up the creation transaction of the tokens without a deployment time,
+// paced like the deployer analyses. Tokens Etherscan has no creation for are left as they are.
+func BackfillDeployedAt() {
+	var tx = getDB()
+	after := ""
+	filled, failed := 0, 0
+	for {
+		ctx, cancel := getCtx(context.Background())
+		tokens, err := tx.Token.FindMany(
+			db.Token.DeployedAt.IsNull(),
+			db.Token.Archived.Equals(false),
+			db.Token.IsFixedPrice.Equals(false),
+			db.Token.Address.Gt(after),
+		).OrderBy(
+			db.Token.Address.Order(db.SortOrderAsc),
+		).Take(deployedAtBackfillBatch).Exec(ctx)
+		cancel()
+		if err != nil {
+			log.Printf("Error getting tokens without a deployment time: %+v", err)
+			break
+		}
+		for _, token := range tokens {
+			after = token.Address
+			time.Sleep(deployerAnalysisInterval)
+			deployment, err := apis.GetContractDeployment(token.Address)
+			if err != nil || deployment.DeployedAt.IsZero() {
+				failed++
+				continue
+			}
+			if err := setTokenDeployment(token.Address, deployment); err != nil {
+				log.Printf("Error saving deployment of %s: %+v", token.Address, err)
+				failed++
+				continue
+			}
+			filled++
+		}
+		if len(tokens) < deployedAtBackfillBatch {
+			break
+		}
+	}
+	log.Printf("Deployment time backfill: %d tokens filled, %d without a known creation", filled, failed)
+}
+
+func setTokenDeployment(tokenAddress string, deployment apis.ContractDeployment) error {
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	var tx = getDB()
+	_, err := tx.Token.FindUnique(
+		db.Token.Address.Equals(tokenAddress),
+	).Update(
+		db.Token.DeployedAt.Set(deployment.DeployedAt),
+		db.Token.DeployedBlock.Set(int(deployment.Block)),
+	).Exec(ctx)
+	invalidateTokens(tokenAddress)
+	return err
+}
@@ -0,0 +1,49 @@
+package tokenRepository
+
+import (
+	"context"
+	"log"
+	db "tokendata/generated/prisma"
+	"tokendata/lib/images"
+)
+
+// Images cached per run of the image cron, so a backlog of new tokens does not flood image hosts.
+const imageCacheBatchSize = 50
+
+// CacheTokenImages downloads the images of tokens that have none cached yet and stores their
+// stable proxy URL. It does nothing while no public proxy URL is configured.
+func CacheTokenImages() {
+	if images.StableURL("") == "" {
+		return
+	}
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	var tx = getDB()
+
+	tokens, err := tx.Token.FindMany(
+		db.Token.CachedImageURL.IsNull(),
+		db.Token.ImageURL.Not(""),
+		db.Token.Archived.Equals(false),
+	).OrderBy(
+		db.Token.CreatedAt.Order(db.SortOrderDesc),
+	).Take(imageCacheBatchSize).Exec(ctx)
+	if err != nil {
+		log.Printf("Error getting tokens without cached images: %+v", err)
+		return
+	}
+	for _, token := range tokens {
+		if _, err := images.Get(token.Address, token.ImageURL, images.DefaultSize); err != nil {
+			log.Printf("Error caching image of %s: %+v", token.Address, err)
+			continue
+		}
+		_, err := tx.Token.FindUnique(
+			db.Token.Address.Equals(token.Address),
+		).Update(
+			db.Token.CachedImageURL.Set(images.StableURL(token.Address)),
+		).Exec(ctx)
+		invalidateTokens(token.Address)
+		if err != nil {
+			log.Printf("Error saving cached image URL of %s: %+v", token.Address, err)
+		}
+	}
+}
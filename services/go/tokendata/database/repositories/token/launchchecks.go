@@ -0,0 +1,51 @@
+package tokenRepository
+
+import (
+	"sync"
+	"time"
+	dto "tokendata/database/dto"
+)
+
+// launchCheckCooldown spaces out the on-demand checks of a launch. Filtered launch subscriptions
+// ask again on every recheck while a launch is held, and a launch too fresh for Dexscreener or
+// Farcaster may need a few tries.
+const launchCheckCooldown = time.Minute
+
+var launchChecks = struct {
+	sync.Mutex
+	requested map[string]time.Time
+}{requested: map[string]time.Time{}}
+
+// RequestLaunchChecks runs the risk, deployer or Farcaster check of a launch in the background
+// instead of waiting for their crons, at most once per launchCheckCooldown whatever the number of
+// subscriptions waiting for it.
+func RequestLaunchChecks(tokenAddress dto.TokenAddress, risk bool, deployer bool, farcaster bool) {
+	if !risk && !deployer && !farcaster {
+		return
+	}
+	now := time.Now()
+	launchChecks.Lock()
+	for address, requestedAt := range launchChecks.requested {
+		if now.Sub(requestedAt) >= launchCheckCooldown {
+			delete(launchChecks.requested, address)
+		}
+	}
+	if _, ok := launchChecks.requested[string(tokenAddress)]; ok {
+		launchChecks.Unlock()
+		return
+	}
+	launchChecks.requested[string(tokenAddress)] = now
+	launchChecks.Unlock()
+
+	go func() {
+		if risk {
+			CheckTokenRisk(tokenAddress)
+		}
+		if deployer {
+			QueueDeployerAnalysis(tokenAddress)
+		}
+		if farcaster {
+			CheckFarcasterMomentum(tokenAddress)
+		}
+	}()
+}
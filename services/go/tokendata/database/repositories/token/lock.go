@@ -0,0 +1,50 @@
+package tokenRepository
+
+import (
+	"strings"
+	"sync"
+	dto "tokendata/database/dto"
+)
+
+// tokenLock serializes the price updates of one token. It is registered while it is held or
+// waited for and dropped by the last holder, so tokens that churn do not leave locks behind.
+type tokenLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+var (
+	tokenLocksMu sync.Mutex
+	tokenLocks   = map[string]*tokenLock{}
+)
+
+// lockTokenUpdate locks the updates of a token and returns the function releasing it.
+func lockTokenUpdate(tokenAddress dto.TokenAddress) (unlock func()) {
+	key := strings.ToLower(string(tokenAddress))
+	tokenLocksMu.Lock()
+	lock, ok := tokenLocks[key]
+	if !ok {
+		lock = &tokenLock{}
+		tokenLocks[key] = lock
+	}
+	lock.refs++
+	tokenLocksMu.Unlock()
+
+	lock.mu.Lock()
+	return func() {
+		lock.mu.Unlock()
+		tokenLocksMu.Lock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(tokenLocks, key)
+		}
+		tokenLocksMu.Unlock()
+	}
+}
+
+// TokenUpdateLocks is the number of tokens with an update in progress or waiting for one.
+func TokenUpdateLocks() int {
+	tokenLocksMu.Lock()
+	defer tokenLocksMu.Unlock()
+	return len(tokenLocks)
+}
@@ -0,0 +1,28 @@
+package tokenRepository
+
+import (
+	"sync"
+	"testing"
+	dto "tokendata/database/dto"
+)
+
+func TestTokenUpdateLocksAreReclaimed(t *testing.T) {
+	var wg sync.WaitGroup
+	updates := 0
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer lockTokenUpdate(dto.TokenAddress(testToken))()
+			updates++
+		}()
+	}
+	wg.Wait()
+
+	if updates != 50 {
+		t.Errorf("updates = %d, want 50", updates)
+	}
+	if n := TokenUpdateLocks(); n != 0 {
+		t.Errorf("token update locks = %d, want 0", n)
+	}
+}
@@ -0,0 +1,200 @@
+package tokenRepository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+	dto "tokendata/database/dto"
+	"tokendata/env"
+	db "tokendata/generated/prisma"
+	"tokendata/lib/apis"
+	dex_dto "tokendata/lib/dex/dto"
+	"tokendata/lib/hub"
+	wsDexManager "tokendata/lib/ws/dex"
+)
+
+const (
+	defaultPoolMigrationMinLiquidityUSD = 1000
+	// Tokens revalidated per run; runs walk through the watched tokens in batches.
+	poolMigrationBatchSize = 300
+	// Dexscreener accepts up to 30 addresses per batch request.
+	poolMigrationChunkSize = 30
+)
+
+// poolMigrationOffset is where the next run of DetectPoolMigrations continues.
+var (
+	poolMigrationMu     sync.Mutex
+	poolMigrationOffset int
+)
+
+type tokenPoolMigratedMessage struct {
+	TokenAddress   string `json:"tokenAddress"`
+	OldPoolAddress string `json:"oldPoolAddress"`
+	PoolAddress    string `json:"poolAddress"`
+	PairAddress    string `json:"pairAddress"`
+	IsV4           bool   `json:"isV4"`
+	Pinned         bool   `json:"pinned"`
+	MigratedAt     int64  `json:"migratedAt"`
+}
+
+// DetectPoolMigrations compares the stored pool of watched tokens with the best pair Dexscreener
+// lists for them. When liquidity moved to another Uniswap pool, e.g. a Clanker token migrating
+// from V3 to V4, the token is switched to the new pool and its watcher restarted. Pools pinned
+// with SetTokenPool are left alone.
+func DetectPoolMigrations() {
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	var tx = getDB()
+	minLiquidityUSD := env.POOL_MIGRATION_MIN_LIQUIDITY_USD.GetEnvAsFloatOrDefault(defaultPoolMigrationMinLiquidityUSD)
+
+	poolMigrationMu.Lock()
+	defer poolMigrationMu.Unlock()
+	tokens, err := tx.Token.FindMany(
+		db.Token.WatchEnabled.Equals(true),
+		db.Token.Delisted.Equals(false),
+		db.Token.Archived.Equals(false),
+		db.Token.IsFixedPrice.Equals(false),
+		db.Token.PoolAddress.Not(""),
+		db.Token.PoolPinned.Equals(false),
+	).OrderBy(
+		db.Token.CreatedAt.Order(db.SortOrderAsc),
+	).Skip(poolMigrationOffset).Take(poolMigrationBatchSize).Exec(ctx)
+	if err != nil {
+		log.Printf("Error getting tokens for pool revalidation: %+v", err)
+		return
+	}
+	if len(tokens) < poolMigrationBatchSize {
+		poolMigrationOffset = 0
+	} else {
+		poolMigrationOffset += len(tokens)
+	}
+
+	migrated := 0
+	for i := 0; i < len(tokens); i += poolMigrationChunkSize {
+		chunk := tokens[i:min(i+poolMigrationChunkSize, len(tokens))]
+		addresses := make([]string, 0, len(chunk))
+		for _, token := range chunk {
+			addresses = append(addresses, token.Address)
+		}
+		data, err := apis.GetDexscreenerBatchTokenData(addresses)
+		if err != nil {
+			log.Printf("Error getting best pools from Dexscreener: %+v", err)
+			continue
+		}
+		for j := range chunk {
+			token := &chunk[j]
+			best, ok := data[strings.ToLower(token.Address)]
+			if !ok || !isPoolMigration(token, best, minLiquidityUSD) {
+				continue
+			}
+			if migrateTokenPool(token, best.Pool) {
+				migrated++
+			}
+		}
+	}
+	if migrated > 0 {
+		log.Printf("Pool revalidation: %d of %d tokens migrated to a new pool", migrated, len(tokens))
+	}
+}
+
+// isPoolMigration reports whether the best pair of a token is a liquid Uniswap V3 or V4 pool
+// other than the stored one. Pools of other dexes and versions cannot be watched, so the token
+// keeps its current pool.
+func isPoolMigration(token *db.TokenModel, best apis.DexscreenerBatchResult, minLiquidityUSD float64) bool {
+	poolAddress, _ := token.PoolAddress()
+	newPoolAddress := strings.ToLower(best.Pool.Address)
+	if newPoolAddress == "" || newPoolAddress == strings.ToLower(poolAddress) || best.Pool.PairAddress == "" {
+		return false
+	}
+	if _, ok := uniswapPoolType(best.Pool); !ok {
+		return false
+	}
+	return best.LiquidityUSD >= minLiquidityUSD
+}
+
+// uniswapPoolType returns the pool type of a Uniswap pair from its Dexscreener version label.
+// Dexscreener lists V2, V3 and V4 pairs under the same dex id, so pairs without a v3 or v4 label
+// are not watchable.
+func uniswapPoolType(pool dex_dto.PoolInfo) (db.DexPoolType, bool) {
+	if !strings.Contains(strings.ToLower(pool.DexID), "uniswap") {
+		return "", false
+	}
+	for _, label := range pool.Labels {
+		switch strings.ToLower(label) {
+		case "v3":
+			return db.DexPoolTypeUniswapV3, true
+		case "v4":
+			return db.DexPoolTypeUniswapV4, true
+		}
+	}
+	return "", false
+}
+
+// migrateTokenPool points a token at the new pool Dexscreener lists for it.
+func migrateTokenPool(token *db.TokenModel, pool dex_dto.PoolInfo) bool {
+	poolType, ok := uniswapPoolType(pool)
+	if !ok {
+		return false
+	}
+	_, err := switchTokenPool(token, strings.ToLower(pool.Address), strings.ToLower(pool.PairAddress), poolType, false, "pool_migration")
+	return err == nil
+}
+
+// switchTokenPool points a token at a pool, stops the watcher of the old one and watches the
+// new one. A pair token that is not tracked yet is added with the reason of the token, or
+// pairReason when it has none.
+func switchTokenPool(token *db.TokenModel, poolAddress string, pairAddress string, poolType db.DexPoolType, pinned bool, pairReason string) (*db.TokenModel, error) {
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	var tx = getDB()
+	oldPoolAddress, _ := token.PoolAddress()
+
+	// The watcher prices the token in its pair token, which has to be known.
+	if getToken(dto.TokenAddress(pairAddress)) == nil {
+		reason, ok := token.Reason()
+		if !ok || reason == "" {
+			reason = pairReason
+		}
+		pairResponse := AddToTokenList(dto.TokenAddress(pairAddress), nil, nil, nil, nil, nil, nil, &reason, nil)
+		if !pairResponse.Success {
+			log.Printf("Error adding pair token %s of pool %s: %s", pairAddress, poolAddress, pairResponse.Message)
+			return nil, fmt.Errorf("adding pair token %s: %s", pairAddress, pairResponse.Message)
+		}
+	}
+
+	updated, err := tx.Token.FindUnique(
+		db.Token.Address.Equals(token.Address),
+	).Update(
+		db.Token.PoolAddress.Set(poolAddress),
+		db.Token.PairAddress.Set(strings.ToLower(pairAddress)),
+		db.Token.PoolType.Set(poolType),
+		db.Token.PoolABI.Set(""),
+		db.Token.PoolPinned.Set(pinned),
+	).Exec(ctx)
+	invalidateTokens(token.Address)
+	if err != nil {
+		log.Printf("Error updating pool of %s: %+v", token.Address, err)
+		return nil, err
+	}
+	log.Printf("Pool of %s (%s) moved from %s to %s (%s, pinned: %t)", updated.Symbol, updated.Address, oldPoolAddress, poolAddress, poolType, pinned)
+
+	wsDexManager.GetManager().StopWatching(updated.Address)
+	if err := StartWatchingForPool(updated); err != nil {
+		log.Printf("Error starting watching for new pool: %+v", err)
+	}
+	go SaveTokenPrice(dto.TokenAddress(updated.Address))
+	go CheckTokenTrading(dto.TokenAddress(updated.Address))
+	hub.PublishToken(updated.Address, "pool_migrated", tokenPoolMigratedMessage{
+		TokenAddress:   updated.Address,
+		OldPoolAddress: oldPoolAddress,
+		PoolAddress:    poolAddress,
+		PairAddress:    pairAddress,
+		IsV4:           poolType == db.DexPoolTypeUniswapV4,
+		Pinned:         pinned,
+		MigratedAt:     time.Now().UnixMilli(),
+	})
+	return updated, nil
+}
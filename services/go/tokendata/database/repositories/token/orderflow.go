@@ -0,0 +1,107 @@
+package tokenRepository
+
+import (
+	"strings"
+	"sync"
+	"time"
+	protoCommon "tokendata/proto/common"
+	proto "tokendata/proto/token"
+)
+
+const (
+	orderflowShortWindow = 5 * time.Minute
+	orderflowLongWindow  = time.Hour
+)
+
+// orderflowBook keeps the last hour of trades of every token that traded to count its buys and
+// sells. It only holds what tokendata decoded since it started.
+type orderflowBook struct {
+	mu        sync.Mutex
+	trades    map[string][]tapeTrade
+	lastSweep time.Time
+}
+
+func newOrderflowBook() *orderflowBook {
+	return &orderflowBook{trades: map[string][]tapeTrade{}}
+}
+
+var orderflow = newOrderflowBook()
+
+// recordOrderflowTrade counts a confirmed trade of trader towards the orderflow of its token.
+func recordOrderflowTrade(trade *proto.TokenTrade, trader string) {
+	orderflow.record(trade, trader, time.Now())
+}
+
+// TokenOrderflow returns the 5 minute and 1 hour orderflow of a token.
+func TokenOrderflow(tokenAddress string) *protoCommon.TokenOrderflow {
+	return orderflow.stats(strings.ToLower(tokenAddress), time.Now())
+}
+
+func (b *orderflowBook) record(trade *proto.TokenTrade, trader string, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	token := trade.TokenAddress
+	b.trades[token] = append(b.prune(token, now), tapeTrade{at: now, maker: trader, amountUSD: trade.AmountUsd, buy: trade.Side == proto.TradeSide_TRADE_BUY})
+
+	// Tokens that stopped trading are dropped once their trades left the window.
+	if now.Sub(b.lastSweep) >= orderflowLongWindow {
+		for token := range b.trades {
+			b.prune(token, now)
+		}
+		b.lastSweep = now
+	}
+}
+
+// prune drops the trades of a token that left the window, and the token once it has none left.
+func (b *orderflowBook) prune(token string, now time.Time) []tapeTrade {
+	trades := b.trades[token]
+	start := 0
+	for start < len(trades) && now.Sub(trades[start].at) > orderflowLongWindow {
+		start++
+	}
+	trades = trades[start:]
+	if len(trades) == 0 {
+		delete(b.trades, token)
+		return nil
+	}
+	b.trades[token] = trades
+	return trades
+}
+
+func (b *orderflowBook) stats(token string, now time.Time) *protoCommon.TokenOrderflow {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	trades := b.prune(token, now)
+	return &protoCommon.TokenOrderflow{
+		M5: orderflowOf(trades, now.Add(-orderflowShortWindow)),
+		H1: orderflowOf(trades, now.Add(-orderflowLongWindow)),
+	}
+}
+
+// orderflowOf counts the trades since a time. Trades without a known trader count towards the
+// volume but not the buyers and sellers.
+func orderflowOf(trades []tapeTrade, since time.Time) *protoCommon.Orderflow {
+	flow := &protoCommon.Orderflow{}
+	buyers, sellers := map[string]bool{}, map[string]bool{}
+	for _, trade := range trades {
+		if trade.at.Before(since) {
+			continue
+		}
+		known := trade.maker != "" && trade.maker != zeroAddress
+		if trade.buy {
+			flow.Buys++
+			flow.BuyVolume += trade.amountUSD
+			if known {
+				buyers[trade.maker] = true
+			}
+		} else {
+			flow.Sells++
+			flow.SellVolume += trade.amountUSD
+			if known {
+				sellers[trade.maker] = true
+			}
+		}
+	}
+	flow.Buyers, flow.Sellers = int32(len(buyers)), int32(len(sellers))
+	return flow
+}
@@ -0,0 +1,66 @@
+package tokenRepository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	proto "tokendata/proto/token"
+)
+
+func TestOrderflowBook(t *testing.T) {
+	book := newOrderflowBook()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	trade := func(amountUSD float64, side proto.TradeSide) *proto.TokenTrade {
+		// The maker of routed swaps is the router; trades are counted under their trader.
+		return &proto.TokenTrade{TokenAddress: testToken, Maker: "0xrouter", AmountUsd: amountUSD, Side: side}
+	}
+
+	book.record(trade(9000, proto.TradeSide_TRADE_BUY), "0xold", now.Add(-2*time.Hour))
+	book.record(trade(100, proto.TradeSide_TRADE_BUY), "0xa", now.Add(-30*time.Minute))
+	book.record(trade(50, proto.TradeSide_TRADE_SELL), "0xb", now.Add(-20*time.Minute))
+	book.record(trade(200, proto.TradeSide_TRADE_BUY), "0xa", now.Add(-time.Minute))
+	book.record(trade(300, proto.TradeSide_TRADE_BUY), "0xc", now)
+	book.record(trade(25, proto.TradeSide_TRADE_SELL), "", now)
+
+	stats := book.stats(testToken, now)
+	if m5 := stats.M5; m5.Buys != 2 || m5.Sells != 1 || m5.Buyers != 2 || m5.Sellers != 0 || m5.BuyVolume != 500 || m5.SellVolume != 25 {
+		t.Errorf("m5 = %+v", m5)
+	}
+	if h1 := stats.H1; h1.Buys != 3 || h1.Sells != 2 || h1.Buyers != 2 || h1.Sellers != 1 || h1.BuyVolume != 600 || h1.SellVolume != 75 {
+		t.Errorf("h1 = %+v", h1)
+	}
+
+	if stats := book.stats(testToken, now.Add(2*time.Hour)); stats.H1.Buys != 0 || len(book.trades) != 0 {
+		t.Errorf("trades left after the window: %+v, %d tokens", stats.H1, len(book.trades))
+	}
+}
+
+func TestTxSenderCache(t *testing.T) {
+	fetches := 0
+	cache := newTxSenderCache(func(ctx context.Context, txHash string) (string, error) {
+		fetches++
+		if txHash == "0xfail" {
+			return "", errors.New("not found")
+		}
+		return "0xsender", nil
+	})
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for range 3 {
+		if sender, err := cache.get(context.Background(), "0xtx", now); err != nil || sender != "0xsender" {
+			t.Fatalf("get = %q, %v", sender, err)
+		}
+	}
+	if fetches != 1 {
+		t.Errorf("fetched %d times, want once for the swaps of one transaction", fetches)
+	}
+	if _, err := cache.get(context.Background(), "0xfail", now); err == nil {
+		t.Error("a failed fetch returned a sender")
+	}
+
+	cache.get(context.Background(), "0xother", now.Add(txSenderTTL))
+	if _, ok := cache.senders["0xtx"]; ok || fetches != 3 {
+		t.Errorf("expired sender still cached after %d fetches", fetches)
+	}
+}
@@ -0,0 +1,277 @@
+package tokenRepository
+
+import (
+	"context"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	dto "tokendata/database/dto"
+	db "tokendata/generated/prisma"
+	"tokendata/lib/anchors"
+	dex_dto "tokendata/lib/dex/dto"
+	wsDexManager "tokendata/lib/ws/dex"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// pairTokenReason tags tokens tracked only to price the tokens paired with them. It is not in
+	// the retention policy, so they stay while their pools are watched.
+	pairTokenReason = "pair_token"
+	// maxPairDepth is how many pools a pair may be away from a tracked token, e.g. 2 for
+	// TOKEN→MEME→WETH when MEME is not tracked.
+	maxPairDepth = 3
+	// pairResolveCooldown is how long a pair that could not be resolved is left alone before its
+	// swaps try again.
+	pairResolveCooldown = 10 * time.Minute
+)
+
+// pairResolver tracks the pair tokens swaps cannot be priced without. A pair token is priced by
+// its own best pool, whose pair is resolved the same way first.
+type pairResolver struct {
+	tracked  func(address string) bool
+	priced   func(address string) bool
+	bestPool func(address string) (dex_dto.TokenDataAsString, dex_dto.PoolInfo)
+	add      func(address string, data dex_dto.TokenDataAsString, pool dex_dto.PoolInfo) bool
+
+	mu       sync.Mutex
+	attempts map[string]time.Time
+}
+
+func newPairResolver() *pairResolver {
+	return &pairResolver{
+		tracked: func(address string) bool {
+			token := getToken(dto.TokenAddress(address))
+			return token != nil && !token.Archived
+		},
+		priced: func(address string) bool {
+			token := getToken(dto.TokenAddress(address))
+			if token == nil || token.Archived {
+				return false
+			}
+			price, err := decimal.NewFromString(token.Price)
+			return err == nil && price.IsPositive()
+		},
+		bestPool: func(address string) (dex_dto.TokenDataAsString, dex_dto.PoolInfo) {
+			return getTokenDataAndBestPoolWithFallback(dto.TokenAddress(address))
+		},
+		add: func(address string, data dex_dto.TokenDataAsString, pool dex_dto.PoolInfo) bool {
+			// The metadata of the pool lookup is passed on, as the token may not be listed where
+			// AddToTokenList looks it up.
+			reason := pairTokenReason
+			var name, circulatedSupply, symbol, image, price *string
+			if data.Name != "" {
+				name, circulatedSupply, symbol, image = &data.Name, &data.CirculatedSupply, &data.Symbol, &data.ImageURL
+			}
+			if data.Price != "" && data.Price != "0" {
+				price = &data.Price
+			}
+			return AddToTokenList(dto.TokenAddress(address), name, circulatedSupply, symbol, image, &pool.Address, &pool.PairAddress, &reason, price).Success
+		},
+		attempts: map[string]time.Time{},
+	}
+}
+
+var (
+	pairResolverOnce sync.Once
+	pairs            *pairResolver
+)
+
+func getPairResolver() *pairResolver {
+	pairResolverOnce.Do(func() {
+		pairs = newPairResolver()
+	})
+	return pairs
+}
+
+// resolvePairToken starts tracking the pair token of a swap that could not be priced, so the
+// next swaps of the pool are.
+func resolvePairToken(pairAddress string) {
+	if getPairResolver().resolve(pairAddress, maxPairDepth, time.Now()) {
+		log.Printf("Tracking pair token %s", pairAddress)
+	}
+}
+
+// claim reports whether a pair may be resolved now. A pair is tried once per cooldown, which
+// also stops a resolution from following a cycle of pools back to a pair it is resolving.
+func (r *pairResolver) claim(address string, now time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if attempt, ok := r.attempts[address]; ok && now.Sub(attempt) < pairResolveCooldown {
+		return false
+	}
+	r.attempts[address] = now
+	return true
+}
+
+// resolve tracks a token and, first, the pair of its best pool when that is not tracked either,
+// following at most depth pools. It reports whether the token is tracked.
+func (r *pairResolver) resolve(address string, depth int, now time.Time) bool {
+	address = strings.ToLower(address)
+	if r.tracked(address) {
+		return true
+	}
+	if depth <= 0 || !r.claim(address, now) {
+		return false
+	}
+	data, pool := r.bestPool(address)
+	if pool.Address == "" || pool.PairAddress == "" {
+		log.Printf("No pool found to price pair token %s", address)
+		return false
+	}
+	if !r.resolve(pool.PairAddress, depth-1, now) {
+		log.Printf("Could not price pair token %s through %s", address, pool.PairAddress)
+		return false
+	}
+	return r.add(address, data, pool)
+}
+
+// unpricedPairs resolves pairs and returns the ones that are not tracked or have no price.
+func (r *pairResolver) unpricedPairs(pairs []string, now time.Time) map[string]bool {
+	unpriced := map[string]bool{}
+	for _, pair := range pairs {
+		// Swaps against stable-quoted pairs are priced without the pair token.
+		if _, ok := anchors.StableQuote(pair); ok {
+			continue
+		}
+		if !r.resolve(pair, maxPairDepth, now) || !r.priced(pair) {
+			unpriced[pair] = true
+		}
+	}
+	return unpriced
+}
+
+// ReconcilePairTokens tracks the pairs of the watched pools that are not tracked yet, marks
+// tracked pairs without a reason as pair tokens and flags the tokens whose pair cannot be
+// priced. Flagged tokens are not watched; the flag is cleared once their pair is priced.
+func ReconcilePairTokens() {
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	tokens, err := getDB().Token.FindMany(
+		db.Token.PairAddress.Not(""),
+		db.Token.Archived.Equals(false),
+		db.Token.IsFixedPrice.Equals(false),
+	).Exec(ctx)
+	if err != nil {
+		log.Printf("Error getting paired tokens: %+v", err)
+		return
+	}
+	pairs := []string{}
+	for _, token := range tokens {
+		pair, _ := token.PairAddress()
+		pair = strings.ToLower(pair)
+		if pair != "" && !slices.Contains(pairs, pair) {
+			pairs = append(pairs, pair)
+		}
+	}
+
+	unpriced := getPairResolver().unpricedPairs(pairs, time.Now())
+	markedCount := markPairTokens(ctx, pairs)
+
+	flagged, cleared := []string{}, []string{}
+	for _, token := range tokens {
+		pair, _ := token.PairAddress()
+		if unpriced[strings.ToLower(pair)] != token.PairUnpriced {
+			if token.PairUnpriced {
+				cleared = append(cleared, token.Address)
+			} else {
+				flagged = append(flagged, token.Address)
+			}
+		}
+	}
+	setPairUnpriced(flagged, true)
+	setPairUnpriced(cleared, false)
+	log.Printf("Reconciled %d pairs: %d unpriceable, %d marked as pair tokens, %d tokens flagged, %d cleared", len(pairs), len(unpriced), markedCount, len(flagged), len(cleared))
+}
+
+// setPairUnpriced flags or unflags tokens whose pair cannot be priced. Flagged tokens stop
+// being watched; unflagged ones are watched again unless something else keeps them unwatched.
+// markPairTokens gives the pairs that have no reason the pair token reason and returns how many
+// it marked.
+func markPairTokens(ctx context.Context, pairs []string) int {
+	unmarked, err := getDB().Token.FindMany(
+		db.Token.Address.In(pairs),
+		db.Token.Or(db.Token.Reason.IsNull(), db.Token.Reason.Equals("")),
+	).Exec(ctx)
+	if err != nil {
+		log.Printf("Error getting unmarked pair tokens: %+v", err)
+		return 0
+	}
+	addresses := make([]string, 0, len(unmarked))
+	for _, token := range unmarked {
+		addresses = append(addresses, token.Address)
+	}
+	if len(addresses) == 0 {
+		return 0
+	}
+	marked, err := getDB().Token.FindMany(
+		db.Token.Address.In(addresses),
+		db.Token.Or(db.Token.Reason.IsNull(), db.Token.Reason.Equals("")),
+	).Update(
+		db.Token.Reason.Set(pairTokenReason),
+	).Exec(ctx)
+	invalidateTokens(addresses...)
+	if err != nil {
+		log.Printf("Error marking pair tokens: %+v", err)
+		return 0
+	}
+	return marked.Count
+}
+
+func setPairUnpriced(addresses []string, unpriced bool) {
+	if len(addresses) == 0 {
+		return
+	}
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	lower := lowerAddresses(addresses)
+	_, err := getDB().Token.FindMany(
+		db.Token.Address.In(lower),
+	).Update(
+		db.Token.PairUnpriced.Set(unpriced),
+	).Exec(ctx)
+	invalidateTokens(lower...)
+	if err != nil {
+		log.Printf("Error flagging tokens with an unpriceable pair: %+v", err)
+		return
+	}
+
+	manager := wsDexManager.GetManager()
+	for _, address := range lower {
+		if unpriced {
+			manager.StopWatching(address)
+			continue
+		}
+		token := getToken(dto.TokenAddress(address))
+		if token == nil || !token.WatchEnabled || token.Archived {
+			continue
+		}
+		if err := StartWatchingForPool(token); err != nil {
+			log.Printf("Error watching %s again after its pair was priced: %+v", address, err)
+		}
+	}
+}
+
+// PairAddresses returns the pairs of the tracked tokens, which are kept whatever lists they are
+// on since the tokens paired with them are priced through them.
+func PairAddresses() map[string]bool {
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	pairs := map[string]bool{}
+	tokens, err := getDB().Token.FindMany(
+		db.Token.PairAddress.Not(""),
+		db.Token.Archived.Equals(false),
+	).Select(db.Token.PairAddress.Field()).Exec(ctx)
+	if err != nil {
+		log.Printf("Error getting pair addresses: %+v", err)
+		return pairs
+	}
+	for _, token := range tokens {
+		if pair, ok := token.PairAddress(); ok && pair != "" {
+			pairs[strings.ToLower(pair)] = true
+		}
+	}
+	return pairs
+}
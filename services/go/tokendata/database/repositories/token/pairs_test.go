@@ -0,0 +1,83 @@
+package tokenRepository
+
+import (
+	"samterminal/pkg/chain"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+	dex_dto "tokendata/lib/dex/dto"
+)
+
+func TestPairResolver(t *testing.T) {
+	tracked := map[string]bool{"0xweth": true}
+	pools := map[string]dex_dto.PoolInfo{
+		"0xtoken": {Address: "0xpool1", PairAddress: "0xmeme"},
+		"0xmeme":  {Address: "0xpool2", PairAddress: "0xweth"},
+		"0xa":     {Address: "0xpool3", PairAddress: "0xb"},
+		"0xb":     {Address: "0xpool4", PairAddress: "0xa"},
+	}
+	added := []string{}
+	resolver := newPairResolver()
+	resolver.tracked = func(address string) bool { return tracked[address] }
+	resolver.bestPool = func(address string) (dex_dto.TokenDataAsString, dex_dto.PoolInfo) {
+		return dex_dto.TokenDataAsString{}, pools[address]
+	}
+	resolver.add = func(address string, data dex_dto.TokenDataAsString, pool dex_dto.PoolInfo) bool {
+		added = append(added, address)
+		tracked[address] = true
+		return true
+	}
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	if !resolver.resolve("0xTOKEN", maxPairDepth, now) {
+		t.Fatal("chained pair was not resolved")
+	}
+	if !slices.Equal(added, []string{"0xmeme", "0xtoken"}) {
+		t.Errorf("added = %v, want the intermediate pair first", added)
+	}
+
+	added = nil
+	if resolver.resolve("0xa", maxPairDepth, now) || len(added) != 0 {
+		t.Errorf("cycle of pools resolved, added %v", added)
+	}
+	if resolver.resolve("0xa", maxPairDepth, now.Add(time.Minute)) {
+		t.Error("pair retried during its cooldown")
+	}
+
+	delete(tracked, "0xmeme")
+	delete(tracked, "0xtoken")
+	fresh := newPairResolver()
+	fresh.tracked, fresh.bestPool, fresh.add = resolver.tracked, resolver.bestPool, resolver.add
+	if fresh.resolve("0xtoken", 1, now) {
+		t.Error("pair two pools away resolved with a depth of 1")
+	}
+}
+
+func TestUnpricedPairs(t *testing.T) {
+	tracked := map[string]bool{"0xweth": true, "0xdead": true}
+	prices := map[string]bool{"0xweth": true}
+	pools := map[string]dex_dto.PoolInfo{
+		"0xmeme": {Address: "0xpool1", PairAddress: "0xweth"},
+	}
+	resolver := newPairResolver()
+	resolver.tracked = func(address string) bool { return tracked[address] }
+	resolver.priced = func(address string) bool { return prices[address] }
+	resolver.bestPool = func(address string) (dex_dto.TokenDataAsString, dex_dto.PoolInfo) {
+		return dex_dto.TokenDataAsString{Name: "Meme", Price: "0.5"}, pools[address]
+	}
+	resolver.add = func(address string, data dex_dto.TokenDataAsString, pool dex_dto.PoolInfo) bool {
+		tracked[address] = true
+		prices[address] = data.Price != ""
+		return true
+	}
+
+	usdc := strings.ToLower(chain.Get().USDC)
+	unpriced := resolver.unpricedPairs([]string{"0xweth", "0xmeme", "0xdead", "0xgone", usdc}, time.Now())
+	if len(unpriced) != 2 || !unpriced["0xdead"] || !unpriced["0xgone"] {
+		t.Errorf("unpriced = %v, want the zero priced and the unresolvable pair but not USDC", unpriced)
+	}
+	if !tracked["0xmeme"] {
+		t.Error("untracked pair with a pool was not added")
+	}
+}
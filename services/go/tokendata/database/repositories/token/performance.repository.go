@@ -0,0 +1,38 @@
+package tokenRepository
+
+import (
+	"context"
+	"log"
+	"tokendata/database/store"
+	db "tokendata/generated/prisma"
+
+	"github.com/shopspring/decimal"
+)
+
+// trackPricePerformance stores the price multiple of a token against its initial price and moves
+// its all-time high when the current price exceeds it, which the store compares against the stored
+// high. Tokens without an initial price are skipped.
+func trackPricePerformance(token *db.TokenModel) {
+	initialPrice, ok := token.InitialPrice()
+	if !ok {
+		return
+	}
+	initial, err := decimal.NewFromString(initialPrice)
+	if err != nil || !initial.IsPositive() {
+		return
+	}
+	price, err := decimal.NewFromString(token.Price)
+	if err != nil || !price.IsPositive() {
+		return
+	}
+	multiple, _ := price.Div(initial).Float64()
+
+	performance := store.PricePerformance{Price: token.Price, Multiple: multiple}
+
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	if err := tokenStore.UpdatePerformance(ctx, token.Address, performance); err != nil {
+		log.Printf("Error updating price performance: %+v", err)
+	}
+	invalidateTokens(token.Address)
+}
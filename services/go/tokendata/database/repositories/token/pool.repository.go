@@ -0,0 +1,210 @@
+package tokenRepository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+	"strings"
+	dto "tokendata/database/dto"
+	db "tokendata/generated/prisma"
+	"tokendata/lib/anchors"
+	"tokendata/lib/apis"
+	wsDexManager "tokendata/lib/ws/dex"
+	proto "tokendata/proto/token"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// v4PoolIDLength is the length of a hex encoded Uniswap V4 pool id (bytes32).
+const v4PoolIDLength = 66
+
+type PoolTokens struct {
+	TokenAddress string
+	PairAddress  string
+	IsV4         bool
+}
+
+// ResolvePoolTokens resolves the base (token) and quote (pair) tokens of a pool. V3 pools are read
+// on-chain; V4 pool ids are not addressable contracts, so they are resolved through Dexscreener.
+func ResolvePoolTokens(poolAddress string, isV4 bool) (PoolTokens, error) {
+	poolAddress = strings.ToLower(strings.TrimSpace(poolAddress))
+	if len(poolAddress) == v4PoolIDLength {
+		isV4 = true
+	}
+
+	if isV4 {
+		pool, err := apis.GetDexscreenerPoolTokens(poolAddress)
+		if err != nil {
+			return PoolTokens{}, err
+		}
+		return PoolTokens{TokenAddress: pool.BaseToken, PairAddress: pool.QuoteToken, IsV4: true}, nil
+	}
+
+	token0, token1, err := wsDexManager.ReadPoolTokens(poolAddress)
+	if err != nil {
+		return PoolTokens{}, err
+	}
+	token0 = strings.ToLower(token0)
+	token1 = strings.ToLower(token1)
+
+	// Anchors are preferred as the quote side of a pool, in priority order.
+	for _, quote := range anchors.Addresses() {
+		if token0 == quote {
+			return PoolTokens{TokenAddress: token1, PairAddress: token0}, nil
+		}
+		if token1 == quote {
+			return PoolTokens{TokenAddress: token0, PairAddress: token1}, nil
+		}
+	}
+
+	// Neither side is a known quote token; ask Dexscreener which side it treats as base.
+	pool, err := apis.GetDexscreenerPoolTokens(poolAddress)
+	if err == nil && slices.Contains([]string{token0, token1}, pool.BaseToken) {
+		return PoolTokens{TokenAddress: pool.BaseToken, PairAddress: pool.QuoteToken}, nil
+	}
+	return PoolTokens{TokenAddress: token0, PairAddress: token1}, nil
+}
+
+// AddPoolToTokenList resolves the tokens of a pool, makes sure both of them exist and starts
+// watching the pool for the base token.
+func AddPoolToTokenList(poolAddress string, isV4 bool, reason *string) (*dto.ResponseType, PoolTokens) {
+	var response = &dto.ResponseType{}
+	if reason == nil || *reason == "" {
+		response.Success = false
+		response.Message = "Reason is required"
+		response.AddingType = proto.TokenAddingType_ADD_ERROR.Enum()
+		return response, PoolTokens{}
+	}
+
+	pool, err := ResolvePoolTokens(poolAddress, isV4)
+	if err != nil || pool.TokenAddress == "" || pool.PairAddress == "" {
+		if err == nil {
+			err = errors.New("pool tokens could not be resolved")
+		}
+		log.Printf("Error resolving pool tokens: pool=%s err=%v", poolAddress, err)
+		response.Success = false
+		response.Message = "Could not resolve pool tokens"
+		response.AddingType = proto.TokenAddingType_ADD_ERROR.Enum()
+		return response, pool
+	}
+
+	if getToken(dto.TokenAddress(pool.PairAddress)) == nil {
+		pairResponse := AddToTokenList(dto.TokenAddress(pool.PairAddress), nil, nil, nil, nil, nil, nil, reason, nil)
+		if !pairResponse.Success {
+			log.Printf("Error adding pair token for pool %s: %s", poolAddress, pairResponse.Message)
+		}
+	}
+
+	if getToken(dto.TokenAddress(pool.TokenAddress)) != nil {
+		incrementUsingend(dto.TokenAddress(pool.TokenAddress))
+		response.Success = true
+		response.Message = "Token already in list. Increment using ends"
+		response.AddingType = proto.TokenAddingType_DUPLICATE.Enum()
+		return response, pool
+	}
+
+	tokenData, _ := getTokenDataAsStringWithFallback(dto.TokenAddress(pool.TokenAddress))
+	if tokenData.Name == "" {
+		response.Success = false
+		response.Message = "Token name is required"
+		response.AddingType = proto.TokenAddingType_ADD_ERROR.Enum()
+		return response, pool
+	}
+	if tokenData.ImageURL == "" {
+		tokenData.ImageURL = apis.GetTokenImageURL(pool.TokenAddress)
+	}
+
+	poolType := db.DexPoolTypeUniswapV3
+	if pool.IsV4 {
+		poolType = db.DexPoolTypeUniswapV4
+	}
+	resolvedPoolAddress := strings.ToLower(strings.TrimSpace(poolAddress))
+	token := GetOrCreateToken(dto.TokenAddress(pool.TokenAddress), &tokenData.Name, &tokenData.Supply, &tokenData.CirculatedSupply, &tokenData.Symbol, &tokenData.ImageURL, &tokenData.Price, &tokenData.Volume24H, &poolType, &resolvedPoolAddress, &pool.PairAddress, reason, nil, false)
+	if token == nil {
+		response.Success = false
+		response.Message = "Could not add token to list"
+		response.AddingType = proto.TokenAddingType_ADD_ERROR.Enum()
+		return response, pool
+	}
+
+	if err := StartWatchingForPool(token); err != nil {
+		log.Printf("Error starting watching for pool: %+v", err)
+		response.Success = false
+		response.Message = "Could not add token to list"
+		response.AddingType = proto.TokenAddingType_ADD_ERROR.Enum()
+		return response, pool
+	}
+
+	response.Success = true
+	response.Message = "Added token to list"
+	response.AddingType = proto.TokenAddingType_FIRST_TIME.Enum()
+	return response, pool
+}
+
+var (
+	ErrInvalidPool      = errors.New("invalid pool address")
+	ErrPoolTokensDiffer = errors.New("pool does not trade the token")
+)
+
+// SetTokenPool pins the pool a token is priced from and restarts its watcher on it. The tokens
+// of the pool are resolved first: one of them has to be the token and the other the pair, which
+// defaults to it. Pinned pools are left alone by DetectPoolMigrations until UnpinTokenPool.
+func SetTokenPool(tokenAddress string, poolAddress string, isV4 bool, pairAddress string) (*db.TokenModel, error) {
+	tokenAddress = strings.ToLower(strings.TrimSpace(tokenAddress))
+	poolAddress = strings.ToLower(strings.TrimSpace(poolAddress))
+	pairAddress = strings.ToLower(strings.TrimSpace(pairAddress))
+	if len(poolAddress) == v4PoolIDLength {
+		isV4 = true
+	}
+	if isV4 && len(poolAddress) != v4PoolIDLength || !isV4 && !common.IsHexAddress(poolAddress) {
+		return nil, ErrInvalidPool
+	}
+	token := getToken(dto.TokenAddress(tokenAddress))
+	if token == nil {
+		return nil, ErrTokenNotFound
+	}
+
+	pool, err := ResolvePoolTokens(poolAddress, isV4)
+	if err != nil {
+		return nil, err
+	}
+	sides := []string{pool.TokenAddress, pool.PairAddress}
+	if !slices.Contains(sides, tokenAddress) {
+		return nil, ErrPoolTokensDiffer
+	}
+	other := pool.PairAddress
+	if other == tokenAddress {
+		other = pool.TokenAddress
+	}
+	if pairAddress == "" {
+		pairAddress = other
+	}
+	if pairAddress != other {
+		return nil, ErrPoolTokensDiffer
+	}
+
+	poolType := db.DexPoolTypeUniswapV3
+	if isV4 {
+		poolType = db.DexPoolTypeUniswapV4
+	}
+	return switchTokenPool(token, poolAddress, pairAddress, poolType, true, "pool_pin")
+}
+
+// UnpinTokenPool hands the pool of a token back to DetectPoolMigrations. The pool is kept until
+// a revalidation moves it.
+func UnpinTokenPool(ctx context.Context, tokenAddress string) (*db.TokenModel, error) {
+	ctx, cancel := getCtx(ctx)
+	defer cancel()
+	address := strings.ToLower(strings.TrimSpace(tokenAddress))
+	updated, err := getDB().Token.FindUnique(
+		db.Token.Address.Equals(address),
+	).Update(
+		db.Token.PoolPinned.Set(false),
+	).Exec(ctx)
+	invalidateTokens(address)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, ErrTokenNotFound
+	}
+	return updated, err
+}
@@ -0,0 +1,52 @@
+package tokenRepository
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"tokendata/database/store/mock"
+	db "tokendata/generated/prisma"
+	dex_dto "tokendata/lib/dex/dto"
+)
+
+func TestSetTokenPoolRejectsBadInput(t *testing.T) {
+	defer SetTokenStore(mock.NewTokenStore())()
+
+	tests := []struct {
+		name        string
+		poolAddress string
+		isV4        bool
+		want        error
+	}{
+		{"not an address", "0x1234", false, ErrInvalidPool},
+		{"V4 without a pool id", testToken, true, ErrInvalidPool},
+		{"untracked token", "0x2222222222222222222222222222222222222222", false, ErrTokenNotFound},
+		{"untracked token with a V4 pool id", "0x" + strings.Repeat("ab", 32), false, ErrTokenNotFound},
+	}
+	for _, test := range tests {
+		if _, err := SetTokenPool(testToken, test.poolAddress, test.isV4, ""); !errors.Is(err, test.want) {
+			t.Errorf("%s: err = %v, want %v", test.name, err, test.want)
+		}
+	}
+}
+
+func TestUniswapPoolType(t *testing.T) {
+	tests := []struct {
+		name   string
+		pool   dex_dto.PoolInfo
+		want   db.DexPoolType
+		wantOk bool
+	}{
+		{"V3", dex_dto.PoolInfo{DexID: "uniswap", Labels: []string{"v3"}}, db.DexPoolTypeUniswapV3, true},
+		{"V4", dex_dto.PoolInfo{DexID: "uniswap", Labels: []string{"v4"}}, db.DexPoolTypeUniswapV4, true},
+		{"V2 under the same dex id", dex_dto.PoolInfo{DexID: "uniswap", Labels: []string{"v2"}}, "", false},
+		{"no label", dex_dto.PoolInfo{DexID: "uniswap"}, "", false},
+		{"other dex", dex_dto.PoolInfo{DexID: "aerodrome", Labels: []string{"v3"}}, "", false},
+	}
+	for _, test := range tests {
+		got, ok := uniswapPoolType(test.pool)
+		if got != test.want || ok != test.wantOk {
+			t.Errorf("%s: pool type = %q, %v, want %q, %v", test.name, got, ok, test.want, test.wantOk)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package tokenRepository
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+	dto "tokendata/database/dto"
+	"tokendata/database/store/mock"
+	db "tokendata/generated/prisma"
+
+	"github.com/shopspring/decimal"
+)
+
+const testToken = "0x1111111111111111111111111111111111111111"
+
+func TestUpdateTokenPriceTracksPerformance(t *testing.T) {
+	tokens := mock.NewTokenStore(mock.NewToken(testToken, "1"))
+	defer SetTokenStore(tokens)()
+
+	UpdateTokenPrice(dto.TokenAddress(testToken), "3", dto.PriceSourceSwap)
+	UpdateTokenPrice(dto.TokenAddress(testToken), "2", dto.PriceSourceSwap)
+
+	token, _ := tokens.Token(testToken)
+	if token.Price != "2" {
+		t.Errorf("price = %s, want 2", token.Price)
+	}
+	if multiple, _ := token.PriceMultiple(); multiple != 2 {
+		t.Errorf("price multiple = %v, want 2", multiple)
+	}
+	if athPrice, _ := token.AthPrice(); athPrice != "3" {
+		t.Errorf("ath price = %s, want 3", athPrice)
+	}
+	if athMultiple, _ := token.AthMultiple(); athMultiple != 3 {
+		t.Errorf("ath multiple = %v, want 3", athMultiple)
+	}
+}
+
+func TestUpdateTokenPriceKeepsDelistedPrice(t *testing.T) {
+	delisted := mock.NewToken(testToken, "1")
+	delisted.Delisted = true
+	tokens := mock.NewTokenStore(delisted)
+	defer SetTokenStore(tokens)()
+
+	UpdateTokenPrice(dto.TokenAddress(testToken), "5", dto.PriceSourceSwap)
+
+	token, _ := tokens.Token(testToken)
+	if token.Price != "1" {
+		t.Errorf("price = %s, want 1", token.Price)
+	}
+	if _, ok := token.AthPrice(); ok {
+		t.Error("ath of a delisted token was updated")
+	}
+}
+
+func TestUpdateTokenPriceWithoutInitialPrice(t *testing.T) {
+	token := mock.NewToken(testToken, "1")
+	token.InnerToken.InitialPrice = nil
+	tokens := mock.NewTokenStore(token)
+	defer SetTokenStore(tokens)()
+
+	UpdateTokenPrice(dto.TokenAddress(testToken), "4", dto.PriceSourceSwap)
+
+	updated, _ := tokens.Token(testToken)
+	if updated.Price != "4" {
+		t.Errorf("price = %s, want 4", updated.Price)
+	}
+	if _, ok := updated.PriceMultiple(); ok {
+		t.Error("price multiple set without an initial price")
+	}
+}
+
+func TestGetAllTokensAddressesSkipsArchived(t *testing.T) {
+	archived := mock.NewToken("0x2222222222222222222222222222222222222222", "1")
+	archived.Archived = true
+	defer SetTokenStore(mock.NewTokenStore(mock.NewToken(testToken, "1"), archived))()
+
+	addresses, err := GetAllTokensAddresses()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(addresses) != 1 || addresses[0] != testToken {
+		t.Errorf("addresses = %v, want [%s]", addresses, testToken)
+	}
+}
+
+func TestSaveTokenPrices(t *testing.T) {
+	const delistedToken = "0x2222222222222222222222222222222222222222"
+	delisted := mock.NewToken(delistedToken, "0")
+	delisted.Delisted = true
+	tokens := mock.NewTokenStore(mock.NewToken(testToken, "0"), delisted)
+	defer SetTokenStore(tokens)()
+
+	updated := saveTokenPrices(map[string]string{testToken: "0.5", delistedToken: "2", "0x3333333333333333333333333333333333333333": "1"}, dto.PriceSourceDexscreener)
+	if updated != 1 {
+		t.Errorf("updated = %d, want 1", updated)
+	}
+	if token, _ := tokens.Token(testToken); token.Price != "0.5" {
+		t.Errorf("price = %s, want 0.5", token.Price)
+	}
+	if token, _ := tokens.Token(delistedToken); token.Price != "0" {
+		t.Errorf("delisted price = %s, want 0", token.Price)
+	}
+}
+
+func TestUpdateTokenPriceRecordsSource(t *testing.T) {
+	tokens := mock.NewTokenStore(mock.NewToken(testToken, "1"))
+	defer SetTokenStore(tokens)()
+
+	UpdateTokenPrice(dto.TokenAddress(testToken), "2", dto.PriceSourceDexscreener)
+
+	token, _ := tokens.Token(testToken)
+	source, updatedAt := PriceSourceOf(&token)
+	if source != dto.PriceSourceDexscreener || time.Since(updatedAt) > time.Minute {
+		t.Errorf("price source = %q set at %s", source, updatedAt)
+	}
+}
+
+func TestPriceConfidence(t *testing.T) {
+	now := time.Now()
+	priced := func(price string, source dto.PriceSource, age time.Duration) db.TokenModel {
+		token := mock.NewToken(testToken, price)
+		updatedAt := now.Add(-age)
+		token.InnerToken.PriceUpdatedAt = &updatedAt
+		if source != "" {
+			text := string(source)
+			token.InnerToken.PriceSource = &text
+		}
+		return token
+	}
+	fixed := priced("1", "", 24*time.Hour)
+	fixed.IsFixedPrice = true
+
+	tests := []struct {
+		name  string
+		token db.TokenModel
+		want  float64
+	}{
+		{"fresh swap", priced("1", dto.PriceSourceSwap, 0), 1},
+		{"swap one stale period old", priced("1", dto.PriceSourceSwap, time.Minute), 0.5},
+		{"coingecko two stale periods old", priced("1", dto.PriceSourceCoingecko, 2*time.Minute), 0.1875},
+		{"unknown source", priced("1", "", 0), unknownPriceSourceTrust},
+		{"fixed", fixed, 1},
+		{"zero price", priced("0", dto.PriceSourceSwap, 0), 0},
+	}
+	for _, test := range tests {
+		if got := priceConfidence(&test.token, now, time.Minute); math.Abs(got-test.want) > 1e-9 {
+			t.Errorf("%s: confidence = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestSetTokenMinSwapUSD(t *testing.T) {
+	defer SetTokenStore(mock.NewTokenStore(mock.NewToken(testToken, "1")))()
+	t.Setenv("MIN_SWAP_USD", "")
+
+	if minSwapUSD := minSwapUSDOf(testToken); !minSwapUSD.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("default min swap = %s, want 1", minSwapUSD)
+	}
+	// The swap handler reads it per swap, so it applies without restarting the watcher.
+	if _, err := SetTokenMinSwapUSD(context.Background(), testToken, "0.000123456789012345678"); err != nil {
+		t.Fatal(err)
+	}
+	if minSwapUSD := minSwapUSDOf(testToken); minSwapUSD.String() != "0.000123456789012345678" {
+		t.Errorf("min swap = %s, want 0.000123456789012345678", minSwapUSD)
+	}
+	if _, err := SetTokenMinSwapUSD(context.Background(), testToken, ""); err != nil {
+		t.Fatal(err)
+	}
+	if minSwapUSD := minSwapUSDOf(testToken); !minSwapUSD.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("cleared min swap = %s, want 1", minSwapUSD)
+	}
+
+	if _, err := SetTokenMinSwapUSD(context.Background(), testToken, "-1"); !errors.Is(err, ErrInvalidMinSwapUSD) {
+		t.Errorf("negative min swap: err = %v", err)
+	}
+	if _, err := SetTokenMinSwapUSD(context.Background(), "0x2222222222222222222222222222222222222222", "5"); !errors.Is(err, ErrTokenNotFound) {
+		t.Errorf("untracked token: err = %v", err)
+	}
+}
@@ -0,0 +1,160 @@
+package tokenRepository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"samterminal/pkg/numeric"
+	"strings"
+	"sync"
+	"time"
+	"tokendata/env"
+	db "tokendata/generated/prisma"
+	"tokendata/lib/anchors"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	defaultPriceHistoryInterval      = 5 * time.Minute
+	defaultPriceHistoryRetentionDays = 365
+	// MaxPriceQueries is the most prices GetTokenPricesAt looks up at once.
+	MaxPriceQueries = 500
+)
+
+// PriceQuery asks for the price of a token at a past time. A price recorded more than MaxAge
+// before At is no answer; 0 uses PRICE_HISTORY_MAX_AGE.
+type PriceQuery struct {
+	TokenAddress string
+	At           time.Time
+	MaxAge       time.Duration
+}
+
+// PricePoint is the USD price of a token and when it was recorded.
+type PricePoint struct {
+	Price      decimal.Decimal
+	RecordedAt time.Time
+}
+
+// priceRecorder remembers when the price of each token was last recorded, so that price updates
+// of busy pools record one point per interval.
+type priceRecorder struct {
+	mu        sync.Mutex
+	last      map[string]time.Time
+	lastSweep time.Time
+}
+
+func newPriceRecorder() *priceRecorder {
+	return &priceRecorder{last: map[string]time.Time{}}
+}
+
+var priceHistory = newPriceRecorder()
+
+// due reports whether the price of a token should be recorded at now, and marks it recorded.
+func (r *priceRecorder) due(address string, now time.Time, interval time.Duration) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if last, ok := r.last[address]; ok && now.Sub(last) < interval {
+		return false
+	}
+	r.last[address] = now
+	// Tokens recorded longer than an interval ago are due anyway.
+	if now.Sub(r.lastSweep) >= interval {
+		for address, last := range r.last {
+			if now.Sub(last) >= interval {
+				delete(r.last, address)
+			}
+		}
+		r.lastSweep = now
+	}
+	return true
+}
+
+// priceHistoryMaxAge is how long before a time a recorded price still counts as the price of that
+// time: PRICE_HISTORY_MAX_AGE, by default two recording intervals.
+func priceHistoryMaxAge() time.Duration {
+	interval := env.PRICE_HISTORY_INTERVAL.GetEnvAsDurationOrDefault(defaultPriceHistoryInterval)
+	return env.PRICE_HISTORY_MAX_AGE.GetEnvAsDurationOrDefault(2 * interval)
+}
+
+// recordPriceHistory adds the new prices of tokens to their price history, skipping the tokens
+// recorded within PRICE_HISTORY_INTERVAL and prices that are not positive.
+func recordPriceHistory(prices map[string]string) {
+	interval := env.PRICE_HISTORY_INTERVAL.GetEnvAsDurationOrDefault(defaultPriceHistoryInterval)
+	now := time.Now()
+	points := map[string]decimal.Decimal{}
+	for address, price := range prices {
+		value, err := numeric.Parse(price)
+		if err != nil || !value.IsPositive() {
+			continue
+		}
+		address = strings.ToLower(address)
+		if priceHistory.due(address, now, interval) {
+			points[address] = value
+		}
+	}
+	if len(points) == 0 {
+		return
+	}
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	if err := tokenStore.SavePriceHistory(ctx, points); err != nil {
+		log.Printf("Error saving token price history: %+v", err)
+	}
+}
+
+// GetTokenPricesAt returns, in the order of the queries, the last price recorded for each token
+// at or before the queried time, nil when there is none within the max age of the query.
+// Stablecoins and fixed-price anchors are answered with their quote without a lookup.
+func GetTokenPricesAt(ctx context.Context, queries []PriceQuery) ([]*PricePoint, error) {
+	if len(queries) > MaxPriceQueries {
+		return nil, fmt.Errorf("at most %d price queries are allowed, got %d", MaxPriceQueries, len(queries))
+	}
+	ctx, cancel := getCtx(ctx)
+	defer cancel()
+	defaultMaxAge := priceHistoryMaxAge()
+	points := make([]*PricePoint, len(queries))
+	found := map[PriceQuery]*PricePoint{}
+	for i, query := range queries {
+		query.TokenAddress = strings.ToLower(query.TokenAddress)
+		if query.MaxAge <= 0 {
+			query.MaxAge = defaultMaxAge
+		}
+		if point, ok := found[query]; ok {
+			points[i] = point
+			continue
+		}
+		if quote, ok := anchors.StableQuote(query.TokenAddress); ok {
+			points[i] = &PricePoint{Price: quote, RecordedAt: query.At}
+		} else {
+			recorded, err := tokenStore.PriceAt(ctx, query.TokenAddress, query.At)
+			switch {
+			case errors.Is(err, db.ErrNotFound):
+			case err != nil:
+				return nil, err
+			case query.At.Sub(recorded.CreatedAt) > query.MaxAge:
+			default:
+				points[i] = &PricePoint{Price: recorded.PriceUsd, RecordedAt: recorded.CreatedAt}
+			}
+		}
+		found[query] = points[i]
+	}
+	return points, nil
+}
+
+// PurgeTokenPriceHistory deletes the prices recorded longer than PRICE_HISTORY_RETENTION_DAYS ago.
+func PurgeTokenPriceHistory() {
+	retentionDays := env.PRICE_HISTORY_RETENTION_DAYS.GetEnvAsNumberOrDefault(defaultPriceHistoryRetentionDays)
+	if retentionDays <= 0 {
+		return
+	}
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	purged, err := tokenStore.PurgePriceHistory(ctx, time.Now().Add(-time.Duration(retentionDays)*24*time.Hour))
+	if err != nil {
+		log.Printf("Error purging token price history: %+v", err)
+		return
+	}
+	log.Printf("Purged %d token price history points", purged)
+}
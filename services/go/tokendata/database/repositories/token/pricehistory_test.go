@@ -0,0 +1,114 @@
+package tokenRepository
+
+import (
+	"context"
+	"samterminal/pkg/chain"
+	"strings"
+	"testing"
+	"time"
+	dto "tokendata/database/dto"
+	"tokendata/database/store/mock"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestUpdateTokenPriceRecordsHistory(t *testing.T) {
+	tokens := mock.NewTokenStore(mock.NewToken(testToken, "1"))
+	defer SetTokenStore(tokens)()
+	priceHistory = newPriceRecorder()
+
+	UpdateTokenPrice(dto.TokenAddress(testToken), "2", dto.PriceSourceSwap)
+	UpdateTokenPrice(dto.TokenAddress(testToken), "3", dto.PriceSourceSwap)
+	if points := tokens.PriceHistory(testToken); len(points) != 1 || !points[0].PriceUsd.Equal(decimal.NewFromInt(2)) {
+		t.Fatalf("history = %+v, want one point of 2 within the interval", points)
+	}
+
+	t.Setenv("PRICE_HISTORY_INTERVAL", "1ns")
+	UpdateTokenPrice(dto.TokenAddress(testToken), "4", dto.PriceSourceSwap)
+	if points := tokens.PriceHistory(testToken); len(points) != 2 || !points[1].PriceUsd.Equal(decimal.NewFromInt(4)) {
+		t.Errorf("history = %+v, want a second point of 4 after the interval", points)
+	}
+}
+
+func TestGetTokenPricesAt(t *testing.T) {
+	tokens := mock.NewTokenStore(mock.NewToken(testToken, "1"))
+	defer SetTokenStore(tokens)()
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tokens.PutPrice(testToken, decimal.NewFromInt(1), start)
+	tokens.PutPrice(testToken, decimal.NewFromInt(2), start.Add(time.Hour))
+
+	usdc := strings.ToLower(chain.Get().USDC)
+	points, err := GetTokenPricesAt(context.Background(), []PriceQuery{
+		{TokenAddress: "0x9999999999999999999999999999999999999999", At: start},
+		{TokenAddress: testToken, At: start.Add(5 * time.Minute)},
+		{TokenAddress: testToken, At: start.Add(time.Hour + 5*time.Minute)},
+		{TokenAddress: testToken, At: start.Add(-time.Minute)},
+		{TokenAddress: usdc, At: start},
+		{TokenAddress: testToken, At: start.Add(3 * time.Hour)},
+		{TokenAddress: testToken, At: start.Add(3 * time.Hour), MaxAge: 3 * time.Hour},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if points[0] != nil {
+		t.Errorf("price of an unknown address = %+v", points[0])
+	}
+	if points[1] == nil || !points[1].Price.Equal(decimal.NewFromInt(1)) || !points[1].RecordedAt.Equal(start) {
+		t.Errorf("price between two points = %+v, want the earlier one", points[1])
+	}
+	if points[2] == nil || !points[2].Price.Equal(decimal.NewFromInt(2)) {
+		t.Errorf("price shortly after the last point = %+v, want 2", points[2])
+	}
+	if points[3] != nil {
+		t.Errorf("price before the first point = %+v, want none", points[3])
+	}
+	if points[4] == nil || !points[4].Price.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("price of USDC = %+v, want 1", points[4])
+	}
+	if points[5] != nil {
+		t.Errorf("price recorded two hours before = %+v, want none past the max age", points[5])
+	}
+	if points[6] == nil || !points[6].Price.Equal(decimal.NewFromInt(2)) {
+		t.Errorf("price with a max age of 3h = %+v, want 2", points[6])
+	}
+
+	t.Setenv("PRICE_HISTORY_MAX_AGE", "4h")
+	if points, err := GetTokenPricesAt(context.Background(), []PriceQuery{{TokenAddress: testToken, At: start.Add(3 * time.Hour)}}); err != nil || points[0] == nil {
+		t.Errorf("price with PRICE_HISTORY_MAX_AGE=4h = %+v, %v", points, err)
+	}
+
+	if _, err := GetTokenPricesAt(context.Background(), make([]PriceQuery, MaxPriceQueries+1)); err == nil {
+		t.Error("too many queries were accepted")
+	}
+}
+
+func TestPriceRecorderSweep(t *testing.T) {
+	recorder := newPriceRecorder()
+	now := time.Now()
+	recorder.due("0xa", now, time.Minute)
+	recorder.due("0xb", now.Add(2*time.Minute), time.Minute)
+	if _, ok := recorder.last["0xa"]; ok || len(recorder.last) != 1 {
+		t.Errorf("last = %+v, want 0xa swept", recorder.last)
+	}
+}
+
+func TestPurgeTokenPriceHistory(t *testing.T) {
+	tokens := mock.NewTokenStore()
+	defer SetTokenStore(tokens)()
+	now := time.Now()
+	tokens.PutPrice(testToken, decimal.NewFromInt(1), now.Add(-40*24*time.Hour))
+	tokens.PutPrice(testToken, decimal.NewFromInt(2), now.Add(-time.Hour))
+
+	t.Setenv("PRICE_HISTORY_RETENTION_DAYS", "30")
+	PurgeTokenPriceHistory()
+	if points := tokens.PriceHistory(testToken); len(points) != 1 || !points[0].PriceUsd.Equal(decimal.NewFromInt(2)) {
+		t.Errorf("history = %+v, want the point within the retention", points)
+	}
+
+	t.Setenv("PRICE_HISTORY_RETENTION_DAYS", "0")
+	tokens.PutPrice(testToken, decimal.NewFromInt(1), now.Add(-400*24*time.Hour))
+	PurgeTokenPriceHistory()
+	if points := tokens.PriceHistory(testToken); len(points) != 2 {
+		t.Errorf("history = %+v, want every point kept with a retention of 0", points)
+	}
+}
@@ -0,0 +1,69 @@
+package tokenRepository
+
+import (
+	"context"
+	"log"
+	"strings"
+	dto "tokendata/database/dto"
+	db "tokendata/generated/prisma"
+	"tokendata/lib/apis"
+	"tokendata/lib/degrade"
+)
+
+// SaveTokenProfile stores the socials of a token and adds the tags its description and Dexscreener
+// labels call for. Empty fields are left untouched so a partial profile does not wipe links found
+// earlier.
+func SaveTokenProfile(tokenAddress dto.TokenAddress, profile apis.TokenProfile) error {
+	if profile.IsEmpty() {
+		return nil
+	}
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	var tx = getDB()
+
+	var params []db.TokenSetParam
+	if profile.Website != "" {
+		params = append(params, db.Token.Website.Set(profile.Website))
+	}
+	if profile.Twitter != "" {
+		params = append(params, db.Token.Twitter.Set(profile.Twitter))
+	}
+	if profile.Telegram != "" {
+		params = append(params, db.Token.Telegram.Set(profile.Telegram))
+	}
+	if profile.Description != "" {
+		params = append(params, db.Token.Description.Set(profile.Description))
+	}
+	if profile.HeaderImageURL != "" {
+		params = append(params, db.Token.HeaderImageURL.Set(profile.HeaderImageURL))
+	}
+	if len(params) > 0 {
+		_, err := tx.Token.FindUnique(
+			db.Token.Address.Equals(strings.ToLower(string(tokenAddress))),
+		).Update(params...).Exec(ctx)
+		invalidateTokens(string(tokenAddress))
+		if err != nil {
+			return err
+		}
+	}
+	tags := mergeTags(labelTags(profile.Labels), classifyToken("", profile.Description)...)
+	if len(tags) == 0 {
+		return nil
+	}
+	return AddTokenTags(tokenAddress, tags...)
+}
+
+// RefreshTokenProfile fetches the socials of a token from Dexscreener and stores them.
+func RefreshTokenProfile(tokenAddress dto.TokenAddress) {
+	if degrade.EnrichmentDisabled() {
+		return
+	}
+	profile, err := apis.GetDexscreenerTokenProfile(string(tokenAddress))
+	if err != nil {
+		log.Printf("Error getting token profile for %s: %+v", tokenAddress, err)
+		return
+	}
+	if err := SaveTokenProfile(tokenAddress, profile); err != nil {
+		log.Printf("Error saving token profile for %s: %+v", tokenAddress, err)
+	}
+}
@@ -0,0 +1,95 @@
+package tokenRepository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	dto "tokendata/database/dto"
+	db "tokendata/generated/prisma"
+	"tokendata/lib/quote"
+	wsDexManager "tokendata/lib/ws/dex"
+	proto "tokendata/proto/token"
+
+	"github.com/shopspring/decimal"
+)
+
+const quoteTimeout = 15 * time.Second
+
+var (
+	ErrInvalidAmount = errors.New("amount must be a positive number")
+	ErrTokenNoPool   = errors.New("token has no pool to quote on")
+)
+
+// GetQuote simulates spending amount on the best pool of a token: the pair token when buying, the
+// token itself when selling. Price impact compares the quoted output with the output at the
+// stored USD prices of both tokens and is left at zero when either price is unknown.
+func GetQuote(tokenAddress string, side proto.QuoteSide, amount string) (*proto.GetQuoteResponse, error) {
+	amountIn, err := decimal.NewFromString(strings.TrimSpace(amount))
+	if err != nil || !amountIn.IsPositive() {
+		return nil, ErrInvalidAmount
+	}
+	token := getToken(dto.TokenAddress(tokenAddress))
+	if token == nil {
+		return nil, ErrTokenNotFound
+	}
+	poolAddress, _ := token.PoolAddress()
+	pairAddress, _ := token.PairAddress()
+	if poolAddress == "" || pairAddress == "" {
+		return nil, ErrTokenNoPool
+	}
+
+	tokenIn, tokenOut := pairAddress, token.Address
+	if side == proto.QuoteSide_QUOTE_SELL {
+		tokenIn, tokenOut = token.Address, pairAddress
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), quoteTimeout)
+	defer cancel()
+	decimals, err := wsDexManager.GetTokensDecimals(ctx, tokenIn, tokenOut)
+	if err != nil {
+		return nil, err
+	}
+	decimalsIn, decimalsOut := decimals[0], decimals[1]
+
+	result, err := quote.ExactInput(ctx, poolAddress, token.PoolType == db.DexPoolTypeUniswapV4, tokenIn, tokenOut, amountIn.Shift(int32(decimalsIn)).BigInt())
+	if err != nil {
+		return nil, err
+	}
+	amountOut := decimal.NewFromBigInt(result.AmountOut, -int32(decimalsOut))
+
+	response := &proto.GetQuoteResponse{
+		TokenIn:     strings.ToLower(tokenIn),
+		TokenOut:    strings.ToLower(tokenOut),
+		AmountIn:    amountIn.String(),
+		AmountOut:   amountOut.String(),
+		GasEstimate: result.GasEstimate,
+		PoolAddress: poolAddress,
+	}
+
+	tokenPrice, _ := decimal.NewFromString(token.Price)
+	pairPrice := decimal.Zero
+	if pair := getToken(dto.TokenAddress(pairAddress)); pair != nil {
+		pairPrice, _ = decimal.NewFromString(pair.Price)
+	}
+	if !tokenPrice.IsPositive() || !pairPrice.IsPositive() || !amountOut.IsPositive() {
+		return response, nil
+	}
+	priceIn, priceOut := pairPrice, tokenPrice
+	tokenAmount := amountOut
+	if side == proto.QuoteSide_QUOTE_SELL {
+		priceIn, priceOut = tokenPrice, pairPrice
+		tokenAmount = amountIn
+	}
+	valueIn := amountIn.Mul(priceIn)
+	expectedOut := valueIn.Div(priceOut)
+	impact, _ := decimal.NewFromInt(1).Sub(amountOut.Div(expectedOut)).Mul(decimal.NewFromInt(100)).Float64()
+	response.PriceImpact = max(impact, 0)
+	// USD paid or received per token, valuing the pair token at its stored price.
+	pairAmount := amountIn
+	if side == proto.QuoteSide_QUOTE_SELL {
+		pairAmount = amountOut
+	}
+	response.ExecutionPriceUsd = pairAmount.Mul(pairPrice).Div(tokenAmount).String()
+	return response, nil
+}
@@ -0,0 +1,228 @@
+package tokenRepository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	db "tokendata/generated/prisma"
+)
+
+const (
+	// readModelFlushInterval is how long a changed token may be listed with its previous values.
+	readModelFlushInterval = 250 * time.Millisecond
+	// readModelReloadInterval is how often every token is reread, picking up changes made outside
+	// of this service.
+	readModelReloadInterval = 5 * time.Minute
+)
+
+// readModel keeps every token in memory so that GetTokens and GetTokenPrice, polled by HTTP
+// clients and by walletdata valuations, do not query Postgres, which stays the system of record.
+// Writes mark the tokens they change as dirty. Dirty tokens are reread together once per flush
+// interval; single-token reads of a dirty token reread it at once, and lists are flushed first
+// when a dirty token is not loaded yet, so that new tokens are listed as soon as they are added.
+type readModel struct {
+	// refreshMu keeps flushes and reloads from overwriting each other with older rows.
+	refreshMu sync.Mutex
+
+	mu     sync.RWMutex
+	loaded bool
+	tokens map[string]db.TokenModel
+	dirty  map[string]bool
+}
+
+var tokenReadModel = newReadModel()
+
+func newReadModel() *readModel {
+	return &readModel{tokens: map[string]db.TokenModel{}, dirty: map[string]bool{}}
+}
+
+// StartTokenReadModel loads every token into memory and keeps them current. Reads go to the store
+// until the first load succeeds.
+func StartTokenReadModel() {
+	if err := tokenReadModel.reload(); err != nil {
+		log.Printf("Error loading token read model: %+v", err)
+	}
+	go func() {
+		flush := time.NewTicker(readModelFlushInterval)
+		reload := time.NewTicker(readModelReloadInterval)
+		defer flush.Stop()
+		defer reload.Stop()
+		for {
+			select {
+			case <-flush.C:
+				if err := tokenReadModel.flush(); err != nil {
+					log.Printf("Error refreshing token read model: %+v", err)
+				}
+			case <-reload.C:
+				if err := tokenReadModel.reload(); err != nil {
+					log.Printf("Error reloading token read model: %+v", err)
+				}
+			}
+		}
+	}()
+}
+
+// invalidateTokens marks tokens as changed in the store.
+func invalidateTokens(addresses ...string) {
+	tokenReadModel.invalidate(addresses...)
+}
+
+func (m *readModel) invalidate(addresses ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, address := range addresses {
+		m.dirty[strings.ToLower(address)] = true
+	}
+}
+
+// token returns a loaded token that has not changed since it was read.
+func (m *readModel) token(address string) (*db.TokenModel, bool) {
+	address = strings.ToLower(address)
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	token, ok := m.tokens[address]
+	if !m.loaded || !ok || m.dirty[address] {
+		return nil, false
+	}
+	return &token, true
+}
+
+// get returns a token from the model, rereading it from the store when it is dirty or not
+// loaded. The reread row is kept unless a flush or reload is running, which could have read a
+// newer one.
+func (m *readModel) get(ctx context.Context, address string) (*db.TokenModel, error) {
+	address = strings.ToLower(address)
+	if token, ok := m.token(address); ok {
+		return token, nil
+	}
+	if !m.refreshMu.TryLock() {
+		return tokenStore.FindToken(ctx, address)
+	}
+	defer m.refreshMu.Unlock()
+
+	m.mu.Lock()
+	delete(m.dirty, address)
+	m.mu.Unlock()
+	token, err := tokenStore.FindToken(ctx, address)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	switch {
+	case errors.Is(err, db.ErrNotFound):
+		delete(m.tokens, address)
+	case err != nil:
+		m.dirty[address] = true
+	case m.loaded:
+		m.tokens[address] = *token
+	}
+	return token, err
+}
+
+// setUsingEnds updates the using ends of a loaded token that is not dirty, without rereading it.
+func (m *readModel) setUsingEnds(address string, usingEnds int) {
+	address = strings.ToLower(address)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if token, ok := m.tokens[address]; ok && !m.dirty[address] {
+		token.UsingEnds = usingEnds
+		m.tokens[address] = token
+	}
+}
+
+// list returns the tokens kept by keep, by address, or false before the model is loaded or when
+// the tokens added since the last flush cannot be read.
+func (m *readModel) list(keep func(token db.TokenModel) bool) ([]db.TokenModel, bool) {
+	if m.unloadedDirty() {
+		if err := m.flush(); err != nil {
+			log.Printf("Error refreshing token read model: %+v", err)
+			return nil, false
+		}
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if !m.loaded {
+		return nil, false
+	}
+	tokens := []db.TokenModel{}
+	for _, token := range m.tokens {
+		if keep(token) {
+			tokens = append(tokens, token)
+		}
+	}
+	slices.SortFunc(tokens, func(a, b db.TokenModel) int { return strings.Compare(a.Address, b.Address) })
+	return tokens, true
+}
+
+// unloadedDirty reports whether a dirty token is missing from the loaded model, as new tokens are.
+func (m *readModel) unloadedDirty() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if !m.loaded {
+		return false
+	}
+	for address := range m.dirty {
+		if _, ok := m.tokens[address]; !ok {
+			return true
+		}
+	}
+	return false
+}
+
+// flush rereads the dirty tokens. Tokens that are gone from the store are dropped.
+func (m *readModel) flush() error {
+	m.refreshMu.Lock()
+	defer m.refreshMu.Unlock()
+
+	m.mu.Lock()
+	addresses := make([]string, 0, len(m.dirty))
+	for address := range m.dirty {
+		addresses = append(addresses, address)
+	}
+	m.dirty = map[string]bool{}
+	m.mu.Unlock()
+	if len(addresses) == 0 {
+		return nil
+	}
+
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	tokens, err := tokenStore.ListTokens(ctx, addresses)
+	if err != nil {
+		m.invalidate(addresses...)
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, address := range addresses {
+		delete(m.tokens, address)
+	}
+	for _, token := range tokens {
+		m.tokens[token.Address] = token
+	}
+	return nil
+}
+
+// reload replaces every token. Tokens that change while they are read stay dirty.
+func (m *readModel) reload() error {
+	m.refreshMu.Lock()
+	defer m.refreshMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	tokens, err := tokenStore.ListTokens(ctx, nil)
+	if err != nil {
+		return err
+	}
+	loaded := make(map[string]db.TokenModel, len(tokens))
+	for _, token := range tokens {
+		loaded[token.Address] = token
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tokens = loaded
+	m.loaded = true
+	return nil
+}
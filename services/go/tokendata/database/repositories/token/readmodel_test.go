@@ -0,0 +1,64 @@
+package tokenRepository
+
+import (
+	"context"
+	"testing"
+	dto "tokendata/database/dto"
+	"tokendata/database/store/mock"
+)
+
+func TestReadModel(t *testing.T) {
+	const otherToken = "0x2222222222222222222222222222222222222222"
+	archived := mock.NewToken(otherToken, "5")
+	archived.Archived = true
+	tokens := mock.NewTokenStore(mock.NewToken(testToken, "1"), archived)
+	defer SetTokenStore(tokens)()
+	previous := tokenReadModel
+	tokenReadModel = newReadModel()
+	defer func() { tokenReadModel = previous }()
+
+	if err := tokenReadModel.reload(); err != nil {
+		t.Fatal(err)
+	}
+	// Changed without invalidation, so the read model keeps serving the loaded token.
+	tokens.Put(mock.NewToken(testToken, "2"))
+	if token, _ := GetToken(context.Background(), dto.TokenAddress(testToken)); token.Price != "1" {
+		t.Errorf("price = %s, want the loaded 1", token.Price)
+	}
+
+	invalidateTokens(testToken)
+	if token, _ := GetToken(context.Background(), dto.TokenAddress(testToken)); token.Price != "2" {
+		t.Errorf("price of a dirty token = %s, want 2 from the store", token.Price)
+	}
+	// The reread token is kept, so it is listed before a flush.
+	tokens.Put(mock.NewToken(testToken, "3"))
+	listed, err := GetAllTokens(context.Background(), nil, nil, false, nil)
+	if err != nil || len(listed) != 1 || listed[0].Price != "2" {
+		t.Errorf("tokens after a dirty read = %+v, %v; want the reread token", listed, err)
+	}
+
+	invalidateTokens(testToken)
+	listed, _ = GetAllTokens(context.Background(), nil, nil, false, nil)
+	if len(listed) != 1 || listed[0].Price != "2" {
+		t.Errorf("tokens before flush = %+v, want the loaded token", listed)
+	}
+	if err := tokenReadModel.flush(); err != nil {
+		t.Fatal(err)
+	}
+	listed, _ = GetAllTokens(context.Background(), nil, nil, true, nil)
+	if len(listed) != 2 || listed[0].Price != "3" || listed[1].Address != otherToken {
+		t.Errorf("tokens after flush = %+v", listed)
+	}
+
+	const newToken = "0x3333333333333333333333333333333333333333"
+	tokens.Put(mock.NewToken(newToken, "4"))
+	invalidateTokens(newToken)
+	if listed, _ = GetAllTokens(context.Background(), nil, nil, true, nil); len(listed) != 3 {
+		t.Errorf("tokens after adding one = %+v, want the new token without waiting for a flush", listed)
+	}
+
+	tokenReadModel.setUsingEnds(testToken, 7)
+	if token, _ := GetToken(context.Background(), dto.TokenAddress(testToken)); token.UsingEnds != 7 {
+		t.Errorf("using ends = %d, want 7 without a reread", token.UsingEnds)
+	}
+}
@@ -0,0 +1,205 @@
+package tokenRepository
+
+import (
+	"cmp"
+	"context"
+	"log"
+	"slices"
+	"sync"
+	"time"
+	dto "tokendata/database/dto"
+	"tokendata/env"
+	websocket "tokendata/lib/ws"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	defaultSwapConfirmations = 2
+	// swapLedgerBlocks is how long swaps are remembered so that their volume can be reverted when
+	// a reorg removes them, well past the depth of the reorgs of the chain.
+	swapLedgerBlocks        = 128
+	swapConfirmationsPeriod = 2 * time.Second
+	// swapFinalizeWorkers is how many tokens have their confirmed swaps finalized at once.
+	swapFinalizeWorkers = 8
+)
+
+// swapKey identifies the log of a swap. A reorg notification repeats it with Removed set.
+type swapKey struct {
+	txHash   common.Hash
+	logIndex uint
+}
+
+type swapEntry struct {
+	tokenAddress string
+	volumeUSD    decimal.Decimal
+	blockNumber  uint64
+	logIndex     uint
+	// finalize applies the price of the swap and sends its alerts once it is confirmed; nil once
+	// it ran.
+	finalize func()
+}
+
+// swapLedger holds the recent swaps of watched pools: their volume, to revert it when a reorg
+// removes them, and the price updates and alerts waiting for confirmations.
+type swapLedger struct {
+	mu            sync.Mutex
+	confirmations uint64
+	swaps         map[swapKey]*swapEntry
+}
+
+func newSwapLedger(confirmations uint64) *swapLedger {
+	return &swapLedger{confirmations: confirmations, swaps: map[swapKey]*swapEntry{}}
+}
+
+// record remembers a swap and returns whether its finalize must run now, which it must when no
+// confirmations are required.
+func (l *swapLedger) record(vLog types.Log, tokenAddress string, volumeUSD decimal.Decimal, finalize func()) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entry := &swapEntry{tokenAddress: tokenAddress, volumeUSD: volumeUSD, blockNumber: vLog.BlockNumber, logIndex: vLog.Index, finalize: finalize}
+	runNow := l.confirmations == 0
+	if runNow {
+		entry.finalize = nil
+	}
+	l.swaps[swapKey{vLog.TxHash, vLog.Index}] = entry
+	return runNow
+}
+
+// remove forgets a swap a reorg removed and returns it, with finalize still set when it was not
+// confirmed yet.
+func (l *swapLedger) remove(vLog types.Log) (swapEntry, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	key := swapKey{vLog.TxHash, vLog.Index}
+	entry, ok := l.swaps[key]
+	if !ok {
+		return swapEntry{}, false
+	}
+	delete(l.swaps, key)
+	return *entry, true
+}
+
+// confirm returns the finalize functions of the swaps that are confirmed at head, grouped by token
+// with the swaps of each token oldest first, and forgets the swaps past swapLedgerBlocks.
+func (l *swapLedger) confirm(head uint64) [][]func() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	confirmed := []*swapEntry{}
+	for key, entry := range l.swaps {
+		if entry.finalize != nil && entry.blockNumber+l.confirmations <= head {
+			confirmed = append(confirmed, entry)
+		}
+		if entry.blockNumber+max(l.confirmations, swapLedgerBlocks) < head {
+			delete(l.swaps, key)
+		}
+	}
+	slices.SortFunc(confirmed, func(a, b *swapEntry) int {
+		return cmp.Or(cmp.Compare(a.blockNumber, b.blockNumber), cmp.Compare(a.logIndex, b.logIndex))
+	})
+	groups := [][]func(){}
+	group := map[string]int{}
+	for _, entry := range confirmed {
+		i, ok := group[entry.tokenAddress]
+		if !ok {
+			i = len(groups)
+			group[entry.tokenAddress] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], entry.finalize)
+		entry.finalize = nil
+	}
+	return groups
+}
+
+// pending reports whether swaps wait for confirmations.
+func (l *swapLedger) pending() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, entry := range l.swaps {
+		if entry.finalize != nil {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	swapsOnce sync.Once
+	swaps     *swapLedger
+)
+
+// getSwapLedger returns the ledger of watched swaps, starting the loop that confirms them against
+// the chain head every swapConfirmationsPeriod. Swaps need SWAP_CONFIRMATIONS blocks on top of
+// theirs before they move prices and send alerts.
+func getSwapLedger() *swapLedger {
+	swapsOnce.Do(func() {
+		confirmations := max(env.SWAP_CONFIRMATIONS.GetEnvAsNumberOrDefault(defaultSwapConfirmations), 0)
+		swaps = newSwapLedger(uint64(confirmations))
+		go func() {
+			ticker := time.NewTicker(swapConfirmationsPeriod)
+			defer ticker.Stop()
+			for range ticker.C {
+				confirmSwaps(swaps)
+			}
+		}()
+	})
+	return swaps
+}
+
+func confirmSwaps(ledger *swapLedger) {
+	if !ledger.pending() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	head, err := websocket.GetEthClient().BlockNumber(ctx)
+	if err != nil {
+		log.Printf("Error getting block number to confirm swaps: %v", err)
+		return
+	}
+	finalizeSwaps(ledger.confirm(head))
+}
+
+// finalizeSwaps runs the finalize functions of each token in order, swapFinalizeWorkers tokens at
+// a time, so that a slow token does not hold up the others.
+func finalizeSwaps(groups [][]func()) {
+	workers := make(chan struct{}, swapFinalizeWorkers)
+	var wg sync.WaitGroup
+	for _, finalizers := range groups {
+		workers <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+			for _, finalize := range finalizers {
+				finalize()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// recordSwap counts the volume of a swap and runs finalize once the swap is confirmed.
+func recordSwap(vLog types.Log, tokenAddress string, volumeUSD decimal.Decimal, finalize func()) {
+	updateCalculatedVolume24H(dto.TokenAddress(tokenAddress), volumeUSD)
+	if getSwapLedger().record(vLog, tokenAddress, volumeUSD, finalize) {
+		finalize()
+	}
+}
+
+// revertSwap takes the volume of a swap a reorg removed back out of its token. Its price update
+// and alerts are dropped if it was not confirmed yet; a confirmed price is left for the next swap
+// to correct.
+func revertSwap(vLog types.Log) {
+	entry, ok := getSwapLedger().remove(vLog)
+	if !ok {
+		return
+	}
+	log.Printf("Reverting swap %s of %s removed by a reorg (confirmed: %v)", vLog.TxHash.Hex(), entry.tokenAddress, entry.finalize == nil)
+	updateCalculatedVolume24H(dto.TokenAddress(entry.tokenAddress), entry.volumeUSD.Neg())
+}
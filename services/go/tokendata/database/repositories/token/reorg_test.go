@@ -0,0 +1,83 @@
+package tokenRepository
+
+import (
+	"math/big"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/shopspring/decimal"
+)
+
+func TestSwapLedgerConfirm(t *testing.T) {
+	ledger := newSwapLedger(2)
+	finalized := []string{}
+	swap := func(hash string, block uint64, index uint) types.Log {
+		vLog := types.Log{TxHash: common.HexToHash(hash), BlockNumber: block, Index: index}
+		if ledger.record(vLog, "0xtoken", decimal.NewFromInt(10), func() { finalized = append(finalized, hash) }) {
+			t.Errorf("swap %s finalized before its confirmations", hash)
+		}
+		return vLog
+	}
+	swap("0x2", 101, 0)
+	swap("0x1", 100, 3)
+	removed := swap("0x3", 101, 1)
+
+	entry, ok := ledger.remove(types.Log{TxHash: removed.TxHash, Index: removed.Index, Removed: true})
+	if !ok || !entry.volumeUSD.Equal(decimal.NewFromInt(10)) || entry.finalize == nil {
+		t.Errorf("removed entry = %+v, %v", entry, ok)
+	}
+
+	finalizeSwaps(ledger.confirm(102))
+	if len(finalized) != 1 || finalized[0] != "0x1" {
+		t.Errorf("finalized at 102 = %v, want [0x1]", finalized)
+	}
+	finalizeSwaps(ledger.confirm(103))
+	if len(finalized) != 2 || finalized[1] != "0x2" || ledger.pending() {
+		t.Errorf("finalized at 103 = %v, want [0x1 0x2] and none pending", finalized)
+	}
+
+	if len(ledger.confirm(103+swapLedgerBlocks)) != 0 || len(ledger.swaps) != 0 {
+		t.Errorf("old swaps kept: %d", len(ledger.swaps))
+	}
+}
+
+func TestSwapLedgerWithoutConfirmations(t *testing.T) {
+	ledger := newSwapLedger(0)
+	vLog := types.Log{TxHash: common.HexToHash("0x1"), BlockNumber: 100}
+	if !ledger.record(vLog, "0xtoken", decimal.NewFromInt(5), func() {}) {
+		t.Error("swap not finalized at once without confirmations")
+	}
+	if entry, ok := ledger.remove(vLog); !ok || !entry.volumeUSD.Equal(decimal.NewFromInt(5)) || entry.finalize != nil {
+		t.Errorf("removed entry = %+v, %v; want its volume, already finalized", entry, ok)
+	}
+}
+
+func TestSwapLedgerConfirmGroupsByToken(t *testing.T) {
+	ledger := newSwapLedger(1)
+	var mu sync.Mutex
+	finalized := map[string][]uint{}
+	swap := func(token string, block uint64, index uint) {
+		vLog := types.Log{TxHash: common.BigToHash(new(big.Int).SetUint64(block*100 + uint64(index))), BlockNumber: block, Index: index}
+		ledger.record(vLog, token, decimal.NewFromInt(1), func() {
+			mu.Lock()
+			defer mu.Unlock()
+			finalized[token] = append(finalized[token], index)
+		})
+	}
+	swap("0xa", 100, 2)
+	swap("0xb", 100, 1)
+	swap("0xa", 100, 0)
+	swap("0xa", 101, 5)
+
+	groups := ledger.confirm(102)
+	if len(groups) != 2 || len(groups[0]) != 3 || len(groups[1]) != 1 {
+		t.Fatalf("groups = %d, want 0xa with 3 swaps and 0xb with 1", len(groups))
+	}
+	finalizeSwaps(groups)
+	if !slices.Equal(finalized["0xa"], []uint{0, 2, 5}) || !slices.Equal(finalized["0xb"], []uint{1}) {
+		t.Errorf("finalized = %v, want the swaps of each token in order", finalized)
+	}
+}
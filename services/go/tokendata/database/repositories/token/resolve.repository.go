@@ -0,0 +1,147 @@
+package tokenRepository
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+	dto "tokendata/database/dto"
+	db "tokendata/generated/prisma"
+	proto "tokendata/proto/token"
+)
+
+var hexIDRe = regexp.MustCompile(`^0x([0-9a-f]{40}|[0-9a-f]{64})$`)
+
+var ErrUnresolvableQuery = errors.New("query is not a supported url or address")
+var ErrTokenNotFound = errors.New("token not found")
+
+// parseResolveQuery extracts an address from a Dexscreener, GeckoTerminal or Basescan url, or a
+// bare address. kind is nil when the address may be either a token or a pool.
+func parseResolveQuery(query string) (string, *proto.ResolveInputType, error) {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return "", nil, ErrUnresolvableQuery
+	}
+
+	if !strings.HasPrefix(query, "0x") {
+		if !strings.Contains(query, "://") {
+			query = "https://" + query
+		}
+		u, err := url.Parse(query)
+		if err != nil {
+			return "", nil, ErrUnresolvableQuery
+		}
+		segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+		if len(segments) < 2 {
+			return "", nil, ErrUnresolvableQuery
+		}
+		address := segments[len(segments)-1]
+		host := strings.TrimPrefix(u.Hostname(), "www.")
+		switch host {
+		case "dexscreener.com":
+			// dexscreener.com/base/<pair or token>
+		case "geckoterminal.com":
+			// geckoterminal.com/base/pools/<pool> or geckoterminal.com/base/tokens/<token>
+			switch segments[len(segments)-2] {
+			case "pools":
+				return validateResolveAddress(address, proto.ResolveInputType_RESOLVED_POOL.Enum())
+			case "tokens":
+				return validateResolveAddress(address, proto.ResolveInputType_RESOLVED_TOKEN.Enum())
+			}
+		case "basescan.org":
+			// basescan.org/token/<token> or basescan.org/address/<token>
+			switch segments[len(segments)-2] {
+			case "token", "address":
+				return validateResolveAddress(address, proto.ResolveInputType_RESOLVED_TOKEN.Enum())
+			}
+			return "", nil, ErrUnresolvableQuery
+		default:
+			return "", nil, ErrUnresolvableQuery
+		}
+		query = address
+	}
+	return validateResolveAddress(query, nil)
+}
+
+func validateResolveAddress(address string, kind *proto.ResolveInputType) (string, *proto.ResolveInputType, error) {
+	if !hexIDRe.MatchString(address) {
+		return "", nil, ErrUnresolvableQuery
+	}
+	if len(address) == v4PoolIDLength {
+		kind = proto.ResolveInputType_RESOLVED_POOL.Enum()
+	}
+	return address, kind, nil
+}
+
+func getTokenByPoolAddress(poolAddress string) *db.TokenModel {
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	var tx = getDB()
+	token, err := tx.Token.FindFirst(db.Token.PoolAddress.Equals(poolAddress)).Exec(ctx)
+	if err != nil {
+		return nil
+	}
+	return token
+}
+
+// Resolve returns the canonical token for a url, pool address or token address. When create is
+// set, unknown tokens and pools are added to the token list.
+func Resolve(query string, create bool, reason *string) (*db.TokenModel, proto.ResolveInputType, error) {
+	address, kind, err := parseResolveQuery(query)
+	if err != nil {
+		return nil, proto.ResolveInputType_RESOLVED_TOKEN, err
+	}
+
+	// Archived tokens are only returned as-is when we are not allowed to restore them.
+	if kind == nil || *kind == proto.ResolveInputType_RESOLVED_TOKEN {
+		if token := getToken(dto.TokenAddress(address)); token != nil && !(create && token.Archived) {
+			return token, proto.ResolveInputType_RESOLVED_TOKEN, nil
+		}
+	}
+	if kind == nil || *kind == proto.ResolveInputType_RESOLVED_POOL {
+		if token := getTokenByPoolAddress(address); token != nil && !(create && token.Archived) {
+			return token, proto.ResolveInputType_RESOLVED_POOL, nil
+		}
+	}
+
+	var pool PoolTokens
+	if kind == nil || *kind == proto.ResolveInputType_RESOLVED_POOL {
+		// Token contracts have no token0/token1, so a successful read means the address is a pool.
+		pool, err = ResolvePoolTokens(address, false)
+		if err == nil && pool.TokenAddress != "" {
+			kind = proto.ResolveInputType_RESOLVED_POOL.Enum()
+			if token := getToken(dto.TokenAddress(pool.TokenAddress)); token != nil && !(create && token.Archived) {
+				return token, *kind, nil
+			}
+		} else if kind != nil {
+			return nil, *kind, ErrTokenNotFound
+		} else {
+			kind = proto.ResolveInputType_RESOLVED_TOKEN.Enum()
+		}
+	}
+
+	if !create {
+		return nil, *kind, ErrTokenNotFound
+	}
+
+	tokenAddress := address
+	if *kind == proto.ResolveInputType_RESOLVED_POOL {
+		response, resolved := AddPoolToTokenList(address, false, reason)
+		if !response.Success {
+			return nil, *kind, errors.New(response.Message)
+		}
+		tokenAddress = resolved.TokenAddress
+	} else {
+		response := AddToTokenList(dto.TokenAddress(address), nil, nil, nil, nil, nil, nil, reason, nil)
+		if !response.Success {
+			return nil, *kind, errors.New(response.Message)
+		}
+	}
+
+	token := getToken(dto.TokenAddress(tokenAddress))
+	if token == nil {
+		return nil, *kind, ErrTokenNotFound
+	}
+	return token, *kind, nil
+}
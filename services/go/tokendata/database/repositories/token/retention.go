@@ -0,0 +1,77 @@
+package tokenRepository
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"tokendata/env"
+)
+
+// retentionPolicy maps a token reason to how long a token may stay unused before it is archived.
+// Reasons that are not in the policy are never archived for being unused.
+type retentionPolicy map[string]time.Duration
+
+const defaultRetention = 30 * time.Minute
+
+func defaultRetentionPolicy() retentionPolicy {
+	return retentionPolicy{
+		"wallet_token": defaultRetention,
+		"token_price":  defaultRetention,
+		"clanker":      defaultRetention,
+		"bankr":        defaultRetention,
+		"resolve":      defaultRetention,
+	}
+}
+
+// parseRetentionPolicy parses "reason=duration" pairs separated by commas, e.g.
+// "clanker=24h,wallet_token=1h". A duration of 0 removes the reason from the policy.
+func parseRetentionPolicy(raw string, policy retentionPolicy) error {
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		reason, value, ok := strings.Cut(entry, "=")
+		reason = strings.TrimSpace(reason)
+		if !ok || reason == "" {
+			return fmt.Errorf("invalid retention entry %q", entry)
+		}
+		retention, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || retention < 0 {
+			return fmt.Errorf("invalid retention for %s: %q", reason, value)
+		}
+		if retention == 0 {
+			delete(policy, reason)
+			continue
+		}
+		policy[reason] = retention
+	}
+	return nil
+}
+
+// loadRetentionPolicy applies TOKEN_RETENTION on top of the defaults. An invalid value is logged
+// and the defaults are used.
+func loadRetentionPolicy() retentionPolicy {
+	policy := defaultRetentionPolicy()
+	raw := env.TOKEN_RETENTION.GetEnv()
+	if raw == "" {
+		return policy
+	}
+	if err := parseRetentionPolicy(raw, policy); err != nil {
+		log.Printf("%v, using default token retention", err)
+		return defaultRetentionPolicy()
+	}
+	return policy
+}
+
+// shortest returns the smallest retention in the policy.
+func (p retentionPolicy) shortest() time.Duration {
+	shortest := time.Duration(0)
+	for _, retention := range p {
+		if shortest == 0 || retention < shortest {
+			shortest = retention
+		}
+	}
+	return shortest
+}
@@ -0,0 +1,206 @@
+package tokenRepository
+
+import (
+	"context"
+	"log"
+	"samterminal/pkg/chain"
+	"samterminal/pkg/usage"
+	"slices"
+	"strings"
+	"time"
+	dto "tokendata/database/dto"
+	"tokendata/database/repositories/blacklist"
+	db "tokendata/generated/prisma"
+	"tokendata/lib/apis"
+	"tokendata/lib/degrade"
+	"tokendata/lib/risk"
+)
+
+const (
+	// Liquidity and holders move with trading, so they are checked again every few hours.
+	riskCheckInterval = 6 * time.Hour
+	riskCheckBatch    = 100
+	// Holders take one Moralis call per token, spaced out like the security checks.
+	riskHoldersInterval = 200 * time.Millisecond
+	// riskTopHolders is how many of the largest holders the concentration is measured over;
+	// more are requested so that pools and burn addresses can be left out.
+	riskTopHolders       = 10
+	riskHoldersRequested = 20
+)
+
+// burnAddresses hold tokens nobody can move, so they do not count as concentrated supply.
+var burnAddresses = []string{
+	"0x0000000000000000000000000000000000000000",
+	"0x000000000000000000000000000000000000dead",
+}
+
+// topHoldersPct is the share of the supply, in percent, that the riskTopHolders largest holders
+// own, leaving out excluded addresses.
+func topHoldersPct(holders []apis.TokenHolder, excluded []string) float64 {
+	pct := 0.0
+	counted := 0
+	for _, holder := range holders {
+		if counted == riskTopHolders {
+			break
+		}
+		if slices.Contains(excluded, strings.ToLower(holder.OwnerAddress)) {
+			continue
+		}
+		pct += holder.PercentageOfSupply
+		counted++
+	}
+	return pct
+}
+
+// notHolders are the addresses of a token that hold its supply without owning it: its pool, the
+// PoolManager holding the tokens of every V4 pool, and burn addresses.
+func notHolders(token *db.TokenModel) []string {
+	excluded := slices.Clone(burnAddresses)
+	if poolAddress, ok := token.PoolAddress(); ok && poolAddress != "" {
+		excluded = append(excluded, strings.ToLower(poolAddress))
+	}
+	if token.PoolType == db.DexPoolTypeUniswapV4 {
+		excluded = append(excluded, strings.ToLower(chain.Get().PoolManager))
+	}
+	return excluded
+}
+
+// checkTokensRisk stores the liquidity and holder concentration of tokens. liquidity holds the
+// Dexscreener answer for them; signals that cannot be read leave the stored value.
+func checkTokensRisk(tokens []db.TokenModel, liquidity map[string]apis.DexscreenerBatchResult) {
+	for i, token := range tokens {
+		if i > 0 {
+			time.Sleep(riskHoldersInterval)
+		}
+		params := []db.TokenSetParam{db.Token.RiskCheckedAt.Set(time.Now())}
+		if result, ok := liquidity[strings.ToLower(token.Address)]; ok {
+			params = append(params, db.Token.LiquidityUSD.Set(result.LiquidityUSD))
+		}
+		// Holders only come from Moralis; over its budget the stored concentration is kept.
+		if !usage.OverBudget(usage.Moralis) {
+			if holders, err := apis.GetTopTokenHolders(token.Address, riskHoldersRequested); err != nil {
+				log.Printf("Error getting top holders of %s: %+v", token.Address, err)
+			} else if len(holders) > 0 {
+				params = append(params, db.Token.TopHoldersPct.Set(topHoldersPct(holders, notHolders(&token))))
+			}
+		}
+
+		ctx, cancel := getCtx(context.Background())
+		var tx = getDB()
+		_, err := tx.Token.FindUnique(
+			db.Token.Address.Equals(strings.ToLower(token.Address)),
+		).Update(params...).Exec(ctx)
+		cancel()
+		invalidateTokens(token.Address)
+		if err != nil {
+			log.Printf("Error saving risk signals of %s: %+v", token.Address, err)
+		}
+	}
+}
+
+// dexscreenerLiquidity returns the Dexscreener best pair of every address it answered for.
+func dexscreenerLiquidity(addresses []string) map[string]apis.DexscreenerBatchResult {
+	liquidity := make(map[string]apis.DexscreenerBatchResult, len(addresses))
+	for i := 0; i < len(addresses); i += delistingChunkSize {
+		chunk := addresses[i:min(i+delistingChunkSize, len(addresses))]
+		data, err := apis.GetDexscreenerBatchTokenData(chunk)
+		if err != nil {
+			log.Printf("Error getting liquidity from Dexscreener: %+v", err)
+			continue
+		}
+		for address, result := range data {
+			liquidity[strings.ToLower(address)] = result
+		}
+	}
+	return liquidity
+}
+
+// CheckTokenRisk refreshes the liquidity and holder concentration of a token.
+func CheckTokenRisk(tokenAddress dto.TokenAddress) {
+	token := getToken(tokenAddress)
+	if token == nil || degrade.EnrichmentDisabled() {
+		return
+	}
+	checkTokensRisk([]db.TokenModel{*token}, dexscreenerLiquidity([]string{token.Address}))
+}
+
+// CheckTokensRisk refreshes the risk signals of the tokens never checked or checked more than
+// riskCheckInterval ago.
+func CheckTokensRisk() {
+	if degrade.EnrichmentDisabled() {
+		return
+	}
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	var tx = getDB()
+	tokens, err := tx.Token.FindMany(
+		db.Token.Archived.Equals(false),
+		db.Token.IsFixedPrice.Equals(false),
+		db.Token.Or(
+			db.Token.RiskCheckedAt.IsNull(),
+			db.Token.RiskCheckedAt.Lt(time.Now().Add(-riskCheckInterval)),
+		),
+	).OrderBy(
+		db.Token.RiskCheckedAt.Order(db.SortOrderAsc),
+	).Take(riskCheckBatch).Exec(ctx)
+	if err != nil {
+		log.Printf("Error getting tokens to check risk: %+v", err)
+		return
+	}
+	addresses := make([]string, len(tokens))
+	for i, token := range tokens {
+		addresses[i] = token.Address
+	}
+	checkTokensRisk(tokens, dexscreenerLiquidity(addresses))
+}
+
+// riskSignalsOf collects the stored security signals of a token.
+func riskSignalsOf(token *db.TokenModel, spam bool) risk.Signals {
+	signals := risk.Signals{Spam: spam, ContractIsProxy: token.ContractIsProxy}
+	if bps, ok := token.TransferTaxBps(); ok {
+		signals.TransferTaxBps = &bps
+	}
+	if score, ok := token.DeployerRiskScore(); ok {
+		signals.DeployerRiskScore = &score
+	}
+	if liquidity, ok := token.LiquidityUSD(); ok {
+		liquidity := float64(liquidity)
+		signals.LiquidityUSD = &liquidity
+	}
+	if _, ok := token.ContractCheckedAt(); ok {
+		signals.ContractVerified = &token.ContractVerified
+	}
+	if pct, ok := token.TopHoldersPct(); ok {
+		pct := float64(pct)
+		signals.TopHoldersPct = &pct
+	}
+	return signals
+}
+
+// TokenRisk scores a listed token. Listed tokens are never spam, which is blacklisted.
+func TokenRisk(token *db.TokenModel) risk.Assessment {
+	return risk.Score(riskSignalsOf(token, false))
+}
+
+// GetTokenRisk scores a token, first refreshing its liquidity and holders when refresh is set or
+// they were never checked, and returns when they were checked. Blacklisted spam tokens score 100
+// whether they are tracked or not.
+func GetTokenRisk(tokenAddress dto.TokenAddress, refresh bool) (risk.Assessment, time.Time, error) {
+	address := strings.ToLower(string(tokenAddress))
+	spam := blacklist.IsTokenInBlacklist(address)
+	token := getToken(dto.TokenAddress(address))
+	if token == nil {
+		if spam {
+			return risk.Score(risk.Signals{Spam: true}), time.Time{}, nil
+		}
+		return risk.Assessment{}, time.Time{}, ErrTokenNotFound
+	}
+	if _, checked := token.RiskCheckedAt(); refresh || !checked {
+		CheckTokenRisk(dto.TokenAddress(address))
+		if refreshed := getToken(dto.TokenAddress(address)); refreshed != nil {
+			token = refreshed
+		}
+	}
+	checkedAt, _ := token.RiskCheckedAt()
+	return risk.Score(riskSignalsOf(token, spam)), checkedAt, nil
+}
@@ -0,0 +1,21 @@
+package tokenRepository
+
+import (
+	"slices"
+	"testing"
+	"tokendata/lib/apis"
+)
+
+func TestTopHoldersPctLeavesOutPoolsAndBurns(t *testing.T) {
+	holders := []apis.TokenHolder{
+		{OwnerAddress: "0xPool", PercentageOfSupply: 40},
+		{OwnerAddress: "0x000000000000000000000000000000000000dEaD", PercentageOfSupply: 20},
+	}
+	for i := 0; i < riskTopHolders+2; i++ {
+		holders = append(holders, apis.TokenHolder{OwnerAddress: "0xholder", PercentageOfSupply: 3})
+	}
+	excluded := slices.Concat(burnAddresses, []string{"0xpool"})
+	if got, want := topHoldersPct(holders, excluded), 3.0*riskTopHolders; got != want {
+		t.Errorf("topHoldersPct = %v, want %v", got, want)
+	}
+}
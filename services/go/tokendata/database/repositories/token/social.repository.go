@@ -0,0 +1,116 @@
+package tokenRepository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+	dto "tokendata/database/dto"
+	db "tokendata/generated/prisma"
+	"tokendata/lib/apis"
+)
+
+const (
+	// Momentum is measured over the last hour of casts and refreshed while a launch is in the
+	// launch feed, its first day.
+	farcasterWindow        = time.Hour
+	farcasterRefreshAfter  = 10 * time.Minute
+	farcasterLaunchAge     = 24 * time.Hour
+	farcasterRefreshBatch  = 50
+	farcasterCheckInterval = 200 * time.Millisecond
+)
+
+// RefreshFarcasterMomentum counts the recent Farcaster casts about the launches of the launch
+// feed: the launches never checked first, newest first, and then the ones checked longest ago,
+// so that every launch gets its turn however many there are. Nothing is done without a Neynar
+// key.
+func RefreshFarcasterMomentum() {
+	tokens, err := farcasterRefreshCandidates(time.Now())
+	if err != nil {
+		log.Printf("Error getting launches to refresh Farcaster momentum: %+v", err)
+		return
+	}
+	for i, token := range tokens {
+		if i > 0 {
+			time.Sleep(farcasterCheckInterval)
+		}
+		checkedAt := time.Now()
+		momentum, err := apis.GetFarcasterMomentum(token.Address, checkedAt.Add(-farcasterWindow))
+		if errors.Is(err, apis.ErrNeynarDisabled) {
+			return
+		}
+		if err != nil {
+			log.Printf("Error getting Farcaster momentum of %s: %+v", token.Address, err)
+			continue
+		}
+		saveFarcasterMomentum(token.Address, momentum, checkedAt)
+	}
+}
+
+// farcasterRefreshCandidates returns up to farcasterRefreshBatch launches of the launch feed due
+// for a Farcaster check.
+func farcasterRefreshCandidates(now time.Time) ([]db.TokenModel, error) {
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	var tx = getDB()
+	launches := []db.TokenWhereParam{
+		db.Token.Archived.Equals(false),
+		db.Token.Reason.In(slices.Collect(maps.Keys(sourceTags))),
+		db.Token.CreatedAt.Gt(now.Add(-farcasterLaunchAge)),
+	}
+	unchecked, err := tx.Token.FindMany(
+		append(launches, db.Token.FarcasterCheckedAt.IsNull())...,
+	).OrderBy(
+		db.Token.CreatedAt.Order(db.SortOrderDesc),
+	).Take(farcasterRefreshBatch).Exec(ctx)
+	if err != nil || len(unchecked) == farcasterRefreshBatch {
+		return unchecked, err
+	}
+	checked, err := tx.Token.FindMany(
+		append(launches, db.Token.FarcasterCheckedAt.Lt(now.Add(-farcasterRefreshAfter)))...,
+	).OrderBy(
+		db.Token.FarcasterCheckedAt.Order(db.SortOrderAsc),
+	).Take(farcasterRefreshBatch - len(unchecked)).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return append(unchecked, checked...), nil
+}
+
+// CheckFarcasterMomentum counts the recent Farcaster casts about a single token.
+func CheckFarcasterMomentum(tokenAddress dto.TokenAddress) {
+	token := getToken(tokenAddress)
+	if token == nil {
+		return
+	}
+	checkedAt := time.Now()
+	momentum, err := apis.GetFarcasterMomentum(token.Address, checkedAt.Add(-farcasterWindow))
+	if errors.Is(err, apis.ErrNeynarDisabled) {
+		return
+	}
+	if err != nil {
+		log.Printf("Error getting Farcaster momentum of %s: %+v", token.Address, err)
+		return
+	}
+	saveFarcasterMomentum(token.Address, momentum, checkedAt)
+}
+
+func saveFarcasterMomentum(tokenAddress string, momentum apis.FarcasterMomentum, checkedAt time.Time) {
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	var tx = getDB()
+	_, err := tx.Token.FindUnique(
+		db.Token.Address.Equals(strings.ToLower(tokenAddress)),
+	).Update(
+		db.Token.FarcasterCasts1H.Set(momentum.Casts),
+		db.Token.FarcasterCasters1H.Set(momentum.Casters),
+		db.Token.FarcasterCheckedAt.Set(checkedAt),
+	).Exec(ctx)
+	invalidateTokens(tokenAddress)
+	if err != nil {
+		log.Printf("Error saving Farcaster momentum of %s: %+v", tokenAddress, err)
+	}
+}
@@ -0,0 +1,179 @@
+package tokenRepository
+
+import (
+	"context"
+	"log"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+	dto "tokendata/database/dto"
+	"tokendata/env"
+	db "tokendata/generated/prisma"
+	"tokendata/lib/degrade"
+	wsDexManager "tokendata/lib/ws/dex"
+)
+
+const (
+	defaultStalePriceAfter = 30 * time.Minute
+	// Tokens checked per run, stalest first.
+	stalePriceBatchSize = 200
+	// How long on-demand refreshes of a token are skipped after no provider could price it.
+	priceRefreshBackoff = time.Minute
+)
+
+// StalePriceAfter is the age past which a stored price is stale.
+func StalePriceAfter() time.Duration {
+	return env.STALE_PRICE_AFTER.GetEnvAsDurationOrDefault(defaultStalePriceAfter)
+}
+
+// IsPriceStale reports whether the price of a token is older than maxAge. Fixed prices never are.
+func IsPriceStale(token *db.TokenModel, maxAge time.Duration) bool {
+	return !token.IsFixedPrice && time.Since(token.LastUpdatedAt) > maxAge
+}
+
+// Occurrences since startup, logged after every run of DetectStalePrices.
+var (
+	stalePricesDetected  atomic.Int64
+	stalePricesRestarted atomic.Int64
+	stalePricesRefreshed atomic.Int64
+	stalePricesFailed    atomic.Int64
+)
+
+// DetectStalePrices finds watched tokens whose price has not been updated for the stale age of
+// their tier, hot tokens first. A token whose pool watcher lost its subscription gets a new
+// watcher; a token whose watcher is alive, or cannot be restarted, is priced from the APIs instead.
+func DetectStalePrices() {
+	tokens, err := staleTokens(time.Now())
+	if err != nil {
+		log.Printf("Error getting stale priced tokens: %+v", err)
+		return
+	}
+
+	manager := wsDexManager.GetManager()
+	for i := range tokens {
+		token := &tokens[i]
+		stalePricesDetected.Add(1)
+		if !manager.IsWatching(token.Address) {
+			if err := StartWatchingForPool(token); err != nil {
+				log.Printf("Error restarting watcher of %s: %+v", token.Address, err)
+			} else if manager.IsWatching(token.Address) {
+				log.Printf("Restarted pool watcher of %s, price stale since %s", token.Address, token.LastUpdatedAt.Format(time.RFC3339))
+				stalePricesRestarted.Add(1)
+				continue
+			}
+		}
+		refreshStalePrice(token)
+	}
+
+	stats := manager.Stats()
+	log.Printf("Stale prices: %d stale this run; since start %d detected, %d watchers restarted, %d refreshed from APIs, %d failed, %d blacklisted watchers prevented; watchers %d alive (%d hot, %d normal, %d cold), %d dead, %d evicted; %d token update locks",
+		len(tokens), stalePricesDetected.Load(), stalePricesRestarted.Load(), stalePricesRefreshed.Load(), stalePricesFailed.Load(), blacklistedWatchersPrevented.Load(),
+		stats.Alive, stats.ByTier[wsDexManager.TierHot], stats.ByTier[wsDexManager.TierNormal], stats.ByTier[wsDexManager.TierCold], stats.Dead, stats.Evicted, TokenUpdateLocks())
+}
+
+// staleTokens returns up to stalePriceBatchSize watched tokens whose price is stale for their
+// tier, hottest and then stalest first. Candidates come from the read model once it is loaded.
+func staleTokens(now time.Time) ([]db.TokenModel, error) {
+	staleAfter := map[wsDexManager.Tier]time.Duration{}
+	for _, tier := range wsDexManager.Tiers {
+		staleAfter[tier] = StalePriceAfterFor(tier)
+	}
+	youngest := slices.Min(slices.Collect(maps.Values(staleAfter)))
+
+	watched := func(token db.TokenModel) bool {
+		return token.WatchEnabled && !token.Delisted && !token.Blacklisted && !token.Archived && !token.IsFixedPrice && now.Sub(token.LastUpdatedAt) > youngest
+	}
+	candidates, ok := tokenReadModel.list(watched)
+	if !ok {
+		ctx, cancel := getCtx(context.Background())
+		defer cancel()
+		var err error
+		candidates, err = getDB().Token.FindMany(
+			db.Token.WatchEnabled.Equals(true),
+			db.Token.Delisted.Equals(false),
+			db.Token.Blacklisted.Equals(false),
+			db.Token.Archived.Equals(false),
+			db.Token.IsFixedPrice.Equals(false),
+			db.Token.LastUpdatedAt.Lt(now.Add(-youngest)),
+		).Exec(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tiers := sortByPriority(candidates)
+	stale := []db.TokenModel{}
+	for _, token := range candidates {
+		if len(stale) == stalePriceBatchSize {
+			break
+		}
+		if now.Sub(token.LastUpdatedAt) > staleAfter[tiers[token.Address]] {
+			stale = append(stale, token)
+		}
+	}
+	return stale, nil
+}
+
+// refreshStalePrice prices a token from the APIs, reporting whether it could. Unlike
+// SaveTokenPrice it keeps the stored price when no provider answers.
+func refreshStalePrice(token *db.TokenModel) bool {
+	if degrade.PriceRefreshDisabled() {
+		stalePricesFailed.Add(1)
+		return false
+	}
+	defer lockTokenUpdate(dto.TokenAddress(token.Address))()
+
+	tokenData, source := getTokenDataAsStringWithFallback(dto.TokenAddress(token.Address))
+	if tokenData.Price == "" {
+		log.Printf("No API price for stale token %s", token.Address)
+		stalePricesFailed.Add(1)
+		return false
+	}
+	UpdateTokenPrice(dto.TokenAddress(token.Address), tokenData.Price, source)
+	stalePricesRefreshed.Add(1)
+	return true
+}
+
+var (
+	priceRefreshFailuresMu sync.Mutex
+	// priceRefreshFailures holds when on-demand refreshes last failed, by token address.
+	priceRefreshFailures = map[string]time.Time{}
+)
+
+// RefreshStaleTokenPrice prices a token a caller needs fresh from the APIs and returns it as
+// stored afterwards. Once no provider could price a token, further calls return it unchanged for
+// priceRefreshBackoff so that callers polling it do not keep every provider busy.
+func RefreshStaleTokenPrice(ctx context.Context, token *db.TokenModel) *db.TokenModel {
+	if token.Delisted || token.IsFixedPrice {
+		return token
+	}
+	priceRefreshFailuresMu.Lock()
+	failedAt, failed := priceRefreshFailures[token.Address]
+	priceRefreshFailuresMu.Unlock()
+	if failed && time.Since(failedAt) < priceRefreshBackoff {
+		return token
+	}
+
+	refreshed := refreshStalePrice(token)
+	priceRefreshFailuresMu.Lock()
+	defer priceRefreshFailuresMu.Unlock()
+	if !refreshed {
+		for address, failedAt := range priceRefreshFailures {
+			if time.Since(failedAt) >= priceRefreshBackoff {
+				delete(priceRefreshFailures, address)
+			}
+		}
+		priceRefreshFailures[token.Address] = time.Now()
+		return token
+	}
+	delete(priceRefreshFailures, token.Address)
+
+	ctx, cancel := getCtx(ctx)
+	defer cancel()
+	if fresh, err := tokenStore.FindToken(ctx, token.Address); err == nil {
+		return fresh
+	}
+	return token
+}
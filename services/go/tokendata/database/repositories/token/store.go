@@ -0,0 +1,16 @@
+package tokenRepository
+
+import "tokendata/database/store"
+
+// tokenStore serves token lookups and price updates.
+var tokenStore store.TokenStore = store.NewPrisma()
+
+// SetTokenStore replaces the store of the repository, e.g. with a mock.TokenStore in tests, and
+// returns a function restoring the previous one.
+func SetTokenStore(s store.TokenStore) (restore func()) {
+	previous := tokenStore
+	tokenStore = s
+	return func() {
+		tokenStore = previous
+	}
+}
@@ -0,0 +1,147 @@
+package tokenRepository
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+	"time"
+	dto "tokendata/database/dto"
+	db "tokendata/generated/prisma"
+	"tokendata/lib/hub"
+	wsDexManager "tokendata/lib/ws/dex"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// Supplies only change on mints and burns, so they are read again once a day.
+	supplyRefreshInterval = 24 * time.Hour
+	supplyRefreshBatch    = 200
+	supplyReadTimeout     = 10 * time.Second
+	// Mints and burns of a token within this window are read as one supply change.
+	supplyChangeDelay = 3 * time.Second
+)
+
+type tokenSupplyMessage struct {
+	TokenAddress   string `json:"tokenAddress"`
+	PreviousSupply string `json:"previousSupply"`
+	Supply         string `json:"supply"`
+	MarketCap      string `json:"marketCap"`
+	Fdv            string `json:"fdv"`
+}
+
+var (
+	pendingSupplyChangesMu sync.Mutex
+	pendingSupplyChanges   = map[string]bool{}
+)
+
+// handleSupplyChange reads the supply of a token again after it was minted or burned. The
+// amount of the event is not applied to the stored supply, which would drift on missed events;
+// the on-chain total supply is read once the changes of a transaction or block have settled.
+func handleSupplyChange(change wsDexManager.SupplyChange) {
+	address := strings.ToLower(change.TokenAddr)
+	pendingSupplyChangesMu.Lock()
+	defer pendingSupplyChangesMu.Unlock()
+	if pendingSupplyChanges[address] {
+		return
+	}
+	pendingSupplyChanges[address] = true
+	time.AfterFunc(supplyChangeDelay, func() {
+		pendingSupplyChangesMu.Lock()
+		delete(pendingSupplyChanges, address)
+		pendingSupplyChangesMu.Unlock()
+		RefreshTokenSupply(dto.TokenAddress(address))
+	})
+}
+
+// RefreshTokenSupply reads the total supply of a token on-chain and stores it in whole tokens,
+// along with the decimals of the token.
+// Subscribers of the token are sent a "supply" event when it changed.
+func RefreshTokenSupply(tokenAddress dto.TokenAddress) {
+	address := strings.ToLower(string(tokenAddress))
+	ctx, cancel := context.WithTimeout(context.Background(), supplyReadTimeout)
+	defer cancel()
+	totalSupply, decimals, err := wsDexManager.GetTokenSupply(ctx, address)
+	if err != nil {
+		log.Printf("Error reading total supply of %s: %+v", address, err)
+		return
+	}
+	supply := decimal.NewFromBigInt(totalSupply, -int32(decimals))
+
+	var tx = getDB()
+	previous, err := tx.Token.FindUnique(
+		db.Token.Address.Equals(address),
+	).Exec(ctx)
+	if err != nil {
+		log.Printf("Error getting token %s to save its supply: %+v", address, err)
+		return
+	}
+	updated, err := tx.Token.FindUnique(
+		db.Token.Address.Equals(address),
+	).Update(
+		db.Token.Supply.Set(supply.String()),
+		db.Token.Decimals.Set(decimals),
+		db.Token.SupplyUpdatedAt.Set(time.Now()),
+	).Exec(ctx)
+	invalidateTokens(address)
+	if err != nil {
+		log.Printf("Error saving total supply of %s: %+v", address, err)
+		return
+	}
+
+	if previousSupply, err := decimal.NewFromString(previous.Supply); err == nil && previousSupply.Equal(supply) {
+		return
+	}
+	marketCap, fdv := TokenValuation(updated)
+	hub.PublishToken(address, "supply", tokenSupplyMessage{
+		TokenAddress:   address,
+		PreviousSupply: previous.Supply,
+		Supply:         updated.Supply,
+		MarketCap:      marketCap,
+		Fdv:            fdv,
+	})
+}
+
+// RefreshTokenSupplies reads the supply of tokens whose supply was never read on-chain or was
+// read more than a day ago.
+func RefreshTokenSupplies() {
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	var tx = getDB()
+	tokens, err := tx.Token.FindMany(
+		db.Token.Archived.Equals(false),
+		db.Token.Or(
+			db.Token.SupplyUpdatedAt.IsNull(),
+			db.Token.SupplyUpdatedAt.Lt(time.Now().Add(-supplyRefreshInterval)),
+		),
+	).OrderBy(
+		db.Token.SupplyUpdatedAt.Order(db.SortOrderAsc),
+	).Take(supplyRefreshBatch).Exec(ctx)
+	if err != nil {
+		log.Printf("Error getting tokens to refresh supply: %+v", err)
+		return
+	}
+	for _, token := range tokens {
+		RefreshTokenSupply(dto.TokenAddress(token.Address))
+	}
+}
+
+// TokenValuation prices a token's supply at its stored price. The FDV uses the total supply; the
+// market cap uses the circulating supply when it is known and the total supply otherwise. Both
+// are "0" when the price or supply is unknown.
+func TokenValuation(token *db.TokenModel) (marketCap string, fdv string) {
+	price, err := decimal.NewFromString(token.Price)
+	if err != nil || !price.IsPositive() {
+		return "0", "0"
+	}
+	supply, err := decimal.NewFromString(token.Supply)
+	if err != nil || !supply.IsPositive() {
+		return "0", "0"
+	}
+	circulating, err := decimal.NewFromString(token.CirculatedSupply)
+	if err != nil || !circulating.IsPositive() {
+		circulating = supply
+	}
+	return circulating.Mul(price).StringFixed(2), supply.Mul(price).StringFixed(2)
+}
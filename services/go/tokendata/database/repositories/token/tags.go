@@ -0,0 +1,173 @@
+package tokenRepository
+
+import (
+	"context"
+	"log"
+	"regexp"
+	"slices"
+	"strings"
+	dto "tokendata/database/dto"
+	db "tokendata/generated/prisma"
+)
+
+// Tags say what kind of token a token is, whatever the reason it is tracked for.
+const (
+	TagClanker = "clanker"
+	TagBankr   = "bankr"
+	TagAIAgent = "ai-agent"
+	TagMeme    = "meme"
+	TagStable  = "stable"
+)
+
+// sourceTags are the tags of the tokens a discovery source adds, keyed by their reason.
+var sourceTags = map[string][]string{
+	"clanker": {TagClanker},
+	"bankr":   {TagBankr},
+}
+
+// knownTags is the tag vocabulary. Tags outside of it are dropped by BackfillTokenTags.
+var knownTags = map[string]bool{
+	TagClanker: true, TagBankr: true, TagAIAgent: true, TagMeme: true, TagStable: true, TagTrending: true,
+}
+
+// dexscreenerLabelTags maps the Dexscreener pair labels that say what kind of token a token is to
+// its tags. Most labels name the pool version or type instead, e.g. "v3", "v4" or "CLMM", and are
+// dropped.
+var dexscreenerLabelTags = map[string]string{
+	"meme":       TagMeme,
+	"memecoin":   TagMeme,
+	"ai":         TagAIAgent,
+	"ai agent":   TagAIAgent,
+	"ai-agent":   TagAIAgent,
+	"stablecoin": TagStable,
+}
+
+// labelTags returns the tags of Dexscreener pair labels.
+func labelTags(labels []string) []string {
+	tags := []string{}
+	for _, label := range labels {
+		if tag, ok := dexscreenerLabelTags[strings.ToLower(strings.TrimSpace(label))]; ok {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+var stableSymbols = map[string]bool{
+	"USDC": true, "USDBC": true, "USDT": true, "DAI": true, "USDS": true, "EURC": true, "GHO": true, "LUSD": true,
+}
+
+var (
+	aiAgentPattern = regexp.MustCompile(`(?i)\b(ai[- ]?agents?|autonomous agents?)\b`)
+	memePattern    = regexp.MustCompile(`(?i)\bmeme(coin)?s?\b`)
+)
+
+// classifyToken tags a token from what its symbol and description say about it.
+func classifyToken(symbol string, description string) []string {
+	tags := []string{}
+	if stableSymbols[strings.ToUpper(strings.TrimSpace(symbol))] {
+		tags = append(tags, TagStable)
+	}
+	if aiAgentPattern.MatchString(description) {
+		tags = append(tags, TagAIAgent)
+	}
+	if memePattern.MatchString(description) {
+		tags = append(tags, TagMeme)
+	}
+	return tags
+}
+
+// newTokenTags returns the tags a token is created with.
+func newTokenTags(reason string, symbol string) []string {
+	return mergeTags(sourceTags[strings.ToLower(reason)], classifyToken(symbol, "")...)
+}
+
+// mergeTags adds tags to existing ones, lowercased, without duplicates and sorted.
+func mergeTags(existing []string, tags ...string) []string {
+	merged := []string{}
+	for _, tag := range slices.Concat(existing, tags) {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" && !slices.Contains(merged, tag) {
+			merged = append(merged, tag)
+		}
+	}
+	slices.Sort(merged)
+	return merged
+}
+
+// AddTokenTags adds tags to a token, keeping the ones it has.
+func AddTokenTags(tokenAddress dto.TokenAddress, tags ...string) error {
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	var tx = getDB()
+
+	token, err := tx.Token.FindUnique(db.Token.Address.Equals(strings.ToLower(string(tokenAddress)))).Exec(ctx)
+	if err != nil {
+		return err
+	}
+	merged := mergeTags(token.Tags, tags...)
+	if slices.Equal(merged, token.Tags) {
+		return nil
+	}
+	_, err = tx.Token.FindUnique(
+		db.Token.Address.Equals(token.Address),
+	).Update(db.Token.Tags.Set(merged)).Exec(ctx)
+	invalidateTokens(token.Address)
+	return err
+}
+
+// tokenTagsBackfillBatch is how many tokens BackfillTokenTags reads at once.
+const tokenTagsBackfillBatch = 100
+
+// BackfillTokenTags retags the tokens kept before tags were limited to the vocabulary: tags
+// outside of it, such as the pool versions stored from Dexscreener labels, are dropped, and the
+// tags of the source, symbol and description of each token are added.
+func BackfillTokenTags() {
+	var tx = getDB()
+	after := ""
+	retagged := 0
+	for {
+		ctx, cancel := getCtx(context.Background())
+		tokens, err := tx.Token.FindMany(
+			db.Token.Address.Gt(after),
+		).OrderBy(
+			db.Token.Address.Order(db.SortOrderAsc),
+		).Take(tokenTagsBackfillBatch).Exec(ctx)
+		cancel()
+		if err != nil {
+			log.Printf("Error getting tokens to retag: %+v", err)
+			break
+		}
+		for _, token := range tokens {
+			after = token.Address
+			tags := backfilledTags(token)
+			if slices.Equal(tags, token.Tags) {
+				continue
+			}
+			ctx, cancel := getCtx(context.Background())
+			_, err := tx.Token.FindUnique(
+				db.Token.Address.Equals(token.Address),
+			).Update(db.Token.Tags.Set(tags)).Exec(ctx)
+			cancel()
+			if err != nil {
+				log.Printf("Error retagging %s: %+v", token.Address, err)
+				continue
+			}
+			invalidateTokens(token.Address)
+			retagged++
+		}
+		if len(tokens) < tokenTagsBackfillBatch {
+			break
+		}
+	}
+	log.Printf("Token tag backfill: %d tokens retagged", retagged)
+}
+
+// backfilledTags returns the tags of a token in the vocabulary, with the ones its source, symbol
+// and description call for.
+func backfilledTags(token db.TokenModel) []string {
+	kept := slices.DeleteFunc(mergeTags(nil, token.Tags...), func(tag string) bool { return !knownTags[tag] })
+	reason, _ := token.Reason()
+	description, _ := token.Description()
+	return mergeTags(kept, slices.Concat(newTokenTags(reason, token.Symbol), classifyToken("", description))...)
+}
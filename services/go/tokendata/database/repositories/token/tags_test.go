@@ -0,0 +1,47 @@
+package tokenRepository
+
+import (
+	"slices"
+	"testing"
+	db "tokendata/generated/prisma"
+)
+
+func TestNewTokenTags(t *testing.T) {
+	tests := []struct {
+		reason, symbol string
+		want           []string
+	}{
+		{"clanker", "DEGEN", []string{TagClanker}},
+		{"bankr", "BNKR", []string{TagBankr}},
+		{"Native Price", "USDC", []string{TagStable}},
+		{"wallet_token", "PEPE", []string{}},
+	}
+	for _, test := range tests {
+		if got := newTokenTags(test.reason, test.symbol); !slices.Equal(got, test.want) {
+			t.Errorf("newTokenTags(%q, %q) = %v, want %v", test.reason, test.symbol, got, test.want)
+		}
+	}
+}
+
+func TestClassifyTokenDescription(t *testing.T) {
+	got := mergeTags(labelTags([]string{"v4", "CLMM", "Meme"}), classifyToken("", "The first AI agent memecoin on Base")...)
+	if want := []string{TagAIAgent, TagMeme}; !slices.Equal(got, want) {
+		t.Errorf("tags = %v, want %v", got, want)
+	}
+	if got := classifyToken("", "Agentic payments for memorable moments"); len(got) != 0 {
+		t.Errorf("tags = %v, want none", got)
+	}
+}
+
+func TestBackfilledTags(t *testing.T) {
+	reason, description := "clanker", "An AI agent on Base"
+	token := db.TokenModel{InnerToken: db.InnerToken{
+		Reason:      &reason,
+		Symbol:      "AGENT",
+		Description: &description,
+		Tags:        []string{"v3", "V4", TagTrending},
+	}}
+	if got, want := backfilledTags(token), []string{TagAIAgent, TagClanker, TagTrending}; !slices.Equal(got, want) {
+		t.Errorf("tags = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,96 @@
+package tokenRepository
+
+import (
+	"cmp"
+	"samterminal/pkg/numeric"
+	"slices"
+	"sync"
+	"time"
+	"tokendata/env"
+	db "tokendata/generated/prisma"
+	wsDexManager "tokendata/lib/ws/dex"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	defaultHotVolume24HUSD  = 100_000.0
+	defaultHotUsingEnds     = 3
+	defaultColdVolume24HUSD = 1_000.0
+)
+
+// heldReasons are the reasons of tokens tracked because wallets hold them or clients price them.
+var heldReasons = map[string]bool{"wallet_token": true, "token_price": true}
+
+type tierConfig struct {
+	hotVolume24HUSD  float64
+	hotUsingEnds     int
+	coldVolume24HUSD float64
+}
+
+var (
+	tierConfigOnce sync.Once
+	tierSettings   tierConfig
+)
+
+func getTierConfig() tierConfig {
+	tierConfigOnce.Do(func() {
+		tierSettings = tierConfig{
+			hotVolume24HUSD:  env.TIER_HOT_VOLUME_24H_USD.GetEnvAsFloatOrDefault(defaultHotVolume24HUSD),
+			hotUsingEnds:     int(max(env.TIER_HOT_USING_ENDS.GetEnvAsNumberOrDefault(defaultHotUsingEnds), 1)),
+			coldVolume24HUSD: env.TIER_COLD_VOLUME_24H_USD.GetEnvAsFloatOrDefault(defaultColdVolume24HUSD),
+		}
+	})
+	return tierSettings
+}
+
+// tokenTier is the priority tier of a token, from how it is used, its volume and its reason.
+func tokenTier(token *db.TokenModel) wsDexManager.Tier {
+	return getTierConfig().tierOf(token)
+}
+
+// tierOf makes tokens kept for good, trending, trading heavily or held by several wallets hot, and
+// tokens only discovery tracks that barely trade cold. The 24h volume is the larger of the one from the
+// APIs and the one summed from watched swaps.
+func (c tierConfig) tierOf(token *db.TokenModel) wsDexManager.Tier {
+	volume := token.CalculatedVolume24H
+	if apiVolume, err := numeric.Parse(token.Volume24H); err == nil {
+		volume = decimal.Max(volume, apiVolume)
+	}
+	reason, _ := token.Reason()
+	_, trending := token.TrendingRank()
+	switch {
+	case token.AlwaysKeep || trending || volume.GreaterThanOrEqual(decimal.NewFromFloat(c.hotVolume24HUSD)) || (heldReasons[reason] && token.UsingEnds >= c.hotUsingEnds):
+		return wsDexManager.TierHot
+	case sourceTags[reason] != nil && token.UsingEnds <= 1 && volume.LessThan(decimal.NewFromFloat(c.coldVolume24HUSD)):
+		return wsDexManager.TierCold
+	default:
+		return wsDexManager.TierNormal
+	}
+}
+
+// StalePriceAfterFor is the age past which the price of a token of a tier is stale. Hot prices go
+// stale six times as fast as STALE_PRICE_AFTER and cold ones four times as slow, unless set with
+// the tier prefix, e.g. HOT_STALE_PRICE_AFTER.
+func StalePriceAfterFor(tier wsDexManager.Tier) time.Duration {
+	staleAfter := StalePriceAfter()
+	switch tier {
+	case wsDexManager.TierHot:
+		staleAfter /= 6
+	case wsDexManager.TierCold:
+		staleAfter *= 4
+	}
+	return env.STALE_PRICE_AFTER.ForSource(tier.String()).GetEnvAsDurationOrDefault(staleAfter)
+}
+
+// sortByPriority sorts tokens hottest first, then stalest first, and returns their tiers.
+func sortByPriority(tokens []db.TokenModel) map[string]wsDexManager.Tier {
+	tiers := make(map[string]wsDexManager.Tier, len(tokens))
+	for i := range tokens {
+		tiers[tokens[i].Address] = tokenTier(&tokens[i])
+	}
+	slices.SortFunc(tokens, func(a, b db.TokenModel) int {
+		return cmp.Or(cmp.Compare(tiers[b.Address], tiers[a.Address]), a.LastUpdatedAt.Compare(b.LastUpdatedAt))
+	})
+	return tiers
+}
@@ -0,0 +1,75 @@
+package tokenRepository
+
+import (
+	"testing"
+	"time"
+	"tokendata/database/store/mock"
+	db "tokendata/generated/prisma"
+	wsDexManager "tokendata/lib/ws/dex"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestTierOf(t *testing.T) {
+	config := tierConfig{hotVolume24HUSD: 100_000, hotUsingEnds: 3, coldVolume24HUSD: 1_000}
+	token := func(reason string, usingEnds int, volume string, calculated int64) *db.TokenModel {
+		token := mock.NewToken(testToken, "1")
+		token.InnerToken.Reason = &reason
+		token.UsingEnds = usingEnds
+		token.Volume24H = volume
+		token.CalculatedVolume24H = decimal.NewFromInt(calculated)
+		return &token
+	}
+	kept := token("clanker", 0, "0", 0)
+	kept.AlwaysKeep = true
+	trending := token("clanker", 1, "200", 0)
+	rank := 3
+	trending.InnerToken.TrendingRank = &rank
+
+	for name, tc := range map[string]struct {
+		token *db.TokenModel
+		want  wsDexManager.Tier
+	}{
+		"kept":                 {kept, wsDexManager.TierHot},
+		"trending":             {trending, wsDexManager.TierHot},
+		"high api volume":      {token("clanker", 1, "250000", 0), wsDexManager.TierHot},
+		"high swap volume":     {token("resolve", 1, "0", 150_000), wsDexManager.TierHot},
+		"held by many wallets": {token("wallet_token", 3, "10", 0), wsDexManager.TierHot},
+		"held by one wallet":   {token("wallet_token", 1, "10", 0), wsDexManager.TierNormal},
+		"trading launch":       {token("clanker", 1, "5000", 0), wsDexManager.TierNormal},
+		"used launch":          {token("bankr", 2, "0", 0), wsDexManager.TierNormal},
+		"idle launch":          {token("clanker", 1, "200", 0), wsDexManager.TierCold},
+		"unparsable volume":    {token("bankr", 0, "", 0), wsDexManager.TierCold},
+	} {
+		if got := config.tierOf(tc.token); got != tc.want {
+			t.Errorf("%s: tier = %s, want %s", name, got, tc.want)
+		}
+	}
+}
+
+func TestSortByPriority(t *testing.T) {
+	now := time.Now()
+	cold := mock.NewToken("0x1111111111111111111111111111111111111111", "1")
+	cold.LastUpdatedAt = now.Add(-time.Hour)
+	normal := mock.NewToken("0x2222222222222222222222222222222222222222", "1")
+	hotFresh := mock.NewToken("0x3333333333333333333333333333333333333333", "1")
+	hotFresh.AlwaysKeep = true
+	hotStale := mock.NewToken("0x4444444444444444444444444444444444444444", "1")
+	hotStale.AlwaysKeep = true
+	hotStale.LastUpdatedAt = now.Add(-time.Minute)
+	for _, token := range []*db.TokenModel{&cold, &normal} {
+		reason := "clanker"
+		token.InnerToken.Reason = &reason
+	}
+	normal.UsingEnds = 2
+
+	tokens := []db.TokenModel{cold, normal, hotFresh, hotStale}
+	tiers := sortByPriority(tokens)
+
+	want := []string{hotStale.Address, hotFresh.Address, normal.Address, cold.Address}
+	for i, token := range tokens {
+		if token.Address != want[i] {
+			t.Errorf("tokens[%d] = %s (%s), want %s", i, token.Address, tiers[token.Address], want[i])
+		}
+	}
+}
@@ -3,25 +3,31 @@ package tokenRepository
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
-	"math"
 	"math/big"
+	"samterminal/pkg/numeric"
+	"samterminal/pkg/telemetry"
+	"samterminal/pkg/usage"
 	"slices"
-	"strconv"
 	"strings"
-	"sync"
 	"time"
 	"tokendata/database"
 	dto "tokendata/database/dto"
 	"tokendata/database/repositories/blacklist"
+	"tokendata/env"
 	db "tokendata/generated/prisma"
+	"tokendata/lib/anchors"
 	"tokendata/lib/apis"
+	"tokendata/lib/degrade"
 	"tokendata/lib/dex"
 	dex_dto "tokendata/lib/dex/dto"
+	"tokendata/lib/hub"
 	wsDexManager "tokendata/lib/ws/dex"
 	proto "tokendata/proto/token"
 
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/shopspring/decimal"
 )
 
 func getDB() *db.PrismaClient {
@@ -33,95 +39,119 @@ func getDB() *db.PrismaClient {
 	return client
 }
 
-func getCtx() (context.Context, context.CancelFunc) {
-	ctx, cancel := context.WithCancel(context.Background())
-	return ctx, cancel
+func getCtx(ctx context.Context) (context.Context, context.CancelFunc) {
+	return telemetry.StartDBSpan(ctx)
 }
 
-var tokenUpdateLocks sync.Map
-
-func getTokenUpdateLock(tokenAddress dto.TokenAddress) *sync.Mutex {
-	key := strings.ToLower(string(tokenAddress))
-	lock, _ := tokenUpdateLocks.LoadOrStore(key, &sync.Mutex{})
-	return lock.(*sync.Mutex)
-}
-
-func getTokenDataAsStringWithFallback(tokenAddress dto.TokenAddress) dex_dto.TokenDataAsString {
+// getTokenDataAsStringWithFallback returns the data of a token from Dexscreener, or Coingecko
+// when Dexscreener fails and Coingecko is within its budget, and the source of its price.
+func getTokenDataAsStringWithFallback(tokenAddress dto.TokenAddress) (dex_dto.TokenDataAsString, dto.PriceSource) {
+	if degrade.EnrichmentDisabled() {
+		return dex_dto.TokenDataAsString{}, ""
+	}
 	data, err := apis.GetDexscreenerTokenDataAsString(string(tokenAddress))
 	if err == nil {
-		return data
+		return data, dto.PriceSourceDexscreener
+	}
+	if usage.OverBudget(usage.Coingecko) {
+		log.Printf("Dexscreener token data failed and Coingecko is over budget: token=%s err=%v", tokenAddress, err)
+		return dex_dto.TokenDataAsString{}, ""
 	}
 	log.Printf("Dexscreener token data failed, falling back to Coingecko: token=%s err=%v", tokenAddress, err)
-	return dex.GetTokenDataAsString(tokenAddress)
+	return dex.GetTokenDataAsString(tokenAddress), dto.PriceSourceCoingecko
 }
 
 func getTokenDataAndBestPoolWithFallback(tokenAddress dto.TokenAddress) (dex_dto.TokenDataAsString, dex_dto.PoolInfo) {
+	if degrade.EnrichmentDisabled() {
+		return dex_dto.TokenDataAsString{}, dex_dto.PoolInfo{}
+	}
 	data, pool, err := apis.GetDexscreenerTokenDataAndBestPool(string(tokenAddress))
 	if err == nil {
 		return data, pool
 	}
+	if usage.OverBudget(usage.Coingecko) {
+		log.Printf("Dexscreener token+pool failed and Coingecko is over budget: token=%s err=%v", tokenAddress, err)
+		return dex_dto.TokenDataAsString{}, dex_dto.PoolInfo{}
+	}
 	log.Printf("Dexscreener token+pool failed, falling back to Coingecko: token=%s err=%v", tokenAddress, err)
 	return dex.GetTokenDataAndBestPool(tokenAddress)
 }
 
 func RemoveFalseTokens() {
-	var ctx, cancel = getCtx()
+	var ctx, cancel = getCtx(context.Background())
 	var tx = getDB()
 	defer cancel()
 
-	_, err := tx.Token.FindMany(
+	tokens, err := tx.Token.FindMany(
 		db.Token.PoolAddress.Equals(""),
-		db.Token.Address.Not("0x4200000000000000000000000000000000000006"),
-	).Delete().Exec(ctx)
+		db.Token.Address.NotIn(anchors.Addresses()),
+		db.Token.Archived.Equals(false),
+	).Exec(ctx)
 
 	if err != nil {
-		log.Printf("Error removing tokens with empty pool address: %+v", err)
+		log.Printf("Error finding tokens with empty pool address: %+v", err)
 		return
 	}
+	for _, token := range tokens {
+		archiveToken(dto.TokenAddress(token.Address))
+		go wsDexManager.GetManager().StopWatching(strings.ToLower(token.Address))
+	}
 
 	// pair address is empty
-	_, err = tx.Token.FindMany(
+	tokens, err = tx.Token.FindMany(
 		db.Token.PairAddress.Equals(""),
-	).Delete().Exec(ctx)
+		db.Token.Archived.Equals(false),
+	).Exec(ctx)
 	if err != nil {
-		log.Printf("Error removing tokens with empty pair address: %+v", err)
+		log.Printf("Error finding tokens with empty pair address: %+v", err)
+		return
+	}
+	for _, token := range tokens {
+		archiveToken(dto.TokenAddress(token.Address))
+		go wsDexManager.GetManager().StopWatching(strings.ToLower(token.Address))
 	}
 }
 
+// RemoveUnusedTokens archives tokens that have not been used for longer than the retention of
+// their reason. Tokens with other reasons are kept. In dry-run mode it only logs what it would
+// archive.
 func RemoveUnusedTokens() {
-	var ctx, cancel = getCtx()
+	var ctx, cancel = getCtx(context.Background())
 	var tx = getDB()
 	defer cancel()
-	var tokens []db.TokenModel
-	tokens, _ = tx.Token.FindMany(db.Token.LastUsedAt.Lt(time.Now().Add(-time.Minute * 30))).Exec(ctx)
+	policy := loadRetentionPolicy()
+	if len(policy) == 0 {
+		return
+	}
+	dryRun := env.TOKEN_RETENTION_DRY_RUN.GetEnvAsBoolOrDefault(false)
 
-	for _, token := range tokens {
-		if token.AlwaysKeep {
-			continue
-		}
-		reason, _ := token.Reason()
-		switch reason {
-		case "wallet_token", "token_price", "clanker", "bankr":
-			removeToken(dto.TokenAddress(token.Address))
-			go wsDexManager.GetManager().StopWatching(strings.ToLower(token.Address))
-		}
+	reasons := make([]string, 0, len(policy))
+	for reason := range policy {
+		reasons = append(reasons, reason)
+	}
+	tokens, err := tx.Token.FindMany(
+		db.Token.LastUsedAt.Lt(time.Now().Add(-policy.shortest())),
+		db.Token.Reason.In(reasons),
+		db.Token.Archived.Equals(false),
+		db.Token.AlwaysKeep.Equals(false),
+	).Exec(ctx)
+	if err != nil {
+		log.Printf("Error getting unused tokens: %+v", err)
+		return
 	}
-}
 
-func AddNotAddedPairAddresses() {
-	var ctx, cancel = getCtx()
-	var tx = getDB()
-	defer cancel()
-	allPairAddresses, _ := tx.Token.FindMany(db.Token.PairAddress.Not("")).Exec(ctx)
-	for _, pairAddress := range allPairAddresses {
-		pairAddress, _ := pairAddress.PairAddress()
-		if pairAddress == "" {
+	for _, token := range tokens {
+		reason, _ := token.Reason()
+		retention, ok := policy[reason]
+		if !ok || time.Since(token.LastUsedAt) < retention {
 			continue
 		}
-		token, _ := tx.Token.FindUnique(db.Token.Address.Equals(strings.ToLower(pairAddress))).Exec(ctx)
-		if token == nil {
-			AddToTokenList(dto.TokenAddress(pairAddress), nil, nil, nil, nil, nil, nil, nil, nil)
+		if dryRun {
+			log.Printf("Retention dry run: would archive %s (%s), reason=%s unused for %s", token.Symbol, token.Address, reason, time.Since(token.LastUsedAt).Round(time.Second))
+			continue
 		}
+		archiveToken(dto.TokenAddress(token.Address))
+		go wsDexManager.GetManager().StopWatching(strings.ToLower(token.Address))
 	}
 }
 
@@ -133,7 +163,13 @@ func GetString(s *string) string {
 }
 
 func GetOrCreateToken(tokenAddress dto.TokenAddress, name *string, supply *string, circulatedSupply *string, symbol *string, imageURL *string, price *string, volume24H *string, poolType *db.DexPoolType, poolAddress *string, pairAddress *string, reason *string, initialPrice *string, alwaysKeep bool) *db.TokenModel {
-	var ctx, cancel = getCtx()
+	token, _ := getOrCreateToken(tokenAddress, name, supply, circulatedSupply, symbol, imageURL, price, volume24H, poolType, poolAddress, pairAddress, reason, initialPrice, alwaysKeep)
+	return token
+}
+
+// getOrCreateToken is GetOrCreateToken reporting whether the token was created by this call.
+func getOrCreateToken(tokenAddress dto.TokenAddress, name *string, supply *string, circulatedSupply *string, symbol *string, imageURL *string, price *string, volume24H *string, poolType *db.DexPoolType, poolAddress *string, pairAddress *string, reason *string, initialPrice *string, alwaysKeep bool) (*db.TokenModel, bool) {
+	var ctx, cancel = getCtx(context.Background())
 	var tx = getDB()
 	defer cancel()
 	var tokenTx = tx.Token.FindUnique(db.Token.Address.Equals(strings.ToLower((string(tokenAddress)))))
@@ -151,48 +187,25 @@ func GetOrCreateToken(tokenAddress dto.TokenAddress, name *string, supply *strin
 		}
 	}
 	if errors.Is(err, db.ErrNotFound) {
-		err := createToken(tokenAddress, GetString(name), GetString(supply), GetString(circulatedSupply), GetString(symbol), GetString(imageURL), GetString(price), GetString(volume24H), *poolType, GetString(poolAddress), GetString(pairAddress), GetString(reason), alwaysKeep)
-		if err != nil {
-			return nil
-		}
-		token = getToken(tokenAddress)
-		if token == nil {
-			return nil
-		}
-		return token
+		return createTokenOnce(tokenAddress, func() *db.TokenModel { return getToken(tokenAddress) }, func() error {
+			return createToken(tokenAddress, GetString(name), GetString(supply), GetString(circulatedSupply), GetString(symbol), GetString(imageURL), GetString(price), GetString(volume24H), *poolType, GetString(poolAddress), GetString(pairAddress), GetString(reason), alwaysKeep)
+		})
 	}
-	return token
+	return token, false
 }
 
 func getToken(tokenAddress dto.TokenAddress) *db.TokenModel {
-	var ctx, cancel = getCtx()
-	var tx = getDB()
+	var ctx, cancel = getCtx(context.Background())
 	defer cancel()
-	var tokenTx = tx.Token.FindUnique(db.Token.Address.Equals(strings.ToLower((string(tokenAddress)))))
-	token, err := tokenTx.Exec(ctx)
+	token, err := tokenStore.FindToken(ctx, strings.ToLower(string(tokenAddress)))
 	if err != nil {
 		return nil
 	}
-	if errors.Is(err, db.ErrNotFound) {
-		return nil
-	}
 	return token
 }
 
-func UpdateZeroPricedTokens() {
-	var ctx, cancel = getCtx()
-	var tx = getDB()
-	defer cancel()
-	var tokens []db.TokenModel
-	tokens, _ = tx.Token.FindMany(db.Token.Price.Equals("0")).Exec(ctx)
-	log.Printf("Found %d zero priced tokens", len(tokens))
-	for _, token := range tokens {
-		SaveTokenPrice(dto.TokenAddress(token.Address))
-	}
-}
-
 func RemoveUnReasonedTokens() {
-	var ctx, cancel = getCtx()
+	var ctx, cancel = getCtx(context.Background())
 	var tx = getDB()
 	defer cancel()
 	var tokens []db.TokenModel
@@ -201,42 +214,75 @@ func RemoveUnReasonedTokens() {
 }
 
 func GetAllTokensAddresses() ([]string, error) {
-	var ctx, cancel = getCtx()
-	var tx = getDB()
+	var ctx, cancel = getCtx(context.Background())
 	defer cancel()
-	var tokens []db.TokenModel
-	tokens, _ = tx.Token.FindMany().Exec(ctx)
-	var tokenAddresses []string
-	for _, token := range tokens {
-		tokenAddresses = append(tokenAddresses, token.Address)
-	}
+	tokenAddresses, _ := tokenStore.ListTokenAddresses(ctx)
 	return tokenAddresses, nil
 }
 
-func GetAllTokens(tokenAddresses []string, excludeUnsecureTokens *bool) ([]db.TokenModel, error) {
-	var ctx, cancel = getCtx()
+// ErrTokensQuery is returned when the token list could not be read, as opposed to the requested
+// tokens not being tracked.
+var ErrTokensQuery = errors.New("error querying tokens")
+
+// ErrAllTokensFiltered is returned when every requested address was removed as unsecure, so the
+// request is not mistaken for one without an address filter.
+var ErrAllTokensFiltered = errors.New("all requested tokens are unsecure")
+
+// GetAllTokens returns the tracked tokens, or the requested ones when addresses are given. Tags
+// keep the tokens having every one of them. Blacklisted tokens are left out unless
+// excludeUnsecureTokens is false.
+func GetAllTokens(ctx context.Context, tokenAddresses []string, excludeUnsecureTokens *bool, includeArchived bool, tags []string) ([]db.TokenModel, error) {
+	ctx, cancel := getCtx(ctx)
 	var tx = getDB()
 	defer cancel()
 	var tokenAddressesLower = make([]string, len(tokenAddresses))
 	for i, tokenAddress := range tokenAddresses {
 		tokenAddressesLower[i] = strings.ToLower(tokenAddress)
 	}
-	if excludeUnsecureTokens == nil || *excludeUnsecureTokens {
-		unsecureTokens, _ := blacklist.GetUnsecureTokensBlacklistAddresses()
-		for i, tokenAddress := range tokenAddressesLower {
-			if slices.Contains(unsecureTokens, tokenAddress) {
-				tokenAddressesLower = slices.Delete(tokenAddressesLower, i, 1)
-			}
+	excludeUnsecure := excludeUnsecureTokens == nil || *excludeUnsecureTokens
+	if len(tokenAddressesLower) > 0 && excludeUnsecure {
+		unsecureTokens, err := blacklist.GetUnsecureTokensBlacklistAddresses()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrTokensQuery, err)
+		}
+		tokenAddressesLower = slices.DeleteFunc(tokenAddressesLower, func(tokenAddress string) bool {
+			return slices.Contains(unsecureTokens, tokenAddress)
+		})
+		if len(tokenAddressesLower) == 0 {
+			return nil, ErrAllTokensFiltered
+		}
+	}
+	tags = mergeTags(nil, tags...)
+	requested := make(map[string]bool, len(tokenAddressesLower))
+	for _, tokenAddress := range tokenAddressesLower {
+		requested[tokenAddress] = true
+	}
+	tokens, ok := tokenReadModel.list(func(token db.TokenModel) bool {
+		return (includeArchived || !token.Archived) &&
+			(!excludeUnsecure || !token.Blacklisted) &&
+			(len(requested) == 0 || requested[token.Address]) &&
+			!slices.ContainsFunc(tags, func(tag string) bool { return !slices.Contains(token.Tags, tag) })
+	})
+	if !ok {
+		var filters []db.TokenWhereParam
+		if !includeArchived {
+			filters = append(filters, db.Token.Archived.Equals(false))
+		}
+		if excludeUnsecure {
+			filters = append(filters, db.Token.Blacklisted.Equals(false))
+		}
+		if len(tokenAddressesLower) > 0 {
+			filters = append(filters, db.Token.Address.In(tokenAddressesLower))
+		}
+		if len(tags) > 0 {
+			filters = append(filters, db.Token.Tags.HasEvery(tags))
+		}
+		var err error
+		tokens, err = tx.Token.FindMany(filters...).Exec(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrTokensQuery, err)
 		}
 	}
-	var tokens []db.TokenModel
-	if len(tokenAddressesLower) == 0 {
-		tokens, _ = tx.Token.FindMany().Exec(ctx)
-	} else {
-		tokens, _ = tx.Token.FindMany(
-			db.Token.Address.In(tokenAddressesLower),
-		).Exec(ctx)
-	}
 
 	if len(tokenAddressesLower) > 0 {
 		for _, token := range tokens {
@@ -249,71 +295,74 @@ func GetAllTokens(tokenAddresses []string, excludeUnsecureTokens *bool) ([]db.To
 	return tokens, nil
 }
 
-func GetToken(tokenAddress dto.TokenAddress) (*db.TokenModel, error) {
-	var ctx, cancel = getCtx()
-	var tx = getDB()
+// GetToken returns a token from the read model, which rereads it from the store when it changed.
+func GetToken(ctx context.Context, tokenAddress dto.TokenAddress) (*db.TokenModel, error) {
+	ctx, cancel := getCtx(ctx)
 	defer cancel()
-	var token, err = tx.Token.FindUnique(
-		db.Token.Address.Equals(strings.ToLower(string(tokenAddress))),
-	).Exec(ctx)
+	var token, err = tokenReadModel.get(ctx, string(tokenAddress))
 	if err != nil {
 		return nil, err
 	}
 	return token, nil
 }
 
+// SaveNecessaryTokens makes sure every anchor token is tracked and priced.
 func SaveNecessaryTokens() {
-	SaveNativePrice()
-	SaveCurrencyPrice()
+	for _, anchor := range anchors.All() {
+		SaveAnchorPrice(anchor)
+	}
 }
 
-func SaveCurrencyPrice() {
-	tokenAddr := dto.TokenAddress("0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913")
-	tokenData := getTokenDataAsStringWithFallback(tokenAddr)
+// SaveAnchorPrice creates an anchor token or refreshes its price. Anchors with a fixed price are
+// stored with it and never priced live.
+func SaveAnchorPrice(anchor anchors.Anchor) {
+	tokenAddr := dto.TokenAddress(anchor.Address)
 	token := getToken(tokenAddr)
-	if token != nil {
-		if !token.IsFixedPrice {
-			UpdateTokenPrice(tokenAddr, tokenData.Price)
-		}
-	} else {
+	if token == nil {
+		tokenData, _ := getTokenDataAsStringWithFallback(tokenAddr)
 		poolType := db.DexPoolTypeUniswapV3
 		pairAddress := ""
 		reason := "Native Price"
-		price := "1"
-		token := GetOrCreateToken(tokenAddr, &tokenData.Name, &tokenData.Supply, &tokenData.CirculatedSupply, &tokenData.Symbol, &tokenData.ImageURL, &price, &tokenData.Volume24H, &poolType, nil, &pairAddress, &reason, nil, true)
+		price := tokenData.Price
+		if anchor.IsFixedPrice() {
+			price = anchor.FixedPrice
+		}
+		token = GetOrCreateToken(tokenAddr, &tokenData.Name, &tokenData.Supply, &tokenData.CirculatedSupply, &tokenData.Symbol, &tokenData.ImageURL, &price, &tokenData.Volume24H, &poolType, nil, &pairAddress, &reason, nil, true)
 		if token == nil {
-			log.Printf("Error creating token: %+v", token)
+			log.Printf("Error creating anchor token %s", anchor.Symbol)
+			return
 		}
+	} else if !anchor.IsFixedPrice() && !degrade.PriceRefreshDisabled() {
+		tokenData, source := getTokenDataAsStringWithFallback(tokenAddr)
+		UpdateTokenPrice(tokenAddr, tokenData.Price, source)
 	}
-}
 
-func SaveNativePrice() {
-	tokenAddr := dto.TokenAddress("0x4200000000000000000000000000000000000006")
-	tokenData := getTokenDataAsStringWithFallback(tokenAddr)
-	token := getToken(tokenAddr)
-	if token != nil {
-		if !token.IsFixedPrice {
-			UpdateTokenPrice(tokenAddr, tokenData.Price)
-		}
-	} else {
-		poolType := db.DexPoolTypeUniswapV3
-		poolAddress := ""
-		pairAddress := ""
-		reason := "Native Price"
-		token := GetOrCreateToken(tokenAddr, &tokenData.Name, &tokenData.Supply, &tokenData.CirculatedSupply, &tokenData.Symbol, &tokenData.ImageURL, &tokenData.Price, &tokenData.Volume24H, &poolType, &poolAddress, &pairAddress, &reason, nil, true)
-		if token == nil {
-			log.Printf("Error creating token: %+v", token)
-		}
+	// Keep the stored pricing mode in line with the configuration, which may have changed.
+	if token.IsFixedPrice == anchor.IsFixedPrice() && (!anchor.IsFixedPrice() || token.Price == anchor.FixedPrice) {
+		return
+	}
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	params := []db.TokenSetParam{db.Token.IsFixedPrice.Set(anchor.IsFixedPrice())}
+	if anchor.IsFixedPrice() {
+		params = append(params,
+			db.Token.Price.Set(anchor.FixedPrice),
+			db.Token.PriceSource.Set(string(dto.PriceSourceFixed)),
+			db.Token.PriceUpdatedAt.Set(time.Now()),
+		)
+	}
+	_, err := getDB().Token.FindUnique(db.Token.Address.Equals(token.Address)).Update(params...).Exec(ctx)
+	invalidateTokens(token.Address)
+	if err != nil {
+		log.Printf("Error updating anchor token pricing: %+v", err)
 	}
 }
 
 func SaveTokenPrice(tokenAddress dto.TokenAddress) {
-	lock := getTokenUpdateLock(tokenAddress)
-	lock.Lock()
-	defer lock.Unlock()
+	defer lockTokenUpdate(tokenAddress)()
 
 	token := getToken(tokenAddress)
-	if token == nil || token.IsFixedPrice {
+	if token == nil || token.IsFixedPrice || token.Delisted || degrade.PriceRefreshDisabled() {
 		return
 	}
 
@@ -322,26 +371,29 @@ func SaveTokenPrice(tokenAddress dto.TokenAddress) {
 	}
 
 	log.Printf("Updating price for token: %+v", tokenAddress)
-	tokenData := getTokenDataAsStringWithFallback(tokenAddress)
+	tokenData, source := getTokenDataAsStringWithFallback(tokenAddress)
 
-	UpdateTokenPrice(tokenAddress, tokenData.Price)
+	UpdateTokenPrice(tokenAddress, tokenData.Price, source)
 
 }
 
 func createToken(tokenAddress dto.TokenAddress, name string, supply string, circulatedSupply string, symbol string, imageURL string, price string, volume24H string, poolType db.DexPoolType, poolAddress string, pairAddress string, reason string, alwaysKeep bool) error {
-	ctx, cancel := getCtx()
+	ctx, cancel := getCtx(context.Background())
 	defer cancel()
 	var tx = getDB()
 
-	isTokenSecure := apis.GetIsTokenSecure(string(tokenAddress))
-	if !isTokenSecure {
+	// Pair tokens are tracked to price the tokens paired with them, whatever their security; a
+	// blacklisted pair would not be watched.
+	if reason != pairTokenReason && !apis.GetIsTokenSecure(string(tokenAddress)) {
 		err := blacklist.AddTokenToBlacklist(string(tokenAddress))
 		if err != nil {
 			log.Printf("Error adding token to blacklist: %+v", err)
 		}
 	}
 
-	_, err := tx.Token.CreateOne(
+	// An upsert leaves a token created meanwhile by another instance as it is instead of failing
+	// on the address.
+	_, err := tx.Token.UpsertOne(db.Token.Address.Equals(strings.ToLower(string(tokenAddress)))).Create(
 		db.Token.Address.Set(strings.ToLower(string(tokenAddress))),
 		db.Token.Volume24H.Set(volume24H),
 		db.Token.Price.Set(string(price)),
@@ -352,25 +404,33 @@ func createToken(tokenAddress dto.TokenAddress, name string, supply string, circ
 		db.Token.UsingEnds.Set(1),
 		db.Token.PoolType.Set(poolType),
 		db.Token.PoolAddress.Set(poolAddress),
-		db.Token.PairAddress.Set(pairAddress),
+		db.Token.PairAddress.Set(strings.ToLower(pairAddress)),
 		db.Token.PoolABI.Set(""),
 		db.Token.WatchEnabled.Set(true),
 		db.Token.CirculatedSupply.Set(string(circulatedSupply)),
 		db.Token.Reason.Set(reason),
 		db.Token.AlwaysKeep.Set(alwaysKeep),
-	).Exec(ctx)
+		db.Token.InitialPrice.Set(string(price)),
+		db.Token.Tags.Set(newTokenTags(reason, symbol)),
+	).Update().Exec(ctx)
 	if err != nil {
 		return err
 	}
+	invalidateTokens(string(tokenAddress))
+	go RefreshTokenSupply(tokenAddress)
+	go CheckTokenTrading(tokenAddress)
 	return nil
 }
 
 func StartWatchingAllPools() error {
 	log.Println("Starting watching all pools")
-	var tokens, err = GetAllTokens(nil, nil)
+	wsDexManager.GetManager().SetOnSupplyChangeHandler(handleSupplyChange)
+	var tokens, err = GetAllTokens(context.Background(), nil, nil, false, nil)
 	if err != nil {
 		return err
 	}
+	// Hot tokens are watched first so that they get a watcher when WATCHER_LIMIT is set.
+	sortByPriority(tokens)
 	for _, token := range tokens {
 		if token.IsFixedPrice {
 			continue
@@ -384,57 +444,185 @@ func StartWatchingAllPools() error {
 	return nil
 }
 
+// defaultMinSwapUSD is the swap size below which a swap only updates volume, not price.
+// Tokens can override it with their minSwapUSD column.
+const defaultMinSwapUSD = 1.0
+
+var ErrInvalidMinSwapUSD = errors.New("minSwapUsd must be a non-negative number")
+
+// minSwapUSDOf returns the swap size below which swaps of a token do not move its price: its
+// minSwapUSD, read from the read model on every swap so that a new one applies at once, or
+// MIN_SWAP_USD.
+func minSwapUSDOf(tokenAddress string) decimal.Decimal {
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	if token, err := tokenReadModel.get(ctx, tokenAddress); err == nil {
+		if minSwapUSD, ok := token.MinSwapUSD(); ok {
+			return minSwapUSD
+		}
+	}
+	return decimal.NewFromFloat(env.MIN_SWAP_USD.GetEnvAsFloatOrDefault(defaultMinSwapUSD))
+}
+
+// SetTokenMinSwapUSD sets the swap size below which swaps of a token only count towards its
+// volume. An empty minSwapUSD clears it, so that MIN_SWAP_USD applies again.
+func SetTokenMinSwapUSD(ctx context.Context, tokenAddress string, minSwapUSD string) (*db.TokenModel, error) {
+	var value *decimal.Decimal
+	if minSwapUSD = strings.TrimSpace(minSwapUSD); minSwapUSD != "" {
+		parsed, err := numeric.Parse(minSwapUSD)
+		if err != nil || parsed.IsNegative() {
+			return nil, ErrInvalidMinSwapUSD
+		}
+		value = &parsed
+	}
+	ctx, cancel := getCtx(ctx)
+	defer cancel()
+	address := strings.ToLower(strings.TrimSpace(tokenAddress))
+	token, err := tokenStore.SetMinSwapUSD(ctx, address, value)
+	invalidateTokens(address)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, ErrTokenNotFound
+	}
+	return token, err
+}
+
+type tokenPriceMessage struct {
+	TokenAddress string `json:"tokenAddress"`
+	Price        string `json:"price"`
+}
+
+type swapMessage struct {
+	TokenAddress string `json:"tokenAddress"`
+	Price        string `json:"price"`
+	AmountUSD    string `json:"amountUsd"`
+	TxHash       string `json:"txHash"`
+	Sender       string `json:"sender"`
+	Recipient    string `json:"recipient,omitempty"`
+	Maker        string `json:"maker"`
+}
+
+// publishSwap sends a swap to the hub topics of the token and of the addresses in the Swap
+// event: sender and recipient for V3 pools, sender for V4.
+func publishSwap(tokenAddress string, vLog types.Log, price string, amountUSD decimal.Decimal, isV4 bool) {
+	parties := wsDexManager.ParseSwapParties(vLog, isV4)
+	message := swapMessage{
+		TokenAddress: strings.ToLower(tokenAddress),
+		Price:        price,
+		AmountUSD:    numeric.FormatUSD(amountUSD),
+		TxHash:       vLog.TxHash.Hex(),
+		Sender:       parties.Sender,
+		Recipient:    parties.Recipient,
+		Maker:        parties.Maker(),
+	}
+	hub.PublishToken(tokenAddress, "swap", message)
+	if message.Sender != "" {
+		hub.Publish(hub.WalletTopic(message.Sender), "swap", message)
+	}
+	if message.Recipient != "" && message.Recipient != message.Sender {
+		hub.Publish(hub.WalletTopic(message.Recipient), "swap", message)
+	}
+}
+
+// pairUSDPrice returns the USD price of the pair a swap is priced against. Stable-quoted pools
+// are priced straight from the pool price, which is already adjusted for the decimals of both
+// tokens, so they neither wait on nor go stale with the stored price of the pair token.
+func pairUSDPrice(pair string) (decimal.Decimal, bool) {
+	if price, ok := anchors.StableQuote(pair); ok {
+		return price, true
+	}
+	SaveTokenPrice(dto.TokenAddress(pair))
+	pairPrice := getToken(dto.TokenAddress(pair))
+	if pairPrice == nil {
+		// The swap is lost, but the pair token is tracked for the next swaps of the pool.
+		log.Printf("Pair price not found for pair: %+v", pair)
+		go resolvePairToken(pair)
+		return decimal.Zero, false
+	}
+	pairPriceValue, err := numeric.Parse(pairPrice.Price)
+	if err != nil {
+		log.Printf("Error parsing pair price: %+v", err)
+		return decimal.Zero, false
+	}
+	return pairPriceValue, true
+}
+
 func StartWatchingForPool(token *db.TokenModel) error {
 	if token == nil {
 		return errors.New("token not found")
 	}
+	// Delisted tokens have no pool worth watching; they are watched again once relisted.
+	if token.Delisted {
+		return nil
+	}
+	// Blacklisted tokens are watched again once they are taken off the blacklist.
+	if token.Blacklisted {
+		blacklistedWatchersPrevented.Add(1)
+		return nil
+	}
+	// Swaps against a pair that cannot be priced cannot be priced either; the pool is watched
+	// again once ReconcilePairTokens prices the pair.
+	if token.PairUnpriced {
+		return nil
+	}
 	var poolAddress, _ = token.PoolAddress()
 	h := func(vLog types.Log, sqrtPriceX96 *big.Int, price *big.Float, pair string, reverse bool, tokenAmount string, tokenDecimals int) {
-		if price == nil {
+		if vLog.Removed {
+			revertSwap(vLog)
 			return
 		}
-
-		SaveTokenPrice(dto.TokenAddress(pair))
-		pairPrice := getToken(dto.TokenAddress(pair))
-		if pairPrice == nil {
-			log.Printf("Pair price not found for pair: %+v", pair)
+		if price == nil {
 			return
 		}
-		pairPriceFloat, err := strconv.ParseFloat(pairPrice.Price, 64)
-		if err != nil {
-			log.Printf("Error parsing weth price: %+v", err)
+
+		pairPriceValue, ok := pairUSDPrice(pair)
+		if !ok {
 			return
 		}
 
 		if reverse {
 			price = price.Quo(big.NewFloat(1), price)
-			price = price.Mul(price, big.NewFloat(pairPriceFloat))
-		} else {
-			price = price.Mul(price, big.NewFloat(pairPriceFloat))
 		}
 		if token.IsFixedPrice {
 			return
 		}
-		UpdateTokenPrice(dto.TokenAddress(token.Address), price.Text('f', -1))
-		tokenAmountFloat, err := strconv.ParseFloat(tokenAmount, 64)
+		// The pool price is exact to 256 bits and the pair price to its digits; neither goes
+		// through a float64, which would drop the digits of micro-cap prices.
+		usdPrice := numeric.FromBigFloat(price).Mul(pairPriceValue)
+		priceText := numeric.FormatPrice(usdPrice)
+		amount, err := decimal.NewFromString(tokenAmount)
 		if err != nil {
 			log.Printf("Error parsing token amount: %+v", err)
+			recordSwap(vLog, token.Address, decimal.Zero, func() {
+				UpdateTokenPrice(dto.TokenAddress(token.Address), priceText, dto.PriceSourceSwap)
+			})
 			return
 		}
-		volumeForSwap := price.Mul(price, big.NewFloat(tokenAmountFloat))
-		volumeForSwapFloat, _ := volumeForSwap.Float64()
-		volumeForSwapFloat = math.Abs(volumeForSwapFloat)
-
-		volumeForSwapFloat = volumeForSwapFloat / math.Pow10(tokenDecimals)
+		volumeForSwap := usdPrice.Mul(amount.Abs()).Shift(-int32(tokenDecimals))
 
-		updateCalculatedVolume24H(dto.TokenAddress(token.Address), volumeForSwapFloat)
+		// Volume counts at once and is reverted if a reorg removes the swap; the price and the
+		// trade alerts wait for the swap to be confirmed. The live swap feed is not held back.
+		recordSwap(vLog, token.Address, volumeForSwap, func() {
+			// Dust swaps still count towards volume but are too small to move the stored price.
+			if volumeForSwap.GreaterThanOrEqual(minSwapUSDOf(token.Address)) {
+				UpdateTokenPrice(dto.TokenAddress(token.Address), priceText, dto.PriceSourceSwap)
+			}
+			trade := tradeOf(token.Address, vLog, priceText, volumeForSwap, tokenAmount, tokenDecimals, token.PoolType == db.DexPoolTypeUniswapV4)
+			notifyTrade(trade)
+			recordTrade(trade)
+		})
+		publishSwap(token.Address, vLog, priceText, volumeForSwap, token.PoolType == db.DexPoolTypeUniswapV4)
 	}
 
 	isV4 := token.PoolType == db.DexPoolTypeUniswapV4
 
 	pairAddress, _ := token.PairAddress()
 
-	err := wsDexManager.GetManager().StartWatchingForPoolWithHandler(context.Background(), strings.ToLower(token.Address), strings.ToLower(pairAddress), isV4, poolAddress, h)
+	err := wsDexManager.GetManager().StartWatchingForPoolWithHandler(context.Background(), strings.ToLower(token.Address), strings.ToLower(pairAddress), isV4, poolAddress, tokenTier(token), h)
+	if errors.Is(err, wsDexManager.ErrWatcherLimit) {
+		// DetectStalePrices refreshes the price of unwatched tokens from the APIs.
+		log.Printf("Not watching pool of %s: %v", token.Address, err)
+		return nil
+	}
 	if err != nil {
 		return err
 	}
@@ -451,13 +639,35 @@ func AddToTokenList(tokenAddress dto.TokenAddress, name *string, circulatedSuppl
 		response.AddingType = proto.TokenAddingType_ADD_ERROR.Enum()
 		return response
 	}
-	if token != nil {
+	if token != nil && token.Archived {
+		token = restoreToken(tokenAddress)
+		if token == nil {
+			response.Success = false
+			response.Message = "Could not restore archived token"
+			response.AddingType = proto.TokenAddingType_ADD_ERROR.Enum()
+			return response
+		}
+		if !token.IsFixedPrice {
+			if err := StartWatchingForPool(token); err != nil {
+				log.Printf("Error starting watching for pool: %+v", err)
+			}
+		}
+		response.Success = true
+		response.Message = "Restored archived token"
+		response.AddingType = proto.TokenAddingType_FIRST_TIME.Enum()
+	} else if token != nil {
 		incrementUsingend(tokenAddress)
 		response.Success = true
 		response.Message = "Token already in list. Increment using ends"
 		response.AddingType = proto.TokenAddingType_DUPLICATE.Enum()
 	} else {
-		tokenData, best := dex.GetTokenDataAndBestPool(tokenAddress)
+		var tokenData dex_dto.TokenDataAsString
+		var best dex_dto.PoolInfo
+		if usage.OverBudget(usage.Coingecko) {
+			tokenData, best = getTokenDataAndBestPoolWithFallback(tokenAddress)
+		} else {
+			tokenData, best = dex.GetTokenDataAndBestPool(tokenAddress)
+		}
 
 		tokenName := name
 		if tokenName == nil {
@@ -521,13 +731,22 @@ func AddToTokenList(tokenAddress dto.TokenAddress, name *string, circulatedSuppl
 		if initialPrice == nil {
 			initialPrice = &tokenData.Price
 		}
-		token := GetOrCreateToken(tokenAddress, tokenName, &tokenData.Supply, tokenCirculatedSupply, tokenSymbol, tokenImage, price, &tokenData.Volume24H, &poolType, tokenPoolAddress, tokenPairAddress, reason, initialPrice, false)
+		token, created := getOrCreateToken(tokenAddress, tokenName, &tokenData.Supply, tokenCirculatedSupply, tokenSymbol, tokenImage, price, &tokenData.Volume24H, &poolType, tokenPoolAddress, tokenPairAddress, reason, initialPrice, false)
 		if token == nil {
 			response.Success = false
 			response.Message = "Could not add token to list"
 			response.AddingType = proto.TokenAddingType_ADD_ERROR.Enum()
 			return response
 		}
+		if !created {
+			// Added concurrently by another caller, which watches its pool.
+			incrementUsingend(tokenAddress)
+			response.Success = true
+			response.Message = "Token already in list. Increment using ends"
+			response.AddingType = proto.TokenAddingType_DUPLICATE.Enum()
+			return response
+		}
+		go RefreshTokenProfile(tokenAddress)
 		err := StartWatchingForPool(token)
 		if err != nil {
 			log.Printf("Error starting watching for pool: %+v", err)
@@ -556,7 +775,7 @@ func RemoveFromTokenList(tokenAddress dto.TokenAddress, bypass *bool) *dto.Respo
 		response.RemovingType = proto.TokenRemovingType_REMOVE_ERROR.Enum()
 	} else {
 		if token.UsingEnds <= 1 || (bypass != nil && *bypass) {
-			removeToken(tokenAddress)
+			archiveToken(tokenAddress)
 			response.Success = true
 			response.Message = "Removed token"
 			response.RemovingType = proto.TokenRemovingType_ALL_CLEAR.Enum()
@@ -572,73 +791,130 @@ func RemoveFromTokenList(tokenAddress dto.TokenAddress, bypass *bool) *dto.Respo
 	return response
 }
 
-func UpdateTokenPrice(tokenAddress dto.TokenAddress, price string) {
-	ctx, cancel := getCtx()
+func UpdateTokenPrice(tokenAddress dto.TokenAddress, price string, source dto.PriceSource) {
+	ctx, cancel := getCtx(context.Background())
 	defer cancel()
-	var tx = getDB()
+	address := strings.ToLower(string(tokenAddress))
 
-	var tokenTx = tx.Token.FindUnique(db.Token.Address.Equals(strings.ToLower((string(tokenAddress)))))
-	var _, err = tokenTx.Update(db.Token.Price.Set(price)).Exec(ctx)
+	// Delisted tokens keep the price they had when they were delisted.
+	updated, err := tokenStore.UpdatePrice(ctx, address, price, source)
+	defer invalidateTokens(address)
 	if err != nil {
 		log.Printf("Error updating token price: %+v", err)
+	} else if updated {
+		hub.PublishToken(string(tokenAddress), "price", tokenPriceMessage{TokenAddress: address, Price: price})
+		recordPriceHistory(map[string]string{address: price})
 	}
-	_, err = tokenTx.Update(db.Token.LastUpdatedAt.Set(time.Now())).Exec(ctx)
+	token, err := tokenStore.MarkUpdated(ctx, address)
 	if err != nil {
 		log.Printf("Error updating token price: %+v", err)
+		return
+	}
+	if updated {
+		trackPricePerformance(token)
 	}
 }
 
-func updateCalculatedVolume24H(tokenAddress dto.TokenAddress, volume float64) {
-	ctx, cancel := getCtx()
+func updateCalculatedVolume24H(tokenAddress dto.TokenAddress, volume decimal.Decimal) {
+	ctx, cancel := getCtx(context.Background())
 	defer cancel()
-	var tx = getDB()
-	var tokenTx = tx.Token.FindUnique(db.Token.Address.Equals(strings.ToLower((string(tokenAddress)))))
-	_, err := tokenTx.Update(db.Token.CalculatedVolume24H.Increment(volume)).Exec(ctx)
+	err := tokenStore.AddVolume(ctx, strings.ToLower(string(tokenAddress)), volume)
+	invalidateTokens(string(tokenAddress))
 	if err != nil {
 		log.Printf("Error updating calculated volume 24h: %+v", err)
 	}
-	_, err = tokenTx.Update(db.Token.LastUpdatedAt.Set(time.Now())).Exec(ctx)
+}
+
+func UpdateLastUsedAt(ctx context.Context, tokenAddress dto.TokenAddress) {
+	ctx, cancel := getCtx(ctx)
+	defer cancel()
+	err := tokenStore.MarkUsed(ctx, strings.ToLower(string(tokenAddress)))
+	invalidateTokens(string(tokenAddress))
 	if err != nil {
-		log.Printf("Error updating last updated at: %+v", err)
+		return
 	}
 }
 
-func UpdateLastUsedAt(tokenAddress dto.TokenAddress) {
-	ctx, cancel := getCtx()
+// archiveToken keeps the row but takes it out of default queries and watching.
+func archiveToken(tokenAddress dto.TokenAddress) {
+	ctx, cancel := getCtx(context.Background())
 	defer cancel()
 	var tx = getDB()
 	var tokenTx = tx.Token.FindUnique(db.Token.Address.Equals(strings.ToLower((string(tokenAddress)))))
-	_, err := tokenTx.Update(db.Token.LastUsedAt.Set(time.Now())).Exec(ctx)
+	_, err := tokenTx.Update(
+		db.Token.Archived.Set(true),
+		db.Token.ArchivedAt.Set(time.Now()),
+		db.Token.WatchEnabled.Set(false),
+	).Exec(ctx)
+	invalidateTokens(string(tokenAddress))
 	if err != nil {
-		return
+		log.Printf("Error archiving token: %+v", err)
 	}
 }
 
-func removeToken(tokenAddress dto.TokenAddress) {
-	ctx, cancel := getCtx()
+func restoreToken(tokenAddress dto.TokenAddress) *db.TokenModel {
+	ctx, cancel := getCtx(context.Background())
 	defer cancel()
 	var tx = getDB()
 	var tokenTx = tx.Token.FindUnique(db.Token.Address.Equals(strings.ToLower((string(tokenAddress)))))
-	_, err := tokenTx.Delete().Exec(ctx)
+	token, err := tokenTx.Update(
+		db.Token.Archived.Set(false),
+		db.Token.ArchivedAt.SetOptional(nil),
+		db.Token.WatchEnabled.Set(true),
+		db.Token.UsingEnds.Set(1),
+		db.Token.LastUsedAt.Set(time.Now()),
+	).Exec(ctx)
+	invalidateTokens(string(tokenAddress))
+	if err != nil {
+		log.Printf("Error restoring token: %+v", err)
+		return nil
+	}
+	return token
+}
+
+// PurgeArchivedTokens hard-deletes tokens that have been archived for longer than the retention window.
+func PurgeArchivedTokens() {
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	var tx = getDB()
+	retentionDays := env.ARCHIVED_TOKEN_RETENTION_DAYS.GetEnvAsNumberOrDefault(30)
+	cutoff := time.Now().Add(-time.Duration(retentionDays) * 24 * time.Hour)
+	result, err := tx.Token.FindMany(
+		db.Token.Archived.Equals(true),
+		db.Token.ArchivedAt.Lt(cutoff),
+		db.Token.AlwaysKeep.Equals(false),
+	).Delete().Exec(ctx)
 	if err != nil {
-		log.Printf("Error deleting token: %+v", err)
+		log.Printf("Error purging archived tokens: %+v", err)
+		return
+	}
+	log.Printf("Purged %d archived tokens older than %d days", result.Count, retentionDays)
+	if result.Count > 0 {
+		if err := tokenReadModel.reload(); err != nil {
+			log.Printf("Error reloading token read model: %+v", err)
+		}
 	}
 }
 
 func incrementUsingend(tokenAddress dto.TokenAddress) {
-	ctx, cancel := getCtx()
+	ctx, cancel := getCtx(context.Background())
 	defer cancel()
 	var tx = getDB()
 	var tokenTx = tx.Token.FindUnique(db.Token.Address.Equals(strings.ToLower((string(tokenAddress)))))
-	_, _ = tokenTx.Update(db.Token.UsingEnds.Increment(1)).Exec(ctx)
+	// Polled tokens are added again on every poll, so only their using ends are updated in the read model.
+	if token, err := tokenTx.Update(db.Token.UsingEnds.Increment(1)).Exec(ctx); err == nil {
+		tokenReadModel.setUsingEnds(token.Address, token.UsingEnds)
+	}
 
 }
 
 func decrementUsingend(tokenAddress dto.TokenAddress) {
-	ctx, cancel := getCtx()
+	ctx, cancel := getCtx(context.Background())
 	defer cancel()
 	var tx = getDB()
 	var tokenTx = tx.Token.FindUnique(db.Token.Address.Equals(strings.ToLower((string(tokenAddress)))))
-	_, _ = tokenTx.Update(db.Token.UsingEnds.Decrement(1)).Exec(ctx)
+	if token, err := tokenTx.Update(db.Token.UsingEnds.Decrement(1)).Exec(ctx); err == nil {
+		tokenReadModel.setUsingEnds(token.Address, token.UsingEnds)
+	}
 
 }
@@ -0,0 +1,91 @@
+package tokenRepository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"samterminal/pkg/chain"
+	"strings"
+	"time"
+	dto "tokendata/database/dto"
+	db "tokendata/generated/prisma"
+	"tokendata/lib/tradecheck"
+)
+
+const (
+	// Owners can change the tax of a token, so trading is checked again once a day.
+	tradeCheckInterval = 24 * time.Hour
+	tradeCheckBatch    = 200
+	tradeCheckTimeout  = 15 * time.Second
+)
+
+// CheckTokenTrading stores the hooks of the V4 pool of a token and the tax its transfers take,
+// which make the price differ from what traders pay. Checks that fail leave the stored value.
+func CheckTokenTrading(tokenAddress dto.TokenAddress) {
+	token := getToken(tokenAddress)
+	if token == nil {
+		return
+	}
+	poolAddress, ok := token.PoolAddress()
+	if !ok || poolAddress == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), tradeCheckTimeout)
+	defer cancel()
+
+	params := []db.TokenSetParam{db.Token.TradeCheckedAt.Set(time.Now())}
+	holder := poolAddress
+	if token.PoolType == db.DexPoolTypeUniswapV4 {
+		// V4 pools hold no tokens of their own; the PoolManager holds those of every pool.
+		holder = chain.Get().PoolManager
+		hooks, err := tradecheck.GetPoolHooks(ctx, poolAddress)
+		switch {
+		case errors.Is(err, tradecheck.ErrUnknownPool):
+		case err != nil:
+			log.Printf("Error reading pool hooks of %s: %+v", token.Address, err)
+		default:
+			params = append(params, db.Token.PoolHooks.Set(hooks.Hooks), db.Token.PoolDynamicFee.Set(hooks.DynamicFee))
+		}
+	} else {
+		params = append(params, db.Token.PoolHooks.Set(""), db.Token.PoolDynamicFee.Set(false))
+	}
+	if taxBps, err := tradecheck.GetTransferTaxBps(ctx, token.Address, holder); err != nil {
+		log.Printf("Error simulating a transfer of %s: %+v", token.Address, err)
+	} else {
+		params = append(params, db.Token.TransferTaxBps.Set(taxBps))
+	}
+
+	var tx = getDB()
+	_, err := tx.Token.FindUnique(
+		db.Token.Address.Equals(strings.ToLower(token.Address)),
+	).Update(params...).Exec(ctx)
+	invalidateTokens(token.Address)
+	if err != nil {
+		log.Printf("Error saving trade checks of %s: %+v", token.Address, err)
+	}
+}
+
+// CheckTokensTrading checks the tokens whose trading was never checked or was checked more than
+// a day ago.
+func CheckTokensTrading() {
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	var tx = getDB()
+	tokens, err := tx.Token.FindMany(
+		db.Token.Archived.Equals(false),
+		db.Token.IsFixedPrice.Equals(false),
+		db.Token.Or(
+			db.Token.TradeCheckedAt.IsNull(),
+			db.Token.TradeCheckedAt.Lt(time.Now().Add(-tradeCheckInterval)),
+		),
+	).OrderBy(
+		db.Token.TradeCheckedAt.Order(db.SortOrderAsc),
+	).Take(tradeCheckBatch).Exec(ctx)
+	if err != nil {
+		log.Printf("Error getting tokens to check trading: %+v", err)
+		return
+	}
+	for _, token := range tokens {
+		CheckTokenTrading(dto.TokenAddress(token.Address))
+	}
+}
@@ -0,0 +1,92 @@
+package tokenRepository
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+	"time"
+	websocket "tokendata/lib/ws"
+	proto "tokendata/proto/token"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// txSenderTTL is how long the sender of a transaction is remembered, long enough for all the
+// swaps of a transaction to be confirmed.
+const txSenderTTL = 10 * time.Minute
+
+type cachedTxSender struct {
+	sender string
+	at     time.Time
+}
+
+// txSenderCache remembers the account that sent a transaction, which the swaps of a routed trade
+// share.
+type txSenderCache struct {
+	fetch func(ctx context.Context, txHash string) (string, error)
+
+	mu        sync.Mutex
+	senders   map[string]cachedTxSender
+	lastSweep time.Time
+}
+
+func newTxSenderCache(fetch func(ctx context.Context, txHash string) (string, error)) *txSenderCache {
+	return &txSenderCache{fetch: fetch, senders: map[string]cachedTxSender{}}
+}
+
+var txSenders = newTxSenderCache(fetchTxSender)
+
+func fetchTxSender(ctx context.Context, txHash string) (string, error) {
+	var tx struct {
+		From common.Address `json:"from"`
+	}
+	if err := websocket.GetEthClient().Client().CallContext(ctx, &tx, "eth_getTransactionByHash", common.HexToHash(txHash)); err != nil {
+		return "", err
+	}
+	return strings.ToLower(tx.From.Hex()), nil
+}
+
+func (c *txSenderCache) get(ctx context.Context, txHash string, now time.Time) (string, error) {
+	c.mu.Lock()
+	cached, ok := c.senders[txHash]
+	c.mu.Unlock()
+	if ok && now.Sub(cached.at) < txSenderTTL {
+		return cached.sender, nil
+	}
+
+	sender, err := c.fetch(ctx, txHash)
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.senders[txHash] = cachedTxSender{sender: sender, at: now}
+	if now.Sub(c.lastSweep) >= txSenderTTL {
+		for hash, cached := range c.senders {
+			if now.Sub(cached.at) >= txSenderTTL {
+				delete(c.senders, hash)
+			}
+		}
+		c.lastSweep = now
+	}
+	return sender, nil
+}
+
+// recordTrade counts a confirmed trade towards the orderflow and the auto-watch of its token under
+// the account that sent its transaction. The addresses of a Swap event are those of the contracts
+// in between: the sender of a V4 swap, and the recipient of a V3 sell through the Universal
+// Router, is the router. A trade whose sender cannot be read counts towards the volume only.
+func recordTrade(trade *proto.TokenTrade) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		trader, err := txSenders.get(ctx, trade.TxHash, time.Now())
+		if err != nil {
+			log.Printf("Error getting sender of %s: %v", trade.TxHash, err)
+		}
+		recordOrderflowTrade(trade, trader)
+		recordAutoWatchTrade(trade, trader)
+	}()
+}
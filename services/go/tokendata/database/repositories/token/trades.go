@@ -0,0 +1,100 @@
+package tokenRepository
+
+import (
+	"log"
+	"math/big"
+	"strings"
+	"sync"
+	wsDexManager "tokendata/lib/ws/dex"
+	proto "tokendata/proto/token"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/shopspring/decimal"
+)
+
+// tradeWatcherBufferSize is how many trades a watcher may fall behind before trades are skipped.
+const tradeWatcherBufferSize = 256
+
+// TradeWatcher receives the trades of a token until it is closed.
+type TradeWatcher struct {
+	tokenAddress string
+	trades       chan *proto.TokenTrade
+	closeOnce    sync.Once
+}
+
+var (
+	tradeWatchersMu sync.RWMutex
+	tradeWatchers   = map[string]map[*TradeWatcher]bool{}
+)
+
+// WatchTrades starts receiving the trades of a token. Callers must Close the watcher when done.
+func WatchTrades(tokenAddress string) *TradeWatcher {
+	w := &TradeWatcher{tokenAddress: strings.ToLower(tokenAddress), trades: make(chan *proto.TokenTrade, tradeWatcherBufferSize)}
+	tradeWatchersMu.Lock()
+	defer tradeWatchersMu.Unlock()
+	if tradeWatchers[w.tokenAddress] == nil {
+		tradeWatchers[w.tokenAddress] = map[*TradeWatcher]bool{}
+	}
+	tradeWatchers[w.tokenAddress][w] = true
+	return w
+}
+
+// Trades returns the trades in the order they were decoded.
+func (w *TradeWatcher) Trades() <-chan *proto.TokenTrade {
+	return w.trades
+}
+
+func (w *TradeWatcher) Close() {
+	w.closeOnce.Do(func() {
+		tradeWatchersMu.Lock()
+		defer tradeWatchersMu.Unlock()
+		delete(tradeWatchers[w.tokenAddress], w)
+		if len(tradeWatchers[w.tokenAddress]) == 0 {
+			delete(tradeWatchers, w.tokenAddress)
+		}
+	})
+}
+
+// notifyTrade sends a trade to the watchers of its token without blocking. A trade tape is of
+// no use late, so watchers that are behind skip the trade rather than hold up the swap handler.
+func notifyTrade(trade *proto.TokenTrade) {
+	tradeWatchersMu.RLock()
+	defer tradeWatchersMu.RUnlock()
+	for w := range tradeWatchers[trade.TokenAddress] {
+		select {
+		case w.trades <- trade:
+		default:
+			log.Printf("Skipping trade %s of %s for a slow watcher", trade.TxHash, trade.TokenAddress)
+		}
+	}
+}
+
+// hasTradeWatchers reports whether anyone watches the trades of a token.
+func hasTradeWatchers(tokenAddress string) bool {
+	tradeWatchersMu.RLock()
+	defer tradeWatchersMu.RUnlock()
+	return len(tradeWatchers[strings.ToLower(tokenAddress)]) > 0
+}
+
+// tradeOf builds the trade of a decoded swap. tokenAmount is the change of the token balance of
+// the pool in base units for V3 pools and of the swapper for V4 pools, so the pool paying out the
+// token is a buy on V3 and the swapper receiving it is a buy on V4.
+func tradeOf(tokenAddress string, vLog types.Log, price string, amountUSD decimal.Decimal, tokenAmount string, tokenDecimals int, isV4 bool) *proto.TokenTrade {
+	trade := &proto.TokenTrade{
+		TokenAddress: strings.ToLower(tokenAddress),
+		Side:         proto.TradeSide_TRADE_SELL,
+		AmountUsd:    amountUSD.InexactFloat64(),
+		Price:        price,
+		TxHash:       vLog.TxHash.Hex(),
+		BlockNumber:  vLog.BlockNumber,
+	}
+	if amount, ok := new(big.Int).SetString(tokenAmount, 10); ok {
+		if (amount.Sign() < 0) != isV4 && amount.Sign() != 0 {
+			trade.Side = proto.TradeSide_TRADE_BUY
+		}
+		trade.TokenAmount = decimal.NewFromBigInt(amount.Abs(amount), -int32(tokenDecimals)).String()
+	}
+	parties := wsDexManager.ParseSwapParties(vLog, isV4)
+	trade.Maker, trade.Sender, trade.Recipient = parties.Maker(), parties.Sender, parties.Recipient
+	return trade
+}
@@ -0,0 +1,57 @@
+package tokenRepository
+
+import (
+	"testing"
+	proto "tokendata/proto/token"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/shopspring/decimal"
+)
+
+func TestTradeOf(t *testing.T) {
+	maker := common.HexToAddress("0x3333333333333333333333333333333333333333")
+	vLog := types.Log{Topics: []common.Hash{{}, {}, common.BytesToHash(maker.Bytes())}, BlockNumber: 7}
+
+	tests := []struct {
+		name        string
+		tokenAmount string
+		isV4        bool
+		side        proto.TradeSide
+	}{
+		{"v3 pool pays out", "-1500000", false, proto.TradeSide_TRADE_BUY},
+		{"v3 pool takes in", "1500000", false, proto.TradeSide_TRADE_SELL},
+		{"v4 swapper receives", "1500000", true, proto.TradeSide_TRADE_BUY},
+		{"v4 swapper pays", "-1500000", true, proto.TradeSide_TRADE_SELL},
+	}
+	for _, test := range tests {
+		trade := tradeOf(testToken, vLog, "2", decimal.NewFromInt(3), test.tokenAmount, 6, test.isV4)
+		if trade.Side != test.side || trade.TokenAmount != "1.5" || trade.Maker != "0x3333333333333333333333333333333333333333" || trade.BlockNumber != 7 {
+			t.Errorf("%s: trade = %+v", test.name, trade)
+		}
+	}
+}
+
+func TestWatchTrades(t *testing.T) {
+	watcher := WatchTrades(testToken)
+	other := WatchTrades("0x2222222222222222222222222222222222222222")
+	defer other.Close()
+
+	notifyTrade(&proto.TokenTrade{TokenAddress: testToken, TxHash: "0xa"})
+	select {
+	case trade := <-watcher.Trades():
+		if trade.TxHash != "0xa" {
+			t.Errorf("trade = %+v", trade)
+		}
+	default:
+		t.Error("watcher of the token got no trade")
+	}
+	if len(other.Trades()) != 0 {
+		t.Error("watcher of another token got the trade")
+	}
+
+	watcher.Close()
+	if hasTradeWatchers(testToken) {
+		t.Error("closed watcher still registered")
+	}
+}
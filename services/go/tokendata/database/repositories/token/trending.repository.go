@@ -0,0 +1,96 @@
+package tokenRepository
+
+import (
+	"context"
+	"log"
+	"slices"
+	"strings"
+	"time"
+	dto "tokendata/database/dto"
+	db "tokendata/generated/prisma"
+	"tokendata/lib/apis"
+	"tokendata/lib/degrade"
+)
+
+const (
+	// TagTrending marks the tokens in the Dexscreener trending list.
+	TagTrending = "trending"
+	// trendingReason is the reason of the trending tokens that were not tracked yet.
+	trendingReason = "dexscreener_trending"
+	// trendingMaxTokens bounds the ranks kept, and so the tokens a poll may add.
+	trendingMaxTokens = 50
+)
+
+// UpdateTrendingTokens ranks and tags the tokens Dexscreener promotes on our chain, adding the
+// ones not tracked yet, and untags the tokens that left the list. Trending tokens are watched
+// as hot tokens.
+func UpdateTrendingTokens() {
+	if degrade.DiscoveryDisabled() {
+		return
+	}
+	trending, err := apis.GetDexscreenerTrendingTokens()
+	if err != nil {
+		log.Printf("Error getting trending tokens from Dexscreener: %+v", err)
+		return
+	}
+	trending = trending[:min(len(trending), trendingMaxTokens)]
+
+	ranks := make(map[string]int, len(trending))
+	for _, token := range trending {
+		ranks[token.Address] = token.Rank
+		if getToken(dto.TokenAddress(token.Address)) != nil {
+			continue
+		}
+		reason := trendingReason
+		if response := AddToTokenList(dto.TokenAddress(token.Address), nil, nil, nil, nil, nil, nil, &reason, nil); !response.Success {
+			log.Printf("Could not add trending token %s: %s", token.Address, response.Message)
+		}
+	}
+
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	var tx = getDB()
+	previous, err := tx.Token.FindMany(db.Token.TrendingRank.Gte(1)).Exec(ctx)
+	if err != nil {
+		log.Printf("Error getting trending tokens: %+v", err)
+		return
+	}
+	for _, token := range previous {
+		if _, ok := ranks[token.Address]; !ok {
+			setTrendingRank(token.Address, 0)
+		}
+	}
+	for address, rank := range ranks {
+		setTrendingRank(address, rank)
+	}
+}
+
+// setTrendingRank stores the trending rank of a tracked token and tags it, or clears both for a
+// rank of 0.
+func setTrendingRank(tokenAddress string, rank int) {
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	var tx = getDB()
+	address := strings.ToLower(tokenAddress)
+	token, err := tx.Token.FindUnique(db.Token.Address.Equals(address)).Exec(ctx)
+	if err != nil {
+		return
+	}
+
+	params := []db.TokenSetParam{
+		db.Token.TrendingRank.SetOptional(nil),
+		db.Token.Tags.Set(slices.DeleteFunc(slices.Clone(token.Tags), func(tag string) bool { return tag == TagTrending })),
+	}
+	if rank > 0 {
+		params = []db.TokenSetParam{
+			db.Token.TrendingRank.Set(rank),
+			db.Token.TrendingAt.Set(time.Now()),
+			db.Token.Tags.Set(mergeTags(token.Tags, TagTrending)),
+		}
+	}
+	_, err = tx.Token.FindUnique(db.Token.Address.Equals(address)).Update(params...).Exec(ctx)
+	invalidateTokens(address)
+	if err != nil {
+		log.Printf("Error saving trending rank of %s: %+v", address, err)
+	}
+}
@@ -0,0 +1,40 @@
+package tokenRepository
+
+import (
+	"context"
+	"fmt"
+	"samterminal/pkg/usage"
+	db "tokendata/generated/prisma"
+)
+
+// usageStore shares the API call counts of the replicas through the ApiUsage table.
+type usageStore struct{}
+
+func (usageStore) Add(ctx context.Context, day string, provider string, calls int64) error {
+	_, err := getDB().Prisma.ExecuteRaw(
+		`INSERT INTO "ApiUsage" ("day", "provider", "calls") VALUES ($1, $2, $3)
+		ON CONFLICT ("day", "provider") DO UPDATE SET "calls" = "ApiUsage"."calls" + EXCLUDED."calls"`,
+		day, provider, calls,
+	).Exec(ctx)
+	return err
+}
+
+func (usageStore) Counts(ctx context.Context, day string) (map[string]int64, error) {
+	var rows []struct {
+		Provider db.RawString `json:"provider"`
+		Calls    db.RawInt    `json:"calls"`
+	}
+	if err := getDB().Prisma.QueryRaw(`SELECT "provider", "calls" FROM "ApiUsage" WHERE "day" = $1`, day).Exec(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("error getting API usage: %w", err)
+	}
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[string(row.Provider)] = int64(row.Calls)
+	}
+	return counts, nil
+}
+
+// StartUsageSync counts the API calls of all replicas towards the daily budgets.
+func StartUsageSync() {
+	usage.StartSync(usageStore{})
+}
@@ -0,0 +1,86 @@
+package tokenRepository
+
+import (
+	"context"
+	"log"
+	dto "tokendata/database/dto"
+	"tokendata/lib/apis"
+	"tokendata/lib/degrade"
+	"tokendata/lib/hub"
+
+	"github.com/shopspring/decimal"
+)
+
+// Dexscreener accepts up to 30 addresses per batch request.
+const zeroPriceChunkSize = 30
+
+// UpdateZeroPricedTokens prices the tokens that have no price yet. Their prices are read from
+// Dexscreener a chunk of tokens per request and stored in a single transaction; tokens
+// Dexscreener does not price are tried again on the next run.
+func UpdateZeroPricedTokens() {
+	if degrade.PriceRefreshDisabled() {
+		return
+	}
+	ctx, cancel := getCtx(context.Background())
+	tokens, err := tokenStore.ListZeroPricedTokens(ctx)
+	cancel()
+	if err != nil {
+		log.Printf("Error getting zero priced tokens: %+v", err)
+		return
+	}
+	addresses := []string{}
+	for _, token := range tokens {
+		if !token.IsFixedPrice && !token.Delisted {
+			addresses = append(addresses, token.Address)
+		}
+	}
+	if len(addresses) == 0 {
+		return
+	}
+
+	prices := map[string]string{}
+	for i := 0; i < len(addresses); i += zeroPriceChunkSize {
+		chunk := addresses[i:min(i+zeroPriceChunkSize, len(addresses))]
+		data, err := apis.GetDexscreenerBatchTokenData(chunk)
+		if err != nil {
+			log.Printf("Error getting Dexscreener prices of zero priced tokens: %+v", err)
+			continue
+		}
+		for address, result := range data {
+			if price, err := decimal.NewFromString(result.TokenData.Price); err == nil && price.IsPositive() {
+				prices[address] = result.TokenData.Price
+			}
+		}
+	}
+	updated := saveTokenPrices(prices, dto.PriceSourceDexscreener)
+	log.Printf("Priced %d of %d zero priced tokens", updated, len(addresses))
+}
+
+// saveTokenPrices stores the prices of many tokens at once and, like UpdateTokenPrice, sends
+// the new prices to subscribers, records them in the price history and tracks the performance
+// of the tokens. It returns how many tokens were updated.
+func saveTokenPrices(prices map[string]string, source dto.PriceSource) int {
+	if len(prices) == 0 {
+		return 0
+	}
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	updated, err := tokenStore.UpdatePrices(ctx, prices, source)
+	if err != nil {
+		log.Printf("Error saving token prices: %+v", err)
+		return 0
+	}
+	invalidateTokens(updated...)
+	recorded := make(map[string]string, len(updated))
+	for _, address := range updated {
+		recorded[address] = prices[address]
+	}
+	recordPriceHistory(recorded)
+	for _, address := range updated {
+		hub.PublishToken(address, "price", tokenPriceMessage{TokenAddress: address, Price: prices[address]})
+		if token, err := tokenStore.FindToken(ctx, address); err == nil {
+			trackPricePerformance(token)
+		}
+	}
+	return len(updated)
+}
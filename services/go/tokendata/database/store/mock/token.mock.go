@@ -0,0 +1,296 @@
+// Package mock provides in-memory stores for unit tests.
+package mock
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	dto "tokendata/database/dto"
+	"tokendata/database/store"
+	db "tokendata/generated/prisma"
+
+	"github.com/shopspring/decimal"
+)
+
+// TokenStore keeps tokens in memory. Err, when set, is returned by every call.
+type TokenStore struct {
+	mu     sync.Mutex
+	tokens map[string]db.TokenModel
+	// history is ordered by CreatedAt.
+	history []db.TokenPriceHistoryModel
+	Err     error
+}
+
+var _ store.TokenStore = (*TokenStore)(nil)
+
+func NewTokenStore(tokens ...db.TokenModel) *TokenStore {
+	s := &TokenStore{tokens: map[string]db.TokenModel{}}
+	for _, token := range tokens {
+		s.Put(token)
+	}
+	return s
+}
+
+// NewToken returns a token with the given address and price, as createToken would store it.
+func NewToken(address string, price string) db.TokenModel {
+	now := time.Now()
+	return db.TokenModel{InnerToken: db.InnerToken{
+		ID:            strings.ToLower(address),
+		Address:       strings.ToLower(address),
+		Price:         price,
+		InitialPrice:  &price,
+		Tags:          []string{},
+		Volume24H:     "0",
+		Supply:        "0",
+		PoolType:      db.DexPoolTypeUniswapV3,
+		WatchEnabled:  true,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		LastUpdatedAt: now,
+		LastUsedAt:    now,
+	}}
+}
+
+// Put adds or replaces a token.
+func (s *TokenStore) Put(token db.TokenModel) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	token.Address = strings.ToLower(token.Address)
+	s.tokens[token.Address] = token
+}
+
+// Token returns a copy of a stored token.
+func (s *TokenStore) Token(address string) (db.TokenModel, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	token, ok := s.tokens[strings.ToLower(address)]
+	return token, ok
+}
+
+// update applies fn to a stored token.
+func (s *TokenStore) update(address string, fn func(token *db.TokenModel)) (*db.TokenModel, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	token, ok := s.tokens[strings.ToLower(address)]
+	if !ok {
+		return nil, db.ErrNotFound
+	}
+	fn(&token)
+	s.tokens[token.Address] = token
+	return &token, nil
+}
+
+func (s *TokenStore) FindToken(ctx context.Context, address string) (*db.TokenModel, error) {
+	return s.update(address, func(*db.TokenModel) {})
+}
+
+func (s *TokenStore) list(keep func(token db.TokenModel) bool) ([]db.TokenModel, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	tokens := []db.TokenModel{}
+	for _, token := range s.tokens {
+		if keep(token) {
+			tokens = append(tokens, token)
+		}
+	}
+	sort.Slice(tokens, func(i, j int) bool { return tokens[i].Address < tokens[j].Address })
+	return tokens, nil
+}
+
+func (s *TokenStore) ListZeroPricedTokens(ctx context.Context) ([]db.TokenModel, error) {
+	return s.list(func(token db.TokenModel) bool { return token.Price == "0" && !token.Archived })
+}
+
+func (s *TokenStore) ListTokens(ctx context.Context, addresses []string) ([]db.TokenModel, error) {
+	return s.list(func(token db.TokenModel) bool {
+		return len(addresses) == 0 || slices.Contains(addresses, token.Address)
+	})
+}
+
+func (s *TokenStore) ListTokenAddresses(ctx context.Context) ([]string, error) {
+	tokens, err := s.list(func(token db.TokenModel) bool { return !token.Archived })
+	if err != nil {
+		return nil, err
+	}
+	addresses := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		addresses = append(addresses, token.Address)
+	}
+	return addresses, nil
+}
+
+func (s *TokenStore) UpdatePrice(ctx context.Context, address string, price string, source dto.PriceSource) (bool, error) {
+	updated := false
+	_, err := s.update(address, func(token *db.TokenModel) {
+		if !token.Delisted {
+			now := time.Now()
+			token.Price = price
+			token.InnerToken.PriceSource = optional(string(source))
+			token.InnerToken.PriceUpdatedAt = &now
+			updated = true
+		}
+	})
+	if errors.Is(err, db.ErrNotFound) {
+		return false, nil
+	}
+	return updated, err
+}
+
+func (s *TokenStore) UpdatePrices(ctx context.Context, prices map[string]string, source dto.PriceSource) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	updated := []string{}
+	for address, price := range prices {
+		token, ok := s.tokens[strings.ToLower(address)]
+		if !ok || token.Delisted {
+			continue
+		}
+		now := time.Now()
+		token.Price = price
+		token.InnerToken.PriceSource = optional(string(source))
+		token.InnerToken.PriceUpdatedAt = &now
+		token.LastUpdatedAt = now
+		s.tokens[token.Address] = token
+		updated = append(updated, token.Address)
+	}
+	sort.Strings(updated)
+	return updated, nil
+}
+
+func (s *TokenStore) MarkUpdated(ctx context.Context, address string) (*db.TokenModel, error) {
+	return s.update(address, func(token *db.TokenModel) {
+		token.LastUpdatedAt = time.Now()
+	})
+}
+
+func (s *TokenStore) AddVolume(ctx context.Context, address string, volume decimal.Decimal) error {
+	_, err := s.update(address, func(token *db.TokenModel) {
+		token.CalculatedVolume24H = token.CalculatedVolume24H.Add(volume)
+		token.LastUpdatedAt = time.Now()
+	})
+	return err
+}
+
+func (s *TokenStore) UpdatePerformance(ctx context.Context, address string, performance store.PricePerformance) error {
+	_, err := s.update(address, func(token *db.TokenModel) {
+		token.InnerToken.PriceMultiple = &performance.Multiple
+		athPrice, ok := token.AthPrice()
+		ath, err := decimal.NewFromString(athPrice)
+		price, _ := decimal.NewFromString(performance.Price)
+		if !ok || err != nil || price.GreaterThan(ath) {
+			now := time.Now()
+			token.InnerToken.AthPrice = &performance.Price
+			token.InnerToken.AthMultiple = &performance.Multiple
+			token.InnerToken.AthAt = &now
+		}
+	})
+	return err
+}
+
+func (s *TokenStore) MarkUsed(ctx context.Context, address string) error {
+	_, err := s.update(address, func(token *db.TokenModel) {
+		token.LastUsedAt = time.Now()
+	})
+	return err
+}
+
+func (s *TokenStore) SetMinSwapUSD(ctx context.Context, address string, minSwapUSD *decimal.Decimal) (*db.TokenModel, error) {
+	return s.update(address, func(token *db.TokenModel) {
+		token.InnerToken.MinSwapUSD = minSwapUSD
+	})
+}
+
+func (s *TokenStore) SavePriceHistory(ctx context.Context, prices map[string]decimal.Decimal) error {
+	now := time.Now()
+	addresses := make([]string, 0, len(prices))
+	for address := range prices {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	for _, address := range addresses {
+		if err := s.PutPrice(address, prices[address], now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PutPrice records the price of a token at a time.
+func (s *TokenStore) PutPrice(address string, price decimal.Decimal, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return s.Err
+	}
+	point := db.TokenPriceHistoryModel{InnerTokenPriceHistory: db.InnerTokenPriceHistory{
+		ID:           strings.ToLower(address) + "@" + at.Format(time.RFC3339Nano),
+		TokenAddress: strings.ToLower(address),
+		PriceUsd:     price,
+		CreatedAt:    at,
+	}}
+	i := sort.Search(len(s.history), func(i int) bool { return s.history[i].CreatedAt.After(at) })
+	s.history = slices.Insert(s.history, i, point)
+	return nil
+}
+
+// PriceHistory returns the recorded prices of a token, oldest first.
+func (s *TokenStore) PriceHistory(address string) []db.TokenPriceHistoryModel {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	points := []db.TokenPriceHistoryModel{}
+	for _, point := range s.history {
+		if point.TokenAddress == strings.ToLower(address) {
+			points = append(points, point)
+		}
+	}
+	return points
+}
+
+func (s *TokenStore) PriceAt(ctx context.Context, address string, at time.Time) (*db.TokenPriceHistoryModel, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	for i := len(s.history) - 1; i >= 0; i-- {
+		point := s.history[i]
+		if point.TokenAddress == strings.ToLower(address) && !point.CreatedAt.After(at) {
+			return &point, nil
+		}
+	}
+	return nil, db.ErrNotFound
+}
+
+func (s *TokenStore) PurgePriceHistory(ctx context.Context, before time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return 0, s.Err
+	}
+	kept := s.history[:0]
+	for _, point := range s.history {
+		if !point.CreatedAt.Before(before) {
+			kept = append(kept, point)
+		}
+	}
+	purged := len(s.history) - len(kept)
+	s.history = kept
+	return purged, nil
+}
+
+func optional[T any](value T) *T {
+	return &value
+}
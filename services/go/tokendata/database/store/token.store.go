@@ -0,0 +1,230 @@
+// Package store defines the storage operations the repositories depend on, so that they can run
+// against the database or against the in-memory implementation in store/mock.
+package store
+
+import (
+	"context"
+	"strings"
+	"time"
+	"tokendata/database"
+	dto "tokendata/database/dto"
+	db "tokendata/generated/prisma"
+
+	"github.com/shopspring/decimal"
+)
+
+// PricePerformance is the price of a token and its multiple of the initial price.
+type PricePerformance struct {
+	Price    string
+	Multiple float64
+}
+
+// TokenStore holds the tokens and their prices. Addresses are passed as stored, lowercased.
+type TokenStore interface {
+	// FindToken returns db.ErrNotFound when the token does not exist.
+	FindToken(ctx context.Context, address string) (*db.TokenModel, error)
+	// ListZeroPricedTokens returns the tokens that are not archived and have no price yet.
+	ListZeroPricedTokens(ctx context.Context) ([]db.TokenModel, error)
+	// ListTokens returns the given tokens, archived or not, or every token when addresses is
+	// empty.
+	ListTokens(ctx context.Context, addresses []string) ([]db.TokenModel, error)
+	// ListTokenAddresses returns the addresses of the tokens that are not archived.
+	ListTokenAddresses(ctx context.Context) ([]string, error)
+	// UpdatePrice sets the price of a token and where it came from unless the token is delisted,
+	// reporting whether it did.
+	UpdatePrice(ctx context.Context, address string, price string, source dto.PriceSource) (bool, error)
+	// UpdatePrices sets the prices of many tokens, all from one source, in one transaction and
+	// marks them as updated, skipping delisted tokens, and returns the addresses it updated.
+	UpdatePrices(ctx context.Context, prices map[string]string, source dto.PriceSource) ([]string, error)
+	// MarkUpdated sets the last update time of a token to now and returns the token.
+	MarkUpdated(ctx context.Context, address string) (*db.TokenModel, error)
+	// AddVolume adds swap volume to the 24h volume of a token and marks it as updated.
+	AddVolume(ctx context.Context, address string, volume decimal.Decimal) error
+	// SetMinSwapUSD sets the swap size below which swaps do not move the price of a token, nil
+	// for the default, and returns the token.
+	SetMinSwapUSD(ctx context.Context, address string, minSwapUSD *decimal.Decimal) (*db.TokenModel, error)
+	// UpdatePerformance sets the price multiple of a token and moves its all-time high to the price
+	// when the price is higher, in one statement so that concurrent updates cannot lower it.
+	UpdatePerformance(ctx context.Context, address string, performance PricePerformance) error
+	// MarkUsed sets the last use time of a token to now.
+	MarkUsed(ctx context.Context, address string) error
+	// SavePriceHistory records the USD prices of tokens at the current time.
+	SavePriceHistory(ctx context.Context, prices map[string]decimal.Decimal) error
+	// PriceAt returns the last price of a token recorded at or before at, db.ErrNotFound when
+	// there is none.
+	PriceAt(ctx context.Context, address string, at time.Time) (*db.TokenPriceHistoryModel, error)
+	// PurgePriceHistory deletes the prices recorded before a time and returns how many it deleted.
+	PurgePriceHistory(ctx context.Context, before time.Time) (int, error)
+}
+
+// Prisma is the TokenStore backed by the database.
+type Prisma struct{}
+
+func NewPrisma() *Prisma {
+	return &Prisma{}
+}
+
+func (p *Prisma) client() *db.PrismaClient {
+	if database.Client == nil {
+		database.CreateClient()
+	}
+	return database.Client
+}
+
+func (p *Prisma) FindToken(ctx context.Context, address string) (*db.TokenModel, error) {
+	return p.client().Token.FindUnique(db.Token.Address.Equals(strings.ToLower(address))).Exec(ctx)
+}
+
+func (p *Prisma) ListZeroPricedTokens(ctx context.Context) ([]db.TokenModel, error) {
+	return p.client().Token.FindMany(db.Token.Price.Equals("0"), db.Token.Archived.Equals(false)).Exec(ctx)
+}
+
+func (p *Prisma) ListTokens(ctx context.Context, addresses []string) ([]db.TokenModel, error) {
+	if len(addresses) == 0 {
+		return p.client().Token.FindMany().Exec(ctx)
+	}
+	return p.client().Token.FindMany(db.Token.Address.In(addresses)).Exec(ctx)
+}
+
+func (p *Prisma) ListTokenAddresses(ctx context.Context) ([]string, error) {
+	tokens, err := p.client().Token.FindMany(db.Token.Archived.Equals(false)).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	addresses := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		addresses = append(addresses, token.Address)
+	}
+	return addresses, nil
+}
+
+func (p *Prisma) UpdatePrice(ctx context.Context, address string, price string, source dto.PriceSource) (bool, error) {
+	updated, err := p.client().Token.FindMany(
+		db.Token.Address.Equals(strings.ToLower(address)),
+		db.Token.Delisted.Equals(false),
+	).Update(
+		db.Token.Price.Set(price),
+		db.Token.PriceSource.Set(string(source)),
+		db.Token.PriceUpdatedAt.Set(time.Now()),
+	).Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	return updated.Count > 0, nil
+}
+
+func (p *Prisma) UpdatePrices(ctx context.Context, prices map[string]string, source dto.PriceSource) ([]string, error) {
+	if len(prices) == 0 {
+		return nil, nil
+	}
+	client := p.client()
+	now := time.Now()
+	addresses := make([]string, 0, len(prices))
+	updates := make([]db.TokenManyTxResult, 0, len(prices))
+	transaction := make([]db.PrismaTransaction, 0, len(prices))
+	for address, price := range prices {
+		update := client.Token.FindMany(
+			db.Token.Address.Equals(strings.ToLower(address)),
+			db.Token.Delisted.Equals(false),
+		).Update(
+			db.Token.Price.Set(price),
+			db.Token.PriceSource.Set(string(source)),
+			db.Token.PriceUpdatedAt.Set(now),
+			db.Token.LastUpdatedAt.Set(now),
+		).Tx()
+		addresses = append(addresses, strings.ToLower(address))
+		updates = append(updates, update)
+		transaction = append(transaction, update)
+	}
+	if err := client.Prisma.Transaction(transaction...).Exec(ctx); err != nil {
+		return nil, err
+	}
+	updated := []string{}
+	for i, update := range updates {
+		if update.Result().Count > 0 {
+			updated = append(updated, addresses[i])
+		}
+	}
+	return updated, nil
+}
+
+func (p *Prisma) MarkUpdated(ctx context.Context, address string) (*db.TokenModel, error) {
+	return p.client().Token.FindUnique(
+		db.Token.Address.Equals(strings.ToLower(address)),
+	).Update(db.Token.LastUpdatedAt.Set(time.Now())).Exec(ctx)
+}
+
+func (p *Prisma) AddVolume(ctx context.Context, address string, volume decimal.Decimal) error {
+	_, err := p.client().Token.FindUnique(
+		db.Token.Address.Equals(strings.ToLower(address)),
+	).Update(
+		db.Token.CalculatedVolume24H.Increment(volume),
+		db.Token.LastUpdatedAt.Set(time.Now()),
+	).Exec(ctx)
+	return err
+}
+
+func (p *Prisma) SetMinSwapUSD(ctx context.Context, address string, minSwapUSD *decimal.Decimal) (*db.TokenModel, error) {
+	return p.client().Token.FindUnique(
+		db.Token.Address.Equals(strings.ToLower(address)),
+	).Update(db.Token.MinSwapUSD.SetOptional(minSwapUSD)).Exec(ctx)
+}
+
+// updatePerformanceQuery raises the all-time high with GREATEST. Every expression reads the row as
+// it was before the update, so the multiple and time of the high move with the price only.
+const updatePerformanceQuery = `
+UPDATE "Token" SET
+	"priceMultiple" = $2,
+	"athPrice" = GREATEST(NULLIF("athPrice", '')::numeric, $3::numeric)::text,
+	"athMultiple" = CASE WHEN NULLIF("athPrice", '') IS NULL OR $3::numeric > "athPrice"::numeric THEN $2 ELSE "athMultiple" END,
+	"athAt" = CASE WHEN NULLIF("athPrice", '') IS NULL OR $3::numeric > "athPrice"::numeric THEN now() ELSE "athAt" END,
+	"updatedAt" = now()
+WHERE "address" = $1`
+
+func (p *Prisma) UpdatePerformance(ctx context.Context, address string, performance PricePerformance) error {
+	_, err := p.client().Prisma.ExecuteRaw(
+		updatePerformanceQuery, strings.ToLower(address), performance.Multiple, performance.Price,
+	).Exec(ctx)
+	return err
+}
+
+func (p *Prisma) MarkUsed(ctx context.Context, address string) error {
+	_, err := p.client().Token.FindUnique(
+		db.Token.Address.Equals(strings.ToLower(address)),
+	).Update(db.Token.LastUsedAt.Set(time.Now())).Exec(ctx)
+	return err
+}
+
+func (p *Prisma) SavePriceHistory(ctx context.Context, prices map[string]decimal.Decimal) error {
+	if len(prices) == 0 {
+		return nil
+	}
+	client := p.client()
+	transaction := make([]db.PrismaTransaction, 0, len(prices))
+	for address, price := range prices {
+		transaction = append(transaction, client.TokenPriceHistory.CreateOne(
+			db.TokenPriceHistory.TokenAddress.Set(strings.ToLower(address)),
+			db.TokenPriceHistory.PriceUsd.Set(price),
+		).Tx())
+	}
+	return client.Prisma.Transaction(transaction...).Exec(ctx)
+}
+
+func (p *Prisma) PriceAt(ctx context.Context, address string, at time.Time) (*db.TokenPriceHistoryModel, error) {
+	return p.client().TokenPriceHistory.FindFirst(
+		db.TokenPriceHistory.TokenAddress.Equals(strings.ToLower(address)),
+		db.TokenPriceHistory.CreatedAt.Lte(at),
+	).OrderBy(
+		db.TokenPriceHistory.CreatedAt.Order(db.SortOrderDesc),
+	).Exec(ctx)
+}
+
+func (p *Prisma) PurgePriceHistory(ctx context.Context, before time.Time) (int, error) {
+	result, err := p.client().TokenPriceHistory.FindMany(
+		db.TokenPriceHistory.CreatedAt.Lt(before),
+	).Delete().Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return result.Count, nil
+}
@@ -1,89 +1,150 @@
 package env
 
 import (
-	"log"
 	"os"
-	"path/filepath"
-	"strconv"
-
-	"github.com/joho/godotenv"
+	"samterminal/pkg/config"
 )
 
-type EnvKey string
+// EnvKey is the name of an environment variable.
+type EnvKey = config.Key
 
+// DATABASE_URL, the DB_* pool settings, OTEL_EXPORTER_OTLP_ENDPOINT, API_DAILY_BUDGETS and the
+// CHAIN_* keys are read by the shared packages and declared in samterminal/pkg/config.
 const (
 	RpcSocketURL    EnvKey = "RPC_SOCKET_URL"
 	CG_API_KEY      EnvKey = "CG_API_KEY"
 	MORALIS_API_KEY EnvKey = "MORALIS_API_KEY"
-	DATABASE_URL    EnvKey = "DATABASE_URL"
+	ES_API_KEY      EnvKey = "ES_API_KEY"
 	PORT            EnvKey = "PORT"
 	HTTP_PORT       EnvKey = "HTTP_PORT"
 	HTTPS_CERT_FILE EnvKey = "HTTPS_CERT_FILE"
 	HTTPS_KEY_FILE  EnvKey = "HTTPS_KEY_FILE"
-)
+	// HTTP server limits. HTTP_AUTH_TOKENS, comma separated, makes every endpoint require one of
+	// them as a bearer token. HTTP_RATE_LIMIT is in requests per second per client IP, off when
+	// unset or 0; HTTP_TRUST_PROXY takes the client IP from X-Forwarded-For.
+	HTTP_READ_TIMEOUT     EnvKey = "HTTP_READ_TIMEOUT"
+	HTTP_WRITE_TIMEOUT    EnvKey = "HTTP_WRITE_TIMEOUT"
+	HTTP_IDLE_TIMEOUT     EnvKey = "HTTP_IDLE_TIMEOUT"
+	HTTP_MAX_HEADER_BYTES EnvKey = "HTTP_MAX_HEADER_BYTES"
+	HTTP_MAX_BODY_BYTES   EnvKey = "HTTP_MAX_BODY_BYTES"
+	HTTP_AUTH_TOKENS      EnvKey = "HTTP_AUTH_TOKENS"
+	HTTP_RATE_LIMIT       EnvKey = "HTTP_RATE_LIMIT"
+	HTTP_RATE_BURST       EnvKey = "HTTP_RATE_BURST"
+	HTTP_TRUST_PROXY      EnvKey = "HTTP_TRUST_PROXY"
 
-// mapPrefixedEnvVars maps root .env prefixed variables to standard names
-func mapPrefixedEnvVars() {
-	// Map TOKENDATA_ prefixed variables to standard names
-	prefixMappings := map[string]string{
-		"TOKENDATA_PORT":         "PORT",
-		"TOKENDATA_HTTP_PORT":    "HTTP_PORT",
-		"TOKENDATA_DATABASE_URL": "DATABASE_URL",
-		"RPC_WS_URL_BASE":        "RPC_SOCKET_URL",
-		"COINGECKO_API_KEY":      "CG_API_KEY",
-	}
+	ARCHIVED_TOKEN_RETENTION_DAYS EnvKey = "ARCHIVED_TOKEN_RETENTION_DAYS"
+	MIN_SWAP_USD                  EnvKey = "MIN_SWAP_USD"
+	DEGRADATION_MODES             EnvKey = "DEGRADATION_MODES"
+	TOKEN_RETENTION               EnvKey = "TOKEN_RETENTION"
+	TOKEN_RETENTION_DRY_RUN       EnvKey = "TOKEN_RETENTION_DRY_RUN"
+	// Blocks on top of a swap before it moves the price and sends trade alerts (default 2).
+	SWAP_CONFIRMATIONS EnvKey = "SWAP_CONFIRMATIONS"
+	// How long Dexscreener and CoinGecko responses are reused; prefix with the provider name,
+	// e.g. COINGECKO_API_CACHE_TTL, to override it per provider.
+	API_CACHE_TTL EnvKey = "API_CACHE_TTL"
+	// Anchor tokens other tokens are paired and priced against, as SYMBOL=address entries with
+	// an optional @price for fixed pricing; the first one is the wrapped native token.
+	ANCHOR_TOKENS EnvKey = "ANCHOR_TOKENS"
+	// Token image proxy: where resized images are stored and the public base URL of the HTTP
+	// endpoint used to build stable image URLs.
+	IMAGE_CACHE_DIR      EnvKey = "IMAGE_CACHE_DIR"
+	IMAGE_PROXY_BASE_URL EnvKey = "IMAGE_PROXY_BASE_URL"
+	// Delisting: tokens without updates for DELIST_STALE_AFTER are checked, and delisted once no
+	// provider lists a pool and on-chain liquidity is below DELIST_MIN_LIQUIDITY_USD.
+	DELIST_STALE_AFTER       EnvKey = "DELIST_STALE_AFTER"
+	DELIST_MIN_LIQUIDITY_USD EnvKey = "DELIST_MIN_LIQUIDITY_USD"
+	// Watched tokens without a price update for STALE_PRICE_AFTER get their pool watcher
+	// restarted or their price refreshed from the APIs. Prefix with a tier, e.g.
+	// HOT_STALE_PRICE_AFTER, to set it per tier.
+	STALE_PRICE_AFTER EnvKey = "STALE_PRICE_AFTER"
+	// Priority tiers: tokens are hot with TIER_HOT_VOLUME_24H_USD of daily volume or when held by
+	// TIER_HOT_USING_ENDS wallets, and cold when only discovery tracks them and their volume is
+	// below TIER_COLD_VOLUME_24H_USD. At most WATCHER_LIMIT pools are watched (0 is unlimited);
+	// at the limit the watchers of colder tokens make room for hotter ones.
+	TIER_HOT_VOLUME_24H_USD  EnvKey = "TIER_HOT_VOLUME_24H_USD"
+	TIER_HOT_USING_ENDS      EnvKey = "TIER_HOT_USING_ENDS"
+	TIER_COLD_VOLUME_24H_USD EnvKey = "TIER_COLD_VOLUME_24H_USD"
+	WATCHER_LIMIT            EnvKey = "WATCHER_LIMIT"
+	// A token moves to the best Dexscreener pair once it is a different Uniswap pool with at
+	// least POOL_MIGRATION_MIN_LIQUIDITY_USD.
+	POOL_MIGRATION_MIN_LIQUIDITY_USD EnvKey = "POOL_MIGRATION_MIN_LIQUIDITY_USD"
+	// Maintenance job schedules, set per job by prefixing the job name, e.g.
+	// DETECT_STALE_PRICES_CRON_INTERVAL.
+	CRON_INTERVAL EnvKey = "CRON_INTERVAL"
+	CRON_JITTER   EnvKey = "CRON_JITTER"
+	CRON_TIMEOUT  EnvKey = "CRON_TIMEOUT"
+	// Token prices are recorded at most once per PRICE_HISTORY_INTERVAL (5m by default) per token
+	// and kept for PRICE_HISTORY_RETENTION_DAYS (365 by default, 0 keeps them forever). A price
+	// is the price of a past time for PRICE_HISTORY_MAX_AGE (twice the interval by default).
+	PRICE_HISTORY_INTERVAL       EnvKey = "PRICE_HISTORY_INTERVAL"
+	PRICE_HISTORY_RETENTION_DAYS EnvKey = "PRICE_HISTORY_RETENTION_DAYS"
+	PRICE_HISTORY_MAX_AGE        EnvKey = "PRICE_HISTORY_MAX_AGE"
+	// The streamTokens change log keeps deleted tokens for TOKEN_CHANGE_RETENTION_DAYS (30 by
+	// default); consumers away for longer miss those deletions.
+	TOKEN_CHANGE_RETENTION_DAYS EnvKey = "TOKEN_CHANGE_RETENTION_DAYS"
 
-	for prefixed, standard := range prefixMappings {
-		if val := os.Getenv(prefixed); val != "" && os.Getenv(standard) == "" {
-			os.Setenv(standard, val)
-		}
-	}
-}
-
-func LoadEnv(path string) {
-	if os.Getenv("DOCKER") == "true" {
-		mapPrefixedEnvVars()
-		return
-	}
+	// Discovery tuning. Each key can be overridden per source by prefixing it with the source
+	// name, e.g. CLANKER_DISCOVERY_INTERVAL.
+	DISCOVERY_INTERVAL     EnvKey = "DISCOVERY_INTERVAL"
+	DISCOVERY_BATCH_SIZE   EnvKey = "DISCOVERY_BATCH_SIZE"
+	DEXSCREENER_CHUNK_SIZE EnvKey = "DEXSCREENER_CHUNK_SIZE"
+	// A token queued by a source is not queued again by it for DISCOVERY_DEDUP_TTL, restarts
+	// included; its discovery event is kept at least that long.
+	DISCOVERY_DEDUP_TTL EnvKey = "DISCOVERY_DEDUP_TTL"
 
-	// Try to load root .env first (single source of truth)
-	// Get the executable's directory and navigate to root
-	execPath, err := os.Executable()
-	if err == nil {
-		rootEnv := filepath.Join(filepath.Dir(execPath), "..", "..", "..", "..", ".env")
-		if err := godotenv.Load(rootEnv); err == nil {
-			log.Println("root .env file loaded")
-			mapPrefixedEnvVars()
-			return
-		}
-	}
+	// Clanker polls read the latest CLANKER_POLL_LIMIT tokens. On startup, older pages are read
+	// to catch up on tokens launched while the service was down, until a known token, a token
+	// older than CLANKER_CATCHUP_MAX_AGE or CLANKER_CATCHUP_MAX_PAGES pages.
+	CLANKER_POLL_LIMIT        EnvKey = "CLANKER_POLL_LIMIT"
+	CLANKER_CATCHUP_MAX_AGE   EnvKey = "CLANKER_CATCHUP_MAX_AGE"
+	CLANKER_CATCHUP_MAX_PAGES EnvKey = "CLANKER_CATCHUP_MAX_PAGES"
 
-	// Fallback: try relative path from working directory
-	rootEnv := filepath.Join("..", "..", "..", ".env")
-	if err := godotenv.Load(rootEnv); err == nil {
-		log.Println("root .env file loaded (relative path)")
-		mapPrefixedEnvVars()
-		return
-	}
+	// Base URLs of the external APIs. They default to the public endpoints and are only set to
+	// point the service at stubs, e.g. in the integration tests.
+	DEXSCREENER_API_URL EnvKey = "DEXSCREENER_API_URL"
+	COINGECKO_API_URL   EnvKey = "COINGECKO_API_URL"
+	MORALIS_API_URL     EnvKey = "MORALIS_API_URL"
+	ETHERSCAN_API_URL   EnvKey = "ETHERSCAN_API_URL"
+	CLANKER_API_URL     EnvKey = "CLANKER_API_URL"
+	// Neynar serves Farcaster casts for the social momentum of launches, skipped without a key.
+	NEYNAR_API_URL EnvKey = "NEYNAR_API_URL"
+	NEYNAR_API_KEY EnvKey = "NEYNAR_API_KEY"
 
-	// Fallback: try local .env
-	if err := godotenv.Load(path); err != nil {
-		log.Println("env file not found, will read from environment variables")
-	} else {
-		log.Println("local env file loaded")
-	}
-	mapPrefixedEnvVars()
-}
+	// walletdata gRPC address; defaults to MICROSERVICES_HOST and WALLETDATA_PORT.
+	WALLET_GRPC_URL EnvKey = "WALLET_GRPC_URL"
+	// Auto-discovered wallets: once a token trades AUTO_WATCH_VOLUME_1H_USD within an hour, its
+	// top AUTO_WATCH_TOP_BUYERS buyers of at least AUTO_WATCH_MIN_BUY_USD are watched by
+	// walletdata, at most AUTO_WATCH_MAX_WALLETS_PER_DAY in a day. Unset volume disables it.
+	AUTO_WATCH_VOLUME_1H_USD       EnvKey = "AUTO_WATCH_VOLUME_1H_USD"
+	AUTO_WATCH_TOP_BUYERS          EnvKey = "AUTO_WATCH_TOP_BUYERS"
+	AUTO_WATCH_MIN_BUY_USD         EnvKey = "AUTO_WATCH_MIN_BUY_USD"
+	AUTO_WATCH_MAX_WALLETS_PER_DAY EnvKey = "AUTO_WATCH_MAX_WALLETS_PER_DAY"
+)
 
-func (key EnvKey) GetEnv() string {
-	return os.Getenv(string(key))
+// prefixMappings maps the TOKENDATA_ prefixed variables of the root .env, and a few provider
+// variables, to the standard names.
+var prefixMappings = map[string]string{
+	"TOKENDATA_PORT":         "PORT",
+	"TOKENDATA_HTTP_PORT":    "HTTP_PORT",
+	"TOKENDATA_DATABASE_URL": "DATABASE_URL",
+	"RPC_WS_URL_BASE":        "RPC_SOCKET_URL",
+	"COINGECKO_API_KEY":      "CG_API_KEY",
+	"ETHERSCAN_API_KEY":      "ES_API_KEY",
 }
 
-func (key EnvKey) GetEnvAsNumber() int64 {
-	val, err := strconv.ParseInt(key.GetEnv(), 10, 64)
-	if err != nil {
-		log.Fatal(err)
-		return 0
+// LoadEnv loads the env file with config.Load and sets the gRPC address of the other service.
+func LoadEnv(path string) {
+	config.Load(path, prefixMappings)
+	// Set WALLET_GRPC_URL from WALLETDATA_PORT if not set
+	if os.Getenv("WALLET_GRPC_URL") == "" {
+		host := os.Getenv("MICROSERVICES_HOST")
+		if host == "" {
+			host = "localhost"
+		}
+		port := os.Getenv("WALLETDATA_PORT")
+		if port == "" {
+			port = "50062"
+		}
+		os.Setenv("WALLET_GRPC_URL", host+":"+port)
 	}
-	return val
 }
@@ -0,0 +1,121 @@
+package anchors
+
+import (
+	"log"
+	"samterminal/pkg/chain"
+	"strings"
+	"sync"
+	"tokendata/env"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+)
+
+// defaultAnchors are WETH and USDC of the chain, both priced live.
+func defaultAnchors() string {
+	config := chain.Get()
+	return "WETH=" + config.WETH + ",USDC=" + config.USDC
+}
+
+// Anchor is a base token that other tokens are paired with and priced against.
+type Anchor struct {
+	Address string
+	Symbol  string
+	// FixedPrice is the USD price of anchors that are not priced live, such as stablecoins.
+	FixedPrice string
+}
+
+func (a Anchor) IsFixedPrice() bool {
+	return a.FixedPrice != ""
+}
+
+var (
+	loadOnce sync.Once
+	anchors  []Anchor
+)
+
+// parse reads anchors from SYMBOL=address entries separated by commas. An entry ending in
+// @price, e.g. USDC=0x...@1, has a fixed price. Invalid entries are skipped.
+func parse(value string) []Anchor {
+	parsed := []Anchor{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		symbol, rest, ok := strings.Cut(entry, "=")
+		address, price, fixed := strings.Cut(rest, "@")
+		symbol, address, price = strings.TrimSpace(symbol), strings.TrimSpace(address), strings.TrimSpace(price)
+		if !ok || symbol == "" || !common.IsHexAddress(address) {
+			log.Printf("Ignoring invalid anchor token %q", entry)
+			continue
+		}
+		anchor := Anchor{Address: strings.ToLower(address), Symbol: symbol}
+		if fixed {
+			value, err := decimal.NewFromString(price)
+			if err != nil || !value.IsPositive() {
+				log.Printf("Ignoring invalid anchor token %q", entry)
+				continue
+			}
+			anchor.FixedPrice = value.String()
+		}
+		parsed = append(parsed, anchor)
+	}
+	return parsed
+}
+
+// All returns the anchor tokens from ANCHOR_TOKENS in priority order, falling back to WETH and
+// USDC of the chain when none are configured. The first anchor must be the chain's wrapped
+// native token.
+func All() []Anchor {
+	loadOnce.Do(func() {
+		anchors = parse(env.ANCHOR_TOKENS.GetEnv())
+		if len(anchors) == 0 {
+			anchors = parse(defaultAnchors())
+		}
+	})
+	return anchors
+}
+
+// Native returns the wrapped native token, the default pair of new launches.
+func Native() Anchor {
+	return All()[0]
+}
+
+// Addresses returns the addresses of all anchors in priority order.
+func Addresses() []string {
+	addresses := make([]string, 0, len(All()))
+	for _, anchor := range All() {
+		addresses = append(addresses, anchor.Address)
+	}
+	return addresses
+}
+
+// BySymbol finds an anchor by its symbol, ignoring case.
+func BySymbol(symbol string) (Anchor, bool) {
+	for _, anchor := range All() {
+		if strings.EqualFold(anchor.Symbol, symbol) {
+			return anchor, true
+		}
+	}
+	return Anchor{}, false
+}
+
+// StableQuote returns the USD price swaps against a pair are priced with directly, without
+// reading the stored price of the pair: 1 for the USDC of the chain, the configured price for
+// fixed-price anchors. Other pairs are priced from their stored price.
+func StableQuote(address string) (decimal.Decimal, bool) {
+	return stableQuote(strings.ToLower(address), All(), strings.ToLower(chain.Get().USDC))
+}
+
+func stableQuote(address string, anchors []Anchor, usdc string) (decimal.Decimal, bool) {
+	for _, anchor := range anchors {
+		if anchor.Address == address && anchor.IsFixedPrice() {
+			return decimal.RequireFromString(anchor.FixedPrice), true
+		}
+	}
+	if address != "" && address == usdc {
+		return decimal.NewFromInt(1), true
+	}
+	return decimal.Zero, false
+}
@@ -0,0 +1,29 @@
+package anchors
+
+import "testing"
+
+func TestStableQuote(t *testing.T) {
+	const (
+		weth = "0x4200000000000000000000000000000000000006"
+		usdc = "0x833589fcd6edb6e08f4c7c32d4f71b54bda02913"
+		dai  = "0x50c5725949a6f0c72e6c4a641f24049a917db0cb"
+	)
+	configured := parse("WETH=" + weth + ",DAI=" + dai + "@1.001")
+
+	cases := []struct {
+		address string
+		price   string
+		ok      bool
+	}{
+		{usdc, "1", true},
+		{dai, "1.001", true},
+		{weth, "0", false},
+		{"", "0", false},
+	}
+	for _, c := range cases {
+		price, ok := stableQuote(c.address, configured, usdc)
+		if ok != c.ok || price.String() != c.price {
+			t.Errorf("stableQuote(%s) = %s, %v, want %s, %v", c.address, price, ok, c.price, c.ok)
+		}
+	}
+}
@@ -3,24 +3,24 @@ package apis
 import (
 	"encoding/json"
 	"fmt"
+	"samterminal/pkg/chain"
+	"samterminal/pkg/httpclient"
+	"strings"
 	"time"
-
-	"github.com/go-resty/resty/v2"
+	"tokendata/env"
 )
 
-const (
-	clankerBaseURL = "https://www.clanker.world/api"
-	clankerChainID = 8453
-)
+const clankerAPI = "https://www.clanker.world/api"
 
-var clankerClient = resty.New().
+var clankerClient = httpclient.New().
 	SetTimeout(10 * time.Second).
 	SetRetryCount(2).
 	SetRetryWaitTime(1 * time.Second).
 	SetRetryMaxWaitTime(3 * time.Second)
 
 type ClankerTokenResponse struct {
-	Data []ClankerToken `json:"data"`
+	Data    []ClankerToken `json:"data"`
+	HasMore bool           `json:"hasMore"`
 }
 
 type ClankerToken struct {
@@ -36,20 +36,65 @@ type ClankerToken struct {
 	Type            string      `json:"type"`
 }
 
+// DeployedTime returns when the token was deployed, or the zero time when the API left it out.
+func (t ClankerToken) DeployedTime() time.Time {
+	deployedAt, err := time.Parse(time.RFC3339, t.DeployedAt)
+	if err != nil {
+		return time.Time{}
+	}
+	return deployedAt
+}
+
 func GetLatestClankerTokens(limit int) ([]ClankerToken, error) {
-	u := fmt.Sprintf("%s/tokens?sort=desc&sortBy=deployed-at&includeMarket=true&chainId=%d&limit=%d", clankerBaseURL, clankerChainID, limit)
+	page, err := GetClankerTokensPage(1, limit)
+	return page.Data, err
+}
+
+// GetClankerTokensPage returns a page of Clanker tokens, newest first. Pages start at 1.
+func GetClankerTokensPage(page int, limit int) (ClankerTokenResponse, error) {
+	u := fmt.Sprintf("%s/tokens?sort=desc&sortBy=deployed-at&includeMarket=true&chainId=%d&limit=%d&page=%d", strings.TrimRight(env.CLANKER_API_URL.GetEnvOrDefault(clankerAPI), "/"), chain.Get().ID, limit, page)
 
 	resp, err := clankerClient.R().Get(u)
 	if err != nil {
-		return nil, fmt.Errorf("clanker request failed: %w", err)
+		return ClankerTokenResponse{}, fmt.Errorf("clanker request failed: %w", err)
 	}
 	if resp.StatusCode() != 200 {
-		return nil, fmt.Errorf("clanker unexpected status: %d", resp.StatusCode())
+		return ClankerTokenResponse{}, fmt.Errorf("clanker unexpected status: %d", resp.StatusCode())
 	}
 
 	var result ClankerTokenResponse
 	if err := json.Unmarshal(resp.Body(), &result); err != nil {
-		return nil, fmt.Errorf("clanker parse error: %w", err)
+		return ClankerTokenResponse{}, fmt.Errorf("clanker parse error: %w", err)
+	}
+	return result, nil
+}
+
+// WalkClankerTokens visits tokens, newest first, limit per page, until visit returns false, a
+// token deployed before cutoff, the last page or maxPages pages. It returns the number of pages
+// read.
+func WalkClankerTokens(limit int, cutoff time.Time, maxPages int, visit func(t ClankerToken) bool) (int, error) {
+	return walkClankerPages(func(page int) (ClankerTokenResponse, error) {
+		return GetClankerTokensPage(page, limit)
+	}, cutoff, maxPages, visit)
+}
+
+func walkClankerPages(fetch func(page int) (ClankerTokenResponse, error), cutoff time.Time, maxPages int, visit func(t ClankerToken) bool) (int, error) {
+	for page := 1; page <= maxPages; page++ {
+		response, err := fetch(page)
+		if err != nil {
+			return page, err
+		}
+		for _, t := range response.Data {
+			if deployedAt := t.DeployedTime(); !deployedAt.IsZero() && deployedAt.Before(cutoff) {
+				return page, nil
+			}
+			if !visit(t) {
+				return page, nil
+			}
+		}
+		if !response.HasMore || len(response.Data) == 0 {
+			return page, nil
+		}
 	}
-	return result.Data, nil
+	return maxPages, nil
 }
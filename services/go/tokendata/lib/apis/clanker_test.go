@@ -0,0 +1,45 @@
+package apis
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestWalkClankerPages(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	token := func(i int) ClankerToken {
+		return ClankerToken{ContractAddress: fmt.Sprintf("0x%040d", i), DeployedAt: now.Add(-time.Duration(i) * time.Hour).Format(time.RFC3339)}
+	}
+	// Ten pages of two tokens, one deployed every hour.
+	fetch := func(page int) (ClankerTokenResponse, error) {
+		return ClankerTokenResponse{Data: []ClankerToken{token(2*page - 2), token(2*page - 1)}, HasMore: page < 10}, nil
+	}
+	walk := func(cutoff time.Time, maxPages int, known int) ([]string, int) {
+		visited := []string{}
+		pages, err := walkClankerPages(fetch, cutoff, maxPages, func(t ClankerToken) bool {
+			if t.ContractAddress == token(known).ContractAddress {
+				return false
+			}
+			visited = append(visited, t.ContractAddress)
+			return true
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return visited, pages
+	}
+
+	if visited, pages := walk(now.Add(-24*time.Hour), 50, 5); len(visited) != 5 || pages != 3 {
+		t.Errorf("stop at known token: visited %d tokens over %d pages, want 5 over 3", len(visited), pages)
+	}
+	if visited, pages := walk(now.Add(-3*time.Hour-time.Minute), 50, -1); len(visited) != 4 || pages != 3 {
+		t.Errorf("stop at cutoff: visited %d tokens over %d pages, want 4 over 3", len(visited), pages)
+	}
+	if visited, pages := walk(now.Add(-24*time.Hour), 2, -1); len(visited) != 4 || pages != 2 {
+		t.Errorf("stop at max pages: visited %d tokens over %d pages, want 4 over 2", len(visited), pages)
+	}
+	if visited, pages := walk(now.Add(-24*time.Hour), 50, -1); len(visited) != 20 || pages != 10 {
+		t.Errorf("stop at last page: visited %d tokens over %d pages, want 20 over 10", len(visited), pages)
+	}
+}
@@ -4,25 +4,59 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"strconv"
+	"samterminal/pkg/chain"
+	"samterminal/pkg/httpclient"
+	"samterminal/pkg/numeric"
+	"samterminal/pkg/usage"
 	"strings"
 	"time"
+	"tokendata/env"
+	"tokendata/lib/cache"
+	"tokendata/lib/degrade"
 	dexdto "tokendata/lib/dex/dto"
 
-	"github.com/go-resty/resty/v2"
+	"github.com/shopspring/decimal"
 )
 
 const (
-	dexscreenerBaseURL      = "https://api.dexscreener.com/token-pairs/v1"
-	dexscreenerTokensURL    = "https://api.dexscreener.com/tokens/v1"
-	dexscreenerChainID      = "base"
+	dexscreenerAPI          = "https://api.dexscreener.com"
+	dexscreenerBasePath     = "/token-pairs/v1"
+	dexscreenerTokensPath   = "/tokens/v1"
+	dexscreenerPairsPath    = "/latest/dex/pairs"
+	dexscreenerProfilesPath = "/token-profiles/latest/v1"
+	// Dexscreener has no trending list in its public API; its trending ranking is driven by the
+	// boosts, with the most boosted tokens first.
+	dexscreenerTopBoostsPath    = "/token-boosts/top/v1"
+	dexscreenerLatestBoostsPath = "/token-boosts/latest/v1"
 )
 
-var dexscreenerClient = resty.New().
-	SetTimeout(10 * time.Second).
+func dexscreenerURL(path string) string {
+	return strings.TrimRight(env.DEXSCREENER_API_URL.GetEnvOrDefault(dexscreenerAPI), "/") + path
+}
+
+var dexscreenerClient = usage.Track(degrade.Track(httpclient.New().
+	SetTimeout(10*time.Second).
 	SetRetryCount(2).
-	SetRetryWaitTime(200 * time.Millisecond).
-	SetRetryMaxWaitTime(1 * time.Second)
+	SetRetryWaitTime(200*time.Millisecond).
+	SetRetryMaxWaitTime(1*time.Second), degrade.ProviderDexscreener), usage.Dexscreener)
+
+// dexscreenerResponses holds response bodies by URL so the lookups done for one token by
+// AddToTokenList, price saving and the crons within a few seconds hit the API once.
+var dexscreenerResponses = cache.New[[]byte](string(degrade.ProviderDexscreener))
+
+// dexscreenerGet returns the body of a successful GET, served from the response cache when fresh.
+func dexscreenerGet(u string) ([]byte, error) {
+	return dexscreenerResponses.Get(u, func() ([]byte, error) {
+		resp, err := dexscreenerClient.R().Get(u)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode() != 200 {
+			return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode())
+		}
+		return resp.Body(), nil
+	})
+}
 
 type dexscreenerPairsDTO []dexscreenerPairDTO
 
@@ -41,11 +75,136 @@ type dexscreenerPairDTO struct {
 	} `json:"quoteToken"`
 	PriceUSD string `json:"priceUsd"`
 	Volume   struct {
-		H24 float64 `json:"h24"`
+		H24 decimal.Decimal `json:"h24"`
 	} `json:"volume"`
 	Liquidity struct {
 		USD float64 `json:"usd"`
 	} `json:"liquidity"`
+	Info   *dexscreenerPairInfoDTO `json:"info"`
+	Labels []string                `json:"labels"`
+}
+
+type dexscreenerLinkDTO struct {
+	Type  string `json:"type"`
+	Label string `json:"label"`
+	URL   string `json:"url"`
+}
+
+type dexscreenerPairInfoDTO struct {
+	ImageURL string               `json:"imageUrl"`
+	Header   string               `json:"header"`
+	Websites []dexscreenerLinkDTO `json:"websites"`
+	Socials  []dexscreenerLinkDTO `json:"socials"`
+}
+
+type dexscreenerTokenProfileDTO struct {
+	ChainID      string               `json:"chainId"`
+	TokenAddress string               `json:"tokenAddress"`
+	Icon         string               `json:"icon"`
+	Header       string               `json:"header"`
+	Description  string               `json:"description"`
+	Links        []dexscreenerLinkDTO `json:"links"`
+}
+
+type dexscreenerBoostDTO struct {
+	ChainID      string  `json:"chainId"`
+	TokenAddress string  `json:"tokenAddress"`
+	TotalAmount  float64 `json:"totalAmount"`
+}
+
+// TrendingToken is a token of the chain Dexscreener promotes, ranked from 1.
+type TrendingToken struct {
+	Address     string
+	Rank        int
+	BoostAmount float64
+}
+
+// GetDexscreenerTrendingTokens returns the tokens of the chain with active Dexscreener boosts:
+// the most boosted first, then the ones boosted most recently.
+func GetDexscreenerTrendingTokens() ([]TrendingToken, error) {
+	lists := make([][]dexscreenerBoostDTO, 0, 2)
+	for _, path := range []string{dexscreenerTopBoostsPath, dexscreenerLatestBoostsPath} {
+		body, err := dexscreenerGet(dexscreenerURL(path))
+		if err != nil {
+			return nil, err
+		}
+		var boosts []dexscreenerBoostDTO
+		if err := json.Unmarshal(body, &boosts); err != nil {
+			return nil, err
+		}
+		lists = append(lists, boosts)
+	}
+	return rankBoostedTokens(chain.Get().DexscreenerID, lists...), nil
+}
+
+// rankBoostedTokens ranks the tokens of a chain in the order of the lists, each token once.
+func rankBoostedTokens(chainID string, lists ...[]dexscreenerBoostDTO) []TrendingToken {
+	ranked := []TrendingToken{}
+	seen := map[string]bool{}
+	for _, boosts := range lists {
+		for _, boost := range boosts {
+			address := strings.ToLower(strings.TrimSpace(boost.TokenAddress))
+			if boost.ChainID != chainID || address == "" || seen[address] {
+				continue
+			}
+			seen[address] = true
+			ranked = append(ranked, TrendingToken{Address: address, Rank: len(ranked) + 1, BoostAmount: boost.TotalAmount})
+		}
+	}
+	return ranked
+}
+
+// TokenProfile holds the socials and links a token team published on Dexscreener.
+type TokenProfile struct {
+	Website        string
+	Twitter        string
+	Telegram       string
+	Description    string
+	HeaderImageURL string
+	// Labels Dexscreener shows on the pair, e.g. "v4".
+	Labels []string
+}
+
+// IsEmpty reports whether the profile has nothing worth saving.
+func (p TokenProfile) IsEmpty() bool {
+	return p.Website == "" && p.Twitter == "" && p.Telegram == "" && p.Description == "" &&
+		p.HeaderImageURL == "" && len(p.Labels) == 0
+}
+
+// applyLinks fills in website, twitter and telegram from Dexscreener links, keeping values that
+// are already set. Websites have no type, only a label.
+func (p *TokenProfile) applyLinks(links []dexscreenerLinkDTO) {
+	for _, link := range links {
+		switch strings.ToLower(link.Type) {
+		case "twitter", "x":
+			if p.Twitter == "" {
+				p.Twitter = link.URL
+			}
+		case "telegram":
+			if p.Telegram == "" {
+				p.Telegram = link.URL
+			}
+		case "", "website":
+			if p.Website == "" {
+				p.Website = link.URL
+			}
+		}
+	}
+}
+
+func tokenProfileFromDexscreenerPair(pair *dexscreenerPairDTO) TokenProfile {
+	profile := TokenProfile{}
+	if pair == nil {
+		return profile
+	}
+	profile.Labels = pair.Labels
+	if pair.Info == nil {
+		return profile
+	}
+	profile.HeaderImageURL = pair.Info.Header
+	profile.applyLinks(pair.Info.Socials)
+	profile.applyLinks(pair.Info.Websites)
+	return profile
 }
 
 func fetchDexscreenerPairs(tokenAddress string) (dexscreenerPairsDTO, error) {
@@ -54,17 +213,14 @@ func fetchDexscreenerPairs(tokenAddress string) (dexscreenerPairsDTO, error) {
 		return nil, errors.New("token address is required")
 	}
 
-	u := fmt.Sprintf("%s/%s/%s", dexscreenerBaseURL, dexscreenerChainID, addr)
-	resp, err := dexscreenerClient.R().Get(u)
+	u := fmt.Sprintf("%s/%s/%s", dexscreenerURL(dexscreenerBasePath), chain.Get().DexscreenerID, addr)
+	body, err := dexscreenerGet(u)
 	if err != nil {
 		return nil, err
 	}
-	if resp.StatusCode() != 200 {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode())
-	}
 
 	var pairs dexscreenerPairsDTO
-	if err := json.Unmarshal(resp.Body(), &pairs); err != nil {
+	if err := json.Unmarshal(body, &pairs); err != nil {
 		return nil, err
 	}
 	return pairs, nil
@@ -87,7 +243,7 @@ func selectBestPairForBaseToken(pairs dexscreenerPairsDTO, tokenAddress string)
 
 		score := p.Liquidity.USD
 		if score == 0 {
-			score = p.Volume.H24
+			score = p.Volume.H24.InexactFloat64()
 		}
 		if score > bestScore {
 			bestScore = score
@@ -98,33 +254,28 @@ func selectBestPairForBaseToken(pairs dexscreenerPairsDTO, tokenAddress string)
 	return best
 }
 
-func normalizeNumericString(v string) string {
-	s := strings.TrimSpace(v)
-	if s == "" {
-		return "0"
-	}
-	f, err := strconv.ParseFloat(s, 64)
-	if err != nil {
-		return s
-	}
-	return strconv.FormatFloat(f, 'f', -1, 64)
-}
-
 func tokenDataFromDexscreenerPair(pair *dexscreenerPairDTO) dexdto.TokenDataAsString {
 	if pair == nil {
 		return dexdto.TokenDataAsString{}
 	}
 	return dexdto.TokenDataAsString{
-		Price:            normalizeNumericString(pair.PriceUSD),
-		Volume24H:        strconv.FormatFloat(pair.Volume.H24, 'f', -1, 64),
+		Price:            numeric.NormalizePrice(pair.PriceUSD),
+		Volume24H:        numeric.FormatUSD(pair.Volume.H24),
 		Supply:           "0",
 		CirculatedSupply: "0",
-		ImageURL:         "",
+		ImageURL:         imageURLFromDexscreenerPair(pair),
 		Name:             pair.BaseToken.Name,
 		Symbol:           pair.BaseToken.Symbol,
 	}
 }
 
+func imageURLFromDexscreenerPair(pair *dexscreenerPairDTO) string {
+	if pair.Info == nil {
+		return ""
+	}
+	return pair.Info.ImageURL
+}
+
 func poolInfoFromDexscreenerPair(pair *dexscreenerPairDTO) dexdto.PoolInfo {
 	if pair == nil {
 		return dexdto.PoolInfo{}
@@ -132,8 +283,10 @@ func poolInfoFromDexscreenerPair(pair *dexscreenerPairDTO) dexdto.PoolInfo {
 	return dexdto.PoolInfo{
 		Address:     pair.PairAddress,
 		PairAddress: pair.QuoteToken.Address,
-		Volume24H:   strconv.FormatFloat(pair.Volume.H24, 'f', -1, 64),
+		Volume24H:   numeric.FormatUSD(pair.Volume.H24),
 		IsV4:        strings.Contains(strings.ToLower(pair.DexID), "v4"),
+		DexID:       pair.DexID,
+		Labels:      pair.Labels,
 	}
 }
 
@@ -171,9 +324,11 @@ func GetDexscreenerTokenDataAndBestPool(tokenAddress string) (dexdto.TokenDataAs
 
 // DexscreenerBatchResult holds token data and pool info for a single token from a batch query.
 type DexscreenerBatchResult struct {
-	Address   string
-	TokenData dexdto.TokenDataAsString
-	Pool      dexdto.PoolInfo
+	Address      string
+	TokenData    dexdto.TokenDataAsString
+	Pool         dexdto.PoolInfo
+	Profile      TokenProfile
+	LiquidityUSD float64
 }
 
 // GetDexscreenerBatchTokenData fetches best-pair data for multiple tokens in a single request
@@ -188,17 +343,14 @@ func GetDexscreenerBatchTokenData(addresses []string) (map[string]DexscreenerBat
 		lowered[i] = strings.ToLower(strings.TrimSpace(a))
 	}
 
-	u := fmt.Sprintf("%s/%s/%s", dexscreenerTokensURL, dexscreenerChainID, strings.Join(lowered, ","))
-	resp, err := dexscreenerClient.R().Get(u)
+	u := fmt.Sprintf("%s/%s/%s", dexscreenerURL(dexscreenerTokensPath), chain.Get().DexscreenerID, strings.Join(lowered, ","))
+	body, err := dexscreenerGet(u)
 	if err != nil {
 		return nil, fmt.Errorf("dexscreener batch request failed: %w", err)
 	}
-	if resp.StatusCode() != 200 {
-		return nil, fmt.Errorf("dexscreener batch unexpected status: %d", resp.StatusCode())
-	}
 
 	var pairs dexscreenerPairsDTO
-	if err := json.Unmarshal(resp.Body(), &pairs); err != nil {
+	if err := json.Unmarshal(body, &pairs); err != nil {
 		return nil, fmt.Errorf("dexscreener batch parse error: %w", err)
 	}
 
@@ -209,10 +361,119 @@ func GetDexscreenerBatchTokenData(addresses []string) (map[string]DexscreenerBat
 			continue
 		}
 		results[addr] = DexscreenerBatchResult{
-			Address:   addr,
-			TokenData: tokenDataFromDexscreenerPair(best),
-			Pool:      poolInfoFromDexscreenerPair(best),
+			Address:      addr,
+			TokenData:    tokenDataFromDexscreenerPair(best),
+			Pool:         poolInfoFromDexscreenerPair(best),
+			Profile:      tokenProfileFromDexscreenerPair(best),
+			LiquidityUSD: best.Liquidity.USD,
+		}
+	}
+	return results, nil
+}
+
+type dexscreenerPairResponseDTO struct {
+	Pairs []dexscreenerPairDTO `json:"pairs"`
+}
+
+// DexscreenerPoolTokens describes the base/quote tokens of a single pool as reported by Dexscreener.
+type DexscreenerPoolTokens struct {
+	BaseToken  string
+	QuoteToken string
+	IsV4       bool
+}
+
+// GetDexscreenerPoolTokens looks up a pool (or V4 pool id) and returns its base and quote token addresses.
+func GetDexscreenerPoolTokens(poolAddress string) (DexscreenerPoolTokens, error) {
+	addr := strings.ToLower(strings.TrimSpace(poolAddress))
+	if addr == "" {
+		return DexscreenerPoolTokens{}, errors.New("pool address is required")
+	}
+
+	u := fmt.Sprintf("%s/%s/%s", dexscreenerURL(dexscreenerPairsPath), chain.Get().DexscreenerID, addr)
+	body, err := dexscreenerGet(u)
+	if err != nil {
+		return DexscreenerPoolTokens{}, err
+	}
+
+	var result dexscreenerPairResponseDTO
+	if err := json.Unmarshal(body, &result); err != nil {
+		return DexscreenerPoolTokens{}, err
+	}
+	for _, pair := range result.Pairs {
+		if !strings.EqualFold(pair.PairAddress, addr) {
+			continue
+		}
+		return DexscreenerPoolTokens{
+			BaseToken:  strings.ToLower(pair.BaseToken.Address),
+			QuoteToken: strings.ToLower(pair.QuoteToken.Address),
+			IsV4:       strings.Contains(strings.ToLower(pair.DexID), "v4"),
+		}, nil
+	}
+	return DexscreenerPoolTokens{}, errors.New("pool not found on dexscreener")
+}
+
+// GetDexscreenerLatestTokenProfiles returns the most recently published Base token profiles keyed
+// by lowercased token address. Descriptions are only available from this endpoint, and fresh
+// launches are the tokens most likely to be in it.
+func GetDexscreenerLatestTokenProfiles() (map[string]TokenProfile, error) {
+	body, err := dexscreenerGet(dexscreenerURL(dexscreenerProfilesPath))
+	if err != nil {
+		return nil, err
+	}
+
+	var profiles []dexscreenerTokenProfileDTO
+	if err := json.Unmarshal(body, &profiles); err != nil {
+		return nil, err
+	}
+	results := make(map[string]TokenProfile, len(profiles))
+	for _, p := range profiles {
+		if p.ChainID != chain.Get().DexscreenerID {
+			continue
+		}
+		profile := TokenProfile{
+			Description:    strings.TrimSpace(p.Description),
+			HeaderImageURL: p.Header,
 		}
+		profile.applyLinks(p.Links)
+		results[strings.ToLower(p.TokenAddress)] = profile
 	}
 	return results, nil
 }
+
+// GetDexscreenerTokenProfile fetches the socials of a single token from its best pair, adding the
+// description when the token is among the latest published profiles.
+func GetDexscreenerTokenProfile(tokenAddress string) (TokenProfile, error) {
+	pairs, err := fetchDexscreenerPairs(tokenAddress)
+	if err != nil {
+		return TokenProfile{}, err
+	}
+	profile := tokenProfileFromDexscreenerPair(selectBestPairForBaseToken(pairs, tokenAddress))
+	latest, err := GetDexscreenerLatestTokenProfiles()
+	if err == nil {
+		profile = MergeTokenProfiles(profile, latest[strings.ToLower(strings.TrimSpace(tokenAddress))])
+	}
+	return profile, nil
+}
+
+// MergeTokenProfiles fills the empty fields of base from extra.
+func MergeTokenProfiles(base TokenProfile, extra TokenProfile) TokenProfile {
+	if base.Website == "" {
+		base.Website = extra.Website
+	}
+	if base.Twitter == "" {
+		base.Twitter = extra.Twitter
+	}
+	if base.Telegram == "" {
+		base.Telegram = extra.Telegram
+	}
+	if base.Description == "" {
+		base.Description = extra.Description
+	}
+	if base.HeaderImageURL == "" {
+		base.HeaderImageURL = extra.HeaderImageURL
+	}
+	if len(base.Labels) == 0 {
+		base.Labels = extra.Labels
+	}
+	return base
+}
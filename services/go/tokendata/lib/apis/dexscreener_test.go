@@ -0,0 +1,43 @@
+package apis
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestRankBoostedTokens(t *testing.T) {
+	top := []dexscreenerBoostDTO{
+		{ChainID: "base", TokenAddress: "0xAAA", TotalAmount: 500},
+		{ChainID: "solana", TokenAddress: "So1"},
+		{ChainID: "base", TokenAddress: "0xbbb", TotalAmount: 100},
+	}
+	latest := []dexscreenerBoostDTO{
+		{ChainID: "base", TokenAddress: "0xccc", TotalAmount: 10},
+		{ChainID: "base", TokenAddress: "0xaaa", TotalAmount: 500},
+	}
+	ranked := rankBoostedTokens("base", top, latest)
+	want := []TrendingToken{
+		{Address: "0xaaa", Rank: 1, BoostAmount: 500},
+		{Address: "0xbbb", Rank: 2, BoostAmount: 100},
+		{Address: "0xccc", Rank: 3, BoostAmount: 10},
+	}
+	if !slices.Equal(ranked, want) {
+		t.Errorf("ranked = %v, want %v", ranked, want)
+	}
+}
+
+func TestTokenDataFromDexscreenerPairKeepsMicroCapPrices(t *testing.T) {
+	var pair dexscreenerPairDTO
+	body := `{"priceUsd":"1.23456789012345678e-13","volume":{"h24":1234.5678}}`
+	if err := json.Unmarshal([]byte(body), &pair); err != nil {
+		t.Fatal(err)
+	}
+	data := tokenDataFromDexscreenerPair(&pair)
+	if data.Price != "0.000000000000123456789012345678" {
+		t.Errorf("price = %s, want every digit without an exponent", data.Price)
+	}
+	if data.Volume24H != "1234.57" {
+		t.Errorf("volume = %s, want it in cents", data.Volume24H)
+	}
+}
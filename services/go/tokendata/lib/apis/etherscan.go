@@ -0,0 +1,244 @@
+package apis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"samterminal/pkg/chain"
+	"samterminal/pkg/httpclient"
+	"strconv"
+	"strings"
+	"time"
+	"tokendata/env"
+)
+
+const (
+	etherscanAPI       = "https://api.etherscan.io/v2/api"
+	etherscanTxListMax = 1000
+)
+
+var etherscanClient = httpclient.New().
+	SetTimeout(10 * time.Second).
+	SetRetryCount(2).
+	SetRetryWaitTime(500 * time.Millisecond)
+
+type etherscanResponse struct {
+	Status  string          `json:"status"`
+	Message string          `json:"message"`
+	Result  json.RawMessage `json:"result"`
+}
+
+// etherscanGet calls an Etherscan v2 endpoint on Base and decodes its result into out.
+func etherscanGet(params map[string]string, out any) error {
+	params["chainid"] = strconv.FormatInt(chain.Get().ID, 10)
+	params["apikey"] = env.ES_API_KEY.GetEnv()
+	resp, err := etherscanClient.R().SetQueryParams(params).Get(env.ETHERSCAN_API_URL.GetEnvOrDefault(etherscanAPI))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != 200 {
+		return fmt.Errorf("etherscan returned status %d", resp.StatusCode())
+	}
+	var response etherscanResponse
+	if err := json.Unmarshal(resp.Body(), &response); err != nil {
+		return err
+	}
+	// Proxy endpoints have no status; the others report errors with status "0" and a string result.
+	if response.Status == "0" && response.Message != "No transactions found" {
+		return fmt.Errorf("etherscan error: %s %s", response.Message, string(response.Result))
+	}
+	return json.Unmarshal(response.Result, out)
+}
+
+type etherscanContractCreation struct {
+	ContractAddress string `json:"contractAddress"`
+	ContractCreator string `json:"contractCreator"`
+	TxHash          string `json:"txHash"`
+	BlockNumber     string `json:"blockNumber"`
+	Timestamp       string `json:"timestamp"`
+}
+
+type etherscanProxyTransaction struct {
+	From        string `json:"from"`
+	To          string `json:"to"`
+	BlockNumber string `json:"blockNumber"`
+}
+
+type etherscanProxyBlock struct {
+	Timestamp string `json:"timestamp"`
+}
+
+// ContractDeployment describes who launched a contract. Creator is the contract that created it,
+// which is a factory for Clanker and Bankr launches; Deployer is the wallet that sent the launch
+// transaction.
+type ContractDeployment struct {
+	Creator  string
+	Deployer string
+	TxHash   string
+	// Block and DeployedAt are zero when the creation block could not be read.
+	Block      uint64
+	DeployedAt time.Time
+}
+
+// GetContractDeployment looks up the creation transaction of a contract and its sender.
+func GetContractDeployment(contractAddress string) (ContractDeployment, error) {
+	var creations []etherscanContractCreation
+	err := etherscanGet(map[string]string{
+		"module":            "contract",
+		"action":            "getcontractcreation",
+		"contractaddresses": strings.ToLower(contractAddress),
+	}, &creations)
+	if err != nil {
+		return ContractDeployment{}, err
+	}
+	if len(creations) == 0 {
+		return ContractDeployment{}, errors.New("contract creation not found")
+	}
+
+	var tx etherscanProxyTransaction
+	err = etherscanGet(map[string]string{
+		"module": "proxy",
+		"action": "eth_getTransactionByHash",
+		"txhash": creations[0].TxHash,
+	}, &tx)
+	if err != nil {
+		return ContractDeployment{}, err
+	}
+	deployment := ContractDeployment{
+		Creator:  strings.ToLower(creations[0].ContractCreator),
+		Deployer: strings.ToLower(tx.From),
+		TxHash:   creations[0].TxHash,
+	}
+	deployment.Block, deployment.DeployedAt = contractCreationTime(creations[0], tx)
+	return deployment, nil
+}
+
+// contractCreationTime reads the block and time of a contract creation. Etherscan includes them
+// in the creation lookup; otherwise the block comes from the transaction and its time from the
+// block header.
+func contractCreationTime(creation etherscanContractCreation, tx etherscanProxyTransaction) (uint64, time.Time) {
+	block, err := strconv.ParseUint(creation.BlockNumber, 10, 64)
+	if err != nil {
+		block, err = strconv.ParseUint(strings.TrimPrefix(tx.BlockNumber, "0x"), 16, 64)
+		if err != nil {
+			return 0, time.Time{}
+		}
+	}
+	if timestamp, err := strconv.ParseInt(creation.Timestamp, 10, 64); err == nil && timestamp > 0 {
+		return block, time.Unix(timestamp, 0)
+	}
+
+	var header etherscanProxyBlock
+	err = etherscanGet(map[string]string{
+		"module":  "proxy",
+		"action":  "eth_getBlockByNumber",
+		"tag":     "0x" + strconv.FormatUint(block, 16),
+		"boolean": "false",
+	}, &header)
+	if err != nil {
+		return block, time.Time{}
+	}
+	timestamp, err := strconv.ParseInt(strings.TrimPrefix(header.Timestamp, "0x"), 16, 64)
+	if err != nil {
+		return block, time.Time{}
+	}
+	return block, time.Unix(timestamp, 0)
+}
+
+type etherscanTransaction struct {
+	TimeStamp       string `json:"timeStamp"`
+	From            string `json:"from"`
+	To              string `json:"to"`
+	ContractAddress string `json:"contractAddress"`
+	IsError         string `json:"isError"`
+}
+
+// DeployerHistory summarises the on-chain history of a deployer wallet.
+type DeployerHistory struct {
+	FirstSeen time.Time
+	// LaunchCount counts direct contract creations and successful calls to the launch factory.
+	LaunchCount int
+	// DeployedContracts are the contracts the wallet created directly.
+	DeployedContracts []string
+}
+
+// GetDeployerHistory reads the oldest transactions of a wallet. factory is the contract launches
+// go through, if any, so that factory launches are counted too.
+func GetDeployerHistory(deployer string, factory string) (DeployerHistory, error) {
+	var txs []etherscanTransaction
+	err := etherscanGet(map[string]string{
+		"module":  "account",
+		"action":  "txlist",
+		"address": strings.ToLower(deployer),
+		"sort":    "asc",
+		"page":    "1",
+		"offset":  strconv.Itoa(etherscanTxListMax),
+	}, &txs)
+	if err != nil {
+		return DeployerHistory{}, err
+	}
+
+	history := DeployerHistory{}
+	deployer = strings.ToLower(deployer)
+	factory = strings.ToLower(factory)
+	for i, tx := range txs {
+		if i == 0 {
+			if ts, err := strconv.ParseInt(tx.TimeStamp, 10, 64); err == nil {
+				history.FirstSeen = time.Unix(ts, 0)
+			}
+		}
+		if tx.IsError == "1" || !strings.EqualFold(tx.From, deployer) {
+			continue
+		}
+		if tx.To == "" && tx.ContractAddress != "" {
+			history.LaunchCount++
+			history.DeployedContracts = append(history.DeployedContracts, strings.ToLower(tx.ContractAddress))
+		} else if factory != "" && strings.EqualFold(tx.To, factory) {
+			history.LaunchCount++
+		}
+	}
+	return history, nil
+}
+
+type etherscanSourceCode struct {
+	SourceCode      string `json:"SourceCode"`
+	ContractName    string `json:"ContractName"`
+	CompilerVersion string `json:"CompilerVersion"`
+	Proxy           string `json:"Proxy"`
+	Implementation  string `json:"Implementation"`
+}
+
+// ContractSource is what Etherscan knows about the source of a contract. Source is empty when
+// the contract is not verified.
+type ContractSource struct {
+	Source          string
+	Name            string
+	CompilerVersion string
+	// Proxy is set when Etherscan detected a proxy; Implementation is the contract it delegates to.
+	Proxy          bool
+	Implementation string
+}
+
+// GetContractSource reads the verified source of a contract.
+func GetContractSource(contractAddress string) (ContractSource, error) {
+	var sources []etherscanSourceCode
+	err := etherscanGet(map[string]string{
+		"module":  "contract",
+		"action":  "getsourcecode",
+		"address": strings.ToLower(contractAddress),
+	}, &sources)
+	if err != nil {
+		return ContractSource{}, err
+	}
+	if len(sources) == 0 {
+		return ContractSource{}, errors.New("contract source not found")
+	}
+	source := sources[0]
+	return ContractSource{
+		Source:          source.SourceCode,
+		Name:            source.ContractName,
+		CompilerVersion: source.CompilerVersion,
+		Proxy:           source.Proxy == "1",
+		Implementation:  strings.ToLower(source.Implementation),
+	}, nil
+}
@@ -2,20 +2,17 @@ package apis
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
+	"samterminal/pkg/chain"
+	"samterminal/pkg/httpclient"
+	"samterminal/pkg/usage"
+	"strconv"
+	"strings"
 	"time"
 	"tokendata/env"
-
-	"github.com/go-resty/resty/v2"
 )
 
-var apiKey string
-
-func init() {
-	env.LoadEnv("./.env")
-	apiKey = env.MORALIS_API_KEY.GetEnv()
-}
-
 type TokenSecurityResult struct {
 	Address          string `json:"address"`
 	Score            int    `json:"score"`
@@ -24,17 +21,28 @@ type TokenSecurityResult struct {
 	VerifiedContract bool   `json:"verified_contract"`
 }
 
+const moralisAPI = "https://deep-index.moralis.io/api/v2.2"
+
+func moralisURL(path string) string {
+	return strings.TrimRight(env.MORALIS_API_URL.GetEnvOrDefault(moralisAPI), "/") + path
+}
+
+// moralisClient is shared by the Moralis calls; its requests count towards the Moralis budget.
+var moralisClient = usage.Track(httpclient.New().
+	SetTimeout(10*time.Second).
+	SetRetryCount(2).
+	SetRetryWaitTime(500*time.Millisecond), usage.Moralis)
+
 type TokenImageURLResult []struct {
 	Logo string `json:"logo"`
 }
 
 func GetTokenImageURL(tokenAddress string) string {
-	url := "https://deep-index.moralis.io/api/v2.2/erc20/metadata"
-	client := resty.New()
-	resp, err := client.R().
-		SetHeader("X-API-Key", apiKey).
+	url := moralisURL("/erc20/metadata")
+	resp, err := moralisClient.R().
+		SetHeader("X-API-Key", env.MORALIS_API_KEY.GetEnv()).
 		SetQueryParam("addresses", tokenAddress).
-		SetQueryParam("chain", "base").
+		SetQueryParam("chain", chain.Get().MoralisID).
 		Get(url)
 	if err != nil {
 		return ""
@@ -58,13 +66,12 @@ func GetTokenImageURL(tokenAddress string) string {
 
 func GetTokenSecurityResult(tokenAddress string) *TokenSecurityResult {
 
-	url := "https://deep-index.moralis.io/api/v2.2/erc20/metadata"
+	url := moralisURL("/erc20/metadata")
 
-	client := resty.New()
-	resp, err := client.R().
-		SetHeader("X-API-Key", apiKey).
+	resp, err := moralisClient.R().
+		SetHeader("X-API-Key", env.MORALIS_API_KEY.GetEnv()).
 		SetQueryParam("addresses", tokenAddress).
-		SetQueryParam("chain", "base").
+		SetQueryParam("chain", chain.Get().MoralisID).
 		Get(url)
 	if err != nil {
 		return nil
@@ -97,3 +104,38 @@ func GetUnsecureTokens(tokenAddresses []string) []string {
 	}
 	return unsecureTokens
 }
+
+type TokenHolder struct {
+	OwnerAddress       string  `json:"owner_address"`
+	Balance            string  `json:"balance"`
+	BalanceFormatted   string  `json:"balance_formatted"`
+	PercentageOfSupply float64 `json:"percentage_relative_to_total_supply"`
+}
+
+type TokenHoldersResult struct {
+	Result []TokenHolder `json:"result"`
+}
+
+// GetTopTokenHolders returns the largest holders of a token, ordered by balance.
+func GetTopTokenHolders(tokenAddress string, limit int) ([]TokenHolder, error) {
+	url := moralisURL("/erc20/" + tokenAddress + "/owners")
+	resp, err := moralisClient.R().
+		SetHeader("X-API-Key", env.MORALIS_API_KEY.GetEnv()).
+		SetQueryParam("chain", chain.Get().MoralisID).
+		SetQueryParam("order", "DESC").
+		SetQueryParam("limit", strconv.Itoa(limit)).
+		Get(url)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("moralis token owners returned status %d", resp.StatusCode())
+	}
+	var tokenHoldersResult TokenHoldersResult
+	err = json.Unmarshal(resp.Body(), &tokenHoldersResult)
+	if err != nil {
+		log.Println("error unmarshalling tokenHoldersResult", err)
+		return nil, err
+	}
+	return tokenHoldersResult.Result, nil
+}
@@ -0,0 +1,138 @@
+package apis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"samterminal/pkg/httpclient"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"tokendata/env"
+)
+
+const (
+	neynarAPI = "https://api.neynar.com"
+	// neynarSearchPath searches casts, newest first with desc_chronological.
+	neynarSearchPath = "/v2/farcaster/cast/search"
+	neynarPageSize   = 100
+	// neynarMaxPages bounds the pages read for one search; a token cast about more than that in
+	// the window is counted as the casts read.
+	neynarMaxPages = 5
+)
+
+var ErrNeynarDisabled = errors.New("neynar api key not set")
+
+func neynarURL(path string) string {
+	return strings.TrimRight(env.NEYNAR_API_URL.GetEnvOrDefault(neynarAPI), "/") + path
+}
+
+var neynarClient = httpclient.New().SetTimeout(10 * time.Second)
+
+type neynarCastDTO struct {
+	Hash      string    `json:"hash"`
+	Text      string    `json:"text"`
+	Timestamp time.Time `json:"timestamp"`
+	Author    struct {
+		FID int64 `json:"fid"`
+	} `json:"author"`
+	Embeds []struct {
+		URL string `json:"url"`
+	} `json:"embeds"`
+}
+
+// mentions reports whether a cast names a token address in its text or in the URL of an embed,
+// e.g. a Dexscreener or Basescan link.
+func (cast neynarCastDTO) mentions(address string) bool {
+	if strings.Contains(strings.ToLower(cast.Text), address) {
+		return true
+	}
+	for _, embed := range cast.Embeds {
+		if strings.Contains(strings.ToLower(embed.URL), address) {
+			return true
+		}
+	}
+	return false
+}
+
+type neynarSearchDTO struct {
+	Result struct {
+		Casts []neynarCastDTO `json:"casts"`
+		Next  struct {
+			Cursor string `json:"cursor"`
+		} `json:"next"`
+	} `json:"result"`
+}
+
+// FarcasterMomentum is how much Farcaster talks about a token: the casts mentioning it and the
+// distinct accounts that cast them.
+type FarcasterMomentum struct {
+	Casts   int
+	Casters int
+}
+
+// GetFarcasterMomentum counts the casts since a time that name the address of a token in their
+// text or embeds. Symbols are not searched: a cashtag such as $DEGEN is shared by every token
+// of that symbol. It fails with ErrNeynarDisabled without NEYNAR_API_KEY.
+func GetFarcasterMomentum(tokenAddress string, since time.Time) (FarcasterMomentum, error) {
+	key := env.NEYNAR_API_KEY.GetEnv()
+	if key == "" {
+		return FarcasterMomentum{}, ErrNeynarDisabled
+	}
+	address := strings.ToLower(tokenAddress)
+	casts, err := searchCasts(key, address, since)
+	if err != nil {
+		return FarcasterMomentum{}, err
+	}
+	casts = slices.DeleteFunc(casts, func(cast neynarCastDTO) bool { return !cast.mentions(address) })
+	return countMomentum(casts, since), nil
+}
+
+// searchCasts reads the casts matching query, newest first, until one is older than since.
+func searchCasts(key string, query string, since time.Time) ([]neynarCastDTO, error) {
+	casts := []neynarCastDTO{}
+	cursor := ""
+	for page := 0; page < neynarMaxPages; page++ {
+		req := neynarClient.R().
+			SetHeader("x-api-key", key).
+			SetQueryParam("q", query).
+			SetQueryParam("sort_type", "desc_chronological").
+			SetQueryParam("limit", strconv.Itoa(neynarPageSize))
+		if cursor != "" {
+			req.SetQueryParam("cursor", cursor)
+		}
+		resp, err := req.Get(neynarURL(neynarSearchPath))
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode() != 200 {
+			return nil, fmt.Errorf("neynar cast search returned status %d", resp.StatusCode())
+		}
+		var result neynarSearchDTO
+		if err := json.Unmarshal(resp.Body(), &result); err != nil {
+			return nil, err
+		}
+		casts = append(casts, result.Result.Casts...)
+		last := len(result.Result.Casts) - 1
+		if last < 0 || result.Result.Casts[last].Timestamp.Before(since) || result.Result.Next.Cursor == "" {
+			break
+		}
+		cursor = result.Result.Next.Cursor
+	}
+	return casts, nil
+}
+
+// countMomentum counts the distinct casts since a time and their distinct authors.
+func countMomentum(casts []neynarCastDTO, since time.Time) FarcasterMomentum {
+	seen := map[string]bool{}
+	casters := map[int64]bool{}
+	for _, cast := range casts {
+		if cast.Timestamp.Before(since) || seen[cast.Hash] {
+			continue
+		}
+		seen[cast.Hash] = true
+		casters[cast.Author.FID] = true
+	}
+	return FarcasterMomentum{Casts: len(seen), Casters: len(casters)}
+}
@@ -0,0 +1,53 @@
+package apis
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetFarcasterMomentum(t *testing.T) {
+	now := time.Now()
+	cast := func(hash string, fid int64, age time.Duration, text string, embeds ...string) map[string]any {
+		urls := []map[string]any{}
+		for _, embed := range embeds {
+			urls = append(urls, map[string]any{"url": embed})
+		}
+		return map[string]any{"hash": hash, "text": text, "timestamp": now.Add(-age), "author": map[string]any{"fid": fid}, "embeds": urls}
+	}
+	queries := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("q"))
+		casts := []map[string]any{
+			cast("0x1", 1, time.Minute, "aping 0xABC"),
+			cast("0x2", 2, 5*time.Minute, "look", "https://dexscreener.com/base/0xabc"),
+			cast("0x3", 1, 10*time.Minute, "$TEST to the moon"),
+			cast("0x0", 3, 2*time.Hour, "0xabc"),
+		}
+		json.NewEncoder(w).Encode(map[string]any{"result": map[string]any{"casts": casts, "next": map[string]any{"cursor": "more"}}})
+	}))
+	defer server.Close()
+	t.Setenv("NEYNAR_API_URL", server.URL)
+	t.Setenv("NEYNAR_API_KEY", "key")
+
+	momentum, err := GetFarcasterMomentum("0xABC", now.Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if momentum != (FarcasterMomentum{Casts: 2, Casters: 2}) {
+		t.Errorf("momentum = %+v, want the 2 casts naming the address in their text or embeds", momentum)
+	}
+	// The search stops at the cast older than the window instead of following the cursor.
+	if len(queries) != 1 || queries[0] != "0xabc" {
+		t.Errorf("queries = %v", queries)
+	}
+}
+
+func TestGetFarcasterMomentumWithoutKey(t *testing.T) {
+	t.Setenv("NEYNAR_API_KEY", "")
+	if _, err := GetFarcasterMomentum("0xabc", time.Now()); err != ErrNeynarDisabled {
+		t.Errorf("err = %v, want %v", err, ErrNeynarDisabled)
+	}
+}
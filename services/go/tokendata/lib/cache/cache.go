@@ -0,0 +1,85 @@
+package cache
+
+import (
+	"sync"
+	"time"
+	"tokendata/env"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	defaultTTL = 10 * time.Second
+	// Expired entries are swept when the cache grows past this many entries.
+	sweepThreshold = 1024
+)
+
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// Cache keeps successful responses of an outbound API for a short window and coalesces
+// concurrent fetches of the same key into one request. Errors are never cached.
+type Cache[V any] struct {
+	name    string
+	ttlOnce sync.Once
+	ttl     time.Duration
+
+	mu      sync.Mutex
+	entries map[string]entry[V]
+	group   singleflight.Group
+}
+
+// New creates a cache for a provider. The window is read from API_CACHE_TTL on first use and can
+// be overridden per provider, e.g. DEXSCREENER_API_CACHE_TTL; 0 disables reuse of responses
+// while still coalescing concurrent requests.
+func New[V any](name string) *Cache[V] {
+	return &Cache[V]{name: name, entries: map[string]entry[V]{}}
+}
+
+func (c *Cache[V]) getTTL() time.Duration {
+	c.ttlOnce.Do(func() {
+		ttl := env.API_CACHE_TTL.GetEnvAsDurationOrDefault(defaultTTL)
+		c.ttl = env.API_CACHE_TTL.ForSource(c.name).GetEnvAsDurationOrDefault(ttl)
+	})
+	return c.ttl
+}
+
+// Get returns the cached value for key, or calls fetch once for all concurrent callers and caches
+// its result.
+func (c *Cache[V]) Get(key string, fetch func() (V, error)) (V, error) {
+	now := time.Now()
+	c.mu.Lock()
+	cached, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.value, nil
+	}
+
+	result, err, _ := c.group.Do(key, func() (any, error) {
+		value, err := fetch()
+		if err != nil {
+			return value, err
+		}
+		if ttl := c.getTTL(); ttl > 0 {
+			c.set(key, value, time.Now().Add(ttl))
+		}
+		return value, nil
+	})
+	return result.(V), err
+}
+
+func (c *Cache[V]) set(key string, value V, expiresAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= sweepThreshold {
+		now := time.Now()
+		for k, e := range c.entries {
+			if !now.Before(e.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+	}
+	c.entries[key] = entry[V]{value: value, expiresAt: expiresAt}
+}
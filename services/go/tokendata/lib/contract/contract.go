@@ -0,0 +1,89 @@
+// Package contract inspects token contracts: whether their source is verified on Etherscan, and
+// whether they are proxies, whose code their admin can swap after launch.
+package contract
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"tokendata/lib/apis"
+	websocket "tokendata/lib/ws"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	// eip1967ImplementationSlot holds the implementation of EIP-1967 proxies.
+	eip1967ImplementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+	// eip1167Prefix starts the code of EIP-1167 minimal proxies, followed by the implementation.
+	eip1167Prefix = common.FromHex("0x363d3d373d3d3d363d73")
+)
+
+// Inspection is what is known about the code of a contract.
+type Inspection struct {
+	Verified        bool
+	Name            string
+	CompilerVersion string
+	// SourceHash is the keccak256 of the verified source, shared by contracts deployed from the
+	// same code.
+	SourceHash string
+	Proxy      bool
+	// Implementation is the contract a proxy delegates to, when known.
+	Implementation string
+}
+
+// Inspect reads the verification of a contract from Etherscan. Proxies Etherscan does not flag
+// are detected from their code and the EIP-1967 implementation slot.
+func Inspect(ctx context.Context, address string) (Inspection, error) {
+	source, err := apis.GetContractSource(address)
+	if err != nil {
+		return Inspection{}, err
+	}
+	inspection := inspectionOf(source)
+	if inspection.Proxy {
+		return inspection, nil
+	}
+
+	client := websocket.GetEthClient()
+	contract := common.HexToAddress(address)
+	code, err := client.CodeAt(ctx, contract, nil)
+	if err != nil {
+		return Inspection{}, err
+	}
+	if implementation, ok := minimalProxyImplementation(code); ok {
+		inspection.Proxy, inspection.Implementation = true, implementation
+		return inspection, nil
+	}
+	slot, err := client.StorageAt(ctx, contract, eip1967ImplementationSlot, nil)
+	if err != nil {
+		return Inspection{}, err
+	}
+	if implementation := common.BytesToAddress(slot); implementation != (common.Address{}) {
+		inspection.Proxy, inspection.Implementation = true, strings.ToLower(implementation.Hex())
+	}
+	return inspection, nil
+}
+
+func inspectionOf(source apis.ContractSource) Inspection {
+	inspection := Inspection{
+		Verified:       source.Source != "",
+		Proxy:          source.Proxy,
+		Implementation: source.Implementation,
+	}
+	if inspection.Verified {
+		inspection.Name = source.Name
+		inspection.CompilerVersion = source.CompilerVersion
+		inspection.SourceHash = crypto.Keccak256Hash([]byte(source.Source)).Hex()
+	}
+	return inspection
+}
+
+// minimalProxyImplementation returns the implementation of an EIP-1167 minimal proxy.
+func minimalProxyImplementation(code []byte) (string, bool) {
+	if len(code) < len(eip1167Prefix)+common.AddressLength || !bytes.HasPrefix(code, eip1167Prefix) {
+		return "", false
+	}
+	implementation := common.BytesToAddress(code[len(eip1167Prefix) : len(eip1167Prefix)+common.AddressLength])
+	return strings.ToLower(implementation.Hex()), true
+}
@@ -0,0 +1,43 @@
+package contract
+
+import (
+	"testing"
+	"tokendata/lib/apis"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestInspectionOf(t *testing.T) {
+	verified := inspectionOf(apis.ContractSource{Source: "contract Token {}", Name: "Token", CompilerVersion: "v0.8.26+commit.8a97fa7a"})
+	if !verified.Verified || verified.Name != "Token" || verified.CompilerVersion != "v0.8.26+commit.8a97fa7a" {
+		t.Errorf("verified = %+v", verified)
+	}
+	if want := crypto.Keccak256Hash([]byte("contract Token {}")).Hex(); verified.SourceHash != want {
+		t.Errorf("SourceHash = %s, want %s", verified.SourceHash, want)
+	}
+
+	unverified := inspectionOf(apis.ContractSource{Name: "", Proxy: true, Implementation: "0xabc"})
+	if unverified.Verified || unverified.SourceHash != "" {
+		t.Errorf("unverified = %+v", unverified)
+	}
+	if !unverified.Proxy || unverified.Implementation != "0xabc" {
+		t.Errorf("proxy flag of Etherscan lost: %+v", unverified)
+	}
+}
+
+func TestMinimalProxyImplementation(t *testing.T) {
+	implementation := common.HexToAddress("0xbebebebebebebebebebebebebebebebebebebebe")
+	code := append(append(append([]byte{}, eip1167Prefix...), implementation.Bytes()...), common.FromHex("0x5af43d82803e903d91602b57fd5bf3")...)
+
+	got, ok := minimalProxyImplementation(code)
+	if !ok || got != "0xbebebebebebebebebebebebebebebebebebebebe" {
+		t.Errorf("minimalProxyImplementation = %s, %v", got, ok)
+	}
+	if _, ok := minimalProxyImplementation(common.FromHex("0x6080604052")); ok {
+		t.Error("regular contract detected as a minimal proxy")
+	}
+	if _, ok := minimalProxyImplementation(eip1167Prefix); ok {
+		t.Error("truncated code detected as a minimal proxy")
+	}
+}
@@ -0,0 +1,192 @@
+package degrade
+
+import (
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"tokendata/env"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Mode is a set of features that are switched off while the service is degraded.
+type Mode struct {
+	DisableDiscovery  bool
+	DisableEnrichment bool
+	CachedPricesOnly  bool
+}
+
+func (m Mode) or(other Mode) Mode {
+	return Mode{
+		DisableDiscovery:  m.DisableDiscovery || other.DisableDiscovery,
+		DisableEnrichment: m.DisableEnrichment || other.DisableEnrichment,
+		CachedPricesOnly:  m.CachedPricesOnly || other.CachedPricesOnly,
+	}
+}
+
+type Provider string
+
+const (
+	ProviderDexscreener Provider = "dexscreener"
+	ProviderCoingecko   Provider = "coingecko"
+)
+
+// enrichmentProviders back token metadata and prices; enrichment is only switched off when all of
+// them are failing, since each one falls back to the other.
+var enrichmentProviders = []Provider{ProviderDexscreener, ProviderCoingecko}
+
+const (
+	failureThreshold = 5
+	failureCooldown  = 5 * time.Minute
+)
+
+type Status struct {
+	Effective        Mode
+	Manual           Mode
+	FailingProviders []string
+}
+
+var (
+	mu       sync.RWMutex
+	manual   Mode
+	failures = map[Provider]int{}
+	tripped  = map[Provider]time.Time{}
+)
+
+func init() {
+	env.LoadEnv(".env")
+	manual = parseModes(env.DEGRADATION_MODES.GetEnv())
+	if manual != (Mode{}) {
+		log.Printf("Starting in degraded mode: %+v", manual)
+	}
+}
+
+// parseModes reads a comma separated list of "discovery", "enrichment" and "cached_prices".
+func parseModes(raw string) Mode {
+	mode := Mode{}
+	for _, name := range strings.Split(raw, ",") {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "discovery":
+			mode.DisableDiscovery = true
+		case "enrichment":
+			mode.DisableEnrichment = true
+		case "cached_prices":
+			mode.CachedPricesOnly = true
+		}
+	}
+	return mode
+}
+
+// automaticLocked derives the mode from the providers that are currently failing.
+// mu must be held.
+func automaticLocked(now time.Time) (Mode, []string) {
+	failing := []string{}
+	for provider, until := range tripped {
+		if now.Before(until) {
+			failing = append(failing, string(provider))
+		}
+	}
+	slices.Sort(failing)
+	if len(failing) == 0 {
+		return Mode{}, failing
+	}
+
+	allEnrichmentFailing := true
+	for _, provider := range enrichmentProviders {
+		if !slices.Contains(failing, string(provider)) {
+			allEnrichmentFailing = false
+		}
+	}
+	// New launches would be created without metadata, so stop discovery as soon as any enrichment
+	// provider is down.
+	return Mode{
+		DisableDiscovery:  true,
+		DisableEnrichment: allEnrichmentFailing,
+		CachedPricesOnly:  allEnrichmentFailing,
+	}, failing
+}
+
+func Current() Status {
+	mu.RLock()
+	defer mu.RUnlock()
+	auto, failing := automaticLocked(time.Now())
+	return Status{
+		Effective:        manual.or(auto),
+		Manual:           manual,
+		FailingProviders: failing,
+	}
+}
+
+// SetManual replaces the manually enabled modes, e.g. during an incident.
+func SetManual(mode Mode) {
+	mu.Lock()
+	defer mu.Unlock()
+	log.Printf("Degradation mode set manually: %+v", mode)
+	manual = mode
+}
+
+// ReportFailure records a failed provider call. After failureThreshold consecutive failures the
+// provider is treated as down for failureCooldown.
+func ReportFailure(provider Provider) {
+	mu.Lock()
+	defer mu.Unlock()
+	failures[provider]++
+	if failures[provider] >= failureThreshold {
+		if until, ok := tripped[provider]; !ok || time.Now().After(until) {
+			log.Printf("Provider %s failed %d times in a row, degrading for %s", provider, failures[provider], failureCooldown)
+		}
+		tripped[provider] = time.Now().Add(failureCooldown)
+		failures[provider] = 0
+	}
+}
+
+func ReportSuccess(provider Provider) {
+	mu.Lock()
+	defer mu.Unlock()
+	failures[provider] = 0
+	if _, ok := tripped[provider]; ok {
+		log.Printf("Provider %s recovered", provider)
+		delete(tripped, provider)
+	}
+}
+
+func DiscoveryDisabled() bool {
+	return Current().Effective.DisableDiscovery
+}
+
+func EnrichmentDisabled() bool {
+	return Current().Effective.DisableEnrichment
+}
+
+func CachedPricesOnly() bool {
+	return Current().Effective.CachedPricesOnly
+}
+
+// Track reports the outcome of every request made with client against provider. Rate limits and
+// server errors count as failures; other statuses mean the provider is reachable.
+func Track(client *resty.Client, provider Provider) *resty.Client {
+	return client.
+		OnAfterResponse(func(c *resty.Client, resp *resty.Response) error {
+			if resp.StatusCode() == http.StatusTooManyRequests || resp.StatusCode() >= http.StatusInternalServerError {
+				ReportFailure(provider)
+			} else {
+				ReportSuccess(provider)
+			}
+			return nil
+		}).
+		OnError(func(req *resty.Request, err error) {
+			if _, ok := err.(*resty.ResponseError); ok {
+				return
+			}
+			ReportFailure(provider)
+		})
+}
+
+// PriceRefreshDisabled reports whether stored prices must not be refreshed from external providers.
+func PriceRefreshDisabled() bool {
+	mode := Current().Effective
+	return mode.CachedPricesOnly || mode.DisableEnrichment
+}
@@ -3,24 +3,23 @@ package dex
 import (
 	"encoding/json"
 	"errors"
+	neturl "net/url"
+	"samterminal/pkg/httpclient"
+	"samterminal/pkg/numeric"
+	"samterminal/pkg/usage"
 	"strconv"
 	db_dto "tokendata/database/dto"
 	"tokendata/env"
+	"tokendata/lib/cache"
+	"tokendata/lib/degrade"
 	dto "tokendata/lib/dex/dto"
 
 	"strings"
 
-	"github.com/go-resty/resty/v2"
+	"github.com/shopspring/decimal"
 )
 
-var apiKey string
-
-func init() {
-	env.LoadEnv(".env")
-	apiKey = env.CG_API_KEY.GetEnv()
-}
-
-var apiUrl = "https://pro-api.coingecko.com/api/v3/onchain/"
+const apiUrl = "https://pro-api.coingecko.com/api/v3/onchain/"
 
 var endpoints = dto.Endpoints{
 	TokenData: "networks/base/tokens/",
@@ -28,45 +27,61 @@ var endpoints = dto.Endpoints{
 }
 
 func getUrl(endpoint string) string {
-	return apiUrl + endpoint
+	return strings.TrimRight(env.COINGECKO_API_URL.GetEnvOrDefault(apiUrl), "/") + "/" + endpoint
+}
+
+// coingeckoResponses holds response bodies by URL and query so repeated lookups of the same token
+// or pool within a few seconds share one request.
+var coingeckoResponses = cache.New[[]byte](string(degrade.ProviderCoingecko))
+
+// coingeckoGet returns the body of a successful GET, served from the response cache when fresh.
+func coingeckoGet(url string, query map[string]string) ([]byte, error) {
+	values := neturl.Values{}
+	for name, value := range query {
+		values.Set(name, value)
+	}
+	key := url + "?" + values.Encode()
+	return coingeckoResponses.Get(key, func() ([]byte, error) {
+		client := usage.Track(degrade.Track(httpclient.New(), degrade.ProviderCoingecko), usage.Coingecko)
+		resp, err := client.R().
+			SetHeader("x-cg-pro-api-key", env.CG_API_KEY.GetEnv()).
+			SetQueryParams(query).
+			Get(url)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode() != 200 {
+			return nil, errors.New("unexpected status code")
+		}
+		return resp.Body(), nil
+	})
 }
 
 func fetchTokenData(tokenAddress db_dto.TokenAddress, includeTopPools bool) (*dto.TokenDataResponse, error) {
-	client := resty.New()
-	request := client.R().
-		SetHeader("x-cg-pro-api-key", apiKey)
+	query := map[string]string{}
 	if includeTopPools {
-		request = request.SetQueryParam("include", "top_pools")
+		query["include"] = "top_pools"
 	}
-	resp, err := request.Get(getUrl(endpoints.TokenData) + "/" + string(tokenAddress))
+	body, err := coingeckoGet(getUrl(endpoints.TokenData)+"/"+string(tokenAddress), query)
 	if err != nil {
 		return nil, err
 	}
-	if resp.StatusCode() != 200 {
-		return nil, errors.New("unexpected status code")
-	}
 
 	var responseData dto.TokenDataResponse
-	if err := json.Unmarshal(resp.Body(), &responseData); err != nil {
+	if err := json.Unmarshal(body, &responseData); err != nil {
 		return nil, err
 	}
 	return &responseData, nil
 }
 
 func fetchPoolData(poolAddress string) (*dto.PoolDataResponse, error) {
-	client := resty.New()
-	request := client.R().
-		SetHeader("x-cg-pro-api-key", apiKey)
-	resp, err := request.Get(getUrl(endpoints.PoolData) + poolAddress)
+	body, err := coingeckoGet(getUrl(endpoints.PoolData)+poolAddress, nil)
 	if err != nil {
 		return nil, err
 	}
-	if resp.StatusCode() != 200 {
-		return nil, errors.New("unexpected status code")
-	}
 
 	var responseData dto.PoolDataResponse
-	if err := json.Unmarshal(resp.Body(), &responseData); err != nil {
+	if err := json.Unmarshal(body, &responseData); err != nil {
 		return nil, err
 	}
 	return &responseData, nil
@@ -82,24 +97,24 @@ func tokenDataFromResponse(response *dto.TokenDataResponse) *dto.TokenData {
 	tokenData.Name = response.Data.Attributes.Name
 	tokenData.Symbol = response.Data.Attributes.Symbol
 
-	if price, err := strconv.ParseFloat(response.Data.Attributes.Price, 64); err == nil {
+	if price, err := numeric.Parse(response.Data.Attributes.Price); err == nil {
 		tokenData.Price = price
 	}
-	if volume, err := strconv.ParseFloat(response.Data.Attributes.Volume24H.USD, 64); err == nil {
+	if volume, err := numeric.Parse(response.Data.Attributes.Volume24H.USD); err == nil {
 		tokenData.Volume24H = volume
 	}
 
-	supplyValue, err := strconv.ParseFloat(response.Data.Attributes.Supply, 64)
+	supplyValue, err := numeric.Parse(response.Data.Attributes.Supply)
 	if err == nil {
-		tokenData.Supply = int64(supplyValue)
+		tokenData.Supply = supplyValue.IntPart()
 	} else {
-		supplyValue = 0
+		supplyValue = decimal.Zero
 	}
 
-	fdv, fdvErr := strconv.ParseFloat(response.Data.Attributes.FDVUSD, 64)
-	marketCap, marketCapErr := strconv.ParseFloat(response.Data.Attributes.MarketCapUSD, 64)
-	if fdvErr == nil && marketCapErr == nil && fdv != 0 && supplyValue != 0 {
-		tokenData.CirculatedSupply = int64((marketCap / fdv) * supplyValue)
+	fdv, fdvErr := numeric.Parse(response.Data.Attributes.FDVUSD)
+	marketCap, marketCapErr := numeric.Parse(response.Data.Attributes.MarketCapUSD)
+	if fdvErr == nil && marketCapErr == nil && !fdv.IsZero() && !supplyValue.IsZero() {
+		tokenData.CirculatedSupply = marketCap.Mul(supplyValue).Div(fdv).IntPart()
 	}
 
 	return tokenData
@@ -111,8 +126,8 @@ func tokenDataToString(tokenData *dto.TokenData) dto.TokenDataAsString {
 	}
 
 	return dto.TokenDataAsString{
-		Price:            strconv.FormatFloat(tokenData.Price, 'f', -1, 64),
-		Volume24H:        strconv.FormatFloat(tokenData.Volume24H, 'f', -1, 64),
+		Price:            numeric.FormatPrice(tokenData.Price),
+		Volume24H:        numeric.FormatUSD(tokenData.Volume24H),
 		Supply:           strconv.FormatInt(tokenData.Supply, 10),
 		CirculatedSupply: strconv.FormatInt(tokenData.CirculatedSupply, 10),
 		ImageURL:         tokenData.ImageURL,
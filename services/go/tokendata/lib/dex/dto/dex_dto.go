@@ -1,13 +1,15 @@
 package dex_dto
 
+import "github.com/shopspring/decimal"
+
 type Endpoints struct {
 	TokenData string
 	PoolData  string
 }
 
 type TokenData struct {
-	Price            float64
-	Volume24H        float64
+	Price            decimal.Decimal
+	Volume24H        decimal.Decimal
 	Supply           int64
 	CirculatedSupply int64
 	ImageURL         string
@@ -95,4 +97,9 @@ type PoolInfo struct {
 	PairAddress string
 	Volume24H   string
 	IsV4        bool
+	// Dexscreener dex id, e.g. "uniswap"; empty for pools from other providers.
+	DexID string
+	// Dexscreener labels of the pair, e.g. "v3". Uniswap pairs of every version share the dex
+	// id "uniswap" and only differ in their label.
+	Labels []string
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"samterminal/pkg/telemetry"
 	"tokendata/env"
 	"tokendata/lib/dex/grpc/server"
 	proto "tokendata/proto/token"
@@ -18,7 +19,7 @@ func StartServer() {
 	} else {
 		log.Printf("Server started at: %d", env.PORT.GetEnvAsNumber())
 	}
-	var opts []grpc_lib.ServerOption
+	opts := []grpc_lib.ServerOption{telemetry.GRPCServerOption()}
 	grpcServer := grpc_lib.NewServer(opts...)
 	proto.RegisterScannerTokenServer(grpcServer, server.NewDexServer())
 	err = grpcServer.Serve(lis)
@@ -2,16 +2,32 @@ package server
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
-	"strconv"
+	"math/big"
+	"samterminal/pkg/numeric"
+	"samterminal/pkg/usage"
+	"slices"
+	"strings"
+	"time"
 	dto "tokendata/database/dto"
 	"tokendata/database/repositories/blacklist"
+	"tokendata/database/repositories/discovery"
+	"tokendata/database/repositories/localization"
 	tokenRepository "tokendata/database/repositories/token"
+	db "tokendata/generated/prisma"
+	"tokendata/lib/apis"
+	"tokendata/lib/degrade"
+	"tokendata/lib/gas"
+	"tokendata/lib/jobs"
+	"tokendata/lib/quote"
 	protoCommon "tokendata/proto/common"
 	proto "tokendata/proto/token"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 type DexServerImpl struct {
@@ -31,6 +47,114 @@ func (s *DexServerImpl) AddToken(ctx context.Context, req *proto.AddTokenRequest
 	return response, nil
 }
 
+func (s *DexServerImpl) AddTokens(ctx context.Context, req *proto.AddTokensRequest) (*proto.AddTokensResponse, error) {
+	return &proto.AddTokensResponse{Results: tokenRepository.AddTokensToList(req.Tokens)}, nil
+}
+
+func (s *DexServerImpl) AddPool(ctx context.Context, req *proto.AddPoolRequest) (*proto.AddPoolResponse, error) {
+	var response = &proto.AddPoolResponse{}
+	if req.GetPoolAddress() == "" {
+		return nil, status.Error(codes.InvalidArgument, "poolAddress is required")
+	}
+	reason := "pool"
+	if req.Reason != nil && *req.Reason != "" {
+		reason = *req.Reason
+	}
+	process, pool := tokenRepository.AddPoolToTokenList(req.GetPoolAddress(), req.GetIsV4(), &reason)
+	response.Success = process.Success
+	response.Type = *process.AddingType
+	response.Message = process.Message
+	response.TokenAddress = pool.TokenAddress
+	response.PairAddress = pool.PairAddress
+	return response, nil
+}
+
+func (s *DexServerImpl) SetTokenPool(ctx context.Context, req *proto.SetTokenPoolRequest) (*proto.SetTokenPoolResponse, error) {
+	if req.GetTokenAddress() == "" {
+		return nil, status.Error(codes.InvalidArgument, "tokenAddress is required")
+	}
+	var token *db.TokenModel
+	var err error
+	if req.Unpin {
+		token, err = tokenRepository.UnpinTokenPool(ctx, req.GetTokenAddress())
+	} else {
+		token, err = tokenRepository.SetTokenPool(req.GetTokenAddress(), req.GetPoolAddress(), req.PoolType == proto.PoolType_POOL_UNISWAP_V4, req.GetPairAddress())
+	}
+	if errors.Is(err, tokenRepository.ErrTokenNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if errors.Is(err, tokenRepository.ErrInvalidPool) || errors.Is(err, tokenRepository.ErrPoolTokensDiffer) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		log.Printf("Error setting pool of %s: %+v", req.GetTokenAddress(), err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	poolAddress, _ := token.PoolAddress()
+	pairAddress, _ := token.PairAddress()
+	response := &proto.SetTokenPoolResponse{
+		TokenAddress: token.Address,
+		PoolAddress:  string(poolAddress),
+		PairAddress:  string(pairAddress),
+		Pinned:       token.PoolPinned,
+	}
+	if token.PoolType == db.DexPoolTypeUniswapV4 {
+		response.PoolType = proto.PoolType_POOL_UNISWAP_V4
+	}
+	return response, nil
+}
+
+func (s *DexServerImpl) SetTokenMinSwapUsd(ctx context.Context, req *proto.SetTokenMinSwapUsdRequest) (*proto.SetTokenMinSwapUsdResponse, error) {
+	if req.GetTokenAddress() == "" {
+		return nil, status.Error(codes.InvalidArgument, "tokenAddress is required")
+	}
+	token, err := tokenRepository.SetTokenMinSwapUSD(ctx, req.GetTokenAddress(), req.GetMinSwapUsd())
+	if errors.Is(err, tokenRepository.ErrTokenNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if errors.Is(err, tokenRepository.ErrInvalidMinSwapUSD) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		log.Printf("Error setting min swap USD of %s: %+v", req.GetTokenAddress(), err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	response := &proto.SetTokenMinSwapUsdResponse{TokenAddress: token.Address}
+	if minSwapUSD, ok := token.MinSwapUSD(); ok {
+		response.MinSwapUsd = minSwapUSD.String()
+	}
+	return response, nil
+}
+
+func (s *DexServerImpl) Resolve(ctx context.Context, req *proto.ResolveRequest) (*proto.ResolveResponse, error) {
+	var response = &proto.ResolveResponse{}
+	if req.GetQuery() == "" {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+	reason := "resolve"
+	if req.Reason != nil && *req.Reason != "" {
+		reason = *req.Reason
+	}
+	token, inputType, err := tokenRepository.Resolve(req.GetQuery(), req.GetCreateIfMissing(), &reason)
+	response.InputType = inputType
+	if errors.Is(err, tokenRepository.ErrUnresolvableQuery) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, tokenRepository.ErrTokenNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		response.Success = false
+		response.Message = err.Error()
+		return response, nil
+	}
+	response.Success = true
+	response.Message = "Resolved token"
+	response.Token = toProtoToken(token)
+	localizeTokens(ctx, []*protoCommon.Token{response.Token}, req.Locales)
+	return response, nil
+}
+
 func (s *DexServerImpl) RemoveToken(ctx context.Context, req *proto.RemoveTokenRequest) (*proto.RemoveTokenResponse, error) {
 	var response = &proto.RemoveTokenResponse{}
 	process := tokenRepository.RemoveFromTokenList(dto.TokenAddress(req.GetTokenAddress()), req.BypassEnds)
@@ -48,15 +172,19 @@ func (s *DexServerImpl) GetTokenPrice(ctx context.Context, req *proto.GetTokenPr
 		return response, status.Error(codes.InvalidArgument, "tokenAddress is required")
 	}
 
-	token, err := tokenRepository.GetToken(dto.TokenAddress(req.GetTokenAddress()))
+	token, err := tokenRepository.GetToken(ctx, dto.TokenAddress(req.GetTokenAddress()))
 
-	if err != nil {
+	// While enrichment is disabled archived tokens are served from their last stored price.
+	if err != nil && degrade.EnrichmentDisabled() {
+		return nil, status.Error(codes.Unavailable, "token not tracked and enrichment is disabled")
+	}
+	if err != nil || (token != nil && token.Archived && !degrade.EnrichmentDisabled()) {
 		reason := "token_price"
 		if req.Reason != nil && *req.Reason != "" {
 			reason = *req.Reason
 		}
 		tokenRepository.AddToTokenList(dto.TokenAddress(req.GetTokenAddress()), nil, nil, nil, nil, nil, nil, &reason, nil)
-		token, err = tokenRepository.GetToken(dto.TokenAddress(req.GetTokenAddress()))
+		token, err = tokenRepository.GetToken(ctx, dto.TokenAddress(req.GetTokenAddress()))
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "error getting token: %v", err)
 		}
@@ -68,31 +196,49 @@ func (s *DexServerImpl) GetTokenPrice(ctx context.Context, req *proto.GetTokenPr
 		return response, status.Error(codes.NotFound, "token not found")
 	}
 
-	price, err := parseFloatOrZero(token.Price)
+	maxAge := tokenRepository.StalePriceAfter()
+	if req.MaxAgeSeconds != nil && *req.MaxAgeSeconds > 0 {
+		maxAge = time.Duration(*req.MaxAgeSeconds) * time.Second
+	}
+	if tokenRepository.IsPriceStale(token, maxAge) {
+		token = tokenRepository.RefreshStaleTokenPrice(ctx, token)
+	}
+	response.LastUpdatedAt = unixMilli(token.LastUpdatedAt)
+	response.Stale = tokenRepository.IsPriceStale(token, maxAge)
+	if response.Stale && req.MaxAgeSeconds != nil && *req.MaxAgeSeconds > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "price of %s was last updated %s ago", token.Address, time.Since(token.LastUpdatedAt).Round(time.Second))
+	}
+
+	price, err := numeric.Parse(token.Price)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "invalid token price: %v", err)
 	}
-	volume24H, err := parseFloatOrZero(token.Volume24H)
+	volume24H, err := numeric.Parse(token.Volume24H)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "invalid token volume24h: %v", err)
 	}
 
+	source, _ := tokenRepository.PriceSourceOf(token)
 	response.Success = true
-	response.Price = strconv.FormatFloat(price, 'f', -1, 64)
-	response.Volume = strconv.FormatFloat(volume24H, 'f', -1, 64)
+	response.Price = numeric.FormatPrice(price)
+	response.Volume = numeric.FormatUSD(volume24H)
+	response.Source = string(source)
+	response.Confidence = tokenRepository.PriceConfidence(token, time.Now())
 	return response, nil
 }
 
-func parseFloatOrZero(v string) (float64, error) {
-	if v == "" {
-		return 0, nil
+func (s *DexServerImpl) GetToken(ctx context.Context, req *proto.GetTokenRequest) (*proto.GetTokenResponse, error) {
+	token, err := getToken(ctx, req)
+	if err != nil {
+		return nil, err
 	}
-	return strconv.ParseFloat(v, 64)
+	response := &proto.GetTokenResponse{Token: toProtoToken(token), Orderflow: tokenRepository.TokenOrderflow(token.Address)}
+	localizeTokens(ctx, []*protoCommon.Token{response.Token}, req.Locales)
+	return response, nil
 }
 
-func (s *DexServerImpl) GetToken(ctx context.Context, req *proto.GetTokenRequest) (*proto.GetTokenResponse, error) {
-	var response = &proto.GetTokenResponse{}
-
+// getToken returns the token a GetToken or GetTokenV2 request asks for.
+func getToken(ctx context.Context, req *proto.GetTokenRequest) (*db.TokenModel, error) {
 	if req.TokenAddress == "" {
 		return nil, status.Error(codes.InvalidArgument, "tokenAddress is required")
 	}
@@ -101,65 +247,256 @@ func (s *DexServerImpl) GetToken(ctx context.Context, req *proto.GetTokenRequest
 		reason := "wallet_token"
 		tokenRepository.AddToTokenList(dto.TokenAddress(req.GetTokenAddress()), nil, nil, nil, nil, nil, nil, &reason, nil)
 	}
-	token, err := tokenRepository.GetToken(dto.TokenAddress(req.TokenAddress))
-	tokenRepository.UpdateLastUsedAt(dto.TokenAddress(req.TokenAddress))
+	token, err := tokenRepository.GetToken(ctx, dto.TokenAddress(req.TokenAddress))
+	tokenRepository.UpdateLastUsedAt(ctx, dto.TokenAddress(req.TokenAddress))
 	if err != nil {
 		return nil, err
 	}
+	return token, nil
+}
+
+func toProtoToken(token *db.TokenModel) *protoCommon.Token {
 	poolAddress, _ := token.PoolAddress()
 	reason, _ := token.Reason()
 	pairAddress, _ := token.PairAddress()
-	response.Token = &protoCommon.Token{
-		Name:             token.Name,
-		Symbol:           token.Symbol,
-		Price:            token.Price,
-		Volume:           token.Volume24H,
-		ImageUrl:         token.ImageURL,
-		Address:          token.Address,
-		CalculatedVolume: strconv.FormatFloat(token.CalculatedVolume24H, 'f', -1, 64),
-		PoolAddress:      string(poolAddress),
-		Supply:           token.Supply,
-		CirculatedSupply: token.CirculatedSupply,
-		Reason:           reason,
-		PairAddress:      string(pairAddress),
+	website, _ := token.Website()
+	twitter, _ := token.Twitter()
+	telegram, _ := token.Telegram()
+	description, _ := token.Description()
+	headerImageURL, _ := token.HeaderImageURL()
+	cachedImageURL, _ := token.CachedImageURL()
+	deployerAddress, _ := token.DeployerAddress()
+	deployerLaunchCount, _ := token.DeployerLaunchCount()
+	deployerRugCount, _ := token.DeployerRugCount()
+	poolHooks, _ := token.PoolHooks()
+	contractName, _ := token.ContractName()
+	compilerVersion, _ := token.CompilerVersion()
+	sourceHash, _ := token.SourceHash()
+	implementation, _ := token.Implementation()
+	marketCap, fdv := tokenRepository.TokenValuation(token)
+	risk := tokenRepository.TokenRisk(token)
+	var delistedAt int64
+	if at, ok := token.DelistedAt(); ok {
+		delistedAt = at.UnixMilli()
+	}
+	var deployerRiskScore *int32
+	if score, ok := token.DeployerRiskScore(); ok {
+		score := int32(score)
+		deployerRiskScore = &score
+	}
+	var transferTaxBps *int32
+	if bps, ok := token.TransferTaxBps(); ok {
+		bps := int32(bps)
+		transferTaxBps = &bps
+	}
+	var trendingRank *int32
+	if rank, ok := token.TrendingRank(); ok {
+		rank := int32(rank)
+		trendingRank = &rank
+	}
+	casts, casters := farcasterMomentumOf(token)
+	var contractVerified *bool
+	if _, ok := token.ContractCheckedAt(); ok {
+		contractVerified = &token.ContractVerified
+	}
+	priceSource, priceUpdatedAt := tokenRepository.PriceSourceOf(token)
+	deployedAt, tokenAge := tokenAgeOf(token, time.Now())
+	return &protoCommon.Token{
+		Name:                 token.Name,
+		Symbol:               token.Symbol,
+		Price:                token.Price,
+		Volume:               token.Volume24H,
+		ImageUrl:             token.ImageURL,
+		Address:              token.Address,
+		CalculatedVolume:     numeric.FormatUSD(token.CalculatedVolume24H),
+		PoolAddress:          string(poolAddress),
+		Supply:               token.Supply,
+		CirculatedSupply:     token.CirculatedSupply,
+		Reason:               reason,
+		PairAddress:          string(pairAddress),
+		Archived:             token.Archived,
+		Website:              website,
+		Twitter:              twitter,
+		Telegram:             telegram,
+		Description:          description,
+		HeaderImageUrl:       headerImageURL,
+		DeployerAddress:      deployerAddress,
+		DeployerRiskScore:    deployerRiskScore,
+		DeployerLaunchCount:  int32(deployerLaunchCount),
+		DeployerRugCount:     int32(deployerRugCount),
+		CachedImageUrl:       cachedImageURL,
+		Delisted:             token.Delisted,
+		DelistedAt:           delistedAt,
+		MarketCap:            marketCap,
+		Fdv:                  fdv,
+		Tags:                 token.Tags,
+		PoolHooks:            poolHooks,
+		PoolDynamicFee:       token.PoolDynamicFee,
+		TransferTaxBps:       transferTaxBps,
+		ContractVerified:     contractVerified,
+		ContractName:         contractName,
+		CompilerVersion:      compilerVersion,
+		SourceHash:           sourceHash,
+		ContractIsProxy:      token.ContractIsProxy,
+		Implementation:       implementation,
+		PriceSource:          string(priceSource),
+		PriceUpdatedAt:       unixMilli(priceUpdatedAt),
+		PriceConfidence:      tokenRepository.PriceConfidence(token, time.Now()),
+		RiskScore:            int32(risk.Score),
+		UncheckedRiskSignals: risk.Unchecked(),
+		TrendingRank:         trendingRank,
+		FarcasterCasts1H:     casts,
+		FarcasterCasters1H:   casters,
+		DeployedAt:           deployedAt,
+		TokenAge:             tokenAge,
 	}
-	return response, nil
 }
 
-func (s *DexServerImpl) GetTokens(ctx context.Context, req *proto.GetTokensRequest) (*proto.GetTokensResponse, error) {
-	var response = &proto.GetTokensResponse{}
+// tokenAgeOf returns when a token was deployed, in Unix milliseconds, and its age in seconds at
+// now; both are 0 until the deployment was looked up.
+func tokenAgeOf(token *db.TokenModel, now time.Time) (int64, int64) {
+	deployedAt, ok := token.DeployedAt()
+	if !ok {
+		return 0, 0
+	}
+	return deployedAt.UnixMilli(), max(int64(now.Sub(deployedAt)/time.Second), 0)
+}
+
+// farcasterMomentumOf returns the Farcaster casts and casters of a token, nil until checked.
+func farcasterMomentumOf(token *db.TokenModel) (*int32, *int32) {
+	casts, ok := token.FarcasterCasts1H()
+	if !ok {
+		return nil, nil
+	}
+	casters, _ := token.FarcasterCasters1H()
+	castCount, casterCount := int32(casts), int32(casters)
+	return &castCount, &casterCount
+}
 
-	tokens, err := tokenRepository.GetAllTokens(req.TokenAddresses, nil)
+// localizeTokens replaces the name and description of tokens with their translation for the
+// preferred locales. Tokens are returned untranslated when the lookup fails.
+func localizeTokens(ctx context.Context, tokens []*protoCommon.Token, locales []string) {
+	if len(locales) == 0 || len(tokens) == 0 {
+		return
+	}
+	addresses := make([]string, len(tokens))
+	for i, token := range tokens {
+		addresses[i] = token.Address
+	}
+	localizations, err := localization.GetTokenLocalizations(ctx, addresses, locales)
+	if err != nil {
+		log.Printf("Error getting token localizations: %+v", err)
+		return
+	}
+	for _, token := range tokens {
+		translated, ok := localizations[token.Address]
+		if !ok {
+			continue
+		}
+		if name, ok := translated.Name(); ok {
+			token.Name = name
+		}
+		if description, ok := translated.Description(); ok {
+			token.Description = description
+		}
+		token.Locale = translated.Locale
+	}
+}
+
+func (s *DexServerImpl) GetTokens(ctx context.Context, req *proto.GetTokensRequest) (*proto.GetTokensResponse, error) {
+	response, _, err := getTokens(ctx, req)
 	if err != nil {
 		return nil, err
 	}
+	if err := maskTokens(response.Tokens, req.Fields); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return response, nil
+}
+
+// maskTokens clears the fields of tokens that are not listed, keeping the address. No fields
+// keep every field.
+func maskTokens(tokens []*protoCommon.Token, fields []string) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	descriptor := (&protoCommon.Token{}).ProtoReflect().Descriptor().Fields()
+	keep := map[protoreflect.FieldNumber]bool{descriptor.ByName("address").Number(): true}
+	for _, name := range fields {
+		field := descriptor.ByName(protoreflect.Name(name))
+		if field == nil {
+			return fmt.Errorf("unknown token field %q", name)
+		}
+		keep[field.Number()] = true
+	}
 	for _, token := range tokens {
-		poolAddress, _ := token.PoolAddress()
-		reason, _ := token.Reason()
-		pairAddress, _ := token.PairAddress()
-		response.Tokens = append(response.Tokens, &protoCommon.Token{
-			Name:             token.Name,
-			Symbol:           token.Symbol,
-			Price:            token.Price,
-			Volume:           token.Volume24H,
-			ImageUrl:         token.ImageURL,
-			Address:          token.Address,
-			CalculatedVolume: strconv.FormatFloat(token.CalculatedVolume24H, 'f', -1, 64),
-			PoolAddress:      string(poolAddress),
-			PairAddress:      string(pairAddress),
-			Supply:           token.Supply,
-			CirculatedSupply: token.CirculatedSupply,
-			Reason:           reason,
+		message := token.ProtoReflect()
+		message.Range(func(field protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+			if !keep[field.Number()] {
+				message.Clear(field)
+			}
+			return true
 		})
 	}
-	return response, nil
+	return nil
+}
+
+// getTokens returns the page of tokens a GetTokens or GetTokensV2 request asks for, along with
+// the stored tokens of the page by address.
+func getTokens(ctx context.Context, req *proto.GetTokensRequest) (*proto.GetTokensResponse, map[string]*db.TokenModel, error) {
+	var response = &proto.GetTokensResponse{}
+
+	excludeUnsecure := !req.GetIncludeBlacklisted()
+	tokens, err := tokenRepository.GetAllTokens(ctx, req.TokenAddresses, &excludeUnsecure, req.GetIncludeArchived(), req.Tags)
+	if errors.Is(err, tokenRepository.ErrTokensQuery) {
+		log.Printf("Error getting tokens: %+v", err)
+		return nil, nil, status.Error(codes.Internal, err.Error())
+	}
+	// Unsecure tokens are reported as missing rather than failing the request.
+	if err != nil && !errors.Is(err, tokenRepository.ErrAllTokensFiltered) {
+		return nil, nil, status.Error(codes.Unknown, err.Error())
+	}
+	models := map[string]*db.TokenModel{}
+	for i := range tokens {
+		token := &tokens[i]
+		response.Tokens = append(response.Tokens, toProtoToken(token))
+		response.FoundAddresses = append(response.FoundAddresses, token.Address)
+		models[token.Address] = token
+	}
+	for _, tokenAddress := range req.TokenAddresses {
+		tokenAddress = strings.ToLower(tokenAddress)
+		if models[tokenAddress] == nil && !slices.Contains(response.MissingAddresses, tokenAddress) {
+			response.MissingAddresses = append(response.MissingAddresses, tokenAddress)
+		}
+	}
+	response.Partial = len(response.MissingAddresses) > 0
+	if req.MaxRiskScore != nil {
+		response.Tokens = slices.DeleteFunc(response.Tokens, func(token *protoCommon.Token) bool {
+			return token.RiskScore > req.GetMaxRiskScore()
+		})
+	}
+	if req.MaxAgeSeconds != nil {
+		response.Tokens = slices.DeleteFunc(response.Tokens, func(token *protoCommon.Token) bool {
+			return token.DeployedAt == 0 || token.TokenAge > req.GetMaxAgeSeconds()
+		})
+	}
+	sortTokens(response.Tokens, req.Sort)
+	if req.Limit != nil || req.Cursor != "" {
+		page, err := pageTokens(response.Tokens, req.Sort, req.Cursor, int(req.GetLimit()))
+		if err != nil {
+			return nil, nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		response.Tokens, response.NextCursor, response.HasMore = page.tokens, page.nextCursor, page.hasMore
+	}
+	localizeTokens(ctx, response.Tokens, req.Locales)
+	return response, models, nil
 }
 
 func (s *DexServerImpl) AddBlacklist(ctx context.Context, req *proto.AddBlacklistRequest) (*proto.AddBlacklistResponse, error) {
 
 	log.Printf("Adding tokens to blacklist: %+v", req.TokenAddresses)
 	var response = &proto.AddBlacklistResponse{}
-	err := blacklist.AddToBlacklist(req.TokenAddresses)
+	err := blacklist.AddToBlacklist(ctx, req.TokenAddresses)
 	if err != nil {
 		response.Success = false
 		return response, err
@@ -167,3 +504,592 @@ func (s *DexServerImpl) AddBlacklist(ctx context.Context, req *proto.AddBlacklis
 	response.Success = true
 	return response, nil
 }
+
+func (s *DexServerImpl) RemoveBlacklist(ctx context.Context, req *proto.RemoveBlacklistRequest) (*proto.RemoveBlacklistResponse, error) {
+	log.Printf("Removing tokens from blacklist: %+v", req.TokenAddresses)
+	if err := blacklist.RemoveFromBlacklist(ctx, req.TokenAddresses); err != nil {
+		return &proto.RemoveBlacklistResponse{Success: false}, status.Error(codes.Internal, err.Error())
+	}
+	return &proto.RemoveBlacklistResponse{Success: true}, nil
+}
+
+// currentBlacklist returns every blacklisted address once.
+func currentBlacklist(ctx context.Context) ([]string, error) {
+	addresses, err := blacklist.GetAllBlacklistAddresses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(addresses)
+	return slices.Compact(addresses), nil
+}
+
+func (s *DexServerImpl) GetBlacklist(ctx context.Context, req *proto.GetBlacklistRequest) (*proto.GetBlacklistResponse, error) {
+	addresses, err := currentBlacklist(ctx)
+	if err != nil {
+		log.Printf("Error getting blacklist: %+v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &proto.GetBlacklistResponse{TokenAddresses: addresses}, nil
+}
+
+func (s *DexServerImpl) WatchBlacklist(req *proto.WatchBlacklistRequest, stream proto.ScannerToken_WatchBlacklistServer) error {
+	// Watching starts before the snapshot is read so that no change falls in between; changes
+	// the snapshot already holds are sent again, which a mirror applies as no-ops.
+	watcher := blacklist.Watch()
+	defer watcher.Close()
+
+	addresses, err := currentBlacklist(stream.Context())
+	if err != nil {
+		log.Printf("Error getting blacklist: %+v", err)
+		return status.Error(codes.Internal, err.Error())
+	}
+	if err := stream.Send(&proto.BlacklistChange{Type: proto.BlacklistChangeType_BLACKLIST_SNAPSHOT, TokenAddresses: addresses}); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-watcher.Done():
+			return status.Error(codes.ResourceExhausted, "blacklist watcher fell behind")
+		case change := <-watcher.Changes():
+			changeType := proto.BlacklistChangeType_BLACKLIST_ADDED
+			if change.Type == blacklist.ChangeRemoved {
+				changeType = proto.BlacklistChangeType_BLACKLIST_REMOVED
+			}
+			if err := stream.Send(&proto.BlacklistChange{Type: changeType, TokenAddresses: change.Addresses}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *DexServerImpl) StreamTokenTrades(req *proto.StreamTokenTradesRequest, stream proto.ScannerToken_StreamTokenTradesServer) error {
+	if req.GetTokenAddress() == "" {
+		return status.Error(codes.InvalidArgument, "tokenAddress is required")
+	}
+	if _, err := tokenRepository.GetToken(stream.Context(), dto.TokenAddress(req.GetTokenAddress())); err != nil {
+		return status.Error(codes.NotFound, "token not tracked")
+	}
+	watcher := tokenRepository.WatchTrades(req.GetTokenAddress())
+	defer watcher.Close()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case trade := <-watcher.Trades():
+			if err := stream.Send(trade); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+const (
+	defaultTokenHoldersLimit = 20
+	maxTokenHoldersLimit     = 100
+)
+
+func (s *DexServerImpl) GetTokenRisk(ctx context.Context, req *proto.GetTokenRiskRequest) (*proto.GetTokenRiskResponse, error) {
+	if req.GetTokenAddress() == "" {
+		return nil, status.Error(codes.InvalidArgument, "tokenAddress is required")
+	}
+	assessment, checkedAt, err := tokenRepository.GetTokenRisk(dto.TokenAddress(req.GetTokenAddress()), req.GetRefresh())
+	if errors.Is(err, tokenRepository.ErrTokenNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	response := &proto.GetTokenRiskResponse{
+		TokenAddress: strings.ToLower(req.GetTokenAddress()),
+		Score:        int32(assessment.Score),
+		CheckedAt:    unixMilli(checkedAt),
+	}
+	for _, factor := range assessment.Factors {
+		response.Factors = append(response.Factors, &proto.RiskFactor{
+			Signal: factor.Signal,
+			Points: int32(factor.Points),
+			Detail: factor.Detail,
+			Known:  factor.Known,
+		})
+	}
+	response.UncheckedSignals = assessment.Unchecked()
+	return response, nil
+}
+
+func (s *DexServerImpl) GetTokenHolders(ctx context.Context, req *proto.GetTokenHoldersRequest) (*proto.GetTokenHoldersResponse, error) {
+	var response = &proto.GetTokenHoldersResponse{}
+	if req.GetTokenAddress() == "" {
+		return nil, status.Error(codes.InvalidArgument, "tokenAddress is required")
+	}
+	limit := defaultTokenHoldersLimit
+	if req.Limit != nil && *req.Limit > 0 {
+		limit = min(int(*req.Limit), maxTokenHoldersLimit)
+	}
+
+	holders, err := apis.GetTopTokenHolders(strings.ToLower(req.GetTokenAddress()), limit)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "error getting token holders: %v", err)
+	}
+	for _, holder := range holders {
+		response.Holders = append(response.Holders, &proto.TokenHolder{
+			Address:            strings.ToLower(holder.OwnerAddress),
+			Balance:            holder.Balance,
+			BalanceFormatted:   holder.BalanceFormatted,
+			PercentageOfSupply: holder.PercentageOfSupply,
+		})
+	}
+	return response, nil
+}
+
+func degradationModeResponse() *proto.DegradationModeResponse {
+	current := degrade.Current()
+	return &proto.DegradationModeResponse{
+		Effective: &proto.DegradationMode{
+			DisableDiscovery:  current.Effective.DisableDiscovery,
+			DisableEnrichment: current.Effective.DisableEnrichment,
+			CachedPricesOnly:  current.Effective.CachedPricesOnly,
+		},
+		Manual: &proto.DegradationMode{
+			DisableDiscovery:  current.Manual.DisableDiscovery,
+			DisableEnrichment: current.Manual.DisableEnrichment,
+			CachedPricesOnly:  current.Manual.CachedPricesOnly,
+		},
+		FailingProviders: current.FailingProviders,
+	}
+}
+
+// SetDegradationMode replaces the manually enabled degradation modes. Modes entered automatically
+// because of provider failures stay active until the providers recover.
+func (s *DexServerImpl) SetDegradationMode(ctx context.Context, req *proto.SetDegradationModeRequest) (*proto.DegradationModeResponse, error) {
+	mode := req.GetMode()
+	degrade.SetManual(degrade.Mode{
+		DisableDiscovery:  mode.GetDisableDiscovery(),
+		DisableEnrichment: mode.GetDisableEnrichment(),
+		CachedPricesOnly:  mode.GetCachedPricesOnly(),
+	})
+	return degradationModeResponse(), nil
+}
+
+func (s *DexServerImpl) GetDegradationMode(ctx context.Context, req *proto.GetDegradationModeRequest) (*proto.DegradationModeResponse, error) {
+	return degradationModeResponse(), nil
+}
+
+func toProtoTokenLocalization(model *db.TokenLocalizationModel) *proto.TokenLocalization {
+	localization := &proto.TokenLocalization{TokenAddress: model.TokenAddress, Locale: model.Locale}
+	if name, ok := model.Name(); ok {
+		localization.Name = &name
+	}
+	if description, ok := model.Description(); ok {
+		localization.Description = &description
+	}
+	return localization
+}
+
+func localizationError(err error) error {
+	switch {
+	case errors.Is(err, localization.ErrInvalidTokenAddress),
+		errors.Is(err, localization.ErrInvalidLocale),
+		errors.Is(err, localization.ErrEmptyLocalization):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	log.Printf("Error managing token localization: %+v", err)
+	return status.Error(codes.Internal, err.Error())
+}
+
+func (s *DexServerImpl) SetTokenLocalization(ctx context.Context, req *proto.SetTokenLocalizationRequest) (*proto.SetTokenLocalizationResponse, error) {
+	input := req.GetLocalization()
+	if input == nil {
+		return nil, status.Error(codes.InvalidArgument, "localization is required")
+	}
+	model, err := localization.SetTokenLocalization(ctx, input.GetTokenAddress(), input.GetLocale(), input.Name, input.Description)
+	if err != nil {
+		return nil, localizationError(err)
+	}
+	return &proto.SetTokenLocalizationResponse{Localization: toProtoTokenLocalization(model)}, nil
+}
+
+func (s *DexServerImpl) RemoveTokenLocalization(ctx context.Context, req *proto.RemoveTokenLocalizationRequest) (*proto.RemoveTokenLocalizationResponse, error) {
+	if err := localization.RemoveTokenLocalization(ctx, req.GetTokenAddress(), req.GetLocale()); err != nil {
+		return nil, localizationError(err)
+	}
+	return &proto.RemoveTokenLocalizationResponse{Success: true}, nil
+}
+
+func (s *DexServerImpl) ListTokenLocalizations(ctx context.Context, req *proto.ListTokenLocalizationsRequest) (*proto.ListTokenLocalizationsResponse, error) {
+	if req.GetTokenAddress() == "" {
+		return nil, status.Error(codes.InvalidArgument, "tokenAddress is required")
+	}
+	models, err := localization.ListTokenLocalizations(ctx, req.GetTokenAddress())
+	if err != nil {
+		return nil, localizationError(err)
+	}
+	response := &proto.ListTokenLocalizationsResponse{}
+	for i := range models {
+		response.Localizations = append(response.Localizations, toProtoTokenLocalization(&models[i]))
+	}
+	return response, nil
+}
+
+func (s *DexServerImpl) GetDiscoveryFeed(ctx context.Context, req *proto.GetDiscoveryFeedRequest) (*proto.GetDiscoveryFeedResponse, error) {
+	events, nextCursor, hasMore, err := discovery.GetFeed(ctx, req.GetCursor(), int(req.GetLimit()), req.GetSources())
+	if errors.Is(err, discovery.ErrInvalidCursor) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		log.Printf("Error getting discovery feed: %+v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	response := &proto.GetDiscoveryFeedResponse{NextCursor: nextCursor, HasMore: hasMore}
+	for _, event := range events {
+		discoveredAt, _ := event.DiscoveredAt()
+		token := &proto.DiscoveredToken{
+			Source:       event.Source,
+			TokenAddress: event.TokenAddress,
+			DiscoveredAt: discoveredAt.Unix(),
+			Cursor:       discovery.EncodeFeedCursor(discoveredAt, event.ID),
+		}
+		token.PairAddress, _ = event.PairAddress()
+		token.PoolAddress, _ = event.PoolAddress()
+		token.Name, _ = event.Name()
+		token.Symbol, _ = event.Symbol()
+		token.ImageUrl, _ = event.ImageURL()
+		response.Tokens = append(response.Tokens, token)
+	}
+	return response, nil
+}
+
+func (s *DexServerImpl) GetRecentLaunches(ctx context.Context, req *proto.GetRecentLaunchesRequest) (*proto.GetRecentLaunchesResponse, error) {
+	if req.GetSince() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "since must not be negative")
+	}
+	if req.GetMinAthMultiple() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "minAthMultiple must not be negative")
+	}
+	launches, err := discovery.GetRecentLaunches(ctx, req.GetSource(), time.Unix(req.GetSince(), 0), int(req.GetLimit()), req.GetMinAthMultiple())
+	if err != nil {
+		log.Printf("Error getting recent launches: %+v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	response := &proto.GetRecentLaunchesResponse{Launches: []*proto.RecentLaunch{}}
+	for _, launch := range launches {
+		response.Launches = append(response.Launches, toProtoLaunch(launch))
+	}
+	return response, nil
+}
+
+func toProtoLaunch(launch discovery.Launch) *proto.RecentLaunch {
+	discoveredAt, _ := launch.Event.DiscoveredAt()
+	entry := &proto.RecentLaunch{
+		Source:        launch.Event.Source,
+		TokenAddress:  launch.Token.Address,
+		Name:          launch.Token.Name,
+		Symbol:        launch.Token.Symbol,
+		ImageUrl:      launch.Token.ImageURL,
+		DiscoveredAt:  discoveredAt.Unix(),
+		CreatedAt:     launch.Token.CreatedAt.Unix(),
+		Price:         launch.Token.Price,
+		PriceMultiple: launch.PriceMultiple(),
+	}
+	entry.PoolAddress, _ = launch.Token.PoolAddress()
+	entry.PairAddress, _ = launch.Token.PairAddress()
+	entry.InitialPrice, _ = launch.Token.InitialPrice()
+	entry.AthPrice, _ = launch.Token.AthPrice()
+	entry.AthMultiple, _ = launch.Token.AthMultiple()
+	if athAt, ok := launch.Token.AthAt(); ok {
+		entry.AthAt = athAt.Unix()
+	}
+	if deployer, ok := launch.Token.DeployerAddress(); ok {
+		entry.DeployerAddress = &deployer
+	}
+	if riskScore, ok := launch.Token.DeployerRiskScore(); ok {
+		score := int32(riskScore)
+		entry.DeployerRiskScore = &score
+	}
+	entry.FarcasterCasts1H, entry.FarcasterCasters1H = farcasterMomentumOf(&launch.Token)
+	entry.Orderflow = tokenRepository.TokenOrderflow(launch.Token.Address)
+	return entry
+}
+
+const (
+	// launchFilterGrace is how long a launch is held for the checks a filter depends on. The
+	// checks of held launches are run on demand rather than left to their crons.
+	launchFilterGrace = 5 * time.Minute
+	// launchFilterRecheck is how often held launches are read again.
+	launchFilterRecheck = 15 * time.Second
+)
+
+func (s *DexServerImpl) SubscribeFilteredLaunches(req *proto.SubscribeFilteredLaunchesRequest, stream proto.ScannerToken_SubscribeFilteredLaunchesServer) error {
+	filter, err := launchFilterOf(req)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	watcher := discovery.WatchLaunches()
+	defer watcher.Close()
+	ticker := time.NewTicker(launchFilterRecheck)
+	defer ticker.Stop()
+
+	held := map[string]discovery.Launch{}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case launch := <-watcher.Launches():
+			switch filter.Check(launch) {
+			case discovery.FilterMatched:
+				if err := stream.Send(toProtoLaunch(launch)); err != nil {
+					return err
+				}
+			case discovery.FilterPending:
+				held[launch.Token.Address] = launch
+				requestLaunchChecks(filter, launch)
+			}
+		case now := <-ticker.C:
+			for address, launch := range held {
+				token, err := tokenRepository.GetToken(stream.Context(), dto.TokenAddress(address))
+				if err != nil {
+					delete(held, address)
+					continue
+				}
+				launch.Token = *token
+				result := filter.Check(launch)
+				discoveredAt, _ := launch.Event.DiscoveredAt()
+				if result == discovery.FilterPending {
+					if now.Sub(discoveredAt) < launchFilterGrace {
+						requestLaunchChecks(filter, launch)
+						continue
+					}
+					missing := filter.Missing(launch)
+					log.Printf("Dropping launch %s from a filtered subscription after %s without checks (risk: %t, deployer: %t, farcaster: %t)",
+						address, launchFilterGrace, missing.Risk, missing.Deployer, missing.Farcaster)
+				}
+				delete(held, address)
+				if result == discovery.FilterMatched {
+					if err := stream.Send(toProtoLaunch(launch)); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+}
+
+// requestLaunchChecks runs the checks a held launch waits for.
+func requestLaunchChecks(filter discovery.LaunchFilter, launch discovery.Launch) {
+	missing := filter.Missing(launch)
+	tokenRepository.RequestLaunchChecks(dto.TokenAddress(launch.Token.Address), missing.Risk, missing.Deployer, missing.Farcaster)
+}
+
+// launchFilterOf builds the filter of a subscription.
+func launchFilterOf(req *proto.SubscribeFilteredLaunchesRequest) (discovery.LaunchFilter, error) {
+	if req.GetMinLiquidityUsd() < 0 {
+		return discovery.LaunchFilter{}, errors.New("minLiquidityUsd must not be negative")
+	}
+	filter := discovery.LaunchFilter{Sources: req.GetSources(), MinLiquidityUSD: req.MinLiquidityUsd}
+	for _, keyword := range req.GetKeywords() {
+		if keyword = strings.TrimSpace(keyword); keyword != "" {
+			filter.Keywords = append(filter.Keywords, keyword)
+		}
+	}
+	optionalInt := func(value *int32) *int {
+		if value == nil {
+			return nil
+		}
+		converted := int(*value)
+		return &converted
+	}
+	filter.MaxDeployerRiskScore = optionalInt(req.MaxDeployerRiskScore)
+	filter.MinFarcasterCasts1H = optionalInt(req.MinFarcasterCasts1H)
+	filter.MinFarcasterCasters1H = optionalInt(req.MinFarcasterCasters1H)
+	return filter, nil
+}
+
+func (s *DexServerImpl) GetQuote(ctx context.Context, req *proto.GetQuoteRequest) (*proto.GetQuoteResponse, error) {
+	if req.GetTokenAddress() == "" {
+		return nil, status.Error(codes.InvalidArgument, "tokenAddress is required")
+	}
+	response, err := tokenRepository.GetQuote(req.GetTokenAddress(), req.GetSide(), req.GetAmount())
+	switch {
+	case errors.Is(err, tokenRepository.ErrInvalidAmount):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, tokenRepository.ErrTokenNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, tokenRepository.ErrTokenNoPool), errors.Is(err, quote.ErrPoolKeyNotFound), errors.Is(err, quote.ErrTokenNotInPool):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case err != nil:
+		log.Printf("Error getting quote: %+v", err)
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	return response, nil
+}
+
+func gwei(wei *big.Int) string {
+	return new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(1e9)).Text('f', 9)
+}
+
+func gasFeeLevel(level gas.Level) *proto.GasFeeLevel {
+	return &proto.GasFeeLevel{PriorityFeeGwei: gwei(level.PriorityFee), MaxFeeGwei: gwei(level.MaxFee)}
+}
+
+func (s *DexServerImpl) GetGasPrice(ctx context.Context, req *proto.GetGasPriceRequest) (*proto.GetGasPriceResponse, error) {
+	snapshot, err := gas.Current()
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	return &proto.GetGasPriceResponse{
+		BlockNumber:     snapshot.BlockNumber,
+		BaseFeeGwei:     gwei(snapshot.BaseFee),
+		NextBaseFeeGwei: gwei(snapshot.NextBaseFee),
+		Slow:            gasFeeLevel(snapshot.Slow),
+		Standard:        gasFeeLevel(snapshot.Standard),
+		Fast:            gasFeeLevel(snapshot.Fast),
+		UpdatedAt:       snapshot.UpdatedAt.Unix(),
+	}, nil
+}
+
+func unixMilli(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+func cronJob(status jobs.Status) *proto.CronJob {
+	return &proto.CronJob{
+		Name:            status.Name,
+		IntervalSeconds: int64(status.Interval.Seconds()),
+		TimeoutSeconds:  int64(status.Timeout.Seconds()),
+		Running:         status.Running,
+		LastStartedAt:   unixMilli(status.LastStartedAt),
+		LastFinishedAt:  unixMilli(status.LastFinishedAt),
+		LastDurationMs:  status.LastDuration.Milliseconds(),
+		LastResult:      string(status.LastResult),
+		Runs:            status.Runs,
+		MissedRuns:      status.MissedRuns,
+		NextRunAt:       unixMilli(status.NextRunAt),
+	}
+}
+
+func (s *DexServerImpl) ListCronJobs(ctx context.Context, req *proto.ListCronJobsRequest) (*proto.ListCronJobsResponse, error) {
+	response := &proto.ListCronJobsResponse{Jobs: []*proto.CronJob{}}
+	for _, status := range jobs.Statuses() {
+		response.Jobs = append(response.Jobs, cronJob(status))
+	}
+	return response, nil
+}
+
+func (s *DexServerImpl) RunCronJob(ctx context.Context, req *proto.RunCronJobRequest) (*proto.RunCronJobResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	job, err := jobs.Trigger(req.GetName())
+	switch {
+	case errors.Is(err, jobs.ErrUnknownJob):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, jobs.ErrJobRunning):
+		return &proto.RunCronJobResponse{Started: false, Job: cronJob(job)}, nil
+	}
+	return &proto.RunCronJobResponse{Started: true, Job: cronJob(job)}, nil
+}
+
+func toProtoApiUsage(report usage.Report) *protoCommon.ApiUsage {
+	response := &protoCommon.ApiUsage{Day: report.Day}
+	for _, provider := range report.Providers {
+		providerUsage := &protoCommon.ProviderApiUsage{
+			Provider:   provider.Provider,
+			Calls:      provider.Calls,
+			Budget:     provider.Budget,
+			OverBudget: provider.OverBudget,
+		}
+		for _, path := range provider.Paths {
+			providerUsage.Paths = append(providerUsage.Paths, &protoCommon.ApiCallPath{Endpoint: path.Endpoint, Caller: path.Caller, Calls: path.Calls})
+		}
+		response.Providers = append(response.Providers, providerUsage)
+	}
+	return response
+}
+
+// GetApiUsage returns the calls all replicas made to external APIs today against their budgets,
+// and the code paths of the calls of this replica.
+func (s *DexServerImpl) GetApiUsage(ctx context.Context, req *proto.GetApiUsageRequest) (*proto.GetApiUsageResponse, error) {
+	return &proto.GetApiUsageResponse{Usage: toProtoApiUsage(usage.Current())}, nil
+}
+
+func (s *DexServerImpl) GetTokenPricesAt(ctx context.Context, req *proto.GetTokenPricesAtRequest) (*proto.GetTokenPricesAtResponse, error) {
+	if len(req.GetQueries()) > tokenRepository.MaxPriceQueries {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d queries are allowed", tokenRepository.MaxPriceQueries)
+	}
+	queries := make([]tokenRepository.PriceQuery, 0, len(req.GetQueries()))
+	for _, query := range req.GetQueries() {
+		if query.GetTokenAddress() == "" {
+			return nil, status.Error(codes.InvalidArgument, "tokenAddress is required")
+		}
+		queries = append(queries, tokenRepository.PriceQuery{
+			TokenAddress: query.GetTokenAddress(),
+			At:           time.UnixMilli(query.GetTimestamp()),
+			MaxAge:       time.Duration(query.GetMaxAge()) * time.Millisecond,
+		})
+	}
+	points, err := tokenRepository.GetTokenPricesAt(ctx, queries)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	response := &proto.GetTokenPricesAtResponse{Prices: make([]*proto.TokenPriceAt, 0, len(points))}
+	for i, point := range points {
+		price := &proto.TokenPriceAt{
+			TokenAddress: strings.ToLower(queries[i].TokenAddress),
+			Timestamp:    req.GetQueries()[i].GetTimestamp(),
+		}
+		if point != nil {
+			price.Found = true
+			price.Price = numeric.FormatPrice(point.Price)
+			price.RecordedAt = unixMilli(point.RecordedAt)
+		}
+		response.Prices = append(response.Prices, price)
+	}
+	return response, nil
+}
+
+const (
+	tokenChangesPageSize = 500
+	// tokenChangesPoll is how often a stream that caught up reads the change log again.
+	tokenChangesPoll = time.Second
+)
+
+func (s *DexServerImpl) StreamTokens(req *proto.StreamTokensRequest, stream proto.ScannerToken_StreamTokensServer) error {
+	cursor := req.GetCursor()
+	for {
+		changes, hasMore, err := tokenRepository.GetTokenChanges(stream.Context(), cursor, tokenChangesPageSize)
+		if errors.Is(err, tokenRepository.ErrInvalidChangeCursor) {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		if err != nil {
+			log.Printf("Error streaming token changes: %+v", err)
+			return status.Error(codes.Internal, err.Error())
+		}
+		for _, change := range changes {
+			message := &proto.TokenChange{
+				Cursor:       change.Cursor,
+				TokenAddress: change.TokenAddress,
+				ChangedAt:    unixMilli(change.ChangedAt),
+				Deleted:      change.Token == nil,
+			}
+			if change.Token != nil {
+				message.Token = toProtoToken(change.Token)
+			}
+			if err := stream.Send(message); err != nil {
+				return err
+			}
+			cursor = change.Cursor
+		}
+		if hasMore {
+			continue
+		}
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-time.After(tokenChangesPoll):
+		}
+	}
+}
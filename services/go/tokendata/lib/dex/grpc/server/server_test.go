@@ -0,0 +1,110 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+	tokenRepository "tokendata/database/repositories/token"
+	"tokendata/database/store/mock"
+	"tokendata/lib/degrade"
+	protoCommon "tokendata/proto/common"
+	proto "tokendata/proto/token"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testToken = "0x1111111111111111111111111111111111111111"
+
+func TestGetToken(t *testing.T) {
+	token := mock.NewToken(testToken, "0.5")
+	token.Symbol = "TEST"
+	token.LastUsedAt = time.Now().Add(-time.Hour)
+	tokens := mock.NewTokenStore(token)
+	defer tokenRepository.SetTokenStore(tokens)()
+
+	response, err := NewDexServer().GetToken(context.Background(), &proto.GetTokenRequest{TokenAddress: testToken})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Token.Symbol != "TEST" || response.Token.Price != "0.5" {
+		t.Errorf("token = %+v", response.Token)
+	}
+	if used, _ := tokens.Token(testToken); !used.LastUsedAt.After(token.LastUsedAt) {
+		t.Error("last used time was not updated")
+	}
+}
+
+func TestGetTokenV2(t *testing.T) {
+	token := mock.NewToken(testToken, "0.5")
+	token.Volume24H = "1200"
+	defer tokenRepository.SetTokenStore(mock.NewTokenStore(token))()
+
+	response, err := NewDexServer().GetTokenV2(context.Background(), &proto.GetTokenRequest{TokenAddress: testToken})
+	if err != nil {
+		t.Fatal(err)
+	}
+	market := response.Token.Market
+	if market.GetPrice().GetValue() != "0.5" || market.GetVolume24H().GetValue() != "1200" || response.Token.ChainId != 8453 {
+		t.Errorf("token = %+v", response.Token)
+	}
+	if response.Token.Decimals != nil || market.LiquidityUsd != nil || response.Token.Description != nil {
+		t.Errorf("unknown values were set: %+v", response.Token)
+	}
+}
+
+func TestGetTokenErrors(t *testing.T) {
+	defer tokenRepository.SetTokenStore(mock.NewTokenStore())()
+	server := NewDexServer()
+
+	_, err := server.GetToken(context.Background(), &proto.GetTokenRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("empty address: err = %v, want InvalidArgument", err)
+	}
+	if _, err := server.GetToken(context.Background(), &proto.GetTokenRequest{TokenAddress: testToken}); err == nil {
+		t.Error("unknown token: expected an error")
+	}
+}
+
+func TestGetTokenPriceFreshness(t *testing.T) {
+	// Stale prices are not refreshed from the APIs while only cached prices are served.
+	degrade.SetManual(degrade.Mode{CachedPricesOnly: true})
+	defer degrade.SetManual(degrade.Mode{})
+	token := mock.NewToken(testToken, "0.5")
+	token.LastUpdatedAt = time.Now().Add(-2 * time.Hour)
+	defer tokenRepository.SetTokenStore(mock.NewTokenStore(token))()
+	server := NewDexServer()
+
+	response, err := server.GetTokenPrice(context.Background(), &proto.GetTokenPriceRequest{TokenAddress: testToken})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !response.Success || !response.Stale || response.Price != "0.5" || response.LastUpdatedAt != token.LastUpdatedAt.UnixMilli() {
+		t.Errorf("response = %+v", response)
+	}
+
+	maxAge := int64(60)
+	_, err = server.GetTokenPrice(context.Background(), &proto.GetTokenPriceRequest{TokenAddress: testToken, MaxAgeSeconds: &maxAge})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("price older than max age: err = %v, want FailedPrecondition", err)
+	}
+
+	maxAge = int64(3 * time.Hour / time.Second)
+	response, err = server.GetTokenPrice(context.Background(), &proto.GetTokenPriceRequest{TokenAddress: testToken, MaxAgeSeconds: &maxAge})
+	if err != nil || response.Stale {
+		t.Errorf("price within max age: response = %+v, err = %v", response, err)
+	}
+}
+
+func TestMaskTokens(t *testing.T) {
+	tokens := []*protoCommon.Token{{Address: testToken, Symbol: "TEST", Price: "0.5", PriceConfidence: 1, Delisted: true}}
+	if err := maskTokens(tokens, []string{"price", "delisted"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := tokens[0]; got.Address != testToken || got.Price != "0.5" || !got.Delisted || got.Symbol != "" || got.PriceConfidence != 0 {
+		t.Errorf("masked token = %+v", got)
+	}
+	if err := maskTokens(tokens, []string{"nope"}); err == nil {
+		t.Error("unknown field: expected an error")
+	}
+}
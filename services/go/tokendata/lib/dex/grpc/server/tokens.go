@@ -0,0 +1,139 @@
+package server
+
+import (
+	"encoding/base64"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	protoCommon "tokendata/proto/common"
+	proto "tokendata/proto/token"
+)
+
+const (
+	defaultTokensLimit = 100
+	maxTokensLimit     = 1000
+)
+
+var errInvalidTokensCursor = errors.New("invalid cursor")
+
+// tokenSortKey is the position of a token in a sorted token list. Tokens without a value, not
+// analysed or not priced yet, come last; ties are ordered by address.
+type tokenSortKey struct {
+	value   *float64
+	address string
+}
+
+func sortKeyOf(token *protoCommon.Token, sort proto.TokenSort) tokenSortKey {
+	key := tokenSortKey{address: token.Address}
+	switch sort {
+	case proto.TokenSort_SORT_DEPLOYER_RISK_ASC, proto.TokenSort_SORT_DEPLOYER_RISK_DESC:
+		if token.DeployerRiskScore != nil {
+			value := float64(*token.DeployerRiskScore)
+			key.value = &value
+		}
+	case proto.TokenSort_SORT_RISK_ASC, proto.TokenSort_SORT_RISK_DESC:
+		value := float64(token.RiskScore)
+		key.value = &value
+	case proto.TokenSort_SORT_TRENDING:
+		if token.TrendingRank != nil {
+			value := float64(*token.TrendingRank)
+			key.value = &value
+		}
+	case proto.TokenSort_SORT_MARKET_CAP_DESC:
+		if value, err := strconv.ParseFloat(token.MarketCap, 64); err == nil && value > 0 {
+			key.value = &value
+		}
+	}
+	return key
+}
+
+func compareSortKeys(a, b tokenSortKey, sort proto.TokenSort) int {
+	switch {
+	case a.value != nil && b.value == nil:
+		return -1
+	case a.value == nil && b.value != nil:
+		return 1
+	case a.value != nil && *a.value != *b.value:
+		if (*a.value < *b.value) == ascending(sort) {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a.address, b.address)
+}
+
+func ascending(sort proto.TokenSort) bool {
+	return sort == proto.TokenSort_SORT_DEPLOYER_RISK_ASC || sort == proto.TokenSort_SORT_RISK_ASC || sort == proto.TokenSort_SORT_TRENDING
+}
+
+// sortTokens orders tokens by deployer risk, risk, trending rank or market cap, and by address by
+// default.
+func sortTokens(tokens []*protoCommon.Token, sort proto.TokenSort) {
+	slices.SortFunc(tokens, func(a, b *protoCommon.Token) int {
+		return compareSortKeys(sortKeyOf(a, sort), sortKeyOf(b, sort), sort)
+	})
+}
+
+func encodeTokensCursor(key tokenSortKey) string {
+	value := ""
+	if key.value != nil {
+		value = strconv.FormatFloat(*key.value, 'g', -1, 64)
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(value + ":" + key.address))
+}
+
+func decodeTokensCursor(cursor string) (tokenSortKey, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return tokenSortKey{}, errInvalidTokensCursor
+	}
+	value, address, ok := strings.Cut(string(raw), ":")
+	if !ok || address == "" {
+		return tokenSortKey{}, errInvalidTokensCursor
+	}
+	key := tokenSortKey{address: address}
+	if value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return tokenSortKey{}, errInvalidTokensCursor
+		}
+		key.value = &parsed
+	}
+	return key, nil
+}
+
+type tokensPage struct {
+	tokens     []*protoCommon.Token
+	nextCursor string
+	hasMore    bool
+}
+
+// pageTokens returns up to limit sorted tokens after cursor. The cursor holds the sort key of the
+// last token of a page rather than its index, so tokens added or removed between two pages do
+// not shift the next one. When no tokens follow, the returned cursor is the one passed in.
+func pageTokens(tokens []*protoCommon.Token, sort proto.TokenSort, cursor string, limit int) (tokensPage, error) {
+	if limit <= 0 {
+		limit = defaultTokensLimit
+	}
+	limit = min(limit, maxTokensLimit)
+	start := 0
+	if cursor != "" {
+		after, err := decodeTokensCursor(cursor)
+		if err != nil {
+			return tokensPage{}, err
+		}
+		start, _ = slices.BinarySearchFunc(tokens, after, func(token *protoCommon.Token, after tokenSortKey) int {
+			if compareSortKeys(sortKeyOf(token, sort), after, sort) <= 0 {
+				return -1
+			}
+			return 1
+		})
+	}
+	end := min(start+limit, len(tokens))
+	page := tokensPage{tokens: tokens[start:end], nextCursor: cursor, hasMore: end < len(tokens)}
+	if end > start {
+		page.nextCursor = encodeTokensCursor(sortKeyOf(tokens[end-1], sort))
+	}
+	return page, nil
+}
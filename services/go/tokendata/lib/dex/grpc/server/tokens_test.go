@@ -0,0 +1,82 @@
+package server
+
+import (
+	"slices"
+	"testing"
+	protoCommon "tokendata/proto/common"
+	proto "tokendata/proto/token"
+)
+
+func TestPageTokens(t *testing.T) {
+	risk := func(score int32) *int32 { return &score }
+	tokens := []*protoCommon.Token{
+		{Address: "0xd", DeployerRiskScore: risk(10)},
+		{Address: "0xa"},
+		{Address: "0xc", DeployerRiskScore: risk(80)},
+		{Address: "0xb", DeployerRiskScore: risk(10)},
+	}
+	sortTokens(tokens, proto.TokenSort_SORT_DEPLOYER_RISK_DESC)
+
+	var pages [][]string
+	cursor := ""
+	for {
+		page, err := pageTokens(tokens, proto.TokenSort_SORT_DEPLOYER_RISK_DESC, cursor, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		addresses := []string{}
+		for _, token := range page.tokens {
+			addresses = append(addresses, token.Address)
+		}
+		pages = append(pages, addresses)
+		cursor = page.nextCursor
+		if !page.hasMore {
+			break
+		}
+	}
+	if want := [][]string{{"0xc", "0xb"}, {"0xd", "0xa"}}; !slices.EqualFunc(pages, want, slices.Equal) {
+		t.Errorf("pages = %v, want %v", pages, want)
+	}
+
+	// A token removed after its page was read does not shift the next page.
+	page, err := pageTokens(slices.Delete(slices.Clone(tokens), 1, 2), proto.TokenSort_SORT_DEPLOYER_RISK_DESC, encodeTokensCursor(sortKeyOf(tokens[1], proto.TokenSort_SORT_DEPLOYER_RISK_DESC)), 2)
+	if err != nil || len(page.tokens) != 2 || page.tokens[0].Address != "0xd" {
+		t.Errorf("page after removed cursor token = %v, %v", page.tokens, err)
+	}
+	if _, err := pageTokens(tokens, proto.TokenSort_SORT_DEFAULT, "not a cursor", 2); err == nil {
+		t.Error("invalid cursor was accepted")
+	}
+}
+
+func TestSortTokensByRiskAscending(t *testing.T) {
+	tokens := []*protoCommon.Token{
+		{Address: "0xa", RiskScore: 40},
+		{Address: "0xb", RiskScore: 5},
+		{Address: "0xc", RiskScore: 40},
+	}
+	sortTokens(tokens, proto.TokenSort_SORT_RISK_ASC)
+	addresses := []string{}
+	for _, token := range tokens {
+		addresses = append(addresses, token.Address)
+	}
+	if want := []string{"0xb", "0xa", "0xc"}; !slices.Equal(addresses, want) {
+		t.Errorf("order = %v, want %v", addresses, want)
+	}
+}
+
+func TestSortTokensByTrendingRank(t *testing.T) {
+	rank := func(r int32) *int32 { return &r }
+	tokens := []*protoCommon.Token{
+		{Address: "0xa"},
+		{Address: "0xb", TrendingRank: rank(2)},
+		{Address: "0xc", TrendingRank: rank(1)},
+	}
+	sortTokens(tokens, proto.TokenSort_SORT_TRENDING)
+	addresses := []string{}
+	for _, token := range tokens {
+		addresses = append(addresses, token.Address)
+	}
+	if want := []string{"0xc", "0xb", "0xa"}; !slices.Equal(addresses, want) {
+		t.Errorf("order = %v, want %v", addresses, want)
+	}
+}
@@ -0,0 +1,149 @@
+package server
+
+import (
+	"context"
+	"samterminal/pkg/chain"
+	"samterminal/pkg/numeric"
+	tokenRepository "tokendata/database/repositories/token"
+	db "tokendata/generated/prisma"
+	protoCommon "tokendata/proto/common"
+	proto "tokendata/proto/token"
+
+	"github.com/shopspring/decimal"
+)
+
+func (s *DexServerImpl) GetTokenV2(ctx context.Context, req *proto.GetTokenRequest) (*proto.GetTokenV2Response, error) {
+	token, err := getToken(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	v1 := toProtoToken(token)
+	localizeTokens(ctx, []*protoCommon.Token{v1}, req.Locales)
+	return &proto.GetTokenV2Response{Token: toProtoTokenV2(token, v1), Orderflow: tokenRepository.TokenOrderflow(token.Address)}, nil
+}
+
+func (s *DexServerImpl) GetTokensV2(ctx context.Context, req *proto.GetTokensRequest) (*proto.GetTokensV2Response, error) {
+	page, models, err := getTokens(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	response := &proto.GetTokensV2Response{
+		FoundAddresses:   page.FoundAddresses,
+		MissingAddresses: page.MissingAddresses,
+		Partial:          page.Partial,
+		NextCursor:       page.NextCursor,
+		HasMore:          page.HasMore,
+	}
+	for _, v1 := range page.Tokens {
+		response.Tokens = append(response.Tokens, toProtoTokenV2(models[v1.Address], v1))
+	}
+	return response, nil
+}
+
+// toProtoTokenV2 converts a stored token to the v2 message. Values v1 already derived, the
+// localized name and description, prices and risk among them, are taken from its v1 message.
+func toProtoTokenV2(token *db.TokenModel, v1 *protoCommon.Token) *protoCommon.TokenV2 {
+	v2 := &protoCommon.TokenV2{
+		Address:        v1.Address,
+		ChainId:        chain.Get().ID,
+		Name:           v1.Name,
+		Symbol:         v1.Symbol,
+		Description:    optionalString(v1.Description),
+		Locale:         optionalString(v1.Locale),
+		ImageUrl:       v1.ImageUrl,
+		CachedImageUrl: optionalString(v1.CachedImageUrl),
+		Tags:           v1.Tags,
+		Reason:         optionalString(v1.Reason),
+		Market: &protoCommon.TokenV2_Market{
+			Price:               positiveDecimal(v1.Price),
+			PriceSource:         optionalString(v1.PriceSource),
+			PriceConfidence:     v1.PriceConfidence,
+			Volume24H:           toProtoDecimal(numeric.ParseOrZero(v1.Volume)),
+			CalculatedVolume24H: toProtoDecimal(token.CalculatedVolume24H),
+			Supply:              positiveDecimal(v1.Supply),
+			CirculatingSupply:   positiveDecimal(v1.CirculatedSupply),
+			MarketCap:           positiveDecimal(v1.MarketCap),
+			Fdv:                 positiveDecimal(v1.Fdv),
+		},
+		Pool: &protoCommon.TokenV2_Pool{
+			PoolAddress: v1.PoolAddress,
+			PairAddress: v1.PairAddress,
+			Hooks:       optionalString(v1.PoolHooks),
+		},
+		Links: &protoCommon.TokenV2_Links{
+			Website:        optionalString(v1.Website),
+			Twitter:        optionalString(v1.Twitter),
+			Telegram:       optionalString(v1.Telegram),
+			HeaderImageUrl: optionalString(v1.HeaderImageUrl),
+		},
+		Contract: &protoCommon.TokenV2_Contract{
+			Verified:        v1.ContractVerified,
+			Name:            optionalString(v1.ContractName),
+			CompilerVersion: optionalString(v1.CompilerVersion),
+			SourceHash:      optionalString(v1.SourceHash),
+			Implementation:  optionalString(v1.Implementation),
+			TransferTaxBps:  v1.TransferTaxBps,
+		},
+		Deployer: &protoCommon.TokenV2_Deployer{
+			Address:     v1.DeployerAddress,
+			RiskScore:   v1.DeployerRiskScore,
+			LaunchCount: v1.DeployerLaunchCount,
+			RugCount:    v1.DeployerRugCount,
+		},
+		Flags: &protoCommon.TokenV2_Flags{
+			Archived:        v1.Archived,
+			Delisted:        v1.Delisted,
+			PoolDynamicFee:  v1.PoolDynamicFee,
+			ContractIsProxy: v1.ContractIsProxy,
+			Blacklisted:     token.Blacklisted,
+		},
+		RiskScore:            v1.RiskScore,
+		UncheckedRiskSignals: v1.UncheckedRiskSignals,
+		TrendingRank:         v1.TrendingRank,
+		FarcasterCasts1H:     v1.FarcasterCasts1H,
+		FarcasterCasters1H:   v1.FarcasterCasters1H,
+	}
+	if decimals, ok := token.Decimals(); ok {
+		decimals := int32(decimals)
+		v2.Decimals = &decimals
+	}
+	if v1.PriceUpdatedAt > 0 {
+		v2.Market.PriceUpdatedAt = &v1.PriceUpdatedAt
+	}
+	if multiple, ok := token.PriceMultiple(); ok {
+		v2.Market.PriceChangePct = toProtoDecimal(decimal.NewFromFloat(multiple).Sub(decimal.NewFromInt(1)).Mul(decimal.NewFromInt(100)))
+	}
+	if liquidity, ok := token.LiquidityUSD(); ok {
+		v2.Market.LiquidityUsd = toProtoDecimal(decimal.NewFromFloat(liquidity))
+	}
+	if v1.DelistedAt > 0 {
+		v2.DelistedAt = &v1.DelistedAt
+	}
+	if v1.DeployedAt > 0 {
+		v2.DeployedAt, v2.TokenAge = &v1.DeployedAt, &v1.TokenAge
+	}
+	return v2
+}
+
+// optionalString is nil for an empty string.
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// toProtoDecimal converts a number to the v2 message.
+func toProtoDecimal(value decimal.Decimal) *protoCommon.Decimal {
+	return &protoCommon.Decimal{Value: value.String()}
+}
+
+// positiveDecimal parses a v1 number, nil when it is not a positive number; v1 sends "0" for
+// unknown prices and supplies.
+func positiveDecimal(value string) *protoCommon.Decimal {
+	parsed, err := numeric.Parse(value)
+	if err != nil || !parsed.IsPositive() {
+		return nil
+	}
+	return toProtoDecimal(parsed)
+}
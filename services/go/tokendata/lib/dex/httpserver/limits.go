@@ -0,0 +1,180 @@
+package httpserver
+
+import (
+	"crypto/subtle"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+	"tokendata/env"
+)
+
+// serverConfig holds the timeouts and limits the HTTP server runs with.
+type serverConfig struct {
+	readTimeout    time.Duration
+	writeTimeout   time.Duration
+	idleTimeout    time.Duration
+	maxHeaderBytes int
+	maxBodyBytes   int64
+	// authTokens are the accepted bearer tokens; none leaves the endpoints public.
+	authTokens []string
+	// rateLimit is in requests per second per client IP; 0, the default, turns the limit off:
+	// behind a proxy without trustProxy every client would share one limit.
+	rateLimit  float64
+	rateBurst  int
+	trustProxy bool
+}
+
+func loadServerConfig() serverConfig {
+	config := serverConfig{
+		readTimeout:    env.HTTP_READ_TIMEOUT.GetEnvAsDurationOrDefault(15 * time.Second),
+		writeTimeout:   env.HTTP_WRITE_TIMEOUT.GetEnvAsDurationOrDefault(30 * time.Second),
+		idleTimeout:    env.HTTP_IDLE_TIMEOUT.GetEnvAsDurationOrDefault(2 * time.Minute),
+		maxHeaderBytes: int(env.HTTP_MAX_HEADER_BYTES.GetEnvAsNumberOrDefault(16 << 10)),
+		maxBodyBytes:   env.HTTP_MAX_BODY_BYTES.GetEnvAsNumberOrDefault(1 << 20),
+		rateLimit:      env.HTTP_RATE_LIMIT.GetEnvAsFloatOrDefault(0),
+		rateBurst:      int(env.HTTP_RATE_BURST.GetEnvAsNumberOrDefault(40)),
+		trustProxy:     env.HTTP_TRUST_PROXY.GetEnvAsBoolOrDefault(false),
+	}
+	for _, token := range strings.Split(env.HTTP_AUTH_TOKENS.GetEnv(), ",") {
+		if token = strings.TrimSpace(token); token != "" {
+			config.authTokens = append(config.authTokens, token)
+		}
+	}
+	return config
+}
+
+// newServer returns an HTTP server serving handler within the limits of config. The CORS headers
+// are set before the limits run, so that browsers can read their 401 and 429 answers too.
+func newServer(addr string, handler http.Handler, config serverConfig) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           withCORS(withLimits(handler, config)),
+		ReadHeaderTimeout: config.readTimeout,
+		ReadTimeout:       config.readTimeout,
+		WriteTimeout:      config.writeTimeout,
+		IdleTimeout:       config.idleTimeout,
+		MaxHeaderBytes:    config.maxHeaderBytes,
+	}
+}
+
+// withLimits rejects oversized bodies, clients over the rate limit and, when tokens are set,
+// requests without one. CORS preflights carry no credentials, so they skip the auth check.
+func withLimits(h http.Handler, config serverConfig) http.Handler {
+	limiter := newIPRateLimiter(config.rateLimit, config.rateBurst)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > config.maxBodyBytes {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, config.maxBodyBytes)
+		if limiter != nil && !limiter.allow(clientIP(r, config.trustProxy), time.Now()) {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		if r.Method != http.MethodOptions && !authorized(r, config.authTokens) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// authorized checks the bearer token of a request. Browsers cannot set headers on WebSockets,
+// EventSources or images, so the token may be given as access_token instead.
+func authorized(r *http.Request, tokens []string) bool {
+	if len(tokens) == 0 {
+		return true
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		token = r.URL.Query().Get("access_token")
+	}
+	if token == "" {
+		return false
+	}
+	for _, accepted := range tokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(accepted)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// clientIP is the address a request came from, or the first X-Forwarded-For address when the
+// server runs behind a trusted proxy.
+func clientIP(r *http.Request, trustProxy bool) string {
+	if trustProxy {
+		if forwarded, _, _ := strings.Cut(r.Header.Get("X-Forwarded-For"), ","); strings.TrimSpace(forwarded) != "" {
+			return strings.TrimSpace(forwarded)
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// ipRateLimiter is a token bucket per client IP, refilled at rate tokens per second up to burst.
+type ipRateLimiter struct {
+	mu        sync.Mutex
+	rate      float64
+	burst     float64
+	buckets   map[string]*rateBucket
+	lastSweep time.Time
+}
+
+type rateBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// rateSweepInterval is how often the buckets of idle clients are dropped.
+const rateSweepInterval = time.Minute
+
+// newIPRateLimiter returns nil when rate is not positive.
+func newIPRateLimiter(rate float64, burst int) *ipRateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	return &ipRateLimiter{
+		rate:    rate,
+		burst:   max(float64(burst), 1),
+		buckets: map[string]*rateBucket{},
+	}
+}
+
+func (l *ipRateLimiter) allow(ip string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if now.Sub(l.lastSweep) >= rateSweepInterval {
+		l.sweep(now)
+	}
+	bucket, ok := l.buckets[ip]
+	if !ok {
+		bucket = &rateBucket{tokens: l.burst, last: now}
+		l.buckets[ip] = bucket
+	}
+	bucket.tokens = min(l.burst, bucket.tokens+now.Sub(bucket.last).Seconds()*l.rate)
+	bucket.last = now
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+// sweep drops the buckets that refilled completely, which a new bucket would start as anyway.
+func (l *ipRateLimiter) sweep(now time.Time) {
+	refill := time.Duration(l.burst / l.rate * float64(time.Second))
+	for ip, bucket := range l.buckets {
+		if now.Sub(bucket.last) >= refill {
+			delete(l.buckets, ip)
+		}
+	}
+	l.lastSweep = now
+}
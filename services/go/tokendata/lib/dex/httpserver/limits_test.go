@@ -0,0 +1,105 @@
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIPRateLimiter(t *testing.T) {
+	limiter := newIPRateLimiter(1, 2)
+	now := time.Now()
+	if !limiter.allow("1.1.1.1", now) || !limiter.allow("1.1.1.1", now) {
+		t.Fatal("requests within the burst were limited")
+	}
+	if limiter.allow("1.1.1.1", now) {
+		t.Error("request past the burst was allowed")
+	}
+	if !limiter.allow("2.2.2.2", now) {
+		t.Error("another client was limited")
+	}
+	if !limiter.allow("1.1.1.1", now.Add(time.Second)) {
+		t.Error("request after a refill was limited")
+	}
+
+	limiter.sweep(now.Add(time.Minute))
+	if len(limiter.buckets) != 0 {
+		t.Errorf("%d idle buckets left after a sweep", len(limiter.buckets))
+	}
+	if newIPRateLimiter(0, 10) != nil {
+		t.Error("a rate of 0 did not turn the limiter off")
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/tokens", nil)
+	r.RemoteAddr = "10.0.0.1:5000"
+	r.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+	if ip := clientIP(r, false); ip != "10.0.0.1" {
+		t.Errorf("clientIP = %s, want the remote address", ip)
+	}
+	if ip := clientIP(r, true); ip != "203.0.113.7" {
+		t.Errorf("clientIP behind a proxy = %s, want the forwarded address", ip)
+	}
+}
+
+func TestWithLimits(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	handler := withLimits(ok, serverConfig{maxBodyBytes: 16, authTokens: []string{"secret"}, rateLimit: 1, rateBurst: 3})
+	serve := func(method, target, authorization, body string) int {
+		r := httptest.NewRequest(method, target, strings.NewReader(body))
+		if authorization != "" {
+			r.Header.Set("Authorization", authorization)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	tests := []struct {
+		name                       string
+		method, target, auth, body string
+		want                       int
+	}{
+		{"bearer token", http.MethodGet, "/tokens", "Bearer secret", "", http.StatusOK},
+		{"query token", http.MethodGet, "/events?access_token=secret", "", "", http.StatusOK},
+		{"wrong token", http.MethodGet, "/tokens", "Bearer nope", "", http.StatusUnauthorized},
+		{"oversized body", http.MethodPost, "/tokens", "Bearer secret", strings.Repeat("x", 17), http.StatusRequestEntityTooLarge},
+		{"rate limited", http.MethodGet, "/tokens", "Bearer secret", "", http.StatusTooManyRequests},
+	}
+	for _, test := range tests {
+		if got := serve(test.method, test.target, test.auth, test.body); got != test.want {
+			t.Errorf("%s: status = %d, want %d", test.name, got, test.want)
+		}
+	}
+}
+
+func TestServerCORSOnRejections(t *testing.T) {
+	t.Setenv("ALLOWED_ORIGINS", "https://app.example")
+	if config := loadServerConfig(); config.rateLimit != 0 {
+		t.Errorf("default rate limit = %v, want off", config.rateLimit)
+	}
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	server := newServer(":0", ok, serverConfig{maxBodyBytes: 16, authTokens: []string{"secret"}, rateLimit: 1, rateBurst: 1})
+	serve := func(authorization string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/tokens", nil)
+		r.Header.Set("Origin", "https://app.example")
+		if authorization != "" {
+			r.Header.Set("Authorization", authorization)
+		}
+		w := httptest.NewRecorder()
+		server.Handler.ServeHTTP(w, r)
+		return w
+	}
+	for _, test := range []struct {
+		auth string
+		want int
+	}{{"", http.StatusUnauthorized}, {"Bearer secret", http.StatusTooManyRequests}} {
+		w := serve(test.auth)
+		if w.Code != test.want || w.Header().Get("Access-Control-Allow-Origin") != "https://app.example" {
+			t.Errorf("status = %d with origin %q, want %d with CORS headers", w.Code, w.Header().Get("Access-Control-Allow-Origin"), test.want)
+		}
+	}
+}
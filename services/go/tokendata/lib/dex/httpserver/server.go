@@ -1,32 +1,39 @@
 package httpserver
 
 import (
-	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
 	"tokendata/env"
+	"tokendata/lib/images"
 	proto "tokendata/proto/token"
 
 	grpc_lib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
-func withCORS(h http.HandlerFunc) http.HandlerFunc {
-	allowedOrigins := strings.Split(os.Getenv("ALLOWED_ORIGINS"), ",")
-	return func(w http.ResponseWriter, r *http.Request) {
-		origin := r.Header.Get("Origin")
-		allowed := false
-		for _, o := range allowedOrigins {
-			if strings.TrimSpace(o) == origin && origin != "" {
-				allowed = true
-				break
-			}
+func originAllowed(origin string) bool {
+	for _, o := range strings.Split(os.Getenv("ALLOWED_ORIGINS"), ",") {
+		if strings.TrimSpace(o) == origin && origin != "" {
+			return true
 		}
-		if allowed {
+	}
+	return false
+}
+
+// withCORS sets the CORS headers of every response and answers preflights.
+func withCORS(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if originAllowed(origin) {
 			w.Header().Set("Access-Control-Allow-Origin", origin)
 			w.Header().Set("Vary", "Origin")
 		}
@@ -37,8 +44,64 @@ func withCORS(h http.HandlerFunc) http.HandlerFunc {
 			w.WriteHeader(http.StatusNoContent)
 			return
 		}
-		h(w, r)
+		h.ServeHTTP(w, r)
+	})
+}
+
+// requestLocales returns the locales a client prefers, most preferred first. A lang query
+// parameter wins over the Accept-Language header; wildcards and q=0 entries are ignored.
+func requestLocales(r *http.Request) []string {
+	if lang := strings.TrimSpace(r.URL.Query().Get("lang")); lang != "" {
+		return []string{lang}
+	}
+	type weighted struct {
+		locale string
+		q      float64
 	}
+	entries := []weighted{}
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		locale, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		locale = strings.TrimSpace(locale)
+		if locale == "" || locale == "*" {
+			continue
+		}
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > 0 {
+			entries = append(entries, weighted{locale, q})
+		}
+	}
+	slices.SortStableFunc(entries, func(a, b weighted) int {
+		switch {
+		case a.q > b.q:
+			return -1
+		case a.q < b.q:
+			return 1
+		}
+		return 0
+	})
+	locales := make([]string, len(entries))
+	for i, entry := range entries {
+		locales[i] = entry.locale
+	}
+	return locales
+}
+
+// requestTags returns the tags a token list is filtered by, given as tags=meme,clanker.
+func requestTags(r *http.Request) []string {
+	tags := []string{}
+	for _, tag := range strings.Split(r.URL.Query().Get("tags"), ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
 }
 
 func Start(grpcPort int64, httpPort int64) {
@@ -50,33 +113,105 @@ func Start(grpcPort int64, httpPort int64) {
 	}
 	client := proto.NewScannerTokenClient(conn)
 
-	http.HandleFunc("/tokens", withCORS(func(w http.ResponseWriter, r *http.Request) {
+	http.HandleFunc("/tokens", serveTokens(client))
+
+	http.HandleFunc("/images/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		tokenAddress := strings.ToLower(strings.TrimPrefix(r.URL.Path, "/images/"))
+		size := images.DefaultSize
+		if value := r.URL.Query().Get("size"); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			size = parsed
+		}
+		res, err := client.GetToken(r.Context(), &proto.GetTokenRequest{TokenAddress: tokenAddress})
+		if err != nil || res.GetToken().GetImageUrl() == "" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		image, err := images.Get(tokenAddress, res.GetToken().GetImageUrl(), size)
+		if errors.Is(err, images.ErrInvalidSize) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			log.Printf("Error getting image of %s: %+v", tokenAddress, err)
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", image.ContentType)
+		w.Header().Set("Cache-Control", "public, max-age=86400")
+		w.Write(image.Data)
+	})
+
+	http.HandleFunc("/discovery", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			w.WriteHeader(http.StatusMethodNotAllowed)
 			return
 		}
-		ctx := context.Background()
-		res, err := client.GetTokens(ctx, &proto.GetTokensRequest{})
+		query := r.URL.Query()
+		req := &proto.GetDiscoveryFeedRequest{Cursor: query.Get("cursor"), Sources: query["source"]}
+		if value := query.Get("limit"); value != "" {
+			limit, err := strconv.Atoi(value)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			limit32 := int32(limit)
+			req.Limit = &limit32
+		}
+		res, err := client.GetDiscoveryFeed(r.Context(), req)
+		if status.Code(err) == codes.InvalidArgument {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			log.Printf("Error getting discovery feed: %+v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(res)
+	})
+	http.HandleFunc("/gas", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		res, err := client.GetGasPrice(r.Context(), &proto.GetGasPriceRequest{})
+		if status.Code(err) == codes.Unavailable {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
 		if err != nil {
-			log.Printf("Error getting tokens: %+v", err)
+			log.Printf("Error getting gas price: %+v", err)
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
+		w.Header().Set("Cache-Control", "public, max-age=2")
 		json.NewEncoder(w).Encode(res)
-	}))
+	})
+	http.HandleFunc("/ws", serveWebSocket)
+	http.HandleFunc("/events", serveEvents)
 
 	srvAddr := fmt.Sprintf(":%d", httpPort)
+	server := newServer(srvAddr, http.DefaultServeMux, loadServerConfig())
 	cert := env.HTTPS_CERT_FILE.GetEnv()
 	key := env.HTTPS_KEY_FILE.GetEnv()
 	if cert != "" && key != "" {
-		log.Printf("HTTPS endpoint started: %s (GET /tokens)", srvAddr)
-		if err := http.ListenAndServeTLS(srvAddr, cert, key, nil); err != nil {
+		log.Printf("HTTPS endpoint started: %s (GET /tokens, GET /images/{address}, GET /discovery, GET /gas, GET /ws, GET /events)", srvAddr)
+		if err := server.ListenAndServeTLS(cert, key); err != nil {
 			log.Printf("HTTPS server error: %v", err)
 		}
 		return
 	}
-	log.Printf("HTTP endpoint started: %s (GET /tokens)", srvAddr)
-	if err := http.ListenAndServe(srvAddr, nil); err != nil {
+	log.Printf("HTTP endpoint started: %s (GET /tokens, GET /images/{address}, GET /discovery, GET /gas, GET /ws, GET /events)", srvAddr)
+	if err := server.ListenAndServe(); err != nil {
 		log.Printf("HTTP server error: %v", err)
 	}
 }
@@ -0,0 +1,194 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+	"tokendata/lib/hub"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// Clients must answer pings within pongWait or the connection is dropped.
+	pingInterval = 30 * time.Second
+	pongWait     = 60 * time.Second
+	writeWait    = 10 * time.Second
+	// Control messages from clients are small subscription requests.
+	maxClientMessageBytes = 4096
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	// Non-browser clients send no Origin header.
+	CheckOrigin: func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		return origin == "" || originAllowed(origin)
+	},
+}
+
+// subscriptionRequest is sent by WS clients to change their topics, e.g.
+// {"action":"subscribe","topics":["token:0x...","discovery"]}.
+type subscriptionRequest struct {
+	Action string   `json:"action"`
+	Topics []string `json:"topics"`
+}
+
+type subscriptionResponse struct {
+	Type   string   `json:"type"`
+	Topics []string `json:"topics,omitempty"`
+	Error  string   `json:"error,omitempty"`
+}
+
+// queryTopics reads the initial topics of a connection from ?topics=a,b.
+func queryTopics(r *http.Request) []string {
+	topics := []string{}
+	for _, topic := range strings.Split(r.URL.Query().Get("topics"), ",") {
+		if topic = strings.TrimSpace(topic); topic != "" {
+			topics = append(topics, topic)
+		}
+	}
+	return topics
+}
+
+// serveWebSocket streams hub messages over a WebSocket. Clients can change their topics at any
+// time with subscription requests; each request is answered with the current topics or an error.
+func serveWebSocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("Error upgrading websocket: %+v", err)
+		return
+	}
+	client := hub.Register()
+	defer hub.Unregister(client)
+	defer conn.Close()
+
+	replies := make(chan subscriptionResponse, 8)
+	reply := func(response subscriptionResponse) {
+		select {
+		case replies <- response:
+		case <-client.Done():
+		}
+	}
+	if err := client.Subscribe(queryTopics(r)...); err != nil {
+		reply(subscriptionResponse{Type: "error", Error: err.Error()})
+	}
+
+	go func() {
+		defer hub.Unregister(client)
+		conn.SetReadLimit(maxClientMessageBytes)
+		conn.SetReadDeadline(time.Now().Add(pongWait))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(pongWait))
+		})
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var request subscriptionRequest
+			if err := json.Unmarshal(data, &request); err != nil {
+				reply(subscriptionResponse{Type: "error", Error: "invalid message"})
+				continue
+			}
+			switch request.Action {
+			case "subscribe":
+				if err := client.Subscribe(request.Topics...); err != nil {
+					reply(subscriptionResponse{Type: "error", Error: err.Error()})
+					continue
+				}
+			case "unsubscribe":
+				client.Unsubscribe(request.Topics...)
+			default:
+				reply(subscriptionResponse{Type: "error", Error: "unknown action"})
+				continue
+			}
+			reply(subscriptionResponse{Type: "subscriptions", Topics: client.Topics()})
+		}
+	}()
+
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-client.Done():
+			conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "closed"), time.Now().Add(writeWait))
+			return
+		case message := <-client.Messages():
+			conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				return
+			}
+		case response := <-replies:
+			conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := conn.WriteJSON(response); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// serveEvents streams hub messages as server-sent events. Topics are fixed for the lifetime of
+// the stream and given as ?topics=a,b.
+func serveEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	topics := queryTopics(r)
+	if len(topics) == 0 {
+		http.Error(w, "topics is required", http.StatusBadRequest)
+		return
+	}
+	client := hub.Register()
+	defer hub.Unregister(client)
+	if err := client.Subscribe(topics...); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Streams outlive the write timeout of the server; pings detect dead clients instead.
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+		log.Printf("Error clearing the write deadline of an event stream: %+v", err)
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-client.Done():
+			return
+		case message := <-client.Messages():
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", message); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-ticker.C:
+			// A comment line keeps proxies from closing idle streams.
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package httpserver
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	proto "tokendata/proto/token"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// tokenSorts are the values of the sort query parameter of /tokens; a leading "-" sorts
+// descending.
+var tokenSorts = map[string]proto.TokenSort{
+	"":              proto.TokenSort_SORT_DEFAULT,
+	"address":       proto.TokenSort_SORT_DEFAULT,
+	"deployerRisk":  proto.TokenSort_SORT_DEPLOYER_RISK_ASC,
+	"-deployerRisk": proto.TokenSort_SORT_DEPLOYER_RISK_DESC,
+	"-marketCap":    proto.TokenSort_SORT_MARKET_CAP_DESC,
+	"risk":          proto.TokenSort_SORT_RISK_ASC,
+	"-risk":         proto.TokenSort_SORT_RISK_DESC,
+	"trending":      proto.TokenSort_SORT_TRENDING,
+}
+
+var errInvalidTokensQuery = errors.New("invalid tokens query")
+
+// tokensRequest reads the token list query: limit, cursor, sort, maxRisk, maxAge, tags and the
+// locales. maxAge is a duration such as 1h. Without limit and cursor every token is listed, as
+// before pagination.
+func tokensRequest(r *http.Request) (*proto.GetTokensRequest, error) {
+	query := r.URL.Query()
+	sort, ok := tokenSorts[query.Get("sort")]
+	if !ok {
+		return nil, errInvalidTokensQuery
+	}
+	req := &proto.GetTokensRequest{
+		Locales: requestLocales(r),
+		Tags:    requestTags(r),
+		Sort:    sort,
+		Cursor:  query.Get("cursor"),
+	}
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			return nil, errInvalidTokensQuery
+		}
+		limit32 := int32(min(limit, math.MaxInt32))
+		req.Limit = &limit32
+	}
+	if value := query.Get("maxRisk"); value != "" {
+		maxRisk, err := strconv.Atoi(value)
+		if err != nil || maxRisk < 0 || maxRisk > 100 {
+			return nil, errInvalidTokensQuery
+		}
+		maxRisk32 := int32(maxRisk)
+		req.MaxRiskScore = &maxRisk32
+	}
+	if value := query.Get("maxAge"); value != "" {
+		maxAge, err := time.ParseDuration(value)
+		if err != nil || maxAge <= 0 {
+			return nil, errInvalidTokensQuery
+		}
+		maxAgeSeconds := int64(maxAge / time.Second)
+		req.MaxAgeSeconds = &maxAgeSeconds
+	}
+	return req, nil
+}
+
+// requestFields returns the token fields a client asked for, given as fields=address,price.
+// Empty means every field.
+func requestFields(r *http.Request) []string {
+	fields := []string{}
+	for _, field := range strings.Split(r.URL.Query().Get("fields"), ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// encodeTokens encodes a token list, keeping only the given fields of each token.
+func encodeTokens(res *proto.GetTokensResponse, fields []string) ([]byte, error) {
+	body, err := json.Marshal(res)
+	if err != nil || len(fields) == 0 {
+		return body, err
+	}
+	var response map[string]json.RawMessage
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, err
+	}
+	var tokens []map[string]json.RawMessage
+	if raw, ok := response["tokens"]; ok {
+		if err := json.Unmarshal(raw, &tokens); err != nil {
+			return nil, err
+		}
+	}
+	for i, token := range tokens {
+		projected := map[string]json.RawMessage{}
+		for _, field := range fields {
+			if value, ok := token[field]; ok {
+				projected[field] = value
+			}
+		}
+		tokens[i] = projected
+	}
+	if tokens != nil {
+		if response["tokens"], err = json.Marshal(tokens); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(response)
+}
+
+// etagOf returns a strong ETag of a response body.
+func etagOf(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatches reports whether an If-None-Match header names etag. Weak validators match too,
+// as the comparison for GET requests is weak.
+func etagMatches(ifNoneMatch string, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+func acceptsGzip(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if strings.TrimSpace(coding) == "gzip" && strings.ReplaceAll(params, " ", "") != "q=0" {
+			return true
+		}
+	}
+	return false
+}
+
+// writeCachedJSON writes a JSON body with an ETag, answering 304 when the client already has it,
+// and gzips it for clients that accept it.
+func writeCachedJSON(w http.ResponseWriter, r *http.Request, body []byte) {
+	etag := etagOf(body)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Add("Vary", "Accept-Encoding")
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if !acceptsGzip(r) {
+		w.Write(body)
+		return
+	}
+	w.Header().Set("Content-Encoding", "gzip")
+	gz := gzip.NewWriter(w)
+	defer gz.Close()
+	gz.Write(body)
+}
+
+// serveTokens lists tokens a page at a time. Polling clients send the ETag of their last
+// response and get a body only when the page changed.
+func serveTokens(client proto.ScannerTokenClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := tokensRequest(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		res, err := client.GetTokens(r.Context(), req)
+		if status.Code(err) == codes.InvalidArgument {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			log.Printf("Error getting tokens: %+v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		body, err := encodeTokens(res, requestFields(r))
+		if err != nil {
+			log.Printf("Error encoding tokens: %+v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Add("Vary", "Accept-Language")
+		writeCachedJSON(w, r, body)
+	}
+}
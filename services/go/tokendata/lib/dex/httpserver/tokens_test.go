@@ -0,0 +1,70 @@
+package httpserver
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	protoCommon "tokendata/proto/common"
+	proto "tokendata/proto/token"
+)
+
+func TestEncodeTokensFields(t *testing.T) {
+	res := &proto.GetTokensResponse{
+		Tokens:     []*protoCommon.Token{{Address: "0xa", Symbol: "A", Price: "1"}},
+		NextCursor: "next",
+		HasMore:    true,
+	}
+	body, err := encodeTokens(res, []string{"address", "price", "unknown"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"hasMore":true,"nextCursor":"next","tokens":[{"address":"0xa","price":"1"}]}`; string(body) != want {
+		t.Errorf("body = %s, want %s", body, want)
+	}
+}
+
+func TestWriteCachedJSON(t *testing.T) {
+	body := []byte(`{"tokens":[]}`)
+	etag := etagOf(body)
+
+	r := httptest.NewRequest(http.MethodGet, "/tokens", nil)
+	r.Header.Set("If-None-Match", "W/"+etag)
+	w := httptest.NewRecorder()
+	writeCachedJSON(w, r, body)
+	if w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Errorf("matching ETag: status = %d, body = %q", w.Code, w.Body)
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/tokens", nil)
+	r.Header.Set("If-None-Match", `"stale"`)
+	r.Header.Set("Accept-Encoding", "br, gzip")
+	w = httptest.NewRecorder()
+	writeCachedJSON(w, r, body)
+	if w.Code != http.StatusOK || w.Header().Get("ETag") != etag || w.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("stale ETag: status = %d, headers = %v", w.Code, w.Header())
+	}
+	gz, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := io.ReadAll(gz); string(got) != string(body) {
+		t.Errorf("body = %s, want %s", got, body)
+	}
+}
+
+func TestTokensRequestMaxAge(t *testing.T) {
+	req, err := tokensRequest(httptest.NewRequest(http.MethodGet, "/tokens?maxAge=1h", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.GetMaxAgeSeconds() != 3600 {
+		t.Errorf("maxAgeSeconds = %d, want 3600", req.GetMaxAgeSeconds())
+	}
+	for _, value := range []string{"1", "-1h", "soon"} {
+		if _, err := tokensRequest(httptest.NewRequest(http.MethodGet, "/tokens?maxAge="+value, nil)); err == nil {
+			t.Errorf("maxAge=%s: expected an error", value)
+		}
+	}
+}
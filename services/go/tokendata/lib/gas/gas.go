@@ -0,0 +1,109 @@
+package gas
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+	websocket "tokendata/lib/ws"
+)
+
+const (
+	// Base produces a block every 2 seconds.
+	pollInterval = 4 * time.Second
+	// historyBlocks is how many recent blocks priority fee percentiles are averaged over.
+	historyBlocks = 20
+	// A snapshot older than this is not served, since fees on Base move quickly.
+	maxSnapshotAge = time.Minute
+)
+
+// rewardPercentiles are the priority fee percentiles of the slow, standard and fast levels.
+var rewardPercentiles = []float64{10, 50, 90}
+
+var ErrNoGasPrice = errors.New("gas price not available yet")
+
+// Level is a suggested priority fee and the max fee that goes with it, in wei.
+type Level struct {
+	PriorityFee *big.Int
+	MaxFee      *big.Int
+}
+
+type Snapshot struct {
+	BlockNumber uint64
+	BaseFee     *big.Int
+	NextBaseFee *big.Int
+	Slow        Level
+	Standard    Level
+	Fast        Level
+	UpdatedAt   time.Time
+}
+
+var (
+	mu      sync.RWMutex
+	current *Snapshot
+)
+
+// Current returns the latest fee snapshot.
+func Current() (Snapshot, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if current == nil || time.Since(current.UpdatedAt) > maxSnapshotAge {
+		return Snapshot{}, ErrNoGasPrice
+	}
+	return *current, nil
+}
+
+// Start refreshes the fee snapshot from the fee history of recent blocks until the process exits.
+func Start() {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		if err := refresh(); err != nil {
+			log.Printf("Error refreshing gas price: %+v", err)
+		}
+		<-ticker.C
+	}
+}
+
+func refresh() error {
+	ctx, cancel := context.WithTimeout(context.Background(), pollInterval)
+	defer cancel()
+	history, err := websocket.GetEthClient().FeeHistory(ctx, historyBlocks, nil, rewardPercentiles)
+	if err != nil {
+		return err
+	}
+	if len(history.BaseFee) < 2 || len(history.Reward) == 0 {
+		return errors.New("empty fee history")
+	}
+
+	// BaseFee has one entry more than the number of blocks: the base fee of the next block.
+	last := len(history.BaseFee) - 2
+	nextBaseFee := history.BaseFee[last+1]
+	levels := make([]Level, len(rewardPercentiles))
+	for i := range rewardPercentiles {
+		sum := new(big.Int)
+		for _, rewards := range history.Reward {
+			sum.Add(sum, rewards[i])
+		}
+		priorityFee := sum.Div(sum, big.NewInt(int64(len(history.Reward))))
+		// Twice the next base fee keeps the transaction valid through several full blocks.
+		maxFee := new(big.Int).Mul(nextBaseFee, big.NewInt(2))
+		levels[i] = Level{PriorityFee: priorityFee, MaxFee: maxFee.Add(maxFee, priorityFee)}
+	}
+
+	snapshot := &Snapshot{
+		BlockNumber: history.OldestBlock.Uint64() + uint64(last),
+		BaseFee:     history.BaseFee[last],
+		NextBaseFee: nextBaseFee,
+		Slow:        levels[0],
+		Standard:    levels[1],
+		Fast:        levels[2],
+		UpdatedAt:   time.Now(),
+	}
+	mu.Lock()
+	current = snapshot
+	mu.Unlock()
+	return nil
+}
@@ -0,0 +1,38 @@
+package wallet_client
+
+import (
+	"context"
+	"log"
+
+	"samterminal/pkg/telemetry"
+	"tokendata/env"
+	proto "tokendata/proto/wallet"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var grpcClient proto.ScannerWalletClient
+var grpcConn *grpc.ClientConn
+
+func init() {
+	env.LoadEnv("./.env")
+	conn, err := grpc.NewClient(env.WALLET_GRPC_URL.GetEnv(), grpc.WithTransportCredentials(insecure.NewCredentials()), telemetry.GRPCDialOption())
+	if err != nil {
+		log.Println("error creating grpc client", err)
+		return
+	}
+	grpcConn = conn
+	grpcClient = proto.NewScannerWalletClient(conn)
+}
+
+func Close() {
+	if grpcConn != nil {
+		grpcConn.Close()
+	}
+}
+
+// AddWallet asks walletdata to watch a wallet and adds tags to it.
+func AddWallet(ctx context.Context, walletAddress string, tags ...string) (*proto.AddWalletResponse, error) {
+	return grpcClient.AddWallet(ctx, &proto.AddWalletRequest{WalletAddress: walletAddress, Tags: tags})
+}
@@ -0,0 +1,197 @@
+package hub
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	TopicTokens    = "tokens"
+	TopicDiscovery = "discovery"
+
+	tokenTopicPrefix  = "token:"
+	walletTopicPrefix = "wallet:"
+
+	// Messages buffered per connection; a client that falls this far behind is evicted.
+	clientBufferSize = 256
+	// maxTopicsPerClient bounds the subscriptions of a single connection.
+	maxTopicsPerClient = 200
+)
+
+var (
+	ErrInvalidTopic  = errors.New("invalid topic")
+	ErrTooManyTopics = errors.New("too many topics")
+)
+
+// Message is what clients receive for every event published on a topic they subscribed to.
+type Message struct {
+	Topic string `json:"topic"`
+	Type  string `json:"type"`
+	Data  any    `json:"data"`
+}
+
+// Client is one WS or SSE connection. Transports read Messages until Done is closed.
+type Client struct {
+	send      chan []byte
+	done      chan struct{}
+	closeOnce sync.Once
+
+	mu     sync.Mutex
+	topics map[string]bool
+}
+
+var (
+	mu      sync.RWMutex
+	clients = map[*Client]bool{}
+)
+
+// TokenTopic is the topic of a single token's updates.
+func TokenTopic(tokenAddress string) string {
+	return tokenTopicPrefix + strings.ToLower(tokenAddress)
+}
+
+// WalletTopic is the topic of a single wallet's activity.
+func WalletTopic(walletAddress string) string {
+	return walletTopicPrefix + strings.ToLower(walletAddress)
+}
+
+// normalizeTopic lowercases a topic and checks that it is one clients can subscribe to.
+func normalizeTopic(topic string) (string, bool) {
+	topic = strings.ToLower(strings.TrimSpace(topic))
+	switch {
+	case topic == TopicTokens || topic == TopicDiscovery:
+		return topic, true
+	case strings.HasPrefix(topic, tokenTopicPrefix):
+		return topic, common.IsHexAddress(strings.TrimPrefix(topic, tokenTopicPrefix))
+	case strings.HasPrefix(topic, walletTopicPrefix):
+		return topic, common.IsHexAddress(strings.TrimPrefix(topic, walletTopicPrefix))
+	}
+	return topic, false
+}
+
+// Register adds a connection to the hub. Callers must Unregister it when the connection ends.
+func Register() *Client {
+	client := &Client{
+		send:   make(chan []byte, clientBufferSize),
+		done:   make(chan struct{}),
+		topics: map[string]bool{},
+	}
+	mu.Lock()
+	clients[client] = true
+	mu.Unlock()
+	return client
+}
+
+// Unregister removes a connection from the hub and closes it.
+func Unregister(client *Client) {
+	mu.Lock()
+	delete(clients, client)
+	mu.Unlock()
+	client.closeOnce.Do(func() { close(client.done) })
+}
+
+// Subscribe adds topics to a connection. Nothing is subscribed when any topic is invalid.
+func (c *Client) Subscribe(topics ...string) error {
+	normalized := make([]string, 0, len(topics))
+	for _, topic := range topics {
+		topic, ok := normalizeTopic(topic)
+		if !ok {
+			return ErrInvalidTopic
+		}
+		normalized = append(normalized, topic)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	added := 0
+	for _, topic := range normalized {
+		if !c.topics[topic] {
+			added++
+		}
+	}
+	if len(c.topics)+added > maxTopicsPerClient {
+		return ErrTooManyTopics
+	}
+	for _, topic := range normalized {
+		c.topics[topic] = true
+	}
+	return nil
+}
+
+// Unsubscribe removes topics from a connection.
+func (c *Client) Unsubscribe(topics ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, topic := range topics {
+		topic, _ := normalizeTopic(topic)
+		delete(c.topics, topic)
+	}
+}
+
+// Topics returns the topics a connection is subscribed to.
+func (c *Client) Topics() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	topics := make([]string, 0, len(c.topics))
+	for topic := range c.topics {
+		topics = append(topics, topic)
+	}
+	return topics
+}
+
+func (c *Client) subscribed(topic string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.topics[topic]
+}
+
+// Messages returns encoded messages for the connection to write.
+func (c *Client) Messages() <-chan []byte {
+	return c.send
+}
+
+// Done is closed when the connection was unregistered or evicted.
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// Publish sends an event to every connection subscribed to topic. Publishing never blocks: a
+// connection whose buffer is full is evicted rather than slowing down the publisher.
+func Publish(topic string, messageType string, data any) {
+	mu.RLock()
+	var receivers []*Client
+	for client := range clients {
+		if client.subscribed(topic) {
+			receivers = append(receivers, client)
+		}
+	}
+	mu.RUnlock()
+	if len(receivers) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(Message{Topic: topic, Type: messageType, Data: data})
+	if err != nil {
+		log.Printf("Error encoding hub message for %s: %+v", topic, err)
+		return
+	}
+	for _, client := range receivers {
+		select {
+		case client.send <- payload:
+		case <-client.done:
+		default:
+			log.Printf("Evicting slow client from hub topic %s", topic)
+			Unregister(client)
+		}
+	}
+}
+
+// PublishToken sends a token event to the token's own topic and to the all-tokens topic.
+func PublishToken(tokenAddress string, messageType string, data any) {
+	Publish(TokenTopic(tokenAddress), messageType, data)
+	Publish(TopicTokens, messageType, data)
+}
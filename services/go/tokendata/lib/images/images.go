@@ -0,0 +1,225 @@
+package images
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"net/http"
+	"os"
+	"path/filepath"
+	"samterminal/pkg/httpclient"
+	"slices"
+	"strings"
+	"time"
+	"tokendata/env"
+
+	"github.com/go-resty/resty/v2"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	DefaultSize     = 128
+	defaultCacheDir = "data/images"
+	maxImageBytes   = 5 << 20
+	// maxImagePixels bounds the decoded size of an image, which a small compressed file can
+	// inflate far beyond maxImageBytes.
+	maxImagePixels = 4096 * 4096
+)
+
+// Sizes are the square bounding boxes images can be resized to.
+var Sizes = []int{64, 128, 256}
+
+var (
+	ErrNoImage     = errors.New("token has no image")
+	ErrInvalidSize = errors.New("invalid image size")
+	ErrNotAnImage  = errors.New("source is not an image")
+	ErrTooLarge    = errors.New("image is too large")
+)
+
+// Image sources are set by token creators, so the client only connects to public hosts and
+// stops reading at maxImageBytes.
+var imageClient = httpclient.NewPublic().
+	SetTimeout(10*time.Second).
+	SetResponseBodyLimit(maxImageBytes).
+	SetRetryCount(1).
+	SetHeader("Accept", "image/*")
+
+var fetches singleflight.Group
+
+// Image is a cached image ready to be served.
+type Image struct {
+	Data        []byte
+	ContentType string
+}
+
+func cacheDir() string {
+	if dir := env.IMAGE_CACHE_DIR.GetEnv(); dir != "" {
+		return dir
+	}
+	return defaultCacheDir
+}
+
+// StableURL returns the proxy URL of a token image, or "" when no public base URL is configured.
+func StableURL(tokenAddress string) string {
+	base := strings.TrimRight(env.IMAGE_PROXY_BASE_URL.GetEnv(), "/")
+	if base == "" {
+		return ""
+	}
+	return base + "/images/" + strings.ToLower(tokenAddress)
+}
+
+// cachePath names cached files after the source URL, so a token whose image changes is fetched
+// again while its stable URL stays the same.
+func cachePath(tokenAddress string, sourceURL string, size int) string {
+	sum := sha1.Sum([]byte(sourceURL))
+	name := fmt.Sprintf("%s_%d", hex.EncodeToString(sum[:8]), size)
+	return filepath.Join(cacheDir(), strings.ToLower(tokenAddress), name)
+}
+
+// Get returns a token image resized to fit size×size, downloading the source the first time it is
+// requested. Images that cannot be decoded, such as WebP, are served as downloaded.
+func Get(tokenAddress string, sourceURL string, size int) (Image, error) {
+	if sourceURL == "" {
+		return Image{}, ErrNoImage
+	}
+	if !slices.Contains(Sizes, size) {
+		return Image{}, ErrInvalidSize
+	}
+	path := cachePath(tokenAddress, sourceURL, size)
+	if data, err := os.ReadFile(path); err == nil {
+		return Image{Data: data, ContentType: http.DetectContentType(data)}, nil
+	}
+
+	result, err, _ := fetches.Do(path, func() (any, error) {
+		original, err := getOriginal(tokenAddress, sourceURL)
+		if err != nil {
+			return nil, err
+		}
+		data, err := resize(original, size)
+		if errors.Is(err, ErrTooLarge) {
+			return nil, err
+		}
+		if err != nil {
+			// Keep serving formats the standard library cannot decode.
+			data = original
+		}
+		if err := writeFile(path, data); err != nil {
+			return nil, err
+		}
+		return data, nil
+	})
+	if err != nil {
+		return Image{}, err
+	}
+	data := result.([]byte)
+	return Image{Data: data, ContentType: http.DetectContentType(data)}, nil
+}
+
+// getOriginal returns the downloaded source image, fetching it only when it is not on disk yet.
+func getOriginal(tokenAddress string, sourceURL string) ([]byte, error) {
+	path := cachePath(tokenAddress, sourceURL, 0)
+	if data, err := os.ReadFile(path); err == nil {
+		return data, nil
+	}
+	resp, err := imageClient.R().Get(sourceURL)
+	if errors.Is(err, resty.ErrResponseBodyTooLarge) {
+		return nil, fmt.Errorf("%w: larger than %d bytes", ErrTooLarge, maxImageBytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode())
+	}
+	data := resp.Body()
+	if !strings.HasPrefix(http.DetectContentType(data), "image/") {
+		return nil, ErrNotAnImage
+	}
+	if err := writeFile(path, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// writeFile writes through a temporary file so readers never see a partial image.
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// resize scales an image down to fit size×size, averaging the source pixels covered by each
+// target pixel, and encodes it as PNG. Smaller images keep their dimensions.
+func resize(data []byte, size int) ([]byte, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if config.Width*config.Height > maxImagePixels {
+		return nil, fmt.Errorf("%w: %dx%d pixels", ErrTooLarge, config.Width, config.Height)
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width == 0 || height == 0 {
+		return nil, ErrNotAnImage
+	}
+	scale := min(float64(size)/float64(width), float64(size)/float64(height), 1)
+	dstWidth := max(int(float64(width)*scale), 1)
+	dstHeight := max(int(float64(height)*scale), 1)
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < dstHeight; y++ {
+		y0 := bounds.Min.Y + y*height/dstHeight
+		y1 := max(bounds.Min.Y+(y+1)*height/dstHeight, y0+1)
+		for x := 0; x < dstWidth; x++ {
+			x0 := bounds.Min.X + x*width/dstWidth
+			x1 := max(bounds.Min.X+(x+1)*width/dstWidth, x0+1)
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+					n++
+				}
+			}
+			// Average premultiplied values, then convert back to non-premultiplied 8-bit.
+			i := dst.PixOffset(x, y)
+			if a == 0 {
+				continue
+			}
+			dst.Pix[i] = uint8(r * 0xff / a)
+			dst.Pix[i+1] = uint8(g * 0xff / a)
+			dst.Pix[i+2] = uint8(b * 0xff / a)
+			dst.Pix[i+3] = uint8(a / n >> 8)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := png.Encode(&out, dst); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
@@ -0,0 +1,43 @@
+package images
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+	"testing"
+)
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var out bytes.Buffer
+	if err := png.Encode(&out, image.NewGray(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+	return out.Bytes()
+}
+
+func TestResize(t *testing.T) {
+	data, err := resize(encodePNG(t, 512, 256), 128)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Width != 128 || config.Height != 64 {
+		t.Errorf("resized to %dx%d, want 128x64", config.Width, config.Height)
+	}
+}
+
+func TestResizeRejectsHugeImages(t *testing.T) {
+	// A blank image compresses to a small file whatever its dimensions.
+	data := encodePNG(t, 8192, 4096)
+	if len(data) > maxImageBytes {
+		t.Fatalf("test image is %d bytes", len(data))
+	}
+	if _, err := resize(data, 128); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("err = %v, want ErrTooLarge", err)
+	}
+}
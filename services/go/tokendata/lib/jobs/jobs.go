@@ -0,0 +1,218 @@
+// Package jobs runs the periodic maintenance jobs of tokendata. Each job has its own interval,
+// jitter and timeout, never runs twice at the same time and can be triggered on demand.
+package jobs
+
+import (
+	"cmp"
+	"errors"
+	"log"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Job is a task run every Interval plus a random delay of up to Jitter. A job with no interval
+// only runs when triggered.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Jitter   time.Duration
+	// Runs longer than Timeout are logged and reported as timed out. Job functions cannot be
+	// interrupted, so the run keeps the job busy until it returns.
+	Timeout    time.Duration
+	RunOnStart bool
+	Run        func()
+}
+
+type Result string
+
+const (
+	ResultOK       Result = "ok"
+	ResultPanic    Result = "panic"
+	ResultTimedOut Result = "timed_out"
+)
+
+// Status is the schedule and last run of a job.
+type Status struct {
+	Name           string
+	Interval       time.Duration
+	Timeout        time.Duration
+	Running        bool
+	LastStartedAt  time.Time
+	LastFinishedAt time.Time
+	LastDuration   time.Duration
+	LastResult     Result
+	Runs           int64
+	// Scheduled runs skipped because the previous run had not finished.
+	MissedRuns int64
+	NextRunAt  time.Time
+}
+
+var (
+	ErrUnknownJob = errors.New("unknown job")
+	ErrJobRunning = errors.New("job is already running")
+)
+
+type entry struct {
+	job Job
+
+	mu     sync.Mutex
+	status Status
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]*entry{}
+	started    bool
+)
+
+// Register adds a job. Jobs registered after Start are scheduled right away.
+func Register(job Job) {
+	e := &entry{job: job, status: Status{Name: job.Name, Interval: job.Interval, Timeout: job.Timeout}}
+	registryMu.Lock()
+	if _, exists := registry[job.Name]; exists {
+		registryMu.Unlock()
+		log.Printf("Job %s is already registered", job.Name)
+		return
+	}
+	registry[job.Name] = e
+	scheduleNow := started
+	registryMu.Unlock()
+	if scheduleNow {
+		go e.schedule()
+	}
+}
+
+// Start schedules every registered job.
+func Start() {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if started {
+		return
+	}
+	started = true
+	for _, e := range registry {
+		go e.schedule()
+	}
+}
+
+// Trigger starts a run of a job now, outside its schedule.
+func Trigger(name string) (Status, error) {
+	registryMu.RLock()
+	e, ok := registry[name]
+	registryMu.RUnlock()
+	if !ok {
+		return Status{}, ErrUnknownJob
+	}
+	if !e.acquire() {
+		return e.snapshot(), ErrJobRunning
+	}
+	log.Printf("Job %s triggered on demand", name)
+	go e.run()
+	return e.snapshot(), nil
+}
+
+// Statuses returns the status of every job, sorted by name.
+func Statuses() []Status {
+	registryMu.RLock()
+	statuses := make([]Status, 0, len(registry))
+	for _, e := range registry {
+		statuses = append(statuses, e.snapshot())
+	}
+	registryMu.RUnlock()
+	slices.SortFunc(statuses, func(a, b Status) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return statuses
+}
+
+func (e *entry) schedule() {
+	if e.job.RunOnStart && e.acquire() {
+		e.run()
+	}
+	if e.job.Interval <= 0 {
+		return
+	}
+	for {
+		wait := e.job.Interval
+		if e.job.Jitter > 0 {
+			wait += rand.N(e.job.Jitter)
+		}
+		e.mu.Lock()
+		e.status.NextRunAt = time.Now().Add(wait)
+		e.mu.Unlock()
+
+		time.Sleep(wait)
+		if !e.acquire() {
+			e.mu.Lock()
+			e.status.MissedRuns++
+			e.mu.Unlock()
+			log.Printf("Job %s skipped, the previous run is still going", e.job.Name)
+			continue
+		}
+		e.run()
+	}
+}
+
+// acquire marks the job as running, reporting false if it already is.
+func (e *entry) acquire() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.status.Running {
+		return false
+	}
+	e.status.Running = true
+	e.status.LastStartedAt = time.Now()
+	return true
+}
+
+// run executes an acquired job and records the outcome.
+func (e *entry) run() {
+	e.mu.Lock()
+	start := e.status.LastStartedAt
+	e.mu.Unlock()
+
+	timedOut := false
+	var watchdog *time.Timer
+	if e.job.Timeout > 0 {
+		watchdog = time.AfterFunc(e.job.Timeout, func() {
+			e.mu.Lock()
+			timedOut = true
+			e.mu.Unlock()
+			log.Printf("Job %s is still running after %s", e.job.Name, e.job.Timeout)
+		})
+	}
+
+	result := ResultOK
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("Job %s panicked: %v", e.job.Name, r)
+				result = ResultPanic
+			}
+		}()
+		e.job.Run()
+	}()
+	if watchdog != nil {
+		watchdog.Stop()
+	}
+
+	finished := time.Now()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if timedOut && result == ResultOK {
+		result = ResultTimedOut
+	}
+	e.status.Running = false
+	e.status.LastFinishedAt = finished
+	e.status.LastDuration = finished.Sub(start)
+	e.status.LastResult = result
+	e.status.Runs++
+}
+
+func (e *entry) snapshot() Status {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.status
+}
@@ -0,0 +1,65 @@
+package liquidity
+
+import (
+	"context"
+	"math/big"
+	"samterminal/pkg/chain"
+	"strings"
+	websocket "tokendata/lib/ws"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const liquidityABI = `[{
+	"inputs": [{"name": "account", "type": "address"}],
+	"name": "balanceOf",
+	"outputs": [{"name": "", "type": "uint256"}],
+	"stateMutability": "view",
+	"type": "function"
+}, {
+	"inputs": [{"name": "poolId", "type": "bytes32"}],
+	"name": "getLiquidity",
+	"outputs": [{"name": "liquidity", "type": "uint128"}],
+	"stateMutability": "view",
+	"type": "function"
+}]`
+
+var contract = mustParseABI(liquidityABI)
+
+func mustParseABI(definition string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+func call(ctx context.Context, to string, method string, args ...any) (*big.Int, error) {
+	data, err := contract.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	address := common.HexToAddress(to)
+	res, err := websocket.GetEthClient().CallContract(ctx, ethereum.CallMsg{To: &address, Data: data}, nil)
+	if err != nil {
+		return nil, err
+	}
+	out, err := contract.Unpack(method, res)
+	if err != nil {
+		return nil, err
+	}
+	return out[0].(*big.Int), nil
+}
+
+// PairBalance returns how much of the pair token a V3 pool holds, in base units.
+func PairBalance(ctx context.Context, poolAddress string, pairAddress string) (*big.Int, error) {
+	return call(ctx, pairAddress, "balanceOf", common.HexToAddress(poolAddress))
+}
+
+// V4Liquidity returns the in-range liquidity of a V4 pool. V4 pools share the PoolManager's
+// balances, so unlike V3 there is no per-pool balance to read.
+func V4Liquidity(ctx context.Context, poolID string) (*big.Int, error) {
+	return call(ctx, chain.Get().StateView, "getLiquidity", common.HexToHash(poolID))
+}
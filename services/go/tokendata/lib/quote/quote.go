@@ -0,0 +1,322 @@
+package quote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"samterminal/pkg/chain"
+	"strings"
+	"sync"
+	"time"
+	"tokendata/lib/anchors"
+	websocket "tokendata/lib/ws"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// The PoolKey of a V4 pool the PositionManager does not know is only available from its
+	// Initialize log, which is searched backwards from the head in windows of v4LogWindow blocks,
+	// about 11 days in total.
+	v4LogWindow     = 10_000
+	v4LogMaxWindows = 50
+	// poolKeyMissTTL is how long a pool whose key was not found is not searched again.
+	poolKeyMissTTL = 10 * time.Minute
+)
+
+const quoterV2ABI = `[{
+	"inputs": [{"components": [
+		{"name": "tokenIn", "type": "address"},
+		{"name": "tokenOut", "type": "address"},
+		{"name": "amountIn", "type": "uint256"},
+		{"name": "fee", "type": "uint24"},
+		{"name": "sqrtPriceLimitX96", "type": "uint160"}
+	], "name": "params", "type": "tuple"}],
+	"name": "quoteExactInputSingle",
+	"outputs": [
+		{"name": "amountOut", "type": "uint256"},
+		{"name": "sqrtPriceX96After", "type": "uint160"},
+		{"name": "initializedTicksCrossed", "type": "uint32"},
+		{"name": "gasEstimate", "type": "uint256"}
+	],
+	"stateMutability": "nonpayable",
+	"type": "function"
+}, {
+	"inputs": [],
+	"name": "fee",
+	"outputs": [{"name": "", "type": "uint24"}],
+	"stateMutability": "view",
+	"type": "function"
+}]`
+
+const v4QuoterABI = `[{
+	"inputs": [{"components": [
+		{"components": [
+			{"name": "currency0", "type": "address"},
+			{"name": "currency1", "type": "address"},
+			{"name": "fee", "type": "uint24"},
+			{"name": "tickSpacing", "type": "int24"},
+			{"name": "hooks", "type": "address"}
+		], "name": "poolKey", "type": "tuple"},
+		{"name": "zeroForOne", "type": "bool"},
+		{"name": "exactAmount", "type": "uint128"},
+		{"name": "hookData", "type": "bytes"}
+	], "name": "params", "type": "tuple"}],
+	"name": "quoteExactInputSingle",
+	"outputs": [
+		{"name": "amountOut", "type": "uint256"},
+		{"name": "gasEstimate", "type": "uint256"}
+	],
+	"stateMutability": "nonpayable",
+	"type": "function"
+}, {
+	"anonymous": false,
+	"inputs": [
+		{"indexed": true, "name": "id", "type": "bytes32"},
+		{"indexed": true, "name": "currency0", "type": "address"},
+		{"indexed": true, "name": "currency1", "type": "address"},
+		{"indexed": false, "name": "fee", "type": "uint24"},
+		{"indexed": false, "name": "tickSpacing", "type": "int24"},
+		{"indexed": false, "name": "hooks", "type": "address"},
+		{"indexed": false, "name": "sqrtPriceX96", "type": "uint160"},
+		{"indexed": false, "name": "tick", "type": "int24"}
+	],
+	"name": "Initialize",
+	"type": "event"
+}]`
+
+const positionManagerABI = `[{
+	"inputs": [{"name": "id", "type": "bytes25"}],
+	"name": "poolKeys",
+	"outputs": [
+		{"name": "currency0", "type": "address"},
+		{"name": "currency1", "type": "address"},
+		{"name": "fee", "type": "uint24"},
+		{"name": "tickSpacing", "type": "int24"},
+		{"name": "hooks", "type": "address"}
+	],
+	"stateMutability": "view",
+	"type": "function"
+}]`
+
+var (
+	ErrPoolKeyNotFound = errors.New("v4 pool key not found")
+	ErrTokenNotInPool  = errors.New("token is not in pool")
+
+	quoterV2        = mustParseABI(quoterV2ABI)
+	v4Quoter        = mustParseABI(v4QuoterABI)
+	positionManager = mustParseABI(positionManagerABI)
+	poolKeys        sync.Map
+)
+
+func mustParseABI(definition string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// Result is the simulated output of an exact input swap, in base units.
+type Result struct {
+	AmountOut   *big.Int
+	GasEstimate uint64
+}
+
+type poolKey struct {
+	Currency0   common.Address
+	Currency1   common.Address
+	Fee         *big.Int
+	TickSpacing *big.Int
+	Hooks       common.Address
+}
+
+type v3Params struct {
+	TokenIn           common.Address
+	TokenOut          common.Address
+	AmountIn          *big.Int
+	Fee               *big.Int
+	SqrtPriceLimitX96 *big.Int
+}
+
+type v4Params struct {
+	PoolKey     poolKey
+	ZeroForOne  bool
+	ExactAmount *big.Int
+	HookData    []byte
+}
+
+func call(ctx context.Context, to string, contract abi.ABI, method string, args ...any) ([]any, error) {
+	data, err := contract.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	address := common.HexToAddress(to)
+	res, err := websocket.GetEthClient().CallContract(ctx, ethereum.CallMsg{To: &address, Data: data}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return contract.Unpack(method, res)
+}
+
+// ExactInput simulates selling amountIn of tokenIn for tokenOut on a pool. poolAddress is the
+// pool contract for V3 and the pool id for V4.
+func ExactInput(ctx context.Context, poolAddress string, isV4 bool, tokenIn string, tokenOut string, amountIn *big.Int) (Result, error) {
+	if isV4 {
+		return exactInputV4(ctx, poolAddress, tokenIn, amountIn)
+	}
+	return exactInputV3(ctx, poolAddress, tokenIn, tokenOut, amountIn)
+}
+
+func exactInputV3(ctx context.Context, poolAddress string, tokenIn string, tokenOut string, amountIn *big.Int) (Result, error) {
+	fee, err := call(ctx, poolAddress, quoterV2, "fee")
+	if err != nil {
+		return Result{}, fmt.Errorf("error reading pool fee: %w", err)
+	}
+	out, err := call(ctx, chain.Get().QuoterV2, quoterV2, "quoteExactInputSingle", v3Params{
+		TokenIn:           common.HexToAddress(tokenIn),
+		TokenOut:          common.HexToAddress(tokenOut),
+		AmountIn:          amountIn,
+		Fee:               fee[0].(*big.Int),
+		SqrtPriceLimitX96: big.NewInt(0),
+	})
+	if err != nil {
+		return Result{}, fmt.Errorf("error quoting v3 swap: %w", err)
+	}
+	return Result{AmountOut: out[0].(*big.Int), GasEstimate: out[3].(*big.Int).Uint64()}, nil
+}
+
+// matchesCurrency treats the wrapped native token as native ETH, which V4 pools hold as the zero
+// address.
+func matchesCurrency(token string, currency common.Address) bool {
+	if currency == (common.Address{}) {
+		return strings.EqualFold(token, anchors.Native().Address)
+	}
+	return strings.EqualFold(token, currency.Hex())
+}
+
+func exactInputV4(ctx context.Context, poolID string, tokenIn string, amountIn *big.Int) (Result, error) {
+	key, err := getPoolKey(ctx, poolID)
+	if err != nil {
+		return Result{}, err
+	}
+	var zeroForOne bool
+	switch {
+	case matchesCurrency(tokenIn, key.Currency0):
+		zeroForOne = true
+	case matchesCurrency(tokenIn, key.Currency1):
+		zeroForOne = false
+	default:
+		return Result{}, ErrTokenNotInPool
+	}
+	out, err := call(ctx, chain.Get().V4Quoter, v4Quoter, "quoteExactInputSingle", v4Params{
+		PoolKey:     key,
+		ZeroForOne:  zeroForOne,
+		ExactAmount: amountIn,
+		HookData:    []byte{},
+	})
+	if err != nil {
+		return Result{}, fmt.Errorf("error quoting v4 swap: %w", err)
+	}
+	return Result{AmountOut: out[0].(*big.Int), GasEstimate: out[1].(*big.Int).Uint64()}, nil
+}
+
+// cachedPoolKey is a pool key, or a miss when found is false.
+type cachedPoolKey struct {
+	key   poolKey
+	found bool
+	at    time.Time
+}
+
+// getPoolKey reads the key of a V4 pool from the PositionManager, which knows every pool that had
+// liquidity added through it, and from its Initialize log otherwise. Keys never change, so they are
+// cached for the lifetime of the process; misses for poolKeyMissTTL.
+func getPoolKey(ctx context.Context, poolID string) (poolKey, error) {
+	id := strings.ToLower(poolID)
+	if cached, ok := poolKeys.Load(id); ok {
+		if entry := cached.(cachedPoolKey); entry.found {
+			return entry.key, nil
+		} else if time.Since(entry.at) < poolKeyMissTTL {
+			return poolKey{}, ErrPoolKeyNotFound
+		}
+	}
+	key, err := readPoolKey(ctx, poolID)
+	if errors.Is(err, ErrPoolKeyNotFound) {
+		key, err = findPoolKey(ctx, poolID)
+	}
+	switch {
+	case err == nil:
+		poolKeys.Store(id, cachedPoolKey{key: key, found: true})
+	case errors.Is(err, ErrPoolKeyNotFound):
+		poolKeys.Store(id, cachedPoolKey{at: time.Now()})
+	}
+	return key, err
+}
+
+func readPoolKey(ctx context.Context, poolID string) (poolKey, error) {
+	var id [25]byte
+	copy(id[:], common.HexToHash(poolID).Bytes())
+	out, err := call(ctx, chain.Get().PositionManager, positionManager, "poolKeys", id)
+	if err != nil {
+		return poolKey{}, err
+	}
+	// currency0 is the zero address for native ETH pools, currency1 never is.
+	if out[1].(common.Address) == (common.Address{}) {
+		return poolKey{}, ErrPoolKeyNotFound
+	}
+	return poolKey{
+		Currency0:   out[0].(common.Address),
+		Currency1:   out[1].(common.Address),
+		Fee:         out[2].(*big.Int),
+		TickSpacing: out[3].(*big.Int),
+		Hooks:       out[4].(common.Address),
+	}, nil
+}
+
+// findPoolKey reads the key of a V4 pool from its Initialize log.
+func findPoolKey(ctx context.Context, poolID string) (poolKey, error) {
+	client := websocket.GetEthClient()
+	head, err := client.BlockNumber(ctx)
+	if err != nil {
+		return poolKey{}, err
+	}
+	event := v4Quoter.Events["Initialize"]
+	to := head
+	for i := 0; i < v4LogMaxWindows && to > 0; i++ {
+		from := to - min(to, v4LogWindow-1)
+		logs, err := client.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(from),
+			ToBlock:   new(big.Int).SetUint64(to),
+			Addresses: []common.Address{common.HexToAddress(chain.Get().PoolManager)},
+			Topics:    [][]common.Hash{{event.ID}, {common.HexToHash(poolID)}},
+		})
+		if err != nil {
+			return poolKey{}, err
+		}
+		if len(logs) > 0 {
+			initialized := logs[0]
+			if len(initialized.Topics) < 4 {
+				return poolKey{}, ErrPoolKeyNotFound
+			}
+			values := map[string]any{}
+			if err := v4Quoter.UnpackIntoMap(values, "Initialize", initialized.Data); err != nil {
+				return poolKey{}, err
+			}
+			return poolKey{
+				Currency0:   common.BytesToAddress(initialized.Topics[2].Bytes()),
+				Currency1:   common.BytesToAddress(initialized.Topics[3].Bytes()),
+				Fee:         values["fee"].(*big.Int),
+				TickSpacing: values["tickSpacing"].(*big.Int),
+				Hooks:       values["hooks"].(common.Address),
+			}, nil
+		}
+		if from == 0 {
+			break
+		}
+		to = from - 1
+	}
+	return poolKey{}, ErrPoolKeyNotFound
+}
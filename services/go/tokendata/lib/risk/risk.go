@@ -0,0 +1,197 @@
+// Package risk combines the security signals collected on a token into one score.
+package risk
+
+import (
+	"fmt"
+	"math"
+)
+
+// Signals are the security checks of a token. Nil fields are checks that did not run yet.
+type Signals struct {
+	// Spam is set when Moralis flagged the token as possible spam.
+	Spam bool
+	// TransferTaxBps is the share of a simulated transfer out of the pool that did not arrive.
+	TransferTaxBps    *int
+	DeployerRiskScore *int
+	LiquidityUSD      *float64
+	ContractVerified  *bool
+	ContractIsProxy   bool
+	// TopHoldersPct is the share of the supply held by the largest holders, pools and burn
+	// addresses left out.
+	TopHoldersPct *float64
+}
+
+// Signal names, as reported in factors.
+const (
+	SignalSpam                = "spam"
+	SignalHoneypot            = "honeypot"
+	SignalDeployer            = "deployer"
+	SignalLiquidity           = "liquidity"
+	SignalContract            = "contract"
+	SignalHolderConcentration = "holder_concentration"
+)
+
+// Factor is what one signal adds to the score.
+type Factor struct {
+	Signal string
+	Points int
+	Detail string
+	// Known is unset for signals that were not checked yet. They add half the points of their
+	// signal, so a token nobody looked at does not rank as safe.
+	Known bool
+}
+
+// Assessment is the risk score of a token, from 0 (no red flags) to 100, with the factors it
+// is made of.
+type Assessment struct {
+	Score   int
+	Factors []Factor
+}
+
+// Unchecked returns the signals of the assessment that were not checked yet.
+func (a Assessment) Unchecked() []string {
+	signals := []string{}
+	for _, factor := range a.Factors {
+		if !factor.Known {
+			signals = append(signals, factor.Signal)
+		}
+	}
+	return signals
+}
+
+const (
+	maxTaxPoints          = 25
+	maxDeployerPoints     = 25
+	maxLiquidityPoints    = 20
+	maxHolderPoints       = 15
+	maxContractPoints     = unverifiedPoints + proxyPoints
+	honeypotTaxBps        = 9900
+	unverifiedPoints      = 10
+	proxyPoints           = 5
+	taxBpsPerPoint        = 40
+	deployerScorePerPoint = 4
+)
+
+// Score rates the signals of a token. Spam and honeypots, where a transfer keeps almost
+// everything, score 100; otherwise the points of each signal add up to at most 100. Signals not
+// checked yet count as neutral, half way between clean and the worst they can be.
+func Score(signals Signals) Assessment {
+	factors := []Factor{
+		spamFactor(signals),
+		honeypotFactor(signals),
+		deployerFactor(signals),
+		liquidityFactor(signals),
+		contractFactor(signals),
+		holderFactor(signals),
+	}
+	score := 0
+	for _, factor := range factors {
+		score += factor.Points
+	}
+	if signals.Spam || (signals.TransferTaxBps != nil && *signals.TransferTaxBps >= honeypotTaxBps) {
+		score = 100
+	}
+	return Assessment{Score: min(score, 100), Factors: factors}
+}
+
+func spamFactor(signals Signals) Factor {
+	if signals.Spam {
+		return Factor{Signal: SignalSpam, Points: 100, Detail: "flagged as possible spam", Known: true}
+	}
+	return Factor{Signal: SignalSpam, Known: true}
+}
+
+func honeypotFactor(signals Signals) Factor {
+	if signals.TransferTaxBps == nil {
+		return uncheckedFactor(SignalHoneypot, maxTaxPoints)
+	}
+	bps := *signals.TransferTaxBps
+	if bps >= honeypotTaxBps {
+		return Factor{Signal: SignalHoneypot, Points: maxTaxPoints, Detail: "transfers keep almost everything", Known: true}
+	}
+	factor := Factor{Signal: SignalHoneypot, Points: min(bps/taxBpsPerPoint, maxTaxPoints), Known: true}
+	if bps > 0 {
+		factor.Detail = fmt.Sprintf("%.2f%% transfer tax", float64(bps)/100)
+	}
+	return factor
+}
+
+func deployerFactor(signals Signals) Factor {
+	if signals.DeployerRiskScore == nil {
+		return uncheckedFactor(SignalDeployer, maxDeployerPoints)
+	}
+	score := *signals.DeployerRiskScore
+	return Factor{
+		Signal: SignalDeployer,
+		Points: min(score/deployerScorePerPoint, maxDeployerPoints),
+		Detail: fmt.Sprintf("deployer risk %d", score),
+		Known:  true,
+	}
+}
+
+func liquidityFactor(signals Signals) Factor {
+	if signals.LiquidityUSD == nil {
+		return uncheckedFactor(SignalLiquidity, maxLiquidityPoints)
+	}
+	liquidity := *signals.LiquidityUSD
+	points := 0
+	switch {
+	case liquidity < 1_000:
+		points = maxLiquidityPoints
+	case liquidity < 10_000:
+		points = 12
+	case liquidity < 50_000:
+		points = 5
+	}
+	return Factor{Signal: SignalLiquidity, Points: points, Detail: fmt.Sprintf("$%.0f liquidity", liquidity), Known: true}
+}
+
+func contractFactor(signals Signals) Factor {
+	if signals.ContractVerified == nil {
+		return uncheckedFactor(SignalContract, maxContractPoints)
+	}
+	factor := Factor{Signal: SignalContract, Known: true}
+	if !*signals.ContractVerified {
+		factor.Points += unverifiedPoints
+		factor.Detail = "unverified source"
+	}
+	if signals.ContractIsProxy {
+		factor.Points += proxyPoints
+		factor.Detail = joinDetail(factor.Detail, "upgradeable proxy")
+	}
+	return factor
+}
+
+func holderFactor(signals Signals) Factor {
+	if signals.TopHoldersPct == nil {
+		return uncheckedFactor(SignalHolderConcentration, maxHolderPoints)
+	}
+	pct := *signals.TopHoldersPct
+	points := 0
+	switch {
+	case pct > 50:
+		points = maxHolderPoints
+	case pct > 30:
+		points = 8
+	case pct > 20:
+		points = 4
+	}
+	return Factor{
+		Signal: SignalHolderConcentration,
+		Points: points,
+		Detail: fmt.Sprintf("top holders own %.1f%%", math.Round(pct*10)/10),
+		Known:  true,
+	}
+}
+
+// uncheckedFactor is a signal that was not checked yet, at half its maximum points.
+func uncheckedFactor(signal string, maxPoints int) Factor {
+	return Factor{Signal: signal, Points: maxPoints / 2, Detail: "not checked yet"}
+}
+
+func joinDetail(a, b string) string {
+	if a == "" {
+		return b
+	}
+	return a + ", " + b
+}
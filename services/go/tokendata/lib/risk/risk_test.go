@@ -0,0 +1,44 @@
+package risk
+
+import "testing"
+
+func ptr[T any](v T) *T { return &v }
+
+func TestScore(t *testing.T) {
+	cases := []struct {
+		name    string
+		signals Signals
+		want    int
+	}{
+		// Half of 25, 25, 20, 15 and 15.
+		{"unchecked", Signals{}, 48},
+		{"clean", Signals{TransferTaxBps: ptr(0), DeployerRiskScore: ptr(0), LiquidityUSD: ptr(250_000.0), ContractVerified: ptr(true), TopHoldersPct: ptr(12.0)}, 0},
+		{"spam", Signals{Spam: true, LiquidityUSD: ptr(250_000.0)}, 100},
+		{"honeypot", Signals{TransferTaxBps: ptr(10_000)}, 100},
+		// 5% tax 12, deployer 60 15, $5k liquidity 12, unverified proxy 15, 35% top holders 8.
+		{"risky", Signals{TransferTaxBps: ptr(500), DeployerRiskScore: ptr(60), LiquidityUSD: ptr(5_000.0), ContractVerified: ptr(false), ContractIsProxy: true, TopHoldersPct: ptr(35.0)}, 62},
+		{"worst", Signals{TransferTaxBps: ptr(5_000), DeployerRiskScore: ptr(100), LiquidityUSD: ptr(10.0), ContractVerified: ptr(false), ContractIsProxy: true, TopHoldersPct: ptr(90.0)}, 100},
+	}
+	for _, c := range cases {
+		if got := Score(c.signals).Score; got != c.want {
+			t.Errorf("%s: score = %d, want %d", c.name, got, c.want)
+		}
+	}
+}
+
+func TestScoreReportsUncheckedSignals(t *testing.T) {
+	assessment := Score(Signals{LiquidityUSD: ptr(500.0)})
+	for _, factor := range assessment.Factors {
+		wantKnown := factor.Signal == SignalSpam || factor.Signal == SignalLiquidity
+		if factor.Known != wantKnown {
+			t.Errorf("%s known = %v, want %v", factor.Signal, factor.Known, wantKnown)
+		}
+	}
+	// $500 liquidity 20, the four unchecked signals 12, 12, 7 and 7.
+	if assessment.Score != 58 {
+		t.Errorf("score = %d, want 58", assessment.Score)
+	}
+	if unchecked := assessment.Unchecked(); len(unchecked) != 4 || unchecked[0] != SignalHoneypot {
+		t.Errorf("unchecked = %v, want the four signals other than spam and liquidity", unchecked)
+	}
+}
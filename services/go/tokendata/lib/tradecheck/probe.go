@@ -0,0 +1,70 @@
+package tradecheck
+
+import "github.com/ethereum/go-ethereum/core/vm"
+
+// transferProbeCode runs in place of the code of a token holder. Called with the token, the
+// recipient and the amount as three words, it transfers the amount to the recipient and returns
+// the balance of the recipient before and after. Any failing call reverts.
+var transferProbeCode = func() []byte {
+	p := &program{}
+	p.balanceOfRecipient(0x80)
+	// transfer(recipient, amount)
+	p.storeSelector(0xa9059cbb)
+	p.push(0x20).op(vm.CALLDATALOAD).push(0x04).op(vm.MSTORE)
+	p.push(0x40).op(vm.CALLDATALOAD).push(0x24).op(vm.MSTORE)
+	// retSize, retOffset, argsSize, argsOffset, value, token, gas
+	p.push(0).push(0).push(0x44).push(0).push(0)
+	p.push(0).op(vm.CALLDATALOAD).op(vm.GAS).op(vm.CALL)
+	p.revertIfZero()
+	p.balanceOfRecipient(0xa0)
+	p.push(0x40).push(0x80).op(vm.RETURN)
+	return p.assemble()
+}()
+
+// program assembles the probe. Failed calls jump to a shared revert at the end.
+type program struct {
+	code  []byte
+	fails []int
+}
+
+func (p *program) op(op vm.OpCode) *program {
+	p.code = append(p.code, byte(op))
+	return p
+}
+
+func (p *program) push(value byte) *program {
+	p.code = append(p.code, byte(vm.PUSH1), value)
+	return p
+}
+
+// storeSelector writes a function selector to the first four bytes of memory.
+func (p *program) storeSelector(selector uint32) {
+	p.code = append(p.code, byte(vm.PUSH4), byte(selector>>24), byte(selector>>16), byte(selector>>8), byte(selector))
+	p.push(0xe0).op(vm.SHL).push(0).op(vm.MSTORE)
+}
+
+func (p *program) revertIfZero() {
+	p.op(vm.ISZERO)
+	p.fails = append(p.fails, len(p.code)+1)
+	p.code = append(p.code, byte(vm.PUSH2), 0, 0)
+	p.op(vm.JUMPI)
+}
+
+// balanceOfRecipient stores the token balance of the recipient in memory at offset.
+func (p *program) balanceOfRecipient(offset byte) {
+	p.storeSelector(0x70a08231)
+	p.push(0x20).op(vm.CALLDATALOAD).push(0x04).op(vm.MSTORE)
+	// retSize, retOffset, argsSize, argsOffset, token, gas
+	p.push(0x20).push(offset).push(0x24).push(0)
+	p.push(0).op(vm.CALLDATALOAD).op(vm.GAS).op(vm.STATICCALL)
+	p.revertIfZero()
+}
+
+func (p *program) assemble() []byte {
+	fail := len(p.code)
+	p.op(vm.JUMPDEST).push(0).push(0).op(vm.REVERT)
+	for _, at := range p.fails {
+		p.code[at], p.code[at+1] = byte(fail>>8), byte(fail)
+	}
+	return p.code
+}
@@ -0,0 +1,147 @@
+// Package tradecheck finds out whether the price of a token differs from what a trader pays: V4
+// pools can run hooks on every swap and some tokens take a fee on every transfer.
+package tradecheck
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"samterminal/pkg/chain"
+	"strings"
+	websocket "tokendata/lib/ws"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+)
+
+// dynamicFeeFlag is the fee of V4 pools whose hook sets the fee of every swap.
+const dynamicFeeFlag = 0x800000
+
+// probeRecipient receives the simulated transfer; it holds no tokens and is exempt from nothing.
+var probeRecipient = common.HexToAddress("0x000000000000000000000000000000000000fee1")
+
+var ErrUnknownPool = errors.New("pool key unknown")
+
+const tradeCheckABI = `[{
+	"inputs": [{"name": "id", "type": "bytes25"}],
+	"name": "poolKeys",
+	"outputs": [
+		{"name": "currency0", "type": "address"},
+		{"name": "currency1", "type": "address"},
+		{"name": "fee", "type": "uint24"},
+		{"name": "tickSpacing", "type": "int24"},
+		{"name": "hooks", "type": "address"}
+	],
+	"stateMutability": "view",
+	"type": "function"
+}, {
+	"inputs": [{"name": "account", "type": "address"}],
+	"name": "balanceOf",
+	"outputs": [{"name": "", "type": "uint256"}],
+	"stateMutability": "view",
+	"type": "function"
+}]`
+
+var contract = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(tradeCheckABI))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// PoolHooks is the hook setup of a V4 pool.
+type PoolHooks struct {
+	// Hooks is the hook contract, empty when the pool has none.
+	Hooks string
+	// DynamicFee is set when the hook chooses the fee of each swap.
+	DynamicFee bool
+}
+
+// GetPoolHooks reads the key of a V4 pool from the PositionManager. Pools that never had
+// liquidity added through it return ErrUnknownPool.
+func GetPoolHooks(ctx context.Context, poolID string) (PoolHooks, error) {
+	var id [25]byte
+	copy(id[:], common.HexToHash(poolID).Bytes())
+	data, err := contract.Pack("poolKeys", id)
+	if err != nil {
+		return PoolHooks{}, err
+	}
+	to := common.HexToAddress(chain.Get().PositionManager)
+	res, err := websocket.GetEthClient().CallContract(ctx, ethereum.CallMsg{To: &to, Data: data}, nil)
+	if err != nil {
+		return PoolHooks{}, err
+	}
+	out, err := contract.Unpack("poolKeys", res)
+	if err != nil {
+		return PoolHooks{}, err
+	}
+	// currency0 is the zero address for native ETH pools, currency1 never is.
+	if out[1].(common.Address) == (common.Address{}) {
+		return PoolHooks{}, ErrUnknownPool
+	}
+	hooks := PoolHooks{DynamicFee: out[2].(*big.Int).Int64() == dynamicFeeFlag}
+	if address := out[4].(common.Address); address != (common.Address{}) {
+		hooks.Hooks = strings.ToLower(address.Hex())
+	}
+	return hooks, nil
+}
+
+// GetTransferTaxBps simulates a transfer of a token out of holder, usually its pool, and returns
+// the share of the amount that did not arrive in basis points. The transfer is run by an eth_call
+// that replaces the code of holder with a probe, so nothing is sent and no key is needed.
+func GetTransferTaxBps(ctx context.Context, tokenAddress string, holder string) (int, error) {
+	token, from := common.HexToAddress(tokenAddress), common.HexToAddress(holder)
+	balance, err := balanceOf(ctx, token, from)
+	if err != nil {
+		return 0, err
+	}
+	amount := new(big.Int).Div(balance, big.NewInt(1000))
+	if amount.Sign() == 0 {
+		return 0, errors.New("holder has no balance to transfer")
+	}
+
+	input := append(common.LeftPadBytes(token.Bytes(), 32), common.LeftPadBytes(probeRecipient.Bytes(), 32)...)
+	input = append(input, common.LeftPadBytes(amount.Bytes(), 32)...)
+	overrides := map[common.Address]gethclient.OverrideAccount{from: {Code: transferProbeCode}}
+	res, err := gethclient.New(websocket.GetEthClient().Client()).CallContract(ctx, ethereum.CallMsg{From: from, To: &from, Data: input}, nil, &overrides)
+	if err != nil {
+		return 0, err
+	}
+	if len(res) != 64 {
+		return 0, errors.New("unexpected transfer probe result")
+	}
+	received := new(big.Int).Sub(new(big.Int).SetBytes(res[32:]), new(big.Int).SetBytes(res[:32]))
+	return transferTaxBps(amount, received)
+}
+
+// transferTaxBps is the share of amount that was not received, in basis points.
+func transferTaxBps(amount *big.Int, received *big.Int) (int, error) {
+	// Tokens that fail a transfer by returning false instead of reverting move nothing.
+	if received.Sign() <= 0 {
+		return 0, errors.New("transfer moved no tokens")
+	}
+	if received.Cmp(amount) >= 0 {
+		return 0, nil
+	}
+	lost := new(big.Int).Mul(new(big.Int).Sub(amount, received), big.NewInt(10000))
+	return int(new(big.Int).Div(lost, amount).Int64()), nil
+}
+
+func balanceOf(ctx context.Context, token common.Address, account common.Address) (*big.Int, error) {
+	data, err := contract.Pack("balanceOf", account)
+	if err != nil {
+		return nil, err
+	}
+	res, err := websocket.GetEthClient().CallContract(ctx, ethereum.CallMsg{To: &token, Data: data}, nil)
+	if err != nil {
+		return nil, err
+	}
+	out, err := contract.Unpack("balanceOf", res)
+	if err != nil {
+		return nil, err
+	}
+	return out[0].(*big.Int), nil
+}
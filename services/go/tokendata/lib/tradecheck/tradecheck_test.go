@@ -0,0 +1,48 @@
+package tradecheck
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+)
+
+// feeToken keeps a single balance: transfer(to, amount) sets it to 90% of amount and
+// balanceOf(account) returns it, like a token taking a 10% fee from a recipient with no tokens.
+var feeToken = common.FromHex("0x600035" + "60e01c" + "80" + "63a9059cbb" + "14" + "601c57" +
+	"50" + "600054" + "600052" + "60206000f3" +
+	"5b" + "50" + "600a" + "6009" + "602435" + "02" + "04" + "600055" + "00")
+
+func TestTransferProbe(t *testing.T) {
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	statedb.SetCode(token, feeToken, 0)
+
+	input := append(common.LeftPadBytes(token.Bytes(), 32), common.LeftPadBytes(probeRecipient.Bytes(), 32)...)
+	input = append(input, common.LeftPadBytes(big.NewInt(1000).Bytes(), 32)...)
+	res, _, err := runtime.Execute(transferProbeCode, input, &runtime.Config{State: statedb})
+	if err != nil {
+		t.Fatal(err)
+	}
+	before, after := new(big.Int).SetBytes(res[:32]), new(big.Int).SetBytes(res[32:])
+	if before.Sign() != 0 || after.Int64() != 900 {
+		t.Fatalf("balances = %s, %s, want 0, 900", before, after)
+	}
+	if bps, err := transferTaxBps(big.NewInt(1000), new(big.Int).Sub(after, before)); err != nil || bps != 1000 {
+		t.Errorf("tax = %d bps, %v, want 1000", bps, err)
+	}
+
+	// A token that reverts must fail the probe rather than report no tax.
+	reverting := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	statedb.SetCode(reverting, common.FromHex("0x60006000fd"), 0)
+	input = append(common.LeftPadBytes(reverting.Bytes(), 32), input[32:]...)
+	if _, _, err := runtime.Execute(transferProbeCode, input, &runtime.Config{State: statedb}); err == nil {
+		t.Error("probe of a failing token did not revert")
+	}
+}
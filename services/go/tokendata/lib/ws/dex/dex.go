@@ -1,11 +1,13 @@
 package wsDex
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"log"
-	"math"
 	"math/big"
+	"samterminal/pkg/chain"
+	"samterminal/pkg/multicall"
 	"strings"
 	websocket "tokendata/lib/ws"
 
@@ -94,9 +96,18 @@ var uniswapV4PoolABI = `[
 ]`
 
 const erc20MetaABI = `[
-  {"inputs":[],"name":"decimals","outputs":[{"internalType":"uint8","name":"","type":"uint8"}],"stateMutability":"view","type":"function"}
+  {"inputs":[],"name":"decimals","outputs":[{"internalType":"uint8","name":"","type":"uint8"}],"stateMutability":"view","type":"function"},
+  {"inputs":[],"name":"totalSupply","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"}
 ]`
 
+var erc20Meta = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(erc20MetaABI))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
 type initializeEvent struct {
 	Currency0 *common.Address
 	Currency1 *common.Address
@@ -113,19 +124,11 @@ type swapEvent struct {
 
 type SwapHandler func(vLog types.Log, sqrtPriceX96 *big.Int, price *big.Float, pair string, reverse bool, tokenAmount string, tokenDecimals int)
 
-const UniswapV4PoolManager = "0x498581ff718922c3f8e6a244956af099b2652b2b"
-
 var (
 	ErrABIRequired      = errors.New("abi json required for generic watcher")
 	ErrSwapEventMissing = errors.New("swap event missing in abi")
 )
 
-var client *ethclient.Client
-
-func init() {
-	client = websocket.GetEthClient()
-}
-
 func readPoolTokens(isV4 bool, poolAddr common.Address) (token0 string, token1 string, err error) {
 	abiJSON := uniswapV3PoolABI
 	if isV4 {
@@ -140,16 +143,16 @@ func readPoolTokens(isV4 bool, poolAddr common.Address) (token0 string, token1 s
 	if isV4 {
 		data := abiParsed.Events["Initialize"]
 
-		head, _ := client.HeaderByNumber(context.Background(), nil)
+		head, _ := websocket.GetEthClient().HeaderByNumber(context.Background(), nil)
 		toBlock := new(big.Int).Set(head.Number)
 		fromBlock := new(big.Int).Sub(toBlock, big.NewInt(5))
 		q := ethereum.FilterQuery{
 			FromBlock: fromBlock,
 			ToBlock:   toBlock,
-			Addresses: []common.Address{common.HexToAddress(UniswapV4PoolManager)},
+			Addresses: []common.Address{common.HexToAddress(chain.Get().PoolManager)},
 			Topics:    [][]common.Hash{{data.ID}},
 		}
-		logs, err := client.FilterLogs(context.Background(), q)
+		logs, err := websocket.GetEthClient().FilterLogs(context.Background(), q)
 		if err != nil {
 			log.Println("wsDex: could not filter logs:", err)
 			return "", "", nil
@@ -176,7 +179,7 @@ func readPoolTokens(isV4 bool, poolAddr common.Address) (token0 string, token1 s
 		log.Println("wsDex: could not pack token1:", err)
 		return "", "", err
 	}
-	res, err := client.CallContract(context.Background(), ethereum.CallMsg{To: &poolAddr, Data: data}, nil)
+	res, err := websocket.GetEthClient().CallContract(context.Background(), ethereum.CallMsg{To: &poolAddr, Data: data}, nil)
 	if err != nil {
 		log.Println("wsDex: could not call contract token1:", err)
 		return "", "", err
@@ -192,7 +195,7 @@ func readPoolTokens(isV4 bool, poolAddr common.Address) (token0 string, token1 s
 		log.Println("wsDex: could not pack token0:", err)
 		return "", "", err
 	}
-	res, err = client.CallContract(context.Background(), ethereum.CallMsg{To: &poolAddr, Data: data}, nil)
+	res, err = websocket.GetEthClient().CallContract(context.Background(), ethereum.CallMsg{To: &poolAddr, Data: data}, nil)
 	if err != nil {
 		log.Println("wsDex: could not call contract token0:", err)
 		return "", "", err
@@ -207,7 +210,17 @@ func readPoolTokens(isV4 bool, poolAddr common.Address) (token0 string, token1 s
 	return token0, token1, nil
 }
 
-func WatchSwapGenericWithABI(ctx context.Context, wssURL string, poolAddr string, isV4 bool, tokenAddr, pairAddress string, onSwap SwapHandler, onError func(error)) (stop func(), err error) {
+// ReadPoolTokens returns token0 and token1 of a Uniswap V3 style pool by calling the pool contract.
+func ReadPoolTokens(poolAddr string) (token0 string, token1 string, err error) {
+	if !common.IsHexAddress(poolAddr) {
+		return "", "", errors.New("invalid pool address")
+	}
+	return readPoolTokens(false, common.HexToAddress(poolAddr))
+}
+
+// WatchSwapGenericWithABI subscribes to the Swap logs of a pool. done is closed once the watcher
+// exits, after stop or when the subscription fails.
+func WatchSwapGenericWithABI(ctx context.Context, wssURL string, poolAddr string, isV4 bool, tokenAddr, pairAddress string, onSwap SwapHandler, onError func(error)) (stop func(), done <-chan struct{}, err error) {
 
 	pAddr := common.HexToAddress(poolAddr)
 
@@ -220,13 +233,13 @@ func WatchSwapGenericWithABI(ctx context.Context, wssURL string, poolAddr string
 	abiParsed, err := abi.JSON(strings.NewReader(useABI))
 	if err != nil {
 		log.Println("wsDex: could not parse abi:", err)
-		return nil, err
+		return nil, nil, err
 	}
 	event := abiParsed.Events["Swap"]
 
 	var poolAddress = pAddr.Hex()
 	if isV4 {
-		poolAddress = UniswapV4PoolManager
+		poolAddress = chain.Get().PoolManager
 	}
 
 	eventTopic := event.ID
@@ -235,10 +248,10 @@ func WatchSwapGenericWithABI(ctx context.Context, wssURL string, poolAddr string
 		query.Topics = append(query.Topics, []common.Hash{common.HexToHash(poolAddr)})
 	}
 	logsCh := make(chan types.Log)
-	sub, err := client.SubscribeFilterLogs(ctx, query, logsCh)
+	sub, err := websocket.GetEthClient().SubscribeFilterLogs(ctx, query, logsCh)
 	if err != nil {
 		log.Printf("Error subscribing to filter logs: %+v", err)
-		return nil, err
+		return nil, nil, err
 	}
 
 	ctxInner, cancel := context.WithCancel(ctx)
@@ -252,11 +265,13 @@ func WatchSwapGenericWithABI(ctx context.Context, wssURL string, poolAddr string
 		if err != nil {
 			log.Println("wsDex: could not read pool tokens:", err)
 			cancel()
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
+	exited := make(chan struct{})
 	go func() {
+		defer close(exited)
 		defer func() {
 			if r := recover(); r != nil {
 				log.Printf("wsDex goroutine panic: %v", r)
@@ -289,14 +304,11 @@ func WatchSwapGenericWithABI(ctx context.Context, wssURL string, poolAddr string
 					continue
 				}
 
-				token0Decimals, err := GetTokenDecimals(ctx, wssURL, token0)
-				if err != nil {
-					log.Println("wsDex: could not get token decimals:", err)
-				}
-				token1Decimals, err := GetTokenDecimals(ctx, wssURL, token1)
+				poolDecimals, err := GetTokensDecimals(ctx, token0, token1)
 				if err != nil {
 					log.Println("wsDex: could not get token decimals:", err)
 				}
+				token0Decimals, token1Decimals := poolDecimals[0], poolDecimals[1]
 				tokenAmount := ev.Amount0
 				tokenDecimals := token0Decimals
 				isSell := ev.Amount0.Sign() == -1
@@ -319,7 +331,7 @@ func WatchSwapGenericWithABI(ctx context.Context, wssURL string, poolAddr string
 	return func() {
 		cancel()
 		sub.Unsubscribe()
-	}, nil
+	}, exited, nil
 }
 
 func sqrtPriceX96ToPriceWithDecimals(sqrtPriceX96 *big.Int, decimals0, decimals1 int, isSell bool) *big.Float {
@@ -333,15 +345,23 @@ func sqrtPriceX96ToPriceWithDecimals(sqrtPriceX96 *big.Int, decimals0, decimals1
 	den := new(big.Float).SetPrec(prec).SetInt(new(big.Int).Lsh(big.NewInt(1), 192))
 	base := new(big.Float).SetPrec(prec).Quo(bfSquared, den)
 
+	// Scaled by an exact power of ten: math.Pow10 is not exact past 10^22.
 	if isSell {
-		base = base.Quo(base, big.NewFloat(math.Pow10(decimals0-decimals1)))
+		base = base.Quo(base, pow10Float(decimals0-decimals1, prec))
 	} else {
-		base = base.Mul(base, big.NewFloat(math.Pow10(decimals1-decimals0)))
+		base = base.Mul(base, pow10Float(decimals1-decimals0, prec))
 	}
 
 	return base
 }
 
+func pow10Float(exponent int, prec uint) *big.Float {
+	if exponent < 0 {
+		return new(big.Float).SetPrec(prec).Quo(big.NewFloat(1), pow10Float(-exponent, prec))
+	}
+	return new(big.Float).SetPrec(prec).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil))
+}
+
 func ethereumFilterQuery(addrs []common.Address, topics [][]common.Hash) ethereum.FilterQuery {
 	return ethereum.FilterQuery{
 		Addresses: addrs,
@@ -354,7 +374,7 @@ func GetTokenDecimals(ctx context.Context, rpcURL, tokenAddr string) (int, error
 	if !common.IsHexAddress(tokenAddr) {
 		return 18, errors.New("invalid token address")
 	}
-	decimals, err := readERC20Decimals(ctx, client, common.HexToAddress(tokenAddr))
+	decimals, err := readERC20Decimals(ctx, websocket.GetEthClient(), common.HexToAddress(tokenAddr))
 	if err != nil {
 		return 18, err
 	}
@@ -363,11 +383,7 @@ func GetTokenDecimals(ctx context.Context, rpcURL, tokenAddr string) (int, error
 }
 
 func readERC20Decimals(ctx context.Context, client *ethclient.Client, token common.Address) (int, error) {
-	ercABI, err := abi.JSON(strings.NewReader(erc20MetaABI))
-	if err != nil {
-		return 0, err
-	}
-	data, err := ercABI.Pack("decimals")
+	data, err := erc20Meta.Pack("decimals")
 	if err != nil {
 		return 0, err
 	}
@@ -375,9 +391,61 @@ func readERC20Decimals(ctx context.Context, client *ethclient.Client, token comm
 	if err != nil {
 		return 0, err
 	}
-	out, err := ercABI.Unpack("decimals", res)
+	out, err := erc20Meta.Unpack("decimals", res)
 	if err != nil || len(out) == 0 {
 		return 0, err
 	}
 	return int(out[0].(uint8)), nil
 }
+
+// GetTokensDecimals reads the decimals of several tokens in one round trip. Like
+// GetTokenDecimals, tokens whose decimals cannot be read get 18 and the first error is returned.
+func GetTokensDecimals(ctx context.Context, tokenAddrs ...string) ([]int, error) {
+	decimals := make([]int, len(tokenAddrs))
+	calls := make([]multicall.Call, len(tokenAddrs))
+	var firstErr error
+	for i, tokenAddr := range tokenAddrs {
+		decimals[i] = 18
+		if !common.IsHexAddress(tokenAddr) {
+			firstErr = cmp.Or(firstErr, errors.New("invalid token address"))
+		}
+		calls[i], _ = multicall.NewCall(erc20Meta, common.HexToAddress(tokenAddr), "decimals")
+	}
+	results, err := multicall.Aggregate(ctx, websocket.GetEthClient(), calls)
+	if err != nil {
+		return decimals, err
+	}
+	for i, result := range results {
+		out, err := result.Unpack(erc20Meta, "decimals")
+		if err != nil {
+			firstErr = cmp.Or(firstErr, err)
+			continue
+		}
+		decimals[i] = int(out[0].(uint8))
+	}
+	return decimals, firstErr
+}
+
+// GetTokenSupply returns the total supply of a token in base units and its decimals, read in one
+// round trip.
+func GetTokenSupply(ctx context.Context, tokenAddr string) (*big.Int, int, error) {
+	if !common.IsHexAddress(tokenAddr) {
+		return nil, 0, errors.New("invalid token address")
+	}
+	token := common.HexToAddress(tokenAddr)
+	totalSupplyCall, _ := multicall.NewCall(erc20Meta, token, "totalSupply")
+	decimalsCall, _ := multicall.NewCall(erc20Meta, token, "decimals")
+	results, err := multicall.Aggregate(ctx, websocket.GetEthClient(), []multicall.Call{totalSupplyCall, decimalsCall})
+	if err != nil {
+		return nil, 0, err
+	}
+	totalSupply, err := results[0].Unpack(erc20Meta, "totalSupply")
+	if err != nil {
+		return nil, 0, err
+	}
+	decimals, err := results[1].Unpack(erc20Meta, "decimals")
+	if err != nil {
+		return nil, 0, err
+	}
+	return totalSupply[0].(*big.Int), int(decimals[0].(uint8)), nil
+}
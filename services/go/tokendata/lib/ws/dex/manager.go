@@ -2,13 +2,19 @@ package wsDex
 
 import (
 	"context"
+	"errors"
 	"log"
 	"strings"
 	"sync"
+	"time"
 
 	"tokendata/env"
 )
 
+// ErrWatcherLimit is returned when WATCHER_LIMIT watchers run and none of them is of a lower tier
+// than the token to watch.
+var ErrWatcherLimit = errors.New("watcher limit reached")
+
 type PoolResolver func(ctx context.Context, tokenAddr string) (poolAddr string, abiJSON string, err error)
 
 type Manager struct {
@@ -16,7 +22,56 @@ type Manager struct {
 	wssURL   string
 	resolver PoolResolver
 	onSwap   SwapHandler
-	watchers map[string]func() // tokenAddr(lowercased) -> stop()
+	onSupply SupplyChangeHandler
+	watchers map[string]*watcher // tokenAddr(lowercased) -> watcher
+	// limit caps the watchers; 0 leaves them unlimited.
+	limit   int
+	evicted int64
+}
+
+type watcher struct {
+	stop      func()
+	done      <-chan struct{}
+	tier      Tier
+	startedAt time.Time
+	// stopSupply and supplyDone are those of the supply watcher of the token, nil when it does
+	// not run. It fails on its own, without taking the pool watcher down.
+	stopSupply func()
+	supplyDone <-chan struct{}
+}
+
+func (w *watcher) alive() bool {
+	return isOpen(w.done)
+}
+
+func (w *watcher) supplyAlive() bool {
+	return w.supplyDone != nil && isOpen(w.supplyDone)
+}
+
+// close stops the pool watcher and the supply watcher of the token.
+func (w *watcher) close() {
+	w.stop()
+	if w.stopSupply != nil {
+		w.stopSupply()
+	}
+}
+
+func isOpen(done <-chan struct{}) bool {
+	select {
+	case <-done:
+		return false
+	default:
+		return true
+	}
+}
+
+// WatcherStats counts the registered pool watchers; Dead ones lost their subscription and are
+// replaced on the next start for their token. ByTier counts the alive ones per tier.
+type WatcherStats struct {
+	Alive   int
+	Dead    int
+	ByTier  map[Tier]int
+	Evicted int64
 }
 
 type PoolType string
@@ -44,7 +99,8 @@ func GetManager() *Manager {
 	managerOnce.Do(func() {
 		manager = &Manager{
 			wssURL:   env.RpcSocketURL.GetEnv(),
-			watchers: make(map[string]func()),
+			watchers: make(map[string]*watcher),
+			limit:    int(max(env.WATCHER_LIMIT.GetEnvAsNumberOrDefault(0), 0)),
 		}
 	})
 	return manager
@@ -68,29 +124,68 @@ func (m *Manager) SetOnSwapHandler(handler SwapHandler) {
 	m.onSwap = handler
 }
 
+// SetOnSupplyChangeHandler sets the handler of the mints and burns of watched tokens. Tokens
+// are only watched for supply changes while it is set.
+func (m *Manager) SetOnSupplyChangeHandler(handler SupplyChangeHandler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onSupply = handler
+}
+
 func (m *Manager) StopWatching(tokenAddr string) {
 	key := strings.ToLower(tokenAddr)
 	m.mu.Lock()
-	stop, exists := m.watchers[key]
+	w, exists := m.watchers[key]
 	if exists {
 		delete(m.watchers, key)
 	}
 	m.mu.Unlock()
-	if exists && stop != nil {
-		stop()
+	if exists {
+		w.close()
 	}
 }
 
-// StartWatchingForPoolWithHandler starts a watcher for a specific token+pool using a custom handler
-func (m *Manager) StartWatchingForPoolWithHandler(ctx context.Context, tokenAddr string, pairAddress string, isV4 bool, poolAddr string, handler SwapHandler) error {
+// IsWatching reports whether the pool of a token has a watcher whose subscription is still alive.
+func (m *Manager) IsWatching(tokenAddr string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	w, exists := m.watchers[strings.ToLower(tokenAddr)]
+	return exists && w.alive()
+}
+
+func (m *Manager) Stats() WatcherStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stats := WatcherStats{ByTier: map[Tier]int{}, Evicted: m.evicted}
+	for _, w := range m.watchers {
+		if w.alive() {
+			stats.Alive++
+			stats.ByTier[w.tier]++
+		} else {
+			stats.Dead++
+		}
+	}
+	return stats
+}
+
+// StartWatchingForPoolWithHandler starts a watcher for a specific token+pool using a custom
+// handler. At the watcher limit the watcher of a lower tier is evicted to make room, see evictee.
+func (m *Manager) StartWatchingForPoolWithHandler(ctx context.Context, tokenAddr string, pairAddress string, isV4 bool, poolAddr string, tier Tier, handler SwapHandler) error {
 	key := strings.ToLower(tokenAddr)
 
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	wss := m.wssURL
-	if m.watchers[key] != nil {
-		return nil
+	if w, exists := m.watchers[key]; exists {
+		if w.alive() {
+			w.tier = tier
+			m.watchSupply(ctx, key, w)
+			return nil
+		}
+		// The subscription died; replace the watcher.
+		w.close()
+		delete(m.watchers, key)
 	}
 
 	if wss == "" || poolAddr == "" {
@@ -98,9 +193,62 @@ func (m *Manager) StartWatchingForPoolWithHandler(ctx context.Context, tokenAddr
 		return nil
 	}
 
-	stop, err := WatchSwapGenericWithABI(ctx, wss, poolAddr, isV4, tokenAddr, pairAddress, handler, func(e error) { log.Println("wsDex other watcher error:", e) })
-	if err == nil && stop != nil {
-		m.watchers[key] = stop
+	if m.limit > 0 && len(m.watchers) >= m.limit {
+		evicted, ok := evictee(m.watchers, tier)
+		if !ok {
+			return ErrWatcherLimit
+		}
+		victim := m.watchers[evicted]
+		victim.close()
+		delete(m.watchers, evicted)
+		m.evicted++
+		log.Printf("wsDex manager: evicted %s watcher of %s for %s watcher of %s", victim.tier, evicted, tier, key)
+	}
+
+	stop, done, err := WatchSwapGenericWithABI(ctx, wss, poolAddr, isV4, tokenAddr, pairAddress, handler, func(e error) { log.Println("wsDex other watcher error:", e) })
+	if err != nil || stop == nil {
+		return err
+	}
+	w := &watcher{stop: stop, done: done, tier: tier, startedAt: time.Now()}
+	m.watchSupply(ctx, key, w)
+	m.watchers[key] = w
+	return nil
+}
+
+// watchSupply starts the supply watcher of a token unless it runs or no supply change handler is
+// set. A supply watcher that failed is restarted on the next start for its token.
+func (m *Manager) watchSupply(ctx context.Context, key string, w *watcher) {
+	if m.onSupply == nil || w.supplyAlive() {
+		return
+	}
+	if w.stopSupply != nil {
+		w.stopSupply()
+		w.stopSupply, w.supplyDone = nil, nil
+	}
+	stop, done, err := WatchSupplyChanges(ctx, key, m.onSupply)
+	if err != nil {
+		log.Printf("wsDex: could not watch supply of %s: %+v", key, err)
+		return
+	}
+	w.stopSupply, w.supplyDone = stop, done
+}
+
+// evictee picks the watcher to stop for one of tier: a dead one if any, otherwise the oldest of
+// the coldest tier below tier. Watchers of the same tier are never evicted for each other, which
+// would only churn subscriptions.
+func evictee(watchers map[string]*watcher, tier Tier) (string, bool) {
+	var evicted string
+	var candidate *watcher
+	for key, w := range watchers {
+		if !w.alive() {
+			return key, true
+		}
+		if w.tier >= tier {
+			continue
+		}
+		if candidate == nil || w.tier < candidate.tier || (w.tier == candidate.tier && w.startedAt.Before(candidate.startedAt)) {
+			evicted, candidate = key, w
+		}
 	}
-	return err
+	return evicted, candidate != nil
 }
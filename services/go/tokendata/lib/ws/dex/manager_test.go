@@ -0,0 +1,57 @@
+package wsDex
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEvictee(t *testing.T) {
+	now := time.Now()
+	running := func(tier Tier, startedAt time.Time) *watcher {
+		return &watcher{stop: func() {}, done: make(chan struct{}), tier: tier, startedAt: startedAt}
+	}
+	watchers := map[string]*watcher{
+		"hot":        running(TierHot, now.Add(-time.Hour)),
+		"normal":     running(TierNormal, now.Add(-time.Hour)),
+		"cold-new":   running(TierCold, now),
+		"cold-old":   running(TierCold, now.Add(-time.Hour)),
+		"normal-new": running(TierNormal, now),
+	}
+
+	if key, ok := evictee(watchers, TierHot); !ok || key != "cold-old" {
+		t.Errorf("evictee(hot) = %q, %v; want the oldest cold watcher", key, ok)
+	}
+	if key, ok := evictee(watchers, TierCold); ok {
+		t.Errorf("evictee(cold) = %q; want none", key)
+	}
+
+	delete(watchers, "cold-new")
+	delete(watchers, "cold-old")
+	if key, ok := evictee(watchers, TierNormal); ok {
+		t.Errorf("evictee(normal) = %q; want none of the same tier", key)
+	}
+	if key, ok := evictee(watchers, TierHot); !ok || key != "normal" {
+		t.Errorf("evictee(hot) = %q, %v; want the oldest normal watcher", key, ok)
+	}
+
+	done := make(chan struct{})
+	close(done)
+	watchers["dead"] = &watcher{stop: func() {}, done: done, tier: TierHot}
+	if key, ok := evictee(watchers, TierCold); !ok || key != "dead" {
+		t.Errorf("evictee(cold) = %q, %v; want the dead watcher", key, ok)
+	}
+}
+
+func TestWatcherSupplyFailure(t *testing.T) {
+	supplyDone := make(chan struct{})
+	close(supplyDone)
+	stopped := 0
+	w := &watcher{stop: func() { stopped++ }, done: make(chan struct{}), stopSupply: func() { stopped++ }, supplyDone: supplyDone}
+	if !w.alive() || w.supplyAlive() {
+		t.Errorf("alive = %v, supply alive = %v; want the pool watcher alive after a supply failure", w.alive(), w.supplyAlive())
+	}
+	w.close()
+	if stopped != 2 {
+		t.Errorf("stopped %d watchers, want both", stopped)
+	}
+}
@@ -0,0 +1,40 @@
+package wsDex
+
+import (
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// SwapParties are the addresses of a Swap event, lowercased. V3 swaps carry a sender and a
+// recipient; V4 swaps only their sender, the contract that called the pool manager.
+type SwapParties struct {
+	Sender    string
+	Recipient string
+}
+
+// Maker is the address the swap is attributed to: the recipient of the output of a V3 swap, as
+// the sender is usually a router, and the sender of a V4 swap. Swaps routed through a contract
+// are attributed to that contract.
+func (p SwapParties) Maker() string {
+	if p.Recipient != "" {
+		return p.Recipient
+	}
+	return p.Sender
+}
+
+// ParseSwapParties reads the indexed addresses of a Swap log: sender and recipient in
+// topics[1] and topics[2] on V3, and sender in topics[2] after the pool id on V4.
+func ParseSwapParties(vLog types.Log, isV4 bool) SwapParties {
+	if len(vLog.Topics) < 3 {
+		return SwapParties{}
+	}
+	address := func(topic common.Hash) string {
+		return strings.ToLower(common.BytesToAddress(topic.Bytes()).Hex())
+	}
+	if isV4 {
+		return SwapParties{Sender: address(vLog.Topics[2])}
+	}
+	return SwapParties{Sender: address(vLog.Topics[1]), Recipient: address(vLog.Topics[2])}
+}
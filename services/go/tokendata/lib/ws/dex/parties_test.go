@@ -0,0 +1,26 @@
+package wsDex
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestParseSwapParties(t *testing.T) {
+	router := common.BytesToHash(common.HexToAddress("0x2222222222222222222222222222222222222222").Bytes())
+	trader := common.BytesToHash(common.HexToAddress("0x3333333333333333333333333333333333333333").Bytes())
+	poolId := common.HexToHash("0x01")
+
+	v3 := ParseSwapParties(types.Log{Topics: []common.Hash{{}, router, trader}}, false)
+	if v3.Sender != "0x2222222222222222222222222222222222222222" || v3.Maker() != "0x3333333333333333333333333333333333333333" {
+		t.Errorf("v3 parties = %+v", v3)
+	}
+	v4 := ParseSwapParties(types.Log{Topics: []common.Hash{{}, poolId, router}}, true)
+	if v4.Recipient != "" || v4.Maker() != "0x2222222222222222222222222222222222222222" {
+		t.Errorf("v4 parties = %+v", v4)
+	}
+	if parties := ParseSwapParties(types.Log{Topics: []common.Hash{{}}}, false); parties.Maker() != "" {
+		t.Errorf("parties of a log without topics = %+v", parties)
+	}
+}
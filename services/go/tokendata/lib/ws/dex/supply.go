@@ -0,0 +1,94 @@
+package wsDex
+
+import (
+	"context"
+	"log"
+	"math/big"
+	websocket "tokendata/lib/ws"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// transferTopic is the topic of the ERC-20 Transfer(address,address,uint256) event.
+var transferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
+// SupplyChange is a mint or burn of a token. Amount is in base units and negative for burns.
+type SupplyChange struct {
+	TokenAddr   string
+	Amount      *big.Int
+	TxHash      string
+	BlockNumber uint64
+}
+
+type SupplyChangeHandler func(change SupplyChange)
+
+// parseSupplyChange reads a Transfer log from or to the zero address. Other transfers, and the
+// four topic Transfer events of ERC-721 tokens, are not supply changes.
+func parseSupplyChange(vLog types.Log) (SupplyChange, bool) {
+	if len(vLog.Topics) != 3 || vLog.Topics[0] != transferTopic || len(vLog.Data) != 32 {
+		return SupplyChange{}, false
+	}
+	from, to := common.BytesToAddress(vLog.Topics[1].Bytes()), common.BytesToAddress(vLog.Topics[2].Bytes())
+	amount := new(big.Int).SetBytes(vLog.Data)
+	switch {
+	case from == to:
+		return SupplyChange{}, false
+	case to == (common.Address{}):
+		amount.Neg(amount)
+	case from != (common.Address{}):
+		return SupplyChange{}, false
+	}
+	return SupplyChange{
+		TokenAddr:   vLog.Address.Hex(),
+		Amount:      amount,
+		TxHash:      vLog.TxHash.Hex(),
+		BlockNumber: vLog.BlockNumber,
+	}, true
+}
+
+// WatchSupplyChanges subscribes to the mints and burns of a token, its transfers from and to the
+// zero address. A single Transfer filter is shared by both, and the other transfers are skipped.
+// done is closed once the watcher exits, after stop or when the subscription fails.
+func WatchSupplyChanges(ctx context.Context, tokenAddr string, onChange SupplyChangeHandler) (stop func(), done <-chan struct{}, err error) {
+	token := []common.Address{common.HexToAddress(tokenAddr)}
+	client := websocket.GetEthClient()
+
+	logsCh := make(chan types.Log)
+	transfers, err := client.SubscribeFilterLogs(ctx, ethereumFilterQuery(token, [][]common.Hash{{transferTopic}}), logsCh)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctxInner, cancel := context.WithCancel(ctx)
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("wsDex supply goroutine panic: %v", r)
+			}
+		}()
+
+		for {
+			select {
+			case <-ctxInner.Done():
+				return
+			case err := <-transfers.Err():
+				log.Printf("wsDex transfer subscription error for %s: %+v", tokenAddr, err)
+				return
+			case vLog := <-logsCh:
+				change, ok := parseSupplyChange(vLog)
+				if ok && !vLog.Removed && onChange != nil {
+					onChange(change)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		transfers.Unsubscribe()
+	}, exited, nil
+}
@@ -0,0 +1,44 @@
+package wsDex
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestParseSupplyChange(t *testing.T) {
+	token := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	holder := common.BytesToHash(common.HexToAddress("0x2222222222222222222222222222222222222222").Bytes())
+	zero := common.Hash{}
+	transfer := func(from, to common.Hash, topics ...common.Hash) types.Log {
+		return types.Log{
+			Address: token,
+			Topics:  append([]common.Hash{transferTopic, from, to}, topics...),
+			Data:    common.LeftPadBytes(big.NewInt(500).Bytes(), 32),
+		}
+	}
+
+	tests := []struct {
+		name   string
+		log    types.Log
+		amount int64
+		ok     bool
+	}{
+		{"mint", transfer(zero, holder), 500, true},
+		{"burn", transfer(holder, zero), -500, true},
+		{"transfer", transfer(holder, holder), 0, false},
+		{"erc721 mint", transfer(zero, holder, common.BigToHash(big.NewInt(1))), 0, false},
+	}
+	for _, test := range tests {
+		change, ok := parseSupplyChange(test.log)
+		if ok != test.ok {
+			t.Errorf("%s: ok = %v, want %v", test.name, ok, test.ok)
+			continue
+		}
+		if ok && change.Amount.Int64() != test.amount {
+			t.Errorf("%s: amount = %s, want %d", test.name, change.Amount, test.amount)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package wsDex
+
+// Tier is how much the freshness of a token matters. Hot tokens, such as blue chips held by
+// wallets, get their pool watched first and their price refreshed most often; cold tokens, such
+// as the backlog of launches nobody holds, are the first to lose their watcher.
+type Tier int
+
+const (
+	TierCold Tier = iota
+	TierNormal
+	TierHot
+)
+
+func (t Tier) String() string {
+	switch t {
+	case TierHot:
+		return "hot"
+	case TierCold:
+		return "cold"
+	default:
+		return "normal"
+	}
+}
+
+// Tiers lists the tiers from hottest to coldest.
+var Tiers = []Tier{TierHot, TierNormal, TierCold}
@@ -2,22 +2,23 @@ package factory
 
 import (
 	"context"
+	"errors"
 	"log"
+	"samterminal/pkg/chain"
+	"samterminal/pkg/multicall"
 	"strings"
 	"sync"
 	"time"
+	"tokendata/lib/anchors"
 	websocket "tokendata/lib/ws"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/ethclient"
 )
 
 const (
-	bankrFactoryAddress = "0x660eaaedebc968f8f3694354fa8ec0b4c5ba8d12"
-
 	// Create(address indexed pairToken, address token, address locker, address token2)
 	bankrCreateEventABI = `[{
 		"anonymous": false,
@@ -31,6 +32,23 @@ const (
 		"type": "event"
 	}]`
 
+	// Initialize of the Uniswap V4 PoolManager, emitted when the factory creates the pool of a token.
+	poolInitializeEventABI = `[{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true,  "name": "id",           "type": "bytes32"},
+			{"indexed": true,  "name": "currency0",    "type": "address"},
+			{"indexed": true,  "name": "currency1",    "type": "address"},
+			{"indexed": false, "name": "fee",          "type": "uint24"},
+			{"indexed": false, "name": "tickSpacing",  "type": "int24"},
+			{"indexed": false, "name": "hooks",        "type": "address"},
+			{"indexed": false, "name": "sqrtPriceX96", "type": "uint160"},
+			{"indexed": false, "name": "tick",         "type": "int24"}
+		],
+		"name": "Initialize",
+		"type": "event"
+	}]`
+
 	erc20NameSymbolABI = `[
 		{"inputs":[],"name":"name","outputs":[{"internalType":"string","name":"","type":"string"}],"stateMutability":"view","type":"function"},
 		{"inputs":[],"name":"symbol","outputs":[{"internalType":"string","name":"","type":"string"}],"stateMutability":"view","type":"function"}
@@ -39,14 +57,27 @@ const (
 
 // Parsed ABIs — cached at init, never re-parsed.
 var (
-	parsedCreateABI    abi.ABI
-	parsedERC20ABI     abi.ABI
-	createEventID      common.Hash
+	parsedCreateABI   abi.ABI
+	parsedERC20ABI    abi.ABI
+	createEventID     common.Hash
+	initializeEventID common.Hash
 )
 
+// BankrCreateEvent is a token deployed by the Bankr factory. PairToken, Locker and Token2 are
+// the fields of the Create log as emitted. PairAddress is the token the created pool pairs the
+// token with and PoolAddress the id of that pool; when the pool is not found, PoolAddress is
+// empty and PairAddress is read from the log alone.
 type BankrCreateEvent struct {
 	TokenAddress string
 	PairAddress  string
+	PoolAddress  string
+	PairToken    string
+	Locker       string
+	Token2       string
+	TxHash       string
+	BlockNumber  uint64
+	// Time of the block, or when the log was received when the node leaves it out of logs.
+	DeployedAt time.Time
 }
 
 type createEventData struct {
@@ -61,11 +92,7 @@ type ERC20Meta struct {
 	Symbol string
 }
 
-var client *ethclient.Client
-
 func init() {
-	client = websocket.GetEthClient()
-
 	var err error
 	parsedCreateABI, err = abi.JSON(strings.NewReader(bankrCreateEventABI))
 	if err != nil {
@@ -73,22 +100,46 @@ func init() {
 	}
 	createEventID = parsedCreateABI.Events["Create"].ID
 
+	parsedInitializeABI, err := abi.JSON(strings.NewReader(poolInitializeEventABI))
+	if err != nil {
+		log.Fatalf("factory: failed to parse Initialize ABI: %v", err)
+	}
+	initializeEventID = parsedInitializeABI.Events["Initialize"].ID
+
 	parsedERC20ABI, err = abi.JSON(strings.NewReader(erc20NameSymbolABI))
 	if err != nil {
 		log.Fatalf("factory: failed to parse ERC20 ABI: %v", err)
 	}
 }
 
+const (
+	// bankrPoolWorkers is how many deployments have their receipt read for the created pool at
+	// once, off the subscription loop.
+	bankrPoolWorkers = 4
+	// bankrPoolQueue is how many decoded deployments wait for a worker before the subscription
+	// loop waits too.
+	bankrPoolQueue = 64
+)
+
+// transactionReceipt reads the receipt of a transaction; tests replace it.
+var transactionReceipt = func(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return websocket.GetEthClient().TransactionReceipt(ctx, txHash)
+}
+
 // SubscribeBankrFactory subscribes to Create events from the Bankr factory contract
 // and sends decoded events to the provided channel. It automatically reconnects
 // on subscription errors with exponential backoff.
 func SubscribeBankrFactory(ctx context.Context, ch chan<- BankrCreateEvent) {
+	created := make(chan BankrCreateEvent, bankrPoolQueue)
+	for range bankrPoolWorkers {
+		go resolveCreatedPools(ctx, created, ch)
+	}
 	go func() {
 		backoff := 2 * time.Second
 		maxBackoff := 60 * time.Second
 
 		for {
-			err := subscribeBankrOnce(ctx, ch)
+			err := subscribeBankrOnce(ctx, created)
 			if ctx.Err() != nil {
 				return // context cancelled, shut down
 			}
@@ -113,14 +164,14 @@ func SubscribeBankrFactory(ctx context.Context, ch chan<- BankrCreateEvent) {
 }
 
 func subscribeBankrOnce(ctx context.Context, ch chan<- BankrCreateEvent) error {
-	factory := common.HexToAddress(bankrFactoryAddress)
+	factory := common.HexToAddress(chain.Get().BankrFactory)
 	query := ethereum.FilterQuery{
 		Addresses: []common.Address{factory},
 		Topics:    [][]common.Hash{{createEventID}},
 	}
 
 	logsCh := make(chan types.Log)
-	sub, err := client.SubscribeFilterLogs(ctx, query, logsCh)
+	sub, err := websocket.GetEthClient().SubscribeFilterLogs(ctx, query, logsCh)
 	if err != nil {
 		return err
 	}
@@ -133,23 +184,154 @@ func subscribeBankrOnce(ctx context.Context, ch chan<- BankrCreateEvent) error {
 		case err := <-sub.Err():
 			return err
 		case vLog := <-logsCh:
-			var ev createEventData
-			if err := parsedCreateABI.UnpackIntoInterface(&ev, "Create", vLog.Data); err != nil {
+			ev, err := parseCreateLog(vLog)
+			if err != nil {
 				log.Printf("Bankr factory: unpack error: %v", err)
 				continue
 			}
-			pairAddr := common.HexToAddress(vLog.Topics[1].Hex()).Hex()
+			if ev.DeployedAt.IsZero() {
+				ev.DeployedAt = time.Now()
+			}
+			select {
+			case ch <- ev:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	}
+}
 
-			ch <- BankrCreateEvent{
-				TokenAddress: strings.ToLower(ev.Token.Hex()),
-				PairAddress:  strings.ToLower(pairAddr),
+// resolveCreatedPools finds the pools of the deployments from created and sends them on to ch.
+// Receipts are read here rather than in the subscription loop, which would stop reading logs
+// for as long as a receipt takes.
+func resolveCreatedPools(ctx context.Context, created <-chan BankrCreateEvent, ch chan<- BankrCreateEvent) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev := <-created:
+			if !findCreatedPool(ctx, &ev) {
+				log.Printf("Bankr factory: no pool created for %s in %s, pair %s taken from the log", ev.TokenAddress, ev.TxHash, ev.PairAddress)
+			}
+			select {
+			case ch <- ev:
+			case <-ctx.Done():
+				return
 			}
 		}
 	}
 }
 
-// BatchReadERC20Meta reads name() and symbol() for multiple tokens concurrently.
+// parseCreateLog decodes a Create log of the Bankr factory. The pair is the pairToken topic,
+// or token2 when the topic is the token itself or empty, until the created pool is found.
+func parseCreateLog(vLog types.Log) (BankrCreateEvent, error) {
+	if len(vLog.Topics) < 2 || vLog.Topics[0] != createEventID {
+		return BankrCreateEvent{}, errors.New("not a Create log")
+	}
+	var data createEventData
+	if err := parsedCreateABI.UnpackIntoInterface(&data, "Create", vLog.Data); err != nil {
+		return BankrCreateEvent{}, err
+	}
+	ev := BankrCreateEvent{
+		TokenAddress: strings.ToLower(data.Token.Hex()),
+		PairToken:    strings.ToLower(common.BytesToAddress(vLog.Topics[1].Bytes()).Hex()),
+		Locker:       strings.ToLower(data.Locker.Hex()),
+		Token2:       strings.ToLower(data.Token2.Hex()),
+		TxHash:       vLog.TxHash.Hex(),
+		BlockNumber:  vLog.BlockNumber,
+	}
+	if vLog.BlockTimestamp > 0 {
+		ev.DeployedAt = time.Unix(int64(vLog.BlockTimestamp), 0)
+	}
+	zero := strings.ToLower(common.Address{}.Hex())
+	for _, candidate := range []string{ev.PairToken, ev.Token2} {
+		if candidate != zero && candidate != ev.TokenAddress {
+			ev.PairAddress = candidate
+			break
+		}
+	}
+	return ev, nil
+}
+
+// findCreatedPool reads the receipt of the deployment for the pool the factory created and
+// takes the pair and the pool id from it.
+func findCreatedPool(ctx context.Context, ev *BankrCreateEvent) bool {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	receipt, err := transactionReceipt(ctx, common.HexToHash(ev.TxHash))
+	if err != nil {
+		log.Printf("Bankr factory: failed to read the receipt of %s: %v", ev.TxHash, err)
+		return false
+	}
+	return setCreatedPool(ev, receipt.Logs)
+}
+
+// setCreatedPool sets the pool of the token from the PoolManager Initialize logs of its
+// deployment. The other currency of the pool is the pair; native ETH, the zero address in V4,
+// is priced as the wrapped native token.
+func setCreatedPool(ev *BankrCreateEvent, logs []*types.Log) bool {
+	poolManager := common.HexToAddress(chain.Get().PoolManager)
+	token := common.HexToAddress(ev.TokenAddress)
+	for _, vLog := range logs {
+		if vLog.Address != poolManager || len(vLog.Topics) < 4 || vLog.Topics[0] != initializeEventID {
+			continue
+		}
+		currency0 := common.BytesToAddress(vLog.Topics[2].Bytes())
+		currency1 := common.BytesToAddress(vLog.Topics[3].Bytes())
+		var pair common.Address
+		switch token {
+		case currency0:
+			pair = currency1
+		case currency1:
+			pair = currency0
+		default:
+			continue
+		}
+		ev.PoolAddress = strings.ToLower(vLog.Topics[1].Hex())
+		ev.PairAddress = strings.ToLower(pair.Hex())
+		if pair == (common.Address{}) {
+			ev.PairAddress = anchors.Native().Address
+		}
+		return true
+	}
+	return false
+}
+
+// BatchReadERC20Meta reads name() and symbol() for multiple tokens in one Multicall3 round trip,
+// falling back to concurrent calls per token when the multicall fails.
 func BatchReadERC20Meta(ctx context.Context, addresses []string) map[string]ERC20Meta {
+	calls := make([]multicall.Call, 0, 2*len(addresses))
+	for _, addr := range addresses {
+		target := common.HexToAddress(addr)
+		nameCall, _ := multicall.NewCall(parsedERC20ABI, target, "name")
+		symbolCall, _ := multicall.NewCall(parsedERC20ABI, target, "symbol")
+		calls = append(calls, nameCall, symbolCall)
+	}
+	batch, err := multicall.Aggregate(ctx, websocket.GetEthClient(), calls)
+	if err != nil {
+		log.Printf("factory: multicall of token metadata failed, reading tokens one by one: %v", err)
+		return readERC20MetaConcurrently(ctx, addresses)
+	}
+	results := make(map[string]ERC20Meta, len(addresses))
+	for i, addr := range addresses {
+		results[addr] = ERC20Meta{
+			Name:   unpackERC20String(batch[2*i], "name"),
+			Symbol: unpackERC20String(batch[2*i+1], "symbol"),
+		}
+	}
+	return results
+}
+
+func unpackERC20String(result multicall.Result, method string) string {
+	out, err := result.Unpack(parsedERC20ABI, method)
+	if err != nil || len(out) == 0 {
+		return ""
+	}
+	s, _ := out[0].(string)
+	return s
+}
+
+func readERC20MetaConcurrently(ctx context.Context, addresses []string) map[string]ERC20Meta {
 	results := make(map[string]ERC20Meta, len(addresses))
 	var mu sync.Mutex
 	var wg sync.WaitGroup
@@ -176,7 +358,7 @@ func readERC20String(ctx context.Context, tokenAddr string, method string) strin
 		return ""
 	}
 	addr := common.HexToAddress(tokenAddr)
-	res, err := client.CallContract(ctx, ethereum.CallMsg{To: &addr, Data: data}, nil)
+	res, err := websocket.GetEthClient().CallContract(ctx, ethereum.CallMsg{To: &addr, Data: data}, nil)
 	if err != nil {
 		return ""
 	}
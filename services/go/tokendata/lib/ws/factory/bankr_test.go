@@ -0,0 +1,124 @@
+package factory
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Logs of Bankr deployments: a token launched against WETH, whose pool pairs it with native ETH,
+// and one whose pairToken topic is the token itself and whose pool pairs it with token2. The
+// factory and PoolManager addresses and the event topics are those of Base; the token, locker,
+// pool ids and transaction are placeholders until logs of real deployments replace them.
+const (
+	wethCreateLog       = `{"address":"0x660eaaedebc968f8f3694354fa8ec0b4c5ba8d12","topics":["0x68ff1cfcdcf76864161555fc0de1878d8f83ec6949bf351df74d8a4a1a2679ab","0x0000000000000000000000004200000000000000000000000000000000000006"],"data":"0x00000000000000000000000022af33fe49fd1fa80c7149773dde5890d3c76f3b0000000000000000000000004d8a2f1e6b3c9a7d0e5f8b2c1a4d7e0f3b6c9a2d0000000000000000000000000000000000000000000000000000000000000000","blockNumber":"0x148bfa6","transactionHash":"0x5c1e3a0d9f6b2e4c7a8d1f0e3b6c9a2d5e8f1b4c7a0d3e6f9b2c5a8d1e4f7b0c","transactionIndex":"0x0","blockHash":"0x000000000000000000000000000000000000000000000000000000000000009a","logIndex":"0x7","removed":false}`
+	nativeInitializeLog = `{"address":"0x498581ff718922c3f8e6a244956af099b2652b2b","topics":["0xdd466e674ea557f56295e2d0218a125ea4b4f0f6f3307b95f85e6110838d6438","0xe7a3c6f0d2b5a8e1c4f7b0d3a6e9c2f5b8d1a4e7c0f3b6d9a2e5c8f1b4d7a0e3","0x0000000000000000000000000000000000000000000000000000000000000000","0x00000000000000000000000022af33fe49fd1fa80c7149773dde5890d3c76f3b"],"data":"0x000000000000000000000000000000000000000000000000000000000080000000000000000000000000000000000000000000000000000000000000000000c8000000000000000000000000bb7784a4d481184283ed89619a3e3ed143e1adc00000000000000000000000000000000000000000000100000000000000000000fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffcfa90","blockNumber":"0x148bfa6","transactionHash":"0x5c1e3a0d9f6b2e4c7a8d1f0e3b6c9a2d5e8f1b4c7a0d3e6f9b2c5a8d1e4f7b0c","transactionIndex":"0x0","blockHash":"0x000000000000000000000000000000000000000000000000000000000000009a","logIndex":"0x5","removed":false}`
+
+	token2CreateLog     = `{"address":"0x660eaaedebc968f8f3694354fa8ec0b4c5ba8d12","topics":["0x68ff1cfcdcf76864161555fc0de1878d8f83ec6949bf351df74d8a4a1a2679ab","0x00000000000000000000000022af33fe49fd1fa80c7149773dde5890d3c76f3b"],"data":"0x00000000000000000000000022af33fe49fd1fa80c7149773dde5890d3c76f3b0000000000000000000000004d8a2f1e6b3c9a7d0e5f8b2c1a4d7e0f3b6c9a2d0000000000000000000000001bc0c42215582d5a085795f4badbac3ff36d1bcb","blockNumber":"0x148bfa6","transactionHash":"0x5c1e3a0d9f6b2e4c7a8d1f0e3b6c9a2d5e8f1b4c7a0d3e6f9b2c5a8d1e4f7b0c","transactionIndex":"0x0","blockHash":"0x000000000000000000000000000000000000000000000000000000000000009a","logIndex":"0x7","removed":false}`
+	token2InitializeLog = `{"address":"0x498581ff718922c3f8e6a244956af099b2652b2b","topics":["0xdd466e674ea557f56295e2d0218a125ea4b4f0f6f3307b95f85e6110838d6438","0x0b8e1d4a7c0f3e6b9d2a5c8f1e4b7d0a3c6f9e2b5d8a1c4f7e0b3d6a9c2f5e8b","0x0000000000000000000000001bc0c42215582d5a085795f4badbac3ff36d1bcb","0x00000000000000000000000022af33fe49fd1fa80c7149773dde5890d3c76f3b"],"data":"0x000000000000000000000000000000000000000000000000000000000080000000000000000000000000000000000000000000000000000000000000000000c8000000000000000000000000bb7784a4d481184283ed89619a3e3ed143e1adc00000000000000000000000000000000000000000000100000000000000000000fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffcfa90","blockNumber":"0x148bfa6","transactionHash":"0x5c1e3a0d9f6b2e4c7a8d1f0e3b6c9a2d5e8f1b4c7a0d3e6f9b2c5a8d1e4f7b0c","transactionIndex":"0x0","blockHash":"0x000000000000000000000000000000000000000000000000000000000000009a","logIndex":"0x5","removed":false}`
+	// An Initialize log of the same shape from a contract other than the PoolManager.
+	foreignInitializeLog = `{"address":"0x1111111111111111111111111111111111111111","topics":["0xdd466e674ea557f56295e2d0218a125ea4b4f0f6f3307b95f85e6110838d6438","0x0000000000000000000000000000000000000000000000000000000000000b8e","0x0000000000000000000000001bc0c42215582d5a085795f4badbac3ff36d1bcb","0x00000000000000000000000022af33fe49fd1fa80c7149773dde5890d3c76f3b"],"data":"0x000000000000000000000000000000000000000000000000000000000080000000000000000000000000000000000000000000000000000000000000000000c8000000000000000000000000bb7784a4d481184283ed89619a3e3ed143e1adc00000000000000000000000000000000000000000000100000000000000000000fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffcfa90","blockNumber":"0x148bfa6","transactionHash":"0x5c1e3a0d9f6b2e4c7a8d1f0e3b6c9a2d5e8f1b4c7a0d3e6f9b2c5a8d1e4f7b0c","transactionIndex":"0x0","blockHash":"0x000000000000000000000000000000000000000000000000000000000000009a","logIndex":"0x4","removed":false}`
+
+	bankrToken = "0x22af33fe49fd1fa80c7149773dde5890d3c76f3b"
+	bankrPair  = "0x1bc0c42215582d5a085795f4badbac3ff36d1bcb"
+	weth       = "0x4200000000000000000000000000000000000006"
+)
+
+func decodeLog(t *testing.T, raw string) *types.Log {
+	t.Helper()
+	var vLog types.Log
+	if err := json.Unmarshal([]byte(raw), &vLog); err != nil {
+		t.Fatal(err)
+	}
+	return &vLog
+}
+
+func TestParseCreateLog(t *testing.T) {
+	ev, err := parseCreateLog(*decodeLog(t, wethCreateLog))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := BankrCreateEvent{
+		TokenAddress: bankrToken,
+		PairAddress:  weth,
+		PairToken:    weth,
+		Locker:       "0x4d8a2f1e6b3c9a7d0e5f8b2c1a4d7e0f3b6c9a2d",
+		Token2:       "0x0000000000000000000000000000000000000000",
+		TxHash:       "0x5c1e3a0d9f6b2e4c7a8d1f0e3b6c9a2d5e8f1b4c7a0d3e6f9b2c5a8d1e4f7b0c",
+		BlockNumber:  21544870,
+	}
+	if ev != want {
+		t.Errorf("event = %+v, want %+v", ev, want)
+	}
+
+	ev, err = parseCreateLog(*decodeLog(t, token2CreateLog))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ev.PairToken != bankrToken || ev.Token2 != bankrPair || ev.PairAddress != bankrPair {
+		t.Errorf("event = %+v, want token2 as the pair when pairToken is the token", ev)
+	}
+
+	if _, err := parseCreateLog(*decodeLog(t, nativeInitializeLog)); err == nil {
+		t.Error("Initialize log parsed as a Create log")
+	}
+}
+
+func TestSetCreatedPool(t *testing.T) {
+	ev, _ := parseCreateLog(*decodeLog(t, wethCreateLog))
+	if !setCreatedPool(&ev, []*types.Log{decodeLog(t, wethCreateLog), decodeLog(t, nativeInitializeLog)}) {
+		t.Fatal("native ETH pool not found")
+	}
+	if ev.PoolAddress != "0xe7a3c6f0d2b5a8e1c4f7b0d3a6e9c2f5b8d1a4e7c0f3b6d9a2e5c8f1b4d7a0e3" || ev.PairAddress != weth {
+		t.Errorf("pool = %s, pair = %s, want the native pool priced as WETH", ev.PoolAddress, ev.PairAddress)
+	}
+
+	ev, _ = parseCreateLog(*decodeLog(t, token2CreateLog))
+	logs := []*types.Log{decodeLog(t, foreignInitializeLog), decodeLog(t, token2InitializeLog), decodeLog(t, token2CreateLog)}
+	if !setCreatedPool(&ev, logs) {
+		t.Fatal("token2 pool not found")
+	}
+	if ev.PoolAddress != "0x0b8e1d4a7c0f3e6b9d2a5c8f1e4b7d0a3c6f9e2b5d8a1c4f7e0b3d6a9c2f5e8b" || ev.PairAddress != bankrPair {
+		t.Errorf("pool = %s, pair = %s, want the PoolManager pool", ev.PoolAddress, ev.PairAddress)
+	}
+
+	ev, _ = parseCreateLog(*decodeLog(t, wethCreateLog))
+	if setCreatedPool(&ev, []*types.Log{decodeLog(t, foreignInitializeLog)}) || ev.PoolAddress != "" || ev.PairAddress != weth {
+		t.Errorf("event = %+v, want the pair of the log without a pool", ev)
+	}
+}
+
+func TestResolveCreatedPools(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	blocked := make(chan struct{})
+	previous := transactionReceipt
+	transactionReceipt = func(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+		if txHash == (common.Hash{}) {
+			<-blocked
+		}
+		return &types.Receipt{Logs: []*types.Log{decodeLog(t, nativeInitializeLog)}}, nil
+	}
+	defer func() { transactionReceipt = previous }()
+
+	created, resolved := make(chan BankrCreateEvent, 2), make(chan BankrCreateEvent)
+	go resolveCreatedPools(ctx, created, resolved)
+	go resolveCreatedPools(ctx, created, resolved)
+	slow, _ := parseCreateLog(*decodeLog(t, wethCreateLog))
+	slow.TxHash = common.Hash{}.Hex()
+	ev, _ := parseCreateLog(*decodeLog(t, wethCreateLog))
+	created <- slow
+	created <- ev
+
+	// The deployment whose receipt is slow holds up its worker only.
+	if got := <-resolved; got.TxHash != ev.TxHash || got.PoolAddress == "" {
+		t.Errorf("resolved = %+v, want the pool of the deployment with a receipt", got)
+	}
+	close(blocked)
+	if got := <-resolved; got.TxHash != slow.TxHash {
+		t.Errorf("resolved = %+v, want the slow deployment after its receipt", got)
+	}
+}
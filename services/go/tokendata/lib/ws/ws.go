@@ -3,26 +3,31 @@ package websocket
 import (
 	"context"
 	"log"
+	"sync"
 	"tokendata/env"
 
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
-var etclient *ethclient.Client
-
-func init() {
-	var err error
-	for attempt := 1; attempt <= 3; attempt++ {
-		etclient, err = ethclient.DialContext(context.Background(), env.RpcSocketURL.GetEnv())
-		if err == nil {
-			return
-		}
-		log.Printf("ws.go init: attempt %d failed to connect: %v", attempt, err)
-	}
-	log.Fatalf("ws.go init: failed to connect after 3 attempts: %v", err)
-}
+var (
+	etclient *ethclient.Client
+	dialOnce sync.Once
+)
 
+// GetEthClient returns the RPC client, connecting on first use so that packages using it can be
+// imported without a node, e.g. in unit tests.
 func GetEthClient() *ethclient.Client {
+	dialOnce.Do(func() {
+		var err error
+		for attempt := 1; attempt <= 3; attempt++ {
+			etclient, err = ethclient.DialContext(context.Background(), env.RpcSocketURL.GetEnv())
+			if err == nil {
+				return
+			}
+			log.Printf("ws.go: attempt %d failed to connect: %v", attempt, err)
+		}
+		log.Fatalf("ws.go: failed to connect after 3 attempts: %v", err)
+	})
 	return etclient
 }
 
@@ -4,14 +4,16 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"samterminal/pkg/config"
+	"samterminal/pkg/telemetry"
 	"syscall"
-	"time"
 	"tokendata/cron"
 	"tokendata/database"
 	tokenRepository "tokendata/database/repositories/token"
 	"tokendata/env"
 	"tokendata/lib/dex/grpc"
 	"tokendata/lib/dex/httpserver"
+	"tokendata/lib/gas"
 )
 
 func init() {
@@ -19,11 +21,14 @@ func init() {
 }
 
 func main() {
+	defer telemetry.Init("tokendata")()
 	database.InitDatabase()
 	go cron.StartCron()
 	defer database.DisconnectFromDB()
 
 	tokenRepository.SaveNecessaryTokens()
+	tokenRepository.StartTokenReadModel()
+	tokenRepository.StartUsageSync()
 
 	go grpc.StartServer()
 	go httpserver.Start(env.PORT.GetEnvAsNumber(), env.HTTP_PORT.GetEnvAsNumber())
@@ -34,8 +39,26 @@ func main() {
 		}
 	}()
 
-	go cron.StartClankerPoller(5 * time.Second)
-	go cron.StartBankrListener(5 * time.Second)
+	go tokenRepository.StartBlacklistSync()
+	go cron.StartClankerPoller()
+	go cron.StartBankrListener()
+	go cron.StartDiscoveryConsumers()
+	go gas.Start()
+
+	// SIGHUP reloads the env file: rotated API keys and ALLOWED_ORIGINS apply without dropping
+	// the watchers a restart would. In Docker only ENV_FILE can be reloaded.
+	reloadCh := make(chan os.Signal, 1)
+	signal.Notify(reloadCh, syscall.SIGHUP)
+	go func() {
+		for range reloadCh {
+			if !config.Reloadable() {
+				log.Println("SIGHUP received, not reloading env: DOCKER=true without ENV_FILE, restart the container instead")
+				continue
+			}
+			log.Println("SIGHUP received, reloading env")
+			env.LoadEnv(".env")
+		}
+	}()
 
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
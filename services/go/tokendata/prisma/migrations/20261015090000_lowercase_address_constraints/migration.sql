@@ -0,0 +1,28 @@
+-- Normalize existing rows: keep one row per lowercased address, preferring the
+-- row that is already lowercase and then the most recently updated one.
+DELETE FROM "Token"
+WHERE "id" IN (
+    SELECT "id" FROM (
+        SELECT "id", ROW_NUMBER() OVER (
+            PARTITION BY lower("address")
+            ORDER BY ("address" = lower("address")) DESC, "lastUpdatedAt" DESC
+        ) AS rn
+        FROM "Token"
+    ) ranked
+    WHERE ranked.rn > 1
+);
+
+UPDATE "Token" SET "address" = lower("address") WHERE "address" <> lower("address");
+UPDATE "Token" SET "pairAddress" = lower("pairAddress") WHERE "pairAddress" <> lower("pairAddress");
+
+-- AddCheckConstraint
+ALTER TABLE "Token" ADD CONSTRAINT "Token_address_lowercase" CHECK ("address" = lower("address"));
+
+-- CreateIndex
+CREATE INDEX "Token_reason_idx" ON "Token"("reason");
+
+-- CreateIndex
+CREATE INDEX "Token_lastUsedAt_idx" ON "Token"("lastUsedAt");
+
+-- CreateIndex
+CREATE INDEX "Token_price_idx" ON "Token"("price");
@@ -0,0 +1,6 @@
+-- AlterTable
+ALTER TABLE "Token" ADD COLUMN     "archived" BOOLEAN NOT NULL DEFAULT false,
+ADD COLUMN     "archivedAt" TIMESTAMP(3);
+
+-- CreateIndex
+CREATE INDEX "Token_archived_archivedAt_idx" ON "Token"("archived", "archivedAt");
@@ -0,0 +1,2 @@
+-- AlterTable
+ALTER TABLE "Token" ADD COLUMN     "minSwapUSD" DOUBLE PRECISION;
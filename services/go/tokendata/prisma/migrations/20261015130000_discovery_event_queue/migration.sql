@@ -0,0 +1,27 @@
+-- CreateEnum
+CREATE TYPE "DiscoveryEventStatus" AS ENUM ('PENDING', 'PROCESSING', 'DONE', 'FAILED');
+
+-- CreateTable
+CREATE TABLE "DiscoveryEvent" (
+    "id" TEXT NOT NULL,
+    "source" TEXT NOT NULL,
+    "tokenAddress" TEXT NOT NULL,
+    "pairAddress" TEXT,
+    "poolAddress" TEXT,
+    "poolType" "DexPoolType",
+    "name" TEXT,
+    "symbol" TEXT,
+    "imageURL" TEXT,
+    "status" "DiscoveryEventStatus" NOT NULL DEFAULT 'PENDING',
+    "attempts" INTEGER NOT NULL DEFAULT 0,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL,
+
+    CONSTRAINT "DiscoveryEvent_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE INDEX "DiscoveryEvent_status_createdAt_idx" ON "DiscoveryEvent"("status", "createdAt");
+
+-- CreateIndex
+CREATE UNIQUE INDEX "DiscoveryEvent_source_tokenAddress_key" ON "DiscoveryEvent"("source", "tokenAddress");
@@ -0,0 +1,6 @@
+-- AlterTable
+ALTER TABLE "Token" ADD COLUMN     "description" TEXT,
+ADD COLUMN     "headerImageURL" TEXT,
+ADD COLUMN     "telegram" TEXT,
+ADD COLUMN     "twitter" TEXT,
+ADD COLUMN     "website" TEXT;
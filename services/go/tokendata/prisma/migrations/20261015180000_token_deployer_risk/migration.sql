@@ -0,0 +1,10 @@
+-- AlterTable
+ALTER TABLE "Token" ADD COLUMN     "deployerAddress" TEXT,
+ADD COLUMN     "deployerCheckedAt" TIMESTAMP(3),
+ADD COLUMN     "deployerFirstSeenAt" TIMESTAMP(3),
+ADD COLUMN     "deployerLaunchCount" INTEGER,
+ADD COLUMN     "deployerRiskScore" INTEGER,
+ADD COLUMN     "deployerRugCount" INTEGER;
+
+-- CreateIndex
+CREATE INDEX "Token_deployerAddress_idx" ON "Token"("deployerAddress");
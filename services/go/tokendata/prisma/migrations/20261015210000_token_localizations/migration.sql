@@ -0,0 +1,15 @@
+-- CreateTable
+CREATE TABLE "TokenLocalization" (
+    "id" TEXT NOT NULL,
+    "tokenAddress" TEXT NOT NULL,
+    "locale" TEXT NOT NULL,
+    "name" TEXT,
+    "description" TEXT,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL,
+
+    CONSTRAINT "TokenLocalization_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE UNIQUE INDEX "TokenLocalization_tokenAddress_locale_key" ON "TokenLocalization"("tokenAddress", "locale");
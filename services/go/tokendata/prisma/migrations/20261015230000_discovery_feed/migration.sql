@@ -0,0 +1,5 @@
+-- AlterTable
+ALTER TABLE "DiscoveryEvent" ADD COLUMN     "discoveredAt" TIMESTAMP(3);
+
+-- CreateIndex
+CREATE INDEX "DiscoveryEvent_status_discoveredAt_id_idx" ON "DiscoveryEvent"("status", "discoveredAt", "id");
@@ -0,0 +1,6 @@
+-- AlterTable
+ALTER TABLE "Token" ADD COLUMN     "delisted" BOOLEAN NOT NULL DEFAULT false,
+ADD COLUMN     "delistedAt" TIMESTAMP(3);
+
+-- CreateIndex
+CREATE INDEX "Token_delisted_lastUpdatedAt_idx" ON "Token"("delisted", "lastUpdatedAt");
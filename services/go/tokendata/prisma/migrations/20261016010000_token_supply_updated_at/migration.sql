@@ -0,0 +1,5 @@
+-- AlterTable
+ALTER TABLE "Token" ADD COLUMN     "supplyUpdatedAt" TIMESTAMP(3);
+
+-- CreateIndex
+CREATE INDEX "Token_supplyUpdatedAt_idx" ON "Token"("supplyUpdatedAt");
@@ -0,0 +1,8 @@
+-- AlterTable
+ALTER TABLE "Token" ADD COLUMN     "athAt" TIMESTAMP(3),
+ADD COLUMN     "athMultiple" DOUBLE PRECISION,
+ADD COLUMN     "athPrice" TEXT,
+ADD COLUMN     "priceMultiple" DOUBLE PRECISION;
+
+-- CreateIndex
+CREATE INDEX "Token_athMultiple_idx" ON "Token"("athMultiple");
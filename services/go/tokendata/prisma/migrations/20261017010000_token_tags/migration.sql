@@ -0,0 +1,5 @@
+-- AlterTable
+ALTER TABLE "Token" ADD COLUMN     "tags" TEXT[] DEFAULT ARRAY[]::TEXT[];
+
+-- CreateIndex
+CREATE INDEX "Token_tags_idx" ON "Token" USING GIN ("tags");
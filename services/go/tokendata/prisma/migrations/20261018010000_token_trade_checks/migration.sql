@@ -0,0 +1,5 @@
+-- AlterTable
+ALTER TABLE "Token" ADD COLUMN     "poolDynamicFee" BOOLEAN NOT NULL DEFAULT false,
+ADD COLUMN     "poolHooks" TEXT,
+ADD COLUMN     "tradeCheckedAt" TIMESTAMP(3),
+ADD COLUMN     "transferTaxBps" INTEGER;
@@ -0,0 +1,8 @@
+-- AlterTable
+ALTER TABLE "Token" ADD COLUMN     "compilerVersion" TEXT,
+ADD COLUMN     "contractCheckedAt" TIMESTAMP(3),
+ADD COLUMN     "contractIsProxy" BOOLEAN NOT NULL DEFAULT false,
+ADD COLUMN     "contractName" TEXT,
+ADD COLUMN     "contractVerified" BOOLEAN NOT NULL DEFAULT false,
+ADD COLUMN     "implementation" TEXT,
+ADD COLUMN     "sourceHash" TEXT;
@@ -0,0 +1,3 @@
+-- AlterTable
+ALTER TABLE "Token" ADD COLUMN     "priceSource" TEXT,
+ADD COLUMN     "priceUpdatedAt" TIMESTAMP(3);
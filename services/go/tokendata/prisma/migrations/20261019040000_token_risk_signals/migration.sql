@@ -0,0 +1,7 @@
+-- AlterTable
+ALTER TABLE "Token" ADD COLUMN     "liquidityUSD" DOUBLE PRECISION,
+ADD COLUMN     "topHoldersPct" DOUBLE PRECISION,
+ADD COLUMN     "riskCheckedAt" TIMESTAMP(3);
+
+-- CreateIndex
+CREATE INDEX "Token_riskCheckedAt_idx" ON "Token"("riskCheckedAt");
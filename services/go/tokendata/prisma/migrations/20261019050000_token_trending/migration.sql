@@ -0,0 +1,6 @@
+-- AlterTable
+ALTER TABLE "Token" ADD COLUMN     "trendingRank" INTEGER,
+ADD COLUMN     "trendingAt" TIMESTAMP(3);
+
+-- CreateIndex
+CREATE INDEX "Token_trendingRank_idx" ON "Token"("trendingRank");
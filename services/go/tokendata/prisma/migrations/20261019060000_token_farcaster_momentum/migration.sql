@@ -0,0 +1,7 @@
+-- AlterTable
+ALTER TABLE "Token" ADD COLUMN     "farcasterCasts1H" INTEGER,
+ADD COLUMN     "farcasterCasters1H" INTEGER,
+ADD COLUMN     "farcasterCheckedAt" TIMESTAMP(3);
+
+-- CreateIndex
+CREATE INDEX "Token_farcasterCheckedAt_idx" ON "Token"("farcasterCheckedAt");
@@ -0,0 +1,2 @@
+-- AlterTable
+ALTER TABLE "Token" ADD COLUMN     "poolPinned" BOOLEAN NOT NULL DEFAULT false;
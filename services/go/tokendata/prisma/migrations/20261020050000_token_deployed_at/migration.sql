@@ -0,0 +1,3 @@
+-- AlterTable
+ALTER TABLE "Token" ADD COLUMN     "deployedAt" TIMESTAMP(3),
+ADD COLUMN     "deployedBlock" INTEGER;
@@ -0,0 +1,6 @@
+-- AlterTable
+ALTER TABLE "Token" ADD COLUMN     "blacklisted" BOOLEAN NOT NULL DEFAULT false;
+
+-- Flag the tokens already on the unsecure tokens blacklist
+UPDATE "Token" SET "blacklisted" = true
+WHERE "address" IN (SELECT lower(unnest("addresses")) FROM "Blacklists" WHERE "name" = 'Unsecure Tokens');
@@ -0,0 +1,2 @@
+-- AlterTable
+ALTER TABLE "Token" ADD COLUMN     "pairUnpriced" BOOLEAN NOT NULL DEFAULT false;
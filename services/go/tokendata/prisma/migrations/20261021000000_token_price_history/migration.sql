@@ -0,0 +1,12 @@
+-- CreateTable
+CREATE TABLE "TokenPriceHistory" (
+    "id" TEXT NOT NULL,
+    "tokenAddress" TEXT NOT NULL,
+    "priceUsd" DOUBLE PRECISION NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "TokenPriceHistory_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE INDEX "TokenPriceHistory_tokenAddress_createdAt_idx" ON "TokenPriceHistory"("tokenAddress", "createdAt");
@@ -0,0 +1,35 @@
+-- CreateTable
+CREATE TABLE "TokenChange" (
+    "id" TEXT NOT NULL,
+    "tokenAddress" TEXT NOT NULL,
+    "changedAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "TokenChange_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE UNIQUE INDEX "TokenChange_tokenAddress_key" ON "TokenChange"("tokenAddress");
+
+-- CreateIndex
+CREATE INDEX "TokenChange_changedAt_tokenAddress_idx" ON "TokenChange"("changedAt", "tokenAddress");
+
+-- Every write to Token moves the token to the end of the change log in the same transaction.
+-- clock_timestamp() rather than now() keeps the order of the writes within long transactions.
+CREATE FUNCTION record_token_change() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO "TokenChange" ("id", "tokenAddress", "changedAt")
+    VALUES (gen_random_uuid()::text, COALESCE(NEW."address", OLD."address"), clock_timestamp())
+    ON CONFLICT ("tokenAddress") DO UPDATE SET "changedAt" = EXCLUDED."changedAt";
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "Token_change_insert_delete" AFTER INSERT OR DELETE ON "Token"
+    FOR EACH ROW EXECUTE FUNCTION record_token_change();
+
+CREATE TRIGGER "Token_change_update" AFTER UPDATE ON "Token"
+    FOR EACH ROW WHEN (OLD.* IS DISTINCT FROM NEW.*) EXECUTE FUNCTION record_token_change();
+
+-- Existing tokens start the log.
+INSERT INTO "TokenChange" ("id", "tokenAddress", "changedAt")
+SELECT gen_random_uuid()::text, "address", "updatedAt" FROM "Token";
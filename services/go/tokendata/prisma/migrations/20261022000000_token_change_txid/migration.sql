@@ -0,0 +1,33 @@
+-- The change log is read in the order of the transactions that wrote it: every transaction still
+-- running when a page is read has a higher id than the changes in it, so no change can commit
+-- behind a cursor a consumer already read, however long its transaction takes.
+ALTER TABLE "TokenChange" ADD COLUMN "txid" BIGINT NOT NULL DEFAULT 0;
+
+-- DropIndex
+DROP INDEX "TokenChange_changedAt_tokenAddress_idx";
+
+-- CreateIndex
+CREATE INDEX "TokenChange_txid_tokenAddress_idx" ON "TokenChange"("txid", "tokenAddress");
+
+-- CreateIndex
+CREATE INDEX "TokenChange_changedAt_idx" ON "TokenChange"("changedAt");
+
+CREATE OR REPLACE FUNCTION record_token_change() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO "TokenChange" ("id", "tokenAddress", "changedAt", "txid")
+    VALUES (gen_random_uuid()::text, COALESCE(NEW."address", OLD."address"), clock_timestamp(), pg_current_xact_id()::text::bigint)
+    ON CONFLICT ("tokenAddress") DO UPDATE SET "changedAt" = EXCLUDED."changedAt", "txid" = EXCLUDED."txid";
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Usage and volume bookkeeping moves on every swap and read without changing what the token is,
+-- and every update sets the @updatedAt timestamps, so those columns do not count as a change.
+DROP TRIGGER "Token_change_update" ON "Token";
+
+CREATE TRIGGER "Token_change_update" AFTER UPDATE ON "Token"
+    FOR EACH ROW WHEN (
+        (to_jsonb(OLD) - ARRAY['updatedAt', 'lastUpdatedAt', 'lastUsedAt', 'usingEnds', 'volume24H', 'calculatedVolume24H', 'priceUpdatedAt', 'priceSource'])
+        IS DISTINCT FROM
+        (to_jsonb(NEW) - ARRAY['updatedAt', 'lastUpdatedAt', 'lastUsedAt', 'usingEnds', 'volume24H', 'calculatedVolume24H', 'priceUpdatedAt', 'priceSource'])
+    ) EXECUTE FUNCTION record_token_change();
@@ -0,0 +1,2 @@
+-- AlterTable
+ALTER TABLE "DiscoveryEvent" ADD COLUMN "deployedAt" TIMESTAMP(3);
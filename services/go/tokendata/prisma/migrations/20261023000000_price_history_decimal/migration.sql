@@ -0,0 +1,2 @@
+-- AlterTable
+ALTER TABLE "TokenPriceHistory" ALTER COLUMN "priceUsd" SET DATA TYPE DECIMAL(65,30);
@@ -0,0 +1,2 @@
+-- AlterTable
+ALTER TABLE "Token" ALTER COLUMN "calculatedVolume24H" SET DATA TYPE DECIMAL(65,30);
@@ -0,0 +1,2 @@
+-- AlterTable
+ALTER TABLE "Token" ALTER COLUMN "minSwapUSD" SET DATA TYPE DECIMAL(65,30);
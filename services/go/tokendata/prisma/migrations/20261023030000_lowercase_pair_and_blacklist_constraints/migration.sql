@@ -0,0 +1,12 @@
+-- Normalize existing rows.
+UPDATE "Token" SET "pairAddress" = lower("pairAddress") WHERE "pairAddress" <> lower("pairAddress");
+
+UPDATE "Blacklists"
+SET "addresses" = ARRAY(SELECT DISTINCT lower("address") FROM unnest("addresses") AS "address")
+WHERE "addresses"::text <> lower("addresses"::text);
+
+-- AddCheckConstraint
+ALTER TABLE "Token" ADD CONSTRAINT "Token_pairAddress_lowercase" CHECK ("pairAddress" = lower("pairAddress"));
+
+-- AddCheckConstraint
+ALTER TABLE "Blacklists" ADD CONSTRAINT "Blacklists_addresses_lowercase" CHECK ("addresses"::text = lower("addresses"::text));
@@ -120,6 +120,302 @@ func (TokenRemovingType) EnumDescriptor() ([]byte, []int) {
 	return file_token_messages_proto_rawDescGZIP(), []int{1}
 }
 
+type ResolveInputType int32
+
+const (
+	ResolveInputType_RESOLVED_TOKEN ResolveInputType = 0
+	ResolveInputType_RESOLVED_POOL  ResolveInputType = 1
+)
+
+// Enum value maps for ResolveInputType.
+var (
+	ResolveInputType_name = map[int32]string{
+		0: "RESOLVED_TOKEN",
+		1: "RESOLVED_POOL",
+	}
+	ResolveInputType_value = map[string]int32{
+		"RESOLVED_TOKEN": 0,
+		"RESOLVED_POOL":  1,
+	}
+)
+
+func (x ResolveInputType) Enum() *ResolveInputType {
+	p := new(ResolveInputType)
+	*p = x
+	return p
+}
+
+func (x ResolveInputType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ResolveInputType) Descriptor() protoreflect.EnumDescriptor {
+	return file_token_messages_proto_enumTypes[2].Descriptor()
+}
+
+func (ResolveInputType) Type() protoreflect.EnumType {
+	return &file_token_messages_proto_enumTypes[2]
+}
+
+func (x ResolveInputType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ResolveInputType.Descriptor instead.
+func (ResolveInputType) EnumDescriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{2}
+}
+
+type PoolType int32
+
+const (
+	PoolType_POOL_UNISWAP_V3 PoolType = 0
+	PoolType_POOL_UNISWAP_V4 PoolType = 1
+)
+
+// Enum value maps for PoolType.
+var (
+	PoolType_name = map[int32]string{
+		0: "POOL_UNISWAP_V3",
+		1: "POOL_UNISWAP_V4",
+	}
+	PoolType_value = map[string]int32{
+		"POOL_UNISWAP_V3": 0,
+		"POOL_UNISWAP_V4": 1,
+	}
+)
+
+func (x PoolType) Enum() *PoolType {
+	p := new(PoolType)
+	*p = x
+	return p
+}
+
+func (x PoolType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PoolType) Descriptor() protoreflect.EnumDescriptor {
+	return file_token_messages_proto_enumTypes[3].Descriptor()
+}
+
+func (PoolType) Type() protoreflect.EnumType {
+	return &file_token_messages_proto_enumTypes[3]
+}
+
+func (x PoolType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PoolType.Descriptor instead.
+func (PoolType) EnumDescriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{3}
+}
+
+type TokenSort int32
+
+const (
+	TokenSort_SORT_DEFAULT            TokenSort = 0
+	TokenSort_SORT_DEPLOYER_RISK_ASC  TokenSort = 1
+	TokenSort_SORT_DEPLOYER_RISK_DESC TokenSort = 2
+	TokenSort_SORT_MARKET_CAP_DESC    TokenSort = 3
+	TokenSort_SORT_RISK_ASC           TokenSort = 4
+	TokenSort_SORT_RISK_DESC          TokenSort = 5
+	// Trending tokens by rank, the others after them.
+	TokenSort_SORT_TRENDING TokenSort = 6
+)
+
+// Enum value maps for TokenSort.
+var (
+	TokenSort_name = map[int32]string{
+		0: "SORT_DEFAULT",
+		1: "SORT_DEPLOYER_RISK_ASC",
+		2: "SORT_DEPLOYER_RISK_DESC",
+		3: "SORT_MARKET_CAP_DESC",
+		4: "SORT_RISK_ASC",
+		5: "SORT_RISK_DESC",
+		6: "SORT_TRENDING",
+	}
+	TokenSort_value = map[string]int32{
+		"SORT_DEFAULT":            0,
+		"SORT_DEPLOYER_RISK_ASC":  1,
+		"SORT_DEPLOYER_RISK_DESC": 2,
+		"SORT_MARKET_CAP_DESC":    3,
+		"SORT_RISK_ASC":           4,
+		"SORT_RISK_DESC":          5,
+		"SORT_TRENDING":           6,
+	}
+)
+
+func (x TokenSort) Enum() *TokenSort {
+	p := new(TokenSort)
+	*p = x
+	return p
+}
+
+func (x TokenSort) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TokenSort) Descriptor() protoreflect.EnumDescriptor {
+	return file_token_messages_proto_enumTypes[4].Descriptor()
+}
+
+func (TokenSort) Type() protoreflect.EnumType {
+	return &file_token_messages_proto_enumTypes[4]
+}
+
+func (x TokenSort) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TokenSort.Descriptor instead.
+func (TokenSort) EnumDescriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{4}
+}
+
+type BlacklistChangeType int32
+
+const (
+	// Every blacklisted token, sent first on each stream.
+	BlacklistChangeType_BLACKLIST_SNAPSHOT BlacklistChangeType = 0
+	BlacklistChangeType_BLACKLIST_ADDED    BlacklistChangeType = 1
+	BlacklistChangeType_BLACKLIST_REMOVED  BlacklistChangeType = 2
+)
+
+// Enum value maps for BlacklistChangeType.
+var (
+	BlacklistChangeType_name = map[int32]string{
+		0: "BLACKLIST_SNAPSHOT",
+		1: "BLACKLIST_ADDED",
+		2: "BLACKLIST_REMOVED",
+	}
+	BlacklistChangeType_value = map[string]int32{
+		"BLACKLIST_SNAPSHOT": 0,
+		"BLACKLIST_ADDED":    1,
+		"BLACKLIST_REMOVED":  2,
+	}
+)
+
+func (x BlacklistChangeType) Enum() *BlacklistChangeType {
+	p := new(BlacklistChangeType)
+	*p = x
+	return p
+}
+
+func (x BlacklistChangeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BlacklistChangeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_token_messages_proto_enumTypes[5].Descriptor()
+}
+
+func (BlacklistChangeType) Type() protoreflect.EnumType {
+	return &file_token_messages_proto_enumTypes[5]
+}
+
+func (x BlacklistChangeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BlacklistChangeType.Descriptor instead.
+func (BlacklistChangeType) EnumDescriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{5}
+}
+
+type QuoteSide int32
+
+const (
+	QuoteSide_QUOTE_BUY  QuoteSide = 0
+	QuoteSide_QUOTE_SELL QuoteSide = 1
+)
+
+// Enum value maps for QuoteSide.
+var (
+	QuoteSide_name = map[int32]string{
+		0: "QUOTE_BUY",
+		1: "QUOTE_SELL",
+	}
+	QuoteSide_value = map[string]int32{
+		"QUOTE_BUY":  0,
+		"QUOTE_SELL": 1,
+	}
+)
+
+func (x QuoteSide) Enum() *QuoteSide {
+	p := new(QuoteSide)
+	*p = x
+	return p
+}
+
+func (x QuoteSide) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (QuoteSide) Descriptor() protoreflect.EnumDescriptor {
+	return file_token_messages_proto_enumTypes[6].Descriptor()
+}
+
+func (QuoteSide) Type() protoreflect.EnumType {
+	return &file_token_messages_proto_enumTypes[6]
+}
+
+func (x QuoteSide) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use QuoteSide.Descriptor instead.
+func (QuoteSide) EnumDescriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{6}
+}
+
+type TradeSide int32
+
+const (
+	TradeSide_TRADE_BUY  TradeSide = 0
+	TradeSide_TRADE_SELL TradeSide = 1
+)
+
+// Enum value maps for TradeSide.
+var (
+	TradeSide_name = map[int32]string{
+		0: "TRADE_BUY",
+		1: "TRADE_SELL",
+	}
+	TradeSide_value = map[string]int32{
+		"TRADE_BUY":  0,
+		"TRADE_SELL": 1,
+	}
+)
+
+func (x TradeSide) Enum() *TradeSide {
+	p := new(TradeSide)
+	*p = x
+	return p
+}
+
+func (x TradeSide) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TradeSide) Descriptor() protoreflect.EnumDescriptor {
+	return file_token_messages_proto_enumTypes[7].Descriptor()
+}
+
+func (TradeSide) Type() protoreflect.EnumType {
+	return &file_token_messages_proto_enumTypes[7]
+}
+
+func (x TradeSide) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TradeSide.Descriptor instead.
+func (TradeSide) EnumDescriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{7}
+}
+
 type AddTokenRequest struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	TokenAddress     string                 `protobuf:"bytes,1,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
@@ -288,28 +584,27 @@ func (x *AddTokenResponse) GetMessage() string {
 	return ""
 }
 
-type GetTokenRequest struct {
+type AddTokensRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TokenAddress  string                 `protobuf:"bytes,1,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
-	AddIfNotExist bool                   `protobuf:"varint,2,opt,name=addIfNotExist,proto3" json:"addIfNotExist,omitempty"`
+	Tokens        []*AddTokenRequest     `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTokenRequest) Reset() {
-	*x = GetTokenRequest{}
+func (x *AddTokensRequest) Reset() {
+	*x = AddTokensRequest{}
 	mi := &file_token_messages_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTokenRequest) String() string {
+func (x *AddTokensRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTokenRequest) ProtoMessage() {}
+func (*AddTokensRequest) ProtoMessage() {}
 
-func (x *GetTokenRequest) ProtoReflect() protoreflect.Message {
+func (x *AddTokensRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_token_messages_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -321,47 +616,39 @@ func (x *GetTokenRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTokenRequest.ProtoReflect.Descriptor instead.
-func (*GetTokenRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AddTokensRequest.ProtoReflect.Descriptor instead.
+func (*AddTokensRequest) Descriptor() ([]byte, []int) {
 	return file_token_messages_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *GetTokenRequest) GetTokenAddress() string {
-	if x != nil {
-		return x.TokenAddress
-	}
-	return ""
-}
-
-func (x *GetTokenRequest) GetAddIfNotExist() bool {
+func (x *AddTokensRequest) GetTokens() []*AddTokenRequest {
 	if x != nil {
-		return x.AddIfNotExist
+		return x.Tokens
 	}
-	return false
+	return nil
 }
 
-type GetTokenPriceRequest struct {
+type AddTokensResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TokenAddress  string                 `protobuf:"bytes,1,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
-	Reason        *string                `protobuf:"bytes,2,opt,name=reason,proto3,oneof" json:"reason,omitempty"`
+	Results       []*AddTokenResponse    `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTokenPriceRequest) Reset() {
-	*x = GetTokenPriceRequest{}
+func (x *AddTokensResponse) Reset() {
+	*x = AddTokensResponse{}
 	mi := &file_token_messages_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTokenPriceRequest) String() string {
+func (x *AddTokensResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTokenPriceRequest) ProtoMessage() {}
+func (*AddTokensResponse) ProtoMessage() {}
 
-func (x *GetTokenPriceRequest) ProtoReflect() protoreflect.Message {
+func (x *AddTokensResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_token_messages_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -373,48 +660,41 @@ func (x *GetTokenPriceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTokenPriceRequest.ProtoReflect.Descriptor instead.
-func (*GetTokenPriceRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AddTokensResponse.ProtoReflect.Descriptor instead.
+func (*AddTokensResponse) Descriptor() ([]byte, []int) {
 	return file_token_messages_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GetTokenPriceRequest) GetTokenAddress() string {
+func (x *AddTokensResponse) GetResults() []*AddTokenResponse {
 	if x != nil {
-		return x.TokenAddress
-	}
-	return ""
-}
-
-func (x *GetTokenPriceRequest) GetReason() string {
-	if x != nil && x.Reason != nil {
-		return *x.Reason
+		return x.Results
 	}
-	return ""
+	return nil
 }
 
-type GetTokenPriceResponse struct {
+type AddPoolRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Price         string                 `protobuf:"bytes,2,opt,name=price,proto3" json:"price,omitempty"`
-	Volume        string                 `protobuf:"bytes,3,opt,name=volume,proto3" json:"volume,omitempty"`
+	PoolAddress   string                 `protobuf:"bytes,1,opt,name=poolAddress,proto3" json:"poolAddress,omitempty"`
+	IsV4          *bool                  `protobuf:"varint,2,opt,name=isV4,proto3,oneof" json:"isV4,omitempty"`
+	Reason        *string                `protobuf:"bytes,3,opt,name=reason,proto3,oneof" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTokenPriceResponse) Reset() {
-	*x = GetTokenPriceResponse{}
+func (x *AddPoolRequest) Reset() {
+	*x = AddPoolRequest{}
 	mi := &file_token_messages_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTokenPriceResponse) String() string {
+func (x *AddPoolRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTokenPriceResponse) ProtoMessage() {}
+func (*AddPoolRequest) ProtoMessage() {}
 
-func (x *GetTokenPriceResponse) ProtoReflect() protoreflect.Message {
+func (x *AddPoolRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_token_messages_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -426,53 +706,57 @@ func (x *GetTokenPriceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTokenPriceResponse.ProtoReflect.Descriptor instead.
-func (*GetTokenPriceResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use AddPoolRequest.ProtoReflect.Descriptor instead.
+func (*AddPoolRequest) Descriptor() ([]byte, []int) {
 	return file_token_messages_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GetTokenPriceResponse) GetSuccess() bool {
+func (x *AddPoolRequest) GetPoolAddress() string {
 	if x != nil {
-		return x.Success
+		return x.PoolAddress
 	}
-	return false
+	return ""
 }
 
-func (x *GetTokenPriceResponse) GetPrice() string {
-	if x != nil {
-		return x.Price
+func (x *AddPoolRequest) GetIsV4() bool {
+	if x != nil && x.IsV4 != nil {
+		return *x.IsV4
 	}
-	return ""
+	return false
 }
 
-func (x *GetTokenPriceResponse) GetVolume() string {
-	if x != nil {
-		return x.Volume
+func (x *AddPoolRequest) GetReason() string {
+	if x != nil && x.Reason != nil {
+		return *x.Reason
 	}
 	return ""
 }
 
-type GetTokenResponse struct {
+type AddPoolResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         *common.Token          `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Type          TokenAddingType        `protobuf:"varint,2,opt,name=type,proto3,enum=token.TokenAddingType" json:"type,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=Message,proto3" json:"Message,omitempty"`
+	TokenAddress  string                 `protobuf:"bytes,4,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
+	PairAddress   string                 `protobuf:"bytes,5,opt,name=pairAddress,proto3" json:"pairAddress,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTokenResponse) Reset() {
-	*x = GetTokenResponse{}
+func (x *AddPoolResponse) Reset() {
+	*x = AddPoolResponse{}
 	mi := &file_token_messages_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTokenResponse) String() string {
+func (x *AddPoolResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTokenResponse) ProtoMessage() {}
+func (*AddPoolResponse) ProtoMessage() {}
 
-func (x *GetTokenResponse) ProtoReflect() protoreflect.Message {
+func (x *AddPoolResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_token_messages_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -484,41 +768,3907 @@ func (x *GetTokenResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTokenResponse.ProtoReflect.Descriptor instead.
-func (*GetTokenResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use AddPoolResponse.ProtoReflect.Descriptor instead.
+func (*AddPoolResponse) Descriptor() ([]byte, []int) {
 	return file_token_messages_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *GetTokenResponse) GetToken() *common.Token {
+func (x *AddPoolResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Token
+		return x.Success
 	}
-	return nil
-}
-
-type RemoveTokenRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TokenAddress  string                 `protobuf:"bytes,1,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
-	BypassEnds    *bool                  `protobuf:"varint,2,opt,name=bypassEnds,proto3,oneof" json:"bypassEnds,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	return false
 }
 
-func (x *RemoveTokenRequest) Reset() {
-	*x = RemoveTokenRequest{}
-	mi := &file_token_messages_proto_msgTypes[6]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *AddPoolResponse) GetType() TokenAddingType {
+	if x != nil {
+		return x.Type
+	}
+	return TokenAddingType_DUPLICATE
 }
 
-func (x *RemoveTokenRequest) String() string {
+func (x *AddPoolResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AddPoolResponse) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *AddPoolResponse) GetPairAddress() string {
+	if x != nil {
+		return x.PairAddress
+	}
+	return ""
+}
+
+// Pins the pool a token is priced from, for when the best pair Dexscreener lists is the wrong
+// one. Pinned pools are left alone by pool revalidation.
+type SetTokenPoolRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	TokenAddress string                 `protobuf:"bytes,1,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
+	// Pool contract for V3, pool id for V4.
+	PoolAddress string   `protobuf:"bytes,2,opt,name=poolAddress,proto3" json:"poolAddress,omitempty"`
+	PoolType    PoolType `protobuf:"varint,3,opt,name=poolType,proto3,enum=token.PoolType" json:"poolType,omitempty"`
+	// Defaults to the other token of the pool.
+	PairAddress string `protobuf:"bytes,4,opt,name=pairAddress,proto3" json:"pairAddress,omitempty"`
+	// Releases the pin instead, handing the pool back to revalidation; the pool is kept until
+	// revalidation moves it.
+	Unpin         bool `protobuf:"varint,5,opt,name=unpin,proto3" json:"unpin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTokenPoolRequest) Reset() {
+	*x = SetTokenPoolRequest{}
+	mi := &file_token_messages_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTokenPoolRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTokenPoolRequest) ProtoMessage() {}
+
+func (x *SetTokenPoolRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTokenPoolRequest.ProtoReflect.Descriptor instead.
+func (*SetTokenPoolRequest) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SetTokenPoolRequest) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *SetTokenPoolRequest) GetPoolAddress() string {
+	if x != nil {
+		return x.PoolAddress
+	}
+	return ""
+}
+
+func (x *SetTokenPoolRequest) GetPoolType() PoolType {
+	if x != nil {
+		return x.PoolType
+	}
+	return PoolType_POOL_UNISWAP_V3
+}
+
+func (x *SetTokenPoolRequest) GetPairAddress() string {
+	if x != nil {
+		return x.PairAddress
+	}
+	return ""
+}
+
+func (x *SetTokenPoolRequest) GetUnpin() bool {
+	if x != nil {
+		return x.Unpin
+	}
+	return false
+}
+
+type SetTokenPoolResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TokenAddress  string                 `protobuf:"bytes,1,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
+	PoolAddress   string                 `protobuf:"bytes,2,opt,name=poolAddress,proto3" json:"poolAddress,omitempty"`
+	PairAddress   string                 `protobuf:"bytes,3,opt,name=pairAddress,proto3" json:"pairAddress,omitempty"`
+	PoolType      PoolType               `protobuf:"varint,4,opt,name=poolType,proto3,enum=token.PoolType" json:"poolType,omitempty"`
+	Pinned        bool                   `protobuf:"varint,5,opt,name=pinned,proto3" json:"pinned,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTokenPoolResponse) Reset() {
+	*x = SetTokenPoolResponse{}
+	mi := &file_token_messages_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTokenPoolResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTokenPoolResponse) ProtoMessage() {}
+
+func (x *SetTokenPoolResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTokenPoolResponse.ProtoReflect.Descriptor instead.
+func (*SetTokenPoolResponse) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SetTokenPoolResponse) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *SetTokenPoolResponse) GetPoolAddress() string {
+	if x != nil {
+		return x.PoolAddress
+	}
+	return ""
+}
+
+func (x *SetTokenPoolResponse) GetPairAddress() string {
+	if x != nil {
+		return x.PairAddress
+	}
+	return ""
+}
+
+func (x *SetTokenPoolResponse) GetPoolType() PoolType {
+	if x != nil {
+		return x.PoolType
+	}
+	return PoolType_POOL_UNISWAP_V3
+}
+
+func (x *SetTokenPoolResponse) GetPinned() bool {
+	if x != nil {
+		return x.Pinned
+	}
+	return false
+}
+
+type SetTokenMinSwapUsdRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	TokenAddress string                 `protobuf:"bytes,1,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
+	// Swaps worth less move the volume of the token but not its price. Empty clears it, so that
+	// MIN_SWAP_USD applies again.
+	MinSwapUsd    string `protobuf:"bytes,2,opt,name=minSwapUsd,proto3" json:"minSwapUsd,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTokenMinSwapUsdRequest) Reset() {
+	*x = SetTokenMinSwapUsdRequest{}
+	mi := &file_token_messages_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTokenMinSwapUsdRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTokenMinSwapUsdRequest) ProtoMessage() {}
+
+func (x *SetTokenMinSwapUsdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTokenMinSwapUsdRequest.ProtoReflect.Descriptor instead.
+func (*SetTokenMinSwapUsdRequest) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SetTokenMinSwapUsdRequest) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *SetTokenMinSwapUsdRequest) GetMinSwapUsd() string {
+	if x != nil {
+		return x.MinSwapUsd
+	}
+	return ""
+}
+
+type SetTokenMinSwapUsdResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	TokenAddress string                 `protobuf:"bytes,1,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
+	// Empty when MIN_SWAP_USD applies.
+	MinSwapUsd    string `protobuf:"bytes,2,opt,name=minSwapUsd,proto3" json:"minSwapUsd,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTokenMinSwapUsdResponse) Reset() {
+	*x = SetTokenMinSwapUsdResponse{}
+	mi := &file_token_messages_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTokenMinSwapUsdResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTokenMinSwapUsdResponse) ProtoMessage() {}
+
+func (x *SetTokenMinSwapUsdResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTokenMinSwapUsdResponse.ProtoReflect.Descriptor instead.
+func (*SetTokenMinSwapUsdResponse) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SetTokenMinSwapUsdResponse) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *SetTokenMinSwapUsdResponse) GetMinSwapUsd() string {
+	if x != nil {
+		return x.MinSwapUsd
+	}
+	return ""
+}
+
+type ResolveRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Query           string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	CreateIfMissing *bool                  `protobuf:"varint,2,opt,name=createIfMissing,proto3,oneof" json:"createIfMissing,omitempty"`
+	Reason          *string                `protobuf:"bytes,3,opt,name=reason,proto3,oneof" json:"reason,omitempty"`
+	// Preferred locales, most preferred first, e.g. ["pt-BR", "en"].
+	Locales       []string `protobuf:"bytes,4,rep,name=locales,proto3" json:"locales,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveRequest) Reset() {
+	*x = ResolveRequest{}
+	mi := &file_token_messages_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveRequest) ProtoMessage() {}
+
+func (x *ResolveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveRequest.ProtoReflect.Descriptor instead.
+func (*ResolveRequest) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ResolveRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ResolveRequest) GetCreateIfMissing() bool {
+	if x != nil && x.CreateIfMissing != nil {
+		return *x.CreateIfMissing
+	}
+	return false
+}
+
+func (x *ResolveRequest) GetReason() string {
+	if x != nil && x.Reason != nil {
+		return *x.Reason
+	}
+	return ""
+}
+
+func (x *ResolveRequest) GetLocales() []string {
+	if x != nil {
+		return x.Locales
+	}
+	return nil
+}
+
+type ResolveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=Message,proto3" json:"Message,omitempty"`
+	InputType     ResolveInputType       `protobuf:"varint,3,opt,name=inputType,proto3,enum=token.ResolveInputType" json:"inputType,omitempty"`
+	Token         *common.Token          `protobuf:"bytes,4,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveResponse) Reset() {
+	*x = ResolveResponse{}
+	mi := &file_token_messages_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveResponse) ProtoMessage() {}
+
+func (x *ResolveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveResponse.ProtoReflect.Descriptor instead.
+func (*ResolveResponse) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ResolveResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ResolveResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ResolveResponse) GetInputType() ResolveInputType {
+	if x != nil {
+		return x.InputType
+	}
+	return ResolveInputType_RESOLVED_TOKEN
+}
+
+func (x *ResolveResponse) GetToken() *common.Token {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+type GetTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TokenAddress  string                 `protobuf:"bytes,1,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
+	AddIfNotExist bool                   `protobuf:"varint,2,opt,name=addIfNotExist,proto3" json:"addIfNotExist,omitempty"`
+	// Preferred locales, most preferred first, e.g. ["pt-BR", "en"].
+	Locales       []string `protobuf:"bytes,3,rep,name=locales,proto3" json:"locales,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTokenRequest) Reset() {
+	*x = GetTokenRequest{}
+	mi := &file_token_messages_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTokenRequest) ProtoMessage() {}
+
+func (x *GetTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTokenRequest.ProtoReflect.Descriptor instead.
+func (*GetTokenRequest) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetTokenRequest) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *GetTokenRequest) GetAddIfNotExist() bool {
+	if x != nil {
+		return x.AddIfNotExist
+	}
+	return false
+}
+
+func (x *GetTokenRequest) GetLocales() []string {
+	if x != nil {
+		return x.Locales
+	}
+	return nil
+}
+
+type GetTokenPriceRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	TokenAddress string                 `protobuf:"bytes,1,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
+	Reason       *string                `protobuf:"bytes,2,opt,name=reason,proto3,oneof" json:"reason,omitempty"`
+	// Prices older than this are refreshed from the APIs, and fail with FAILED_PRECONDITION when
+	// they cannot be.
+	MaxAgeSeconds *int64 `protobuf:"varint,3,opt,name=maxAgeSeconds,proto3,oneof" json:"maxAgeSeconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTokenPriceRequest) Reset() {
+	*x = GetTokenPriceRequest{}
+	mi := &file_token_messages_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTokenPriceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTokenPriceRequest) ProtoMessage() {}
+
+func (x *GetTokenPriceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTokenPriceRequest.ProtoReflect.Descriptor instead.
+func (*GetTokenPriceRequest) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetTokenPriceRequest) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *GetTokenPriceRequest) GetReason() string {
+	if x != nil && x.Reason != nil {
+		return *x.Reason
+	}
+	return ""
+}
+
+func (x *GetTokenPriceRequest) GetMaxAgeSeconds() int64 {
+	if x != nil && x.MaxAgeSeconds != nil {
+		return *x.MaxAgeSeconds
+	}
+	return 0
+}
+
+type GetTokenPriceResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Price   string                 `protobuf:"bytes,2,opt,name=price,proto3" json:"price,omitempty"`
+	Volume  string                 `protobuf:"bytes,3,opt,name=volume,proto3" json:"volume,omitempty"`
+	// Unix milliseconds of the last price update.
+	LastUpdatedAt int64 `protobuf:"varint,4,opt,name=lastUpdatedAt,proto3" json:"lastUpdatedAt,omitempty"`
+	// The price is older than maxAgeSeconds, or STALE_PRICE_AFTER when it is not given, and
+	// could not be refreshed.
+	Stale bool `protobuf:"varint,5,opt,name=stale,proto3" json:"stale,omitempty"`
+	// Where the price came from and the confidence in it, as on common.Token.
+	Source        string  `protobuf:"bytes,6,opt,name=source,proto3" json:"source,omitempty"`
+	Confidence    float64 `protobuf:"fixed64,7,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTokenPriceResponse) Reset() {
+	*x = GetTokenPriceResponse{}
+	mi := &file_token_messages_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTokenPriceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTokenPriceResponse) ProtoMessage() {}
+
+func (x *GetTokenPriceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTokenPriceResponse.ProtoReflect.Descriptor instead.
+func (*GetTokenPriceResponse) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetTokenPriceResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetTokenPriceResponse) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *GetTokenPriceResponse) GetVolume() string {
+	if x != nil {
+		return x.Volume
+	}
+	return ""
+}
+
+func (x *GetTokenPriceResponse) GetLastUpdatedAt() int64 {
+	if x != nil {
+		return x.LastUpdatedAt
+	}
+	return 0
+}
+
+func (x *GetTokenPriceResponse) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+func (x *GetTokenPriceResponse) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *GetTokenPriceResponse) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+type GetTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         *common.Token          `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Orderflow     *common.TokenOrderflow `protobuf:"bytes,2,opt,name=orderflow,proto3" json:"orderflow,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTokenResponse) Reset() {
+	*x = GetTokenResponse{}
+	mi := &file_token_messages_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTokenResponse) ProtoMessage() {}
+
+func (x *GetTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTokenResponse.ProtoReflect.Descriptor instead.
+func (*GetTokenResponse) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetTokenResponse) GetToken() *common.Token {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *GetTokenResponse) GetOrderflow() *common.TokenOrderflow {
+	if x != nil {
+		return x.Orderflow
+	}
+	return nil
+}
+
+type RemoveTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TokenAddress  string                 `protobuf:"bytes,1,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
+	BypassEnds    *bool                  `protobuf:"varint,2,opt,name=bypassEnds,proto3,oneof" json:"bypassEnds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveTokenRequest) Reset() {
+	*x = RemoveTokenRequest{}
+	mi := &file_token_messages_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveTokenRequest) ProtoMessage() {}
+
+func (x *RemoveTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveTokenRequest.ProtoReflect.Descriptor instead.
+func (*RemoveTokenRequest) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RemoveTokenRequest) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *RemoveTokenRequest) GetBypassEnds() bool {
+	if x != nil && x.BypassEnds != nil {
+		return *x.BypassEnds
+	}
+	return false
+}
+
+type RemoveTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Type          TokenRemovingType      `protobuf:"varint,2,opt,name=type,proto3,enum=token.TokenRemovingType" json:"type,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=Message,proto3" json:"Message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveTokenResponse) Reset() {
+	*x = RemoveTokenResponse{}
+	mi := &file_token_messages_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveTokenResponse) ProtoMessage() {}
+
+func (x *RemoveTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveTokenResponse.ProtoReflect.Descriptor instead.
+func (*RemoveTokenResponse) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RemoveTokenResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RemoveTokenResponse) GetType() TokenRemovingType {
+	if x != nil {
+		return x.Type
+	}
+	return TokenRemovingType_STILL_CALCULATES
+}
+
+func (x *RemoveTokenResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type GetTokensRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TokenAddresses  []string               `protobuf:"bytes,1,rep,name=tokenAddresses,proto3" json:"tokenAddresses,omitempty"`
+	IncludeArchived *bool                  `protobuf:"varint,2,opt,name=includeArchived,proto3,oneof" json:"includeArchived,omitempty"`
+	Sort            TokenSort              `protobuf:"varint,3,opt,name=sort,proto3,enum=token.TokenSort" json:"sort,omitempty"`
+	// Preferred locales, most preferred first, e.g. ["pt-BR", "en"].
+	Locales []string `protobuf:"bytes,4,rep,name=locales,proto3" json:"locales,omitempty"`
+	// Only tokens having every tag are returned.
+	Tags []string `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Page size; every token is returned when neither limit nor cursor is set.
+	Limit *int32 `protobuf:"varint,6,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	// Position to continue after, the nextCursor of the previous page in the same sort.
+	Cursor string `protobuf:"bytes,7,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Only tokens whose risk score is at most maxRiskScore are returned.
+	MaxRiskScore *int32 `protobuf:"varint,8,opt,name=maxRiskScore,proto3,oneof" json:"maxRiskScore,omitempty"`
+	// Only tokens deployed at most maxAgeSeconds ago are returned; tokens whose deployment was
+	// not looked up yet are left out.
+	MaxAgeSeconds *int64 `protobuf:"varint,9,opt,name=maxAgeSeconds,proto3,oneof" json:"maxAgeSeconds,omitempty"`
+	// Token fields to return by name, e.g. ["address", "price"]; every field when empty. The
+	// address is always returned. getTokensV2 ignores it.
+	Fields []string `protobuf:"bytes,10,rep,name=fields,proto3" json:"fields,omitempty"`
+	// Blacklisted tokens are left out unless set.
+	IncludeBlacklisted *bool `protobuf:"varint,11,opt,name=includeBlacklisted,proto3,oneof" json:"includeBlacklisted,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *GetTokensRequest) Reset() {
+	*x = GetTokensRequest{}
+	mi := &file_token_messages_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTokensRequest) ProtoMessage() {}
+
+func (x *GetTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTokensRequest.ProtoReflect.Descriptor instead.
+func (*GetTokensRequest) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetTokensRequest) GetTokenAddresses() []string {
+	if x != nil {
+		return x.TokenAddresses
+	}
+	return nil
+}
+
+func (x *GetTokensRequest) GetIncludeArchived() bool {
+	if x != nil && x.IncludeArchived != nil {
+		return *x.IncludeArchived
+	}
+	return false
+}
+
+func (x *GetTokensRequest) GetSort() TokenSort {
+	if x != nil {
+		return x.Sort
+	}
+	return TokenSort_SORT_DEFAULT
+}
+
+func (x *GetTokensRequest) GetLocales() []string {
+	if x != nil {
+		return x.Locales
+	}
+	return nil
+}
+
+func (x *GetTokensRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *GetTokensRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *GetTokensRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetTokensRequest) GetMaxRiskScore() int32 {
+	if x != nil && x.MaxRiskScore != nil {
+		return *x.MaxRiskScore
+	}
+	return 0
+}
+
+func (x *GetTokensRequest) GetMaxAgeSeconds() int64 {
+	if x != nil && x.MaxAgeSeconds != nil {
+		return *x.MaxAgeSeconds
+	}
+	return 0
+}
+
+func (x *GetTokensRequest) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *GetTokensRequest) GetIncludeBlacklisted() bool {
+	if x != nil && x.IncludeBlacklisted != nil {
+		return *x.IncludeBlacklisted
+	}
+	return false
+}
+
+type GetTokensResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Tokens           []*common.Token        `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	FoundAddresses   []string               `protobuf:"bytes,2,rep,name=foundAddresses,proto3" json:"foundAddresses,omitempty"`
+	MissingAddresses []string               `protobuf:"bytes,3,rep,name=missingAddresses,proto3" json:"missingAddresses,omitempty"`
+	Partial          bool                   `protobuf:"varint,4,opt,name=partial,proto3" json:"partial,omitempty"`
+	NextCursor       string                 `protobuf:"bytes,5,opt,name=nextCursor,proto3" json:"nextCursor,omitempty"`
+	HasMore          bool                   `protobuf:"varint,6,opt,name=hasMore,proto3" json:"hasMore,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetTokensResponse) Reset() {
+	*x = GetTokensResponse{}
+	mi := &file_token_messages_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTokensResponse) ProtoMessage() {}
+
+func (x *GetTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTokensResponse.ProtoReflect.Descriptor instead.
+func (*GetTokensResponse) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetTokensResponse) GetTokens() []*common.Token {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+func (x *GetTokensResponse) GetFoundAddresses() []string {
+	if x != nil {
+		return x.FoundAddresses
+	}
+	return nil
+}
+
+func (x *GetTokensResponse) GetMissingAddresses() []string {
+	if x != nil {
+		return x.MissingAddresses
+	}
+	return nil
+}
+
+func (x *GetTokensResponse) GetPartial() bool {
+	if x != nil {
+		return x.Partial
+	}
+	return false
+}
+
+func (x *GetTokensResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *GetTokensResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type GetTokenV2Response struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         *common.TokenV2        `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Orderflow     *common.TokenOrderflow `protobuf:"bytes,2,opt,name=orderflow,proto3" json:"orderflow,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTokenV2Response) Reset() {
+	*x = GetTokenV2Response{}
+	mi := &file_token_messages_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTokenV2Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTokenV2Response) ProtoMessage() {}
+
+func (x *GetTokenV2Response) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTokenV2Response.ProtoReflect.Descriptor instead.
+func (*GetTokenV2Response) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetTokenV2Response) GetToken() *common.TokenV2 {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *GetTokenV2Response) GetOrderflow() *common.TokenOrderflow {
+	if x != nil {
+		return x.Orderflow
+	}
+	return nil
+}
+
+type GetTokensV2Response struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Tokens           []*common.TokenV2      `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	FoundAddresses   []string               `protobuf:"bytes,2,rep,name=foundAddresses,proto3" json:"foundAddresses,omitempty"`
+	MissingAddresses []string               `protobuf:"bytes,3,rep,name=missingAddresses,proto3" json:"missingAddresses,omitempty"`
+	Partial          bool                   `protobuf:"varint,4,opt,name=partial,proto3" json:"partial,omitempty"`
+	NextCursor       string                 `protobuf:"bytes,5,opt,name=nextCursor,proto3" json:"nextCursor,omitempty"`
+	HasMore          bool                   `protobuf:"varint,6,opt,name=hasMore,proto3" json:"hasMore,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetTokensV2Response) Reset() {
+	*x = GetTokensV2Response{}
+	mi := &file_token_messages_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTokensV2Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTokensV2Response) ProtoMessage() {}
+
+func (x *GetTokensV2Response) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTokensV2Response.ProtoReflect.Descriptor instead.
+func (*GetTokensV2Response) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetTokensV2Response) GetTokens() []*common.TokenV2 {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+func (x *GetTokensV2Response) GetFoundAddresses() []string {
+	if x != nil {
+		return x.FoundAddresses
+	}
+	return nil
+}
+
+func (x *GetTokensV2Response) GetMissingAddresses() []string {
+	if x != nil {
+		return x.MissingAddresses
+	}
+	return nil
+}
+
+func (x *GetTokensV2Response) GetPartial() bool {
+	if x != nil {
+		return x.Partial
+	}
+	return false
+}
+
+func (x *GetTokensV2Response) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *GetTokensV2Response) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type AddBlacklistRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TokenAddresses []string               `protobuf:"bytes,1,rep,name=tokenAddresses,proto3" json:"tokenAddresses,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AddBlacklistRequest) Reset() {
+	*x = AddBlacklistRequest{}
+	mi := &file_token_messages_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddBlacklistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddBlacklistRequest) ProtoMessage() {}
+
+func (x *AddBlacklistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddBlacklistRequest.ProtoReflect.Descriptor instead.
+func (*AddBlacklistRequest) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *AddBlacklistRequest) GetTokenAddresses() []string {
+	if x != nil {
+		return x.TokenAddresses
+	}
+	return nil
+}
+
+type AddBlacklistResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddBlacklistResponse) Reset() {
+	*x = AddBlacklistResponse{}
+	mi := &file_token_messages_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddBlacklistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddBlacklistResponse) ProtoMessage() {}
+
+func (x *AddBlacklistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddBlacklistResponse.ProtoReflect.Descriptor instead.
+func (*AddBlacklistResponse) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AddBlacklistResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type RemoveBlacklistRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TokenAddresses []string               `protobuf:"bytes,1,rep,name=tokenAddresses,proto3" json:"tokenAddresses,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RemoveBlacklistRequest) Reset() {
+	*x = RemoveBlacklistRequest{}
+	mi := &file_token_messages_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveBlacklistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveBlacklistRequest) ProtoMessage() {}
+
+func (x *RemoveBlacklistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveBlacklistRequest.ProtoReflect.Descriptor instead.
+func (*RemoveBlacklistRequest) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RemoveBlacklistRequest) GetTokenAddresses() []string {
+	if x != nil {
+		return x.TokenAddresses
+	}
+	return nil
+}
+
+type RemoveBlacklistResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveBlacklistResponse) Reset() {
+	*x = RemoveBlacklistResponse{}
+	mi := &file_token_messages_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveBlacklistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveBlacklistResponse) ProtoMessage() {}
+
+func (x *RemoveBlacklistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveBlacklistResponse.ProtoReflect.Descriptor instead.
+func (*RemoveBlacklistResponse) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RemoveBlacklistResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type GetBlacklistRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBlacklistRequest) Reset() {
+	*x = GetBlacklistRequest{}
+	mi := &file_token_messages_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBlacklistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlacklistRequest) ProtoMessage() {}
+
+func (x *GetBlacklistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlacklistRequest.ProtoReflect.Descriptor instead.
+func (*GetBlacklistRequest) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{26}
+}
+
+type GetBlacklistResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TokenAddresses []string               `protobuf:"bytes,1,rep,name=tokenAddresses,proto3" json:"tokenAddresses,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetBlacklistResponse) Reset() {
+	*x = GetBlacklistResponse{}
+	mi := &file_token_messages_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBlacklistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlacklistResponse) ProtoMessage() {}
+
+func (x *GetBlacklistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlacklistResponse.ProtoReflect.Descriptor instead.
+func (*GetBlacklistResponse) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetBlacklistResponse) GetTokenAddresses() []string {
+	if x != nil {
+		return x.TokenAddresses
+	}
+	return nil
+}
+
+type WatchBlacklistRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchBlacklistRequest) Reset() {
+	*x = WatchBlacklistRequest{}
+	mi := &file_token_messages_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchBlacklistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchBlacklistRequest) ProtoMessage() {}
+
+func (x *WatchBlacklistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchBlacklistRequest.ProtoReflect.Descriptor instead.
+func (*WatchBlacklistRequest) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{28}
+}
+
+type BlacklistChange struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Type           BlacklistChangeType    `protobuf:"varint,1,opt,name=type,proto3,enum=token.BlacklistChangeType" json:"type,omitempty"`
+	TokenAddresses []string               `protobuf:"bytes,2,rep,name=tokenAddresses,proto3" json:"tokenAddresses,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BlacklistChange) Reset() {
+	*x = BlacklistChange{}
+	mi := &file_token_messages_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlacklistChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlacklistChange) ProtoMessage() {}
+
+func (x *BlacklistChange) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlacklistChange.ProtoReflect.Descriptor instead.
+func (*BlacklistChange) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *BlacklistChange) GetType() BlacklistChangeType {
+	if x != nil {
+		return x.Type
+	}
+	return BlacklistChangeType_BLACKLIST_SNAPSHOT
+}
+
+func (x *BlacklistChange) GetTokenAddresses() []string {
+	if x != nil {
+		return x.TokenAddresses
+	}
+	return nil
+}
+
+type GetTokenHoldersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TokenAddress  string                 `protobuf:"bytes,1,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
+	Limit         *int32                 `protobuf:"varint,2,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTokenHoldersRequest) Reset() {
+	*x = GetTokenHoldersRequest{}
+	mi := &file_token_messages_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTokenHoldersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTokenHoldersRequest) ProtoMessage() {}
+
+func (x *GetTokenHoldersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTokenHoldersRequest.ProtoReflect.Descriptor instead.
+func (*GetTokenHoldersRequest) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetTokenHoldersRequest) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *GetTokenHoldersRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+type TokenHolder struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Address            string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Balance            string                 `protobuf:"bytes,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	BalanceFormatted   string                 `protobuf:"bytes,3,opt,name=balanceFormatted,proto3" json:"balanceFormatted,omitempty"`
+	PercentageOfSupply float64                `protobuf:"fixed64,4,opt,name=percentageOfSupply,proto3" json:"percentageOfSupply,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *TokenHolder) Reset() {
+	*x = TokenHolder{}
+	mi := &file_token_messages_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenHolder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenHolder) ProtoMessage() {}
+
+func (x *TokenHolder) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenHolder.ProtoReflect.Descriptor instead.
+func (*TokenHolder) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *TokenHolder) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *TokenHolder) GetBalance() string {
+	if x != nil {
+		return x.Balance
+	}
+	return ""
+}
+
+func (x *TokenHolder) GetBalanceFormatted() string {
+	if x != nil {
+		return x.BalanceFormatted
+	}
+	return ""
+}
+
+func (x *TokenHolder) GetPercentageOfSupply() float64 {
+	if x != nil {
+		return x.PercentageOfSupply
+	}
+	return 0
+}
+
+type GetTokenHoldersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Holders       []*TokenHolder         `protobuf:"bytes,1,rep,name=holders,proto3" json:"holders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTokenHoldersResponse) Reset() {
+	*x = GetTokenHoldersResponse{}
+	mi := &file_token_messages_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTokenHoldersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTokenHoldersResponse) ProtoMessage() {}
+
+func (x *GetTokenHoldersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTokenHoldersResponse.ProtoReflect.Descriptor instead.
+func (*GetTokenHoldersResponse) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetTokenHoldersResponse) GetHolders() []*TokenHolder {
+	if x != nil {
+		return x.Holders
+	}
+	return nil
+}
+
+type DegradationMode struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	DisableDiscovery  bool                   `protobuf:"varint,1,opt,name=disableDiscovery,proto3" json:"disableDiscovery,omitempty"`
+	DisableEnrichment bool                   `protobuf:"varint,2,opt,name=disableEnrichment,proto3" json:"disableEnrichment,omitempty"`
+	CachedPricesOnly  bool                   `protobuf:"varint,3,opt,name=cachedPricesOnly,proto3" json:"cachedPricesOnly,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *DegradationMode) Reset() {
+	*x = DegradationMode{}
+	mi := &file_token_messages_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DegradationMode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DegradationMode) ProtoMessage() {}
+
+func (x *DegradationMode) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DegradationMode.ProtoReflect.Descriptor instead.
+func (*DegradationMode) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DegradationMode) GetDisableDiscovery() bool {
+	if x != nil {
+		return x.DisableDiscovery
+	}
+	return false
+}
+
+func (x *DegradationMode) GetDisableEnrichment() bool {
+	if x != nil {
+		return x.DisableEnrichment
+	}
+	return false
+}
+
+func (x *DegradationMode) GetCachedPricesOnly() bool {
+	if x != nil {
+		return x.CachedPricesOnly
+	}
+	return false
+}
+
+type SetDegradationModeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mode          *DegradationMode       `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDegradationModeRequest) Reset() {
+	*x = SetDegradationModeRequest{}
+	mi := &file_token_messages_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDegradationModeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDegradationModeRequest) ProtoMessage() {}
+
+func (x *SetDegradationModeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDegradationModeRequest.ProtoReflect.Descriptor instead.
+func (*SetDegradationModeRequest) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SetDegradationModeRequest) GetMode() *DegradationMode {
+	if x != nil {
+		return x.Mode
+	}
+	return nil
+}
+
+type GetDegradationModeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDegradationModeRequest) Reset() {
+	*x = GetDegradationModeRequest{}
+	mi := &file_token_messages_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDegradationModeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDegradationModeRequest) ProtoMessage() {}
+
+func (x *GetDegradationModeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDegradationModeRequest.ProtoReflect.Descriptor instead.
+func (*GetDegradationModeRequest) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{35}
+}
+
+type DegradationModeResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Effective        *DegradationMode       `protobuf:"bytes,1,opt,name=effective,proto3" json:"effective,omitempty"`
+	Manual           *DegradationMode       `protobuf:"bytes,2,opt,name=manual,proto3" json:"manual,omitempty"`
+	FailingProviders []string               `protobuf:"bytes,3,rep,name=failingProviders,proto3" json:"failingProviders,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DegradationModeResponse) Reset() {
+	*x = DegradationModeResponse{}
+	mi := &file_token_messages_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DegradationModeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DegradationModeResponse) ProtoMessage() {}
+
+func (x *DegradationModeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DegradationModeResponse.ProtoReflect.Descriptor instead.
+func (*DegradationModeResponse) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *DegradationModeResponse) GetEffective() *DegradationMode {
+	if x != nil {
+		return x.Effective
+	}
+	return nil
+}
+
+func (x *DegradationModeResponse) GetManual() *DegradationMode {
+	if x != nil {
+		return x.Manual
+	}
+	return nil
+}
+
+func (x *DegradationModeResponse) GetFailingProviders() []string {
+	if x != nil {
+		return x.FailingProviders
+	}
+	return nil
+}
+
+type TokenLocalization struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TokenAddress  string                 `protobuf:"bytes,1,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
+	Locale        string                 `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`
+	Name          *string                `protobuf:"bytes,3,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Description   *string                `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TokenLocalization) Reset() {
+	*x = TokenLocalization{}
+	mi := &file_token_messages_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenLocalization) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenLocalization) ProtoMessage() {}
+
+func (x *TokenLocalization) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenLocalization.ProtoReflect.Descriptor instead.
+func (*TokenLocalization) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *TokenLocalization) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *TokenLocalization) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *TokenLocalization) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *TokenLocalization) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+type SetTokenLocalizationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Localization  *TokenLocalization     `protobuf:"bytes,1,opt,name=localization,proto3" json:"localization,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTokenLocalizationRequest) Reset() {
+	*x = SetTokenLocalizationRequest{}
+	mi := &file_token_messages_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTokenLocalizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTokenLocalizationRequest) ProtoMessage() {}
+
+func (x *SetTokenLocalizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTokenLocalizationRequest.ProtoReflect.Descriptor instead.
+func (*SetTokenLocalizationRequest) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *SetTokenLocalizationRequest) GetLocalization() *TokenLocalization {
+	if x != nil {
+		return x.Localization
+	}
+	return nil
+}
+
+type SetTokenLocalizationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Localization  *TokenLocalization     `protobuf:"bytes,1,opt,name=localization,proto3" json:"localization,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTokenLocalizationResponse) Reset() {
+	*x = SetTokenLocalizationResponse{}
+	mi := &file_token_messages_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTokenLocalizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTokenLocalizationResponse) ProtoMessage() {}
+
+func (x *SetTokenLocalizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTokenLocalizationResponse.ProtoReflect.Descriptor instead.
+func (*SetTokenLocalizationResponse) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *SetTokenLocalizationResponse) GetLocalization() *TokenLocalization {
+	if x != nil {
+		return x.Localization
+	}
+	return nil
+}
+
+type RemoveTokenLocalizationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TokenAddress  string                 `protobuf:"bytes,1,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
+	Locale        string                 `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveTokenLocalizationRequest) Reset() {
+	*x = RemoveTokenLocalizationRequest{}
+	mi := &file_token_messages_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveTokenLocalizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveTokenLocalizationRequest) ProtoMessage() {}
+
+func (x *RemoveTokenLocalizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveTokenLocalizationRequest.ProtoReflect.Descriptor instead.
+func (*RemoveTokenLocalizationRequest) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *RemoveTokenLocalizationRequest) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *RemoveTokenLocalizationRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type RemoveTokenLocalizationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveTokenLocalizationResponse) Reset() {
+	*x = RemoveTokenLocalizationResponse{}
+	mi := &file_token_messages_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveTokenLocalizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveTokenLocalizationResponse) ProtoMessage() {}
+
+func (x *RemoveTokenLocalizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveTokenLocalizationResponse.ProtoReflect.Descriptor instead.
+func (*RemoveTokenLocalizationResponse) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *RemoveTokenLocalizationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListTokenLocalizationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TokenAddress  string                 `protobuf:"bytes,1,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTokenLocalizationsRequest) Reset() {
+	*x = ListTokenLocalizationsRequest{}
+	mi := &file_token_messages_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTokenLocalizationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTokenLocalizationsRequest) ProtoMessage() {}
+
+func (x *ListTokenLocalizationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTokenLocalizationsRequest.ProtoReflect.Descriptor instead.
+func (*ListTokenLocalizationsRequest) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListTokenLocalizationsRequest) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+type ListTokenLocalizationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Localizations []*TokenLocalization   `protobuf:"bytes,1,rep,name=localizations,proto3" json:"localizations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTokenLocalizationsResponse) Reset() {
+	*x = ListTokenLocalizationsResponse{}
+	mi := &file_token_messages_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTokenLocalizationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTokenLocalizationsResponse) ProtoMessage() {}
+
+func (x *ListTokenLocalizationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTokenLocalizationsResponse.ProtoReflect.Descriptor instead.
+func (*ListTokenLocalizationsResponse) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListTokenLocalizationsResponse) GetLocalizations() []*TokenLocalization {
+	if x != nil {
+		return x.Localizations
+	}
+	return nil
+}
+
+type DiscoveredToken struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Source       string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	TokenAddress string                 `protobuf:"bytes,2,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
+	PairAddress  string                 `protobuf:"bytes,3,opt,name=pairAddress,proto3" json:"pairAddress,omitempty"`
+	PoolAddress  string                 `protobuf:"bytes,4,opt,name=poolAddress,proto3" json:"poolAddress,omitempty"`
+	Name         string                 `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	Symbol       string                 `protobuf:"bytes,6,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	ImageUrl     string                 `protobuf:"bytes,7,opt,name=imageUrl,proto3" json:"imageUrl,omitempty"`
+	DiscoveredAt int64                  `protobuf:"varint,8,opt,name=discoveredAt,proto3" json:"discoveredAt,omitempty"`
+	// Feed position of this token; pass it as cursor to continue after it.
+	Cursor        string `protobuf:"bytes,9,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiscoveredToken) Reset() {
+	*x = DiscoveredToken{}
+	mi := &file_token_messages_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscoveredToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscoveredToken) ProtoMessage() {}
+
+func (x *DiscoveredToken) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscoveredToken.ProtoReflect.Descriptor instead.
+func (*DiscoveredToken) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *DiscoveredToken) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *DiscoveredToken) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *DiscoveredToken) GetPairAddress() string {
+	if x != nil {
+		return x.PairAddress
+	}
+	return ""
+}
+
+func (x *DiscoveredToken) GetPoolAddress() string {
+	if x != nil {
+		return x.PoolAddress
+	}
+	return ""
+}
+
+func (x *DiscoveredToken) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DiscoveredToken) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *DiscoveredToken) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *DiscoveredToken) GetDiscoveredAt() int64 {
+	if x != nil {
+		return x.DiscoveredAt
+	}
+	return 0
+}
+
+func (x *DiscoveredToken) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type GetDiscoveryFeedRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Position to continue after; empty starts at the oldest retained token.
+	Cursor string `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit  *int32 `protobuf:"varint,2,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	// Discovery sources to include, e.g. "clanker" or "bankr"; empty includes all.
+	Sources       []string `protobuf:"bytes,3,rep,name=sources,proto3" json:"sources,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDiscoveryFeedRequest) Reset() {
+	*x = GetDiscoveryFeedRequest{}
+	mi := &file_token_messages_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDiscoveryFeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDiscoveryFeedRequest) ProtoMessage() {}
+
+func (x *GetDiscoveryFeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDiscoveryFeedRequest.ProtoReflect.Descriptor instead.
+func (*GetDiscoveryFeedRequest) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetDiscoveryFeedRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetDiscoveryFeedRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *GetDiscoveryFeedRequest) GetSources() []string {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+type GetDiscoveryFeedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tokens        []*DiscoveredToken     `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=nextCursor,proto3" json:"nextCursor,omitempty"`
+	HasMore       bool                   `protobuf:"varint,3,opt,name=hasMore,proto3" json:"hasMore,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDiscoveryFeedResponse) Reset() {
+	*x = GetDiscoveryFeedResponse{}
+	mi := &file_token_messages_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDiscoveryFeedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDiscoveryFeedResponse) ProtoMessage() {}
+
+func (x *GetDiscoveryFeedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDiscoveryFeedResponse.ProtoReflect.Descriptor instead.
+func (*GetDiscoveryFeedResponse) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *GetDiscoveryFeedResponse) GetTokens() []*DiscoveredToken {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+func (x *GetDiscoveryFeedResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *GetDiscoveryFeedResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type GetRecentLaunchesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Discovery source, e.g. "clanker" or "bankr"; empty includes all.
+	Source string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	// Unix seconds; only launches discovered after it are returned.
+	Since int64  `protobuf:"varint,2,opt,name=since,proto3" json:"since,omitempty"`
+	Limit *int32 `protobuf:"varint,3,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	// Only launches whose all-time high reached this multiple of the initial price, e.g. 10.
+	MinAthMultiple *float64 `protobuf:"fixed64,4,opt,name=minAthMultiple,proto3,oneof" json:"minAthMultiple,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetRecentLaunchesRequest) Reset() {
+	*x = GetRecentLaunchesRequest{}
+	mi := &file_token_messages_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecentLaunchesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecentLaunchesRequest) ProtoMessage() {}
+
+func (x *GetRecentLaunchesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecentLaunchesRequest.ProtoReflect.Descriptor instead.
+func (*GetRecentLaunchesRequest) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *GetRecentLaunchesRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *GetRecentLaunchesRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+func (x *GetRecentLaunchesRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *GetRecentLaunchesRequest) GetMinAthMultiple() float64 {
+	if x != nil && x.MinAthMultiple != nil {
+		return *x.MinAthMultiple
+	}
+	return 0
+}
+
+type RecentLaunch struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Source            string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	TokenAddress      string                 `protobuf:"bytes,2,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
+	Name              string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Symbol            string                 `protobuf:"bytes,4,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	ImageUrl          string                 `protobuf:"bytes,5,opt,name=imageUrl,proto3" json:"imageUrl,omitempty"`
+	PoolAddress       string                 `protobuf:"bytes,6,opt,name=poolAddress,proto3" json:"poolAddress,omitempty"`
+	PairAddress       string                 `protobuf:"bytes,7,opt,name=pairAddress,proto3" json:"pairAddress,omitempty"`
+	DeployerAddress   *string                `protobuf:"bytes,8,opt,name=deployerAddress,proto3,oneof" json:"deployerAddress,omitempty"`
+	DeployerRiskScore *int32                 `protobuf:"varint,9,opt,name=deployerRiskScore,proto3,oneof" json:"deployerRiskScore,omitempty"`
+	// Unix seconds.
+	DiscoveredAt int64 `protobuf:"varint,10,opt,name=discoveredAt,proto3" json:"discoveredAt,omitempty"`
+	CreatedAt    int64 `protobuf:"varint,11,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	// Price when the token was added; empty for tokens added before it was recorded.
+	InitialPrice string `protobuf:"bytes,12,opt,name=initialPrice,proto3" json:"initialPrice,omitempty"`
+	Price        string `protobuf:"bytes,13,opt,name=price,proto3" json:"price,omitempty"`
+	// Current price divided by the initial price; zero when the initial price is unknown.
+	PriceMultiple float64 `protobuf:"fixed64,14,opt,name=priceMultiple,proto3" json:"priceMultiple,omitempty"`
+	// Highest price since the token was added, its multiple and when it was reached (Unix
+	// seconds); empty and zero when the initial price is unknown.
+	AthPrice    string  `protobuf:"bytes,15,opt,name=athPrice,proto3" json:"athPrice,omitempty"`
+	AthMultiple float64 `protobuf:"fixed64,16,opt,name=athMultiple,proto3" json:"athMultiple,omitempty"`
+	AthAt       int64   `protobuf:"varint,17,opt,name=athAt,proto3" json:"athAt,omitempty"`
+	// Farcaster casts mentioning the token in the last hour and their distinct casters; unset
+	// until checked.
+	FarcasterCasts1H   *int32                 `protobuf:"varint,18,opt,name=farcasterCasts1h,proto3,oneof" json:"farcasterCasts1h,omitempty"`
+	FarcasterCasters1H *int32                 `protobuf:"varint,19,opt,name=farcasterCasters1h,proto3,oneof" json:"farcasterCasters1h,omitempty"`
+	Orderflow          *common.TokenOrderflow `protobuf:"bytes,20,opt,name=orderflow,proto3" json:"orderflow,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *RecentLaunch) Reset() {
+	*x = RecentLaunch{}
+	mi := &file_token_messages_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecentLaunch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecentLaunch) ProtoMessage() {}
+
+func (x *RecentLaunch) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecentLaunch.ProtoReflect.Descriptor instead.
+func (*RecentLaunch) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *RecentLaunch) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *RecentLaunch) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *RecentLaunch) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RecentLaunch) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *RecentLaunch) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *RecentLaunch) GetPoolAddress() string {
+	if x != nil {
+		return x.PoolAddress
+	}
+	return ""
+}
+
+func (x *RecentLaunch) GetPairAddress() string {
+	if x != nil {
+		return x.PairAddress
+	}
+	return ""
+}
+
+func (x *RecentLaunch) GetDeployerAddress() string {
+	if x != nil && x.DeployerAddress != nil {
+		return *x.DeployerAddress
+	}
+	return ""
+}
+
+func (x *RecentLaunch) GetDeployerRiskScore() int32 {
+	if x != nil && x.DeployerRiskScore != nil {
+		return *x.DeployerRiskScore
+	}
+	return 0
+}
+
+func (x *RecentLaunch) GetDiscoveredAt() int64 {
+	if x != nil {
+		return x.DiscoveredAt
+	}
+	return 0
+}
+
+func (x *RecentLaunch) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *RecentLaunch) GetInitialPrice() string {
+	if x != nil {
+		return x.InitialPrice
+	}
+	return ""
+}
+
+func (x *RecentLaunch) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *RecentLaunch) GetPriceMultiple() float64 {
+	if x != nil {
+		return x.PriceMultiple
+	}
+	return 0
+}
+
+func (x *RecentLaunch) GetAthPrice() string {
+	if x != nil {
+		return x.AthPrice
+	}
+	return ""
+}
+
+func (x *RecentLaunch) GetAthMultiple() float64 {
+	if x != nil {
+		return x.AthMultiple
+	}
+	return 0
+}
+
+func (x *RecentLaunch) GetAthAt() int64 {
+	if x != nil {
+		return x.AthAt
+	}
+	return 0
+}
+
+func (x *RecentLaunch) GetFarcasterCasts1H() int32 {
+	if x != nil && x.FarcasterCasts1H != nil {
+		return *x.FarcasterCasts1H
+	}
+	return 0
+}
+
+func (x *RecentLaunch) GetFarcasterCasters1H() int32 {
+	if x != nil && x.FarcasterCasters1H != nil {
+		return *x.FarcasterCasters1H
+	}
+	return 0
+}
+
+func (x *RecentLaunch) GetOrderflow() *common.TokenOrderflow {
+	if x != nil {
+		return x.Orderflow
+	}
+	return nil
+}
+
+type GetRecentLaunchesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Launches      []*RecentLaunch        `protobuf:"bytes,1,rep,name=launches,proto3" json:"launches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecentLaunchesResponse) Reset() {
+	*x = GetRecentLaunchesResponse{}
+	mi := &file_token_messages_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecentLaunchesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecentLaunchesResponse) ProtoMessage() {}
+
+func (x *GetRecentLaunchesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecentLaunchesResponse.ProtoReflect.Descriptor instead.
+func (*GetRecentLaunchesResponse) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetRecentLaunchesResponse) GetLaunches() []*RecentLaunch {
+	if x != nil {
+		return x.Launches
+	}
+	return nil
+}
+
+// Criteria of a filtered launch subscription. Unset criteria match every launch. A launch whose
+// liquidity, deployer risk or Farcaster momentum is not known yet is held until its checks ran,
+// up to a few minutes.
+type SubscribeFilteredLaunchesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Discovery sources, e.g. "clanker" or "bankr"; empty includes all.
+	Sources []string `protobuf:"bytes,1,rep,name=sources,proto3" json:"sources,omitempty"`
+	// Launches whose name or symbol contains any of them, ignoring case.
+	Keywords              []string `protobuf:"bytes,2,rep,name=keywords,proto3" json:"keywords,omitempty"`
+	MinLiquidityUsd       *float64 `protobuf:"fixed64,3,opt,name=minLiquidityUsd,proto3,oneof" json:"minLiquidityUsd,omitempty"`
+	MaxDeployerRiskScore  *int32   `protobuf:"varint,4,opt,name=maxDeployerRiskScore,proto3,oneof" json:"maxDeployerRiskScore,omitempty"`
+	MinFarcasterCasts1H   *int32   `protobuf:"varint,5,opt,name=minFarcasterCasts1h,proto3,oneof" json:"minFarcasterCasts1h,omitempty"`
+	MinFarcasterCasters1H *int32   `protobuf:"varint,6,opt,name=minFarcasterCasters1h,proto3,oneof" json:"minFarcasterCasters1h,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *SubscribeFilteredLaunchesRequest) Reset() {
+	*x = SubscribeFilteredLaunchesRequest{}
+	mi := &file_token_messages_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeFilteredLaunchesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeFilteredLaunchesRequest) ProtoMessage() {}
+
+func (x *SubscribeFilteredLaunchesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeFilteredLaunchesRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeFilteredLaunchesRequest) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *SubscribeFilteredLaunchesRequest) GetSources() []string {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+func (x *SubscribeFilteredLaunchesRequest) GetKeywords() []string {
+	if x != nil {
+		return x.Keywords
+	}
+	return nil
+}
+
+func (x *SubscribeFilteredLaunchesRequest) GetMinLiquidityUsd() float64 {
+	if x != nil && x.MinLiquidityUsd != nil {
+		return *x.MinLiquidityUsd
+	}
+	return 0
+}
+
+func (x *SubscribeFilteredLaunchesRequest) GetMaxDeployerRiskScore() int32 {
+	if x != nil && x.MaxDeployerRiskScore != nil {
+		return *x.MaxDeployerRiskScore
+	}
+	return 0
+}
+
+func (x *SubscribeFilteredLaunchesRequest) GetMinFarcasterCasts1H() int32 {
+	if x != nil && x.MinFarcasterCasts1H != nil {
+		return *x.MinFarcasterCasts1H
+	}
+	return 0
+}
+
+func (x *SubscribeFilteredLaunchesRequest) GetMinFarcasterCasters1H() int32 {
+	if x != nil && x.MinFarcasterCasters1H != nil {
+		return *x.MinFarcasterCasters1H
+	}
+	return 0
+}
+
+type GetQuoteRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	TokenAddress string                 `protobuf:"bytes,1,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
+	Side         QuoteSide              `protobuf:"varint,2,opt,name=side,proto3,enum=token.QuoteSide" json:"side,omitempty"`
+	// Amount spent, in whole units: of the pair token (e.g. WETH) to buy, of the token to sell.
+	Amount        string `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuoteRequest) Reset() {
+	*x = GetQuoteRequest{}
+	mi := &file_token_messages_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuoteRequest) ProtoMessage() {}
+
+func (x *GetQuoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuoteRequest.ProtoReflect.Descriptor instead.
+func (*GetQuoteRequest) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *GetQuoteRequest) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *GetQuoteRequest) GetSide() QuoteSide {
+	if x != nil {
+		return x.Side
+	}
+	return QuoteSide_QUOTE_BUY
+}
+
+func (x *GetQuoteRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+type GetQuoteResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	TokenIn   string                 `protobuf:"bytes,1,opt,name=tokenIn,proto3" json:"tokenIn,omitempty"`
+	TokenOut  string                 `protobuf:"bytes,2,opt,name=tokenOut,proto3" json:"tokenOut,omitempty"`
+	AmountIn  string                 `protobuf:"bytes,3,opt,name=amountIn,proto3" json:"amountIn,omitempty"`
+	AmountOut string                 `protobuf:"bytes,4,opt,name=amountOut,proto3" json:"amountOut,omitempty"`
+	// Percentage by which the output falls short of the output at the stored prices.
+	PriceImpact       float64 `protobuf:"fixed64,5,opt,name=priceImpact,proto3" json:"priceImpact,omitempty"`
+	GasEstimate       uint64  `protobuf:"varint,6,opt,name=gasEstimate,proto3" json:"gasEstimate,omitempty"`
+	ExecutionPriceUsd string  `protobuf:"bytes,7,opt,name=executionPriceUsd,proto3" json:"executionPriceUsd,omitempty"`
+	PoolAddress       string  `protobuf:"bytes,8,opt,name=poolAddress,proto3" json:"poolAddress,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetQuoteResponse) Reset() {
+	*x = GetQuoteResponse{}
+	mi := &file_token_messages_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuoteResponse) ProtoMessage() {}
+
+func (x *GetQuoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuoteResponse.ProtoReflect.Descriptor instead.
+func (*GetQuoteResponse) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *GetQuoteResponse) GetTokenIn() string {
+	if x != nil {
+		return x.TokenIn
+	}
+	return ""
+}
+
+func (x *GetQuoteResponse) GetTokenOut() string {
+	if x != nil {
+		return x.TokenOut
+	}
+	return ""
+}
+
+func (x *GetQuoteResponse) GetAmountIn() string {
+	if x != nil {
+		return x.AmountIn
+	}
+	return ""
+}
+
+func (x *GetQuoteResponse) GetAmountOut() string {
+	if x != nil {
+		return x.AmountOut
+	}
+	return ""
+}
+
+func (x *GetQuoteResponse) GetPriceImpact() float64 {
+	if x != nil {
+		return x.PriceImpact
+	}
+	return 0
+}
+
+func (x *GetQuoteResponse) GetGasEstimate() uint64 {
+	if x != nil {
+		return x.GasEstimate
+	}
+	return 0
+}
+
+func (x *GetQuoteResponse) GetExecutionPriceUsd() string {
+	if x != nil {
+		return x.ExecutionPriceUsd
+	}
+	return ""
+}
+
+func (x *GetQuoteResponse) GetPoolAddress() string {
+	if x != nil {
+		return x.PoolAddress
+	}
+	return ""
+}
+
+type GetGasPriceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGasPriceRequest) Reset() {
+	*x = GetGasPriceRequest{}
+	mi := &file_token_messages_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGasPriceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGasPriceRequest) ProtoMessage() {}
+
+func (x *GetGasPriceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGasPriceRequest.ProtoReflect.Descriptor instead.
+func (*GetGasPriceRequest) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{53}
+}
+
+type GasFeeLevel struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PriorityFeeGwei string                 `protobuf:"bytes,1,opt,name=priorityFeeGwei,proto3" json:"priorityFeeGwei,omitempty"`
+	MaxFeeGwei      string                 `protobuf:"bytes,2,opt,name=maxFeeGwei,proto3" json:"maxFeeGwei,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GasFeeLevel) Reset() {
+	*x = GasFeeLevel{}
+	mi := &file_token_messages_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GasFeeLevel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GasFeeLevel) ProtoMessage() {}
+
+func (x *GasFeeLevel) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GasFeeLevel.ProtoReflect.Descriptor instead.
+func (*GasFeeLevel) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *GasFeeLevel) GetPriorityFeeGwei() string {
+	if x != nil {
+		return x.PriorityFeeGwei
+	}
+	return ""
+}
+
+func (x *GasFeeLevel) GetMaxFeeGwei() string {
+	if x != nil {
+		return x.MaxFeeGwei
+	}
+	return ""
+}
+
+type GetGasPriceResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	BlockNumber     uint64                 `protobuf:"varint,1,opt,name=blockNumber,proto3" json:"blockNumber,omitempty"`
+	BaseFeeGwei     string                 `protobuf:"bytes,2,opt,name=baseFeeGwei,proto3" json:"baseFeeGwei,omitempty"`
+	NextBaseFeeGwei string                 `protobuf:"bytes,3,opt,name=nextBaseFeeGwei,proto3" json:"nextBaseFeeGwei,omitempty"`
+	Slow            *GasFeeLevel           `protobuf:"bytes,4,opt,name=slow,proto3" json:"slow,omitempty"`
+	Standard        *GasFeeLevel           `protobuf:"bytes,5,opt,name=standard,proto3" json:"standard,omitempty"`
+	Fast            *GasFeeLevel           `protobuf:"bytes,6,opt,name=fast,proto3" json:"fast,omitempty"`
+	UpdatedAt       int64                  `protobuf:"varint,7,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetGasPriceResponse) Reset() {
+	*x = GetGasPriceResponse{}
+	mi := &file_token_messages_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGasPriceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGasPriceResponse) ProtoMessage() {}
+
+func (x *GetGasPriceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGasPriceResponse.ProtoReflect.Descriptor instead.
+func (*GetGasPriceResponse) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GetGasPriceResponse) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *GetGasPriceResponse) GetBaseFeeGwei() string {
+	if x != nil {
+		return x.BaseFeeGwei
+	}
+	return ""
+}
+
+func (x *GetGasPriceResponse) GetNextBaseFeeGwei() string {
+	if x != nil {
+		return x.NextBaseFeeGwei
+	}
+	return ""
+}
+
+func (x *GetGasPriceResponse) GetSlow() *GasFeeLevel {
+	if x != nil {
+		return x.Slow
+	}
+	return nil
+}
+
+func (x *GetGasPriceResponse) GetStandard() *GasFeeLevel {
+	if x != nil {
+		return x.Standard
+	}
+	return nil
+}
+
+func (x *GetGasPriceResponse) GetFast() *GasFeeLevel {
+	if x != nil {
+		return x.Fast
+	}
+	return nil
+}
+
+func (x *GetGasPriceResponse) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type CronJob struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Zero for jobs that only run on demand.
+	IntervalSeconds int64 `protobuf:"varint,2,opt,name=intervalSeconds,proto3" json:"intervalSeconds,omitempty"`
+	TimeoutSeconds  int64 `protobuf:"varint,3,opt,name=timeoutSeconds,proto3" json:"timeoutSeconds,omitempty"`
+	Running         bool  `protobuf:"varint,4,opt,name=running,proto3" json:"running,omitempty"`
+	// Unix milliseconds; zero before the first run.
+	LastStartedAt  int64 `protobuf:"varint,5,opt,name=lastStartedAt,proto3" json:"lastStartedAt,omitempty"`
+	LastFinishedAt int64 `protobuf:"varint,6,opt,name=lastFinishedAt,proto3" json:"lastFinishedAt,omitempty"`
+	LastDurationMs int64 `protobuf:"varint,7,opt,name=lastDurationMs,proto3" json:"lastDurationMs,omitempty"`
+	// "ok", "panic" or "timed_out"; empty before the first run finishes.
+	LastResult string `protobuf:"bytes,8,opt,name=lastResult,proto3" json:"lastResult,omitempty"`
+	Runs       int64  `protobuf:"varint,9,opt,name=runs,proto3" json:"runs,omitempty"`
+	// Scheduled runs skipped because the previous run had not finished.
+	MissedRuns    int64 `protobuf:"varint,10,opt,name=missedRuns,proto3" json:"missedRuns,omitempty"`
+	NextRunAt     int64 `protobuf:"varint,11,opt,name=nextRunAt,proto3" json:"nextRunAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CronJob) Reset() {
+	*x = CronJob{}
+	mi := &file_token_messages_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CronJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CronJob) ProtoMessage() {}
+
+func (x *CronJob) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CronJob.ProtoReflect.Descriptor instead.
+func (*CronJob) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *CronJob) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CronJob) GetIntervalSeconds() int64 {
+	if x != nil {
+		return x.IntervalSeconds
+	}
+	return 0
+}
+
+func (x *CronJob) GetTimeoutSeconds() int64 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *CronJob) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+func (x *CronJob) GetLastStartedAt() int64 {
+	if x != nil {
+		return x.LastStartedAt
+	}
+	return 0
+}
+
+func (x *CronJob) GetLastFinishedAt() int64 {
+	if x != nil {
+		return x.LastFinishedAt
+	}
+	return 0
+}
+
+func (x *CronJob) GetLastDurationMs() int64 {
+	if x != nil {
+		return x.LastDurationMs
+	}
+	return 0
+}
+
+func (x *CronJob) GetLastResult() string {
+	if x != nil {
+		return x.LastResult
+	}
+	return ""
+}
+
+func (x *CronJob) GetRuns() int64 {
+	if x != nil {
+		return x.Runs
+	}
+	return 0
+}
+
+func (x *CronJob) GetMissedRuns() int64 {
+	if x != nil {
+		return x.MissedRuns
+	}
+	return 0
+}
+
+func (x *CronJob) GetNextRunAt() int64 {
+	if x != nil {
+		return x.NextRunAt
+	}
+	return 0
+}
+
+type ListCronJobsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCronJobsRequest) Reset() {
+	*x = ListCronJobsRequest{}
+	mi := &file_token_messages_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCronJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCronJobsRequest) ProtoMessage() {}
+
+func (x *ListCronJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCronJobsRequest.ProtoReflect.Descriptor instead.
+func (*ListCronJobsRequest) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{57}
+}
+
+type ListCronJobsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Jobs          []*CronJob             `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCronJobsResponse) Reset() {
+	*x = ListCronJobsResponse{}
+	mi := &file_token_messages_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCronJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCronJobsResponse) ProtoMessage() {}
+
+func (x *ListCronJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCronJobsResponse.ProtoReflect.Descriptor instead.
+func (*ListCronJobsResponse) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ListCronJobsResponse) GetJobs() []*CronJob {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+type RunCronJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunCronJobRequest) Reset() {
+	*x = RunCronJobRequest{}
+	mi := &file_token_messages_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunCronJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunCronJobRequest) ProtoMessage() {}
+
+func (x *RunCronJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunCronJobRequest.ProtoReflect.Descriptor instead.
+func (*RunCronJobRequest) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *RunCronJobRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type RunCronJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Started       bool                   `protobuf:"varint,1,opt,name=started,proto3" json:"started,omitempty"`
+	Job           *CronJob               `protobuf:"bytes,2,opt,name=job,proto3" json:"job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunCronJobResponse) Reset() {
+	*x = RunCronJobResponse{}
+	mi := &file_token_messages_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunCronJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunCronJobResponse) ProtoMessage() {}
+
+func (x *RunCronJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunCronJobResponse.ProtoReflect.Descriptor instead.
+func (*RunCronJobResponse) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *RunCronJobResponse) GetStarted() bool {
+	if x != nil {
+		return x.Started
+	}
+	return false
+}
+
+func (x *RunCronJobResponse) GetJob() *CronJob {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+type StreamTokenTradesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TokenAddress  string                 `protobuf:"bytes,1,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamTokenTradesRequest) Reset() {
+	*x = StreamTokenTradesRequest{}
+	mi := &file_token_messages_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamTokenTradesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamTokenTradesRequest) ProtoMessage() {}
+
+func (x *StreamTokenTradesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamTokenTradesRequest.ProtoReflect.Descriptor instead.
+func (*StreamTokenTradesRequest) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *StreamTokenTradesRequest) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+type TokenTrade struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	TokenAddress string                 `protobuf:"bytes,1,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
+	Side         TradeSide              `protobuf:"varint,2,opt,name=side,proto3,enum=token.TradeSide" json:"side,omitempty"`
+	// USD size of the trade and USD price of the token after it.
+	AmountUsd float64 `protobuf:"fixed64,3,opt,name=amountUsd,proto3" json:"amountUsd,omitempty"`
+	Price     string  `protobuf:"bytes,4,opt,name=price,proto3" json:"price,omitempty"`
+	// Tokens bought or sold, in token units.
+	TokenAmount string `protobuf:"bytes,5,opt,name=tokenAmount,proto3" json:"tokenAmount,omitempty"`
+	TxHash      string `protobuf:"bytes,6,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	// The recipient of a V3 swap or the sender of a V4 swap, which is a router for trades
+	// routed through one.
+	Maker       string `protobuf:"bytes,7,opt,name=maker,proto3" json:"maker,omitempty"`
+	BlockNumber uint64 `protobuf:"varint,8,opt,name=blockNumber,proto3" json:"blockNumber,omitempty"`
+	// The indexed addresses of the Swap event; V4 swaps have no recipient.
+	Sender        string `protobuf:"bytes,9,opt,name=sender,proto3" json:"sender,omitempty"`
+	Recipient     string `protobuf:"bytes,10,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TokenTrade) Reset() {
+	*x = TokenTrade{}
+	mi := &file_token_messages_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenTrade) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenTrade) ProtoMessage() {}
+
+func (x *TokenTrade) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenTrade.ProtoReflect.Descriptor instead.
+func (*TokenTrade) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *TokenTrade) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *TokenTrade) GetSide() TradeSide {
+	if x != nil {
+		return x.Side
+	}
+	return TradeSide_TRADE_BUY
+}
+
+func (x *TokenTrade) GetAmountUsd() float64 {
+	if x != nil {
+		return x.AmountUsd
+	}
+	return 0
+}
+
+func (x *TokenTrade) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *TokenTrade) GetTokenAmount() string {
+	if x != nil {
+		return x.TokenAmount
+	}
+	return ""
+}
+
+func (x *TokenTrade) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *TokenTrade) GetMaker() string {
+	if x != nil {
+		return x.Maker
+	}
+	return ""
+}
+
+func (x *TokenTrade) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *TokenTrade) GetSender() string {
+	if x != nil {
+		return x.Sender
+	}
+	return ""
+}
+
+func (x *TokenTrade) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+type GetTokenRiskRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	TokenAddress string                 `protobuf:"bytes,1,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
+	// Reads liquidity and holders again instead of using the last check.
+	Refresh       bool `protobuf:"varint,2,opt,name=refresh,proto3" json:"refresh,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTokenRiskRequest) Reset() {
+	*x = GetTokenRiskRequest{}
+	mi := &file_token_messages_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTokenRiskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTokenRiskRequest) ProtoMessage() {}
+
+func (x *GetTokenRiskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTokenRiskRequest.ProtoReflect.Descriptor instead.
+func (*GetTokenRiskRequest) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *GetTokenRiskRequest) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *GetTokenRiskRequest) GetRefresh() bool {
+	if x != nil {
+		return x.Refresh
+	}
+	return false
+}
+
+// RiskFactor is what one security check adds to the risk score.
+type RiskFactor struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// spam, honeypot, deployer, liquidity, contract or holder_concentration.
+	Signal string `protobuf:"bytes,1,opt,name=signal,proto3" json:"signal,omitempty"`
+	Points int32  `protobuf:"varint,2,opt,name=points,proto3" json:"points,omitempty"`
+	Detail string `protobuf:"bytes,3,opt,name=detail,proto3" json:"detail,omitempty"`
+	// Unset for checks that did not run yet; they add half the points of their signal.
+	Known         bool `protobuf:"varint,4,opt,name=known,proto3" json:"known,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RiskFactor) Reset() {
+	*x = RiskFactor{}
+	mi := &file_token_messages_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RiskFactor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RiskFactor) ProtoMessage() {}
+
+func (x *RiskFactor) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RiskFactor.ProtoReflect.Descriptor instead.
+func (*RiskFactor) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *RiskFactor) GetSignal() string {
+	if x != nil {
+		return x.Signal
+	}
+	return ""
+}
+
+func (x *RiskFactor) GetPoints() int32 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *RiskFactor) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *RiskFactor) GetKnown() bool {
+	if x != nil {
+		return x.Known
+	}
+	return false
+}
+
+type GetTokenRiskResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	TokenAddress string                 `protobuf:"bytes,1,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
+	// From 0 (no red flags) to 100.
+	Score   int32         `protobuf:"varint,2,opt,name=score,proto3" json:"score,omitempty"`
+	Factors []*RiskFactor `protobuf:"bytes,3,rep,name=factors,proto3" json:"factors,omitempty"`
+	// Unix milliseconds of the last liquidity and holders check, 0 if never checked.
+	CheckedAt int64 `protobuf:"varint,4,opt,name=checkedAt,proto3" json:"checkedAt,omitempty"`
+	// Signals that were not checked yet and count as neutral in the score.
+	UncheckedSignals []string `protobuf:"bytes,5,rep,name=uncheckedSignals,proto3" json:"uncheckedSignals,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetTokenRiskResponse) Reset() {
+	*x = GetTokenRiskResponse{}
+	mi := &file_token_messages_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTokenRiskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTokenRiskResponse) ProtoMessage() {}
+
+func (x *GetTokenRiskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTokenRiskResponse.ProtoReflect.Descriptor instead.
+func (*GetTokenRiskResponse) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *GetTokenRiskResponse) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *GetTokenRiskResponse) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *GetTokenRiskResponse) GetFactors() []*RiskFactor {
+	if x != nil {
+		return x.Factors
+	}
+	return nil
+}
+
+func (x *GetTokenRiskResponse) GetCheckedAt() int64 {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return 0
+}
+
+func (x *GetTokenRiskResponse) GetUncheckedSignals() []string {
+	if x != nil {
+		return x.UncheckedSignals
+	}
+	return nil
+}
+
+// Totals are of all replicas, paths of the replica that receives the request.
+type GetApiUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetApiUsageRequest) Reset() {
+	*x = GetApiUsageRequest{}
+	mi := &file_token_messages_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetApiUsageRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveTokenRequest) ProtoMessage() {}
+func (*GetApiUsageRequest) ProtoMessage() {}
 
-func (x *RemoveTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_token_messages_proto_msgTypes[6]
+func (x *GetApiUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -529,49 +4679,82 @@ func (x *RemoveTokenRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveTokenRequest.ProtoReflect.Descriptor instead.
-func (*RemoveTokenRequest) Descriptor() ([]byte, []int) {
-	return file_token_messages_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use GetApiUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetApiUsageRequest) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{66}
 }
 
-func (x *RemoveTokenRequest) GetTokenAddress() string {
+type GetApiUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Usage         *common.ApiUsage       `protobuf:"bytes,1,opt,name=usage,proto3" json:"usage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetApiUsageResponse) Reset() {
+	*x = GetApiUsageResponse{}
+	mi := &file_token_messages_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetApiUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetApiUsageResponse) ProtoMessage() {}
+
+func (x *GetApiUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[67]
 	if x != nil {
-		return x.TokenAddress
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *RemoveTokenRequest) GetBypassEnds() bool {
-	if x != nil && x.BypassEnds != nil {
-		return *x.BypassEnds
+// Deprecated: Use GetApiUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetApiUsageResponse) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *GetApiUsageResponse) GetUsage() *common.ApiUsage {
+	if x != nil {
+		return x.Usage
 	}
-	return false
+	return nil
 }
 
-type RemoveTokenResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Type          TokenRemovingType      `protobuf:"varint,2,opt,name=type,proto3,enum=token.TokenRemovingType" json:"type,omitempty"`
-	Message       string                 `protobuf:"bytes,3,opt,name=Message,proto3" json:"Message,omitempty"`
+type TokenPriceAtQuery struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	TokenAddress string                 `protobuf:"bytes,1,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
+	// Unix milliseconds.
+	Timestamp int64 `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Milliseconds a price may have been recorded before timestamp and still be its price; 0 uses
+	// PRICE_HISTORY_MAX_AGE.
+	MaxAge        int64 `protobuf:"varint,3,opt,name=maxAge,proto3" json:"maxAge,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RemoveTokenResponse) Reset() {
-	*x = RemoveTokenResponse{}
-	mi := &file_token_messages_proto_msgTypes[7]
+func (x *TokenPriceAtQuery) Reset() {
+	*x = TokenPriceAtQuery{}
+	mi := &file_token_messages_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RemoveTokenResponse) String() string {
+func (x *TokenPriceAtQuery) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveTokenResponse) ProtoMessage() {}
+func (*TokenPriceAtQuery) ProtoMessage() {}
 
-func (x *RemoveTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_token_messages_proto_msgTypes[7]
+func (x *TokenPriceAtQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -582,54 +4765,55 @@ func (x *RemoveTokenResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveTokenResponse.ProtoReflect.Descriptor instead.
-func (*RemoveTokenResponse) Descriptor() ([]byte, []int) {
-	return file_token_messages_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use TokenPriceAtQuery.ProtoReflect.Descriptor instead.
+func (*TokenPriceAtQuery) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{68}
 }
 
-func (x *RemoveTokenResponse) GetSuccess() bool {
+func (x *TokenPriceAtQuery) GetTokenAddress() string {
 	if x != nil {
-		return x.Success
+		return x.TokenAddress
 	}
-	return false
+	return ""
 }
 
-func (x *RemoveTokenResponse) GetType() TokenRemovingType {
+func (x *TokenPriceAtQuery) GetTimestamp() int64 {
 	if x != nil {
-		return x.Type
+		return x.Timestamp
 	}
-	return TokenRemovingType_STILL_CALCULATES
+	return 0
 }
 
-func (x *RemoveTokenResponse) GetMessage() string {
+func (x *TokenPriceAtQuery) GetMaxAge() int64 {
 	if x != nil {
-		return x.Message
+		return x.MaxAge
 	}
-	return ""
+	return 0
 }
 
-type GetTokensRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	TokenAddresses []string               `protobuf:"bytes,1,rep,name=tokenAddresses,proto3" json:"tokenAddresses,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type GetTokenPricesAtRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// At most 500 queries.
+	Queries       []*TokenPriceAtQuery `protobuf:"bytes,1,rep,name=queries,proto3" json:"queries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTokensRequest) Reset() {
-	*x = GetTokensRequest{}
-	mi := &file_token_messages_proto_msgTypes[8]
+func (x *GetTokenPricesAtRequest) Reset() {
+	*x = GetTokenPricesAtRequest{}
+	mi := &file_token_messages_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTokensRequest) String() string {
+func (x *GetTokenPricesAtRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTokensRequest) ProtoMessage() {}
+func (*GetTokenPricesAtRequest) ProtoMessage() {}
 
-func (x *GetTokensRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_token_messages_proto_msgTypes[8]
+func (x *GetTokenPricesAtRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -640,40 +4824,48 @@ func (x *GetTokensRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTokensRequest.ProtoReflect.Descriptor instead.
-func (*GetTokensRequest) Descriptor() ([]byte, []int) {
-	return file_token_messages_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use GetTokenPricesAtRequest.ProtoReflect.Descriptor instead.
+func (*GetTokenPricesAtRequest) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{69}
 }
 
-func (x *GetTokensRequest) GetTokenAddresses() []string {
+func (x *GetTokenPricesAtRequest) GetQueries() []*TokenPriceAtQuery {
 	if x != nil {
-		return x.TokenAddresses
+		return x.Queries
 	}
 	return nil
 }
 
-type GetTokensResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tokens        []*common.Token        `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
+type TokenPriceAt struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	TokenAddress string                 `protobuf:"bytes,1,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
+	Timestamp    int64                  `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// The last price recorded at or before timestamp and within the max age of the query. found is
+	// false when none was recorded, e.g. when the token was tracked later or had no price update
+	// for longer than the max age.
+	Found bool   `protobuf:"varint,3,opt,name=found,proto3" json:"found,omitempty"`
+	Price string `protobuf:"bytes,4,opt,name=price,proto3" json:"price,omitempty"`
+	// Unix milliseconds of the price point.
+	RecordedAt    int64 `protobuf:"varint,5,opt,name=recordedAt,proto3" json:"recordedAt,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTokensResponse) Reset() {
-	*x = GetTokensResponse{}
-	mi := &file_token_messages_proto_msgTypes[9]
+func (x *TokenPriceAt) Reset() {
+	*x = TokenPriceAt{}
+	mi := &file_token_messages_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTokensResponse) String() string {
+func (x *TokenPriceAt) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTokensResponse) ProtoMessage() {}
+func (*TokenPriceAt) ProtoMessage() {}
 
-func (x *GetTokensResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_token_messages_proto_msgTypes[9]
+func (x *TokenPriceAt) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -684,40 +4876,69 @@ func (x *GetTokensResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTokensResponse.ProtoReflect.Descriptor instead.
-func (*GetTokensResponse) Descriptor() ([]byte, []int) {
-	return file_token_messages_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use TokenPriceAt.ProtoReflect.Descriptor instead.
+func (*TokenPriceAt) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{70}
 }
 
-func (x *GetTokensResponse) GetTokens() []*common.Token {
+func (x *TokenPriceAt) GetTokenAddress() string {
 	if x != nil {
-		return x.Tokens
+		return x.TokenAddress
 	}
-	return nil
+	return ""
 }
 
-type AddBlacklistRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	TokenAddresses []string               `protobuf:"bytes,1,rep,name=tokenAddresses,proto3" json:"tokenAddresses,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *TokenPriceAt) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
 }
 
-func (x *AddBlacklistRequest) Reset() {
-	*x = AddBlacklistRequest{}
-	mi := &file_token_messages_proto_msgTypes[10]
+func (x *TokenPriceAt) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *TokenPriceAt) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *TokenPriceAt) GetRecordedAt() int64 {
+	if x != nil {
+		return x.RecordedAt
+	}
+	return 0
+}
+
+type GetTokenPricesAtResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// In the order of the queries.
+	Prices        []*TokenPriceAt `protobuf:"bytes,1,rep,name=prices,proto3" json:"prices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTokenPricesAtResponse) Reset() {
+	*x = GetTokenPricesAtResponse{}
+	mi := &file_token_messages_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddBlacklistRequest) String() string {
+func (x *GetTokenPricesAtResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddBlacklistRequest) ProtoMessage() {}
+func (*GetTokenPricesAtResponse) ProtoMessage() {}
 
-func (x *AddBlacklistRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_token_messages_proto_msgTypes[10]
+func (x *GetTokenPricesAtResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -728,40 +4949,41 @@ func (x *AddBlacklistRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddBlacklistRequest.ProtoReflect.Descriptor instead.
-func (*AddBlacklistRequest) Descriptor() ([]byte, []int) {
-	return file_token_messages_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use GetTokenPricesAtResponse.ProtoReflect.Descriptor instead.
+func (*GetTokenPricesAtResponse) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{71}
 }
 
-func (x *AddBlacklistRequest) GetTokenAddresses() []string {
+func (x *GetTokenPricesAtResponse) GetPrices() []*TokenPriceAt {
 	if x != nil {
-		return x.TokenAddresses
+		return x.Prices
 	}
 	return nil
 }
 
-type AddBlacklistResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+type StreamTokensRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Cursor of the last change the consumer applied; empty replays every token.
+	Cursor        string `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddBlacklistResponse) Reset() {
-	*x = AddBlacklistResponse{}
-	mi := &file_token_messages_proto_msgTypes[11]
+func (x *StreamTokensRequest) Reset() {
+	*x = StreamTokensRequest{}
+	mi := &file_token_messages_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddBlacklistResponse) String() string {
+func (x *StreamTokensRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddBlacklistResponse) ProtoMessage() {}
+func (*StreamTokensRequest) ProtoMessage() {}
 
-func (x *AddBlacklistResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_token_messages_proto_msgTypes[11]
+func (x *StreamTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -772,18 +4994,100 @@ func (x *AddBlacklistResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddBlacklistResponse.ProtoReflect.Descriptor instead.
-func (*AddBlacklistResponse) Descriptor() ([]byte, []int) {
-	return file_token_messages_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use StreamTokensRequest.ProtoReflect.Descriptor instead.
+func (*StreamTokensRequest) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{72}
 }
 
-func (x *AddBlacklistResponse) GetSuccess() bool {
+func (x *StreamTokensRequest) GetCursor() string {
 	if x != nil {
-		return x.Success
+		return x.Cursor
+	}
+	return ""
+}
+
+// The current state of a token that changed. A token is sent once for its latest change, so
+// changes made while a consumer was away arrive as one. Usage and volume bookkeeping, such as
+// lastUsedAt and volume24H, is not a change on its own.
+type TokenChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Position of the change to resume after.
+	Cursor       string `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	TokenAddress string `protobuf:"bytes,2,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
+	// Unix milliseconds.
+	ChangedAt int64 `protobuf:"varint,3,opt,name=changedAt,proto3" json:"changedAt,omitempty"`
+	// The token was deleted; token is not set.
+	Deleted       bool          `protobuf:"varint,4,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	Token         *common.Token `protobuf:"bytes,5,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TokenChange) Reset() {
+	*x = TokenChange{}
+	mi := &file_token_messages_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenChange) ProtoMessage() {}
+
+func (x *TokenChange) ProtoReflect() protoreflect.Message {
+	mi := &file_token_messages_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenChange.ProtoReflect.Descriptor instead.
+func (*TokenChange) Descriptor() ([]byte, []int) {
+	return file_token_messages_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *TokenChange) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *TokenChange) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *TokenChange) GetChangedAt() int64 {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return 0
+}
+
+func (x *TokenChange) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
 	}
 	return false
 }
 
+func (x *TokenChange) GetToken() *common.Token {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
 var File_token_messages_proto protoreflect.FileDescriptor
 
 const file_token_messages_proto_rawDesc = "" +
@@ -810,20 +5114,80 @@ const file_token_messages_proto_rawDesc = "" +
 	"\x10AddTokenResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12*\n" +
 	"\x04type\x18\x02 \x01(\x0e2\x16.token.TokenAddingTypeR\x04type\x12\x18\n" +
-	"\aMessage\x18\x03 \x01(\tR\aMessage\"[\n" +
+	"\aMessage\x18\x03 \x01(\tR\aMessage\"B\n" +
+	"\x10AddTokensRequest\x12.\n" +
+	"\x06tokens\x18\x01 \x03(\v2\x16.token.AddTokenRequestR\x06tokens\"F\n" +
+	"\x11AddTokensResponse\x121\n" +
+	"\aresults\x18\x01 \x03(\v2\x17.token.AddTokenResponseR\aresults\"|\n" +
+	"\x0eAddPoolRequest\x12 \n" +
+	"\vpoolAddress\x18\x01 \x01(\tR\vpoolAddress\x12\x17\n" +
+	"\x04isV4\x18\x02 \x01(\bH\x00R\x04isV4\x88\x01\x01\x12\x1b\n" +
+	"\x06reason\x18\x03 \x01(\tH\x01R\x06reason\x88\x01\x01B\a\n" +
+	"\x05_isV4B\t\n" +
+	"\a_reason\"\xb7\x01\n" +
+	"\x0fAddPoolResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12*\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x16.token.TokenAddingTypeR\x04type\x12\x18\n" +
+	"\aMessage\x18\x03 \x01(\tR\aMessage\x12\"\n" +
+	"\ftokenAddress\x18\x04 \x01(\tR\ftokenAddress\x12 \n" +
+	"\vpairAddress\x18\x05 \x01(\tR\vpairAddress\"\xc0\x01\n" +
+	"\x13SetTokenPoolRequest\x12\"\n" +
+	"\ftokenAddress\x18\x01 \x01(\tR\ftokenAddress\x12 \n" +
+	"\vpoolAddress\x18\x02 \x01(\tR\vpoolAddress\x12+\n" +
+	"\bpoolType\x18\x03 \x01(\x0e2\x0f.token.PoolTypeR\bpoolType\x12 \n" +
+	"\vpairAddress\x18\x04 \x01(\tR\vpairAddress\x12\x14\n" +
+	"\x05unpin\x18\x05 \x01(\bR\x05unpin\"\xc3\x01\n" +
+	"\x14SetTokenPoolResponse\x12\"\n" +
+	"\ftokenAddress\x18\x01 \x01(\tR\ftokenAddress\x12 \n" +
+	"\vpoolAddress\x18\x02 \x01(\tR\vpoolAddress\x12 \n" +
+	"\vpairAddress\x18\x03 \x01(\tR\vpairAddress\x12+\n" +
+	"\bpoolType\x18\x04 \x01(\x0e2\x0f.token.PoolTypeR\bpoolType\x12\x16\n" +
+	"\x06pinned\x18\x05 \x01(\bR\x06pinned\"_\n" +
+	"\x19SetTokenMinSwapUsdRequest\x12\"\n" +
+	"\ftokenAddress\x18\x01 \x01(\tR\ftokenAddress\x12\x1e\n" +
+	"\n" +
+	"minSwapUsd\x18\x02 \x01(\tR\n" +
+	"minSwapUsd\"`\n" +
+	"\x1aSetTokenMinSwapUsdResponse\x12\"\n" +
+	"\ftokenAddress\x18\x01 \x01(\tR\ftokenAddress\x12\x1e\n" +
+	"\n" +
+	"minSwapUsd\x18\x02 \x01(\tR\n" +
+	"minSwapUsd\"\xab\x01\n" +
+	"\x0eResolveRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12-\n" +
+	"\x0fcreateIfMissing\x18\x02 \x01(\bH\x00R\x0fcreateIfMissing\x88\x01\x01\x12\x1b\n" +
+	"\x06reason\x18\x03 \x01(\tH\x01R\x06reason\x88\x01\x01\x12\x18\n" +
+	"\alocales\x18\x04 \x03(\tR\alocalesB\x12\n" +
+	"\x10_createIfMissingB\t\n" +
+	"\a_reason\"\xa1\x01\n" +
+	"\x0fResolveResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\aMessage\x18\x02 \x01(\tR\aMessage\x125\n" +
+	"\tinputType\x18\x03 \x01(\x0e2\x17.token.ResolveInputTypeR\tinputType\x12#\n" +
+	"\x05token\x18\x04 \x01(\v2\r.common.TokenR\x05token\"u\n" +
 	"\x0fGetTokenRequest\x12\"\n" +
 	"\ftokenAddress\x18\x01 \x01(\tR\ftokenAddress\x12$\n" +
-	"\raddIfNotExist\x18\x02 \x01(\bR\raddIfNotExist\"b\n" +
+	"\raddIfNotExist\x18\x02 \x01(\bR\raddIfNotExist\x12\x18\n" +
+	"\alocales\x18\x03 \x03(\tR\alocales\"\x9f\x01\n" +
 	"\x14GetTokenPriceRequest\x12\"\n" +
 	"\ftokenAddress\x18\x01 \x01(\tR\ftokenAddress\x12\x1b\n" +
-	"\x06reason\x18\x02 \x01(\tH\x00R\x06reason\x88\x01\x01B\t\n" +
-	"\a_reason\"_\n" +
+	"\x06reason\x18\x02 \x01(\tH\x00R\x06reason\x88\x01\x01\x12)\n" +
+	"\rmaxAgeSeconds\x18\x03 \x01(\x03H\x01R\rmaxAgeSeconds\x88\x01\x01B\t\n" +
+	"\a_reasonB\x10\n" +
+	"\x0e_maxAgeSeconds\"\xd3\x01\n" +
 	"\x15GetTokenPriceResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
 	"\x05price\x18\x02 \x01(\tR\x05price\x12\x16\n" +
-	"\x06volume\x18\x03 \x01(\tR\x06volume\"7\n" +
+	"\x06volume\x18\x03 \x01(\tR\x06volume\x12$\n" +
+	"\rlastUpdatedAt\x18\x04 \x01(\x03R\rlastUpdatedAt\x12\x14\n" +
+	"\x05stale\x18\x05 \x01(\bR\x05stale\x12\x16\n" +
+	"\x06source\x18\x06 \x01(\tR\x06source\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\a \x01(\x01R\n" +
+	"confidence\"m\n" +
 	"\x10GetTokenResponse\x12#\n" +
-	"\x05token\x18\x01 \x01(\v2\r.common.TokenR\x05token\"l\n" +
+	"\x05token\x18\x01 \x01(\v2\r.common.TokenR\x05token\x124\n" +
+	"\torderflow\x18\x02 \x01(\v2\x16.common.TokenOrderflowR\torderflow\"l\n" +
 	"\x12RemoveTokenRequest\x12\"\n" +
 	"\ftokenAddress\x18\x01 \x01(\tR\ftokenAddress\x12#\n" +
 	"\n" +
@@ -833,15 +5197,279 @@ const file_token_messages_proto_rawDesc = "" +
 	"\x13RemoveTokenResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12,\n" +
 	"\x04type\x18\x02 \x01(\x0e2\x18.token.TokenRemovingTypeR\x04type\x12\x18\n" +
-	"\aMessage\x18\x03 \x01(\tR\aMessage\":\n" +
+	"\aMessage\x18\x03 \x01(\tR\aMessage\"\xe9\x03\n" +
 	"\x10GetTokensRequest\x12&\n" +
-	"\x0etokenAddresses\x18\x01 \x03(\tR\x0etokenAddresses\":\n" +
+	"\x0etokenAddresses\x18\x01 \x03(\tR\x0etokenAddresses\x12-\n" +
+	"\x0fincludeArchived\x18\x02 \x01(\bH\x00R\x0fincludeArchived\x88\x01\x01\x12$\n" +
+	"\x04sort\x18\x03 \x01(\x0e2\x10.token.TokenSortR\x04sort\x12\x18\n" +
+	"\alocales\x18\x04 \x03(\tR\alocales\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12\x19\n" +
+	"\x05limit\x18\x06 \x01(\x05H\x01R\x05limit\x88\x01\x01\x12\x16\n" +
+	"\x06cursor\x18\a \x01(\tR\x06cursor\x12'\n" +
+	"\fmaxRiskScore\x18\b \x01(\x05H\x02R\fmaxRiskScore\x88\x01\x01\x12)\n" +
+	"\rmaxAgeSeconds\x18\t \x01(\x03H\x03R\rmaxAgeSeconds\x88\x01\x01\x12\x16\n" +
+	"\x06fields\x18\n" +
+	" \x03(\tR\x06fields\x123\n" +
+	"\x12includeBlacklisted\x18\v \x01(\bH\x04R\x12includeBlacklisted\x88\x01\x01B\x12\n" +
+	"\x10_includeArchivedB\b\n" +
+	"\x06_limitB\x0f\n" +
+	"\r_maxRiskScoreB\x10\n" +
+	"\x0e_maxAgeSecondsB\x15\n" +
+	"\x13_includeBlacklisted\"\xe2\x01\n" +
 	"\x11GetTokensResponse\x12%\n" +
-	"\x06tokens\x18\x01 \x03(\v2\r.common.TokenR\x06tokens\"=\n" +
+	"\x06tokens\x18\x01 \x03(\v2\r.common.TokenR\x06tokens\x12&\n" +
+	"\x0efoundAddresses\x18\x02 \x03(\tR\x0efoundAddresses\x12*\n" +
+	"\x10missingAddresses\x18\x03 \x03(\tR\x10missingAddresses\x12\x18\n" +
+	"\apartial\x18\x04 \x01(\bR\apartial\x12\x1e\n" +
+	"\n" +
+	"nextCursor\x18\x05 \x01(\tR\n" +
+	"nextCursor\x12\x18\n" +
+	"\ahasMore\x18\x06 \x01(\bR\ahasMore\"q\n" +
+	"\x12GetTokenV2Response\x12%\n" +
+	"\x05token\x18\x01 \x01(\v2\x0f.common.TokenV2R\x05token\x124\n" +
+	"\torderflow\x18\x02 \x01(\v2\x16.common.TokenOrderflowR\torderflow\"\xe6\x01\n" +
+	"\x13GetTokensV2Response\x12'\n" +
+	"\x06tokens\x18\x01 \x03(\v2\x0f.common.TokenV2R\x06tokens\x12&\n" +
+	"\x0efoundAddresses\x18\x02 \x03(\tR\x0efoundAddresses\x12*\n" +
+	"\x10missingAddresses\x18\x03 \x03(\tR\x10missingAddresses\x12\x18\n" +
+	"\apartial\x18\x04 \x01(\bR\apartial\x12\x1e\n" +
+	"\n" +
+	"nextCursor\x18\x05 \x01(\tR\n" +
+	"nextCursor\x12\x18\n" +
+	"\ahasMore\x18\x06 \x01(\bR\ahasMore\"=\n" +
 	"\x13AddBlacklistRequest\x12&\n" +
 	"\x0etokenAddresses\x18\x01 \x03(\tR\x0etokenAddresses\"0\n" +
 	"\x14AddBlacklistResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess*?\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"@\n" +
+	"\x16RemoveBlacklistRequest\x12&\n" +
+	"\x0etokenAddresses\x18\x01 \x03(\tR\x0etokenAddresses\"3\n" +
+	"\x17RemoveBlacklistResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x15\n" +
+	"\x13GetBlacklistRequest\">\n" +
+	"\x14GetBlacklistResponse\x12&\n" +
+	"\x0etokenAddresses\x18\x01 \x03(\tR\x0etokenAddresses\"\x17\n" +
+	"\x15WatchBlacklistRequest\"i\n" +
+	"\x0fBlacklistChange\x12.\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x1a.token.BlacklistChangeTypeR\x04type\x12&\n" +
+	"\x0etokenAddresses\x18\x02 \x03(\tR\x0etokenAddresses\"a\n" +
+	"\x16GetTokenHoldersRequest\x12\"\n" +
+	"\ftokenAddress\x18\x01 \x01(\tR\ftokenAddress\x12\x19\n" +
+	"\x05limit\x18\x02 \x01(\x05H\x00R\x05limit\x88\x01\x01B\b\n" +
+	"\x06_limit\"\x9d\x01\n" +
+	"\vTokenHolder\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x18\n" +
+	"\abalance\x18\x02 \x01(\tR\abalance\x12*\n" +
+	"\x10balanceFormatted\x18\x03 \x01(\tR\x10balanceFormatted\x12.\n" +
+	"\x12percentageOfSupply\x18\x04 \x01(\x01R\x12percentageOfSupply\"G\n" +
+	"\x17GetTokenHoldersResponse\x12,\n" +
+	"\aholders\x18\x01 \x03(\v2\x12.token.TokenHolderR\aholders\"\x97\x01\n" +
+	"\x0fDegradationMode\x12*\n" +
+	"\x10disableDiscovery\x18\x01 \x01(\bR\x10disableDiscovery\x12,\n" +
+	"\x11disableEnrichment\x18\x02 \x01(\bR\x11disableEnrichment\x12*\n" +
+	"\x10cachedPricesOnly\x18\x03 \x01(\bR\x10cachedPricesOnly\"G\n" +
+	"\x19SetDegradationModeRequest\x12*\n" +
+	"\x04mode\x18\x01 \x01(\v2\x16.token.DegradationModeR\x04mode\"\x1b\n" +
+	"\x19GetDegradationModeRequest\"\xab\x01\n" +
+	"\x17DegradationModeResponse\x124\n" +
+	"\teffective\x18\x01 \x01(\v2\x16.token.DegradationModeR\teffective\x12.\n" +
+	"\x06manual\x18\x02 \x01(\v2\x16.token.DegradationModeR\x06manual\x12*\n" +
+	"\x10failingProviders\x18\x03 \x03(\tR\x10failingProviders\"\xa8\x01\n" +
+	"\x11TokenLocalization\x12\"\n" +
+	"\ftokenAddress\x18\x01 \x01(\tR\ftokenAddress\x12\x16\n" +
+	"\x06locale\x18\x02 \x01(\tR\x06locale\x12\x17\n" +
+	"\x04name\x18\x03 \x01(\tH\x00R\x04name\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x04 \x01(\tH\x01R\vdescription\x88\x01\x01B\a\n" +
+	"\x05_nameB\x0e\n" +
+	"\f_description\"[\n" +
+	"\x1bSetTokenLocalizationRequest\x12<\n" +
+	"\flocalization\x18\x01 \x01(\v2\x18.token.TokenLocalizationR\flocalization\"\\\n" +
+	"\x1cSetTokenLocalizationResponse\x12<\n" +
+	"\flocalization\x18\x01 \x01(\v2\x18.token.TokenLocalizationR\flocalization\"\\\n" +
+	"\x1eRemoveTokenLocalizationRequest\x12\"\n" +
+	"\ftokenAddress\x18\x01 \x01(\tR\ftokenAddress\x12\x16\n" +
+	"\x06locale\x18\x02 \x01(\tR\x06locale\";\n" +
+	"\x1fRemoveTokenLocalizationResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"C\n" +
+	"\x1dListTokenLocalizationsRequest\x12\"\n" +
+	"\ftokenAddress\x18\x01 \x01(\tR\ftokenAddress\"`\n" +
+	"\x1eListTokenLocalizationsResponse\x12>\n" +
+	"\rlocalizations\x18\x01 \x03(\v2\x18.token.TokenLocalizationR\rlocalizations\"\x95\x02\n" +
+	"\x0fDiscoveredToken\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\"\n" +
+	"\ftokenAddress\x18\x02 \x01(\tR\ftokenAddress\x12 \n" +
+	"\vpairAddress\x18\x03 \x01(\tR\vpairAddress\x12 \n" +
+	"\vpoolAddress\x18\x04 \x01(\tR\vpoolAddress\x12\x12\n" +
+	"\x04name\x18\x05 \x01(\tR\x04name\x12\x16\n" +
+	"\x06symbol\x18\x06 \x01(\tR\x06symbol\x12\x1a\n" +
+	"\bimageUrl\x18\a \x01(\tR\bimageUrl\x12\"\n" +
+	"\fdiscoveredAt\x18\b \x01(\x03R\fdiscoveredAt\x12\x16\n" +
+	"\x06cursor\x18\t \x01(\tR\x06cursor\"p\n" +
+	"\x17GetDiscoveryFeedRequest\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12\x19\n" +
+	"\x05limit\x18\x02 \x01(\x05H\x00R\x05limit\x88\x01\x01\x12\x18\n" +
+	"\asources\x18\x03 \x03(\tR\asourcesB\b\n" +
+	"\x06_limit\"\x84\x01\n" +
+	"\x18GetDiscoveryFeedResponse\x12.\n" +
+	"\x06tokens\x18\x01 \x03(\v2\x16.token.DiscoveredTokenR\x06tokens\x12\x1e\n" +
+	"\n" +
+	"nextCursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x12\x18\n" +
+	"\ahasMore\x18\x03 \x01(\bR\ahasMore\"\xad\x01\n" +
+	"\x18GetRecentLaunchesRequest\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\x14\n" +
+	"\x05since\x18\x02 \x01(\x03R\x05since\x12\x19\n" +
+	"\x05limit\x18\x03 \x01(\x05H\x00R\x05limit\x88\x01\x01\x12+\n" +
+	"\x0eminAthMultiple\x18\x04 \x01(\x01H\x01R\x0eminAthMultiple\x88\x01\x01B\b\n" +
+	"\x06_limitB\x11\n" +
+	"\x0f_minAthMultiple\"\xa0\x06\n" +
+	"\fRecentLaunch\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\"\n" +
+	"\ftokenAddress\x18\x02 \x01(\tR\ftokenAddress\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x16\n" +
+	"\x06symbol\x18\x04 \x01(\tR\x06symbol\x12\x1a\n" +
+	"\bimageUrl\x18\x05 \x01(\tR\bimageUrl\x12 \n" +
+	"\vpoolAddress\x18\x06 \x01(\tR\vpoolAddress\x12 \n" +
+	"\vpairAddress\x18\a \x01(\tR\vpairAddress\x12-\n" +
+	"\x0fdeployerAddress\x18\b \x01(\tH\x00R\x0fdeployerAddress\x88\x01\x01\x121\n" +
+	"\x11deployerRiskScore\x18\t \x01(\x05H\x01R\x11deployerRiskScore\x88\x01\x01\x12\"\n" +
+	"\fdiscoveredAt\x18\n" +
+	" \x01(\x03R\fdiscoveredAt\x12\x1c\n" +
+	"\tcreatedAt\x18\v \x01(\x03R\tcreatedAt\x12\"\n" +
+	"\finitialPrice\x18\f \x01(\tR\finitialPrice\x12\x14\n" +
+	"\x05price\x18\r \x01(\tR\x05price\x12$\n" +
+	"\rpriceMultiple\x18\x0e \x01(\x01R\rpriceMultiple\x12\x1a\n" +
+	"\bathPrice\x18\x0f \x01(\tR\bathPrice\x12 \n" +
+	"\vathMultiple\x18\x10 \x01(\x01R\vathMultiple\x12\x14\n" +
+	"\x05athAt\x18\x11 \x01(\x03R\x05athAt\x12/\n" +
+	"\x10farcasterCasts1h\x18\x12 \x01(\x05H\x02R\x10farcasterCasts1h\x88\x01\x01\x123\n" +
+	"\x12farcasterCasters1h\x18\x13 \x01(\x05H\x03R\x12farcasterCasters1h\x88\x01\x01\x124\n" +
+	"\torderflow\x18\x14 \x01(\v2\x16.common.TokenOrderflowR\torderflowB\x12\n" +
+	"\x10_deployerAddressB\x14\n" +
+	"\x12_deployerRiskScoreB\x13\n" +
+	"\x11_farcasterCasts1hB\x15\n" +
+	"\x13_farcasterCasters1h\"L\n" +
+	"\x19GetRecentLaunchesResponse\x12/\n" +
+	"\blaunches\x18\x01 \x03(\v2\x13.token.RecentLaunchR\blaunches\"\x91\x03\n" +
+	" SubscribeFilteredLaunchesRequest\x12\x18\n" +
+	"\asources\x18\x01 \x03(\tR\asources\x12\x1a\n" +
+	"\bkeywords\x18\x02 \x03(\tR\bkeywords\x12-\n" +
+	"\x0fminLiquidityUsd\x18\x03 \x01(\x01H\x00R\x0fminLiquidityUsd\x88\x01\x01\x127\n" +
+	"\x14maxDeployerRiskScore\x18\x04 \x01(\x05H\x01R\x14maxDeployerRiskScore\x88\x01\x01\x125\n" +
+	"\x13minFarcasterCasts1h\x18\x05 \x01(\x05H\x02R\x13minFarcasterCasts1h\x88\x01\x01\x129\n" +
+	"\x15minFarcasterCasters1h\x18\x06 \x01(\x05H\x03R\x15minFarcasterCasters1h\x88\x01\x01B\x12\n" +
+	"\x10_minLiquidityUsdB\x17\n" +
+	"\x15_maxDeployerRiskScoreB\x16\n" +
+	"\x14_minFarcasterCasts1hB\x18\n" +
+	"\x16_minFarcasterCasters1h\"s\n" +
+	"\x0fGetQuoteRequest\x12\"\n" +
+	"\ftokenAddress\x18\x01 \x01(\tR\ftokenAddress\x12$\n" +
+	"\x04side\x18\x02 \x01(\x0e2\x10.token.QuoteSideR\x04side\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\tR\x06amount\"\x96\x02\n" +
+	"\x10GetQuoteResponse\x12\x18\n" +
+	"\atokenIn\x18\x01 \x01(\tR\atokenIn\x12\x1a\n" +
+	"\btokenOut\x18\x02 \x01(\tR\btokenOut\x12\x1a\n" +
+	"\bamountIn\x18\x03 \x01(\tR\bamountIn\x12\x1c\n" +
+	"\tamountOut\x18\x04 \x01(\tR\tamountOut\x12 \n" +
+	"\vpriceImpact\x18\x05 \x01(\x01R\vpriceImpact\x12 \n" +
+	"\vgasEstimate\x18\x06 \x01(\x04R\vgasEstimate\x12,\n" +
+	"\x11executionPriceUsd\x18\a \x01(\tR\x11executionPriceUsd\x12 \n" +
+	"\vpoolAddress\x18\b \x01(\tR\vpoolAddress\"\x14\n" +
+	"\x12GetGasPriceRequest\"W\n" +
+	"\vGasFeeLevel\x12(\n" +
+	"\x0fpriorityFeeGwei\x18\x01 \x01(\tR\x0fpriorityFeeGwei\x12\x1e\n" +
+	"\n" +
+	"maxFeeGwei\x18\x02 \x01(\tR\n" +
+	"maxFeeGwei\"\xa1\x02\n" +
+	"\x13GetGasPriceResponse\x12 \n" +
+	"\vblockNumber\x18\x01 \x01(\x04R\vblockNumber\x12 \n" +
+	"\vbaseFeeGwei\x18\x02 \x01(\tR\vbaseFeeGwei\x12(\n" +
+	"\x0fnextBaseFeeGwei\x18\x03 \x01(\tR\x0fnextBaseFeeGwei\x12&\n" +
+	"\x04slow\x18\x04 \x01(\v2\x12.token.GasFeeLevelR\x04slow\x12.\n" +
+	"\bstandard\x18\x05 \x01(\v2\x12.token.GasFeeLevelR\bstandard\x12&\n" +
+	"\x04fast\x18\x06 \x01(\v2\x12.token.GasFeeLevelR\x04fast\x12\x1c\n" +
+	"\tupdatedAt\x18\a \x01(\x03R\tupdatedAt\"\xf1\x02\n" +
+	"\aCronJob\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12(\n" +
+	"\x0fintervalSeconds\x18\x02 \x01(\x03R\x0fintervalSeconds\x12&\n" +
+	"\x0etimeoutSeconds\x18\x03 \x01(\x03R\x0etimeoutSeconds\x12\x18\n" +
+	"\arunning\x18\x04 \x01(\bR\arunning\x12$\n" +
+	"\rlastStartedAt\x18\x05 \x01(\x03R\rlastStartedAt\x12&\n" +
+	"\x0elastFinishedAt\x18\x06 \x01(\x03R\x0elastFinishedAt\x12&\n" +
+	"\x0elastDurationMs\x18\a \x01(\x03R\x0elastDurationMs\x12\x1e\n" +
+	"\n" +
+	"lastResult\x18\b \x01(\tR\n" +
+	"lastResult\x12\x12\n" +
+	"\x04runs\x18\t \x01(\x03R\x04runs\x12\x1e\n" +
+	"\n" +
+	"missedRuns\x18\n" +
+	" \x01(\x03R\n" +
+	"missedRuns\x12\x1c\n" +
+	"\tnextRunAt\x18\v \x01(\x03R\tnextRunAt\"\x15\n" +
+	"\x13ListCronJobsRequest\":\n" +
+	"\x14ListCronJobsResponse\x12\"\n" +
+	"\x04jobs\x18\x01 \x03(\v2\x0e.token.CronJobR\x04jobs\"'\n" +
+	"\x11RunCronJobRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"P\n" +
+	"\x12RunCronJobResponse\x12\x18\n" +
+	"\astarted\x18\x01 \x01(\bR\astarted\x12 \n" +
+	"\x03job\x18\x02 \x01(\v2\x0e.token.CronJobR\x03job\">\n" +
+	"\x18StreamTokenTradesRequest\x12\"\n" +
+	"\ftokenAddress\x18\x01 \x01(\tR\ftokenAddress\"\xb2\x02\n" +
+	"\n" +
+	"TokenTrade\x12\"\n" +
+	"\ftokenAddress\x18\x01 \x01(\tR\ftokenAddress\x12$\n" +
+	"\x04side\x18\x02 \x01(\x0e2\x10.token.TradeSideR\x04side\x12\x1c\n" +
+	"\tamountUsd\x18\x03 \x01(\x01R\tamountUsd\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\tR\x05price\x12 \n" +
+	"\vtokenAmount\x18\x05 \x01(\tR\vtokenAmount\x12\x16\n" +
+	"\x06txHash\x18\x06 \x01(\tR\x06txHash\x12\x14\n" +
+	"\x05maker\x18\a \x01(\tR\x05maker\x12 \n" +
+	"\vblockNumber\x18\b \x01(\x04R\vblockNumber\x12\x16\n" +
+	"\x06sender\x18\t \x01(\tR\x06sender\x12\x1c\n" +
+	"\trecipient\x18\n" +
+	" \x01(\tR\trecipient\"S\n" +
+	"\x13GetTokenRiskRequest\x12\"\n" +
+	"\ftokenAddress\x18\x01 \x01(\tR\ftokenAddress\x12\x18\n" +
+	"\arefresh\x18\x02 \x01(\bR\arefresh\"j\n" +
+	"\n" +
+	"RiskFactor\x12\x16\n" +
+	"\x06signal\x18\x01 \x01(\tR\x06signal\x12\x16\n" +
+	"\x06points\x18\x02 \x01(\x05R\x06points\x12\x16\n" +
+	"\x06detail\x18\x03 \x01(\tR\x06detail\x12\x14\n" +
+	"\x05known\x18\x04 \x01(\bR\x05known\"\xc7\x01\n" +
+	"\x14GetTokenRiskResponse\x12\"\n" +
+	"\ftokenAddress\x18\x01 \x01(\tR\ftokenAddress\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x05R\x05score\x12+\n" +
+	"\afactors\x18\x03 \x03(\v2\x11.token.RiskFactorR\afactors\x12\x1c\n" +
+	"\tcheckedAt\x18\x04 \x01(\x03R\tcheckedAt\x12*\n" +
+	"\x10uncheckedSignals\x18\x05 \x03(\tR\x10uncheckedSignals\"\x14\n" +
+	"\x12GetApiUsageRequest\"=\n" +
+	"\x13GetApiUsageResponse\x12&\n" +
+	"\x05usage\x18\x01 \x01(\v2\x10.common.ApiUsageR\x05usage\"m\n" +
+	"\x11TokenPriceAtQuery\x12\"\n" +
+	"\ftokenAddress\x18\x01 \x01(\tR\ftokenAddress\x12\x1c\n" +
+	"\ttimestamp\x18\x02 \x01(\x03R\ttimestamp\x12\x16\n" +
+	"\x06maxAge\x18\x03 \x01(\x03R\x06maxAge\"M\n" +
+	"\x17GetTokenPricesAtRequest\x122\n" +
+	"\aqueries\x18\x01 \x03(\v2\x18.token.TokenPriceAtQueryR\aqueries\"\x9c\x01\n" +
+	"\fTokenPriceAt\x12\"\n" +
+	"\ftokenAddress\x18\x01 \x01(\tR\ftokenAddress\x12\x1c\n" +
+	"\ttimestamp\x18\x02 \x01(\x03R\ttimestamp\x12\x14\n" +
+	"\x05found\x18\x03 \x01(\bR\x05found\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\tR\x05price\x12\x1e\n" +
+	"\n" +
+	"recordedAt\x18\x05 \x01(\x03R\n" +
+	"recordedAt\"G\n" +
+	"\x18GetTokenPricesAtResponse\x12+\n" +
+	"\x06prices\x18\x01 \x03(\v2\x13.token.TokenPriceAtR\x06prices\"-\n" +
+	"\x13StreamTokensRequest\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\tR\x06cursor\"\xa6\x01\n" +
+	"\vTokenChange\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12\"\n" +
+	"\ftokenAddress\x18\x02 \x01(\tR\ftokenAddress\x12\x1c\n" +
+	"\tchangedAt\x18\x03 \x01(\x03R\tchangedAt\x12\x18\n" +
+	"\adeleted\x18\x04 \x01(\bR\adeleted\x12#\n" +
+	"\x05token\x18\x05 \x01(\v2\r.common.TokenR\x05token*?\n" +
 	"\x0fTokenAddingType\x12\r\n" +
 	"\tDUPLICATE\x10\x00\x12\x0e\n" +
 	"\n" +
@@ -850,7 +5478,33 @@ const file_token_messages_proto_rawDesc = "" +
 	"\x11TokenRemovingType\x12\x14\n" +
 	"\x10STILL_CALCULATES\x10\x00\x12\r\n" +
 	"\tALL_CLEAR\x10\x01\x12\x10\n" +
-	"\fREMOVE_ERROR\x10\x02B\x17Z\x15tokendata/proto/tokenb\x06proto3"
+	"\fREMOVE_ERROR\x10\x02*9\n" +
+	"\x10ResolveInputType\x12\x12\n" +
+	"\x0eRESOLVED_TOKEN\x10\x00\x12\x11\n" +
+	"\rRESOLVED_POOL\x10\x01*4\n" +
+	"\bPoolType\x12\x13\n" +
+	"\x0fPOOL_UNISWAP_V3\x10\x00\x12\x13\n" +
+	"\x0fPOOL_UNISWAP_V4\x10\x01*\xaa\x01\n" +
+	"\tTokenSort\x12\x10\n" +
+	"\fSORT_DEFAULT\x10\x00\x12\x1a\n" +
+	"\x16SORT_DEPLOYER_RISK_ASC\x10\x01\x12\x1b\n" +
+	"\x17SORT_DEPLOYER_RISK_DESC\x10\x02\x12\x18\n" +
+	"\x14SORT_MARKET_CAP_DESC\x10\x03\x12\x11\n" +
+	"\rSORT_RISK_ASC\x10\x04\x12\x12\n" +
+	"\x0eSORT_RISK_DESC\x10\x05\x12\x11\n" +
+	"\rSORT_TRENDING\x10\x06*Y\n" +
+	"\x13BlacklistChangeType\x12\x16\n" +
+	"\x12BLACKLIST_SNAPSHOT\x10\x00\x12\x13\n" +
+	"\x0fBLACKLIST_ADDED\x10\x01\x12\x15\n" +
+	"\x11BLACKLIST_REMOVED\x10\x02**\n" +
+	"\tQuoteSide\x12\r\n" +
+	"\tQUOTE_BUY\x10\x00\x12\x0e\n" +
+	"\n" +
+	"QUOTE_SELL\x10\x01**\n" +
+	"\tTradeSide\x12\r\n" +
+	"\tTRADE_BUY\x10\x00\x12\x0e\n" +
+	"\n" +
+	"TRADE_SELL\x10\x01B\x17Z\x15tokendata/proto/tokenb\x06proto3"
 
 var (
 	file_token_messages_proto_rawDescOnce sync.Once
@@ -864,35 +5518,141 @@ func file_token_messages_proto_rawDescGZIP() []byte {
 	return file_token_messages_proto_rawDescData
 }
 
-var file_token_messages_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_token_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_token_messages_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_token_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 74)
 var file_token_messages_proto_goTypes = []any{
-	(TokenAddingType)(0),          // 0: token.TokenAddingType
-	(TokenRemovingType)(0),        // 1: token.TokenRemovingType
-	(*AddTokenRequest)(nil),       // 2: token.AddTokenRequest
-	(*AddTokenResponse)(nil),      // 3: token.AddTokenResponse
-	(*GetTokenRequest)(nil),       // 4: token.GetTokenRequest
-	(*GetTokenPriceRequest)(nil),  // 5: token.GetTokenPriceRequest
-	(*GetTokenPriceResponse)(nil), // 6: token.GetTokenPriceResponse
-	(*GetTokenResponse)(nil),      // 7: token.GetTokenResponse
-	(*RemoveTokenRequest)(nil),    // 8: token.RemoveTokenRequest
-	(*RemoveTokenResponse)(nil),   // 9: token.RemoveTokenResponse
-	(*GetTokensRequest)(nil),      // 10: token.GetTokensRequest
-	(*GetTokensResponse)(nil),     // 11: token.GetTokensResponse
-	(*AddBlacklistRequest)(nil),   // 12: token.AddBlacklistRequest
-	(*AddBlacklistResponse)(nil),  // 13: token.AddBlacklistResponse
-	(*common.Token)(nil),          // 14: common.Token
+	(TokenAddingType)(0),                     // 0: token.TokenAddingType
+	(TokenRemovingType)(0),                   // 1: token.TokenRemovingType
+	(ResolveInputType)(0),                    // 2: token.ResolveInputType
+	(PoolType)(0),                            // 3: token.PoolType
+	(TokenSort)(0),                           // 4: token.TokenSort
+	(BlacklistChangeType)(0),                 // 5: token.BlacklistChangeType
+	(QuoteSide)(0),                           // 6: token.QuoteSide
+	(TradeSide)(0),                           // 7: token.TradeSide
+	(*AddTokenRequest)(nil),                  // 8: token.AddTokenRequest
+	(*AddTokenResponse)(nil),                 // 9: token.AddTokenResponse
+	(*AddTokensRequest)(nil),                 // 10: token.AddTokensRequest
+	(*AddTokensResponse)(nil),                // 11: token.AddTokensResponse
+	(*AddPoolRequest)(nil),                   // 12: token.AddPoolRequest
+	(*AddPoolResponse)(nil),                  // 13: token.AddPoolResponse
+	(*SetTokenPoolRequest)(nil),              // 14: token.SetTokenPoolRequest
+	(*SetTokenPoolResponse)(nil),             // 15: token.SetTokenPoolResponse
+	(*SetTokenMinSwapUsdRequest)(nil),        // 16: token.SetTokenMinSwapUsdRequest
+	(*SetTokenMinSwapUsdResponse)(nil),       // 17: token.SetTokenMinSwapUsdResponse
+	(*ResolveRequest)(nil),                   // 18: token.ResolveRequest
+	(*ResolveResponse)(nil),                  // 19: token.ResolveResponse
+	(*GetTokenRequest)(nil),                  // 20: token.GetTokenRequest
+	(*GetTokenPriceRequest)(nil),             // 21: token.GetTokenPriceRequest
+	(*GetTokenPriceResponse)(nil),            // 22: token.GetTokenPriceResponse
+	(*GetTokenResponse)(nil),                 // 23: token.GetTokenResponse
+	(*RemoveTokenRequest)(nil),               // 24: token.RemoveTokenRequest
+	(*RemoveTokenResponse)(nil),              // 25: token.RemoveTokenResponse
+	(*GetTokensRequest)(nil),                 // 26: token.GetTokensRequest
+	(*GetTokensResponse)(nil),                // 27: token.GetTokensResponse
+	(*GetTokenV2Response)(nil),               // 28: token.GetTokenV2Response
+	(*GetTokensV2Response)(nil),              // 29: token.GetTokensV2Response
+	(*AddBlacklistRequest)(nil),              // 30: token.AddBlacklistRequest
+	(*AddBlacklistResponse)(nil),             // 31: token.AddBlacklistResponse
+	(*RemoveBlacklistRequest)(nil),           // 32: token.RemoveBlacklistRequest
+	(*RemoveBlacklistResponse)(nil),          // 33: token.RemoveBlacklistResponse
+	(*GetBlacklistRequest)(nil),              // 34: token.GetBlacklistRequest
+	(*GetBlacklistResponse)(nil),             // 35: token.GetBlacklistResponse
+	(*WatchBlacklistRequest)(nil),            // 36: token.WatchBlacklistRequest
+	(*BlacklistChange)(nil),                  // 37: token.BlacklistChange
+	(*GetTokenHoldersRequest)(nil),           // 38: token.GetTokenHoldersRequest
+	(*TokenHolder)(nil),                      // 39: token.TokenHolder
+	(*GetTokenHoldersResponse)(nil),          // 40: token.GetTokenHoldersResponse
+	(*DegradationMode)(nil),                  // 41: token.DegradationMode
+	(*SetDegradationModeRequest)(nil),        // 42: token.SetDegradationModeRequest
+	(*GetDegradationModeRequest)(nil),        // 43: token.GetDegradationModeRequest
+	(*DegradationModeResponse)(nil),          // 44: token.DegradationModeResponse
+	(*TokenLocalization)(nil),                // 45: token.TokenLocalization
+	(*SetTokenLocalizationRequest)(nil),      // 46: token.SetTokenLocalizationRequest
+	(*SetTokenLocalizationResponse)(nil),     // 47: token.SetTokenLocalizationResponse
+	(*RemoveTokenLocalizationRequest)(nil),   // 48: token.RemoveTokenLocalizationRequest
+	(*RemoveTokenLocalizationResponse)(nil),  // 49: token.RemoveTokenLocalizationResponse
+	(*ListTokenLocalizationsRequest)(nil),    // 50: token.ListTokenLocalizationsRequest
+	(*ListTokenLocalizationsResponse)(nil),   // 51: token.ListTokenLocalizationsResponse
+	(*DiscoveredToken)(nil),                  // 52: token.DiscoveredToken
+	(*GetDiscoveryFeedRequest)(nil),          // 53: token.GetDiscoveryFeedRequest
+	(*GetDiscoveryFeedResponse)(nil),         // 54: token.GetDiscoveryFeedResponse
+	(*GetRecentLaunchesRequest)(nil),         // 55: token.GetRecentLaunchesRequest
+	(*RecentLaunch)(nil),                     // 56: token.RecentLaunch
+	(*GetRecentLaunchesResponse)(nil),        // 57: token.GetRecentLaunchesResponse
+	(*SubscribeFilteredLaunchesRequest)(nil), // 58: token.SubscribeFilteredLaunchesRequest
+	(*GetQuoteRequest)(nil),                  // 59: token.GetQuoteRequest
+	(*GetQuoteResponse)(nil),                 // 60: token.GetQuoteResponse
+	(*GetGasPriceRequest)(nil),               // 61: token.GetGasPriceRequest
+	(*GasFeeLevel)(nil),                      // 62: token.GasFeeLevel
+	(*GetGasPriceResponse)(nil),              // 63: token.GetGasPriceResponse
+	(*CronJob)(nil),                          // 64: token.CronJob
+	(*ListCronJobsRequest)(nil),              // 65: token.ListCronJobsRequest
+	(*ListCronJobsResponse)(nil),             // 66: token.ListCronJobsResponse
+	(*RunCronJobRequest)(nil),                // 67: token.RunCronJobRequest
+	(*RunCronJobResponse)(nil),               // 68: token.RunCronJobResponse
+	(*StreamTokenTradesRequest)(nil),         // 69: token.StreamTokenTradesRequest
+	(*TokenTrade)(nil),                       // 70: token.TokenTrade
+	(*GetTokenRiskRequest)(nil),              // 71: token.GetTokenRiskRequest
+	(*RiskFactor)(nil),                       // 72: token.RiskFactor
+	(*GetTokenRiskResponse)(nil),             // 73: token.GetTokenRiskResponse
+	(*GetApiUsageRequest)(nil),               // 74: token.GetApiUsageRequest
+	(*GetApiUsageResponse)(nil),              // 75: token.GetApiUsageResponse
+	(*TokenPriceAtQuery)(nil),                // 76: token.TokenPriceAtQuery
+	(*GetTokenPricesAtRequest)(nil),          // 77: token.GetTokenPricesAtRequest
+	(*TokenPriceAt)(nil),                     // 78: token.TokenPriceAt
+	(*GetTokenPricesAtResponse)(nil),         // 79: token.GetTokenPricesAtResponse
+	(*StreamTokensRequest)(nil),              // 80: token.StreamTokensRequest
+	(*TokenChange)(nil),                      // 81: token.TokenChange
+	(*common.Token)(nil),                     // 82: common.Token
+	(*common.TokenOrderflow)(nil),            // 83: common.TokenOrderflow
+	(*common.TokenV2)(nil),                   // 84: common.TokenV2
+	(*common.ApiUsage)(nil),                  // 85: common.ApiUsage
 }
 var file_token_messages_proto_depIdxs = []int32{
 	0,  // 0: token.AddTokenResponse.type:type_name -> token.TokenAddingType
-	14, // 1: token.GetTokenResponse.token:type_name -> common.Token
-	1,  // 2: token.RemoveTokenResponse.type:type_name -> token.TokenRemovingType
-	14, // 3: token.GetTokensResponse.tokens:type_name -> common.Token
-	4,  // [4:4] is the sub-list for method output_type
-	4,  // [4:4] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	8,  // 1: token.AddTokensRequest.tokens:type_name -> token.AddTokenRequest
+	9,  // 2: token.AddTokensResponse.results:type_name -> token.AddTokenResponse
+	0,  // 3: token.AddPoolResponse.type:type_name -> token.TokenAddingType
+	3,  // 4: token.SetTokenPoolRequest.poolType:type_name -> token.PoolType
+	3,  // 5: token.SetTokenPoolResponse.poolType:type_name -> token.PoolType
+	2,  // 6: token.ResolveResponse.inputType:type_name -> token.ResolveInputType
+	82, // 7: token.ResolveResponse.token:type_name -> common.Token
+	82, // 8: token.GetTokenResponse.token:type_name -> common.Token
+	83, // 9: token.GetTokenResponse.orderflow:type_name -> common.TokenOrderflow
+	1,  // 10: token.RemoveTokenResponse.type:type_name -> token.TokenRemovingType
+	4,  // 11: token.GetTokensRequest.sort:type_name -> token.TokenSort
+	82, // 12: token.GetTokensResponse.tokens:type_name -> common.Token
+	84, // 13: token.GetTokenV2Response.token:type_name -> common.TokenV2
+	83, // 14: token.GetTokenV2Response.orderflow:type_name -> common.TokenOrderflow
+	84, // 15: token.GetTokensV2Response.tokens:type_name -> common.TokenV2
+	5,  // 16: token.BlacklistChange.type:type_name -> token.BlacklistChangeType
+	39, // 17: token.GetTokenHoldersResponse.holders:type_name -> token.TokenHolder
+	41, // 18: token.SetDegradationModeRequest.mode:type_name -> token.DegradationMode
+	41, // 19: token.DegradationModeResponse.effective:type_name -> token.DegradationMode
+	41, // 20: token.DegradationModeResponse.manual:type_name -> token.DegradationMode
+	45, // 21: token.SetTokenLocalizationRequest.localization:type_name -> token.TokenLocalization
+	45, // 22: token.SetTokenLocalizationResponse.localization:type_name -> token.TokenLocalization
+	45, // 23: token.ListTokenLocalizationsResponse.localizations:type_name -> token.TokenLocalization
+	52, // 24: token.GetDiscoveryFeedResponse.tokens:type_name -> token.DiscoveredToken
+	83, // 25: token.RecentLaunch.orderflow:type_name -> common.TokenOrderflow
+	56, // 26: token.GetRecentLaunchesResponse.launches:type_name -> token.RecentLaunch
+	6,  // 27: token.GetQuoteRequest.side:type_name -> token.QuoteSide
+	62, // 28: token.GetGasPriceResponse.slow:type_name -> token.GasFeeLevel
+	62, // 29: token.GetGasPriceResponse.standard:type_name -> token.GasFeeLevel
+	62, // 30: token.GetGasPriceResponse.fast:type_name -> token.GasFeeLevel
+	64, // 31: token.ListCronJobsResponse.jobs:type_name -> token.CronJob
+	64, // 32: token.RunCronJobResponse.job:type_name -> token.CronJob
+	7,  // 33: token.TokenTrade.side:type_name -> token.TradeSide
+	72, // 34: token.GetTokenRiskResponse.factors:type_name -> token.RiskFactor
+	85, // 35: token.GetApiUsageResponse.usage:type_name -> common.ApiUsage
+	76, // 36: token.GetTokenPricesAtRequest.queries:type_name -> token.TokenPriceAtQuery
+	78, // 37: token.GetTokenPricesAtResponse.prices:type_name -> token.TokenPriceAt
+	82, // 38: token.TokenChange.token:type_name -> common.Token
+	39, // [39:39] is the sub-list for method output_type
+	39, // [39:39] is the sub-list for method input_type
+	39, // [39:39] is the sub-list for extension type_name
+	39, // [39:39] is the sub-list for extension extendee
+	0,  // [0:39] is the sub-list for field type_name
 }
 
 func init() { file_token_messages_proto_init() }
@@ -901,15 +5661,24 @@ func file_token_messages_proto_init() {
 		return
 	}
 	file_token_messages_proto_msgTypes[0].OneofWrappers = []any{}
-	file_token_messages_proto_msgTypes[3].OneofWrappers = []any{}
-	file_token_messages_proto_msgTypes[6].OneofWrappers = []any{}
+	file_token_messages_proto_msgTypes[4].OneofWrappers = []any{}
+	file_token_messages_proto_msgTypes[10].OneofWrappers = []any{}
+	file_token_messages_proto_msgTypes[13].OneofWrappers = []any{}
+	file_token_messages_proto_msgTypes[16].OneofWrappers = []any{}
+	file_token_messages_proto_msgTypes[18].OneofWrappers = []any{}
+	file_token_messages_proto_msgTypes[30].OneofWrappers = []any{}
+	file_token_messages_proto_msgTypes[37].OneofWrappers = []any{}
+	file_token_messages_proto_msgTypes[45].OneofWrappers = []any{}
+	file_token_messages_proto_msgTypes[47].OneofWrappers = []any{}
+	file_token_messages_proto_msgTypes[48].OneofWrappers = []any{}
+	file_token_messages_proto_msgTypes[50].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_token_messages_proto_rawDesc), len(file_token_messages_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   12,
+			NumEnums:      8,
+			NumMessages:   74,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -24,44 +24,183 @@ var File_token_token_proto protoreflect.FileDescriptor
 
 const file_token_token_proto_rawDesc = "" +
 	"\n" +
-	"\x11token/token.proto\x12\rscanner_token\x1a\x14token/messages.proto2\xa3\x03\n" +
-	"\fScannerToken\x12;\n" +
-	"\bgetToken\x12\x16.token.GetTokenRequest\x1a\x17.token.GetTokenResponse\x12>\n" +
-	"\tgetTokens\x12\x17.token.GetTokensRequest\x1a\x18.token.GetTokensResponse\x12J\n" +
+	"\x11token/token.proto\x12\rscanner_token\x1a\x14token/messages.proto2\xad\x14\n" +
+	"\fScannerToken\x12@\n" +
+	"\bgetToken\x12\x16.token.GetTokenRequest\x1a\x17.token.GetTokenResponse\"\x03\x88\x02\x01\x12C\n" +
+	"\tgetTokens\x12\x17.token.GetTokensRequest\x1a\x18.token.GetTokensResponse\"\x03\x88\x02\x01\x12?\n" +
+	"\n" +
+	"getTokenV2\x12\x16.token.GetTokenRequest\x1a\x19.token.GetTokenV2Response\x12B\n" +
+	"\vgetTokensV2\x12\x17.token.GetTokensRequest\x1a\x1a.token.GetTokensV2Response\x12J\n" +
 	"\rgetTokenPrice\x12\x1b.token.GetTokenPriceRequest\x1a\x1c.token.GetTokenPriceResponse\x12;\n" +
-	"\baddToken\x12\x16.token.AddTokenRequest\x1a\x17.token.AddTokenResponse\x12D\n" +
+	"\baddToken\x12\x16.token.AddTokenRequest\x1a\x17.token.AddTokenResponse\x12>\n" +
+	"\taddTokens\x12\x17.token.AddTokensRequest\x1a\x18.token.AddTokensResponse\x128\n" +
+	"\aaddPool\x12\x15.token.AddPoolRequest\x1a\x16.token.AddPoolResponse\x12G\n" +
+	"\fsetTokenPool\x12\x1a.token.SetTokenPoolRequest\x1a\x1b.token.SetTokenPoolResponse\x12Y\n" +
+	"\x12setTokenMinSwapUsd\x12 .token.SetTokenMinSwapUsdRequest\x1a!.token.SetTokenMinSwapUsdResponse\x128\n" +
+	"\aresolve\x12\x15.token.ResolveRequest\x1a\x16.token.ResolveResponse\x12D\n" +
 	"\vremoveToken\x12\x19.token.RemoveTokenRequest\x1a\x1a.token.RemoveTokenResponse\x12G\n" +
-	"\faddBlacklist\x12\x1a.token.AddBlacklistRequest\x1a\x1b.token.AddBlacklistResponseB\x17Z\x15tokendata/proto/tokenb\x06proto3"
+	"\faddBlacklist\x12\x1a.token.AddBlacklistRequest\x1a\x1b.token.AddBlacklistResponse\x12P\n" +
+	"\x0fremoveBlacklist\x12\x1d.token.RemoveBlacklistRequest\x1a\x1e.token.RemoveBlacklistResponse\x12G\n" +
+	"\fgetBlacklist\x12\x1a.token.GetBlacklistRequest\x1a\x1b.token.GetBlacklistResponse\x12H\n" +
+	"\x0ewatchBlacklist\x12\x1c.token.WatchBlacklistRequest\x1a\x16.token.BlacklistChange0\x01\x12I\n" +
+	"\x11streamTokenTrades\x12\x1f.token.StreamTokenTradesRequest\x1a\x11.token.TokenTrade0\x01\x12P\n" +
+	"\x0fgetTokenHolders\x12\x1d.token.GetTokenHoldersRequest\x1a\x1e.token.GetTokenHoldersResponse\x12V\n" +
+	"\x12setDegradationMode\x12 .token.SetDegradationModeRequest\x1a\x1e.token.DegradationModeResponse\x12V\n" +
+	"\x12getDegradationMode\x12 .token.GetDegradationModeRequest\x1a\x1e.token.DegradationModeResponse\x12_\n" +
+	"\x14setTokenLocalization\x12\".token.SetTokenLocalizationRequest\x1a#.token.SetTokenLocalizationResponse\x12h\n" +
+	"\x17removeTokenLocalization\x12%.token.RemoveTokenLocalizationRequest\x1a&.token.RemoveTokenLocalizationResponse\x12e\n" +
+	"\x16listTokenLocalizations\x12$.token.ListTokenLocalizationsRequest\x1a%.token.ListTokenLocalizationsResponse\x12S\n" +
+	"\x10getDiscoveryFeed\x12\x1e.token.GetDiscoveryFeedRequest\x1a\x1f.token.GetDiscoveryFeedResponse\x12;\n" +
+	"\bgetQuote\x12\x16.token.GetQuoteRequest\x1a\x17.token.GetQuoteResponse\x12D\n" +
+	"\vgetGasPrice\x12\x19.token.GetGasPriceRequest\x1a\x1a.token.GetGasPriceResponse\x12G\n" +
+	"\flistCronJobs\x12\x1a.token.ListCronJobsRequest\x1a\x1b.token.ListCronJobsResponse\x12A\n" +
+	"\n" +
+	"runCronJob\x12\x18.token.RunCronJobRequest\x1a\x19.token.RunCronJobResponse\x12V\n" +
+	"\x11getRecentLaunches\x12\x1f.token.GetRecentLaunchesRequest\x1a .token.GetRecentLaunchesResponse\x12[\n" +
+	"\x19subscribeFilteredLaunches\x12'.token.SubscribeFilteredLaunchesRequest\x1a\x13.token.RecentLaunch0\x01\x12G\n" +
+	"\fgetTokenRisk\x12\x1a.token.GetTokenRiskRequest\x1a\x1b.token.GetTokenRiskResponse\x12D\n" +
+	"\vgetApiUsage\x12\x19.token.GetApiUsageRequest\x1a\x1a.token.GetApiUsageResponse\x12S\n" +
+	"\x10getTokenPricesAt\x12\x1e.token.GetTokenPricesAtRequest\x1a\x1f.token.GetTokenPricesAtResponse\x12@\n" +
+	"\fstreamTokens\x12\x1a.token.StreamTokensRequest\x1a\x12.token.TokenChange0\x01B\x17Z\x15tokendata/proto/tokenb\x06proto3"
 
 var file_token_token_proto_goTypes = []any{
-	(*GetTokenRequest)(nil),       // 0: token.GetTokenRequest
-	(*GetTokensRequest)(nil),      // 1: token.GetTokensRequest
-	(*GetTokenPriceRequest)(nil),  // 2: token.GetTokenPriceRequest
-	(*AddTokenRequest)(nil),       // 3: token.AddTokenRequest
-	(*RemoveTokenRequest)(nil),    // 4: token.RemoveTokenRequest
-	(*AddBlacklistRequest)(nil),   // 5: token.AddBlacklistRequest
-	(*GetTokenResponse)(nil),      // 6: token.GetTokenResponse
-	(*GetTokensResponse)(nil),     // 7: token.GetTokensResponse
-	(*GetTokenPriceResponse)(nil), // 8: token.GetTokenPriceResponse
-	(*AddTokenResponse)(nil),      // 9: token.AddTokenResponse
-	(*RemoveTokenResponse)(nil),   // 10: token.RemoveTokenResponse
-	(*AddBlacklistResponse)(nil),  // 11: token.AddBlacklistResponse
+	(*GetTokenRequest)(nil),                  // 0: token.GetTokenRequest
+	(*GetTokensRequest)(nil),                 // 1: token.GetTokensRequest
+	(*GetTokenPriceRequest)(nil),             // 2: token.GetTokenPriceRequest
+	(*AddTokenRequest)(nil),                  // 3: token.AddTokenRequest
+	(*AddTokensRequest)(nil),                 // 4: token.AddTokensRequest
+	(*AddPoolRequest)(nil),                   // 5: token.AddPoolRequest
+	(*SetTokenPoolRequest)(nil),              // 6: token.SetTokenPoolRequest
+	(*SetTokenMinSwapUsdRequest)(nil),        // 7: token.SetTokenMinSwapUsdRequest
+	(*ResolveRequest)(nil),                   // 8: token.ResolveRequest
+	(*RemoveTokenRequest)(nil),               // 9: token.RemoveTokenRequest
+	(*AddBlacklistRequest)(nil),              // 10: token.AddBlacklistRequest
+	(*RemoveBlacklistRequest)(nil),           // 11: token.RemoveBlacklistRequest
+	(*GetBlacklistRequest)(nil),              // 12: token.GetBlacklistRequest
+	(*WatchBlacklistRequest)(nil),            // 13: token.WatchBlacklistRequest
+	(*StreamTokenTradesRequest)(nil),         // 14: token.StreamTokenTradesRequest
+	(*GetTokenHoldersRequest)(nil),           // 15: token.GetTokenHoldersRequest
+	(*SetDegradationModeRequest)(nil),        // 16: token.SetDegradationModeRequest
+	(*GetDegradationModeRequest)(nil),        // 17: token.GetDegradationModeRequest
+	(*SetTokenLocalizationRequest)(nil),      // 18: token.SetTokenLocalizationRequest
+	(*RemoveTokenLocalizationRequest)(nil),   // 19: token.RemoveTokenLocalizationRequest
+	(*ListTokenLocalizationsRequest)(nil),    // 20: token.ListTokenLocalizationsRequest
+	(*GetDiscoveryFeedRequest)(nil),          // 21: token.GetDiscoveryFeedRequest
+	(*GetQuoteRequest)(nil),                  // 22: token.GetQuoteRequest
+	(*GetGasPriceRequest)(nil),               // 23: token.GetGasPriceRequest
+	(*ListCronJobsRequest)(nil),              // 24: token.ListCronJobsRequest
+	(*RunCronJobRequest)(nil),                // 25: token.RunCronJobRequest
+	(*GetRecentLaunchesRequest)(nil),         // 26: token.GetRecentLaunchesRequest
+	(*SubscribeFilteredLaunchesRequest)(nil), // 27: token.SubscribeFilteredLaunchesRequest
+	(*GetTokenRiskRequest)(nil),              // 28: token.GetTokenRiskRequest
+	(*GetApiUsageRequest)(nil),               // 29: token.GetApiUsageRequest
+	(*GetTokenPricesAtRequest)(nil),          // 30: token.GetTokenPricesAtRequest
+	(*StreamTokensRequest)(nil),              // 31: token.StreamTokensRequest
+	(*GetTokenResponse)(nil),                 // 32: token.GetTokenResponse
+	(*GetTokensResponse)(nil),                // 33: token.GetTokensResponse
+	(*GetTokenV2Response)(nil),               // 34: token.GetTokenV2Response
+	(*GetTokensV2Response)(nil),              // 35: token.GetTokensV2Response
+	(*GetTokenPriceResponse)(nil),            // 36: token.GetTokenPriceResponse
+	(*AddTokenResponse)(nil),                 // 37: token.AddTokenResponse
+	(*AddTokensResponse)(nil),                // 38: token.AddTokensResponse
+	(*AddPoolResponse)(nil),                  // 39: token.AddPoolResponse
+	(*SetTokenPoolResponse)(nil),             // 40: token.SetTokenPoolResponse
+	(*SetTokenMinSwapUsdResponse)(nil),       // 41: token.SetTokenMinSwapUsdResponse
+	(*ResolveResponse)(nil),                  // 42: token.ResolveResponse
+	(*RemoveTokenResponse)(nil),              // 43: token.RemoveTokenResponse
+	(*AddBlacklistResponse)(nil),             // 44: token.AddBlacklistResponse
+	(*RemoveBlacklistResponse)(nil),          // 45: token.RemoveBlacklistResponse
+	(*GetBlacklistResponse)(nil),             // 46: token.GetBlacklistResponse
+	(*BlacklistChange)(nil),                  // 47: token.BlacklistChange
+	(*TokenTrade)(nil),                       // 48: token.TokenTrade
+	(*GetTokenHoldersResponse)(nil),          // 49: token.GetTokenHoldersResponse
+	(*DegradationModeResponse)(nil),          // 50: token.DegradationModeResponse
+	(*SetTokenLocalizationResponse)(nil),     // 51: token.SetTokenLocalizationResponse
+	(*RemoveTokenLocalizationResponse)(nil),  // 52: token.RemoveTokenLocalizationResponse
+	(*ListTokenLocalizationsResponse)(nil),   // 53: token.ListTokenLocalizationsResponse
+	(*GetDiscoveryFeedResponse)(nil),         // 54: token.GetDiscoveryFeedResponse
+	(*GetQuoteResponse)(nil),                 // 55: token.GetQuoteResponse
+	(*GetGasPriceResponse)(nil),              // 56: token.GetGasPriceResponse
+	(*ListCronJobsResponse)(nil),             // 57: token.ListCronJobsResponse
+	(*RunCronJobResponse)(nil),               // 58: token.RunCronJobResponse
+	(*GetRecentLaunchesResponse)(nil),        // 59: token.GetRecentLaunchesResponse
+	(*RecentLaunch)(nil),                     // 60: token.RecentLaunch
+	(*GetTokenRiskResponse)(nil),             // 61: token.GetTokenRiskResponse
+	(*GetApiUsageResponse)(nil),              // 62: token.GetApiUsageResponse
+	(*GetTokenPricesAtResponse)(nil),         // 63: token.GetTokenPricesAtResponse
+	(*TokenChange)(nil),                      // 64: token.TokenChange
 }
 var file_token_token_proto_depIdxs = []int32{
 	0,  // 0: scanner_token.ScannerToken.getToken:input_type -> token.GetTokenRequest
 	1,  // 1: scanner_token.ScannerToken.getTokens:input_type -> token.GetTokensRequest
-	2,  // 2: scanner_token.ScannerToken.getTokenPrice:input_type -> token.GetTokenPriceRequest
-	3,  // 3: scanner_token.ScannerToken.addToken:input_type -> token.AddTokenRequest
-	4,  // 4: scanner_token.ScannerToken.removeToken:input_type -> token.RemoveTokenRequest
-	5,  // 5: scanner_token.ScannerToken.addBlacklist:input_type -> token.AddBlacklistRequest
-	6,  // 6: scanner_token.ScannerToken.getToken:output_type -> token.GetTokenResponse
-	7,  // 7: scanner_token.ScannerToken.getTokens:output_type -> token.GetTokensResponse
-	8,  // 8: scanner_token.ScannerToken.getTokenPrice:output_type -> token.GetTokenPriceResponse
-	9,  // 9: scanner_token.ScannerToken.addToken:output_type -> token.AddTokenResponse
-	10, // 10: scanner_token.ScannerToken.removeToken:output_type -> token.RemoveTokenResponse
-	11, // 11: scanner_token.ScannerToken.addBlacklist:output_type -> token.AddBlacklistResponse
-	6,  // [6:12] is the sub-list for method output_type
-	0,  // [0:6] is the sub-list for method input_type
+	0,  // 2: scanner_token.ScannerToken.getTokenV2:input_type -> token.GetTokenRequest
+	1,  // 3: scanner_token.ScannerToken.getTokensV2:input_type -> token.GetTokensRequest
+	2,  // 4: scanner_token.ScannerToken.getTokenPrice:input_type -> token.GetTokenPriceRequest
+	3,  // 5: scanner_token.ScannerToken.addToken:input_type -> token.AddTokenRequest
+	4,  // 6: scanner_token.ScannerToken.addTokens:input_type -> token.AddTokensRequest
+	5,  // 7: scanner_token.ScannerToken.addPool:input_type -> token.AddPoolRequest
+	6,  // 8: scanner_token.ScannerToken.setTokenPool:input_type -> token.SetTokenPoolRequest
+	7,  // 9: scanner_token.ScannerToken.setTokenMinSwapUsd:input_type -> token.SetTokenMinSwapUsdRequest
+	8,  // 10: scanner_token.ScannerToken.resolve:input_type -> token.ResolveRequest
+	9,  // 11: scanner_token.ScannerToken.removeToken:input_type -> token.RemoveTokenRequest
+	10, // 12: scanner_token.ScannerToken.addBlacklist:input_type -> token.AddBlacklistRequest
+	11, // 13: scanner_token.ScannerToken.removeBlacklist:input_type -> token.RemoveBlacklistRequest
+	12, // 14: scanner_token.ScannerToken.getBlacklist:input_type -> token.GetBlacklistRequest
+	13, // 15: scanner_token.ScannerToken.watchBlacklist:input_type -> token.WatchBlacklistRequest
+	14, // 16: scanner_token.ScannerToken.streamTokenTrades:input_type -> token.StreamTokenTradesRequest
+	15, // 17: scanner_token.ScannerToken.getTokenHolders:input_type -> token.GetTokenHoldersRequest
+	16, // 18: scanner_token.ScannerToken.setDegradationMode:input_type -> token.SetDegradationModeRequest
+	17, // 19: scanner_token.ScannerToken.getDegradationMode:input_type -> token.GetDegradationModeRequest
+	18, // 20: scanner_token.ScannerToken.setTokenLocalization:input_type -> token.SetTokenLocalizationRequest
+	19, // 21: scanner_token.ScannerToken.removeTokenLocalization:input_type -> token.RemoveTokenLocalizationRequest
+	20, // 22: scanner_token.ScannerToken.listTokenLocalizations:input_type -> token.ListTokenLocalizationsRequest
+	21, // 23: scanner_token.ScannerToken.getDiscoveryFeed:input_type -> token.GetDiscoveryFeedRequest
+	22, // 24: scanner_token.ScannerToken.getQuote:input_type -> token.GetQuoteRequest
+	23, // 25: scanner_token.ScannerToken.getGasPrice:input_type -> token.GetGasPriceRequest
+	24, // 26: scanner_token.ScannerToken.listCronJobs:input_type -> token.ListCronJobsRequest
+	25, // 27: scanner_token.ScannerToken.runCronJob:input_type -> token.RunCronJobRequest
+	26, // 28: scanner_token.ScannerToken.getRecentLaunches:input_type -> token.GetRecentLaunchesRequest
+	27, // 29: scanner_token.ScannerToken.subscribeFilteredLaunches:input_type -> token.SubscribeFilteredLaunchesRequest
+	28, // 30: scanner_token.ScannerToken.getTokenRisk:input_type -> token.GetTokenRiskRequest
+	29, // 31: scanner_token.ScannerToken.getApiUsage:input_type -> token.GetApiUsageRequest
+	30, // 32: scanner_token.ScannerToken.getTokenPricesAt:input_type -> token.GetTokenPricesAtRequest
+	31, // 33: scanner_token.ScannerToken.streamTokens:input_type -> token.StreamTokensRequest
+	32, // 34: scanner_token.ScannerToken.getToken:output_type -> token.GetTokenResponse
+	33, // 35: scanner_token.ScannerToken.getTokens:output_type -> token.GetTokensResponse
+	34, // 36: scanner_token.ScannerToken.getTokenV2:output_type -> token.GetTokenV2Response
+	35, // 37: scanner_token.ScannerToken.getTokensV2:output_type -> token.GetTokensV2Response
+	36, // 38: scanner_token.ScannerToken.getTokenPrice:output_type -> token.GetTokenPriceResponse
+	37, // 39: scanner_token.ScannerToken.addToken:output_type -> token.AddTokenResponse
+	38, // 40: scanner_token.ScannerToken.addTokens:output_type -> token.AddTokensResponse
+	39, // 41: scanner_token.ScannerToken.addPool:output_type -> token.AddPoolResponse
+	40, // 42: scanner_token.ScannerToken.setTokenPool:output_type -> token.SetTokenPoolResponse
+	41, // 43: scanner_token.ScannerToken.setTokenMinSwapUsd:output_type -> token.SetTokenMinSwapUsdResponse
+	42, // 44: scanner_token.ScannerToken.resolve:output_type -> token.ResolveResponse
+	43, // 45: scanner_token.ScannerToken.removeToken:output_type -> token.RemoveTokenResponse
+	44, // 46: scanner_token.ScannerToken.addBlacklist:output_type -> token.AddBlacklistResponse
+	45, // 47: scanner_token.ScannerToken.removeBlacklist:output_type -> token.RemoveBlacklistResponse
+	46, // 48: scanner_token.ScannerToken.getBlacklist:output_type -> token.GetBlacklistResponse
+	47, // 49: scanner_token.ScannerToken.watchBlacklist:output_type -> token.BlacklistChange
+	48, // 50: scanner_token.ScannerToken.streamTokenTrades:output_type -> token.TokenTrade
+	49, // 51: scanner_token.ScannerToken.getTokenHolders:output_type -> token.GetTokenHoldersResponse
+	50, // 52: scanner_token.ScannerToken.setDegradationMode:output_type -> token.DegradationModeResponse
+	50, // 53: scanner_token.ScannerToken.getDegradationMode:output_type -> token.DegradationModeResponse
+	51, // 54: scanner_token.ScannerToken.setTokenLocalization:output_type -> token.SetTokenLocalizationResponse
+	52, // 55: scanner_token.ScannerToken.removeTokenLocalization:output_type -> token.RemoveTokenLocalizationResponse
+	53, // 56: scanner_token.ScannerToken.listTokenLocalizations:output_type -> token.ListTokenLocalizationsResponse
+	54, // 57: scanner_token.ScannerToken.getDiscoveryFeed:output_type -> token.GetDiscoveryFeedResponse
+	55, // 58: scanner_token.ScannerToken.getQuote:output_type -> token.GetQuoteResponse
+	56, // 59: scanner_token.ScannerToken.getGasPrice:output_type -> token.GetGasPriceResponse
+	57, // 60: scanner_token.ScannerToken.listCronJobs:output_type -> token.ListCronJobsResponse
+	58, // 61: scanner_token.ScannerToken.runCronJob:output_type -> token.RunCronJobResponse
+	59, // 62: scanner_token.ScannerToken.getRecentLaunches:output_type -> token.GetRecentLaunchesResponse
+	60, // 63: scanner_token.ScannerToken.subscribeFilteredLaunches:output_type -> token.RecentLaunch
+	61, // 64: scanner_token.ScannerToken.getTokenRisk:output_type -> token.GetTokenRiskResponse
+	62, // 65: scanner_token.ScannerToken.getApiUsage:output_type -> token.GetApiUsageResponse
+	63, // 66: scanner_token.ScannerToken.getTokenPricesAt:output_type -> token.GetTokenPricesAtResponse
+	64, // 67: scanner_token.ScannerToken.streamTokens:output_type -> token.TokenChange
+	34, // [34:68] is the sub-list for method output_type
+	0,  // [0:34] is the sub-list for method input_type
 	0,  // [0:0] is the sub-list for extension type_name
 	0,  // [0:0] is the sub-list for extension extendee
 	0,  // [0:0] is the sub-list for field type_name
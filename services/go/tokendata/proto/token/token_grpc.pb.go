@@ -19,24 +19,95 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ScannerToken_GetToken_FullMethodName      = "/scanner_token.ScannerToken/getToken"
-	ScannerToken_GetTokens_FullMethodName     = "/scanner_token.ScannerToken/getTokens"
-	ScannerToken_GetTokenPrice_FullMethodName = "/scanner_token.ScannerToken/getTokenPrice"
-	ScannerToken_AddToken_FullMethodName      = "/scanner_token.ScannerToken/addToken"
-	ScannerToken_RemoveToken_FullMethodName   = "/scanner_token.ScannerToken/removeToken"
-	ScannerToken_AddBlacklist_FullMethodName  = "/scanner_token.ScannerToken/addBlacklist"
+	ScannerToken_GetToken_FullMethodName                  = "/scanner_token.ScannerToken/getToken"
+	ScannerToken_GetTokens_FullMethodName                 = "/scanner_token.ScannerToken/getTokens"
+	ScannerToken_GetTokenV2_FullMethodName                = "/scanner_token.ScannerToken/getTokenV2"
+	ScannerToken_GetTokensV2_FullMethodName               = "/scanner_token.ScannerToken/getTokensV2"
+	ScannerToken_GetTokenPrice_FullMethodName             = "/scanner_token.ScannerToken/getTokenPrice"
+	ScannerToken_AddToken_FullMethodName                  = "/scanner_token.ScannerToken/addToken"
+	ScannerToken_AddTokens_FullMethodName                 = "/scanner_token.ScannerToken/addTokens"
+	ScannerToken_AddPool_FullMethodName                   = "/scanner_token.ScannerToken/addPool"
+	ScannerToken_SetTokenPool_FullMethodName              = "/scanner_token.ScannerToken/setTokenPool"
+	ScannerToken_SetTokenMinSwapUsd_FullMethodName        = "/scanner_token.ScannerToken/setTokenMinSwapUsd"
+	ScannerToken_Resolve_FullMethodName                   = "/scanner_token.ScannerToken/resolve"
+	ScannerToken_RemoveToken_FullMethodName               = "/scanner_token.ScannerToken/removeToken"
+	ScannerToken_AddBlacklist_FullMethodName              = "/scanner_token.ScannerToken/addBlacklist"
+	ScannerToken_RemoveBlacklist_FullMethodName           = "/scanner_token.ScannerToken/removeBlacklist"
+	ScannerToken_GetBlacklist_FullMethodName              = "/scanner_token.ScannerToken/getBlacklist"
+	ScannerToken_WatchBlacklist_FullMethodName            = "/scanner_token.ScannerToken/watchBlacklist"
+	ScannerToken_StreamTokenTrades_FullMethodName         = "/scanner_token.ScannerToken/streamTokenTrades"
+	ScannerToken_GetTokenHolders_FullMethodName           = "/scanner_token.ScannerToken/getTokenHolders"
+	ScannerToken_SetDegradationMode_FullMethodName        = "/scanner_token.ScannerToken/setDegradationMode"
+	ScannerToken_GetDegradationMode_FullMethodName        = "/scanner_token.ScannerToken/getDegradationMode"
+	ScannerToken_SetTokenLocalization_FullMethodName      = "/scanner_token.ScannerToken/setTokenLocalization"
+	ScannerToken_RemoveTokenLocalization_FullMethodName   = "/scanner_token.ScannerToken/removeTokenLocalization"
+	ScannerToken_ListTokenLocalizations_FullMethodName    = "/scanner_token.ScannerToken/listTokenLocalizations"
+	ScannerToken_GetDiscoveryFeed_FullMethodName          = "/scanner_token.ScannerToken/getDiscoveryFeed"
+	ScannerToken_GetQuote_FullMethodName                  = "/scanner_token.ScannerToken/getQuote"
+	ScannerToken_GetGasPrice_FullMethodName               = "/scanner_token.ScannerToken/getGasPrice"
+	ScannerToken_ListCronJobs_FullMethodName              = "/scanner_token.ScannerToken/listCronJobs"
+	ScannerToken_RunCronJob_FullMethodName                = "/scanner_token.ScannerToken/runCronJob"
+	ScannerToken_GetRecentLaunches_FullMethodName         = "/scanner_token.ScannerToken/getRecentLaunches"
+	ScannerToken_SubscribeFilteredLaunches_FullMethodName = "/scanner_token.ScannerToken/subscribeFilteredLaunches"
+	ScannerToken_GetTokenRisk_FullMethodName              = "/scanner_token.ScannerToken/getTokenRisk"
+	ScannerToken_GetApiUsage_FullMethodName               = "/scanner_token.ScannerToken/getApiUsage"
+	ScannerToken_GetTokenPricesAt_FullMethodName          = "/scanner_token.ScannerToken/getTokenPricesAt"
+	ScannerToken_StreamTokens_FullMethodName              = "/scanner_token.ScannerToken/streamTokens"
 )
 
 // ScannerTokenClient is the client API for ScannerToken service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ScannerTokenClient interface {
+	// Deprecated: Do not use.
+	// v1 token methods, served alongside the v2 ones until clients have moved over.
 	GetToken(ctx context.Context, in *GetTokenRequest, opts ...grpc.CallOption) (*GetTokenResponse, error)
+	// Deprecated: Do not use.
 	GetTokens(ctx context.Context, in *GetTokensRequest, opts ...grpc.CallOption) (*GetTokensResponse, error)
+	// v2 token methods take the same requests and return tokens with typed numbers.
+	GetTokenV2(ctx context.Context, in *GetTokenRequest, opts ...grpc.CallOption) (*GetTokenV2Response, error)
+	GetTokensV2(ctx context.Context, in *GetTokensRequest, opts ...grpc.CallOption) (*GetTokensV2Response, error)
 	GetTokenPrice(ctx context.Context, in *GetTokenPriceRequest, opts ...grpc.CallOption) (*GetTokenPriceResponse, error)
 	AddToken(ctx context.Context, in *AddTokenRequest, opts ...grpc.CallOption) (*AddTokenResponse, error)
+	AddTokens(ctx context.Context, in *AddTokensRequest, opts ...grpc.CallOption) (*AddTokensResponse, error)
+	AddPool(ctx context.Context, in *AddPoolRequest, opts ...grpc.CallOption) (*AddPoolResponse, error)
+	SetTokenPool(ctx context.Context, in *SetTokenPoolRequest, opts ...grpc.CallOption) (*SetTokenPoolResponse, error)
+	SetTokenMinSwapUsd(ctx context.Context, in *SetTokenMinSwapUsdRequest, opts ...grpc.CallOption) (*SetTokenMinSwapUsdResponse, error)
+	Resolve(ctx context.Context, in *ResolveRequest, opts ...grpc.CallOption) (*ResolveResponse, error)
 	RemoveToken(ctx context.Context, in *RemoveTokenRequest, opts ...grpc.CallOption) (*RemoveTokenResponse, error)
 	AddBlacklist(ctx context.Context, in *AddBlacklistRequest, opts ...grpc.CallOption) (*AddBlacklistResponse, error)
+	RemoveBlacklist(ctx context.Context, in *RemoveBlacklistRequest, opts ...grpc.CallOption) (*RemoveBlacklistResponse, error)
+	GetBlacklist(ctx context.Context, in *GetBlacklistRequest, opts ...grpc.CallOption) (*GetBlacklistResponse, error)
+	// Streams the blacklist as a snapshot followed by every addition and removal. A consumer
+	// that falls behind has its stream closed and resubscribes for a new snapshot.
+	WatchBlacklist(ctx context.Context, in *WatchBlacklistRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BlacklistChange], error)
+	// Streams the swaps of a tracked token as they are decoded. Trades a slow consumer cannot
+	// take are skipped.
+	StreamTokenTrades(ctx context.Context, in *StreamTokenTradesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TokenTrade], error)
+	GetTokenHolders(ctx context.Context, in *GetTokenHoldersRequest, opts ...grpc.CallOption) (*GetTokenHoldersResponse, error)
+	SetDegradationMode(ctx context.Context, in *SetDegradationModeRequest, opts ...grpc.CallOption) (*DegradationModeResponse, error)
+	GetDegradationMode(ctx context.Context, in *GetDegradationModeRequest, opts ...grpc.CallOption) (*DegradationModeResponse, error)
+	SetTokenLocalization(ctx context.Context, in *SetTokenLocalizationRequest, opts ...grpc.CallOption) (*SetTokenLocalizationResponse, error)
+	RemoveTokenLocalization(ctx context.Context, in *RemoveTokenLocalizationRequest, opts ...grpc.CallOption) (*RemoveTokenLocalizationResponse, error)
+	ListTokenLocalizations(ctx context.Context, in *ListTokenLocalizationsRequest, opts ...grpc.CallOption) (*ListTokenLocalizationsResponse, error)
+	GetDiscoveryFeed(ctx context.Context, in *GetDiscoveryFeedRequest, opts ...grpc.CallOption) (*GetDiscoveryFeedResponse, error)
+	GetQuote(ctx context.Context, in *GetQuoteRequest, opts ...grpc.CallOption) (*GetQuoteResponse, error)
+	GetGasPrice(ctx context.Context, in *GetGasPriceRequest, opts ...grpc.CallOption) (*GetGasPriceResponse, error)
+	ListCronJobs(ctx context.Context, in *ListCronJobsRequest, opts ...grpc.CallOption) (*ListCronJobsResponse, error)
+	RunCronJob(ctx context.Context, in *RunCronJobRequest, opts ...grpc.CallOption) (*RunCronJobResponse, error)
+	GetRecentLaunches(ctx context.Context, in *GetRecentLaunchesRequest, opts ...grpc.CallOption) (*GetRecentLaunchesResponse, error)
+	// Streams the launches discovered from now on that match the criteria. Launches a slow
+	// consumer cannot take are skipped.
+	SubscribeFilteredLaunches(ctx context.Context, in *SubscribeFilteredLaunchesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RecentLaunch], error)
+	GetTokenRisk(ctx context.Context, in *GetTokenRiskRequest, opts ...grpc.CallOption) (*GetTokenRiskResponse, error)
+	GetApiUsage(ctx context.Context, in *GetApiUsageRequest, opts ...grpc.CallOption) (*GetApiUsageResponse, error)
+	// Returns the recorded price of tokens at past times, to value transactions at the price of
+	// their time.
+	GetTokenPricesAt(ctx context.Context, in *GetTokenPricesAtRequest, opts ...grpc.CallOption) (*GetTokenPricesAtResponse, error)
+	// Replays the change log of the tokens from a cursor, then streams new changes as they are
+	// committed. Delivery is at least once: a consumer stores the cursor of each change it
+	// applied and resumes from it after a disconnect.
+	StreamTokens(ctx context.Context, in *StreamTokensRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TokenChange], error)
 }
 
 type scannerTokenClient struct {
@@ -47,6 +118,7 @@ func NewScannerTokenClient(cc grpc.ClientConnInterface) ScannerTokenClient {
 	return &scannerTokenClient{cc}
 }
 
+// Deprecated: Do not use.
 func (c *scannerTokenClient) GetToken(ctx context.Context, in *GetTokenRequest, opts ...grpc.CallOption) (*GetTokenResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetTokenResponse)
@@ -57,6 +129,7 @@ func (c *scannerTokenClient) GetToken(ctx context.Context, in *GetTokenRequest,
 	return out, nil
 }
 
+// Deprecated: Do not use.
 func (c *scannerTokenClient) GetTokens(ctx context.Context, in *GetTokensRequest, opts ...grpc.CallOption) (*GetTokensResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetTokensResponse)
@@ -67,6 +140,26 @@ func (c *scannerTokenClient) GetTokens(ctx context.Context, in *GetTokensRequest
 	return out, nil
 }
 
+func (c *scannerTokenClient) GetTokenV2(ctx context.Context, in *GetTokenRequest, opts ...grpc.CallOption) (*GetTokenV2Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTokenV2Response)
+	err := c.cc.Invoke(ctx, ScannerToken_GetTokenV2_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerTokenClient) GetTokensV2(ctx context.Context, in *GetTokensRequest, opts ...grpc.CallOption) (*GetTokensV2Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTokensV2Response)
+	err := c.cc.Invoke(ctx, ScannerToken_GetTokensV2_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *scannerTokenClient) GetTokenPrice(ctx context.Context, in *GetTokenPriceRequest, opts ...grpc.CallOption) (*GetTokenPriceResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetTokenPriceResponse)
@@ -87,6 +180,56 @@ func (c *scannerTokenClient) AddToken(ctx context.Context, in *AddTokenRequest,
 	return out, nil
 }
 
+func (c *scannerTokenClient) AddTokens(ctx context.Context, in *AddTokensRequest, opts ...grpc.CallOption) (*AddTokensResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddTokensResponse)
+	err := c.cc.Invoke(ctx, ScannerToken_AddTokens_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerTokenClient) AddPool(ctx context.Context, in *AddPoolRequest, opts ...grpc.CallOption) (*AddPoolResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddPoolResponse)
+	err := c.cc.Invoke(ctx, ScannerToken_AddPool_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerTokenClient) SetTokenPool(ctx context.Context, in *SetTokenPoolRequest, opts ...grpc.CallOption) (*SetTokenPoolResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetTokenPoolResponse)
+	err := c.cc.Invoke(ctx, ScannerToken_SetTokenPool_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerTokenClient) SetTokenMinSwapUsd(ctx context.Context, in *SetTokenMinSwapUsdRequest, opts ...grpc.CallOption) (*SetTokenMinSwapUsdResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetTokenMinSwapUsdResponse)
+	err := c.cc.Invoke(ctx, ScannerToken_SetTokenMinSwapUsd_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerTokenClient) Resolve(ctx context.Context, in *ResolveRequest, opts ...grpc.CallOption) (*ResolveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResolveResponse)
+	err := c.cc.Invoke(ctx, ScannerToken_Resolve_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *scannerTokenClient) RemoveToken(ctx context.Context, in *RemoveTokenRequest, opts ...grpc.CallOption) (*RemoveTokenResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(RemoveTokenResponse)
@@ -107,16 +250,305 @@ func (c *scannerTokenClient) AddBlacklist(ctx context.Context, in *AddBlacklistR
 	return out, nil
 }
 
+func (c *scannerTokenClient) RemoveBlacklist(ctx context.Context, in *RemoveBlacklistRequest, opts ...grpc.CallOption) (*RemoveBlacklistResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveBlacklistResponse)
+	err := c.cc.Invoke(ctx, ScannerToken_RemoveBlacklist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerTokenClient) GetBlacklist(ctx context.Context, in *GetBlacklistRequest, opts ...grpc.CallOption) (*GetBlacklistResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBlacklistResponse)
+	err := c.cc.Invoke(ctx, ScannerToken_GetBlacklist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerTokenClient) WatchBlacklist(ctx context.Context, in *WatchBlacklistRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BlacklistChange], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ScannerToken_ServiceDesc.Streams[0], ScannerToken_WatchBlacklist_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchBlacklistRequest, BlacklistChange]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ScannerToken_WatchBlacklistClient = grpc.ServerStreamingClient[BlacklistChange]
+
+func (c *scannerTokenClient) StreamTokenTrades(ctx context.Context, in *StreamTokenTradesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TokenTrade], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ScannerToken_ServiceDesc.Streams[1], ScannerToken_StreamTokenTrades_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamTokenTradesRequest, TokenTrade]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ScannerToken_StreamTokenTradesClient = grpc.ServerStreamingClient[TokenTrade]
+
+func (c *scannerTokenClient) GetTokenHolders(ctx context.Context, in *GetTokenHoldersRequest, opts ...grpc.CallOption) (*GetTokenHoldersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTokenHoldersResponse)
+	err := c.cc.Invoke(ctx, ScannerToken_GetTokenHolders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerTokenClient) SetDegradationMode(ctx context.Context, in *SetDegradationModeRequest, opts ...grpc.CallOption) (*DegradationModeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DegradationModeResponse)
+	err := c.cc.Invoke(ctx, ScannerToken_SetDegradationMode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerTokenClient) GetDegradationMode(ctx context.Context, in *GetDegradationModeRequest, opts ...grpc.CallOption) (*DegradationModeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DegradationModeResponse)
+	err := c.cc.Invoke(ctx, ScannerToken_GetDegradationMode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerTokenClient) SetTokenLocalization(ctx context.Context, in *SetTokenLocalizationRequest, opts ...grpc.CallOption) (*SetTokenLocalizationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetTokenLocalizationResponse)
+	err := c.cc.Invoke(ctx, ScannerToken_SetTokenLocalization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerTokenClient) RemoveTokenLocalization(ctx context.Context, in *RemoveTokenLocalizationRequest, opts ...grpc.CallOption) (*RemoveTokenLocalizationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveTokenLocalizationResponse)
+	err := c.cc.Invoke(ctx, ScannerToken_RemoveTokenLocalization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerTokenClient) ListTokenLocalizations(ctx context.Context, in *ListTokenLocalizationsRequest, opts ...grpc.CallOption) (*ListTokenLocalizationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTokenLocalizationsResponse)
+	err := c.cc.Invoke(ctx, ScannerToken_ListTokenLocalizations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerTokenClient) GetDiscoveryFeed(ctx context.Context, in *GetDiscoveryFeedRequest, opts ...grpc.CallOption) (*GetDiscoveryFeedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDiscoveryFeedResponse)
+	err := c.cc.Invoke(ctx, ScannerToken_GetDiscoveryFeed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerTokenClient) GetQuote(ctx context.Context, in *GetQuoteRequest, opts ...grpc.CallOption) (*GetQuoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetQuoteResponse)
+	err := c.cc.Invoke(ctx, ScannerToken_GetQuote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerTokenClient) GetGasPrice(ctx context.Context, in *GetGasPriceRequest, opts ...grpc.CallOption) (*GetGasPriceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetGasPriceResponse)
+	err := c.cc.Invoke(ctx, ScannerToken_GetGasPrice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerTokenClient) ListCronJobs(ctx context.Context, in *ListCronJobsRequest, opts ...grpc.CallOption) (*ListCronJobsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCronJobsResponse)
+	err := c.cc.Invoke(ctx, ScannerToken_ListCronJobs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerTokenClient) RunCronJob(ctx context.Context, in *RunCronJobRequest, opts ...grpc.CallOption) (*RunCronJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RunCronJobResponse)
+	err := c.cc.Invoke(ctx, ScannerToken_RunCronJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerTokenClient) GetRecentLaunches(ctx context.Context, in *GetRecentLaunchesRequest, opts ...grpc.CallOption) (*GetRecentLaunchesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRecentLaunchesResponse)
+	err := c.cc.Invoke(ctx, ScannerToken_GetRecentLaunches_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerTokenClient) SubscribeFilteredLaunches(ctx context.Context, in *SubscribeFilteredLaunchesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RecentLaunch], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ScannerToken_ServiceDesc.Streams[2], ScannerToken_SubscribeFilteredLaunches_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeFilteredLaunchesRequest, RecentLaunch]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ScannerToken_SubscribeFilteredLaunchesClient = grpc.ServerStreamingClient[RecentLaunch]
+
+func (c *scannerTokenClient) GetTokenRisk(ctx context.Context, in *GetTokenRiskRequest, opts ...grpc.CallOption) (*GetTokenRiskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTokenRiskResponse)
+	err := c.cc.Invoke(ctx, ScannerToken_GetTokenRisk_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerTokenClient) GetApiUsage(ctx context.Context, in *GetApiUsageRequest, opts ...grpc.CallOption) (*GetApiUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetApiUsageResponse)
+	err := c.cc.Invoke(ctx, ScannerToken_GetApiUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerTokenClient) GetTokenPricesAt(ctx context.Context, in *GetTokenPricesAtRequest, opts ...grpc.CallOption) (*GetTokenPricesAtResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTokenPricesAtResponse)
+	err := c.cc.Invoke(ctx, ScannerToken_GetTokenPricesAt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerTokenClient) StreamTokens(ctx context.Context, in *StreamTokensRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TokenChange], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ScannerToken_ServiceDesc.Streams[3], ScannerToken_StreamTokens_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamTokensRequest, TokenChange]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ScannerToken_StreamTokensClient = grpc.ServerStreamingClient[TokenChange]
+
 // ScannerTokenServer is the server API for ScannerToken service.
 // All implementations must embed UnimplementedScannerTokenServer
 // for forward compatibility.
 type ScannerTokenServer interface {
+	// Deprecated: Do not use.
+	// v1 token methods, served alongside the v2 ones until clients have moved over.
 	GetToken(context.Context, *GetTokenRequest) (*GetTokenResponse, error)
+	// Deprecated: Do not use.
 	GetTokens(context.Context, *GetTokensRequest) (*GetTokensResponse, error)
+	// v2 token methods take the same requests and return tokens with typed numbers.
+	GetTokenV2(context.Context, *GetTokenRequest) (*GetTokenV2Response, error)
+	GetTokensV2(context.Context, *GetTokensRequest) (*GetTokensV2Response, error)
 	GetTokenPrice(context.Context, *GetTokenPriceRequest) (*GetTokenPriceResponse, error)
 	AddToken(context.Context, *AddTokenRequest) (*AddTokenResponse, error)
+	AddTokens(context.Context, *AddTokensRequest) (*AddTokensResponse, error)
+	AddPool(context.Context, *AddPoolRequest) (*AddPoolResponse, error)
+	SetTokenPool(context.Context, *SetTokenPoolRequest) (*SetTokenPoolResponse, error)
+	SetTokenMinSwapUsd(context.Context, *SetTokenMinSwapUsdRequest) (*SetTokenMinSwapUsdResponse, error)
+	Resolve(context.Context, *ResolveRequest) (*ResolveResponse, error)
 	RemoveToken(context.Context, *RemoveTokenRequest) (*RemoveTokenResponse, error)
 	AddBlacklist(context.Context, *AddBlacklistRequest) (*AddBlacklistResponse, error)
+	RemoveBlacklist(context.Context, *RemoveBlacklistRequest) (*RemoveBlacklistResponse, error)
+	GetBlacklist(context.Context, *GetBlacklistRequest) (*GetBlacklistResponse, error)
+	// Streams the blacklist as a snapshot followed by every addition and removal. A consumer
+	// that falls behind has its stream closed and resubscribes for a new snapshot.
+	WatchBlacklist(*WatchBlacklistRequest, grpc.ServerStreamingServer[BlacklistChange]) error
+	// Streams the swaps of a tracked token as they are decoded. Trades a slow consumer cannot
+	// take are skipped.
+	StreamTokenTrades(*StreamTokenTradesRequest, grpc.ServerStreamingServer[TokenTrade]) error
+	GetTokenHolders(context.Context, *GetTokenHoldersRequest) (*GetTokenHoldersResponse, error)
+	SetDegradationMode(context.Context, *SetDegradationModeRequest) (*DegradationModeResponse, error)
+	GetDegradationMode(context.Context, *GetDegradationModeRequest) (*DegradationModeResponse, error)
+	SetTokenLocalization(context.Context, *SetTokenLocalizationRequest) (*SetTokenLocalizationResponse, error)
+	RemoveTokenLocalization(context.Context, *RemoveTokenLocalizationRequest) (*RemoveTokenLocalizationResponse, error)
+	ListTokenLocalizations(context.Context, *ListTokenLocalizationsRequest) (*ListTokenLocalizationsResponse, error)
+	GetDiscoveryFeed(context.Context, *GetDiscoveryFeedRequest) (*GetDiscoveryFeedResponse, error)
+	GetQuote(context.Context, *GetQuoteRequest) (*GetQuoteResponse, error)
+	GetGasPrice(context.Context, *GetGasPriceRequest) (*GetGasPriceResponse, error)
+	ListCronJobs(context.Context, *ListCronJobsRequest) (*ListCronJobsResponse, error)
+	RunCronJob(context.Context, *RunCronJobRequest) (*RunCronJobResponse, error)
+	GetRecentLaunches(context.Context, *GetRecentLaunchesRequest) (*GetRecentLaunchesResponse, error)
+	// Streams the launches discovered from now on that match the criteria. Launches a slow
+	// consumer cannot take are skipped.
+	SubscribeFilteredLaunches(*SubscribeFilteredLaunchesRequest, grpc.ServerStreamingServer[RecentLaunch]) error
+	GetTokenRisk(context.Context, *GetTokenRiskRequest) (*GetTokenRiskResponse, error)
+	GetApiUsage(context.Context, *GetApiUsageRequest) (*GetApiUsageResponse, error)
+	// Returns the recorded price of tokens at past times, to value transactions at the price of
+	// their time.
+	GetTokenPricesAt(context.Context, *GetTokenPricesAtRequest) (*GetTokenPricesAtResponse, error)
+	// Replays the change log of the tokens from a cursor, then streams new changes as they are
+	// committed. Delivery is at least once: a consumer stores the cursor of each change it
+	// applied and resumes from it after a disconnect.
+	StreamTokens(*StreamTokensRequest, grpc.ServerStreamingServer[TokenChange]) error
 	mustEmbedUnimplementedScannerTokenServer()
 }
 
@@ -133,18 +565,102 @@ func (UnimplementedScannerTokenServer) GetToken(context.Context, *GetTokenReques
 func (UnimplementedScannerTokenServer) GetTokens(context.Context, *GetTokensRequest) (*GetTokensResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetTokens not implemented")
 }
+func (UnimplementedScannerTokenServer) GetTokenV2(context.Context, *GetTokenRequest) (*GetTokenV2Response, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTokenV2 not implemented")
+}
+func (UnimplementedScannerTokenServer) GetTokensV2(context.Context, *GetTokensRequest) (*GetTokensV2Response, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTokensV2 not implemented")
+}
 func (UnimplementedScannerTokenServer) GetTokenPrice(context.Context, *GetTokenPriceRequest) (*GetTokenPriceResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetTokenPrice not implemented")
 }
 func (UnimplementedScannerTokenServer) AddToken(context.Context, *AddTokenRequest) (*AddTokenResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method AddToken not implemented")
 }
+func (UnimplementedScannerTokenServer) AddTokens(context.Context, *AddTokensRequest) (*AddTokensResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddTokens not implemented")
+}
+func (UnimplementedScannerTokenServer) AddPool(context.Context, *AddPoolRequest) (*AddPoolResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddPool not implemented")
+}
+func (UnimplementedScannerTokenServer) SetTokenPool(context.Context, *SetTokenPoolRequest) (*SetTokenPoolResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetTokenPool not implemented")
+}
+func (UnimplementedScannerTokenServer) SetTokenMinSwapUsd(context.Context, *SetTokenMinSwapUsdRequest) (*SetTokenMinSwapUsdResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetTokenMinSwapUsd not implemented")
+}
+func (UnimplementedScannerTokenServer) Resolve(context.Context, *ResolveRequest) (*ResolveResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Resolve not implemented")
+}
 func (UnimplementedScannerTokenServer) RemoveToken(context.Context, *RemoveTokenRequest) (*RemoveTokenResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method RemoveToken not implemented")
 }
 func (UnimplementedScannerTokenServer) AddBlacklist(context.Context, *AddBlacklistRequest) (*AddBlacklistResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method AddBlacklist not implemented")
 }
+func (UnimplementedScannerTokenServer) RemoveBlacklist(context.Context, *RemoveBlacklistRequest) (*RemoveBlacklistResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveBlacklist not implemented")
+}
+func (UnimplementedScannerTokenServer) GetBlacklist(context.Context, *GetBlacklistRequest) (*GetBlacklistResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBlacklist not implemented")
+}
+func (UnimplementedScannerTokenServer) WatchBlacklist(*WatchBlacklistRequest, grpc.ServerStreamingServer[BlacklistChange]) error {
+	return status.Error(codes.Unimplemented, "method WatchBlacklist not implemented")
+}
+func (UnimplementedScannerTokenServer) StreamTokenTrades(*StreamTokenTradesRequest, grpc.ServerStreamingServer[TokenTrade]) error {
+	return status.Error(codes.Unimplemented, "method StreamTokenTrades not implemented")
+}
+func (UnimplementedScannerTokenServer) GetTokenHolders(context.Context, *GetTokenHoldersRequest) (*GetTokenHoldersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTokenHolders not implemented")
+}
+func (UnimplementedScannerTokenServer) SetDegradationMode(context.Context, *SetDegradationModeRequest) (*DegradationModeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetDegradationMode not implemented")
+}
+func (UnimplementedScannerTokenServer) GetDegradationMode(context.Context, *GetDegradationModeRequest) (*DegradationModeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDegradationMode not implemented")
+}
+func (UnimplementedScannerTokenServer) SetTokenLocalization(context.Context, *SetTokenLocalizationRequest) (*SetTokenLocalizationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetTokenLocalization not implemented")
+}
+func (UnimplementedScannerTokenServer) RemoveTokenLocalization(context.Context, *RemoveTokenLocalizationRequest) (*RemoveTokenLocalizationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveTokenLocalization not implemented")
+}
+func (UnimplementedScannerTokenServer) ListTokenLocalizations(context.Context, *ListTokenLocalizationsRequest) (*ListTokenLocalizationsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTokenLocalizations not implemented")
+}
+func (UnimplementedScannerTokenServer) GetDiscoveryFeed(context.Context, *GetDiscoveryFeedRequest) (*GetDiscoveryFeedResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDiscoveryFeed not implemented")
+}
+func (UnimplementedScannerTokenServer) GetQuote(context.Context, *GetQuoteRequest) (*GetQuoteResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetQuote not implemented")
+}
+func (UnimplementedScannerTokenServer) GetGasPrice(context.Context, *GetGasPriceRequest) (*GetGasPriceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetGasPrice not implemented")
+}
+func (UnimplementedScannerTokenServer) ListCronJobs(context.Context, *ListCronJobsRequest) (*ListCronJobsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCronJobs not implemented")
+}
+func (UnimplementedScannerTokenServer) RunCronJob(context.Context, *RunCronJobRequest) (*RunCronJobResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RunCronJob not implemented")
+}
+func (UnimplementedScannerTokenServer) GetRecentLaunches(context.Context, *GetRecentLaunchesRequest) (*GetRecentLaunchesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRecentLaunches not implemented")
+}
+func (UnimplementedScannerTokenServer) SubscribeFilteredLaunches(*SubscribeFilteredLaunchesRequest, grpc.ServerStreamingServer[RecentLaunch]) error {
+	return status.Error(codes.Unimplemented, "method SubscribeFilteredLaunches not implemented")
+}
+func (UnimplementedScannerTokenServer) GetTokenRisk(context.Context, *GetTokenRiskRequest) (*GetTokenRiskResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTokenRisk not implemented")
+}
+func (UnimplementedScannerTokenServer) GetApiUsage(context.Context, *GetApiUsageRequest) (*GetApiUsageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetApiUsage not implemented")
+}
+func (UnimplementedScannerTokenServer) GetTokenPricesAt(context.Context, *GetTokenPricesAtRequest) (*GetTokenPricesAtResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTokenPricesAt not implemented")
+}
+func (UnimplementedScannerTokenServer) StreamTokens(*StreamTokensRequest, grpc.ServerStreamingServer[TokenChange]) error {
+	return status.Error(codes.Unimplemented, "method StreamTokens not implemented")
+}
 func (UnimplementedScannerTokenServer) mustEmbedUnimplementedScannerTokenServer() {}
 func (UnimplementedScannerTokenServer) testEmbeddedByValue()                      {}
 
@@ -202,44 +718,170 @@ func _ScannerToken_GetTokens_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ScannerToken_GetTokenPrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetTokenPriceRequest)
+func _ScannerToken_GetTokenV2_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTokenRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ScannerTokenServer).GetTokenPrice(ctx, in)
+		return srv.(ScannerTokenServer).GetTokenV2(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ScannerToken_GetTokenPrice_FullMethodName,
+		FullMethod: ScannerToken_GetTokenV2_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ScannerTokenServer).GetTokenPrice(ctx, req.(*GetTokenPriceRequest))
+		return srv.(ScannerTokenServer).GetTokenV2(ctx, req.(*GetTokenRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ScannerToken_AddToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddTokenRequest)
+func _ScannerToken_GetTokensV2_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTokensRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ScannerTokenServer).AddToken(ctx, in)
+		return srv.(ScannerTokenServer).GetTokensV2(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ScannerToken_AddToken_FullMethodName,
+		FullMethod: ScannerToken_GetTokensV2_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ScannerTokenServer).AddToken(ctx, req.(*AddTokenRequest))
+		return srv.(ScannerTokenServer).GetTokensV2(ctx, req.(*GetTokensRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ScannerToken_RemoveToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RemoveTokenRequest)
+func _ScannerToken_GetTokenPrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTokenPriceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerTokenServer).GetTokenPrice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerToken_GetTokenPrice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerTokenServer).GetTokenPrice(ctx, req.(*GetTokenPriceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerToken_AddToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerTokenServer).AddToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerToken_AddToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerTokenServer).AddToken(ctx, req.(*AddTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerToken_AddTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerTokenServer).AddTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerToken_AddTokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerTokenServer).AddTokens(ctx, req.(*AddTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerToken_AddPool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddPoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerTokenServer).AddPool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerToken_AddPool_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerTokenServer).AddPool(ctx, req.(*AddPoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerToken_SetTokenPool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTokenPoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerTokenServer).SetTokenPool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerToken_SetTokenPool_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerTokenServer).SetTokenPool(ctx, req.(*SetTokenPoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerToken_SetTokenMinSwapUsd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTokenMinSwapUsdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerTokenServer).SetTokenMinSwapUsd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerToken_SetTokenMinSwapUsd_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerTokenServer).SetTokenMinSwapUsd(ctx, req.(*SetTokenMinSwapUsdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerToken_Resolve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerTokenServer).Resolve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerToken_Resolve_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerTokenServer).Resolve(ctx, req.(*ResolveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerToken_RemoveToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTokenRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -274,6 +916,356 @@ func _ScannerToken_AddBlacklist_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ScannerToken_RemoveBlacklist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveBlacklistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerTokenServer).RemoveBlacklist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerToken_RemoveBlacklist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerTokenServer).RemoveBlacklist(ctx, req.(*RemoveBlacklistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerToken_GetBlacklist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlacklistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerTokenServer).GetBlacklist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerToken_GetBlacklist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerTokenServer).GetBlacklist(ctx, req.(*GetBlacklistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerToken_WatchBlacklist_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchBlacklistRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ScannerTokenServer).WatchBlacklist(m, &grpc.GenericServerStream[WatchBlacklistRequest, BlacklistChange]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ScannerToken_WatchBlacklistServer = grpc.ServerStreamingServer[BlacklistChange]
+
+func _ScannerToken_StreamTokenTrades_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamTokenTradesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ScannerTokenServer).StreamTokenTrades(m, &grpc.GenericServerStream[StreamTokenTradesRequest, TokenTrade]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ScannerToken_StreamTokenTradesServer = grpc.ServerStreamingServer[TokenTrade]
+
+func _ScannerToken_GetTokenHolders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTokenHoldersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerTokenServer).GetTokenHolders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerToken_GetTokenHolders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerTokenServer).GetTokenHolders(ctx, req.(*GetTokenHoldersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerToken_SetDegradationMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDegradationModeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerTokenServer).SetDegradationMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerToken_SetDegradationMode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerTokenServer).SetDegradationMode(ctx, req.(*SetDegradationModeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerToken_GetDegradationMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDegradationModeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerTokenServer).GetDegradationMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerToken_GetDegradationMode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerTokenServer).GetDegradationMode(ctx, req.(*GetDegradationModeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerToken_SetTokenLocalization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTokenLocalizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerTokenServer).SetTokenLocalization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerToken_SetTokenLocalization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerTokenServer).SetTokenLocalization(ctx, req.(*SetTokenLocalizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerToken_RemoveTokenLocalization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTokenLocalizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerTokenServer).RemoveTokenLocalization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerToken_RemoveTokenLocalization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerTokenServer).RemoveTokenLocalization(ctx, req.(*RemoveTokenLocalizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerToken_ListTokenLocalizations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTokenLocalizationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerTokenServer).ListTokenLocalizations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerToken_ListTokenLocalizations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerTokenServer).ListTokenLocalizations(ctx, req.(*ListTokenLocalizationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerToken_GetDiscoveryFeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDiscoveryFeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerTokenServer).GetDiscoveryFeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerToken_GetDiscoveryFeed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerTokenServer).GetDiscoveryFeed(ctx, req.(*GetDiscoveryFeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerToken_GetQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerTokenServer).GetQuote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerToken_GetQuote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerTokenServer).GetQuote(ctx, req.(*GetQuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerToken_GetGasPrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGasPriceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerTokenServer).GetGasPrice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerToken_GetGasPrice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerTokenServer).GetGasPrice(ctx, req.(*GetGasPriceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerToken_ListCronJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCronJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerTokenServer).ListCronJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerToken_ListCronJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerTokenServer).ListCronJobs(ctx, req.(*ListCronJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerToken_RunCronJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunCronJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerTokenServer).RunCronJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerToken_RunCronJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerTokenServer).RunCronJob(ctx, req.(*RunCronJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerToken_GetRecentLaunches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecentLaunchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerTokenServer).GetRecentLaunches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerToken_GetRecentLaunches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerTokenServer).GetRecentLaunches(ctx, req.(*GetRecentLaunchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerToken_SubscribeFilteredLaunches_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeFilteredLaunchesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ScannerTokenServer).SubscribeFilteredLaunches(m, &grpc.GenericServerStream[SubscribeFilteredLaunchesRequest, RecentLaunch]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ScannerToken_SubscribeFilteredLaunchesServer = grpc.ServerStreamingServer[RecentLaunch]
+
+func _ScannerToken_GetTokenRisk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTokenRiskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerTokenServer).GetTokenRisk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerToken_GetTokenRisk_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerTokenServer).GetTokenRisk(ctx, req.(*GetTokenRiskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerToken_GetApiUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetApiUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerTokenServer).GetApiUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerToken_GetApiUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerTokenServer).GetApiUsage(ctx, req.(*GetApiUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerToken_GetTokenPricesAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTokenPricesAtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerTokenServer).GetTokenPricesAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerToken_GetTokenPricesAt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerTokenServer).GetTokenPricesAt(ctx, req.(*GetTokenPricesAtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerToken_StreamTokens_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamTokensRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ScannerTokenServer).StreamTokens(m, &grpc.GenericServerStream[StreamTokensRequest, TokenChange]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ScannerToken_StreamTokensServer = grpc.ServerStreamingServer[TokenChange]
+
 // ScannerToken_ServiceDesc is the grpc.ServiceDesc for ScannerToken service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -289,6 +1281,14 @@ var ScannerToken_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "getTokens",
 			Handler:    _ScannerToken_GetTokens_Handler,
 		},
+		{
+			MethodName: "getTokenV2",
+			Handler:    _ScannerToken_GetTokenV2_Handler,
+		},
+		{
+			MethodName: "getTokensV2",
+			Handler:    _ScannerToken_GetTokensV2_Handler,
+		},
 		{
 			MethodName: "getTokenPrice",
 			Handler:    _ScannerToken_GetTokenPrice_Handler,
@@ -297,6 +1297,26 @@ var ScannerToken_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "addToken",
 			Handler:    _ScannerToken_AddToken_Handler,
 		},
+		{
+			MethodName: "addTokens",
+			Handler:    _ScannerToken_AddTokens_Handler,
+		},
+		{
+			MethodName: "addPool",
+			Handler:    _ScannerToken_AddPool_Handler,
+		},
+		{
+			MethodName: "setTokenPool",
+			Handler:    _ScannerToken_SetTokenPool_Handler,
+		},
+		{
+			MethodName: "setTokenMinSwapUsd",
+			Handler:    _ScannerToken_SetTokenMinSwapUsd_Handler,
+		},
+		{
+			MethodName: "resolve",
+			Handler:    _ScannerToken_Resolve_Handler,
+		},
 		{
 			MethodName: "removeToken",
 			Handler:    _ScannerToken_RemoveToken_Handler,
@@ -305,7 +1325,96 @@ var ScannerToken_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "addBlacklist",
 			Handler:    _ScannerToken_AddBlacklist_Handler,
 		},
+		{
+			MethodName: "removeBlacklist",
+			Handler:    _ScannerToken_RemoveBlacklist_Handler,
+		},
+		{
+			MethodName: "getBlacklist",
+			Handler:    _ScannerToken_GetBlacklist_Handler,
+		},
+		{
+			MethodName: "getTokenHolders",
+			Handler:    _ScannerToken_GetTokenHolders_Handler,
+		},
+		{
+			MethodName: "setDegradationMode",
+			Handler:    _ScannerToken_SetDegradationMode_Handler,
+		},
+		{
+			MethodName: "getDegradationMode",
+			Handler:    _ScannerToken_GetDegradationMode_Handler,
+		},
+		{
+			MethodName: "setTokenLocalization",
+			Handler:    _ScannerToken_SetTokenLocalization_Handler,
+		},
+		{
+			MethodName: "removeTokenLocalization",
+			Handler:    _ScannerToken_RemoveTokenLocalization_Handler,
+		},
+		{
+			MethodName: "listTokenLocalizations",
+			Handler:    _ScannerToken_ListTokenLocalizations_Handler,
+		},
+		{
+			MethodName: "getDiscoveryFeed",
+			Handler:    _ScannerToken_GetDiscoveryFeed_Handler,
+		},
+		{
+			MethodName: "getQuote",
+			Handler:    _ScannerToken_GetQuote_Handler,
+		},
+		{
+			MethodName: "getGasPrice",
+			Handler:    _ScannerToken_GetGasPrice_Handler,
+		},
+		{
+			MethodName: "listCronJobs",
+			Handler:    _ScannerToken_ListCronJobs_Handler,
+		},
+		{
+			MethodName: "runCronJob",
+			Handler:    _ScannerToken_RunCronJob_Handler,
+		},
+		{
+			MethodName: "getRecentLaunches",
+			Handler:    _ScannerToken_GetRecentLaunches_Handler,
+		},
+		{
+			MethodName: "getTokenRisk",
+			Handler:    _ScannerToken_GetTokenRisk_Handler,
+		},
+		{
+			MethodName: "getApiUsage",
+			Handler:    _ScannerToken_GetApiUsage_Handler,
+		},
+		{
+			MethodName: "getTokenPricesAt",
+			Handler:    _ScannerToken_GetTokenPricesAt_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "watchBlacklist",
+			Handler:       _ScannerToken_WatchBlacklist_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "streamTokenTrades",
+			Handler:       _ScannerToken_StreamTokenTrades_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "subscribeFilteredLaunches",
+			Handler:       _ScannerToken_SubscribeFilteredLaunches_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "streamTokens",
+			Handler:       _ScannerToken_StreamTokens_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "token/token.proto",
 }
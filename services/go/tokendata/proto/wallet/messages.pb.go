@@ -0,0 +1,5678 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.4
+// source: wallet/messages.proto
+
+package wallet
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	common "tokendata/proto/common"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Where GetWallet reads a wallet from. Reads default to the stored wallet; API, which calls out to
+// Moralis, has to be asked for.
+type DataType int32
+
+const (
+	// Not set: read as DB.
+	DataType_DATA_TYPE_UNSPECIFIED DataType = 0
+	// The stored wallet, as last refreshed. SCANNER is the former name of DB.
+	DataType_SCANNER DataType = 1
+	DataType_DB      DataType = 1
+	// The stored wallet, refreshed in the background when it is stale.
+	DataType_HYBRID DataType = 2
+	// Moralis and the chain, storing what was read. API was 0 before, so clients built against
+	// the former numbering that ask for it read the stored wallet.
+	DataType_API DataType = 3
+)
+
+// Enum value maps for DataType.
+var (
+	DataType_name = map[int32]string{
+		0: "DATA_TYPE_UNSPECIFIED",
+		1: "SCANNER",
+		// Duplicate value: 1: "DB",
+		2: "HYBRID",
+		3: "API",
+	}
+	DataType_value = map[string]int32{
+		"DATA_TYPE_UNSPECIFIED": 0,
+		"SCANNER":               1,
+		"DB":                    1,
+		"HYBRID":                2,
+		"API":                   3,
+	}
+)
+
+func (x DataType) Enum() *DataType {
+	p := new(DataType)
+	*p = x
+	return p
+}
+
+func (x DataType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DataType) Descriptor() protoreflect.EnumDescriptor {
+	return file_wallet_messages_proto_enumTypes[0].Descriptor()
+}
+
+func (DataType) Type() protoreflect.EnumType {
+	return &file_wallet_messages_proto_enumTypes[0]
+}
+
+func (x DataType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DataType.Descriptor instead.
+func (DataType) EnumDescriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{0}
+}
+
+type TradeSide int32
+
+const (
+	TradeSide_BUY  TradeSide = 0
+	TradeSide_SELL TradeSide = 1
+)
+
+// Enum value maps for TradeSide.
+var (
+	TradeSide_name = map[int32]string{
+		0: "BUY",
+		1: "SELL",
+	}
+	TradeSide_value = map[string]int32{
+		"BUY":  0,
+		"SELL": 1,
+	}
+)
+
+func (x TradeSide) Enum() *TradeSide {
+	p := new(TradeSide)
+	*p = x
+	return p
+}
+
+func (x TradeSide) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TradeSide) Descriptor() protoreflect.EnumDescriptor {
+	return file_wallet_messages_proto_enumTypes[1].Descriptor()
+}
+
+func (TradeSide) Type() protoreflect.EnumType {
+	return &file_wallet_messages_proto_enumTypes[1]
+}
+
+func (x TradeSide) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TradeSide.Descriptor instead.
+func (TradeSide) EnumDescriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{1}
+}
+
+// What a wallet transaction did, decoded from its input selector and logs. Contract interactions
+// are the calls that fit no other type.
+type ActivityType int32
+
+const (
+	ActivityType_ACTIVITY_CONTRACT_INTERACTION ActivityType = 0
+	ActivityType_ACTIVITY_SWAP                 ActivityType = 1
+	ActivityType_ACTIVITY_TRANSFER             ActivityType = 2
+	ActivityType_ACTIVITY_MINT                 ActivityType = 3
+	ActivityType_ACTIVITY_APPROVAL             ActivityType = 4
+)
+
+// Enum value maps for ActivityType.
+var (
+	ActivityType_name = map[int32]string{
+		0: "ACTIVITY_CONTRACT_INTERACTION",
+		1: "ACTIVITY_SWAP",
+		2: "ACTIVITY_TRANSFER",
+		3: "ACTIVITY_MINT",
+		4: "ACTIVITY_APPROVAL",
+	}
+	ActivityType_value = map[string]int32{
+		"ACTIVITY_CONTRACT_INTERACTION": 0,
+		"ACTIVITY_SWAP":                 1,
+		"ACTIVITY_TRANSFER":             2,
+		"ACTIVITY_MINT":                 3,
+		"ACTIVITY_APPROVAL":             4,
+	}
+)
+
+func (x ActivityType) Enum() *ActivityType {
+	p := new(ActivityType)
+	*p = x
+	return p
+}
+
+func (x ActivityType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ActivityType) Descriptor() protoreflect.EnumDescriptor {
+	return file_wallet_messages_proto_enumTypes[2].Descriptor()
+}
+
+func (ActivityType) Type() protoreflect.EnumType {
+	return &file_wallet_messages_proto_enumTypes[2]
+}
+
+func (x ActivityType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ActivityType.Descriptor instead.
+func (ActivityType) EnumDescriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{2}
+}
+
+type LeaderboardPeriod int32
+
+const (
+	LeaderboardPeriod_PERIOD_7D  LeaderboardPeriod = 0
+	LeaderboardPeriod_PERIOD_30D LeaderboardPeriod = 1
+)
+
+// Enum value maps for LeaderboardPeriod.
+var (
+	LeaderboardPeriod_name = map[int32]string{
+		0: "PERIOD_7D",
+		1: "PERIOD_30D",
+	}
+	LeaderboardPeriod_value = map[string]int32{
+		"PERIOD_7D":  0,
+		"PERIOD_30D": 1,
+	}
+)
+
+func (x LeaderboardPeriod) Enum() *LeaderboardPeriod {
+	p := new(LeaderboardPeriod)
+	*p = x
+	return p
+}
+
+func (x LeaderboardPeriod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LeaderboardPeriod) Descriptor() protoreflect.EnumDescriptor {
+	return file_wallet_messages_proto_enumTypes[3].Descriptor()
+}
+
+func (LeaderboardPeriod) Type() protoreflect.EnumType {
+	return &file_wallet_messages_proto_enumTypes[3]
+}
+
+func (x LeaderboardPeriod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LeaderboardPeriod.Descriptor instead.
+func (LeaderboardPeriod) EnumDescriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{3}
+}
+
+type WebhookEvent int32
+
+const (
+	// A transaction of the wallet was mined.
+	WebhookEvent_WEBHOOK_TRANSACTION WebhookEvent = 0
+	// The portfolio value of the wallet moved by at least the minimum change.
+	WebhookEvent_WEBHOOK_VALUE_CHANGE WebhookEvent = 1
+	// An alert rule of the wallet fired.
+	WebhookEvent_WEBHOOK_ALERT WebhookEvent = 2
+)
+
+// Enum value maps for WebhookEvent.
+var (
+	WebhookEvent_name = map[int32]string{
+		0: "WEBHOOK_TRANSACTION",
+		1: "WEBHOOK_VALUE_CHANGE",
+		2: "WEBHOOK_ALERT",
+	}
+	WebhookEvent_value = map[string]int32{
+		"WEBHOOK_TRANSACTION":  0,
+		"WEBHOOK_VALUE_CHANGE": 1,
+		"WEBHOOK_ALERT":        2,
+	}
+)
+
+func (x WebhookEvent) Enum() *WebhookEvent {
+	p := new(WebhookEvent)
+	*p = x
+	return p
+}
+
+func (x WebhookEvent) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WebhookEvent) Descriptor() protoreflect.EnumDescriptor {
+	return file_wallet_messages_proto_enumTypes[4].Descriptor()
+}
+
+func (WebhookEvent) Type() protoreflect.EnumType {
+	return &file_wallet_messages_proto_enumTypes[4]
+}
+
+func (x WebhookEvent) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WebhookEvent.Descriptor instead.
+func (WebhookEvent) EnumDescriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{4}
+}
+
+type WalletAlertType int32
+
+const (
+	WalletAlertType_ALERT_VALUE_CHANGE WalletAlertType = 0
+	WalletAlertType_ALERT_TRANSFER     WalletAlertType = 1
+)
+
+// Enum value maps for WalletAlertType.
+var (
+	WalletAlertType_name = map[int32]string{
+		0: "ALERT_VALUE_CHANGE",
+		1: "ALERT_TRANSFER",
+	}
+	WalletAlertType_value = map[string]int32{
+		"ALERT_VALUE_CHANGE": 0,
+		"ALERT_TRANSFER":     1,
+	}
+)
+
+func (x WalletAlertType) Enum() *WalletAlertType {
+	p := new(WalletAlertType)
+	*p = x
+	return p
+}
+
+func (x WalletAlertType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WalletAlertType) Descriptor() protoreflect.EnumDescriptor {
+	return file_wallet_messages_proto_enumTypes[5].Descriptor()
+}
+
+func (WalletAlertType) Type() protoreflect.EnumType {
+	return &file_wallet_messages_proto_enumTypes[5]
+}
+
+func (x WalletAlertType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WalletAlertType.Descriptor instead.
+func (WalletAlertType) EnumDescriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{5}
+}
+
+type ContractCategory int32
+
+const (
+	// Not set: addKnownContract rejects it and listKnownContracts lists every category.
+	ContractCategory_CONTRACT_CATEGORY_UNSPECIFIED ContractCategory = 0
+	ContractCategory_LOCKER                        ContractCategory = 1
+	ContractCategory_BRIDGE                        ContractCategory = 2
+	ContractCategory_CEX                           ContractCategory = 3
+	ContractCategory_OTHER                         ContractCategory = 4
+	// ROUTER was 0 before, so clients built against the former numbering that add a router are
+	// asked for a category instead of storing the wrong one.
+	ContractCategory_ROUTER ContractCategory = 5
+)
+
+// Enum value maps for ContractCategory.
+var (
+	ContractCategory_name = map[int32]string{
+		0: "CONTRACT_CATEGORY_UNSPECIFIED",
+		1: "LOCKER",
+		2: "BRIDGE",
+		3: "CEX",
+		4: "OTHER",
+		5: "ROUTER",
+	}
+	ContractCategory_value = map[string]int32{
+		"CONTRACT_CATEGORY_UNSPECIFIED": 0,
+		"LOCKER":                        1,
+		"BRIDGE":                        2,
+		"CEX":                           3,
+		"OTHER":                         4,
+		"ROUTER":                        5,
+	}
+)
+
+func (x ContractCategory) Enum() *ContractCategory {
+	p := new(ContractCategory)
+	*p = x
+	return p
+}
+
+func (x ContractCategory) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ContractCategory) Descriptor() protoreflect.EnumDescriptor {
+	return file_wallet_messages_proto_enumTypes[6].Descriptor()
+}
+
+func (ContractCategory) Type() protoreflect.EnumType {
+	return &file_wallet_messages_proto_enumTypes[6]
+}
+
+func (x ContractCategory) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ContractCategory.Descriptor instead.
+func (ContractCategory) EnumDescriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{6}
+}
+
+type WalletFlowType int32
+
+const (
+	WalletFlowType_BRIDGE_DEPOSIT    WalletFlowType = 0
+	WalletFlowType_BRIDGE_WITHDRAWAL WalletFlowType = 1
+	WalletFlowType_CEX_DEPOSIT       WalletFlowType = 2
+	WalletFlowType_CEX_WITHDRAWAL    WalletFlowType = 3
+)
+
+// Enum value maps for WalletFlowType.
+var (
+	WalletFlowType_name = map[int32]string{
+		0: "BRIDGE_DEPOSIT",
+		1: "BRIDGE_WITHDRAWAL",
+		2: "CEX_DEPOSIT",
+		3: "CEX_WITHDRAWAL",
+	}
+	WalletFlowType_value = map[string]int32{
+		"BRIDGE_DEPOSIT":    0,
+		"BRIDGE_WITHDRAWAL": 1,
+		"CEX_DEPOSIT":       2,
+		"CEX_WITHDRAWAL":    3,
+	}
+)
+
+func (x WalletFlowType) Enum() *WalletFlowType {
+	p := new(WalletFlowType)
+	*p = x
+	return p
+}
+
+func (x WalletFlowType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WalletFlowType) Descriptor() protoreflect.EnumDescriptor {
+	return file_wallet_messages_proto_enumTypes[7].Descriptor()
+}
+
+func (WalletFlowType) Type() protoreflect.EnumType {
+	return &file_wallet_messages_proto_enumTypes[7]
+}
+
+func (x WalletFlowType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WalletFlowType.Descriptor instead.
+func (WalletFlowType) EnumDescriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{7}
+}
+
+type PortfolioRange int32
+
+const (
+	PortfolioRange_RANGE_24H PortfolioRange = 0
+	PortfolioRange_RANGE_30D PortfolioRange = 1
+	PortfolioRange_RANGE_1Y  PortfolioRange = 2
+)
+
+// Enum value maps for PortfolioRange.
+var (
+	PortfolioRange_name = map[int32]string{
+		0: "RANGE_24H",
+		1: "RANGE_30D",
+		2: "RANGE_1Y",
+	}
+	PortfolioRange_value = map[string]int32{
+		"RANGE_24H": 0,
+		"RANGE_30D": 1,
+		"RANGE_1Y":  2,
+	}
+)
+
+func (x PortfolioRange) Enum() *PortfolioRange {
+	p := new(PortfolioRange)
+	*p = x
+	return p
+}
+
+func (x PortfolioRange) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PortfolioRange) Descriptor() protoreflect.EnumDescriptor {
+	return file_wallet_messages_proto_enumTypes[8].Descriptor()
+}
+
+func (PortfolioRange) Type() protoreflect.EnumType {
+	return &file_wallet_messages_proto_enumTypes[8]
+}
+
+func (x PortfolioRange) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PortfolioRange.Descriptor instead.
+func (PortfolioRange) EnumDescriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{8}
+}
+
+type ImportJobState int32
+
+const (
+	ImportJobState_IMPORT_QUEUED  ImportJobState = 0
+	ImportJobState_IMPORT_RUNNING ImportJobState = 1
+	ImportJobState_IMPORT_DONE    ImportJobState = 2
+)
+
+// Enum value maps for ImportJobState.
+var (
+	ImportJobState_name = map[int32]string{
+		0: "IMPORT_QUEUED",
+		1: "IMPORT_RUNNING",
+		2: "IMPORT_DONE",
+	}
+	ImportJobState_value = map[string]int32{
+		"IMPORT_QUEUED":  0,
+		"IMPORT_RUNNING": 1,
+		"IMPORT_DONE":    2,
+	}
+)
+
+func (x ImportJobState) Enum() *ImportJobState {
+	p := new(ImportJobState)
+	*p = x
+	return p
+}
+
+func (x ImportJobState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImportJobState) Descriptor() protoreflect.EnumDescriptor {
+	return file_wallet_messages_proto_enumTypes[9].Descriptor()
+}
+
+func (ImportJobState) Type() protoreflect.EnumType {
+	return &file_wallet_messages_proto_enumTypes[9]
+}
+
+func (x ImportJobState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImportJobState.Descriptor instead.
+func (ImportJobState) EnumDescriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{9}
+}
+
+type AddWalletRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddress string                 `protobuf:"bytes,1,opt,name=walletAddress,proto3" json:"walletAddress,omitempty"`
+	// Added to the tags of the wallet, whether it was watched already or not.
+	Tags          []string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddWalletRequest) Reset() {
+	*x = AddWalletRequest{}
+	mi := &file_wallet_messages_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddWalletRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddWalletRequest) ProtoMessage() {}
+
+func (x *AddWalletRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddWalletRequest.ProtoReflect.Descriptor instead.
+func (*AddWalletRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AddWalletRequest) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *AddWalletRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type AddWalletResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddWalletResponse) Reset() {
+	*x = AddWalletResponse{}
+	mi := &file_wallet_messages_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddWalletResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddWalletResponse) ProtoMessage() {}
+
+func (x *AddWalletResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddWalletResponse.ProtoReflect.Descriptor instead.
+func (*AddWalletResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AddWalletResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type GetWalletRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddress  string                 `protobuf:"bytes,1,opt,name=walletAddress,proto3" json:"walletAddress,omitempty"`
+	Chain          common.CHAIN           `protobuf:"varint,2,opt,name=chain,proto3,enum=common.CHAIN" json:"chain,omitempty"`
+	Type           DataType               `protobuf:"varint,3,opt,name=type,proto3,enum=wallet.DataType" json:"type,omitempty"`
+	TokenAddresses []string               `protobuf:"bytes,4,rep,name=tokenAddresses,proto3" json:"tokenAddresses,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetWalletRequest) Reset() {
+	*x = GetWalletRequest{}
+	mi := &file_wallet_messages_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWalletRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWalletRequest) ProtoMessage() {}
+
+func (x *GetWalletRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWalletRequest.ProtoReflect.Descriptor instead.
+func (*GetWalletRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetWalletRequest) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *GetWalletRequest) GetChain() common.CHAIN {
+	if x != nil {
+		return x.Chain
+	}
+	return common.CHAIN(0)
+}
+
+func (x *GetWalletRequest) GetType() DataType {
+	if x != nil {
+		return x.Type
+	}
+	return DataType_DATA_TYPE_UNSPECIFIED
+}
+
+func (x *GetWalletRequest) GetTokenAddresses() []string {
+	if x != nil {
+		return x.TokenAddresses
+	}
+	return nil
+}
+
+type GetWalletResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	WalletData *common.Wallet         `protobuf:"bytes,1,opt,name=walletData,proto3" json:"walletData,omitempty"`
+	// api when the wallet was just read from Moralis and the chain, db for the stored wallet.
+	DataSource string `protobuf:"bytes,2,opt,name=dataSource,proto3" json:"dataSource,omitempty"`
+	// When the stored wallet was last updated, in unix seconds.
+	UpdatedAt int64 `protobuf:"varint,3,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	// Set for HYBRID reads that started a background refresh.
+	Refreshing    bool `protobuf:"varint,4,opt,name=refreshing,proto3" json:"refreshing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWalletResponse) Reset() {
+	*x = GetWalletResponse{}
+	mi := &file_wallet_messages_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWalletResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWalletResponse) ProtoMessage() {}
+
+func (x *GetWalletResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWalletResponse.ProtoReflect.Descriptor instead.
+func (*GetWalletResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetWalletResponse) GetWalletData() *common.Wallet {
+	if x != nil {
+		return x.WalletData
+	}
+	return nil
+}
+
+func (x *GetWalletResponse) GetDataSource() string {
+	if x != nil {
+		return x.DataSource
+	}
+	return ""
+}
+
+func (x *GetWalletResponse) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *GetWalletResponse) GetRefreshing() bool {
+	if x != nil {
+		return x.Refreshing
+	}
+	return false
+}
+
+type GetWalletTokensRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddress  string                 `protobuf:"bytes,1,opt,name=walletAddress,proto3" json:"walletAddress,omitempty"`
+	Chain          common.CHAIN           `protobuf:"varint,2,opt,name=chain,proto3,enum=common.CHAIN" json:"chain,omitempty"`
+	Type           DataType               `protobuf:"varint,3,opt,name=type,proto3,enum=wallet.DataType" json:"type,omitempty"`
+	TokenAddresses []string               `protobuf:"bytes,4,rep,name=tokenAddresses,proto3" json:"tokenAddresses,omitempty"`
+	FilterLowUSD   bool                   `protobuf:"varint,5,opt,name=filterLowUSD,proto3" json:"filterLowUSD,omitempty"`
+	// Read the balances from Moralis, checked against the chain, instead of listing the stored
+	// tokens of the wallet.
+	WithBalances bool `protobuf:"varint,6,opt,name=withBalances,proto3" json:"withBalances,omitempty"`
+	// Also list the quarantined spam tokens of the wallet, flagged as quarantined.
+	IncludeSpam   bool `protobuf:"varint,7,opt,name=includeSpam,proto3" json:"includeSpam,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWalletTokensRequest) Reset() {
+	*x = GetWalletTokensRequest{}
+	mi := &file_wallet_messages_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWalletTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWalletTokensRequest) ProtoMessage() {}
+
+func (x *GetWalletTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWalletTokensRequest.ProtoReflect.Descriptor instead.
+func (*GetWalletTokensRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetWalletTokensRequest) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *GetWalletTokensRequest) GetChain() common.CHAIN {
+	if x != nil {
+		return x.Chain
+	}
+	return common.CHAIN(0)
+}
+
+func (x *GetWalletTokensRequest) GetType() DataType {
+	if x != nil {
+		return x.Type
+	}
+	return DataType_DATA_TYPE_UNSPECIFIED
+}
+
+func (x *GetWalletTokensRequest) GetTokenAddresses() []string {
+	if x != nil {
+		return x.TokenAddresses
+	}
+	return nil
+}
+
+func (x *GetWalletTokensRequest) GetFilterLowUSD() bool {
+	if x != nil {
+		return x.FilterLowUSD
+	}
+	return false
+}
+
+func (x *GetWalletTokensRequest) GetWithBalances() bool {
+	if x != nil {
+		return x.WithBalances
+	}
+	return false
+}
+
+func (x *GetWalletTokensRequest) GetIncludeSpam() bool {
+	if x != nil {
+		return x.IncludeSpam
+	}
+	return false
+}
+
+type GetWalletTokensResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Tokens         []*common.WalletToken  `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	NumberOfTokens int32                  `protobuf:"varint,2,opt,name=numberOfTokens,proto3" json:"numberOfTokens,omitempty"`
+	// stored for the stored token list; moralis, or onchain when on-chain balances replaced some
+	// Moralis ones, for balances.
+	DataSource    string `protobuf:"bytes,3,opt,name=dataSource,proto3" json:"dataSource,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWalletTokensResponse) Reset() {
+	*x = GetWalletTokensResponse{}
+	mi := &file_wallet_messages_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWalletTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWalletTokensResponse) ProtoMessage() {}
+
+func (x *GetWalletTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWalletTokensResponse.ProtoReflect.Descriptor instead.
+func (*GetWalletTokensResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetWalletTokensResponse) GetTokens() []*common.WalletToken {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+func (x *GetWalletTokensResponse) GetNumberOfTokens() int32 {
+	if x != nil {
+		return x.NumberOfTokens
+	}
+	return 0
+}
+
+func (x *GetWalletTokensResponse) GetDataSource() string {
+	if x != nil {
+		return x.DataSource
+	}
+	return ""
+}
+
+// Lists a spam token in the token list of a wallet despite the global blacklist, or with revoke
+// quarantines it again from the next wallet update.
+type MarkTokenSafeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddress string                 `protobuf:"bytes,1,opt,name=walletAddress,proto3" json:"walletAddress,omitempty"`
+	TokenAddress  string                 `protobuf:"bytes,2,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
+	Revoke        bool                   `protobuf:"varint,3,opt,name=revoke,proto3" json:"revoke,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkTokenSafeRequest) Reset() {
+	*x = MarkTokenSafeRequest{}
+	mi := &file_wallet_messages_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkTokenSafeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkTokenSafeRequest) ProtoMessage() {}
+
+func (x *MarkTokenSafeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkTokenSafeRequest.ProtoReflect.Descriptor instead.
+func (*MarkTokenSafeRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MarkTokenSafeRequest) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *MarkTokenSafeRequest) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *MarkTokenSafeRequest) GetRevoke() bool {
+	if x != nil {
+		return x.Revoke
+	}
+	return false
+}
+
+type MarkTokenSafeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkTokenSafeResponse) Reset() {
+	*x = MarkTokenSafeResponse{}
+	mi := &file_wallet_messages_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkTokenSafeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkTokenSafeResponse) ProtoMessage() {}
+
+func (x *MarkTokenSafeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkTokenSafeResponse.ProtoReflect.Descriptor instead.
+func (*MarkTokenSafeResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MarkTokenSafeResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type GetWalletDetailsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddress  string                 `protobuf:"bytes,1,opt,name=walletAddress,proto3" json:"walletAddress,omitempty"`
+	Chain          common.CHAIN           `protobuf:"varint,2,opt,name=chain,proto3,enum=common.CHAIN" json:"chain,omitempty"`
+	Type           DataType               `protobuf:"varint,3,opt,name=type,proto3,enum=wallet.DataType" json:"type,omitempty"`
+	TokenAddresses []string               `protobuf:"bytes,4,rep,name=tokenAddresses,proto3" json:"tokenAddresses,omitempty"`
+	FilterLowUSD   bool                   `protobuf:"varint,5,opt,name=filterLowUSD,proto3" json:"filterLowUSD,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetWalletDetailsRequest) Reset() {
+	*x = GetWalletDetailsRequest{}
+	mi := &file_wallet_messages_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWalletDetailsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWalletDetailsRequest) ProtoMessage() {}
+
+func (x *GetWalletDetailsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWalletDetailsRequest.ProtoReflect.Descriptor instead.
+func (*GetWalletDetailsRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetWalletDetailsRequest) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *GetWalletDetailsRequest) GetChain() common.CHAIN {
+	if x != nil {
+		return x.Chain
+	}
+	return common.CHAIN(0)
+}
+
+func (x *GetWalletDetailsRequest) GetType() DataType {
+	if x != nil {
+		return x.Type
+	}
+	return DataType_DATA_TYPE_UNSPECIFIED
+}
+
+func (x *GetWalletDetailsRequest) GetTokenAddresses() []string {
+	if x != nil {
+		return x.TokenAddresses
+	}
+	return nil
+}
+
+func (x *GetWalletDetailsRequest) GetFilterLowUSD() bool {
+	if x != nil {
+		return x.FilterLowUSD
+	}
+	return false
+}
+
+type GetWalletDetailsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Tokens         []*common.WalletToken  `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	NumberOfTokens int32                  `protobuf:"varint,2,opt,name=numberOfTokens,proto3" json:"numberOfTokens,omitempty"`
+	WalletData     *common.Wallet         `protobuf:"bytes,3,opt,name=walletData,proto3" json:"walletData,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetWalletDetailsResponse) Reset() {
+	*x = GetWalletDetailsResponse{}
+	mi := &file_wallet_messages_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWalletDetailsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWalletDetailsResponse) ProtoMessage() {}
+
+func (x *GetWalletDetailsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWalletDetailsResponse.ProtoReflect.Descriptor instead.
+func (*GetWalletDetailsResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetWalletDetailsResponse) GetTokens() []*common.WalletToken {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+func (x *GetWalletDetailsResponse) GetNumberOfTokens() int32 {
+	if x != nil {
+		return x.NumberOfTokens
+	}
+	return 0
+}
+
+func (x *GetWalletDetailsResponse) GetWalletData() *common.Wallet {
+	if x != nil {
+		return x.WalletData
+	}
+	return nil
+}
+
+type UpdateWalletPortfolioRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddress    string                 `protobuf:"bytes,1,opt,name=walletAddress,proto3" json:"walletAddress,omitempty"`
+	TotalDollarValue string                 `protobuf:"bytes,2,opt,name=totalDollarValue,proto3" json:"totalDollarValue,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *UpdateWalletPortfolioRequest) Reset() {
+	*x = UpdateWalletPortfolioRequest{}
+	mi := &file_wallet_messages_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateWalletPortfolioRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateWalletPortfolioRequest) ProtoMessage() {}
+
+func (x *UpdateWalletPortfolioRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateWalletPortfolioRequest.ProtoReflect.Descriptor instead.
+func (*UpdateWalletPortfolioRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateWalletPortfolioRequest) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *UpdateWalletPortfolioRequest) GetTotalDollarValue() string {
+	if x != nil {
+		return x.TotalDollarValue
+	}
+	return ""
+}
+
+type UpdateWalletPortfolioResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateWalletPortfolioResponse) Reset() {
+	*x = UpdateWalletPortfolioResponse{}
+	mi := &file_wallet_messages_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateWalletPortfolioResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateWalletPortfolioResponse) ProtoMessage() {}
+
+func (x *UpdateWalletPortfolioResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateWalletPortfolioResponse.ProtoReflect.Descriptor instead.
+func (*UpdateWalletPortfolioResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateWalletPortfolioResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type WatchTokenHoldersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TokenAddress  string                 `protobuf:"bytes,1,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
+	Limit         *int32                 `protobuf:"varint,2,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchTokenHoldersRequest) Reset() {
+	*x = WatchTokenHoldersRequest{}
+	mi := &file_wallet_messages_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTokenHoldersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTokenHoldersRequest) ProtoMessage() {}
+
+func (x *WatchTokenHoldersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTokenHoldersRequest.ProtoReflect.Descriptor instead.
+func (*WatchTokenHoldersRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *WatchTokenHoldersRequest) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *WatchTokenHoldersRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+type WatchTokenHoldersResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Success         bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	WalletAddresses []string               `protobuf:"bytes,2,rep,name=walletAddresses,proto3" json:"walletAddresses,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *WatchTokenHoldersResponse) Reset() {
+	*x = WatchTokenHoldersResponse{}
+	mi := &file_wallet_messages_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTokenHoldersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTokenHoldersResponse) ProtoMessage() {}
+
+func (x *WatchTokenHoldersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTokenHoldersResponse.ProtoReflect.Descriptor instead.
+func (*WatchTokenHoldersResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *WatchTokenHoldersResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *WatchTokenHoldersResponse) GetWalletAddresses() []string {
+	if x != nil {
+		return x.WalletAddresses
+	}
+	return nil
+}
+
+type GetHolderFlowsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TokenAddress  string                 `protobuf:"bytes,1,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHolderFlowsRequest) Reset() {
+	*x = GetHolderFlowsRequest{}
+	mi := &file_wallet_messages_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHolderFlowsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHolderFlowsRequest) ProtoMessage() {}
+
+func (x *GetHolderFlowsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHolderFlowsRequest.ProtoReflect.Descriptor instead.
+func (*GetHolderFlowsRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetHolderFlowsRequest) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+type HolderFlow struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddress  string                 `protobuf:"bytes,1,opt,name=walletAddress,proto3" json:"walletAddress,omitempty"`
+	InitialBalance string                 `protobuf:"bytes,2,opt,name=initialBalance,proto3" json:"initialBalance,omitempty"`
+	Balance        string                 `protobuf:"bytes,3,opt,name=balance,proto3" json:"balance,omitempty"`
+	Change         string                 `protobuf:"bytes,4,opt,name=change,proto3" json:"change,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *HolderFlow) Reset() {
+	*x = HolderFlow{}
+	mi := &file_wallet_messages_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HolderFlow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HolderFlow) ProtoMessage() {}
+
+func (x *HolderFlow) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HolderFlow.ProtoReflect.Descriptor instead.
+func (*HolderFlow) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *HolderFlow) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *HolderFlow) GetInitialBalance() string {
+	if x != nil {
+		return x.InitialBalance
+	}
+	return ""
+}
+
+func (x *HolderFlow) GetBalance() string {
+	if x != nil {
+		return x.Balance
+	}
+	return ""
+}
+
+func (x *HolderFlow) GetChange() string {
+	if x != nil {
+		return x.Change
+	}
+	return ""
+}
+
+type GetHolderFlowsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TokenAddress  string                 `protobuf:"bytes,1,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
+	HolderCount   int32                  `protobuf:"varint,2,opt,name=holderCount,proto3" json:"holderCount,omitempty"`
+	Inflow        string                 `protobuf:"bytes,3,opt,name=inflow,proto3" json:"inflow,omitempty"`
+	Outflow       string                 `protobuf:"bytes,4,opt,name=outflow,proto3" json:"outflow,omitempty"`
+	NetFlow       string                 `protobuf:"bytes,5,opt,name=netFlow,proto3" json:"netFlow,omitempty"`
+	Accumulating  int32                  `protobuf:"varint,6,opt,name=accumulating,proto3" json:"accumulating,omitempty"`
+	Distributing  int32                  `protobuf:"varint,7,opt,name=distributing,proto3" json:"distributing,omitempty"`
+	Holders       []*HolderFlow          `protobuf:"bytes,8,rep,name=holders,proto3" json:"holders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHolderFlowsResponse) Reset() {
+	*x = GetHolderFlowsResponse{}
+	mi := &file_wallet_messages_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHolderFlowsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHolderFlowsResponse) ProtoMessage() {}
+
+func (x *GetHolderFlowsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHolderFlowsResponse.ProtoReflect.Descriptor instead.
+func (*GetHolderFlowsResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetHolderFlowsResponse) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *GetHolderFlowsResponse) GetHolderCount() int32 {
+	if x != nil {
+		return x.HolderCount
+	}
+	return 0
+}
+
+func (x *GetHolderFlowsResponse) GetInflow() string {
+	if x != nil {
+		return x.Inflow
+	}
+	return ""
+}
+
+func (x *GetHolderFlowsResponse) GetOutflow() string {
+	if x != nil {
+		return x.Outflow
+	}
+	return ""
+}
+
+func (x *GetHolderFlowsResponse) GetNetFlow() string {
+	if x != nil {
+		return x.NetFlow
+	}
+	return ""
+}
+
+func (x *GetHolderFlowsResponse) GetAccumulating() int32 {
+	if x != nil {
+		return x.Accumulating
+	}
+	return 0
+}
+
+func (x *GetHolderFlowsResponse) GetDistributing() int32 {
+	if x != nil {
+		return x.Distributing
+	}
+	return 0
+}
+
+func (x *GetHolderFlowsResponse) GetHolders() []*HolderFlow {
+	if x != nil {
+		return x.Holders
+	}
+	return nil
+}
+
+// Unset fields leave the wallet unchanged: the label when label is unset, the tags and groups
+// when the lists are empty. clearTags and clearGroups remove all of them.
+type SetWalletLabelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddress string                 `protobuf:"bytes,1,opt,name=walletAddress,proto3" json:"walletAddress,omitempty"`
+	Label         *string                `protobuf:"bytes,2,opt,name=label,proto3,oneof" json:"label,omitempty"`
+	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	Groups        []string               `protobuf:"bytes,4,rep,name=groups,proto3" json:"groups,omitempty"`
+	ClearTags     bool                   `protobuf:"varint,5,opt,name=clearTags,proto3" json:"clearTags,omitempty"`
+	ClearGroups   bool                   `protobuf:"varint,6,opt,name=clearGroups,proto3" json:"clearGroups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetWalletLabelRequest) Reset() {
+	*x = SetWalletLabelRequest{}
+	mi := &file_wallet_messages_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetWalletLabelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetWalletLabelRequest) ProtoMessage() {}
+
+func (x *SetWalletLabelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetWalletLabelRequest.ProtoReflect.Descriptor instead.
+func (*SetWalletLabelRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SetWalletLabelRequest) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *SetWalletLabelRequest) GetLabel() string {
+	if x != nil && x.Label != nil {
+		return *x.Label
+	}
+	return ""
+}
+
+func (x *SetWalletLabelRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *SetWalletLabelRequest) GetGroups() []string {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *SetWalletLabelRequest) GetClearTags() bool {
+	if x != nil {
+		return x.ClearTags
+	}
+	return false
+}
+
+func (x *SetWalletLabelRequest) GetClearGroups() bool {
+	if x != nil {
+		return x.ClearGroups
+	}
+	return false
+}
+
+type SetWalletLabelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	WalletData    *common.Wallet         `protobuf:"bytes,2,opt,name=walletData,proto3" json:"walletData,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetWalletLabelResponse) Reset() {
+	*x = SetWalletLabelResponse{}
+	mi := &file_wallet_messages_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetWalletLabelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetWalletLabelResponse) ProtoMessage() {}
+
+func (x *SetWalletLabelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetWalletLabelResponse.ProtoReflect.Descriptor instead.
+func (*SetWalletLabelResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SetWalletLabelResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SetWalletLabelResponse) GetWalletData() *common.Wallet {
+	if x != nil {
+		return x.WalletData
+	}
+	return nil
+}
+
+type ListWalletsByTagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tag           string                 `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Group         *string                `protobuf:"bytes,2,opt,name=group,proto3,oneof" json:"group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWalletsByTagRequest) Reset() {
+	*x = ListWalletsByTagRequest{}
+	mi := &file_wallet_messages_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWalletsByTagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWalletsByTagRequest) ProtoMessage() {}
+
+func (x *ListWalletsByTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWalletsByTagRequest.ProtoReflect.Descriptor instead.
+func (*ListWalletsByTagRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListWalletsByTagRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *ListWalletsByTagRequest) GetGroup() string {
+	if x != nil && x.Group != nil {
+		return *x.Group
+	}
+	return ""
+}
+
+type ListWalletsByTagResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Wallets       []*common.Wallet       `protobuf:"bytes,1,rep,name=wallets,proto3" json:"wallets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWalletsByTagResponse) Reset() {
+	*x = ListWalletsByTagResponse{}
+	mi := &file_wallet_messages_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWalletsByTagResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWalletsByTagResponse) ProtoMessage() {}
+
+func (x *ListWalletsByTagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWalletsByTagResponse.ProtoReflect.Descriptor instead.
+func (*ListWalletsByTagResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListWalletsByTagResponse) GetWallets() []*common.Wallet {
+	if x != nil {
+		return x.Wallets
+	}
+	return nil
+}
+
+type StreamWalletTradesRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddresses []string               `protobuf:"bytes,1,rep,name=walletAddresses,proto3" json:"walletAddresses,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *StreamWalletTradesRequest) Reset() {
+	*x = StreamWalletTradesRequest{}
+	mi := &file_wallet_messages_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamWalletTradesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamWalletTradesRequest) ProtoMessage() {}
+
+func (x *StreamWalletTradesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamWalletTradesRequest.ProtoReflect.Descriptor instead.
+func (*StreamWalletTradesRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *StreamWalletTradesRequest) GetWalletAddresses() []string {
+	if x != nil {
+		return x.WalletAddresses
+	}
+	return nil
+}
+
+type WalletTrade struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddress     string                 `protobuf:"bytes,1,opt,name=walletAddress,proto3" json:"walletAddress,omitempty"`
+	WalletLabel       string                 `protobuf:"bytes,2,opt,name=walletLabel,proto3" json:"walletLabel,omitempty"`
+	Side              TradeSide              `protobuf:"varint,3,opt,name=side,proto3,enum=wallet.TradeSide" json:"side,omitempty"`
+	TokenAddress      string                 `protobuf:"bytes,4,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
+	TokenName         string                 `protobuf:"bytes,5,opt,name=tokenName,proto3" json:"tokenName,omitempty"`
+	TokenSymbol       string                 `protobuf:"bytes,6,opt,name=tokenSymbol,proto3" json:"tokenSymbol,omitempty"`
+	TokenAmount       string                 `protobuf:"bytes,7,opt,name=tokenAmount,proto3" json:"tokenAmount,omitempty"`
+	PriceUsd          string                 `protobuf:"bytes,8,opt,name=priceUsd,proto3" json:"priceUsd,omitempty"`
+	UsdValue          string                 `protobuf:"bytes,9,opt,name=usdValue,proto3" json:"usdValue,omitempty"`
+	TxHash            string                 `protobuf:"bytes,10,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	Timestamp         int64                  `protobuf:"varint,11,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Counterparty      string                 `protobuf:"bytes,12,opt,name=counterparty,proto3" json:"counterparty,omitempty"`
+	CounterpartyLabel string                 `protobuf:"bytes,13,opt,name=counterpartyLabel,proto3" json:"counterpartyLabel,omitempty"`
+	// What the transaction of the trade did, e.g. a mint paid for in ETH rather than a swap.
+	Activity      ActivityType `protobuf:"varint,14,opt,name=activity,proto3,enum=wallet.ActivityType" json:"activity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WalletTrade) Reset() {
+	*x = WalletTrade{}
+	mi := &file_wallet_messages_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WalletTrade) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WalletTrade) ProtoMessage() {}
+
+func (x *WalletTrade) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WalletTrade.ProtoReflect.Descriptor instead.
+func (*WalletTrade) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *WalletTrade) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *WalletTrade) GetWalletLabel() string {
+	if x != nil {
+		return x.WalletLabel
+	}
+	return ""
+}
+
+func (x *WalletTrade) GetSide() TradeSide {
+	if x != nil {
+		return x.Side
+	}
+	return TradeSide_BUY
+}
+
+func (x *WalletTrade) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *WalletTrade) GetTokenName() string {
+	if x != nil {
+		return x.TokenName
+	}
+	return ""
+}
+
+func (x *WalletTrade) GetTokenSymbol() string {
+	if x != nil {
+		return x.TokenSymbol
+	}
+	return ""
+}
+
+func (x *WalletTrade) GetTokenAmount() string {
+	if x != nil {
+		return x.TokenAmount
+	}
+	return ""
+}
+
+func (x *WalletTrade) GetPriceUsd() string {
+	if x != nil {
+		return x.PriceUsd
+	}
+	return ""
+}
+
+func (x *WalletTrade) GetUsdValue() string {
+	if x != nil {
+		return x.UsdValue
+	}
+	return ""
+}
+
+func (x *WalletTrade) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *WalletTrade) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *WalletTrade) GetCounterparty() string {
+	if x != nil {
+		return x.Counterparty
+	}
+	return ""
+}
+
+func (x *WalletTrade) GetCounterpartyLabel() string {
+	if x != nil {
+		return x.CounterpartyLabel
+	}
+	return ""
+}
+
+func (x *WalletTrade) GetActivity() ActivityType {
+	if x != nil {
+		return x.Activity
+	}
+	return ActivityType_ACTIVITY_CONTRACT_INTERACTION
+}
+
+// Transactions are the ones the wallet sent or received ETH in, newest first.
+type GetWalletTransactionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddress string                 `protobuf:"bytes,1,opt,name=walletAddress,proto3" json:"walletAddress,omitempty"`
+	Page          *int32                 `protobuf:"varint,2,opt,name=page,proto3,oneof" json:"page,omitempty"`
+	PageSize      *int32                 `protobuf:"varint,3,opt,name=pageSize,proto3,oneof" json:"pageSize,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWalletTransactionsRequest) Reset() {
+	*x = GetWalletTransactionsRequest{}
+	mi := &file_wallet_messages_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWalletTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWalletTransactionsRequest) ProtoMessage() {}
+
+func (x *GetWalletTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWalletTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*GetWalletTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetWalletTransactionsRequest) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *GetWalletTransactionsRequest) GetPage() int32 {
+	if x != nil && x.Page != nil {
+		return *x.Page
+	}
+	return 0
+}
+
+func (x *GetWalletTransactionsRequest) GetPageSize() int32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+type WalletTransaction struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	TxHash    string                 `protobuf:"bytes,1,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	Timestamp int64                  `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	From      string                 `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To        string                 `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	// incoming, outgoing or self.
+	Direction string       `protobuf:"bytes,5,opt,name=direction,proto3" json:"direction,omitempty"`
+	ValueWei  string       `protobuf:"bytes,6,opt,name=valueWei,proto3" json:"valueWei,omitempty"`
+	Activity  ActivityType `protobuf:"varint,7,opt,name=activity,proto3,enum=wallet.ActivityType" json:"activity,omitempty"`
+	Failed    bool         `protobuf:"varint,8,opt,name=failed,proto3" json:"failed,omitempty"`
+	// USD value of valueWei at the native token price recorded at or before the transaction,
+	// empty when tokendata has no price of that time.
+	ValueUsd      string `protobuf:"bytes,9,opt,name=valueUsd,proto3" json:"valueUsd,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WalletTransaction) Reset() {
+	*x = WalletTransaction{}
+	mi := &file_wallet_messages_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WalletTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WalletTransaction) ProtoMessage() {}
+
+func (x *WalletTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WalletTransaction.ProtoReflect.Descriptor instead.
+func (*WalletTransaction) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *WalletTransaction) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *WalletTransaction) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *WalletTransaction) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *WalletTransaction) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *WalletTransaction) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *WalletTransaction) GetValueWei() string {
+	if x != nil {
+		return x.ValueWei
+	}
+	return ""
+}
+
+func (x *WalletTransaction) GetActivity() ActivityType {
+	if x != nil {
+		return x.Activity
+	}
+	return ActivityType_ACTIVITY_CONTRACT_INTERACTION
+}
+
+func (x *WalletTransaction) GetFailed() bool {
+	if x != nil {
+		return x.Failed
+	}
+	return false
+}
+
+func (x *WalletTransaction) GetValueUsd() string {
+	if x != nil {
+		return x.ValueUsd
+	}
+	return ""
+}
+
+type GetWalletTransactionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddress string                 `protobuf:"bytes,1,opt,name=walletAddress,proto3" json:"walletAddress,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	Transactions  []*WalletTransaction   `protobuf:"bytes,4,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWalletTransactionsResponse) Reset() {
+	*x = GetWalletTransactionsResponse{}
+	mi := &file_wallet_messages_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWalletTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWalletTransactionsResponse) ProtoMessage() {}
+
+func (x *GetWalletTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWalletTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*GetWalletTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetWalletTransactionsResponse) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *GetWalletTransactionsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetWalletTransactionsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetWalletTransactionsResponse) GetTransactions() []*WalletTransaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+type GetWalletLeaderboardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Period        LeaderboardPeriod      `protobuf:"varint,1,opt,name=period,proto3,enum=wallet.LeaderboardPeriod" json:"period,omitempty"`
+	Page          *int32                 `protobuf:"varint,2,opt,name=page,proto3,oneof" json:"page,omitempty"`
+	PageSize      *int32                 `protobuf:"varint,3,opt,name=pageSize,proto3,oneof" json:"pageSize,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWalletLeaderboardRequest) Reset() {
+	*x = GetWalletLeaderboardRequest{}
+	mi := &file_wallet_messages_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWalletLeaderboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWalletLeaderboardRequest) ProtoMessage() {}
+
+func (x *GetWalletLeaderboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWalletLeaderboardRequest.ProtoReflect.Descriptor instead.
+func (*GetWalletLeaderboardRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetWalletLeaderboardRequest) GetPeriod() LeaderboardPeriod {
+	if x != nil {
+		return x.Period
+	}
+	return LeaderboardPeriod_PERIOD_7D
+}
+
+func (x *GetWalletLeaderboardRequest) GetPage() int32 {
+	if x != nil && x.Page != nil {
+		return *x.Page
+	}
+	return 0
+}
+
+func (x *GetWalletLeaderboardRequest) GetPageSize() int32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+type LeaderboardEntry struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Rank           int32                  `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`
+	WalletAddress  string                 `protobuf:"bytes,2,opt,name=walletAddress,proto3" json:"walletAddress,omitempty"`
+	WalletLabel    string                 `protobuf:"bytes,3,opt,name=walletLabel,proto3" json:"walletLabel,omitempty"`
+	RealizedPnlUsd string                 `protobuf:"bytes,4,opt,name=realizedPnlUsd,proto3" json:"realizedPnlUsd,omitempty"`
+	WinRate        string                 `protobuf:"bytes,5,opt,name=winRate,proto3" json:"winRate,omitempty"`
+	TradeCount     int32                  `protobuf:"varint,6,opt,name=tradeCount,proto3" json:"tradeCount,omitempty"`
+	WinningTrades  int32                  `protobuf:"varint,7,opt,name=winningTrades,proto3" json:"winningTrades,omitempty"`
+	// Only set on the daily leaderboard: open positions in tokens traded that day marked at the
+	// current price, and the realized plus unrealized total the entries are ranked by.
+	UnrealizedPnlUsd string `protobuf:"bytes,8,opt,name=unrealizedPnlUsd,proto3" json:"unrealizedPnlUsd,omitempty"`
+	TotalPnlUsd      string `protobuf:"bytes,9,opt,name=totalPnlUsd,proto3" json:"totalPnlUsd,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *LeaderboardEntry) Reset() {
+	*x = LeaderboardEntry{}
+	mi := &file_wallet_messages_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardEntry) ProtoMessage() {}
+
+func (x *LeaderboardEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardEntry.ProtoReflect.Descriptor instead.
+func (*LeaderboardEntry) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *LeaderboardEntry) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *LeaderboardEntry) GetWalletLabel() string {
+	if x != nil {
+		return x.WalletLabel
+	}
+	return ""
+}
+
+func (x *LeaderboardEntry) GetRealizedPnlUsd() string {
+	if x != nil {
+		return x.RealizedPnlUsd
+	}
+	return ""
+}
+
+func (x *LeaderboardEntry) GetWinRate() string {
+	if x != nil {
+		return x.WinRate
+	}
+	return ""
+}
+
+func (x *LeaderboardEntry) GetTradeCount() int32 {
+	if x != nil {
+		return x.TradeCount
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetWinningTrades() int32 {
+	if x != nil {
+		return x.WinningTrades
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetUnrealizedPnlUsd() string {
+	if x != nil {
+		return x.UnrealizedPnlUsd
+	}
+	return ""
+}
+
+func (x *LeaderboardEntry) GetTotalPnlUsd() string {
+	if x != nil {
+		return x.TotalPnlUsd
+	}
+	return ""
+}
+
+type GetWalletLeaderboardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*LeaderboardEntry    `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWalletLeaderboardResponse) Reset() {
+	*x = GetWalletLeaderboardResponse{}
+	mi := &file_wallet_messages_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWalletLeaderboardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWalletLeaderboardResponse) ProtoMessage() {}
+
+func (x *GetWalletLeaderboardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWalletLeaderboardResponse.ProtoReflect.Descriptor instead.
+func (*GetWalletLeaderboardResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetWalletLeaderboardResponse) GetEntries() []*LeaderboardEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetWalletLeaderboardResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetWalletLeaderboardResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetWalletLeaderboardResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type GetDailyLeaderboardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          *int32                 `protobuf:"varint,1,opt,name=page,proto3,oneof" json:"page,omitempty"`
+	PageSize      *int32                 `protobuf:"varint,2,opt,name=pageSize,proto3,oneof" json:"pageSize,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDailyLeaderboardRequest) Reset() {
+	*x = GetDailyLeaderboardRequest{}
+	mi := &file_wallet_messages_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDailyLeaderboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDailyLeaderboardRequest) ProtoMessage() {}
+
+func (x *GetDailyLeaderboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDailyLeaderboardRequest.ProtoReflect.Descriptor instead.
+func (*GetDailyLeaderboardRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetDailyLeaderboardRequest) GetPage() int32 {
+	if x != nil && x.Page != nil {
+		return *x.Page
+	}
+	return 0
+}
+
+func (x *GetDailyLeaderboardRequest) GetPageSize() int32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+type GetDailyLeaderboardResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Entries  []*LeaderboardEntry    `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	Page     int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize int32                  `protobuf:"varint,3,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	Total    int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	// UTC day the leaderboard covers, as YYYY-MM-DD.
+	Day string `protobuf:"bytes,5,opt,name=day,proto3" json:"day,omitempty"`
+	// Unix milliseconds of the last computation.
+	UpdatedAt     int64 `protobuf:"varint,6,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDailyLeaderboardResponse) Reset() {
+	*x = GetDailyLeaderboardResponse{}
+	mi := &file_wallet_messages_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDailyLeaderboardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDailyLeaderboardResponse) ProtoMessage() {}
+
+func (x *GetDailyLeaderboardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDailyLeaderboardResponse.ProtoReflect.Descriptor instead.
+func (*GetDailyLeaderboardResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetDailyLeaderboardResponse) GetEntries() []*LeaderboardEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetDailyLeaderboardResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetDailyLeaderboardResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetDailyLeaderboardResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetDailyLeaderboardResponse) GetDay() string {
+	if x != nil {
+		return x.Day
+	}
+	return ""
+}
+
+func (x *GetDailyLeaderboardResponse) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type SetWalletLeaderboardOptInRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddress string                 `protobuf:"bytes,1,opt,name=walletAddress,proto3" json:"walletAddress,omitempty"`
+	OptIn         bool                   `protobuf:"varint,2,opt,name=optIn,proto3" json:"optIn,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetWalletLeaderboardOptInRequest) Reset() {
+	*x = SetWalletLeaderboardOptInRequest{}
+	mi := &file_wallet_messages_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetWalletLeaderboardOptInRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetWalletLeaderboardOptInRequest) ProtoMessage() {}
+
+func (x *SetWalletLeaderboardOptInRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetWalletLeaderboardOptInRequest.ProtoReflect.Descriptor instead.
+func (*SetWalletLeaderboardOptInRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SetWalletLeaderboardOptInRequest) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *SetWalletLeaderboardOptInRequest) GetOptIn() bool {
+	if x != nil {
+		return x.OptIn
+	}
+	return false
+}
+
+type SetWalletLeaderboardOptInResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetWalletLeaderboardOptInResponse) Reset() {
+	*x = SetWalletLeaderboardOptInResponse{}
+	mi := &file_wallet_messages_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetWalletLeaderboardOptInResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetWalletLeaderboardOptInResponse) ProtoMessage() {}
+
+func (x *SetWalletLeaderboardOptInResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetWalletLeaderboardOptInResponse.ProtoReflect.Descriptor instead.
+func (*SetWalletLeaderboardOptInResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SetWalletLeaderboardOptInResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// Minimums are decimal strings, empty for none: minNative in ETH, minTokenUsd in USD. Token
+// transfers of a wallet update it only when their token passes the lists and a minimum is met.
+type SetWalletWatchFilterRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddress  string                 `protobuf:"bytes,1,opt,name=walletAddress,proto3" json:"walletAddress,omitempty"`
+	MinNative      string                 `protobuf:"bytes,2,opt,name=minNative,proto3" json:"minNative,omitempty"`
+	MinTokenUsd    string                 `protobuf:"bytes,3,opt,name=minTokenUsd,proto3" json:"minTokenUsd,omitempty"`
+	TokenAllowlist []string               `protobuf:"bytes,4,rep,name=tokenAllowlist,proto3" json:"tokenAllowlist,omitempty"`
+	TokenDenylist  []string               `protobuf:"bytes,5,rep,name=tokenDenylist,proto3" json:"tokenDenylist,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SetWalletWatchFilterRequest) Reset() {
+	*x = SetWalletWatchFilterRequest{}
+	mi := &file_wallet_messages_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetWalletWatchFilterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetWalletWatchFilterRequest) ProtoMessage() {}
+
+func (x *SetWalletWatchFilterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetWalletWatchFilterRequest.ProtoReflect.Descriptor instead.
+func (*SetWalletWatchFilterRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SetWalletWatchFilterRequest) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *SetWalletWatchFilterRequest) GetMinNative() string {
+	if x != nil {
+		return x.MinNative
+	}
+	return ""
+}
+
+func (x *SetWalletWatchFilterRequest) GetMinTokenUsd() string {
+	if x != nil {
+		return x.MinTokenUsd
+	}
+	return ""
+}
+
+func (x *SetWalletWatchFilterRequest) GetTokenAllowlist() []string {
+	if x != nil {
+		return x.TokenAllowlist
+	}
+	return nil
+}
+
+func (x *SetWalletWatchFilterRequest) GetTokenDenylist() []string {
+	if x != nil {
+		return x.TokenDenylist
+	}
+	return nil
+}
+
+type SetWalletWatchFilterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetWalletWatchFilterResponse) Reset() {
+	*x = SetWalletWatchFilterResponse{}
+	mi := &file_wallet_messages_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetWalletWatchFilterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetWalletWatchFilterResponse) ProtoMessage() {}
+
+func (x *SetWalletWatchFilterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetWalletWatchFilterResponse.ProtoReflect.Descriptor instead.
+func (*SetWalletWatchFilterResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SetWalletWatchFilterResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// An empty url removes the webhook. The url must resolve to public addresses only, and redirects
+// are not followed. Notifications are POSTed as JSON with the hex HMAC-SHA256 of the body under
+// the secret in the X-Samterminal-Signature header. Empty events subscribe to all of them;
+// minValueChangePct is a decimal string, empty for the default of 5, measured from the value last
+// sent.
+type SetWalletWebhookRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddress     string                 `protobuf:"bytes,1,opt,name=walletAddress,proto3" json:"walletAddress,omitempty"`
+	Url               string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Secret            string                 `protobuf:"bytes,3,opt,name=secret,proto3" json:"secret,omitempty"`
+	Events            []WebhookEvent         `protobuf:"varint,4,rep,packed,name=events,proto3,enum=wallet.WebhookEvent" json:"events,omitempty"`
+	MinValueChangePct string                 `protobuf:"bytes,5,opt,name=minValueChangePct,proto3" json:"minValueChangePct,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SetWalletWebhookRequest) Reset() {
+	*x = SetWalletWebhookRequest{}
+	mi := &file_wallet_messages_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetWalletWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetWalletWebhookRequest) ProtoMessage() {}
+
+func (x *SetWalletWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetWalletWebhookRequest.ProtoReflect.Descriptor instead.
+func (*SetWalletWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SetWalletWebhookRequest) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *SetWalletWebhookRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *SetWalletWebhookRequest) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *SetWalletWebhookRequest) GetEvents() []WebhookEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *SetWalletWebhookRequest) GetMinValueChangePct() string {
+	if x != nil {
+		return x.MinValueChangePct
+	}
+	return ""
+}
+
+type SetWalletWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetWalletWebhookResponse) Reset() {
+	*x = SetWalletWebhookResponse{}
+	mi := &file_wallet_messages_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetWalletWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetWalletWebhookResponse) ProtoMessage() {}
+
+func (x *SetWalletWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetWalletWebhookResponse.ProtoReflect.Descriptor instead.
+func (*SetWalletWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *SetWalletWebhookResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// Alert rules of a wallet; thresholds are decimal strings, empty to turn a rule off. A value alert
+// fires when the portfolio value moves by valueChangePct percent within windowMinutes, a
+// transfer alert when the wallet sends or receives at least transferMinUsd in one transaction.
+// Alerts go to the webhook of the wallet and to wallet event subscribers.
+type SetWalletAlertsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddress  string                 `protobuf:"bytes,1,opt,name=walletAddress,proto3" json:"walletAddress,omitempty"`
+	ValueChangePct string                 `protobuf:"bytes,2,opt,name=valueChangePct,proto3" json:"valueChangePct,omitempty"`
+	WindowMinutes  int32                  `protobuf:"varint,3,opt,name=windowMinutes,proto3" json:"windowMinutes,omitempty"`
+	TransferMinUsd string                 `protobuf:"bytes,4,opt,name=transferMinUsd,proto3" json:"transferMinUsd,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SetWalletAlertsRequest) Reset() {
+	*x = SetWalletAlertsRequest{}
+	mi := &file_wallet_messages_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetWalletAlertsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetWalletAlertsRequest) ProtoMessage() {}
+
+func (x *SetWalletAlertsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetWalletAlertsRequest.ProtoReflect.Descriptor instead.
+func (*SetWalletAlertsRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SetWalletAlertsRequest) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *SetWalletAlertsRequest) GetValueChangePct() string {
+	if x != nil {
+		return x.ValueChangePct
+	}
+	return ""
+}
+
+func (x *SetWalletAlertsRequest) GetWindowMinutes() int32 {
+	if x != nil {
+		return x.WindowMinutes
+	}
+	return 0
+}
+
+func (x *SetWalletAlertsRequest) GetTransferMinUsd() string {
+	if x != nil {
+		return x.TransferMinUsd
+	}
+	return ""
+}
+
+type SetWalletAlertsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetWalletAlertsResponse) Reset() {
+	*x = SetWalletAlertsResponse{}
+	mi := &file_wallet_messages_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetWalletAlertsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetWalletAlertsResponse) ProtoMessage() {}
+
+func (x *SetWalletAlertsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetWalletAlertsResponse.ProtoReflect.Descriptor instead.
+func (*SetWalletAlertsResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *SetWalletAlertsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type WalletAlert struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddress string                 `protobuf:"bytes,1,opt,name=walletAddress,proto3" json:"walletAddress,omitempty"`
+	WalletLabel   string                 `protobuf:"bytes,2,opt,name=walletLabel,proto3" json:"walletLabel,omitempty"`
+	Type          WalletAlertType        `protobuf:"varint,3,opt,name=type,proto3,enum=wallet.WalletAlertType" json:"type,omitempty"`
+	// Value alerts: the value at the start of the window and now, in USD, and the change in
+	// percent.
+	PreviousValueUsd string `protobuf:"bytes,4,opt,name=previousValueUsd,proto3" json:"previousValueUsd,omitempty"`
+	ValueUsd         string `protobuf:"bytes,5,opt,name=valueUsd,proto3" json:"valueUsd,omitempty"`
+	ChangePct        string `protobuf:"bytes,6,opt,name=changePct,proto3" json:"changePct,omitempty"`
+	WindowMinutes    int32  `protobuf:"varint,7,opt,name=windowMinutes,proto3" json:"windowMinutes,omitempty"`
+	// Transfer alerts: the transaction, whether it was incoming or outgoing and the USD value it
+	// moved.
+	TxHash        string `protobuf:"bytes,8,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	Direction     string `protobuf:"bytes,9,opt,name=direction,proto3" json:"direction,omitempty"`
+	TransferUsd   string `protobuf:"bytes,10,opt,name=transferUsd,proto3" json:"transferUsd,omitempty"`
+	Timestamp     int64  `protobuf:"varint,11,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WalletAlert) Reset() {
+	*x = WalletAlert{}
+	mi := &file_wallet_messages_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WalletAlert) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WalletAlert) ProtoMessage() {}
+
+func (x *WalletAlert) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WalletAlert.ProtoReflect.Descriptor instead.
+func (*WalletAlert) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *WalletAlert) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *WalletAlert) GetWalletLabel() string {
+	if x != nil {
+		return x.WalletLabel
+	}
+	return ""
+}
+
+func (x *WalletAlert) GetType() WalletAlertType {
+	if x != nil {
+		return x.Type
+	}
+	return WalletAlertType_ALERT_VALUE_CHANGE
+}
+
+func (x *WalletAlert) GetPreviousValueUsd() string {
+	if x != nil {
+		return x.PreviousValueUsd
+	}
+	return ""
+}
+
+func (x *WalletAlert) GetValueUsd() string {
+	if x != nil {
+		return x.ValueUsd
+	}
+	return ""
+}
+
+func (x *WalletAlert) GetChangePct() string {
+	if x != nil {
+		return x.ChangePct
+	}
+	return ""
+}
+
+func (x *WalletAlert) GetWindowMinutes() int32 {
+	if x != nil {
+		return x.WindowMinutes
+	}
+	return 0
+}
+
+func (x *WalletAlert) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *WalletAlert) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *WalletAlert) GetTransferUsd() string {
+	if x != nil {
+		return x.TransferUsd
+	}
+	return ""
+}
+
+func (x *WalletAlert) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type KnownContract struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Category      ContractCategory       `protobuf:"varint,3,opt,name=category,proto3,enum=wallet.ContractCategory" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KnownContract) Reset() {
+	*x = KnownContract{}
+	mi := &file_wallet_messages_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KnownContract) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KnownContract) ProtoMessage() {}
+
+func (x *KnownContract) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KnownContract.ProtoReflect.Descriptor instead.
+func (*KnownContract) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *KnownContract) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *KnownContract) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *KnownContract) GetCategory() ContractCategory {
+	if x != nil {
+		return x.Category
+	}
+	return ContractCategory_CONTRACT_CATEGORY_UNSPECIFIED
+}
+
+type AddKnownContractRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Category      ContractCategory       `protobuf:"varint,3,opt,name=category,proto3,enum=wallet.ContractCategory" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddKnownContractRequest) Reset() {
+	*x = AddKnownContractRequest{}
+	mi := &file_wallet_messages_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddKnownContractRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddKnownContractRequest) ProtoMessage() {}
+
+func (x *AddKnownContractRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddKnownContractRequest.ProtoReflect.Descriptor instead.
+func (*AddKnownContractRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *AddKnownContractRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *AddKnownContractRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AddKnownContractRequest) GetCategory() ContractCategory {
+	if x != nil {
+		return x.Category
+	}
+	return ContractCategory_CONTRACT_CATEGORY_UNSPECIFIED
+}
+
+type AddKnownContractResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Contract      *KnownContract         `protobuf:"bytes,2,opt,name=contract,proto3" json:"contract,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddKnownContractResponse) Reset() {
+	*x = AddKnownContractResponse{}
+	mi := &file_wallet_messages_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddKnownContractResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddKnownContractResponse) ProtoMessage() {}
+
+func (x *AddKnownContractResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddKnownContractResponse.ProtoReflect.Descriptor instead.
+func (*AddKnownContractResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *AddKnownContractResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *AddKnownContractResponse) GetContract() *KnownContract {
+	if x != nil {
+		return x.Contract
+	}
+	return nil
+}
+
+type RemoveKnownContractRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveKnownContractRequest) Reset() {
+	*x = RemoveKnownContractRequest{}
+	mi := &file_wallet_messages_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveKnownContractRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveKnownContractRequest) ProtoMessage() {}
+
+func (x *RemoveKnownContractRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveKnownContractRequest.ProtoReflect.Descriptor instead.
+func (*RemoveKnownContractRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *RemoveKnownContractRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type RemoveKnownContractResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveKnownContractResponse) Reset() {
+	*x = RemoveKnownContractResponse{}
+	mi := &file_wallet_messages_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveKnownContractResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveKnownContractResponse) ProtoMessage() {}
+
+func (x *RemoveKnownContractResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveKnownContractResponse.ProtoReflect.Descriptor instead.
+func (*RemoveKnownContractResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *RemoveKnownContractResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListKnownContractsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      *ContractCategory      `protobuf:"varint,1,opt,name=category,proto3,enum=wallet.ContractCategory,oneof" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListKnownContractsRequest) Reset() {
+	*x = ListKnownContractsRequest{}
+	mi := &file_wallet_messages_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListKnownContractsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListKnownContractsRequest) ProtoMessage() {}
+
+func (x *ListKnownContractsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListKnownContractsRequest.ProtoReflect.Descriptor instead.
+func (*ListKnownContractsRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListKnownContractsRequest) GetCategory() ContractCategory {
+	if x != nil && x.Category != nil {
+		return *x.Category
+	}
+	return ContractCategory_CONTRACT_CATEGORY_UNSPECIFIED
+}
+
+type ListKnownContractsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Contracts     []*KnownContract       `protobuf:"bytes,1,rep,name=contracts,proto3" json:"contracts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListKnownContractsResponse) Reset() {
+	*x = ListKnownContractsResponse{}
+	mi := &file_wallet_messages_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListKnownContractsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListKnownContractsResponse) ProtoMessage() {}
+
+func (x *ListKnownContractsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListKnownContractsResponse.ProtoReflect.Descriptor instead.
+func (*ListKnownContractsResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListKnownContractsResponse) GetContracts() []*KnownContract {
+	if x != nil {
+		return x.Contracts
+	}
+	return nil
+}
+
+type WalletFlow struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddress         string                 `protobuf:"bytes,1,opt,name=walletAddress,proto3" json:"walletAddress,omitempty"`
+	WalletLabel           string                 `protobuf:"bytes,2,opt,name=walletLabel,proto3" json:"walletLabel,omitempty"`
+	Type                  WalletFlowType         `protobuf:"varint,3,opt,name=type,proto3,enum=wallet.WalletFlowType" json:"type,omitempty"`
+	Counterparty          string                 `protobuf:"bytes,4,opt,name=counterparty,proto3" json:"counterparty,omitempty"`
+	CounterpartyLabel     string                 `protobuf:"bytes,5,opt,name=counterpartyLabel,proto3" json:"counterpartyLabel,omitempty"`
+	TokenAddress          string                 `protobuf:"bytes,6,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
+	TokenSymbol           string                 `protobuf:"bytes,7,opt,name=tokenSymbol,proto3" json:"tokenSymbol,omitempty"`
+	Amount                string                 `protobuf:"bytes,8,opt,name=amount,proto3" json:"amount,omitempty"`
+	UsdValue              string                 `protobuf:"bytes,9,opt,name=usdValue,proto3" json:"usdValue,omitempty"`
+	TxHash                string                 `protobuf:"bytes,10,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	Timestamp             int64                  `protobuf:"varint,11,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	MovedToExchangeUsd24H string                 `protobuf:"bytes,12,opt,name=movedToExchangeUsd24h,proto3" json:"movedToExchangeUsd24h,omitempty"`
+	ExchangeDeposits24H   int32                  `protobuf:"varint,13,opt,name=exchangeDeposits24h,proto3" json:"exchangeDeposits24h,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *WalletFlow) Reset() {
+	*x = WalletFlow{}
+	mi := &file_wallet_messages_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WalletFlow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WalletFlow) ProtoMessage() {}
+
+func (x *WalletFlow) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WalletFlow.ProtoReflect.Descriptor instead.
+func (*WalletFlow) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *WalletFlow) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *WalletFlow) GetWalletLabel() string {
+	if x != nil {
+		return x.WalletLabel
+	}
+	return ""
+}
+
+func (x *WalletFlow) GetType() WalletFlowType {
+	if x != nil {
+		return x.Type
+	}
+	return WalletFlowType_BRIDGE_DEPOSIT
+}
+
+func (x *WalletFlow) GetCounterparty() string {
+	if x != nil {
+		return x.Counterparty
+	}
+	return ""
+}
+
+func (x *WalletFlow) GetCounterpartyLabel() string {
+	if x != nil {
+		return x.CounterpartyLabel
+	}
+	return ""
+}
+
+func (x *WalletFlow) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *WalletFlow) GetTokenSymbol() string {
+	if x != nil {
+		return x.TokenSymbol
+	}
+	return ""
+}
+
+func (x *WalletFlow) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *WalletFlow) GetUsdValue() string {
+	if x != nil {
+		return x.UsdValue
+	}
+	return ""
+}
+
+func (x *WalletFlow) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *WalletFlow) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *WalletFlow) GetMovedToExchangeUsd24H() string {
+	if x != nil {
+		return x.MovedToExchangeUsd24H
+	}
+	return ""
+}
+
+func (x *WalletFlow) GetExchangeDeposits24H() int32 {
+	if x != nil {
+		return x.ExchangeDeposits24H
+	}
+	return 0
+}
+
+type StreamWalletEventsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddresses []string               `protobuf:"bytes,1,rep,name=walletAddresses,proto3" json:"walletAddresses,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *StreamWalletEventsRequest) Reset() {
+	*x = StreamWalletEventsRequest{}
+	mi := &file_wallet_messages_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamWalletEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamWalletEventsRequest) ProtoMessage() {}
+
+func (x *StreamWalletEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamWalletEventsRequest.ProtoReflect.Descriptor instead.
+func (*StreamWalletEventsRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *StreamWalletEventsRequest) GetWalletAddresses() []string {
+	if x != nil {
+		return x.WalletAddresses
+	}
+	return nil
+}
+
+type WalletEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*WalletEvent_Trade
+	//	*WalletEvent_Flow
+	//	*WalletEvent_Alert
+	Event         isWalletEvent_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WalletEvent) Reset() {
+	*x = WalletEvent{}
+	mi := &file_wallet_messages_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WalletEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WalletEvent) ProtoMessage() {}
+
+func (x *WalletEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WalletEvent.ProtoReflect.Descriptor instead.
+func (*WalletEvent) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *WalletEvent) GetEvent() isWalletEvent_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *WalletEvent) GetTrade() *WalletTrade {
+	if x != nil {
+		if x, ok := x.Event.(*WalletEvent_Trade); ok {
+			return x.Trade
+		}
+	}
+	return nil
+}
+
+func (x *WalletEvent) GetFlow() *WalletFlow {
+	if x != nil {
+		if x, ok := x.Event.(*WalletEvent_Flow); ok {
+			return x.Flow
+		}
+	}
+	return nil
+}
+
+func (x *WalletEvent) GetAlert() *WalletAlert {
+	if x != nil {
+		if x, ok := x.Event.(*WalletEvent_Alert); ok {
+			return x.Alert
+		}
+	}
+	return nil
+}
+
+type isWalletEvent_Event interface {
+	isWalletEvent_Event()
+}
+
+type WalletEvent_Trade struct {
+	Trade *WalletTrade `protobuf:"bytes,1,opt,name=trade,proto3,oneof"`
+}
+
+type WalletEvent_Flow struct {
+	Flow *WalletFlow `protobuf:"bytes,2,opt,name=flow,proto3,oneof"`
+}
+
+type WalletEvent_Alert struct {
+	Alert *WalletAlert `protobuf:"bytes,3,opt,name=alert,proto3,oneof"`
+}
+
+func (*WalletEvent_Trade) isWalletEvent_Event() {}
+
+func (*WalletEvent_Flow) isWalletEvent_Event() {}
+
+func (*WalletEvent_Alert) isWalletEvent_Event() {}
+
+type GetPortfolioHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddress string                 `protobuf:"bytes,1,opt,name=walletAddress,proto3" json:"walletAddress,omitempty"`
+	Range         PortfolioRange         `protobuf:"varint,2,opt,name=range,proto3,enum=wallet.PortfolioRange" json:"range,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPortfolioHistoryRequest) Reset() {
+	*x = GetPortfolioHistoryRequest{}
+	mi := &file_wallet_messages_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPortfolioHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPortfolioHistoryRequest) ProtoMessage() {}
+
+func (x *GetPortfolioHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPortfolioHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetPortfolioHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GetPortfolioHistoryRequest) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *GetPortfolioHistoryRequest) GetRange() PortfolioRange {
+	if x != nil {
+		return x.Range
+	}
+	return PortfolioRange_RANGE_24H
+}
+
+type PortfolioPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp     int64                  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	ValueUsd      string                 `protobuf:"bytes,2,opt,name=valueUsd,proto3" json:"valueUsd,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PortfolioPoint) Reset() {
+	*x = PortfolioPoint{}
+	mi := &file_wallet_messages_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PortfolioPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PortfolioPoint) ProtoMessage() {}
+
+func (x *PortfolioPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PortfolioPoint.ProtoReflect.Descriptor instead.
+func (*PortfolioPoint) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *PortfolioPoint) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *PortfolioPoint) GetValueUsd() string {
+	if x != nil {
+		return x.ValueUsd
+	}
+	return ""
+}
+
+type GetPortfolioHistoryResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddress     string                 `protobuf:"bytes,1,opt,name=walletAddress,proto3" json:"walletAddress,omitempty"`
+	Range             PortfolioRange         `protobuf:"varint,2,opt,name=range,proto3,enum=wallet.PortfolioRange" json:"range,omitempty"`
+	ResolutionSeconds int64                  `protobuf:"varint,3,opt,name=resolutionSeconds,proto3" json:"resolutionSeconds,omitempty"`
+	Points            []*PortfolioPoint      `protobuf:"bytes,4,rep,name=points,proto3" json:"points,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetPortfolioHistoryResponse) Reset() {
+	*x = GetPortfolioHistoryResponse{}
+	mi := &file_wallet_messages_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPortfolioHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPortfolioHistoryResponse) ProtoMessage() {}
+
+func (x *GetPortfolioHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPortfolioHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetPortfolioHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *GetPortfolioHistoryResponse) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *GetPortfolioHistoryResponse) GetRange() PortfolioRange {
+	if x != nil {
+		return x.Range
+	}
+	return PortfolioRange_RANGE_24H
+}
+
+func (x *GetPortfolioHistoryResponse) GetResolutionSeconds() int64 {
+	if x != nil {
+		return x.ResolutionSeconds
+	}
+	return 0
+}
+
+func (x *GetPortfolioHistoryResponse) GetPoints() []*PortfolioPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+type GetWalletApprovalsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddress string                 `protobuf:"bytes,1,opt,name=walletAddress,proto3" json:"walletAddress,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWalletApprovalsRequest) Reset() {
+	*x = GetWalletApprovalsRequest{}
+	mi := &file_wallet_messages_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWalletApprovalsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWalletApprovalsRequest) ProtoMessage() {}
+
+func (x *GetWalletApprovalsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWalletApprovalsRequest.ProtoReflect.Descriptor instead.
+func (*GetWalletApprovalsRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *GetWalletApprovalsRequest) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+type WalletApproval struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	TokenAddress string                 `protobuf:"bytes,1,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
+	TokenSymbol  string                 `protobuf:"bytes,2,opt,name=tokenSymbol,proto3" json:"tokenSymbol,omitempty"`
+	TokenName    string                 `protobuf:"bytes,3,opt,name=tokenName,proto3" json:"tokenName,omitempty"`
+	Spender      string                 `protobuf:"bytes,4,opt,name=spender,proto3" json:"spender,omitempty"`
+	// Address book name of the spender, or the indexer's label when it is not in the address book.
+	SpenderLabel string `protobuf:"bytes,5,opt,name=spenderLabel,proto3" json:"spenderLabel,omitempty"`
+	KnownSpender bool   `protobuf:"varint,6,opt,name=knownSpender,proto3" json:"knownSpender,omitempty"`
+	// Allowance in token units.
+	Allowance string `protobuf:"bytes,7,opt,name=allowance,proto3" json:"allowance,omitempty"`
+	Unlimited bool   `protobuf:"varint,8,opt,name=unlimited,proto3" json:"unlimited,omitempty"`
+	// USD value of the part of the wallet balance the spender can move.
+	UsdValueAtRisk string `protobuf:"bytes,9,opt,name=usdValueAtRisk,proto3" json:"usdValueAtRisk,omitempty"`
+	// Unlimited approval to a spender that is not in the address book.
+	Flagged       bool   `protobuf:"varint,10,opt,name=flagged,proto3" json:"flagged,omitempty"`
+	TxHash        string `protobuf:"bytes,11,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	Timestamp     int64  `protobuf:"varint,12,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WalletApproval) Reset() {
+	*x = WalletApproval{}
+	mi := &file_wallet_messages_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WalletApproval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WalletApproval) ProtoMessage() {}
+
+func (x *WalletApproval) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WalletApproval.ProtoReflect.Descriptor instead.
+func (*WalletApproval) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *WalletApproval) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *WalletApproval) GetTokenSymbol() string {
+	if x != nil {
+		return x.TokenSymbol
+	}
+	return ""
+}
+
+func (x *WalletApproval) GetTokenName() string {
+	if x != nil {
+		return x.TokenName
+	}
+	return ""
+}
+
+func (x *WalletApproval) GetSpender() string {
+	if x != nil {
+		return x.Spender
+	}
+	return ""
+}
+
+func (x *WalletApproval) GetSpenderLabel() string {
+	if x != nil {
+		return x.SpenderLabel
+	}
+	return ""
+}
+
+func (x *WalletApproval) GetKnownSpender() bool {
+	if x != nil {
+		return x.KnownSpender
+	}
+	return false
+}
+
+func (x *WalletApproval) GetAllowance() string {
+	if x != nil {
+		return x.Allowance
+	}
+	return ""
+}
+
+func (x *WalletApproval) GetUnlimited() bool {
+	if x != nil {
+		return x.Unlimited
+	}
+	return false
+}
+
+func (x *WalletApproval) GetUsdValueAtRisk() string {
+	if x != nil {
+		return x.UsdValueAtRisk
+	}
+	return ""
+}
+
+func (x *WalletApproval) GetFlagged() bool {
+	if x != nil {
+		return x.Flagged
+	}
+	return false
+}
+
+func (x *WalletApproval) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *WalletApproval) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type GetWalletApprovalsResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddress       string                 `protobuf:"bytes,1,opt,name=walletAddress,proto3" json:"walletAddress,omitempty"`
+	Approvals           []*WalletApproval      `protobuf:"bytes,2,rep,name=approvals,proto3" json:"approvals,omitempty"`
+	TotalUsdValueAtRisk string                 `protobuf:"bytes,3,opt,name=totalUsdValueAtRisk,proto3" json:"totalUsdValueAtRisk,omitempty"`
+	FlaggedCount        int32                  `protobuf:"varint,4,opt,name=flaggedCount,proto3" json:"flaggedCount,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *GetWalletApprovalsResponse) Reset() {
+	*x = GetWalletApprovalsResponse{}
+	mi := &file_wallet_messages_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWalletApprovalsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWalletApprovalsResponse) ProtoMessage() {}
+
+func (x *GetWalletApprovalsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWalletApprovalsResponse.ProtoReflect.Descriptor instead.
+func (*GetWalletApprovalsResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GetWalletApprovalsResponse) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *GetWalletApprovalsResponse) GetApprovals() []*WalletApproval {
+	if x != nil {
+		return x.Approvals
+	}
+	return nil
+}
+
+func (x *GetWalletApprovalsResponse) GetTotalUsdValueAtRisk() string {
+	if x != nil {
+		return x.TotalUsdValueAtRisk
+	}
+	return ""
+}
+
+func (x *GetWalletApprovalsResponse) GetFlaggedCount() int32 {
+	if x != nil {
+		return x.FlaggedCount
+	}
+	return 0
+}
+
+type GetAggregatedPortfolioRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddresses []string               `protobuf:"bytes,1,rep,name=walletAddresses,proto3" json:"walletAddresses,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetAggregatedPortfolioRequest) Reset() {
+	*x = GetAggregatedPortfolioRequest{}
+	mi := &file_wallet_messages_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAggregatedPortfolioRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAggregatedPortfolioRequest) ProtoMessage() {}
+
+func (x *GetAggregatedPortfolioRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAggregatedPortfolioRequest.ProtoReflect.Descriptor instead.
+func (*GetAggregatedPortfolioRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GetAggregatedPortfolioRequest) GetWalletAddresses() []string {
+	if x != nil {
+		return x.WalletAddresses
+	}
+	return nil
+}
+
+type WalletHolding struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddress string                 `protobuf:"bytes,1,opt,name=walletAddress,proto3" json:"walletAddress,omitempty"`
+	Balance       string                 `protobuf:"bytes,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	UsdValue      string                 `protobuf:"bytes,3,opt,name=usdValue,proto3" json:"usdValue,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WalletHolding) Reset() {
+	*x = WalletHolding{}
+	mi := &file_wallet_messages_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WalletHolding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WalletHolding) ProtoMessage() {}
+
+func (x *WalletHolding) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WalletHolding.ProtoReflect.Descriptor instead.
+func (*WalletHolding) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *WalletHolding) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *WalletHolding) GetBalance() string {
+	if x != nil {
+		return x.Balance
+	}
+	return ""
+}
+
+func (x *WalletHolding) GetUsdValue() string {
+	if x != nil {
+		return x.UsdValue
+	}
+	return ""
+}
+
+type AggregatedToken struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	TokenAddress string                 `protobuf:"bytes,1,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
+	Name         string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Symbol       string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Image        string                 `protobuf:"bytes,4,opt,name=image,proto3" json:"image,omitempty"`
+	Price        string                 `protobuf:"bytes,5,opt,name=price,proto3" json:"price,omitempty"`
+	// Summed over the wallets, in token units.
+	Balance       string           `protobuf:"bytes,6,opt,name=balance,proto3" json:"balance,omitempty"`
+	UsdValue      string           `protobuf:"bytes,7,opt,name=usdValue,proto3" json:"usdValue,omitempty"`
+	Wallets       []*WalletHolding `protobuf:"bytes,8,rep,name=wallets,proto3" json:"wallets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AggregatedToken) Reset() {
+	*x = AggregatedToken{}
+	mi := &file_wallet_messages_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AggregatedToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregatedToken) ProtoMessage() {}
+
+func (x *AggregatedToken) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregatedToken.ProtoReflect.Descriptor instead.
+func (*AggregatedToken) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *AggregatedToken) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *AggregatedToken) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AggregatedToken) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *AggregatedToken) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *AggregatedToken) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *AggregatedToken) GetBalance() string {
+	if x != nil {
+		return x.Balance
+	}
+	return ""
+}
+
+func (x *AggregatedToken) GetUsdValue() string {
+	if x != nil {
+		return x.UsdValue
+	}
+	return ""
+}
+
+func (x *AggregatedToken) GetWallets() []*WalletHolding {
+	if x != nil {
+		return x.Wallets
+	}
+	return nil
+}
+
+type GetAggregatedPortfolioResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddresses []string               `protobuf:"bytes,1,rep,name=walletAddresses,proto3" json:"walletAddresses,omitempty"`
+	// Largest holding first.
+	Tokens           []*AggregatedToken `protobuf:"bytes,2,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	TotalDollarValue string             `protobuf:"bytes,3,opt,name=totalDollarValue,proto3" json:"totalDollarValue,omitempty"`
+	// Wallets whose holdings could not be read; they are left out of the totals.
+	FailedWallets []string `protobuf:"bytes,4,rep,name=failedWallets,proto3" json:"failedWallets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAggregatedPortfolioResponse) Reset() {
+	*x = GetAggregatedPortfolioResponse{}
+	mi := &file_wallet_messages_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAggregatedPortfolioResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAggregatedPortfolioResponse) ProtoMessage() {}
+
+func (x *GetAggregatedPortfolioResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAggregatedPortfolioResponse.ProtoReflect.Descriptor instead.
+func (*GetAggregatedPortfolioResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *GetAggregatedPortfolioResponse) GetWalletAddresses() []string {
+	if x != nil {
+		return x.WalletAddresses
+	}
+	return nil
+}
+
+func (x *GetAggregatedPortfolioResponse) GetTokens() []*AggregatedToken {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+func (x *GetAggregatedPortfolioResponse) GetTotalDollarValue() string {
+	if x != nil {
+		return x.TotalDollarValue
+	}
+	return ""
+}
+
+func (x *GetAggregatedPortfolioResponse) GetFailedWallets() []string {
+	if x != nil {
+		return x.FailedWallets
+	}
+	return nil
+}
+
+type ImportWalletsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddresses []string               `protobuf:"bytes,1,rep,name=walletAddresses,proto3" json:"walletAddresses,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ImportWalletsRequest) Reset() {
+	*x = ImportWalletsRequest{}
+	mi := &file_wallet_messages_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportWalletsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportWalletsRequest) ProtoMessage() {}
+
+func (x *ImportWalletsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportWalletsRequest.ProtoReflect.Descriptor instead.
+func (*ImportWalletsRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ImportWalletsRequest) GetWalletAddresses() []string {
+	if x != nil {
+		return x.WalletAddresses
+	}
+	return nil
+}
+
+type ImportWalletsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	JobId string                 `protobuf:"bytes,1,opt,name=jobId,proto3" json:"jobId,omitempty"`
+	// Distinct addresses queued for import.
+	Total         int32 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportWalletsResponse) Reset() {
+	*x = ImportWalletsResponse{}
+	mi := &file_wallet_messages_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportWalletsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportWalletsResponse) ProtoMessage() {}
+
+func (x *ImportWalletsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportWalletsResponse.ProtoReflect.Descriptor instead.
+func (*ImportWalletsResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ImportWalletsResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *ImportWalletsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type ImportFailure struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddress string                 `protobuf:"bytes,1,opt,name=walletAddress,proto3" json:"walletAddress,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportFailure) Reset() {
+	*x = ImportFailure{}
+	mi := &file_wallet_messages_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportFailure) ProtoMessage() {}
+
+func (x *ImportFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportFailure.ProtoReflect.Descriptor instead.
+func (*ImportFailure) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ImportFailure) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *ImportFailure) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ImportJob struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	JobId string                 `protobuf:"bytes,1,opt,name=jobId,proto3" json:"jobId,omitempty"`
+	State ImportJobState         `protobuf:"varint,2,opt,name=state,proto3,enum=wallet.ImportJobState" json:"state,omitempty"`
+	Total int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	// Processed wallets are added, already tracked or failed.
+	Processed int32            `protobuf:"varint,4,opt,name=processed,proto3" json:"processed,omitempty"`
+	Added     int32            `protobuf:"varint,5,opt,name=added,proto3" json:"added,omitempty"`
+	Existing  int32            `protobuf:"varint,6,opt,name=existing,proto3" json:"existing,omitempty"`
+	Failures  []*ImportFailure `protobuf:"bytes,7,rep,name=failures,proto3" json:"failures,omitempty"`
+	// Unix milliseconds; finishedAt is 0 until the job is done.
+	CreatedAt     int64 `protobuf:"varint,8,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	FinishedAt    int64 `protobuf:"varint,9,opt,name=finishedAt,proto3" json:"finishedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportJob) Reset() {
+	*x = ImportJob{}
+	mi := &file_wallet_messages_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportJob) ProtoMessage() {}
+
+func (x *ImportJob) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportJob.ProtoReflect.Descriptor instead.
+func (*ImportJob) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ImportJob) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *ImportJob) GetState() ImportJobState {
+	if x != nil {
+		return x.State
+	}
+	return ImportJobState_IMPORT_QUEUED
+}
+
+func (x *ImportJob) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ImportJob) GetProcessed() int32 {
+	if x != nil {
+		return x.Processed
+	}
+	return 0
+}
+
+func (x *ImportJob) GetAdded() int32 {
+	if x != nil {
+		return x.Added
+	}
+	return 0
+}
+
+func (x *ImportJob) GetExisting() int32 {
+	if x != nil {
+		return x.Existing
+	}
+	return 0
+}
+
+func (x *ImportJob) GetFailures() []*ImportFailure {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+func (x *ImportJob) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *ImportJob) GetFinishedAt() int64 {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return 0
+}
+
+type GetImportJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=jobId,proto3" json:"jobId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetImportJobRequest) Reset() {
+	*x = GetImportJobRequest{}
+	mi := &file_wallet_messages_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetImportJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetImportJobRequest) ProtoMessage() {}
+
+func (x *GetImportJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetImportJobRequest.ProtoReflect.Descriptor instead.
+func (*GetImportJobRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *GetImportJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type GetImportJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *ImportJob             `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetImportJobResponse) Reset() {
+	*x = GetImportJobResponse{}
+	mi := &file_wallet_messages_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetImportJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetImportJobResponse) ProtoMessage() {}
+
+func (x *GetImportJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetImportJobResponse.ProtoReflect.Descriptor instead.
+func (*GetImportJobResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *GetImportJobResponse) GetJob() *ImportJob {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+// Rebalancing applies to the replica that receives it; it is sent to every replica after the
+// WALLET_SHARD_* settings change.
+type RebalanceWalletShardsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RebalanceWalletShardsRequest) Reset() {
+	*x = RebalanceWalletShardsRequest{}
+	mi := &file_wallet_messages_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RebalanceWalletShardsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebalanceWalletShardsRequest) ProtoMessage() {}
+
+func (x *RebalanceWalletShardsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebalanceWalletShardsRequest.ProtoReflect.Descriptor instead.
+func (*RebalanceWalletShardsRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{66}
+}
+
+type RebalanceWalletShardsResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ShardId    int32                  `protobuf:"varint,1,opt,name=shardId,proto3" json:"shardId,omitempty"`
+	ShardCount int32                  `protobuf:"varint,2,opt,name=shardCount,proto3" json:"shardCount,omitempty"`
+	// Watchers started for wallets of this shard and stopped for wallets of other shards.
+	Started int32 `protobuf:"varint,3,opt,name=started,proto3" json:"started,omitempty"`
+	Stopped int32 `protobuf:"varint,4,opt,name=stopped,proto3" json:"stopped,omitempty"`
+	// Wallets this replica watches after rebalancing.
+	Watching      int32 `protobuf:"varint,5,opt,name=watching,proto3" json:"watching,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RebalanceWalletShardsResponse) Reset() {
+	*x = RebalanceWalletShardsResponse{}
+	mi := &file_wallet_messages_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RebalanceWalletShardsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebalanceWalletShardsResponse) ProtoMessage() {}
+
+func (x *RebalanceWalletShardsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebalanceWalletShardsResponse.ProtoReflect.Descriptor instead.
+func (*RebalanceWalletShardsResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *RebalanceWalletShardsResponse) GetShardId() int32 {
+	if x != nil {
+		return x.ShardId
+	}
+	return 0
+}
+
+func (x *RebalanceWalletShardsResponse) GetShardCount() int32 {
+	if x != nil {
+		return x.ShardCount
+	}
+	return 0
+}
+
+func (x *RebalanceWalletShardsResponse) GetStarted() int32 {
+	if x != nil {
+		return x.Started
+	}
+	return 0
+}
+
+func (x *RebalanceWalletShardsResponse) GetStopped() int32 {
+	if x != nil {
+		return x.Stopped
+	}
+	return 0
+}
+
+func (x *RebalanceWalletShardsResponse) GetWatching() int32 {
+	if x != nil {
+		return x.Watching
+	}
+	return 0
+}
+
+// Totals are of all replicas, paths of the replica that receives the request.
+type GetApiUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetApiUsageRequest) Reset() {
+	*x = GetApiUsageRequest{}
+	mi := &file_wallet_messages_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetApiUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetApiUsageRequest) ProtoMessage() {}
+
+func (x *GetApiUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetApiUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetApiUsageRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{68}
+}
+
+type GetApiUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Usage         *common.ApiUsage       `protobuf:"bytes,1,opt,name=usage,proto3" json:"usage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetApiUsageResponse) Reset() {
+	*x = GetApiUsageResponse{}
+	mi := &file_wallet_messages_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetApiUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetApiUsageResponse) ProtoMessage() {}
+
+func (x *GetApiUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_messages_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetApiUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetApiUsageResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_messages_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *GetApiUsageResponse) GetUsage() *common.ApiUsage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+var File_wallet_messages_proto protoreflect.FileDescriptor
+
+const file_wallet_messages_proto_rawDesc = "" +
+	"\n" +
+	"\x15wallet/messages.proto\x12\x06wallet\x1a\x13common/common.proto\"L\n" +
+	"\x10AddWalletRequest\x12$\n" +
+	"\rwalletAddress\x18\x01 \x01(\tR\rwalletAddress\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\"-\n" +
+	"\x11AddWalletResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xab\x01\n" +
+	"\x10GetWalletRequest\x12$\n" +
+	"\rwalletAddress\x18\x01 \x01(\tR\rwalletAddress\x12#\n" +
+	"\x05chain\x18\x02 \x01(\x0e2\r.common.CHAINR\x05chain\x12$\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x10.wallet.DataTypeR\x04type\x12&\n" +
+	"\x0etokenAddresses\x18\x04 \x03(\tR\x0etokenAddresses\"\xa1\x01\n" +
+	"\x11GetWalletResponse\x12.\n" +
+	"\n" +
+	"walletData\x18\x01 \x01(\v2\x0e.common.WalletR\n" +
+	"walletData\x12\x1e\n" +
+	"\n" +
+	"dataSource\x18\x02 \x01(\tR\n" +
+	"dataSource\x12\x1c\n" +
+	"\tupdatedAt\x18\x03 \x01(\x03R\tupdatedAt\x12\x1e\n" +
+	"\n" +
+	"refreshing\x18\x04 \x01(\bR\n" +
+	"refreshing\"\x9b\x02\n" +
+	"\x16GetWalletTokensRequest\x12$\n" +
+	"\rwalletAddress\x18\x01 \x01(\tR\rwalletAddress\x12#\n" +
+	"\x05chain\x18\x02 \x01(\x0e2\r.common.CHAINR\x05chain\x12$\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x10.wallet.DataTypeR\x04type\x12&\n" +
+	"\x0etokenAddresses\x18\x04 \x03(\tR\x0etokenAddresses\x12\"\n" +
+	"\ffilterLowUSD\x18\x05 \x01(\bR\ffilterLowUSD\x12\"\n" +
+	"\fwithBalances\x18\x06 \x01(\bR\fwithBalances\x12 \n" +
+	"\vincludeSpam\x18\a \x01(\bR\vincludeSpam\"\x8e\x01\n" +
+	"\x17GetWalletTokensResponse\x12+\n" +
+	"\x06tokens\x18\x01 \x03(\v2\x13.common.WalletTokenR\x06tokens\x12&\n" +
+	"\x0enumberOfTokens\x18\x02 \x01(\x05R\x0enumberOfTokens\x12\x1e\n" +
+	"\n" +
+	"dataSource\x18\x03 \x01(\tR\n" +
+	"dataSource\"x\n" +
+	"\x14MarkTokenSafeRequest\x12$\n" +
+	"\rwalletAddress\x18\x01 \x01(\tR\rwalletAddress\x12\"\n" +
+	"\ftokenAddress\x18\x02 \x01(\tR\ftokenAddress\x12\x16\n" +
+	"\x06revoke\x18\x03 \x01(\bR\x06revoke\"1\n" +
+	"\x15MarkTokenSafeResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xd6\x01\n" +
+	"\x17GetWalletDetailsRequest\x12$\n" +
+	"\rwalletAddress\x18\x01 \x01(\tR\rwalletAddress\x12#\n" +
+	"\x05chain\x18\x02 \x01(\x0e2\r.common.CHAINR\x05chain\x12$\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x10.wallet.DataTypeR\x04type\x12&\n" +
+	"\x0etokenAddresses\x18\x04 \x03(\tR\x0etokenAddresses\x12\"\n" +
+	"\ffilterLowUSD\x18\x05 \x01(\bR\ffilterLowUSD\"\x9f\x01\n" +
+	"\x18GetWalletDetailsResponse\x12+\n" +
+	"\x06tokens\x18\x01 \x03(\v2\x13.common.WalletTokenR\x06tokens\x12&\n" +
+	"\x0enumberOfTokens\x18\x02 \x01(\x05R\x0enumberOfTokens\x12.\n" +
+	"\n" +
+	"walletData\x18\x03 \x01(\v2\x0e.common.WalletR\n" +
+	"walletData\"p\n" +
+	"\x1cUpdateWalletPortfolioRequest\x12$\n" +
+	"\rwalletAddress\x18\x01 \x01(\tR\rwalletAddress\x12*\n" +
+	"\x10totalDollarValue\x18\x02 \x01(\tR\x10totalDollarValue\"9\n" +
+	"\x1dUpdateWalletPortfolioResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"c\n" +
+	"\x18WatchTokenHoldersRequest\x12\"\n" +
+	"\ftokenAddress\x18\x01 \x01(\tR\ftokenAddress\x12\x19\n" +
+	"\x05limit\x18\x02 \x01(\x05H\x00R\x05limit\x88\x01\x01B\b\n" +
+	"\x06_limit\"_\n" +
+	"\x19WatchTokenHoldersResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12(\n" +
+	"\x0fwalletAddresses\x18\x02 \x03(\tR\x0fwalletAddresses\";\n" +
+	"\x15GetHolderFlowsRequest\x12\"\n" +
+	"\ftokenAddress\x18\x01 \x01(\tR\ftokenAddress\"\x8c\x01\n" +
+	"\n" +
+	"HolderFlow\x12$\n" +
+	"\rwalletAddress\x18\x01 \x01(\tR\rwalletAddress\x12&\n" +
+	"\x0einitialBalance\x18\x02 \x01(\tR\x0einitialBalance\x12\x18\n" +
+	"\abalance\x18\x03 \x01(\tR\abalance\x12\x16\n" +
+	"\x06change\x18\x04 \x01(\tR\x06change\"\xa0\x02\n" +
+	"\x16GetHolderFlowsResponse\x12\"\n" +
+	"\ftokenAddress\x18\x01 \x01(\tR\ftokenAddress\x12 \n" +
+	"\vholderCount\x18\x02 \x01(\x05R\vholderCount\x12\x16\n" +
+	"\x06inflow\x18\x03 \x01(\tR\x06inflow\x12\x18\n" +
+	"\aoutflow\x18\x04 \x01(\tR\aoutflow\x12\x18\n" +
+	"\anetFlow\x18\x05 \x01(\tR\anetFlow\x12\"\n" +
+	"\faccumulating\x18\x06 \x01(\x05R\faccumulating\x12\"\n" +
+	"\fdistributing\x18\a \x01(\x05R\fdistributing\x12,\n" +
+	"\aholders\x18\b \x03(\v2\x12.wallet.HolderFlowR\aholders\"\xce\x01\n" +
+	"\x15SetWalletLabelRequest\x12$\n" +
+	"\rwalletAddress\x18\x01 \x01(\tR\rwalletAddress\x12\x19\n" +
+	"\x05label\x18\x02 \x01(\tH\x00R\x05label\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12\x16\n" +
+	"\x06groups\x18\x04 \x03(\tR\x06groups\x12\x1c\n" +
+	"\tclearTags\x18\x05 \x01(\bR\tclearTags\x12 \n" +
+	"\vclearGroups\x18\x06 \x01(\bR\vclearGroupsB\b\n" +
+	"\x06_label\"b\n" +
+	"\x16SetWalletLabelResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12.\n" +
+	"\n" +
+	"walletData\x18\x02 \x01(\v2\x0e.common.WalletR\n" +
+	"walletData\"P\n" +
+	"\x17ListWalletsByTagRequest\x12\x10\n" +
+	"\x03tag\x18\x01 \x01(\tR\x03tag\x12\x19\n" +
+	"\x05group\x18\x02 \x01(\tH\x00R\x05group\x88\x01\x01B\b\n" +
+	"\x06_group\"D\n" +
+	"\x18ListWalletsByTagResponse\x12(\n" +
+	"\awallets\x18\x01 \x03(\v2\x0e.common.WalletR\awallets\"E\n" +
+	"\x19StreamWalletTradesRequest\x12(\n" +
+	"\x0fwalletAddresses\x18\x01 \x03(\tR\x0fwalletAddresses\"\xf4\x03\n" +
+	"\vWalletTrade\x12$\n" +
+	"\rwalletAddress\x18\x01 \x01(\tR\rwalletAddress\x12 \n" +
+	"\vwalletLabel\x18\x02 \x01(\tR\vwalletLabel\x12%\n" +
+	"\x04side\x18\x03 \x01(\x0e2\x11.wallet.TradeSideR\x04side\x12\"\n" +
+	"\ftokenAddress\x18\x04 \x01(\tR\ftokenAddress\x12\x1c\n" +
+	"\ttokenName\x18\x05 \x01(\tR\ttokenName\x12 \n" +
+	"\vtokenSymbol\x18\x06 \x01(\tR\vtokenSymbol\x12 \n" +
+	"\vtokenAmount\x18\a \x01(\tR\vtokenAmount\x12\x1a\n" +
+	"\bpriceUsd\x18\b \x01(\tR\bpriceUsd\x12\x1a\n" +
+	"\busdValue\x18\t \x01(\tR\busdValue\x12\x16\n" +
+	"\x06txHash\x18\n" +
+	" \x01(\tR\x06txHash\x12\x1c\n" +
+	"\ttimestamp\x18\v \x01(\x03R\ttimestamp\x12\"\n" +
+	"\fcounterparty\x18\f \x01(\tR\fcounterparty\x12,\n" +
+	"\x11counterpartyLabel\x18\r \x01(\tR\x11counterpartyLabel\x120\n" +
+	"\bactivity\x18\x0e \x01(\x0e2\x14.wallet.ActivityTypeR\bactivity\"\x94\x01\n" +
+	"\x1cGetWalletTransactionsRequest\x12$\n" +
+	"\rwalletAddress\x18\x01 \x01(\tR\rwalletAddress\x12\x17\n" +
+	"\x04page\x18\x02 \x01(\x05H\x00R\x04page\x88\x01\x01\x12\x1f\n" +
+	"\bpageSize\x18\x03 \x01(\x05H\x01R\bpageSize\x88\x01\x01B\a\n" +
+	"\x05_pageB\v\n" +
+	"\t_pageSize\"\x8d\x02\n" +
+	"\x11WalletTransaction\x12\x16\n" +
+	"\x06txHash\x18\x01 \x01(\tR\x06txHash\x12\x1c\n" +
+	"\ttimestamp\x18\x02 \x01(\x03R\ttimestamp\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x04 \x01(\tR\x02to\x12\x1c\n" +
+	"\tdirection\x18\x05 \x01(\tR\tdirection\x12\x1a\n" +
+	"\bvalueWei\x18\x06 \x01(\tR\bvalueWei\x120\n" +
+	"\bactivity\x18\a \x01(\x0e2\x14.wallet.ActivityTypeR\bactivity\x12\x16\n" +
+	"\x06failed\x18\b \x01(\bR\x06failed\x12\x1a\n" +
+	"\bvalueUsd\x18\t \x01(\tR\bvalueUsd\"\xb4\x01\n" +
+	"\x1dGetWalletTransactionsResponse\x12$\n" +
+	"\rwalletAddress\x18\x01 \x01(\tR\rwalletAddress\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1a\n" +
+	"\bpageSize\x18\x03 \x01(\x05R\bpageSize\x12=\n" +
+	"\ftransactions\x18\x04 \x03(\v2\x19.wallet.WalletTransactionR\ftransactions\"\xa0\x01\n" +
+	"\x1bGetWalletLeaderboardRequest\x121\n" +
+	"\x06period\x18\x01 \x01(\x0e2\x19.wallet.LeaderboardPeriodR\x06period\x12\x17\n" +
+	"\x04page\x18\x02 \x01(\x05H\x00R\x04page\x88\x01\x01\x12\x1f\n" +
+	"\bpageSize\x18\x03 \x01(\x05H\x01R\bpageSize\x88\x01\x01B\a\n" +
+	"\x05_pageB\v\n" +
+	"\t_pageSize\"\xc4\x02\n" +
+	"\x10LeaderboardEntry\x12\x12\n" +
+	"\x04rank\x18\x01 \x01(\x05R\x04rank\x12$\n" +
+	"\rwalletAddress\x18\x02 \x01(\tR\rwalletAddress\x12 \n" +
+	"\vwalletLabel\x18\x03 \x01(\tR\vwalletLabel\x12&\n" +
+	"\x0erealizedPnlUsd\x18\x04 \x01(\tR\x0erealizedPnlUsd\x12\x18\n" +
+	"\awinRate\x18\x05 \x01(\tR\awinRate\x12\x1e\n" +
+	"\n" +
+	"tradeCount\x18\x06 \x01(\x05R\n" +
+	"tradeCount\x12$\n" +
+	"\rwinningTrades\x18\a \x01(\x05R\rwinningTrades\x12*\n" +
+	"\x10unrealizedPnlUsd\x18\b \x01(\tR\x10unrealizedPnlUsd\x12 \n" +
+	"\vtotalPnlUsd\x18\t \x01(\tR\vtotalPnlUsd\"\x98\x01\n" +
+	"\x1cGetWalletLeaderboardResponse\x122\n" +
+	"\aentries\x18\x01 \x03(\v2\x18.wallet.LeaderboardEntryR\aentries\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1a\n" +
+	"\bpageSize\x18\x03 \x01(\x05R\bpageSize\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x05R\x05total\"l\n" +
+	"\x1aGetDailyLeaderboardRequest\x12\x17\n" +
+	"\x04page\x18\x01 \x01(\x05H\x00R\x04page\x88\x01\x01\x12\x1f\n" +
+	"\bpageSize\x18\x02 \x01(\x05H\x01R\bpageSize\x88\x01\x01B\a\n" +
+	"\x05_pageB\v\n" +
+	"\t_pageSize\"\xc7\x01\n" +
+	"\x1bGetDailyLeaderboardResponse\x122\n" +
+	"\aentries\x18\x01 \x03(\v2\x18.wallet.LeaderboardEntryR\aentries\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1a\n" +
+	"\bpageSize\x18\x03 \x01(\x05R\bpageSize\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x05R\x05total\x12\x10\n" +
+	"\x03day\x18\x05 \x01(\tR\x03day\x12\x1c\n" +
+	"\tupdatedAt\x18\x06 \x01(\x03R\tupdatedAt\"^\n" +
+	" SetWalletLeaderboardOptInRequest\x12$\n" +
+	"\rwalletAddress\x18\x01 \x01(\tR\rwalletAddress\x12\x14\n" +
+	"\x05optIn\x18\x02 \x01(\bR\x05optIn\"=\n" +
+	"!SetWalletLeaderboardOptInResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xd1\x01\n" +
+	"\x1bSetWalletWatchFilterRequest\x12$\n" +
+	"\rwalletAddress\x18\x01 \x01(\tR\rwalletAddress\x12\x1c\n" +
+	"\tminNative\x18\x02 \x01(\tR\tminNative\x12 \n" +
+	"\vminTokenUsd\x18\x03 \x01(\tR\vminTokenUsd\x12&\n" +
+	"\x0etokenAllowlist\x18\x04 \x03(\tR\x0etokenAllowlist\x12$\n" +
+	"\rtokenDenylist\x18\x05 \x03(\tR\rtokenDenylist\"8\n" +
+	"\x1cSetWalletWatchFilterResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xc5\x01\n" +
+	"\x17SetWalletWebhookRequest\x12$\n" +
+	"\rwalletAddress\x18\x01 \x01(\tR\rwalletAddress\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x16\n" +
+	"\x06secret\x18\x03 \x01(\tR\x06secret\x12,\n" +
+	"\x06events\x18\x04 \x03(\x0e2\x14.wallet.WebhookEventR\x06events\x12,\n" +
+	"\x11minValueChangePct\x18\x05 \x01(\tR\x11minValueChangePct\"4\n" +
+	"\x18SetWalletWebhookResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xb4\x01\n" +
+	"\x16SetWalletAlertsRequest\x12$\n" +
+	"\rwalletAddress\x18\x01 \x01(\tR\rwalletAddress\x12&\n" +
+	"\x0evalueChangePct\x18\x02 \x01(\tR\x0evalueChangePct\x12$\n" +
+	"\rwindowMinutes\x18\x03 \x01(\x05R\rwindowMinutes\x12&\n" +
+	"\x0etransferMinUsd\x18\x04 \x01(\tR\x0etransferMinUsd\"3\n" +
+	"\x17SetWalletAlertsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x84\x03\n" +
+	"\vWalletAlert\x12$\n" +
+	"\rwalletAddress\x18\x01 \x01(\tR\rwalletAddress\x12 \n" +
+	"\vwalletLabel\x18\x02 \x01(\tR\vwalletLabel\x12+\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x17.wallet.WalletAlertTypeR\x04type\x12*\n" +
+	"\x10previousValueUsd\x18\x04 \x01(\tR\x10previousValueUsd\x12\x1a\n" +
+	"\bvalueUsd\x18\x05 \x01(\tR\bvalueUsd\x12\x1c\n" +
+	"\tchangePct\x18\x06 \x01(\tR\tchangePct\x12$\n" +
+	"\rwindowMinutes\x18\a \x01(\x05R\rwindowMinutes\x12\x16\n" +
+	"\x06txHash\x18\b \x01(\tR\x06txHash\x12\x1c\n" +
+	"\tdirection\x18\t \x01(\tR\tdirection\x12 \n" +
+	"\vtransferUsd\x18\n" +
+	" \x01(\tR\vtransferUsd\x12\x1c\n" +
+	"\ttimestamp\x18\v \x01(\x03R\ttimestamp\"s\n" +
+	"\rKnownContract\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x124\n" +
+	"\bcategory\x18\x03 \x01(\x0e2\x18.wallet.ContractCategoryR\bcategory\"}\n" +
+	"\x17AddKnownContractRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x124\n" +
+	"\bcategory\x18\x03 \x01(\x0e2\x18.wallet.ContractCategoryR\bcategory\"g\n" +
+	"\x18AddKnownContractResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x121\n" +
+	"\bcontract\x18\x02 \x01(\v2\x15.wallet.KnownContractR\bcontract\"6\n" +
+	"\x1aRemoveKnownContractRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\"7\n" +
+	"\x1bRemoveKnownContractResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"c\n" +
+	"\x19ListKnownContractsRequest\x129\n" +
+	"\bcategory\x18\x01 \x01(\x0e2\x18.wallet.ContractCategoryH\x00R\bcategory\x88\x01\x01B\v\n" +
+	"\t_category\"Q\n" +
+	"\x1aListKnownContractsResponse\x123\n" +
+	"\tcontracts\x18\x01 \x03(\v2\x15.wallet.KnownContractR\tcontracts\"\xea\x03\n" +
+	"\n" +
+	"WalletFlow\x12$\n" +
+	"\rwalletAddress\x18\x01 \x01(\tR\rwalletAddress\x12 \n" +
+	"\vwalletLabel\x18\x02 \x01(\tR\vwalletLabel\x12*\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x16.wallet.WalletFlowTypeR\x04type\x12\"\n" +
+	"\fcounterparty\x18\x04 \x01(\tR\fcounterparty\x12,\n" +
+	"\x11counterpartyLabel\x18\x05 \x01(\tR\x11counterpartyLabel\x12\"\n" +
+	"\ftokenAddress\x18\x06 \x01(\tR\ftokenAddress\x12 \n" +
+	"\vtokenSymbol\x18\a \x01(\tR\vtokenSymbol\x12\x16\n" +
+	"\x06amount\x18\b \x01(\tR\x06amount\x12\x1a\n" +
+	"\busdValue\x18\t \x01(\tR\busdValue\x12\x16\n" +
+	"\x06txHash\x18\n" +
+	" \x01(\tR\x06txHash\x12\x1c\n" +
+	"\ttimestamp\x18\v \x01(\x03R\ttimestamp\x124\n" +
+	"\x15movedToExchangeUsd24h\x18\f \x01(\tR\x15movedToExchangeUsd24h\x120\n" +
+	"\x13exchangeDeposits24h\x18\r \x01(\x05R\x13exchangeDeposits24h\"E\n" +
+	"\x19StreamWalletEventsRequest\x12(\n" +
+	"\x0fwalletAddresses\x18\x01 \x03(\tR\x0fwalletAddresses\"\x9a\x01\n" +
+	"\vWalletEvent\x12+\n" +
+	"\x05trade\x18\x01 \x01(\v2\x13.wallet.WalletTradeH\x00R\x05trade\x12(\n" +
+	"\x04flow\x18\x02 \x01(\v2\x12.wallet.WalletFlowH\x00R\x04flow\x12+\n" +
+	"\x05alert\x18\x03 \x01(\v2\x13.wallet.WalletAlertH\x00R\x05alertB\a\n" +
+	"\x05event\"p\n" +
+	"\x1aGetPortfolioHistoryRequest\x12$\n" +
+	"\rwalletAddress\x18\x01 \x01(\tR\rwalletAddress\x12,\n" +
+	"\x05range\x18\x02 \x01(\x0e2\x16.wallet.PortfolioRangeR\x05range\"J\n" +
+	"\x0ePortfolioPoint\x12\x1c\n" +
+	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\x12\x1a\n" +
+	"\bvalueUsd\x18\x02 \x01(\tR\bvalueUsd\"\xcf\x01\n" +
+	"\x1bGetPortfolioHistoryResponse\x12$\n" +
+	"\rwalletAddress\x18\x01 \x01(\tR\rwalletAddress\x12,\n" +
+	"\x05range\x18\x02 \x01(\x0e2\x16.wallet.PortfolioRangeR\x05range\x12,\n" +
+	"\x11resolutionSeconds\x18\x03 \x01(\x03R\x11resolutionSeconds\x12.\n" +
+	"\x06points\x18\x04 \x03(\v2\x16.wallet.PortfolioPointR\x06points\"A\n" +
+	"\x19GetWalletApprovalsRequest\x12$\n" +
+	"\rwalletAddress\x18\x01 \x01(\tR\rwalletAddress\"\x8a\x03\n" +
+	"\x0eWalletApproval\x12\"\n" +
+	"\ftokenAddress\x18\x01 \x01(\tR\ftokenAddress\x12 \n" +
+	"\vtokenSymbol\x18\x02 \x01(\tR\vtokenSymbol\x12\x1c\n" +
+	"\ttokenName\x18\x03 \x01(\tR\ttokenName\x12\x18\n" +
+	"\aspender\x18\x04 \x01(\tR\aspender\x12\"\n" +
+	"\fspenderLabel\x18\x05 \x01(\tR\fspenderLabel\x12\"\n" +
+	"\fknownSpender\x18\x06 \x01(\bR\fknownSpender\x12\x1c\n" +
+	"\tallowance\x18\a \x01(\tR\tallowance\x12\x1c\n" +
+	"\tunlimited\x18\b \x01(\bR\tunlimited\x12&\n" +
+	"\x0eusdValueAtRisk\x18\t \x01(\tR\x0eusdValueAtRisk\x12\x18\n" +
+	"\aflagged\x18\n" +
+	" \x01(\bR\aflagged\x12\x16\n" +
+	"\x06txHash\x18\v \x01(\tR\x06txHash\x12\x1c\n" +
+	"\ttimestamp\x18\f \x01(\x03R\ttimestamp\"\xce\x01\n" +
+	"\x1aGetWalletApprovalsResponse\x12$\n" +
+	"\rwalletAddress\x18\x01 \x01(\tR\rwalletAddress\x124\n" +
+	"\tapprovals\x18\x02 \x03(\v2\x16.wallet.WalletApprovalR\tapprovals\x120\n" +
+	"\x13totalUsdValueAtRisk\x18\x03 \x01(\tR\x13totalUsdValueAtRisk\x12\"\n" +
+	"\fflaggedCount\x18\x04 \x01(\x05R\fflaggedCount\"I\n" +
+	"\x1dGetAggregatedPortfolioRequest\x12(\n" +
+	"\x0fwalletAddresses\x18\x01 \x03(\tR\x0fwalletAddresses\"k\n" +
+	"\rWalletHolding\x12$\n" +
+	"\rwalletAddress\x18\x01 \x01(\tR\rwalletAddress\x12\x18\n" +
+	"\abalance\x18\x02 \x01(\tR\abalance\x12\x1a\n" +
+	"\busdValue\x18\x03 \x01(\tR\busdValue\"\xf4\x01\n" +
+	"\x0fAggregatedToken\x12\"\n" +
+	"\ftokenAddress\x18\x01 \x01(\tR\ftokenAddress\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06symbol\x18\x03 \x01(\tR\x06symbol\x12\x14\n" +
+	"\x05image\x18\x04 \x01(\tR\x05image\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\tR\x05price\x12\x18\n" +
+	"\abalance\x18\x06 \x01(\tR\abalance\x12\x1a\n" +
+	"\busdValue\x18\a \x01(\tR\busdValue\x12/\n" +
+	"\awallets\x18\b \x03(\v2\x15.wallet.WalletHoldingR\awallets\"\xcd\x01\n" +
+	"\x1eGetAggregatedPortfolioResponse\x12(\n" +
+	"\x0fwalletAddresses\x18\x01 \x03(\tR\x0fwalletAddresses\x12/\n" +
+	"\x06tokens\x18\x02 \x03(\v2\x17.wallet.AggregatedTokenR\x06tokens\x12*\n" +
+	"\x10totalDollarValue\x18\x03 \x01(\tR\x10totalDollarValue\x12$\n" +
+	"\rfailedWallets\x18\x04 \x03(\tR\rfailedWallets\"@\n" +
+	"\x14ImportWalletsRequest\x12(\n" +
+	"\x0fwalletAddresses\x18\x01 \x03(\tR\x0fwalletAddresses\"C\n" +
+	"\x15ImportWalletsResponse\x12\x14\n" +
+	"\x05jobId\x18\x01 \x01(\tR\x05jobId\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"K\n" +
+	"\rImportFailure\x12$\n" +
+	"\rwalletAddress\x18\x01 \x01(\tR\rwalletAddress\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\xa6\x02\n" +
+	"\tImportJob\x12\x14\n" +
+	"\x05jobId\x18\x01 \x01(\tR\x05jobId\x12,\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x16.wallet.ImportJobStateR\x05state\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\x12\x1c\n" +
+	"\tprocessed\x18\x04 \x01(\x05R\tprocessed\x12\x14\n" +
+	"\x05added\x18\x05 \x01(\x05R\x05added\x12\x1a\n" +
+	"\bexisting\x18\x06 \x01(\x05R\bexisting\x121\n" +
+	"\bfailures\x18\a \x03(\v2\x15.wallet.ImportFailureR\bfailures\x12\x1c\n" +
+	"\tcreatedAt\x18\b \x01(\x03R\tcreatedAt\x12\x1e\n" +
+	"\n" +
+	"finishedAt\x18\t \x01(\x03R\n" +
+	"finishedAt\"+\n" +
+	"\x13GetImportJobRequest\x12\x14\n" +
+	"\x05jobId\x18\x01 \x01(\tR\x05jobId\";\n" +
+	"\x14GetImportJobResponse\x12#\n" +
+	"\x03job\x18\x01 \x01(\v2\x11.wallet.ImportJobR\x03job\"\x1e\n" +
+	"\x1cRebalanceWalletShardsRequest\"\xa9\x01\n" +
+	"\x1dRebalanceWalletShardsResponse\x12\x18\n" +
+	"\ashardId\x18\x01 \x01(\x05R\ashardId\x12\x1e\n" +
+	"\n" +
+	"shardCount\x18\x02 \x01(\x05R\n" +
+	"shardCount\x12\x18\n" +
+	"\astarted\x18\x03 \x01(\x05R\astarted\x12\x18\n" +
+	"\astopped\x18\x04 \x01(\x05R\astopped\x12\x1a\n" +
+	"\bwatching\x18\x05 \x01(\x05R\bwatching\"\x14\n" +
+	"\x12GetApiUsageRequest\"=\n" +
+	"\x13GetApiUsageResponse\x12&\n" +
+	"\x05usage\x18\x01 \x01(\v2\x10.common.ApiUsageR\x05usage*S\n" +
+	"\bDataType\x12\x19\n" +
+	"\x15DATA_TYPE_UNSPECIFIED\x10\x00\x12\v\n" +
+	"\aSCANNER\x10\x01\x12\x06\n" +
+	"\x02DB\x10\x01\x12\n" +
+	"\n" +
+	"\x06HYBRID\x10\x02\x12\a\n" +
+	"\x03API\x10\x03\x1a\x02\x10\x01*\x1e\n" +
+	"\tTradeSide\x12\a\n" +
+	"\x03BUY\x10\x00\x12\b\n" +
+	"\x04SELL\x10\x01*\x85\x01\n" +
+	"\fActivityType\x12!\n" +
+	"\x1dACTIVITY_CONTRACT_INTERACTION\x10\x00\x12\x11\n" +
+	"\rACTIVITY_SWAP\x10\x01\x12\x15\n" +
+	"\x11ACTIVITY_TRANSFER\x10\x02\x12\x11\n" +
+	"\rACTIVITY_MINT\x10\x03\x12\x15\n" +
+	"\x11ACTIVITY_APPROVAL\x10\x04*2\n" +
+	"\x11LeaderboardPeriod\x12\r\n" +
+	"\tPERIOD_7D\x10\x00\x12\x0e\n" +
+	"\n" +
+	"PERIOD_30D\x10\x01*T\n" +
+	"\fWebhookEvent\x12\x17\n" +
+	"\x13WEBHOOK_TRANSACTION\x10\x00\x12\x18\n" +
+	"\x14WEBHOOK_VALUE_CHANGE\x10\x01\x12\x11\n" +
+	"\rWEBHOOK_ALERT\x10\x02*=\n" +
+	"\x0fWalletAlertType\x12\x16\n" +
+	"\x12ALERT_VALUE_CHANGE\x10\x00\x12\x12\n" +
+	"\x0eALERT_TRANSFER\x10\x01*m\n" +
+	"\x10ContractCategory\x12!\n" +
+	"\x1dCONTRACT_CATEGORY_UNSPECIFIED\x10\x00\x12\n" +
+	"\n" +
+	"\x06LOCKER\x10\x01\x12\n" +
+	"\n" +
+	"\x06BRIDGE\x10\x02\x12\a\n" +
+	"\x03CEX\x10\x03\x12\t\n" +
+	"\x05OTHER\x10\x04\x12\n" +
+	"\n" +
+	"\x06ROUTER\x10\x05*`\n" +
+	"\x0eWalletFlowType\x12\x12\n" +
+	"\x0eBRIDGE_DEPOSIT\x10\x00\x12\x15\n" +
+	"\x11BRIDGE_WITHDRAWAL\x10\x01\x12\x0f\n" +
+	"\vCEX_DEPOSIT\x10\x02\x12\x12\n" +
+	"\x0eCEX_WITHDRAWAL\x10\x03*<\n" +
+	"\x0ePortfolioRange\x12\r\n" +
+	"\tRANGE_24H\x10\x00\x12\r\n" +
+	"\tRANGE_30D\x10\x01\x12\f\n" +
+	"\bRANGE_1Y\x10\x02*H\n" +
+	"\x0eImportJobState\x12\x11\n" +
+	"\rIMPORT_QUEUED\x10\x00\x12\x12\n" +
+	"\x0eIMPORT_RUNNING\x10\x01\x12\x0f\n" +
+	"\vIMPORT_DONE\x10\x02B\x19Z\x17walletdata/proto/walletb\x06proto3"
+
+var (
+	file_wallet_messages_proto_rawDescOnce sync.Once
+	file_wallet_messages_proto_rawDescData []byte
+)
+
+func file_wallet_messages_proto_rawDescGZIP() []byte {
+	file_wallet_messages_proto_rawDescOnce.Do(func() {
+		file_wallet_messages_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_wallet_messages_proto_rawDesc), len(file_wallet_messages_proto_rawDesc)))
+	})
+	return file_wallet_messages_proto_rawDescData
+}
+
+var file_wallet_messages_proto_enumTypes = make([]protoimpl.EnumInfo, 10)
+var file_wallet_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 70)
+var file_wallet_messages_proto_goTypes = []any{
+	(DataType)(0),                             // 0: wallet.DataType
+	(TradeSide)(0),                            // 1: wallet.TradeSide
+	(ActivityType)(0),                         // 2: wallet.ActivityType
+	(LeaderboardPeriod)(0),                    // 3: wallet.LeaderboardPeriod
+	(WebhookEvent)(0),                         // 4: wallet.WebhookEvent
+	(WalletAlertType)(0),                      // 5: wallet.WalletAlertType
+	(ContractCategory)(0),                     // 6: wallet.ContractCategory
+	(WalletFlowType)(0),                       // 7: wallet.WalletFlowType
+	(PortfolioRange)(0),                       // 8: wallet.PortfolioRange
+	(ImportJobState)(0),                       // 9: wallet.ImportJobState
+	(*AddWalletRequest)(nil),                  // 10: wallet.AddWalletRequest
+	(*AddWalletResponse)(nil),                 // 11: wallet.AddWalletResponse
+	(*GetWalletRequest)(nil),                  // 12: wallet.GetWalletRequest
+	(*GetWalletResponse)(nil),                 // 13: wallet.GetWalletResponse
+	(*GetWalletTokensRequest)(nil),            // 14: wallet.GetWalletTokensRequest
+	(*GetWalletTokensResponse)(nil),           // 15: wallet.GetWalletTokensResponse
+	(*MarkTokenSafeRequest)(nil),              // 16: wallet.MarkTokenSafeRequest
+	(*MarkTokenSafeResponse)(nil),             // 17: wallet.MarkTokenSafeResponse
+	(*GetWalletDetailsRequest)(nil),           // 18: wallet.GetWalletDetailsRequest
+	(*GetWalletDetailsResponse)(nil),          // 19: wallet.GetWalletDetailsResponse
+	(*UpdateWalletPortfolioRequest)(nil),      // 20: wallet.UpdateWalletPortfolioRequest
+	(*UpdateWalletPortfolioResponse)(nil),     // 21: wallet.UpdateWalletPortfolioResponse
+	(*WatchTokenHoldersRequest)(nil),          // 22: wallet.WatchTokenHoldersRequest
+	(*WatchTokenHoldersResponse)(nil),         // 23: wallet.WatchTokenHoldersResponse
+	(*GetHolderFlowsRequest)(nil),             // 24: wallet.GetHolderFlowsRequest
+	(*HolderFlow)(nil),                        // 25: wallet.HolderFlow
+	(*GetHolderFlowsResponse)(nil),            // 26: wallet.GetHolderFlowsResponse
+	(*SetWalletLabelRequest)(nil),             // 27: wallet.SetWalletLabelRequest
+	(*SetWalletLabelResponse)(nil),            // 28: wallet.SetWalletLabelResponse
+	(*ListWalletsByTagRequest)(nil),           // 29: wallet.ListWalletsByTagRequest
+	(*ListWalletsByTagResponse)(nil),          // 30: wallet.ListWalletsByTagResponse
+	(*StreamWalletTradesRequest)(nil),         // 31: wallet.StreamWalletTradesRequest
+	(*WalletTrade)(nil),                       // 32: wallet.WalletTrade
+	(*GetWalletTransactionsRequest)(nil),      // 33: wallet.GetWalletTransactionsRequest
+	(*WalletTransaction)(nil),                 // 34: wallet.WalletTransaction
+	(*GetWalletTransactionsResponse)(nil),     // 35: wallet.GetWalletTransactionsResponse
+	(*GetWalletLeaderboardRequest)(nil),       // 36: wallet.GetWalletLeaderboardRequest
+	(*LeaderboardEntry)(nil),                  // 37: wallet.LeaderboardEntry
+	(*GetWalletLeaderboardResponse)(nil),      // 38: wallet.GetWalletLeaderboardResponse
+	(*GetDailyLeaderboardRequest)(nil),        // 39: wallet.GetDailyLeaderboardRequest
+	(*GetDailyLeaderboardResponse)(nil),       // 40: wallet.GetDailyLeaderboardResponse
+	(*SetWalletLeaderboardOptInRequest)(nil),  // 41: wallet.SetWalletLeaderboardOptInRequest
+	(*SetWalletLeaderboardOptInResponse)(nil), // 42: wallet.SetWalletLeaderboardOptInResponse
+	(*SetWalletWatchFilterRequest)(nil),       // 43: wallet.SetWalletWatchFilterRequest
+	(*SetWalletWatchFilterResponse)(nil),      // 44: wallet.SetWalletWatchFilterResponse
+	(*SetWalletWebhookRequest)(nil),           // 45: wallet.SetWalletWebhookRequest
+	(*SetWalletWebhookResponse)(nil),          // 46: wallet.SetWalletWebhookResponse
+	(*SetWalletAlertsRequest)(nil),            // 47: wallet.SetWalletAlertsRequest
+	(*SetWalletAlertsResponse)(nil),           // 48: wallet.SetWalletAlertsResponse
+	(*WalletAlert)(nil),                       // 49: wallet.WalletAlert
+	(*KnownContract)(nil),                     // 50: wallet.KnownContract
+	(*AddKnownContractRequest)(nil),           // 51: wallet.AddKnownContractRequest
+	(*AddKnownContractResponse)(nil),          // 52: wallet.AddKnownContractResponse
+	(*RemoveKnownContractRequest)(nil),        // 53: wallet.RemoveKnownContractRequest
+	(*RemoveKnownContractResponse)(nil),       // 54: wallet.RemoveKnownContractResponse
+	(*ListKnownContractsRequest)(nil),         // 55: wallet.ListKnownContractsRequest
+	(*ListKnownContractsResponse)(nil),        // 56: wallet.ListKnownContractsResponse
+	(*WalletFlow)(nil),                        // 57: wallet.WalletFlow
+	(*StreamWalletEventsRequest)(nil),         // 58: wallet.StreamWalletEventsRequest
+	(*WalletEvent)(nil),                       // 59: wallet.WalletEvent
+	(*GetPortfolioHistoryRequest)(nil),        // 60: wallet.GetPortfolioHistoryRequest
+	(*PortfolioPoint)(nil),                    // 61: wallet.PortfolioPoint
+	(*GetPortfolioHistoryResponse)(nil),       // 62: wallet.GetPortfolioHistoryResponse
+	(*GetWalletApprovalsRequest)(nil),         // 63: wallet.GetWalletApprovalsRequest
+	(*WalletApproval)(nil),                    // 64: wallet.WalletApproval
+	(*GetWalletApprovalsResponse)(nil),        // 65: wallet.GetWalletApprovalsResponse
+	(*GetAggregatedPortfolioRequest)(nil),     // 66: wallet.GetAggregatedPortfolioRequest
+	(*WalletHolding)(nil),                     // 67: wallet.WalletHolding
+	(*AggregatedToken)(nil),                   // 68: wallet.AggregatedToken
+	(*GetAggregatedPortfolioResponse)(nil),    // 69: wallet.GetAggregatedPortfolioResponse
+	(*ImportWalletsRequest)(nil),              // 70: wallet.ImportWalletsRequest
+	(*ImportWalletsResponse)(nil),             // 71: wallet.ImportWalletsResponse
+	(*ImportFailure)(nil),                     // 72: wallet.ImportFailure
+	(*ImportJob)(nil),                         // 73: wallet.ImportJob
+	(*GetImportJobRequest)(nil),               // 74: wallet.GetImportJobRequest
+	(*GetImportJobResponse)(nil),              // 75: wallet.GetImportJobResponse
+	(*RebalanceWalletShardsRequest)(nil),      // 76: wallet.RebalanceWalletShardsRequest
+	(*RebalanceWalletShardsResponse)(nil),     // 77: wallet.RebalanceWalletShardsResponse
+	(*GetApiUsageRequest)(nil),                // 78: wallet.GetApiUsageRequest
+	(*GetApiUsageResponse)(nil),               // 79: wallet.GetApiUsageResponse
+	(common.CHAIN)(0),                         // 80: common.CHAIN
+	(*common.Wallet)(nil),                     // 81: common.Wallet
+	(*common.WalletToken)(nil),                // 82: common.WalletToken
+	(*common.ApiUsage)(nil),                   // 83: common.ApiUsage
+}
+var file_wallet_messages_proto_depIdxs = []int32{
+	80, // 0: wallet.GetWalletRequest.chain:type_name -> common.CHAIN
+	0,  // 1: wallet.GetWalletRequest.type:type_name -> wallet.DataType
+	81, // 2: wallet.GetWalletResponse.walletData:type_name -> common.Wallet
+	80, // 3: wallet.GetWalletTokensRequest.chain:type_name -> common.CHAIN
+	0,  // 4: wallet.GetWalletTokensRequest.type:type_name -> wallet.DataType
+	82, // 5: wallet.GetWalletTokensResponse.tokens:type_name -> common.WalletToken
+	80, // 6: wallet.GetWalletDetailsRequest.chain:type_name -> common.CHAIN
+	0,  // 7: wallet.GetWalletDetailsRequest.type:type_name -> wallet.DataType
+	82, // 8: wallet.GetWalletDetailsResponse.tokens:type_name -> common.WalletToken
+	81, // 9: wallet.GetWalletDetailsResponse.walletData:type_name -> common.Wallet
+	25, // 10: wallet.GetHolderFlowsResponse.holders:type_name -> wallet.HolderFlow
+	81, // 11: wallet.SetWalletLabelResponse.walletData:type_name -> common.Wallet
+	81, // 12: wallet.ListWalletsByTagResponse.wallets:type_name -> common.Wallet
+	1,  // 13: wallet.WalletTrade.side:type_name -> wallet.TradeSide
+	2,  // 14: wallet.WalletTrade.activity:type_name -> wallet.ActivityType
+	2,  // 15: wallet.WalletTransaction.activity:type_name -> wallet.ActivityType
+	34, // 16: wallet.GetWalletTransactionsResponse.transactions:type_name -> wallet.WalletTransaction
+	3,  // 17: wallet.GetWalletLeaderboardRequest.period:type_name -> wallet.LeaderboardPeriod
+	37, // 18: wallet.GetWalletLeaderboardResponse.entries:type_name -> wallet.LeaderboardEntry
+	37, // 19: wallet.GetDailyLeaderboardResponse.entries:type_name -> wallet.LeaderboardEntry
+	4,  // 20: wallet.SetWalletWebhookRequest.events:type_name -> wallet.WebhookEvent
+	5,  // 21: wallet.WalletAlert.type:type_name -> wallet.WalletAlertType
+	6,  // 22: wallet.KnownContract.category:type_name -> wallet.ContractCategory
+	6,  // 23: wallet.AddKnownContractRequest.category:type_name -> wallet.ContractCategory
+	50, // 24: wallet.AddKnownContractResponse.contract:type_name -> wallet.KnownContract
+	6,  // 25: wallet.ListKnownContractsRequest.category:type_name -> wallet.ContractCategory
+	50, // 26: wallet.ListKnownContractsResponse.contracts:type_name -> wallet.KnownContract
+	7,  // 27: wallet.WalletFlow.type:type_name -> wallet.WalletFlowType
+	32, // 28: wallet.WalletEvent.trade:type_name -> wallet.WalletTrade
+	57, // 29: wallet.WalletEvent.flow:type_name -> wallet.WalletFlow
+	49, // 30: wallet.WalletEvent.alert:type_name -> wallet.WalletAlert
+	8,  // 31: wallet.GetPortfolioHistoryRequest.range:type_name -> wallet.PortfolioRange
+	8,  // 32: wallet.GetPortfolioHistoryResponse.range:type_name -> wallet.PortfolioRange
+	61, // 33: wallet.GetPortfolioHistoryResponse.points:type_name -> wallet.PortfolioPoint
+	64, // 34: wallet.GetWalletApprovalsResponse.approvals:type_name -> wallet.WalletApproval
+	67, // 35: wallet.AggregatedToken.wallets:type_name -> wallet.WalletHolding
+	68, // 36: wallet.GetAggregatedPortfolioResponse.tokens:type_name -> wallet.AggregatedToken
+	9,  // 37: wallet.ImportJob.state:type_name -> wallet.ImportJobState
+	72, // 38: wallet.ImportJob.failures:type_name -> wallet.ImportFailure
+	73, // 39: wallet.GetImportJobResponse.job:type_name -> wallet.ImportJob
+	83, // 40: wallet.GetApiUsageResponse.usage:type_name -> common.ApiUsage
+	41, // [41:41] is the sub-list for method output_type
+	41, // [41:41] is the sub-list for method input_type
+	41, // [41:41] is the sub-list for extension type_name
+	41, // [41:41] is the sub-list for extension extendee
+	0,  // [0:41] is the sub-list for field type_name
+}
+
+func init() { file_wallet_messages_proto_init() }
+func file_wallet_messages_proto_init() {
+	if File_wallet_messages_proto != nil {
+		return
+	}
+	file_wallet_messages_proto_msgTypes[12].OneofWrappers = []any{}
+	file_wallet_messages_proto_msgTypes[17].OneofWrappers = []any{}
+	file_wallet_messages_proto_msgTypes[19].OneofWrappers = []any{}
+	file_wallet_messages_proto_msgTypes[23].OneofWrappers = []any{}
+	file_wallet_messages_proto_msgTypes[26].OneofWrappers = []any{}
+	file_wallet_messages_proto_msgTypes[29].OneofWrappers = []any{}
+	file_wallet_messages_proto_msgTypes[45].OneofWrappers = []any{}
+	file_wallet_messages_proto_msgTypes[49].OneofWrappers = []any{
+		(*WalletEvent_Trade)(nil),
+		(*WalletEvent_Flow)(nil),
+		(*WalletEvent_Alert)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_wallet_messages_proto_rawDesc), len(file_wallet_messages_proto_rawDesc)),
+			NumEnums:      10,
+			NumMessages:   70,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_wallet_messages_proto_goTypes,
+		DependencyIndexes: file_wallet_messages_proto_depIdxs,
+		EnumInfos:         file_wallet_messages_proto_enumTypes,
+		MessageInfos:      file_wallet_messages_proto_msgTypes,
+	}.Build()
+	File_wallet_messages_proto = out.File
+	file_wallet_messages_proto_goTypes = nil
+	file_wallet_messages_proto_depIdxs = nil
+}
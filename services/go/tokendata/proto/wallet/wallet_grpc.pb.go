@@ -0,0 +1,1192 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v6.33.4
+// source: wallet/wallet.proto
+
+package wallet
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ScannerWallet_AddWallet_FullMethodName                 = "/scanner_wallet.ScannerWallet/addWallet"
+	ScannerWallet_GetWallet_FullMethodName                 = "/scanner_wallet.ScannerWallet/getWallet"
+	ScannerWallet_GetWalletTokens_FullMethodName           = "/scanner_wallet.ScannerWallet/getWalletTokens"
+	ScannerWallet_GetWalletDetails_FullMethodName          = "/scanner_wallet.ScannerWallet/getWalletDetails"
+	ScannerWallet_UpdateWalletPortfolio_FullMethodName     = "/scanner_wallet.ScannerWallet/updateWalletPortfolio"
+	ScannerWallet_WatchTokenHolders_FullMethodName         = "/scanner_wallet.ScannerWallet/watchTokenHolders"
+	ScannerWallet_GetHolderFlows_FullMethodName            = "/scanner_wallet.ScannerWallet/getHolderFlows"
+	ScannerWallet_SetWalletLabel_FullMethodName            = "/scanner_wallet.ScannerWallet/setWalletLabel"
+	ScannerWallet_ListWalletsByTag_FullMethodName          = "/scanner_wallet.ScannerWallet/listWalletsByTag"
+	ScannerWallet_StreamWalletTrades_FullMethodName        = "/scanner_wallet.ScannerWallet/streamWalletTrades"
+	ScannerWallet_StreamWalletEvents_FullMethodName        = "/scanner_wallet.ScannerWallet/streamWalletEvents"
+	ScannerWallet_GetPortfolioHistory_FullMethodName       = "/scanner_wallet.ScannerWallet/getPortfolioHistory"
+	ScannerWallet_GetWalletApprovals_FullMethodName        = "/scanner_wallet.ScannerWallet/getWalletApprovals"
+	ScannerWallet_GetAggregatedPortfolio_FullMethodName    = "/scanner_wallet.ScannerWallet/getAggregatedPortfolio"
+	ScannerWallet_GetWalletLeaderboard_FullMethodName      = "/scanner_wallet.ScannerWallet/getWalletLeaderboard"
+	ScannerWallet_GetDailyLeaderboard_FullMethodName       = "/scanner_wallet.ScannerWallet/getDailyLeaderboard"
+	ScannerWallet_SetWalletLeaderboardOptIn_FullMethodName = "/scanner_wallet.ScannerWallet/setWalletLeaderboardOptIn"
+	ScannerWallet_SetWalletWatchFilter_FullMethodName      = "/scanner_wallet.ScannerWallet/setWalletWatchFilter"
+	ScannerWallet_SetWalletWebhook_FullMethodName          = "/scanner_wallet.ScannerWallet/setWalletWebhook"
+	ScannerWallet_SetWalletAlerts_FullMethodName           = "/scanner_wallet.ScannerWallet/setWalletAlerts"
+	ScannerWallet_AddKnownContract_FullMethodName          = "/scanner_wallet.ScannerWallet/addKnownContract"
+	ScannerWallet_RemoveKnownContract_FullMethodName       = "/scanner_wallet.ScannerWallet/removeKnownContract"
+	ScannerWallet_ListKnownContracts_FullMethodName        = "/scanner_wallet.ScannerWallet/listKnownContracts"
+	ScannerWallet_ImportWallets_FullMethodName             = "/scanner_wallet.ScannerWallet/importWallets"
+	ScannerWallet_GetImportJob_FullMethodName              = "/scanner_wallet.ScannerWallet/getImportJob"
+	ScannerWallet_GetWalletTransactions_FullMethodName     = "/scanner_wallet.ScannerWallet/getWalletTransactions"
+	ScannerWallet_MarkTokenSafe_FullMethodName             = "/scanner_wallet.ScannerWallet/markTokenSafe"
+	ScannerWallet_RebalanceWalletShards_FullMethodName     = "/scanner_wallet.ScannerWallet/rebalanceWalletShards"
+	ScannerWallet_GetApiUsage_FullMethodName               = "/scanner_wallet.ScannerWallet/getApiUsage"
+)
+
+// ScannerWalletClient is the client API for ScannerWallet service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ScannerWalletClient interface {
+	AddWallet(ctx context.Context, in *AddWalletRequest, opts ...grpc.CallOption) (*AddWalletResponse, error)
+	GetWallet(ctx context.Context, in *GetWalletRequest, opts ...grpc.CallOption) (*GetWalletResponse, error)
+	GetWalletTokens(ctx context.Context, in *GetWalletTokensRequest, opts ...grpc.CallOption) (*GetWalletTokensResponse, error)
+	GetWalletDetails(ctx context.Context, in *GetWalletDetailsRequest, opts ...grpc.CallOption) (*GetWalletDetailsResponse, error)
+	UpdateWalletPortfolio(ctx context.Context, in *UpdateWalletPortfolioRequest, opts ...grpc.CallOption) (*UpdateWalletPortfolioResponse, error)
+	WatchTokenHolders(ctx context.Context, in *WatchTokenHoldersRequest, opts ...grpc.CallOption) (*WatchTokenHoldersResponse, error)
+	GetHolderFlows(ctx context.Context, in *GetHolderFlowsRequest, opts ...grpc.CallOption) (*GetHolderFlowsResponse, error)
+	SetWalletLabel(ctx context.Context, in *SetWalletLabelRequest, opts ...grpc.CallOption) (*SetWalletLabelResponse, error)
+	ListWalletsByTag(ctx context.Context, in *ListWalletsByTagRequest, opts ...grpc.CallOption) (*ListWalletsByTagResponse, error)
+	StreamWalletTrades(ctx context.Context, in *StreamWalletTradesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WalletTrade], error)
+	StreamWalletEvents(ctx context.Context, in *StreamWalletEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WalletEvent], error)
+	GetPortfolioHistory(ctx context.Context, in *GetPortfolioHistoryRequest, opts ...grpc.CallOption) (*GetPortfolioHistoryResponse, error)
+	GetWalletApprovals(ctx context.Context, in *GetWalletApprovalsRequest, opts ...grpc.CallOption) (*GetWalletApprovalsResponse, error)
+	GetAggregatedPortfolio(ctx context.Context, in *GetAggregatedPortfolioRequest, opts ...grpc.CallOption) (*GetAggregatedPortfolioResponse, error)
+	GetWalletLeaderboard(ctx context.Context, in *GetWalletLeaderboardRequest, opts ...grpc.CallOption) (*GetWalletLeaderboardResponse, error)
+	GetDailyLeaderboard(ctx context.Context, in *GetDailyLeaderboardRequest, opts ...grpc.CallOption) (*GetDailyLeaderboardResponse, error)
+	SetWalletLeaderboardOptIn(ctx context.Context, in *SetWalletLeaderboardOptInRequest, opts ...grpc.CallOption) (*SetWalletLeaderboardOptInResponse, error)
+	SetWalletWatchFilter(ctx context.Context, in *SetWalletWatchFilterRequest, opts ...grpc.CallOption) (*SetWalletWatchFilterResponse, error)
+	SetWalletWebhook(ctx context.Context, in *SetWalletWebhookRequest, opts ...grpc.CallOption) (*SetWalletWebhookResponse, error)
+	SetWalletAlerts(ctx context.Context, in *SetWalletAlertsRequest, opts ...grpc.CallOption) (*SetWalletAlertsResponse, error)
+	AddKnownContract(ctx context.Context, in *AddKnownContractRequest, opts ...grpc.CallOption) (*AddKnownContractResponse, error)
+	RemoveKnownContract(ctx context.Context, in *RemoveKnownContractRequest, opts ...grpc.CallOption) (*RemoveKnownContractResponse, error)
+	ListKnownContracts(ctx context.Context, in *ListKnownContractsRequest, opts ...grpc.CallOption) (*ListKnownContractsResponse, error)
+	ImportWallets(ctx context.Context, in *ImportWalletsRequest, opts ...grpc.CallOption) (*ImportWalletsResponse, error)
+	GetImportJob(ctx context.Context, in *GetImportJobRequest, opts ...grpc.CallOption) (*GetImportJobResponse, error)
+	GetWalletTransactions(ctx context.Context, in *GetWalletTransactionsRequest, opts ...grpc.CallOption) (*GetWalletTransactionsResponse, error)
+	MarkTokenSafe(ctx context.Context, in *MarkTokenSafeRequest, opts ...grpc.CallOption) (*MarkTokenSafeResponse, error)
+	RebalanceWalletShards(ctx context.Context, in *RebalanceWalletShardsRequest, opts ...grpc.CallOption) (*RebalanceWalletShardsResponse, error)
+	GetApiUsage(ctx context.Context, in *GetApiUsageRequest, opts ...grpc.CallOption) (*GetApiUsageResponse, error)
+}
+
+type scannerWalletClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewScannerWalletClient(cc grpc.ClientConnInterface) ScannerWalletClient {
+	return &scannerWalletClient{cc}
+}
+
+func (c *scannerWalletClient) AddWallet(ctx context.Context, in *AddWalletRequest, opts ...grpc.CallOption) (*AddWalletResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddWalletResponse)
+	err := c.cc.Invoke(ctx, ScannerWallet_AddWallet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerWalletClient) GetWallet(ctx context.Context, in *GetWalletRequest, opts ...grpc.CallOption) (*GetWalletResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetWalletResponse)
+	err := c.cc.Invoke(ctx, ScannerWallet_GetWallet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerWalletClient) GetWalletTokens(ctx context.Context, in *GetWalletTokensRequest, opts ...grpc.CallOption) (*GetWalletTokensResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetWalletTokensResponse)
+	err := c.cc.Invoke(ctx, ScannerWallet_GetWalletTokens_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerWalletClient) GetWalletDetails(ctx context.Context, in *GetWalletDetailsRequest, opts ...grpc.CallOption) (*GetWalletDetailsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetWalletDetailsResponse)
+	err := c.cc.Invoke(ctx, ScannerWallet_GetWalletDetails_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerWalletClient) UpdateWalletPortfolio(ctx context.Context, in *UpdateWalletPortfolioRequest, opts ...grpc.CallOption) (*UpdateWalletPortfolioResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateWalletPortfolioResponse)
+	err := c.cc.Invoke(ctx, ScannerWallet_UpdateWalletPortfolio_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerWalletClient) WatchTokenHolders(ctx context.Context, in *WatchTokenHoldersRequest, opts ...grpc.CallOption) (*WatchTokenHoldersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WatchTokenHoldersResponse)
+	err := c.cc.Invoke(ctx, ScannerWallet_WatchTokenHolders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerWalletClient) GetHolderFlows(ctx context.Context, in *GetHolderFlowsRequest, opts ...grpc.CallOption) (*GetHolderFlowsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetHolderFlowsResponse)
+	err := c.cc.Invoke(ctx, ScannerWallet_GetHolderFlows_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerWalletClient) SetWalletLabel(ctx context.Context, in *SetWalletLabelRequest, opts ...grpc.CallOption) (*SetWalletLabelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetWalletLabelResponse)
+	err := c.cc.Invoke(ctx, ScannerWallet_SetWalletLabel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerWalletClient) ListWalletsByTag(ctx context.Context, in *ListWalletsByTagRequest, opts ...grpc.CallOption) (*ListWalletsByTagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWalletsByTagResponse)
+	err := c.cc.Invoke(ctx, ScannerWallet_ListWalletsByTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerWalletClient) StreamWalletTrades(ctx context.Context, in *StreamWalletTradesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WalletTrade], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ScannerWallet_ServiceDesc.Streams[0], ScannerWallet_StreamWalletTrades_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamWalletTradesRequest, WalletTrade]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ScannerWallet_StreamWalletTradesClient = grpc.ServerStreamingClient[WalletTrade]
+
+func (c *scannerWalletClient) StreamWalletEvents(ctx context.Context, in *StreamWalletEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WalletEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ScannerWallet_ServiceDesc.Streams[1], ScannerWallet_StreamWalletEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamWalletEventsRequest, WalletEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ScannerWallet_StreamWalletEventsClient = grpc.ServerStreamingClient[WalletEvent]
+
+func (c *scannerWalletClient) GetPortfolioHistory(ctx context.Context, in *GetPortfolioHistoryRequest, opts ...grpc.CallOption) (*GetPortfolioHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPortfolioHistoryResponse)
+	err := c.cc.Invoke(ctx, ScannerWallet_GetPortfolioHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerWalletClient) GetWalletApprovals(ctx context.Context, in *GetWalletApprovalsRequest, opts ...grpc.CallOption) (*GetWalletApprovalsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetWalletApprovalsResponse)
+	err := c.cc.Invoke(ctx, ScannerWallet_GetWalletApprovals_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerWalletClient) GetAggregatedPortfolio(ctx context.Context, in *GetAggregatedPortfolioRequest, opts ...grpc.CallOption) (*GetAggregatedPortfolioResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAggregatedPortfolioResponse)
+	err := c.cc.Invoke(ctx, ScannerWallet_GetAggregatedPortfolio_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerWalletClient) GetWalletLeaderboard(ctx context.Context, in *GetWalletLeaderboardRequest, opts ...grpc.CallOption) (*GetWalletLeaderboardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetWalletLeaderboardResponse)
+	err := c.cc.Invoke(ctx, ScannerWallet_GetWalletLeaderboard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerWalletClient) GetDailyLeaderboard(ctx context.Context, in *GetDailyLeaderboardRequest, opts ...grpc.CallOption) (*GetDailyLeaderboardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDailyLeaderboardResponse)
+	err := c.cc.Invoke(ctx, ScannerWallet_GetDailyLeaderboard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerWalletClient) SetWalletLeaderboardOptIn(ctx context.Context, in *SetWalletLeaderboardOptInRequest, opts ...grpc.CallOption) (*SetWalletLeaderboardOptInResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetWalletLeaderboardOptInResponse)
+	err := c.cc.Invoke(ctx, ScannerWallet_SetWalletLeaderboardOptIn_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerWalletClient) SetWalletWatchFilter(ctx context.Context, in *SetWalletWatchFilterRequest, opts ...grpc.CallOption) (*SetWalletWatchFilterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetWalletWatchFilterResponse)
+	err := c.cc.Invoke(ctx, ScannerWallet_SetWalletWatchFilter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerWalletClient) SetWalletWebhook(ctx context.Context, in *SetWalletWebhookRequest, opts ...grpc.CallOption) (*SetWalletWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetWalletWebhookResponse)
+	err := c.cc.Invoke(ctx, ScannerWallet_SetWalletWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerWalletClient) SetWalletAlerts(ctx context.Context, in *SetWalletAlertsRequest, opts ...grpc.CallOption) (*SetWalletAlertsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetWalletAlertsResponse)
+	err := c.cc.Invoke(ctx, ScannerWallet_SetWalletAlerts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerWalletClient) AddKnownContract(ctx context.Context, in *AddKnownContractRequest, opts ...grpc.CallOption) (*AddKnownContractResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddKnownContractResponse)
+	err := c.cc.Invoke(ctx, ScannerWallet_AddKnownContract_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerWalletClient) RemoveKnownContract(ctx context.Context, in *RemoveKnownContractRequest, opts ...grpc.CallOption) (*RemoveKnownContractResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveKnownContractResponse)
+	err := c.cc.Invoke(ctx, ScannerWallet_RemoveKnownContract_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerWalletClient) ListKnownContracts(ctx context.Context, in *ListKnownContractsRequest, opts ...grpc.CallOption) (*ListKnownContractsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListKnownContractsResponse)
+	err := c.cc.Invoke(ctx, ScannerWallet_ListKnownContracts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerWalletClient) ImportWallets(ctx context.Context, in *ImportWalletsRequest, opts ...grpc.CallOption) (*ImportWalletsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportWalletsResponse)
+	err := c.cc.Invoke(ctx, ScannerWallet_ImportWallets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerWalletClient) GetImportJob(ctx context.Context, in *GetImportJobRequest, opts ...grpc.CallOption) (*GetImportJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetImportJobResponse)
+	err := c.cc.Invoke(ctx, ScannerWallet_GetImportJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerWalletClient) GetWalletTransactions(ctx context.Context, in *GetWalletTransactionsRequest, opts ...grpc.CallOption) (*GetWalletTransactionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetWalletTransactionsResponse)
+	err := c.cc.Invoke(ctx, ScannerWallet_GetWalletTransactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerWalletClient) MarkTokenSafe(ctx context.Context, in *MarkTokenSafeRequest, opts ...grpc.CallOption) (*MarkTokenSafeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MarkTokenSafeResponse)
+	err := c.cc.Invoke(ctx, ScannerWallet_MarkTokenSafe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerWalletClient) RebalanceWalletShards(ctx context.Context, in *RebalanceWalletShardsRequest, opts ...grpc.CallOption) (*RebalanceWalletShardsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RebalanceWalletShardsResponse)
+	err := c.cc.Invoke(ctx, ScannerWallet_RebalanceWalletShards_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerWalletClient) GetApiUsage(ctx context.Context, in *GetApiUsageRequest, opts ...grpc.CallOption) (*GetApiUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetApiUsageResponse)
+	err := c.cc.Invoke(ctx, ScannerWallet_GetApiUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScannerWalletServer is the server API for ScannerWallet service.
+// All implementations must embed UnimplementedScannerWalletServer
+// for forward compatibility.
+type ScannerWalletServer interface {
+	AddWallet(context.Context, *AddWalletRequest) (*AddWalletResponse, error)
+	GetWallet(context.Context, *GetWalletRequest) (*GetWalletResponse, error)
+	GetWalletTokens(context.Context, *GetWalletTokensRequest) (*GetWalletTokensResponse, error)
+	GetWalletDetails(context.Context, *GetWalletDetailsRequest) (*GetWalletDetailsResponse, error)
+	UpdateWalletPortfolio(context.Context, *UpdateWalletPortfolioRequest) (*UpdateWalletPortfolioResponse, error)
+	WatchTokenHolders(context.Context, *WatchTokenHoldersRequest) (*WatchTokenHoldersResponse, error)
+	GetHolderFlows(context.Context, *GetHolderFlowsRequest) (*GetHolderFlowsResponse, error)
+	SetWalletLabel(context.Context, *SetWalletLabelRequest) (*SetWalletLabelResponse, error)
+	ListWalletsByTag(context.Context, *ListWalletsByTagRequest) (*ListWalletsByTagResponse, error)
+	StreamWalletTrades(*StreamWalletTradesRequest, grpc.ServerStreamingServer[WalletTrade]) error
+	StreamWalletEvents(*StreamWalletEventsRequest, grpc.ServerStreamingServer[WalletEvent]) error
+	GetPortfolioHistory(context.Context, *GetPortfolioHistoryRequest) (*GetPortfolioHistoryResponse, error)
+	GetWalletApprovals(context.Context, *GetWalletApprovalsRequest) (*GetWalletApprovalsResponse, error)
+	GetAggregatedPortfolio(context.Context, *GetAggregatedPortfolioRequest) (*GetAggregatedPortfolioResponse, error)
+	GetWalletLeaderboard(context.Context, *GetWalletLeaderboardRequest) (*GetWalletLeaderboardResponse, error)
+	GetDailyLeaderboard(context.Context, *GetDailyLeaderboardRequest) (*GetDailyLeaderboardResponse, error)
+	SetWalletLeaderboardOptIn(context.Context, *SetWalletLeaderboardOptInRequest) (*SetWalletLeaderboardOptInResponse, error)
+	SetWalletWatchFilter(context.Context, *SetWalletWatchFilterRequest) (*SetWalletWatchFilterResponse, error)
+	SetWalletWebhook(context.Context, *SetWalletWebhookRequest) (*SetWalletWebhookResponse, error)
+	SetWalletAlerts(context.Context, *SetWalletAlertsRequest) (*SetWalletAlertsResponse, error)
+	AddKnownContract(context.Context, *AddKnownContractRequest) (*AddKnownContractResponse, error)
+	RemoveKnownContract(context.Context, *RemoveKnownContractRequest) (*RemoveKnownContractResponse, error)
+	ListKnownContracts(context.Context, *ListKnownContractsRequest) (*ListKnownContractsResponse, error)
+	ImportWallets(context.Context, *ImportWalletsRequest) (*ImportWalletsResponse, error)
+	GetImportJob(context.Context, *GetImportJobRequest) (*GetImportJobResponse, error)
+	GetWalletTransactions(context.Context, *GetWalletTransactionsRequest) (*GetWalletTransactionsResponse, error)
+	MarkTokenSafe(context.Context, *MarkTokenSafeRequest) (*MarkTokenSafeResponse, error)
+	RebalanceWalletShards(context.Context, *RebalanceWalletShardsRequest) (*RebalanceWalletShardsResponse, error)
+	GetApiUsage(context.Context, *GetApiUsageRequest) (*GetApiUsageResponse, error)
+	mustEmbedUnimplementedScannerWalletServer()
+}
+
+// UnimplementedScannerWalletServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedScannerWalletServer struct{}
+
+func (UnimplementedScannerWalletServer) AddWallet(context.Context, *AddWalletRequest) (*AddWalletResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddWallet not implemented")
+}
+func (UnimplementedScannerWalletServer) GetWallet(context.Context, *GetWalletRequest) (*GetWalletResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetWallet not implemented")
+}
+func (UnimplementedScannerWalletServer) GetWalletTokens(context.Context, *GetWalletTokensRequest) (*GetWalletTokensResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetWalletTokens not implemented")
+}
+func (UnimplementedScannerWalletServer) GetWalletDetails(context.Context, *GetWalletDetailsRequest) (*GetWalletDetailsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetWalletDetails not implemented")
+}
+func (UnimplementedScannerWalletServer) UpdateWalletPortfolio(context.Context, *UpdateWalletPortfolioRequest) (*UpdateWalletPortfolioResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateWalletPortfolio not implemented")
+}
+func (UnimplementedScannerWalletServer) WatchTokenHolders(context.Context, *WatchTokenHoldersRequest) (*WatchTokenHoldersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method WatchTokenHolders not implemented")
+}
+func (UnimplementedScannerWalletServer) GetHolderFlows(context.Context, *GetHolderFlowsRequest) (*GetHolderFlowsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetHolderFlows not implemented")
+}
+func (UnimplementedScannerWalletServer) SetWalletLabel(context.Context, *SetWalletLabelRequest) (*SetWalletLabelResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetWalletLabel not implemented")
+}
+func (UnimplementedScannerWalletServer) ListWalletsByTag(context.Context, *ListWalletsByTagRequest) (*ListWalletsByTagResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListWalletsByTag not implemented")
+}
+func (UnimplementedScannerWalletServer) StreamWalletTrades(*StreamWalletTradesRequest, grpc.ServerStreamingServer[WalletTrade]) error {
+	return status.Error(codes.Unimplemented, "method StreamWalletTrades not implemented")
+}
+func (UnimplementedScannerWalletServer) StreamWalletEvents(*StreamWalletEventsRequest, grpc.ServerStreamingServer[WalletEvent]) error {
+	return status.Error(codes.Unimplemented, "method StreamWalletEvents not implemented")
+}
+func (UnimplementedScannerWalletServer) GetPortfolioHistory(context.Context, *GetPortfolioHistoryRequest) (*GetPortfolioHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPortfolioHistory not implemented")
+}
+func (UnimplementedScannerWalletServer) GetWalletApprovals(context.Context, *GetWalletApprovalsRequest) (*GetWalletApprovalsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetWalletApprovals not implemented")
+}
+func (UnimplementedScannerWalletServer) GetAggregatedPortfolio(context.Context, *GetAggregatedPortfolioRequest) (*GetAggregatedPortfolioResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAggregatedPortfolio not implemented")
+}
+func (UnimplementedScannerWalletServer) GetWalletLeaderboard(context.Context, *GetWalletLeaderboardRequest) (*GetWalletLeaderboardResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetWalletLeaderboard not implemented")
+}
+func (UnimplementedScannerWalletServer) GetDailyLeaderboard(context.Context, *GetDailyLeaderboardRequest) (*GetDailyLeaderboardResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDailyLeaderboard not implemented")
+}
+func (UnimplementedScannerWalletServer) SetWalletLeaderboardOptIn(context.Context, *SetWalletLeaderboardOptInRequest) (*SetWalletLeaderboardOptInResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetWalletLeaderboardOptIn not implemented")
+}
+func (UnimplementedScannerWalletServer) SetWalletWatchFilter(context.Context, *SetWalletWatchFilterRequest) (*SetWalletWatchFilterResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetWalletWatchFilter not implemented")
+}
+func (UnimplementedScannerWalletServer) SetWalletWebhook(context.Context, *SetWalletWebhookRequest) (*SetWalletWebhookResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetWalletWebhook not implemented")
+}
+func (UnimplementedScannerWalletServer) SetWalletAlerts(context.Context, *SetWalletAlertsRequest) (*SetWalletAlertsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetWalletAlerts not implemented")
+}
+func (UnimplementedScannerWalletServer) AddKnownContract(context.Context, *AddKnownContractRequest) (*AddKnownContractResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddKnownContract not implemented")
+}
+func (UnimplementedScannerWalletServer) RemoveKnownContract(context.Context, *RemoveKnownContractRequest) (*RemoveKnownContractResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveKnownContract not implemented")
+}
+func (UnimplementedScannerWalletServer) ListKnownContracts(context.Context, *ListKnownContractsRequest) (*ListKnownContractsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListKnownContracts not implemented")
+}
+func (UnimplementedScannerWalletServer) ImportWallets(context.Context, *ImportWalletsRequest) (*ImportWalletsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ImportWallets not implemented")
+}
+func (UnimplementedScannerWalletServer) GetImportJob(context.Context, *GetImportJobRequest) (*GetImportJobResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetImportJob not implemented")
+}
+func (UnimplementedScannerWalletServer) GetWalletTransactions(context.Context, *GetWalletTransactionsRequest) (*GetWalletTransactionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetWalletTransactions not implemented")
+}
+func (UnimplementedScannerWalletServer) MarkTokenSafe(context.Context, *MarkTokenSafeRequest) (*MarkTokenSafeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MarkTokenSafe not implemented")
+}
+func (UnimplementedScannerWalletServer) RebalanceWalletShards(context.Context, *RebalanceWalletShardsRequest) (*RebalanceWalletShardsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RebalanceWalletShards not implemented")
+}
+func (UnimplementedScannerWalletServer) GetApiUsage(context.Context, *GetApiUsageRequest) (*GetApiUsageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetApiUsage not implemented")
+}
+func (UnimplementedScannerWalletServer) mustEmbedUnimplementedScannerWalletServer() {}
+func (UnimplementedScannerWalletServer) testEmbeddedByValue()                       {}
+
+// UnsafeScannerWalletServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ScannerWalletServer will
+// result in compilation errors.
+type UnsafeScannerWalletServer interface {
+	mustEmbedUnimplementedScannerWalletServer()
+}
+
+func RegisterScannerWalletServer(s grpc.ServiceRegistrar, srv ScannerWalletServer) {
+	// If the following call panics, it indicates UnimplementedScannerWalletServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ScannerWallet_ServiceDesc, srv)
+}
+
+func _ScannerWallet_AddWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerWalletServer).AddWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerWallet_AddWallet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerWalletServer).AddWallet(ctx, req.(*AddWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerWallet_GetWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerWalletServer).GetWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerWallet_GetWallet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerWalletServer).GetWallet(ctx, req.(*GetWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerWallet_GetWalletTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWalletTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerWalletServer).GetWalletTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerWallet_GetWalletTokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerWalletServer).GetWalletTokens(ctx, req.(*GetWalletTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerWallet_GetWalletDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWalletDetailsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerWalletServer).GetWalletDetails(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerWallet_GetWalletDetails_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerWalletServer).GetWalletDetails(ctx, req.(*GetWalletDetailsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerWallet_UpdateWalletPortfolio_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateWalletPortfolioRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerWalletServer).UpdateWalletPortfolio(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerWallet_UpdateWalletPortfolio_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerWalletServer).UpdateWalletPortfolio(ctx, req.(*UpdateWalletPortfolioRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerWallet_WatchTokenHolders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WatchTokenHoldersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerWalletServer).WatchTokenHolders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerWallet_WatchTokenHolders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerWalletServer).WatchTokenHolders(ctx, req.(*WatchTokenHoldersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerWallet_GetHolderFlows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHolderFlowsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerWalletServer).GetHolderFlows(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerWallet_GetHolderFlows_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerWalletServer).GetHolderFlows(ctx, req.(*GetHolderFlowsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerWallet_SetWalletLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetWalletLabelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerWalletServer).SetWalletLabel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerWallet_SetWalletLabel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerWalletServer).SetWalletLabel(ctx, req.(*SetWalletLabelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerWallet_ListWalletsByTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWalletsByTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerWalletServer).ListWalletsByTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerWallet_ListWalletsByTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerWalletServer).ListWalletsByTag(ctx, req.(*ListWalletsByTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerWallet_StreamWalletTrades_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamWalletTradesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ScannerWalletServer).StreamWalletTrades(m, &grpc.GenericServerStream[StreamWalletTradesRequest, WalletTrade]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ScannerWallet_StreamWalletTradesServer = grpc.ServerStreamingServer[WalletTrade]
+
+func _ScannerWallet_StreamWalletEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamWalletEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ScannerWalletServer).StreamWalletEvents(m, &grpc.GenericServerStream[StreamWalletEventsRequest, WalletEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ScannerWallet_StreamWalletEventsServer = grpc.ServerStreamingServer[WalletEvent]
+
+func _ScannerWallet_GetPortfolioHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPortfolioHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerWalletServer).GetPortfolioHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerWallet_GetPortfolioHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerWalletServer).GetPortfolioHistory(ctx, req.(*GetPortfolioHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerWallet_GetWalletApprovals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWalletApprovalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerWalletServer).GetWalletApprovals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerWallet_GetWalletApprovals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerWalletServer).GetWalletApprovals(ctx, req.(*GetWalletApprovalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerWallet_GetAggregatedPortfolio_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAggregatedPortfolioRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerWalletServer).GetAggregatedPortfolio(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerWallet_GetAggregatedPortfolio_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerWalletServer).GetAggregatedPortfolio(ctx, req.(*GetAggregatedPortfolioRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerWallet_GetWalletLeaderboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWalletLeaderboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerWalletServer).GetWalletLeaderboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerWallet_GetWalletLeaderboard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerWalletServer).GetWalletLeaderboard(ctx, req.(*GetWalletLeaderboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerWallet_GetDailyLeaderboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDailyLeaderboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerWalletServer).GetDailyLeaderboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerWallet_GetDailyLeaderboard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerWalletServer).GetDailyLeaderboard(ctx, req.(*GetDailyLeaderboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerWallet_SetWalletLeaderboardOptIn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetWalletLeaderboardOptInRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerWalletServer).SetWalletLeaderboardOptIn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerWallet_SetWalletLeaderboardOptIn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerWalletServer).SetWalletLeaderboardOptIn(ctx, req.(*SetWalletLeaderboardOptInRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerWallet_SetWalletWatchFilter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetWalletWatchFilterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerWalletServer).SetWalletWatchFilter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerWallet_SetWalletWatchFilter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerWalletServer).SetWalletWatchFilter(ctx, req.(*SetWalletWatchFilterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerWallet_SetWalletWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetWalletWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerWalletServer).SetWalletWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerWallet_SetWalletWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerWalletServer).SetWalletWebhook(ctx, req.(*SetWalletWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerWallet_SetWalletAlerts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetWalletAlertsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerWalletServer).SetWalletAlerts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerWallet_SetWalletAlerts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerWalletServer).SetWalletAlerts(ctx, req.(*SetWalletAlertsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerWallet_AddKnownContract_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddKnownContractRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerWalletServer).AddKnownContract(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerWallet_AddKnownContract_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerWalletServer).AddKnownContract(ctx, req.(*AddKnownContractRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerWallet_RemoveKnownContract_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveKnownContractRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerWalletServer).RemoveKnownContract(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerWallet_RemoveKnownContract_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerWalletServer).RemoveKnownContract(ctx, req.(*RemoveKnownContractRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerWallet_ListKnownContracts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListKnownContractsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerWalletServer).ListKnownContracts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerWallet_ListKnownContracts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerWalletServer).ListKnownContracts(ctx, req.(*ListKnownContractsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerWallet_ImportWallets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportWalletsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerWalletServer).ImportWallets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerWallet_ImportWallets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerWalletServer).ImportWallets(ctx, req.(*ImportWalletsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerWallet_GetImportJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetImportJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerWalletServer).GetImportJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerWallet_GetImportJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerWalletServer).GetImportJob(ctx, req.(*GetImportJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerWallet_GetWalletTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWalletTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerWalletServer).GetWalletTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerWallet_GetWalletTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerWalletServer).GetWalletTransactions(ctx, req.(*GetWalletTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerWallet_MarkTokenSafe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkTokenSafeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerWalletServer).MarkTokenSafe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerWallet_MarkTokenSafe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerWalletServer).MarkTokenSafe(ctx, req.(*MarkTokenSafeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerWallet_RebalanceWalletShards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RebalanceWalletShardsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerWalletServer).RebalanceWalletShards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerWallet_RebalanceWalletShards_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerWalletServer).RebalanceWalletShards(ctx, req.(*RebalanceWalletShardsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerWallet_GetApiUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetApiUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerWalletServer).GetApiUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerWallet_GetApiUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerWalletServer).GetApiUsage(ctx, req.(*GetApiUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ScannerWallet_ServiceDesc is the grpc.ServiceDesc for ScannerWallet service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ScannerWallet_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "scanner_wallet.ScannerWallet",
+	HandlerType: (*ScannerWalletServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "addWallet",
+			Handler:    _ScannerWallet_AddWallet_Handler,
+		},
+		{
+			MethodName: "getWallet",
+			Handler:    _ScannerWallet_GetWallet_Handler,
+		},
+		{
+			MethodName: "getWalletTokens",
+			Handler:    _ScannerWallet_GetWalletTokens_Handler,
+		},
+		{
+			MethodName: "getWalletDetails",
+			Handler:    _ScannerWallet_GetWalletDetails_Handler,
+		},
+		{
+			MethodName: "updateWalletPortfolio",
+			Handler:    _ScannerWallet_UpdateWalletPortfolio_Handler,
+		},
+		{
+			MethodName: "watchTokenHolders",
+			Handler:    _ScannerWallet_WatchTokenHolders_Handler,
+		},
+		{
+			MethodName: "getHolderFlows",
+			Handler:    _ScannerWallet_GetHolderFlows_Handler,
+		},
+		{
+			MethodName: "setWalletLabel",
+			Handler:    _ScannerWallet_SetWalletLabel_Handler,
+		},
+		{
+			MethodName: "listWalletsByTag",
+			Handler:    _ScannerWallet_ListWalletsByTag_Handler,
+		},
+		{
+			MethodName: "getPortfolioHistory",
+			Handler:    _ScannerWallet_GetPortfolioHistory_Handler,
+		},
+		{
+			MethodName: "getWalletApprovals",
+			Handler:    _ScannerWallet_GetWalletApprovals_Handler,
+		},
+		{
+			MethodName: "getAggregatedPortfolio",
+			Handler:    _ScannerWallet_GetAggregatedPortfolio_Handler,
+		},
+		{
+			MethodName: "getWalletLeaderboard",
+			Handler:    _ScannerWallet_GetWalletLeaderboard_Handler,
+		},
+		{
+			MethodName: "getDailyLeaderboard",
+			Handler:    _ScannerWallet_GetDailyLeaderboard_Handler,
+		},
+		{
+			MethodName: "setWalletLeaderboardOptIn",
+			Handler:    _ScannerWallet_SetWalletLeaderboardOptIn_Handler,
+		},
+		{
+			MethodName: "setWalletWatchFilter",
+			Handler:    _ScannerWallet_SetWalletWatchFilter_Handler,
+		},
+		{
+			MethodName: "setWalletWebhook",
+			Handler:    _ScannerWallet_SetWalletWebhook_Handler,
+		},
+		{
+			MethodName: "setWalletAlerts",
+			Handler:    _ScannerWallet_SetWalletAlerts_Handler,
+		},
+		{
+			MethodName: "addKnownContract",
+			Handler:    _ScannerWallet_AddKnownContract_Handler,
+		},
+		{
+			MethodName: "removeKnownContract",
+			Handler:    _ScannerWallet_RemoveKnownContract_Handler,
+		},
+		{
+			MethodName: "listKnownContracts",
+			Handler:    _ScannerWallet_ListKnownContracts_Handler,
+		},
+		{
+			MethodName: "importWallets",
+			Handler:    _ScannerWallet_ImportWallets_Handler,
+		},
+		{
+			MethodName: "getImportJob",
+			Handler:    _ScannerWallet_GetImportJob_Handler,
+		},
+		{
+			MethodName: "getWalletTransactions",
+			Handler:    _ScannerWallet_GetWalletTransactions_Handler,
+		},
+		{
+			MethodName: "markTokenSafe",
+			Handler:    _ScannerWallet_MarkTokenSafe_Handler,
+		},
+		{
+			MethodName: "rebalanceWalletShards",
+			Handler:    _ScannerWallet_RebalanceWalletShards_Handler,
+		},
+		{
+			MethodName: "getApiUsage",
+			Handler:    _ScannerWallet_GetApiUsage_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "streamWalletTrades",
+			Handler:       _ScannerWallet_StreamWalletTrades_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "streamWalletEvents",
+			Handler:       _ScannerWallet_StreamWalletEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "wallet/wallet.proto",
+}
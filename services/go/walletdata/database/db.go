@@ -2,9 +2,8 @@ package database
 
 import (
 	"context"
-	"log"
+	"samterminal/pkg/dbconn"
 	"sync"
-	"time"
 	db "walletdata/generated/prisma"
 )
 
@@ -13,28 +12,15 @@ var Client *db.PrismaClient
 var once sync.Once
 
 func CreateClient() {
-	Client = db.NewClient()
+	Client = db.NewClient(db.WithDatasourceURL(dbconn.DatasourceURL()))
 }
 
 func ConnectToDB() bool {
 	var result = false
 	once.Do(func() {
-		const maxAttempts = 10
-		for attempt := 1; attempt <= maxAttempts; attempt++ {
-			if err := Client.Prisma.Connect(); err != nil {
-				log.Printf("Database connect error (attempt %d/%d): %v", attempt, maxAttempts, err)
-				time.Sleep(time.Duration(attempt) * time.Second)
-				continue
-			}
-			ctx := context.Background()
+		result = dbconn.Connect(Client.Prisma.Connect, func(ctx context.Context) {
 			_, _ = Client.Wallet.FindMany().Take(0).Exec(ctx)
-			log.Println("Connected to Database")
-			result = true
-			break
-		}
-		if !result {
-			log.Fatal("Could not connect to Database after retries")
-		}
+		})
 	})
 	return result
 }
@@ -43,11 +29,7 @@ func DisconnectFromDB() {
 	if Client == nil || Client.Prisma == nil {
 		return
 	}
-	if err := Client.Prisma.Disconnect(); err != nil {
-		log.Printf("Database disconnect error: %v", err)
-		return
-	}
-	log.Println("Disconnected from Database")
+	dbconn.Disconnect(Client.Prisma.Disconnect)
 }
 
 func InitDatabase() {
@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"samterminal/pkg/chain"
+	"slices"
+	"strings"
+	"sync"
+	db "walletdata/generated/prisma"
+	wallet_proto "walletdata/proto/wallet"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type knownContractSeed struct {
+	address  string
+	name     string
+	category db.ContractCategory
+}
+
+// defaultKnownContracts are the well-known contracts of a chain seeded on startup; those the
+// chain has no address for are left out. CEX deposit addresses and lockers change too often to
+// configure and are added through the admin RPCs.
+func defaultKnownContracts(c chain.Config) []knownContractSeed {
+	seeds := []knownContractSeed{
+		{c.UniversalRouter, "Uniswap Universal Router", db.ContractCategoryRouter},
+		{c.SwapRouter02, "Uniswap V3 SwapRouter02", db.ContractCategoryRouter},
+		{c.V2Router, "Uniswap V2 Router", db.ContractCategoryRouter},
+		{c.PoolManager, "Uniswap V4 PoolManager", db.ContractCategoryRouter},
+		{c.AerodromeRouter, "Aerodrome Router", db.ContractCategoryRouter},
+		{c.Permit2, "Permit2", db.ContractCategoryOther},
+		{c.StandardBridge, "L2 Standard Bridge", db.ContractCategoryBridge},
+	}
+	return slices.DeleteFunc(seeds, func(seed knownContractSeed) bool { return seed.address == "" })
+}
+
+var (
+	ErrInvalidContractAddress  = errors.New("invalid contract address")
+	ErrInvalidContractCategory = errors.New("contract category is required")
+)
+
+var (
+	knownContractsMu    sync.RWMutex
+	knownContractsCache map[string]db.KnownContractModel
+)
+
+func invalidateKnownContracts() {
+	knownContractsMu.Lock()
+	knownContractsCache = nil
+	knownContractsMu.Unlock()
+}
+
+// knownContracts returns the address book keyed by lowercased address, loading it on first use.
+func knownContracts() map[string]db.KnownContractModel {
+	knownContractsMu.RLock()
+	cache := knownContractsCache
+	knownContractsMu.RUnlock()
+	if cache != nil {
+		return cache
+	}
+
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	tx := getDB()
+	contracts, err := tx.KnownContract.FindMany().Exec(ctx)
+	if err != nil {
+		log.Println("Error getting known contracts:", err)
+		return map[string]db.KnownContractModel{}
+	}
+	cache = map[string]db.KnownContractModel{}
+	for _, contract := range contracts {
+		cache[contract.Address] = contract
+	}
+	knownContractsMu.Lock()
+	knownContractsCache = cache
+	knownContractsMu.Unlock()
+	return cache
+}
+
+// LookupKnownContract returns the address book entry for an address, if any.
+func LookupKnownContract(address string) (db.KnownContractModel, bool) {
+	contract, ok := knownContracts()[strings.ToLower(address)]
+	return contract, ok
+}
+
+// IsKnownContract reports whether an address is a router, locker, bridge or other non-holder.
+func IsKnownContract(address string) bool {
+	_, ok := LookupKnownContract(address)
+	return ok
+}
+
+// SeedKnownContracts adds the default contracts without overwriting admin edits.
+func SeedKnownContracts() {
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	tx := getDB()
+	for _, contract := range defaultKnownContracts(chain.Get()) {
+		_, err := tx.KnownContract.UpsertOne(
+			db.KnownContract.Address.Equals(contract.address),
+		).Create(
+			db.KnownContract.Address.Set(contract.address),
+			db.KnownContract.Name.Set(contract.name),
+			db.KnownContract.Category.Set(contract.category),
+		).Update().Exec(ctx)
+		if err != nil {
+			log.Println("Error seeding known contract", contract.address, ":", err)
+		}
+	}
+	invalidateKnownContracts()
+}
+
+// AddKnownContract adds or renames an address book entry.
+func AddKnownContract(ctx context.Context, address string, name string, category wallet_proto.ContractCategory) (*wallet_proto.KnownContract, error) {
+	ctx, cancel := getCtx(ctx)
+	defer cancel()
+	tx := getDB()
+
+	if !common.IsHexAddress(address) {
+		return nil, ErrInvalidContractAddress
+	}
+	if category == wallet_proto.ContractCategory_CONTRACT_CATEGORY_UNSPECIFIED {
+		return nil, ErrInvalidContractCategory
+	}
+	address = strings.ToLower(address)
+	dbCategory := db.ContractCategory(category.String())
+	contract, err := tx.KnownContract.UpsertOne(
+		db.KnownContract.Address.Equals(address),
+	).Create(
+		db.KnownContract.Address.Set(address),
+		db.KnownContract.Name.Set(strings.TrimSpace(name)),
+		db.KnownContract.Category.Set(dbCategory),
+	).Update(
+		db.KnownContract.Name.Set(strings.TrimSpace(name)),
+		db.KnownContract.Category.Set(dbCategory),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	invalidateKnownContracts()
+	return knownContractToProto(contract), nil
+}
+
+func RemoveKnownContract(ctx context.Context, address string) error {
+	ctx, cancel := getCtx(ctx)
+	defer cancel()
+	tx := getDB()
+
+	_, err := tx.KnownContract.FindUnique(
+		db.KnownContract.Address.Equals(strings.ToLower(address)),
+	).Delete().Exec(ctx)
+	if err != nil {
+		return err
+	}
+	invalidateKnownContracts()
+	return nil
+}
+
+// ListKnownContracts returns the address book, optionally limited to one category. An unspecified
+// category lists all of them.
+func ListKnownContracts(ctx context.Context, category *wallet_proto.ContractCategory) ([]*wallet_proto.KnownContract, error) {
+	ctx, cancel := getCtx(ctx)
+	defer cancel()
+	tx := getDB()
+
+	filters := []db.KnownContractWhereParam{}
+	if category != nil && *category != wallet_proto.ContractCategory_CONTRACT_CATEGORY_UNSPECIFIED {
+		filters = append(filters, db.KnownContract.Category.Equals(db.ContractCategory(category.String())))
+	}
+	contracts, err := tx.KnownContract.FindMany(filters...).OrderBy(
+		db.KnownContract.Name.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response := []*wallet_proto.KnownContract{}
+	for _, contract := range contracts {
+		response = append(response, knownContractToProto(&contract))
+	}
+	return response, nil
+}
+
+func knownContractToProto(contract *db.KnownContractModel) *wallet_proto.KnownContract {
+	return &wallet_proto.KnownContract{
+		Address:  contract.Address,
+		Name:     contract.Name,
+		Category: wallet_proto.ContractCategory(wallet_proto.ContractCategory_value[string(contract.Category)]),
+	}
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"samterminal/pkg/chain"
+	"testing"
+)
+
+func TestDefaultKnownContracts(t *testing.T) {
+	seeds := defaultKnownContracts(chain.Config{ID: 84532, PoolManager: "0x05e73354cfdd6745c338b50bcfdfa3aa6fa03408"})
+	if len(seeds) != 1 || seeds[0].address != "0x05e73354cfdd6745c338b50bcfdfa3aa6fa03408" || seeds[0].name != "Uniswap V4 PoolManager" {
+		t.Errorf("seeds = %+v, want only the configured PoolManager", seeds)
+	}
+}
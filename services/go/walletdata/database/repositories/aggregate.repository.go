@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"samterminal/pkg/numeric"
+	"slices"
+	"strings"
+	"sync"
+	"walletdata/lib/api"
+	"walletdata/proto/common"
+	wallet_proto "walletdata/proto/wallet"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+)
+
+// maxAggregatedWallets caps how many wallets one portfolio can merge; each costs a Moralis call.
+const maxAggregatedWallets = 20
+
+var ErrInvalidPortfolioWallets = errors.New("between 1 and 20 valid wallet addresses are required")
+
+type aggregatedToken struct {
+	token    *wallet_proto.AggregatedToken
+	balance  decimal.Decimal
+	usdValue decimal.Decimal
+	price    decimal.Decimal
+}
+
+// normalizeWalletAddresses lowercases and dedupes wallet addresses, rejecting invalid ones.
+func normalizeWalletAddresses(walletAddresses []string) ([]string, error) {
+	normalized := []string{}
+	for _, walletAddress := range walletAddresses {
+		walletAddress = strings.ToLower(strings.TrimSpace(walletAddress))
+		if !gethcommon.IsHexAddress(walletAddress) {
+			return nil, ErrInvalidPortfolioWallets
+		}
+		if !slices.Contains(normalized, walletAddress) {
+			normalized = append(normalized, walletAddress)
+		}
+	}
+	if len(normalized) == 0 || len(normalized) > maxAggregatedWallets {
+		return nil, ErrInvalidPortfolioWallets
+	}
+	return normalized, nil
+}
+
+// GetAggregatedPortfolio merges the holdings of several wallets into one portfolio. Tokens held by
+// more than one wallet are listed once with the balance of each wallet. Values use tokendata
+// prices, falling back to the indexer price for tokens tokendata does not know yet.
+func GetAggregatedPortfolio(ctx context.Context, walletAddresses []string) (*wallet_proto.GetAggregatedPortfolioResponse, error) {
+	walletAddresses, err := normalizeWalletAddresses(walletAddresses)
+	if err != nil {
+		return nil, err
+	}
+
+	holdings := make([][]*common.WalletToken, len(walletAddresses))
+	failed := make([]bool, len(walletAddresses))
+	var wg sync.WaitGroup
+	for i, walletAddress := range walletAddresses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tokens, err := api.GetWalletTokens(walletAddress, true)
+			if err != nil {
+				log.Println("Error getting holdings of", walletAddress, ":", err)
+				failed[i] = true
+				return
+			}
+			holdings[i] = tokens
+		}()
+	}
+	wg.Wait()
+
+	tokenAddresses := []string{}
+	for _, tokens := range holdings {
+		for _, token := range tokens {
+			tokenAddress := strings.ToLower(token.TokenAddress)
+			if !slices.Contains(tokenAddresses, tokenAddress) {
+				tokenAddresses = append(tokenAddresses, tokenAddress)
+			}
+		}
+	}
+	prices := currentPrices(ctx, tokenAddresses)
+
+	response := &wallet_proto.GetAggregatedPortfolioResponse{
+		WalletAddresses: walletAddresses,
+		Tokens:          []*wallet_proto.AggregatedToken{},
+		FailedWallets:   []string{},
+	}
+	merged := map[string]*aggregatedToken{}
+	order := []string{}
+	totalDollarValue := decimal.Zero
+	for w, tokens := range holdings {
+		if failed[w] {
+			response.FailedWallets = append(response.FailedWallets, walletAddresses[w])
+			continue
+		}
+		for _, holding := range tokens {
+			tokenAddress := strings.ToLower(holding.TokenAddress)
+			balance, err := numeric.Parse(holding.TokenBalanceFormatted)
+			if err != nil {
+				continue
+			}
+			entry, exists := merged[tokenAddress]
+			if !exists {
+				price, ok := prices[tokenAddress]
+				if !ok {
+					price = numeric.ParseOrZero(holding.TokenPrice)
+				}
+				entry = &aggregatedToken{
+					token: &wallet_proto.AggregatedToken{
+						TokenAddress: tokenAddress,
+						Name:         holding.TokenName,
+						Symbol:       holding.TokenSymbol,
+						Image:        holding.TokenImage,
+						Price:        numeric.FormatPrice(price),
+						Wallets:      []*wallet_proto.WalletHolding{},
+					},
+					price: price,
+				}
+				merged[tokenAddress] = entry
+				order = append(order, tokenAddress)
+			}
+			usdValue := balance.Mul(entry.price)
+			entry.balance = entry.balance.Add(balance)
+			entry.usdValue = entry.usdValue.Add(usdValue)
+			totalDollarValue = totalDollarValue.Add(usdValue)
+			entry.token.Wallets = append(entry.token.Wallets, &wallet_proto.WalletHolding{
+				WalletAddress: walletAddresses[w],
+				Balance:       holding.TokenBalanceFormatted,
+				UsdValue:      numeric.FormatUSD(usdValue),
+			})
+		}
+	}
+
+	slices.SortStableFunc(order, func(a, b string) int {
+		return merged[b].usdValue.Cmp(merged[a].usdValue)
+	})
+	for _, tokenAddress := range order {
+		entry := merged[tokenAddress]
+		entry.token.Balance = entry.balance.String()
+		entry.token.UsdValue = numeric.FormatUSD(entry.usdValue)
+		response.Tokens = append(response.Tokens, entry.token)
+	}
+	response.TotalDollarValue = numeric.FormatUSD(totalDollarValue)
+	return response, nil
+}
@@ -0,0 +1,254 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+	db "walletdata/generated/prisma"
+	"walletdata/lib/events"
+	"walletdata/lib/trades"
+	"walletdata/lib/webhook"
+	wallet_proto "walletdata/proto/wallet"
+	"walletdata/rpc"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+)
+
+var ErrInvalidAlerts = errors.New("invalid alert rules")
+
+// defaultAlertWindow is the window of value alerts that do not set their own.
+const defaultAlertWindow = time.Hour
+
+// AlertRules are the alert rules of a wallet. Zero thresholds turn a rule off.
+type AlertRules struct {
+	// ValueChangePct is in percent, TransferMinUsd in USD.
+	ValueChangePct decimal.Decimal
+	Window         time.Duration
+	TransferMinUsd decimal.Decimal
+}
+
+func alertRulesOf(wallet *db.WalletModel) AlertRules {
+	rules := AlertRules{Window: defaultAlertWindow}
+	if value, ok := wallet.AlertValueChangePct(); ok {
+		rules.ValueChangePct, _ = decimal.NewFromString(value)
+	}
+	if minutes, ok := wallet.AlertWindowMinutes(); ok && minutes > 0 {
+		rules.Window = time.Duration(minutes) * time.Minute
+	}
+	if value, ok := wallet.AlertTransferMinUsd(); ok {
+		rules.TransferMinUsd, _ = decimal.NewFromString(value)
+	}
+	return rules
+}
+
+// valueChanged reports whether the value moved from previous to current by at least the change
+// of the rules, and by how many percent. Moves from zero are not measured.
+func (r AlertRules) valueChanged(previous decimal.Decimal, current decimal.Decimal) (decimal.Decimal, bool) {
+	if !r.ValueChangePct.IsPositive() || !previous.IsPositive() {
+		return decimal.Zero, false
+	}
+	change := current.Sub(previous).Div(previous).Mul(decimal.NewFromInt(100))
+	return change, change.Abs().GreaterThanOrEqual(r.ValueChangePct)
+}
+
+// transferValues sums the USD value a transaction moved into and out of a wallet, native value
+// and token transfers included. valueUsd prices a transfer.
+func transferValues(walletAddress string, event rpc.WalletTransaction, valueUsd func(transfer rpc.TokenTransfer) decimal.Decimal) (incoming decimal.Decimal, outgoing decimal.Decimal) {
+	if event.ValueWei != nil && event.ValueWei.Sign() > 0 && event.Direction != rpc.DirectionSelf {
+		value, err := decimal.NewFromString(trades.ValueTokenAmount(trades.NativeToken(), new(big.Int).Set(event.ValueWei)).USDValue)
+		if err == nil {
+			if event.Direction == rpc.DirectionOutgoing {
+				outgoing = outgoing.Add(value)
+			} else {
+				incoming = incoming.Add(value)
+			}
+		}
+	}
+	wallet := common.HexToAddress(walletAddress)
+	for _, transfer := range event.TokenTransfers {
+		if transfer.From == transfer.To {
+			continue
+		}
+		if transfer.From == wallet {
+			outgoing = outgoing.Add(valueUsd(transfer))
+		} else if transfer.To == wallet {
+			incoming = incoming.Add(valueUsd(transfer))
+		}
+	}
+	return incoming, outgoing
+}
+
+// alertCooldownSweep is how often the cooldowns that ended are dropped.
+const alertCooldownSweep = time.Hour
+
+// alertCooldowns holds until when the value alerts of a wallet are quiet after one fired, so a
+// move fires once per window rather than on every update within it.
+var alertCooldowns = struct {
+	sync.Mutex
+	until     map[string]time.Time
+	lastSweep time.Time
+}{until: map[string]time.Time{}}
+
+// claimValueAlert reports whether a value alert of a wallet may fire now and, if so, starts its
+// cooldown.
+func claimValueAlert(walletAddress string, now time.Time, window time.Duration) bool {
+	alertCooldowns.Lock()
+	defer alertCooldowns.Unlock()
+	if now.Sub(alertCooldowns.lastSweep) >= alertCooldownSweep {
+		for address, until := range alertCooldowns.until {
+			if !now.Before(until) {
+				delete(alertCooldowns.until, address)
+			}
+		}
+		alertCooldowns.lastSweep = now
+	}
+	if until, ok := alertCooldowns.until[walletAddress]; ok && now.Before(until) {
+		return false
+	}
+	alertCooldowns.until[walletAddress] = now.Add(window)
+	return true
+}
+
+// checkValueAlert fires a value alert when the new value of a wallet moved past its threshold
+// since the oldest snapshot within the window. wallet is the wallet before the update, nil when it
+// could not be read.
+func checkValueAlert(wallet *db.WalletModel, dollarValue string) {
+	if wallet == nil {
+		return
+	}
+	rules := alertRulesOf(wallet)
+	if !rules.ValueChangePct.IsPositive() {
+		return
+	}
+	current, err := decimal.NewFromString(dollarValue)
+	if err != nil {
+		return
+	}
+	now := time.Now()
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	snapshot, err := walletStore.FirstPortfolioSnapshotSince(ctx, wallet.Address, now.Add(-rules.Window))
+	if err != nil {
+		if !errors.Is(err, db.ErrNotFound) {
+			log.Println("Error getting portfolio snapshot of", wallet.Address, ":", err)
+		}
+		return
+	}
+	previous := snapshot.ValueUsd
+	change, ok := rules.valueChanged(previous, current)
+	if !ok || !claimValueAlert(wallet.Address, now, rules.Window) {
+		return
+	}
+	sendAlert(wallet, &wallet_proto.WalletAlert{
+		WalletAddress:    wallet.Address,
+		Type:             wallet_proto.WalletAlertType_ALERT_VALUE_CHANGE,
+		PreviousValueUsd: previous.StringFixed(2),
+		ValueUsd:         current.StringFixed(2),
+		ChangePct:        change.StringFixed(2),
+		WindowMinutes:    int32(rules.Window / time.Minute),
+		Timestamp:        now.Unix(),
+	})
+}
+
+// checkTransferAlert fires a transfer alert for each direction in which a transaction of a
+// wallet moved at least its threshold. wallet is nil when it could not be read.
+func checkTransferAlert(wallet *db.WalletModel, event rpc.WalletTransaction) {
+	if wallet == nil {
+		return
+	}
+	rules := alertRulesOf(wallet)
+	if !rules.TransferMinUsd.IsPositive() {
+		return
+	}
+	incoming, outgoing := transferValues(wallet.Address, event, transferValueUsd)
+	moved := []struct {
+		direction rpc.TransactionDirection
+		value     decimal.Decimal
+	}{{rpc.DirectionIncoming, incoming}, {rpc.DirectionOutgoing, outgoing}}
+	for _, m := range moved {
+		if m.value.LessThan(rules.TransferMinUsd) {
+			continue
+		}
+		sendAlert(wallet, &wallet_proto.WalletAlert{
+			WalletAddress: wallet.Address,
+			Type:          wallet_proto.WalletAlertType_ALERT_TRANSFER,
+			TxHash:        event.Hash.Hex(),
+			Direction:     string(m.direction),
+			TransferUsd:   m.value.StringFixed(2),
+			Timestamp:     time.Now().Unix(),
+		})
+	}
+}
+
+// alertPayload is the data of an alert event.
+type alertPayload struct {
+	Type             string `json:"type"`
+	PreviousValueUsd string `json:"previousValueUsd,omitempty"`
+	ValueUsd         string `json:"valueUsd,omitempty"`
+	ChangePct        string `json:"changePct,omitempty"`
+	WindowMinutes    int32  `json:"windowMinutes,omitempty"`
+	TxHash           string `json:"txHash,omitempty"`
+	Direction        string `json:"direction,omitempty"`
+	TransferUsd      string `json:"transferUsd,omitempty"`
+}
+
+// sendAlert publishes an alert to wallet event subscribers and to the webhook of the wallet.
+func sendAlert(wallet *db.WalletModel, alert *wallet_proto.WalletAlert) {
+	if label, ok := wallet.Label(); ok {
+		alert.WalletLabel = label
+	}
+	events.PublishWalletAlert(alert)
+
+	hook, ok := webhookOf(wallet)
+	if !ok || !hook.subscribes(webhook.EventAlert) {
+		return
+	}
+	webhook.Send(hook.URL, hook.Secret, wallet.Address, webhook.EventAlert, alertPayload{
+		Type:             strings.ToLower(strings.TrimPrefix(alert.Type.String(), "ALERT_")),
+		PreviousValueUsd: alert.PreviousValueUsd,
+		ValueUsd:         alert.ValueUsd,
+		ChangePct:        alert.ChangePct,
+		WindowMinutes:    alert.WindowMinutes,
+		TxHash:           alert.TxHash,
+		Direction:        alert.Direction,
+		TransferUsd:      alert.TransferUsd,
+	})
+}
+
+// SetWalletAlerts replaces the alert rules of a tracked wallet. Thresholds are decimal strings,
+// empty to turn a rule off; a window of 0 uses the default window.
+func SetWalletAlerts(ctx context.Context, walletAddress string, valueChangePct string, windowMinutes int32, transferMinUsd string) error {
+	if windowMinutes < 0 {
+		return ErrInvalidAlerts
+	}
+	changePct, err := normalizeMinimum(valueChangePct)
+	if err != nil {
+		return ErrInvalidAlerts
+	}
+	minUsd, err := normalizeMinimum(transferMinUsd)
+	if err != nil {
+		return ErrInvalidAlerts
+	}
+	var window *int
+	if windowMinutes > 0 {
+		minutes := int(windowMinutes)
+		window = &minutes
+	}
+
+	ctx, cancel := getCtx(ctx)
+	defer cancel()
+	tx := getDB()
+	_, err = tx.Wallet.FindUnique(
+		db.Wallet.Address.Equals(strings.ToLower(walletAddress)),
+	).Update(
+		db.Wallet.AlertValueChangePct.SetOptional(changePct),
+		db.Wallet.AlertWindowMinutes.SetOptional(window),
+		db.Wallet.AlertTransferMinUsd.SetOptional(minUsd),
+	).Exec(ctx)
+	return err
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"math/big"
+	"testing"
+	"time"
+	"walletdata/database/store/mock"
+	"walletdata/lib/events"
+	wallet_proto "walletdata/proto/wallet"
+	"walletdata/rpc"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+)
+
+func TestAlertRulesValueChanged(t *testing.T) {
+	rules := AlertRules{ValueChangePct: decimal.NewFromInt(10)}
+	tests := []struct {
+		previous, current string
+		want              bool
+	}{
+		{"100", "109", false},
+		{"100", "110", true},
+		{"100", "85", true},
+		{"0", "1000", false},
+	}
+	for _, test := range tests {
+		if _, got := rules.valueChanged(decimal.RequireFromString(test.previous), decimal.RequireFromString(test.current)); got != test.want {
+			t.Errorf("valueChanged(%s, %s) = %v, want %v", test.previous, test.current, got, test.want)
+		}
+	}
+}
+
+func TestTransferValues(t *testing.T) {
+	wallet := common.HexToAddress(testWallet)
+	other := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	event := rpc.WalletTransaction{TokenTransfers: []rpc.TokenTransfer{
+		{From: other, To: wallet, Amount: big.NewInt(300)},
+		{From: wallet, To: other, Amount: big.NewInt(50)},
+		{From: other, To: wallet, Amount: big.NewInt(20)},
+	}}
+	incoming, outgoing := transferValues(testWallet, event, func(transfer rpc.TokenTransfer) decimal.Decimal {
+		return decimal.NewFromBigInt(transfer.Amount, 0)
+	})
+	if !incoming.Equal(decimal.NewFromInt(320)) || !outgoing.Equal(decimal.NewFromInt(50)) {
+		t.Errorf("incoming, outgoing = %s, %s, want 320, 50", incoming, outgoing)
+	}
+}
+
+func TestCheckValueAlert(t *testing.T) {
+	wallet := mock.NewWallet(testWallet)
+	pct, window := "10", 30
+	wallet.InnerWallet.AlertValueChangePct, wallet.InnerWallet.AlertWindowMinutes = &pct, &window
+	defer SetWalletStore(mock.NewWalletStore(wallet))()
+	if err := savePortfolioSnapshot(testWallet, "100", nil); err != nil {
+		t.Fatal(err)
+	}
+	alerts, unsubscribe := events.Subscribe()
+	defer unsubscribe()
+
+	checkValueAlert(&wallet, "105")
+	checkValueAlert(&wallet, "150")
+	checkValueAlert(&wallet, "160")
+	select {
+	case event := <-alerts:
+		alert := event.GetAlert()
+		if alert.GetType() != wallet_proto.WalletAlertType_ALERT_VALUE_CHANGE || alert.ChangePct != "50.00" || alert.WindowMinutes != 30 {
+			t.Errorf("alert = %+v", alert)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no alert for a 50% move")
+	}
+	select {
+	case event := <-alerts:
+		t.Errorf("alert within the cooldown: %+v", event)
+	default:
+	}
+}
+
+func TestClaimValueAlertSweep(t *testing.T) {
+	now := time.Now()
+	claimValueAlert("0xa", now, time.Minute)
+	claimValueAlert("0xb", now.Add(2*time.Hour), time.Minute)
+	if _, ok := alertCooldowns.until["0xa"]; ok {
+		t.Errorf("cooldowns = %+v, want the ended cooldown of 0xa swept", alertCooldowns.until)
+	}
+	if claimValueAlert("0xb", now.Add(2*time.Hour+30*time.Second), time.Minute) {
+		t.Error("alert claimed within the cooldown")
+	}
+}
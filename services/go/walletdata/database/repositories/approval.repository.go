@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"math/big"
+	"strings"
+	"time"
+	"walletdata/lib/api"
+	"walletdata/lib/trades"
+	wallet_proto "walletdata/proto/wallet"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// unlimitedAllowance is where an allowance counts as unlimited. It covers type(uint256).max as
+// well as what is left of it on tokens that decrement infinite approvals when spent.
+var unlimitedAllowance = new(big.Int).Lsh(big.NewInt(1), 255)
+
+// GetWalletApprovals lists the active ERC-20 allowances of a wallet with the USD value each
+// spender could move, and flags unlimited approvals to spenders outside the address book.
+func GetWalletApprovals(walletAddress string) (*wallet_proto.GetWalletApprovalsResponse, error) {
+	walletAddress = strings.ToLower(walletAddress)
+	approvals, err := api.GetWalletApprovals(walletAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &wallet_proto.GetWalletApprovalsResponse{
+		WalletAddress: walletAddress,
+		Approvals:     []*wallet_proto.WalletApproval{},
+	}
+	totalAtRisk := new(big.Float)
+	for _, approval := range approvals {
+		allowance, ok := new(big.Int).SetString(approval.Value, 10)
+		if !ok || allowance.Sign() == 0 || !common.IsHexAddress(approval.Token.Address) {
+			continue
+		}
+		token := common.HexToAddress(approval.Token.Address)
+
+		// The spender can move at most the wallet balance.
+		atRisk := allowance
+		if balance, ok := new(big.Int).SetString(approval.Token.CurrentBalance, 10); ok && balance.Cmp(allowance) < 0 {
+			atRisk = balance
+		}
+		value := trades.ValueTokenAmount(token, atRisk)
+		if usdValue, ok := new(big.Float).SetString(value.USDValue); ok {
+			totalAtRisk.Add(totalAtRisk, usdValue)
+		}
+
+		entry := &wallet_proto.WalletApproval{
+			TokenAddress:   strings.ToLower(token.Hex()),
+			TokenSymbol:    approval.Token.Symbol,
+			TokenName:      approval.Token.Name,
+			Spender:        strings.ToLower(approval.Spender.Address),
+			SpenderLabel:   approval.Spender.AddressLabel,
+			Unlimited:      allowance.Cmp(unlimitedAllowance) >= 0,
+			UsdValueAtRisk: value.USDValue,
+			TxHash:         approval.TransactionHash,
+		}
+		if contract, ok := LookupKnownContract(approval.Spender.Address); ok {
+			entry.KnownSpender = true
+			entry.SpenderLabel = contract.Name
+		}
+		switch {
+		case entry.Unlimited:
+			entry.Allowance = "unlimited"
+		case atRisk == allowance:
+			entry.Allowance = value.Amount
+		default:
+			entry.Allowance = trades.ValueTokenAmount(token, allowance).Amount
+		}
+		if timestamp, err := time.Parse(time.RFC3339, approval.BlockTimestamp); err == nil {
+			entry.Timestamp = timestamp.Unix()
+		}
+		entry.Flagged = entry.Unlimited && !entry.KnownSpender
+		if entry.Flagged {
+			response.FlaggedCount++
+		}
+		response.Approvals = append(response.Approvals, entry)
+	}
+	response.TotalUsdValueAtRisk = totalAtRisk.Text('f', 2)
+	return response, nil
+}
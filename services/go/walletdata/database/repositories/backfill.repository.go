@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"log"
+	"math/big"
+	"samterminal/pkg/numeric"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"walletdata/lib/api"
+	api_dto "walletdata/lib/api/dto"
+	"walletdata/lib/trades"
+	wallet_proto "walletdata/proto/wallet"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+)
+
+// backfillQueueSize is how many added wallets may wait for their backfill. Wallets added while the
+// queue is full are not backfilled.
+const backfillQueueSize = 100
+
+var (
+	backfillOnce  sync.Once
+	backfillQueue chan string
+)
+
+// QueueWalletBackfill schedules the trade history of a wallet to be read from the chain.
+// Backfills run one at a time to stay within the Etherscan rate limit.
+func QueueWalletBackfill(walletAddress string) {
+	backfillOnce.Do(func() {
+		backfillQueue = make(chan string, backfillQueueSize)
+		go func() {
+			for walletAddress := range backfillQueue {
+				count, err := BackfillWalletTrades(walletAddress)
+				if err != nil {
+					log.Println("Error backfilling trades of", walletAddress, ":", err)
+					continue
+				}
+				log.Println("Backfilled", count, "trades of", walletAddress)
+			}
+		}()
+	})
+	select {
+	case backfillQueue <- strings.ToLower(walletAddress):
+	default:
+		log.Println("Backfill queue is full, skipping", walletAddress)
+	}
+}
+
+// BackfillWalletTrades reconstructs the trades a wallet made before it was watched from its
+// Etherscan history and records them for PnL, returning how many it found. Trades that were
+// already recorded are left as they are.
+func BackfillWalletTrades(walletAddress string) (int, error) {
+	walletAddress = strings.ToLower(walletAddress)
+	transfers, err := api.GetWalletTokenTransfers(walletAddress)
+	if err != nil {
+		return 0, err
+	}
+	transactions, err := api.GetWalletTransactions(walletAddress, false)
+	if err != nil {
+		return 0, err
+	}
+	internal, err := api.GetWalletTransactions(walletAddress, true)
+	if err != nil {
+		return 0, err
+	}
+
+	transactions = append(transactions, internal...)
+	prices := txPrices(getPricesAt(quoteQueries(transfers, transactions), 0))
+	history := historicalTrades(walletAddress, transfers, transactions, prices)
+	for _, trade := range history {
+		if err := SaveWalletTrade(trade); err != nil {
+			return 0, err
+		}
+	}
+	return len(history), nil
+}
+
+// quoteQueries lists the quote tokens a wallet moved and when, to price them at that time.
+// Transactions without a transfer to their hash cannot be trades and are left out.
+func quoteQueries(transfers []api_dto.TokenTransfer, transactions []api_dto.Transaction) []tokenAt {
+	queries := []tokenAt{}
+	timestamps := map[string]int64{}
+	for _, transfer := range transfers {
+		timestamp, _ := strconv.ParseInt(transfer.TimeStamp, 10, 64)
+		hash := strings.ToLower(transfer.Hash)
+		if _, ok := timestamps[hash]; !ok {
+			timestamps[hash] = timestamp
+		}
+		if slices.Contains(trades.QuoteTokens(), common.HexToAddress(transfer.ContractAddress)) {
+			queries = append(queries, tokenAt{token: strings.ToLower(transfer.ContractAddress), timestamp: timestamps[hash]})
+		}
+	}
+	nativeToken := strings.ToLower(trades.NativeToken().Hex())
+	for _, transaction := range transactions {
+		if timestamp, ok := timestamps[strings.ToLower(transaction.Hash)]; ok && transaction.Value != "0" {
+			queries = append(queries, tokenAt{token: nativeToken, timestamp: timestamp})
+		}
+	}
+	return queries
+}
+
+// historicalTrades turns the transfers of a wallet into trades. A transaction is a trade when the
+// wallet gained or lost exactly one token and paid or received quote tokens or ETH for it; the
+// quote side sets the price. Quote tokens are valued at their price at the time of the
+// transaction, and unpriced quote tokens leave the transaction out.
+func historicalTrades(walletAddress string, transfers []api_dto.TokenTransfer, transactions []api_dto.Transaction, quotePrices txPrices) []*wallet_proto.WalletTrade {
+	type txFlows struct {
+		timestamp int64
+		tokens    map[string]*big.Float
+		quoteUsd  decimal.Decimal
+		priced    bool
+	}
+	flows := map[string]*txFlows{}
+	order := []string{}
+	flowsOf := func(hash string, timeStamp string) *txFlows {
+		f, ok := flows[hash]
+		if !ok {
+			timestamp, _ := strconv.ParseInt(timeStamp, 10, 64)
+			f = &txFlows{timestamp: timestamp, tokens: map[string]*big.Float{}, priced: true}
+			flows[hash] = f
+			order = append(order, hash)
+		}
+		return f
+	}
+	addQuote := func(f *txFlows, token string, amount *big.Float) {
+		price, ok := quotePrices.at(token, f.timestamp)
+		if !ok {
+			f.priced = false
+			return
+		}
+		f.quoteUsd = f.quoteUsd.Add(numeric.FromBigFloat(new(big.Float).Abs(amount)).Mul(price))
+	}
+	nativeToken := strings.ToLower(trades.NativeToken().Hex())
+
+	for _, transfer := range transfers {
+		from, to := strings.EqualFold(transfer.From, walletAddress), strings.EqualFold(transfer.To, walletAddress)
+		raw, ok := new(big.Int).SetString(transfer.Value, 10)
+		if from == to || !ok {
+			continue
+		}
+		decimals, err := strconv.Atoi(transfer.TokenDecimal)
+		if err != nil {
+			continue
+		}
+		amount := new(big.Float).Quo(new(big.Float).SetInt(raw), new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)))
+		if from {
+			amount.Neg(amount)
+		}
+		f := flowsOf(strings.ToLower(transfer.Hash), transfer.TimeStamp)
+		token := strings.ToLower(transfer.ContractAddress)
+		if slices.Contains(trades.QuoteTokens(), common.HexToAddress(token)) {
+			addQuote(f, token, amount)
+			continue
+		}
+		if _, ok := f.tokens[token]; !ok {
+			f.tokens[token] = new(big.Float)
+		}
+		f.tokens[token].Add(f.tokens[token], amount)
+	}
+	for _, transaction := range transactions {
+		f, ok := flows[strings.ToLower(transaction.Hash)]
+		wei, valid := new(big.Int).SetString(transaction.Value, 10)
+		if !ok || !valid || wei.Sign() == 0 || transaction.IsError == "1" {
+			continue
+		}
+		addQuote(f, nativeToken, new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(1e18)))
+	}
+
+	history := []*wallet_proto.WalletTrade{}
+	for _, hash := range order {
+		f := flows[hash]
+		traded := []string{}
+		for token, amount := range f.tokens {
+			if amount.Sign() != 0 {
+				traded = append(traded, token)
+			}
+		}
+		if len(traded) != 1 || !f.priced || !f.quoteUsd.IsPositive() {
+			continue
+		}
+		token := traded[0]
+		side := wallet_proto.TradeSide_BUY
+		if f.tokens[token].Sign() < 0 {
+			side = wallet_proto.TradeSide_SELL
+		}
+		amount := numeric.FromBigFloat(new(big.Float).Abs(f.tokens[token]))
+		history = append(history, &wallet_proto.WalletTrade{
+			WalletAddress: walletAddress,
+			Side:          side,
+			TokenAddress:  token,
+			TokenAmount:   amount.String(),
+			PriceUsd:      numeric.FormatPrice(f.quoteUsd.Div(amount)),
+			UsdValue:      numeric.FormatUSD(f.quoteUsd),
+			TxHash:        hash,
+			Timestamp:     f.timestamp,
+		})
+	}
+	return history
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	api_dto "walletdata/lib/api/dto"
+	"walletdata/lib/trades"
+	wallet_proto "walletdata/proto/wallet"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestHistoricalTrades(t *testing.T) {
+	weth := strings.ToLower(trades.NativeToken().Hex())
+	const token = "0x2222222222222222222222222222222222222222"
+	const router = "0x3333333333333333333333333333333333333333"
+	transfers := []api_dto.TokenTransfer{
+		// Bought 1000 tokens with 0.5 WETH.
+		{Hash: "0xa", TimeStamp: "1700000000", From: testWallet, To: router, ContractAddress: weth, Value: "500000000000000000", TokenDecimal: "18"},
+		{Hash: "0xa", TimeStamp: "1700000000", From: router, To: testWallet, ContractAddress: token, Value: "1000000", TokenDecimal: "3"},
+		// Sold 400 tokens, paid out in ETH by the router.
+		{Hash: "0xb", TimeStamp: "1700000100", From: testWallet, To: router, ContractAddress: token, Value: "400000", TokenDecimal: "3"},
+		// Received tokens for nothing: not a trade.
+		{Hash: "0xc", TimeStamp: "1700000200", From: router, To: testWallet, ContractAddress: token, Value: "1000", TokenDecimal: "3"},
+	}
+	transactions := []api_dto.Transaction{
+		{Hash: "0xb", From: router, To: testWallet, Value: "250000000000000000"},
+	}
+
+	history := historicalTrades(testWallet, transfers, transactions, txPrices{{token: weth, timestamp: 1700000000}: decimal.NewFromInt(2000), {token: weth, timestamp: 1700000100}: decimal.NewFromInt(2000)})
+	if len(history) != 2 {
+		t.Fatalf("trades = %+v, want 2", history)
+	}
+	buy, sell := history[0], history[1]
+	if buy.Side != wallet_proto.TradeSide_BUY || buy.TokenAmount != "1000" || buy.UsdValue != "1000.00" || buy.PriceUsd != "1" || buy.Timestamp != 1700000000 {
+		t.Errorf("buy = %+v", buy)
+	}
+	if sell.Side != wallet_proto.TradeSide_SELL || sell.TokenAmount != "400" || sell.UsdValue != "500.00" || sell.PriceUsd != "1.25" {
+		t.Errorf("sell = %+v", sell)
+	}
+
+	if unpriced := historicalTrades(testWallet, transfers, transactions, txPrices{}); len(unpriced) != 0 {
+		t.Errorf("trades without quote prices = %+v, want none", unpriced)
+	}
+
+	// The buy happened when WETH was at 3000; no price was recorded shortly before the sell.
+	prices := txPrices{{token: weth, timestamp: 1700000000}: decimal.NewFromInt(3000)}
+	history = historicalTrades(testWallet, transfers, transactions, prices)
+	if len(history) != 1 || history[0].UsdValue != "1500.00" {
+		t.Errorf("trades = %+v, want only the buy at the price of its time", history)
+	}
+
+	queries := quoteQueries(transfers, append(transactions, api_dto.Transaction{Hash: "0xd", Value: "1"}))
+	want := []tokenAt{{token: weth, timestamp: 1700000000}, {token: weth, timestamp: 1700000100}}
+	if !slices.Equal(queries, want) {
+		t.Errorf("quote queries = %+v, want %+v", queries, want)
+	}
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"math/big"
+	"samterminal/pkg/numeric"
+	"strings"
+	"time"
+	db "walletdata/generated/prisma"
+	"walletdata/lib/events"
+	"walletdata/lib/trades"
+	wallet_proto "walletdata/proto/wallet"
+	"walletdata/rpc"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+)
+
+// flowType classifies a transfer with a known contract. Only bridges and exchanges are flows.
+func flowType(category db.ContractCategory, outgoing bool) (wallet_proto.WalletFlowType, bool) {
+	switch category {
+	case db.ContractCategoryBridge:
+		if outgoing {
+			return wallet_proto.WalletFlowType_BRIDGE_DEPOSIT, true
+		}
+		return wallet_proto.WalletFlowType_BRIDGE_WITHDRAWAL, true
+	case db.ContractCategoryCex:
+		if outgoing {
+			return wallet_proto.WalletFlowType_CEX_DEPOSIT, true
+		}
+		return wallet_proto.WalletFlowType_CEX_WITHDRAWAL, true
+	}
+	return 0, false
+}
+
+func newWalletFlow(walletAddress string, counterparty common.Address, outgoing bool, event rpc.WalletTransaction) *wallet_proto.WalletFlow {
+	contract, ok := LookupKnownContract(counterparty.Hex())
+	if !ok {
+		return nil
+	}
+	kind, ok := flowType(contract.Category, outgoing)
+	if !ok {
+		return nil
+	}
+	return &wallet_proto.WalletFlow{
+		WalletAddress:     strings.ToLower(walletAddress),
+		Type:              kind,
+		Counterparty:      contract.Address,
+		CounterpartyLabel: contract.Name,
+		TxHash:            event.Hash.Hex(),
+		Timestamp:         time.Now().Unix(),
+	}
+}
+
+// classifyWalletFlows finds the native and token transfers of a transaction that moved funds
+// between the wallet and a bridge or exchange in the address book.
+func classifyWalletFlows(walletAddress string, event rpc.WalletTransaction) []*wallet_proto.WalletFlow {
+	wallet := common.HexToAddress(walletAddress)
+	flows := []*wallet_proto.WalletFlow{}
+
+	if event.Counterparty != nil && event.ValueWei != nil && event.ValueWei.Sign() > 0 && event.Direction != rpc.DirectionSelf {
+		flow := newWalletFlow(walletAddress, *event.Counterparty, event.Direction == rpc.DirectionOutgoing, event)
+		if flow != nil {
+			value := trades.ValueTokenAmount(trades.NativeToken(), event.ValueWei)
+			flow.TokenSymbol = "ETH"
+			flow.Amount = value.Amount
+			flow.UsdValue = value.USDValue
+			flows = append(flows, flow)
+		}
+	}
+
+	for _, transfer := range event.TokenTransfers {
+		var flow *wallet_proto.WalletFlow
+		if transfer.From == wallet {
+			flow = newWalletFlow(walletAddress, transfer.To, true, event)
+		} else if transfer.To == wallet {
+			flow = newWalletFlow(walletAddress, transfer.From, false, event)
+		}
+		if flow == nil {
+			continue
+		}
+		value := trades.ValueTokenAmount(transfer.Token, new(big.Int).Set(transfer.Amount))
+		flow.TokenAddress = strings.ToLower(transfer.Token.Hex())
+		flow.TokenSymbol = value.Symbol
+		flow.Amount = value.Amount
+		flow.UsdValue = value.USDValue
+		flows = append(flows, flow)
+	}
+	return flows
+}
+
+func saveWalletFlow(flow *wallet_proto.WalletFlow) error {
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	tx := getDB()
+
+	usdValue := numeric.ParseOrZero(flow.UsdValue)
+	_, err := tx.WalletFlow.UpsertOne(
+		db.WalletFlow.TxHashWalletAddressTokenAddress(
+			db.WalletFlow.TxHash.Equals(flow.TxHash),
+			db.WalletFlow.WalletAddress.Equals(flow.WalletAddress),
+			db.WalletFlow.TokenAddress.Equals(flow.TokenAddress),
+		),
+	).Create(
+		db.WalletFlow.WalletAddress.Set(flow.WalletAddress),
+		db.WalletFlow.Type.Set(db.WalletFlowType(flow.Type.String())),
+		db.WalletFlow.Counterparty.Set(flow.Counterparty),
+		db.WalletFlow.TokenAddress.Set(flow.TokenAddress),
+		db.WalletFlow.Amount.Set(flow.Amount),
+		db.WalletFlow.UsdValue.Set(usdValue),
+		db.WalletFlow.TxHash.Set(flow.TxHash),
+	).Update().Exec(ctx)
+	return err
+}
+
+// exchangeDeposits24h sums what a wallet sent to exchanges over the last day.
+func exchangeDeposits24h(walletAddress string) (decimal.Decimal, int, error) {
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	tx := getDB()
+
+	flows, err := tx.WalletFlow.FindMany(
+		db.WalletFlow.WalletAddress.Equals(walletAddress),
+		db.WalletFlow.Type.Equals(db.WalletFlowTypeCexDeposit),
+		db.WalletFlow.CreatedAt.Gte(time.Now().Add(-24*time.Hour)),
+	).Exec(ctx)
+	if err != nil {
+		return decimal.Zero, 0, err
+	}
+	total := decimal.Zero
+	for _, flow := range flows {
+		total = total.Add(flow.UsdValue)
+	}
+	return total, len(flows), nil
+}
+
+// publishWalletFlows records bridge and exchange flows of a wallet transaction and sends them,
+// with the wallet's exchange deposits over the last day, to wallet event subscribers.
+func publishWalletFlows(walletAddress string, label string, event rpc.WalletTransaction) {
+	for _, flow := range classifyWalletFlows(walletAddress, event) {
+		flow.WalletLabel = label
+		if err := saveWalletFlow(flow); err != nil {
+			log.Println("Error saving wallet flow:", err)
+		}
+		total, count, err := exchangeDeposits24h(flow.WalletAddress)
+		if err != nil {
+			log.Println("Error getting exchange deposits:", err)
+		}
+		flow.MovedToExchangeUsd24H = numeric.FormatUSD(total)
+		flow.ExchangeDeposits24H = int32(count)
+		events.PublishWalletFlow(flow)
+	}
+}
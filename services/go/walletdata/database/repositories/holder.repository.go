@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"samterminal/pkg/numeric"
+	"slices"
+	"strings"
+	db "walletdata/generated/prisma"
+	"walletdata/lib/api"
+	token_client "walletdata/lib/grpc/client/token"
+	wallet_proto "walletdata/proto/wallet"
+	"walletdata/rpc"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	defaultHolderWatchLimit = 20
+	maxHolderWatchLimit     = 100
+)
+
+// WatchTokenHolders starts watching the top holders of a token. Their balance at the time they are
+// first watched is kept as the baseline used to report flows.
+func WatchTokenHolders(ctx context.Context, tokenAddress string, limit int32) ([]string, error) {
+	ctx, cancel := getCtx(ctx)
+	defer cancel()
+	tx := getDB()
+
+	tokenAddress = strings.ToLower(tokenAddress)
+	if limit <= 0 {
+		limit = defaultHolderWatchLimit
+	}
+	// Routers, lockers, bridges and CEX wallets are not real holders, so fetch enough extra holders
+	// to fill the limit after they are skipped.
+	holdersResponse, err := token_client.GetTokenHolders(context.Background(), tokenAddress, min(limit+int32(len(knownContracts())), maxHolderWatchLimit))
+	if err != nil {
+		return nil, err
+	}
+	if len(holdersResponse.Holders) == 0 {
+		return nil, errors.New("no holders found for token")
+	}
+
+	walletAddresses := []string{}
+	for _, holder := range holdersResponse.Holders {
+		walletAddress := strings.ToLower(holder.Address)
+		if IsKnownContract(walletAddress) {
+			continue
+		}
+		if len(walletAddresses) >= int(limit) {
+			break
+		}
+		err := AddWallet(ctx, walletAddress, []string{})
+		if err != nil {
+			log.Println("Error adding holder wallet", walletAddress, ":", err)
+			continue
+		}
+		_, err = tx.HolderWatch.UpsertOne(
+			db.HolderWatch.TokenAddressWalletAddress(
+				db.HolderWatch.TokenAddress.Equals(tokenAddress),
+				db.HolderWatch.WalletAddress.Equals(walletAddress),
+			),
+		).Create(
+			db.HolderWatch.TokenAddress.Set(tokenAddress),
+			db.HolderWatch.WalletAddress.Set(walletAddress),
+			db.HolderWatch.InitialBalance.Set(holder.BalanceFormatted),
+			db.HolderWatch.Balance.Set(holder.BalanceFormatted),
+		).Update(
+			db.HolderWatch.Balance.Set(holder.BalanceFormatted),
+		).Exec(ctx)
+		if err != nil {
+			log.Println("Error saving holder watch for", walletAddress, ":", err)
+			continue
+		}
+		walletAddresses = append(walletAddresses, walletAddress)
+	}
+	return walletAddresses, nil
+}
+
+// updateHolderBalances refreshes the watched token balances of a wallet for the tokens it just
+// transferred.
+func updateHolderBalances(walletAddress string, transfers []rpc.TokenTransfer) {
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	tx := getDB()
+
+	tokenAddresses := []string{}
+	for _, transfer := range transfers {
+		tokenAddresses = append(tokenAddresses, strings.ToLower(transfer.Token.Hex()))
+	}
+
+	walletAddress = strings.ToLower(walletAddress)
+	watches, err := tx.HolderWatch.FindMany(
+		db.HolderWatch.WalletAddress.Equals(walletAddress),
+		db.HolderWatch.TokenAddress.In(tokenAddresses),
+	).Exec(ctx)
+	if err != nil || len(watches) == 0 {
+		return
+	}
+
+	// Watched tokens may be flagged as spam, so read balances without the spam filter.
+	walletTokens, err := api.GetWalletTokens(walletAddress, false)
+	if err != nil {
+		log.Println("Error getting holder wallet tokens:", err)
+		return
+	}
+	balances := map[string]string{}
+	for _, token := range walletTokens {
+		balances[strings.ToLower(token.TokenAddress)] = token.TokenBalanceFormatted
+	}
+
+	for _, watch := range watches {
+		balance, ok := balances[watch.TokenAddress]
+		if !ok {
+			balance = "0"
+		}
+		_, err := tx.HolderWatch.FindUnique(
+			db.HolderWatch.ID.Equals(watch.ID),
+		).Update(
+			db.HolderWatch.Balance.Set(balance),
+		).Exec(ctx)
+		if err != nil {
+			log.Println("Error updating holder balance:", err)
+		}
+	}
+}
+
+// GetHolderFlows aggregates how much the watched holders of a token bought or sold since they were
+// first watched.
+func GetHolderFlows(ctx context.Context, tokenAddress string) (*wallet_proto.GetHolderFlowsResponse, error) {
+	ctx, cancel := getCtx(ctx)
+	defer cancel()
+	tx := getDB()
+
+	tokenAddress = strings.ToLower(tokenAddress)
+	watches, err := tx.HolderWatch.FindMany(
+		db.HolderWatch.TokenAddress.Equals(tokenAddress),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Holders watched before a contract was added to the address book are left out.
+	watches = slices.DeleteFunc(watches, func(watch db.HolderWatchModel) bool {
+		return IsKnownContract(watch.WalletAddress)
+	})
+	response := &wallet_proto.GetHolderFlowsResponse{
+		TokenAddress: tokenAddress,
+		HolderCount:  int32(len(watches)),
+	}
+	inflow, outflow := decimal.Zero, decimal.Zero
+	for _, watch := range watches {
+		change := numeric.ParseOrZero(watch.Balance).Sub(numeric.ParseOrZero(watch.InitialBalance))
+		if change.IsPositive() {
+			inflow = inflow.Add(change)
+			response.Accumulating++
+		} else if change.IsNegative() {
+			outflow = outflow.Sub(change)
+			response.Distributing++
+		}
+		response.Holders = append(response.Holders, &wallet_proto.HolderFlow{
+			WalletAddress:  watch.WalletAddress,
+			InitialBalance: watch.InitialBalance,
+			Balance:        watch.Balance,
+			Change:         change.String(),
+		})
+	}
+	response.Inflow = inflow.String()
+	response.Outflow = outflow.String()
+	response.NetFlow = inflow.Sub(outflow).String()
+	return response, nil
+}
@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"time"
+	wallet_proto "walletdata/proto/wallet"
+
+	"github.com/ethereum/go-ethereum/common"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// walletImportInterval spaces the wallets of imports, each of which reads its tokens from
+	// Moralis, to stay within the Moralis rate limit.
+	walletImportInterval = 500 * time.Millisecond
+	// importQueueSize is how many imports may wait for the one running.
+	importQueueSize = 16
+	// maxImportWallets caps the wallets of one import.
+	maxImportWallets = 1000
+	// importJobsKept is how many imports are kept for their status; the oldest finished ones
+	// are forgotten first.
+	importJobsKept = 100
+)
+
+var (
+	ErrEmptyImport          = errors.New("no wallets to import")
+	ErrTooManyWallets       = errors.New("too many wallets to import")
+	ErrImportQueueFull      = errors.New("import queue is full")
+	ErrImportJobNotFound    = errors.New("import job not found")
+	ErrInvalidWalletAddress = errors.New("invalid wallet address")
+)
+
+type importJob struct {
+	addresses []string
+	status    *wallet_proto.ImportJob
+}
+
+var (
+	importOnce  sync.Once
+	importQueue chan *importJob
+
+	importJobsMu sync.Mutex
+	importJobs   = map[string]*importJob{}
+	importOrder  []string
+)
+
+// ImportWallets queues wallets to be added in the background and returns the job that tracks
+// them. Wallets are added one at a time; GetImportJob reports the progress.
+func ImportWallets(walletAddresses []string) (*wallet_proto.ImportJob, error) {
+	addresses := []string{}
+	seen := map[string]bool{}
+	for _, address := range walletAddresses {
+		address = strings.ToLower(strings.TrimSpace(address))
+		if address != "" && !seen[address] {
+			seen[address] = true
+			addresses = append(addresses, address)
+		}
+	}
+	if len(addresses) == 0 {
+		return nil, ErrEmptyImport
+	}
+	if len(addresses) > maxImportWallets {
+		return nil, ErrTooManyWallets
+	}
+
+	importOnce.Do(func() {
+		importQueue = make(chan *importJob, importQueueSize)
+		go func() {
+			ticker := time.NewTicker(walletImportInterval)
+			defer ticker.Stop()
+			for job := range importQueue {
+				processImportJob(job, func() { <-ticker.C }, importWallet)
+			}
+		}()
+	})
+
+	job := newImportJob(addresses)
+	select {
+	case importQueue <- job:
+	default:
+		forgetImportJob(job.status.JobId)
+		return nil, ErrImportQueueFull
+	}
+	return snapshotImportJob(job), nil
+}
+
+// GetImportJob returns the progress of an import.
+func GetImportJob(jobId string) (*wallet_proto.ImportJob, error) {
+	importJobsMu.Lock()
+	job, ok := importJobs[jobId]
+	importJobsMu.Unlock()
+	if !ok {
+		return nil, ErrImportJobNotFound
+	}
+	return snapshotImportJob(job), nil
+}
+
+func newImportJob(addresses []string) *importJob {
+	id := make([]byte, 12)
+	rand.Read(id)
+	job := &importJob{
+		addresses: addresses,
+		status: &wallet_proto.ImportJob{
+			JobId:     hex.EncodeToString(id),
+			State:     wallet_proto.ImportJobState_IMPORT_QUEUED,
+			Total:     int32(len(addresses)),
+			CreatedAt: time.Now().UnixMilli(),
+		},
+	}
+
+	importJobsMu.Lock()
+	defer importJobsMu.Unlock()
+	importJobs[job.status.JobId] = job
+	importOrder = append(importOrder, job.status.JobId)
+	for i := 0; len(importOrder) > importJobsKept && i < len(importOrder); {
+		if importJobs[importOrder[i]].status.State != wallet_proto.ImportJobState_IMPORT_DONE {
+			i++
+			continue
+		}
+		delete(importJobs, importOrder[i])
+		importOrder = append(importOrder[:i], importOrder[i+1:]...)
+	}
+	return job
+}
+
+func forgetImportJob(jobId string) {
+	importJobsMu.Lock()
+	defer importJobsMu.Unlock()
+	delete(importJobs, jobId)
+	for i, id := range importOrder {
+		if id == jobId {
+			importOrder = append(importOrder[:i], importOrder[i+1:]...)
+			break
+		}
+	}
+}
+
+func snapshotImportJob(job *importJob) *wallet_proto.ImportJob {
+	importJobsMu.Lock()
+	defer importJobsMu.Unlock()
+	return proto.Clone(job.status).(*wallet_proto.ImportJob)
+}
+
+// updateImportJob changes the status of a job under the lock GetImportJob reads it with.
+func updateImportJob(job *importJob, update func(status *wallet_proto.ImportJob)) {
+	importJobsMu.Lock()
+	defer importJobsMu.Unlock()
+	update(job.status)
+}
+
+// processImportJob imports the wallets of a job, calling wait before each one. importOne
+// reports whether the wallet was added or already tracked.
+func processImportJob(job *importJob, wait func(), importOne func(walletAddress string) (bool, error)) {
+	updateImportJob(job, func(status *wallet_proto.ImportJob) {
+		status.State = wallet_proto.ImportJobState_IMPORT_RUNNING
+	})
+	for _, address := range job.addresses {
+		var added bool
+		var err error
+		if !common.IsHexAddress(address) {
+			err = ErrInvalidWalletAddress
+		} else {
+			wait()
+			added, err = importOne(address)
+		}
+		if err != nil {
+			log.Println("Error importing wallet", address, ":", err)
+		}
+		updateImportJob(job, func(status *wallet_proto.ImportJob) {
+			status.Processed++
+			switch {
+			case err != nil:
+				status.Failures = append(status.Failures, &wallet_proto.ImportFailure{WalletAddress: address, Error: err.Error()})
+			case added:
+				status.Added++
+			default:
+				status.Existing++
+			}
+		})
+	}
+	updateImportJob(job, func(status *wallet_proto.ImportJob) {
+		status.State = wallet_proto.ImportJobState_IMPORT_DONE
+		status.FinishedAt = time.Now().UnixMilli()
+	})
+	log.Println("Imported wallets of job", job.status.JobId)
+}
+
+// importWallet adds a wallet and reads its tokens, as AddWallet followed by the first GetWallet
+// would. Tracked wallets are left as they are.
+func importWallet(walletAddress string) (bool, error) {
+	if WalletExists(walletAddress) {
+		return false, nil
+	}
+	if err := AddWallet(context.Background(), walletAddress, []string{}); err != nil {
+		return false, err
+	}
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	if err := UpdateWallet(ctx, walletAddress); err != nil {
+		return true, err
+	}
+	return true, nil
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	wallet_proto "walletdata/proto/wallet"
+)
+
+func TestProcessImportJob(t *testing.T) {
+	const existing = "0x2222222222222222222222222222222222222222"
+	const failing = "0x3333333333333333333333333333333333333333"
+	job := newImportJob([]string{testWallet, existing, failing, "0xnot"})
+	defer forgetImportJob(job.status.JobId)
+
+	waits := 0
+	processImportJob(job, func() { waits++ }, func(walletAddress string) (bool, error) {
+		switch walletAddress {
+		case existing:
+			return false, nil
+		case failing:
+			return false, errors.New("moralis unavailable")
+		}
+		return true, nil
+	})
+
+	status, err := GetImportJob(job.status.JobId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.State != wallet_proto.ImportJobState_IMPORT_DONE || status.Total != 4 || status.Processed != 4 || status.Added != 1 || status.Existing != 1 {
+		t.Errorf("job = %+v", status)
+	}
+	if len(status.Failures) != 2 || status.Failures[0].WalletAddress != failing || status.Failures[1].WalletAddress != "0xnot" {
+		t.Errorf("failures = %+v", status.Failures)
+	}
+	if waits != 3 {
+		t.Errorf("waited %d times, want 3: invalid addresses are not rate limited", waits)
+	}
+}
+
+func TestImportWalletsValidates(t *testing.T) {
+	if _, err := ImportWallets([]string{" ", ""}); !errors.Is(err, ErrEmptyImport) {
+		t.Errorf("empty import: err = %v", err)
+	}
+	if _, err := GetImportJob("unknown"); !errors.Is(err, ErrImportJobNotFound) {
+		t.Errorf("unknown job: err = %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"log"
+	"strings"
+	"sync"
+	"time"
+	"walletdata/env"
+	"walletdata/lib/api"
+	"walletdata/rpc"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	ingestionWebSocket      = "websocket"
+	ingestionAlchemyWebhook = "alchemy_webhook"
+
+	// webhookDedupWindow is how long a transaction of a wallet is remembered, so the activities
+	// of one transaction and the retries of a webhook are handled once.
+	webhookDedupWindow = 10 * time.Minute
+)
+
+var ingestionMode = sync.OnceValue(func() string {
+	mode := strings.ToLower(env.WALLET_INGESTION.GetEnvOrDefault(ingestionWebSocket))
+	if mode != ingestionWebSocket && mode != ingestionAlchemyWebhook {
+		log.Printf("Unknown WALLET_INGESTION %q, subscribing to wallets over the websocket", mode)
+		return ingestionWebSocket
+	}
+	return mode
+})
+
+// WebhookIngestion reports whether wallet transactions come from the Alchemy webhook rather than
+// subscriptions.
+func WebhookIngestion() bool {
+	return ingestionMode() == ingestionAlchemyWebhook
+}
+
+// registerWebhookAddresses adds wallets to the Alchemy webhook when it is managed here; otherwise
+// they must be added to it by hand.
+func registerWebhookAddresses(addresses []string) {
+	if !api.AlchemyWebhookConfigured() || len(addresses) == 0 {
+		return
+	}
+	if err := api.AddAlchemyWebhookAddresses(addresses); err != nil {
+		log.Println("Error adding wallets to the Alchemy webhook:", err)
+	}
+}
+
+// seenTransactions remembers the transactions of wallets handled from webhooks.
+type seenTransactions struct {
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// claim reports whether a transaction of a wallet was not handled within the window and marks
+// it handled.
+func (s *seenTransactions) claim(key string, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for k, at := range s.seen {
+		if now.Sub(at) > webhookDedupWindow {
+			delete(s.seen, k)
+		}
+	}
+	if _, ok := s.seen[key]; ok {
+		return false
+	}
+	s.seen[key] = now
+	return true
+}
+
+var webhookTransactions = &seenTransactions{seen: map[string]time.Time{}}
+
+// walletActivities returns the transaction hashes of the activities by the tracked wallet they
+// involve, each pair once.
+func walletActivities(activities []rpc.AlchemyActivity, tracked func(address string) bool) map[string][]common.Hash {
+	byWallet := map[string][]common.Hash{}
+	seen := map[string]bool{}
+	for _, activity := range activities {
+		if !strings.HasPrefix(activity.Hash, "0x") {
+			continue
+		}
+		hash := common.HexToHash(activity.Hash)
+		for _, address := range []string{activity.FromAddress, activity.ToAddress} {
+			address = strings.ToLower(address)
+			key := address + ":" + hash.Hex()
+			if !common.IsHexAddress(address) || seen[key] || !tracked(address) {
+				continue
+			}
+			seen[key] = true
+			byWallet[address] = append(byWallet[address], hash)
+		}
+	}
+	return byWallet
+}
+
+// IngestAlchemyActivity handles the transactions of an Alchemy address activity webhook like the
+// ones a wallet subscription delivers.
+func IngestAlchemyActivity(activities []rpc.AlchemyActivity) {
+	now := time.Now()
+	for address, hashes := range walletActivities(activities, WalletExists) {
+		for _, hash := range hashes {
+			if !webhookTransactions.claim(address+":"+hash.Hex(), now) {
+				continue
+			}
+			event, err := rpc.WalletTransactionByHash(hash, common.HexToAddress(address))
+			if err != nil {
+				log.Println("Error reading webhook transaction", hash.Hex(), ":", err)
+				continue
+			}
+			handleWalletTransaction(address, event)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"testing"
+	"time"
+	"walletdata/rpc"
+)
+
+func TestWalletActivities(t *testing.T) {
+	const (
+		tracked = "0x1111111111111111111111111111111111111111"
+		other   = "0x2222222222222222222222222222222222222222"
+		hash    = "0x00000000000000000000000000000000000000000000000000000000000000aa"
+	)
+	activities := []rpc.AlchemyActivity{
+		{FromAddress: "0x1111111111111111111111111111111111111111", ToAddress: other, Hash: hash},
+		// A token transfer of the same transaction.
+		{FromAddress: other, ToAddress: tracked, Hash: hash},
+		{FromAddress: other, ToAddress: other, Hash: hash},
+		{FromAddress: tracked, ToAddress: other, Hash: "not a hash"},
+	}
+	byWallet := walletActivities(activities, func(address string) bool { return address == tracked })
+	if len(byWallet) != 1 || len(byWallet[tracked]) != 1 || byWallet[tracked][0].Hex() != hash {
+		t.Errorf("activities by wallet = %v", byWallet)
+	}
+}
+
+func TestSeenTransactions(t *testing.T) {
+	seen := &seenTransactions{seen: map[string]time.Time{}}
+	now := time.Now()
+	if !seen.claim("a", now) || seen.claim("a", now.Add(time.Minute)) {
+		t.Error("transaction handled twice within the window")
+	}
+	if !seen.claim("a", now.Add(webhookDedupWindow+time.Second)) {
+		t.Error("transaction not handled again after the window")
+	}
+}
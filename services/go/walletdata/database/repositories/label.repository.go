@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"strings"
+	db "walletdata/generated/prisma"
+	"walletdata/proto/common"
+)
+
+// normalizeNames lowercases, trims and deduplicates tag and group names.
+func normalizeNames(names []string) []string {
+	normalized := []string{}
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || slices.Contains(normalized, name) {
+			continue
+		}
+		normalized = append(normalized, name)
+	}
+	return normalized
+}
+
+// SetWalletLabel replaces the label, tags and groups of a wallet, adding the wallet if it is not
+// watched yet. A nil label, tags or groups leaves the current value unchanged; an empty one
+// clears it.
+func SetWalletLabel(ctx context.Context, walletAddress string, label *string, tags []string, groups []string) (*common.Wallet, error) {
+	ctx, cancel := getCtx(ctx)
+	defer cancel()
+	tx := getDB()
+
+	walletAddress = strings.ToLower(walletAddress)
+	err := AddWallet(ctx, walletAddress, []string{})
+	if err != nil {
+		return nil, err
+	}
+
+	params := []db.WalletSetParam{}
+	if tags != nil {
+		params = append(params, db.Wallet.Tags.Set(normalizeNames(tags)))
+	}
+	if groups != nil {
+		params = append(params, db.Wallet.Groups.Set(normalizeNames(groups)))
+	}
+	if label != nil {
+		trimmed := strings.TrimSpace(*label)
+		if trimmed == "" {
+			params = append(params, db.Wallet.Label.SetOptional(nil))
+		} else {
+			params = append(params, db.Wallet.Label.Set(trimmed))
+		}
+	}
+	if len(params) == 0 {
+		wallet, err := walletStore.FindWallet(ctx, walletAddress)
+		if err != nil {
+			return nil, err
+		}
+		return walletModelToProto(wallet), nil
+	}
+	wallet, err := tx.Wallet.FindUnique(
+		db.Wallet.Address.Equals(walletAddress),
+	).Update(params...).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return walletModelToProto(wallet), nil
+}
+
+// AddWalletTags adds tags to a watched wallet, keeping the ones it has.
+func AddWalletTags(ctx context.Context, walletAddress string, tags []string) error {
+	ctx, cancel := getCtx(ctx)
+	defer cancel()
+	tx := getDB()
+
+	walletAddress = strings.ToLower(walletAddress)
+	wallet, err := walletStore.FindWallet(ctx, walletAddress)
+	if err != nil {
+		return err
+	}
+	merged := normalizeNames(append(slices.Clone(wallet.Tags), tags...))
+	if slices.Equal(merged, wallet.Tags) {
+		return nil
+	}
+	_, err = tx.Wallet.FindUnique(
+		db.Wallet.Address.Equals(walletAddress),
+	).Update(db.Wallet.Tags.Set(merged)).Exec(ctx)
+	return err
+}
+
+// ListWalletsByTag returns the wallets with the given tag, optionally limited to a group.
+func ListWalletsByTag(ctx context.Context, tag string, group *string) ([]*common.Wallet, error) {
+	ctx, cancel := getCtx(ctx)
+	defer cancel()
+	tx := getDB()
+
+	filters := []db.WalletWhereParam{
+		db.Wallet.Tags.Has(strings.ToLower(strings.TrimSpace(tag))),
+	}
+	if group != nil && *group != "" {
+		filters = append(filters, db.Wallet.Groups.Has(strings.ToLower(strings.TrimSpace(*group))))
+	}
+	wallets, err := tx.Wallet.FindMany(filters...).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response := []*common.Wallet{}
+	for _, wallet := range wallets {
+		response = append(response, walletModelToProto(&wallet))
+	}
+	return response, nil
+}
@@ -0,0 +1,265 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"samterminal/pkg/numeric"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	db "walletdata/generated/prisma"
+	token_client "walletdata/lib/grpc/client/token"
+	wallet_proto "walletdata/proto/wallet"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	dailyLeaderboardInterval = 5 * time.Minute
+	// dailyStartPriceMaxAge is how long before the start of the day the last price of a token may
+	// have been recorded to be its price at the start of the day. Quiet tokens record no price for
+	// longer than the default max age of tokendata, and an hour-old price still values the
+	// holdings carried into the day better than none.
+	dailyStartPriceMaxAge = time.Hour
+)
+
+type dailyLeaderboardEntry struct {
+	walletPnL
+	label      string
+	unrealized decimal.Decimal
+}
+
+func (e dailyLeaderboardEntry) total() decimal.Decimal {
+	return e.realized.Add(e.unrealized)
+}
+
+// The daily leaderboard is computed in the background and served from memory.
+var dailyLeaderboard struct {
+	mu        sync.RWMutex
+	day       string
+	entries   []dailyLeaderboardEntry
+	updatedAt time.Time
+}
+
+// SetWalletLeaderboardOptIn sets whether a tracked wallet appears on the daily leaderboard.
+func SetWalletLeaderboardOptIn(ctx context.Context, walletAddress string, optIn bool) error {
+	ctx, cancel := getCtx(ctx)
+	defer cancel()
+	tx := getDB()
+	_, err := tx.Wallet.FindUnique(
+		db.Wallet.Address.Equals(strings.ToLower(walletAddress)),
+	).Update(
+		db.Wallet.LeaderboardOptIn.Set(optIn),
+	).Exec(ctx)
+	return err
+}
+
+// currentPrices returns the USD price of tokens from tokendata. Delisted tokens are priced at zero
+// since they can no longer be sold.
+func currentPrices(ctx context.Context, tokenAddresses []string) map[string]decimal.Decimal {
+	prices := map[string]decimal.Decimal{}
+	if len(tokenAddresses) == 0 {
+		return prices
+	}
+	tokensResponse, err := token_client.GetTokens(ctx, tokenAddresses, "price", "delisted")
+	if err != nil {
+		log.Println("Error getting leaderboard token prices:", err)
+		return prices
+	}
+	for _, token := range tokensResponse.Tokens {
+		price, err := numeric.Parse(token.Price)
+		if err != nil {
+			continue
+		}
+		if token.Delisted {
+			price = decimal.Zero
+		}
+		prices[strings.ToLower(token.Address)] = price
+	}
+	return prices
+}
+
+// dailyPnL replays the trades a wallet made during the day in order with average cost basis.
+// Tokens the wallet held before the day count as bought at their price at the start of the day, so
+// only what they gained during the day counts; they are skipped when that price is unknown. Open
+// positions are marked at the current prices.
+func dailyPnL(walletAddress string, trades []db.TradeModel, startPrices map[string]decimal.Decimal, prices map[string]decimal.Decimal) dailyLeaderboardEntry {
+	entry := dailyLeaderboardEntry{walletPnL: walletPnL{walletAddress: walletAddress}}
+	positions := map[string]*position{}
+	for _, trade := range trades {
+		pos, ok := positions[trade.TokenAddress]
+		if !ok {
+			pos = &position{}
+			positions[trade.TokenAddress] = pos
+		}
+		if trade.Side == db.TradeSideBuy {
+			pos.amount = pos.amount.Add(trade.TokenAmount)
+			pos.costUsd = pos.costUsd.Add(trade.UsdValue)
+			continue
+		}
+
+		sold := decimal.Min(trade.TokenAmount, pos.amount)
+		pnl := decimal.Zero
+		if sold.IsPositive() {
+			averageCost := pos.costUsd.Div(pos.amount)
+			pnl = sold.Mul(trade.PriceUsd.Sub(averageCost))
+			pos.costUsd = pos.costUsd.Sub(sold.Mul(averageCost))
+			pos.amount = pos.amount.Sub(sold)
+		}
+		held := trade.TokenAmount.Sub(sold)
+		if startPrice, ok := startPrices[trade.TokenAddress]; ok && held.IsPositive() {
+			pnl = pnl.Add(held.Mul(trade.PriceUsd.Sub(startPrice)))
+			sold = sold.Add(held)
+		}
+		if !sold.IsPositive() {
+			continue
+		}
+		entry.realized = entry.realized.Add(pnl)
+		entry.trades++
+		if pnl.IsPositive() {
+			entry.wins++
+		}
+	}
+	for tokenAddress, pos := range positions {
+		price, ok := prices[tokenAddress]
+		if !ok || !pos.amount.IsPositive() {
+			continue
+		}
+		entry.unrealized = entry.unrealized.Add(pos.amount.Mul(price).Sub(pos.costUsd))
+	}
+	return entry
+}
+
+// RefreshDailyLeaderboard ranks opted-in wallets that traded during the current UTC day by their
+// PnL that day: realized on their sells and unrealized on the positions they opened.
+func RefreshDailyLeaderboard() {
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	tx := getDB()
+
+	now := time.Now().UTC()
+	since := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	wallets, err := tx.Wallet.FindMany(
+		db.Wallet.LeaderboardOptIn.Equals(true),
+	).Exec(ctx)
+	if err != nil {
+		log.Println("Error getting leaderboard wallets:", err)
+		return
+	}
+	labels := map[string]string{}
+	walletAddresses := make([]string, 0, len(wallets))
+	for _, wallet := range wallets {
+		walletAddresses = append(walletAddresses, wallet.Address)
+		if label, ok := wallet.Label(); ok {
+			labels[wallet.Address] = label
+		}
+	}
+
+	trades, err := tx.Trade.FindMany(
+		db.Trade.WalletAddress.In(walletAddresses),
+		db.Trade.CreatedAt.Gte(since),
+	).OrderBy(
+		db.Trade.CreatedAt.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil {
+		log.Println("Error getting leaderboard trades:", err)
+		return
+	}
+	tradesByWallet := map[string][]db.TradeModel{}
+	tokenAddresses := []string{}
+	startQueries := []tokenAt{}
+	seen := map[string]bool{}
+	for _, trade := range trades {
+		tradesByWallet[trade.WalletAddress] = append(tradesByWallet[trade.WalletAddress], trade)
+		if !seen[trade.TokenAddress] {
+			seen[trade.TokenAddress] = true
+			tokenAddresses = append(tokenAddresses, trade.TokenAddress)
+			startQueries = append(startQueries, tokenAt{token: trade.TokenAddress, timestamp: since.Unix()})
+		}
+	}
+	startPrices := map[string]decimal.Decimal{}
+	for query, price := range getPricesAt(startQueries, dailyStartPriceMaxAge) {
+		startPrices[query.token] = price
+	}
+	prices := currentPrices(context.Background(), tokenAddresses)
+
+	entries := make([]dailyLeaderboardEntry, 0, len(tradesByWallet))
+	for _, walletAddress := range walletAddresses {
+		walletTrades := tradesByWallet[walletAddress]
+		if len(walletTrades) == 0 {
+			continue
+		}
+		entry := dailyPnL(walletAddress, walletTrades, startPrices, prices)
+		entry.label = labels[walletAddress]
+		entries = append(entries, entry)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].total().Equal(entries[j].total()) {
+			return entries[i].total().GreaterThan(entries[j].total())
+		}
+		return entries[i].winRate() > entries[j].winRate()
+	})
+
+	dailyLeaderboard.mu.Lock()
+	dailyLeaderboard.day = since.Format(time.DateOnly)
+	dailyLeaderboard.entries = entries
+	dailyLeaderboard.updatedAt = now
+	dailyLeaderboard.mu.Unlock()
+}
+
+// StartDailyLeaderboard keeps the daily leaderboard up to date until the process exits.
+func StartDailyLeaderboard() {
+	go func() {
+		ticker := time.NewTicker(dailyLeaderboardInterval)
+		defer ticker.Stop()
+		for {
+			RefreshDailyLeaderboard()
+			<-ticker.C
+		}
+	}()
+}
+
+// GetDailyLeaderboard returns a page of the last computed daily leaderboard. Pages start at 1.
+func GetDailyLeaderboard(page int32, pageSize int32) *wallet_proto.GetDailyLeaderboardResponse {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = defaultLeaderboardPageSize
+	}
+	pageSize = min(pageSize, maxLeaderboardPageSize)
+
+	dailyLeaderboard.mu.RLock()
+	defer dailyLeaderboard.mu.RUnlock()
+	response := &wallet_proto.GetDailyLeaderboardResponse{
+		Page:     page,
+		PageSize: pageSize,
+		Total:    int32(len(dailyLeaderboard.entries)),
+		Day:      dailyLeaderboard.day,
+	}
+	if !dailyLeaderboard.updatedAt.IsZero() {
+		response.UpdatedAt = dailyLeaderboard.updatedAt.UnixMilli()
+	}
+	start := int((page - 1) * pageSize)
+	if start >= len(dailyLeaderboard.entries) {
+		return response
+	}
+	end := min(start+int(pageSize), len(dailyLeaderboard.entries))
+	for i, entry := range dailyLeaderboard.entries[start:end] {
+		response.Entries = append(response.Entries, &wallet_proto.LeaderboardEntry{
+			Rank:             int32(start + i + 1),
+			WalletAddress:    entry.walletAddress,
+			WalletLabel:      entry.label,
+			RealizedPnlUsd:   numeric.FormatUSD(entry.realized),
+			WinRate:          strconv.FormatFloat(entry.winRate(), 'f', 4, 64),
+			TradeCount:       entry.trades,
+			WinningTrades:    entry.wins,
+			UnrealizedPnlUsd: numeric.FormatUSD(entry.unrealized),
+			TotalPnlUsd:      numeric.FormatUSD(entry.total()),
+		})
+	}
+	return response
+}
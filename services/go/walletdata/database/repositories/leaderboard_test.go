@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"testing"
+	db "walletdata/generated/prisma"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestDailyPnL(t *testing.T) {
+	const held = "0x2222222222222222222222222222222222222222"
+	const bought = "0x3333333333333333333333333333333333333333"
+	const unpriced = "0x4444444444444444444444444444444444444444"
+	trades := []db.TradeModel{
+		// 100 tokens held since before the day, at 1 at its start, sold at 1.5.
+		{InnerTrade: db.InnerTrade{TokenAddress: held, Side: db.TradeSideSell, TokenAmount: decimal.NewFromInt(100), PriceUsd: decimal.RequireFromString("1.5"), UsdValue: decimal.NewFromInt(150)}},
+		// 10 tokens bought at 2 and 4 sold at 3, the other 6 still open at 2.5.
+		{InnerTrade: db.InnerTrade{TokenAddress: bought, Side: db.TradeSideBuy, TokenAmount: decimal.NewFromInt(10), PriceUsd: decimal.NewFromInt(2), UsdValue: decimal.NewFromInt(20)}},
+		{InnerTrade: db.InnerTrade{TokenAddress: bought, Side: db.TradeSideSell, TokenAmount: decimal.NewFromInt(4), PriceUsd: decimal.NewFromInt(3), UsdValue: decimal.NewFromInt(12)}},
+		// Held before the day without a start of day price.
+		{InnerTrade: db.InnerTrade{TokenAddress: unpriced, Side: db.TradeSideSell, TokenAmount: decimal.NewFromInt(5), PriceUsd: decimal.NewFromInt(1), UsdValue: decimal.NewFromInt(5)}},
+	}
+	entry := dailyPnL(testWallet, trades,
+		map[string]decimal.Decimal{held: decimal.NewFromInt(1)},
+		map[string]decimal.Decimal{held: decimal.NewFromInt(9), bought: decimal.RequireFromString("2.5")},
+	)
+
+	if !entry.realized.Equal(decimal.NewFromInt(54)) {
+		t.Errorf("realized = %v, want 50 on the held tokens and 4 on the bought ones", entry.realized)
+	}
+	if !entry.unrealized.Equal(decimal.NewFromInt(3)) {
+		t.Errorf("unrealized = %v, want 3 on the 6 open bought tokens", entry.unrealized)
+	}
+	if entry.trades != 2 || entry.wins != 2 {
+		t.Errorf("trades = %d, wins = %d, want 2 and 2", entry.trades, entry.wins)
+	}
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+	token_client "walletdata/lib/grpc/client/token"
+	"walletdata/lib/trades"
+	"walletdata/rpc"
+
+	"github.com/shopspring/decimal"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// nativePriceTTL is how long the price of the wrapped native token is reused for valuations.
+	nativePriceTTL = 30 * time.Second
+	// nativePriceRetry is how long a failed refresh is not tried again.
+	nativePriceRetry = 5 * time.Second
+)
+
+var nativePrice struct {
+	mu      sync.Mutex
+	price   decimal.Decimal
+	fetched time.Time
+	failed  time.Time
+	refresh singleflight.Group
+}
+
+// fetchNativePrice reads the tokendata price of the wrapped native token.
+var fetchNativePrice = func(ctx context.Context) (decimal.Decimal, error) {
+	response, err := token_client.GetToken(ctx, strings.ToLower(trades.NativeToken().Hex()))
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if response.Token == nil {
+		return decimal.Zero, errors.New("native token not found")
+	}
+	return decimal.NewFromString(response.Token.Price)
+}
+
+// getNativePrice returns the tokendata price of the wrapped native token. A stale price is served
+// while one refresh runs in the background; only the first call, with no price known yet, waits
+// for it. Failed refreshes are retried after nativePriceRetry.
+func getNativePrice() decimal.Decimal {
+	nativePrice.mu.Lock()
+	now := time.Now()
+	known := !nativePrice.fetched.IsZero()
+	if now.Sub(nativePrice.fetched) < nativePriceTTL || now.Sub(nativePrice.failed) < nativePriceRetry {
+		price := nativePrice.price
+		nativePrice.mu.Unlock()
+		return price
+	}
+	price := nativePrice.price
+	nativePrice.mu.Unlock()
+
+	result := nativePrice.refresh.DoChan("native", refreshNativePrice)
+	if known {
+		return price
+	}
+	return (<-result).Val.(decimal.Decimal)
+}
+
+// refreshNativePrice fetches the native price and returns the price to use, the last known one
+// when the fetch failed.
+func refreshNativePrice() (any, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	price, err := fetchNativePrice(ctx)
+
+	nativePrice.mu.Lock()
+	defer nativePrice.mu.Unlock()
+	if err != nil {
+		log.Println("Error getting native token price:", err)
+		nativePrice.failed = time.Now()
+		return nativePrice.price, nil
+	}
+	nativePrice.price = price
+	nativePrice.fetched = time.Now()
+	return price, nil
+}
+
+// parseWei reads a balance in wei, treating invalid balances as zero.
+func parseWei(balance string) decimal.Decimal {
+	wei, ok := new(big.Int).SetString(balance, 10)
+	if !ok {
+		return decimal.Zero
+	}
+	return decimal.NewFromBigInt(wei, -18)
+}
+
+// formatNativeBalance converts a balance in wei to ETH.
+func formatNativeBalance(balance string) string {
+	return parseWei(balance).String()
+}
+
+// nativeBalanceUsd prices a balance in wei. Empty balances are not priced.
+func nativeBalanceUsd(balance string) string {
+	eth := parseWei(balance)
+	if eth.IsZero() {
+		return "0"
+	}
+	return eth.Mul(getNativePrice()).StringFixed(2)
+}
+
+// RefreshNativeBalance reads the native balance of a wallet from the node and stores it.
+func RefreshNativeBalance(walletAddress string) error {
+	balance, err := rpc.GetNativeBalance(walletAddress)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	return walletStore.UpdateNativeBalance(ctx, strings.ToLower(walletAddress), balance)
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestGetNativePrice(t *testing.T) {
+	previous := fetchNativePrice
+	defer func() { fetchNativePrice = previous }()
+	nativePrice.mu.Lock()
+	nativePrice.price, nativePrice.fetched, nativePrice.failed = decimal.Zero, time.Time{}, time.Time{}
+	nativePrice.mu.Unlock()
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	fetchNativePrice = func(ctx context.Context) (decimal.Decimal, error) {
+		calls.Add(1)
+		<-release
+		return decimal.NewFromInt(3000), nil
+	}
+
+	// Concurrent first reads share one fetch.
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if price := getNativePrice(); !price.Equal(decimal.NewFromInt(3000)) {
+				t.Errorf("price = %s, want 3000", price)
+			}
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if calls.Load() != 1 {
+		t.Errorf("fetches = %d, want 1", calls.Load())
+	}
+
+	// A stale price is served while the refresh fails, and the failure is not retried at once.
+	fetchNativePrice = func(ctx context.Context) (decimal.Decimal, error) {
+		calls.Add(1)
+		return decimal.Zero, errors.New("unavailable")
+	}
+	nativePrice.mu.Lock()
+	nativePrice.fetched = time.Now().Add(-time.Hour)
+	nativePrice.mu.Unlock()
+	if price := getNativePrice(); !price.Equal(decimal.NewFromInt(3000)) {
+		t.Errorf("stale price = %s, want 3000", price)
+	}
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(time.Millisecond) {
+		nativePrice.mu.Lock()
+		failed := !nativePrice.failed.IsZero()
+		nativePrice.mu.Unlock()
+		if failed {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("the background refresh did not run")
+		}
+	}
+	getNativePrice()
+	if calls.Load() != 2 {
+		t.Errorf("fetches = %d, want no retry right after a failure", calls.Load())
+	}
+}
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+	token_client "walletdata/lib/grpc/client/token"
+	proto "walletdata/proto/token"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Kinds of the calls to tokendata that go through the outbox.
+const (
+	outboxAddTokens    = "addTokens"
+	outboxAddBlacklist = "addBlacklist"
+)
+
+const (
+	outboxBatchSize    = 100
+	outboxPollInterval = 5 * time.Second
+	// outboxClaim is how long a message being delivered is hidden from the dispatchers of the
+	// other replicas; a replica stopping mid-delivery leaves it to be retried after it.
+	outboxClaim        = time.Minute
+	outboxRetryBackoff = 5 * time.Second
+	maxOutboxBackoff   = 10 * time.Minute
+	// maxOutboxAttempts is how often a message tokendata fails on is tried, about 2.5 hours of
+	// backoff, before it is dropped. Messages are kept while tokendata is unreachable.
+	maxOutboxAttempts = 20
+)
+
+type addTokensMessage struct {
+	TokenAddresses []string `json:"tokenAddresses"`
+	Reason         string   `json:"reason"`
+}
+
+type addBlacklistMessage struct {
+	TokenAddresses []string `json:"tokenAddresses"`
+}
+
+// outboxHandlers deliver the messages of each kind to tokendata.
+var outboxHandlers = map[string]func(ctx context.Context, payload []byte) error{
+	outboxAddTokens: func(ctx context.Context, payload []byte) error {
+		var message addTokensMessage
+		if err := json.Unmarshal(payload, &message); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		_, err := token_client.AddTokens(ctx, message.TokenAddresses, message.Reason)
+		return err
+	},
+	outboxAddBlacklist: func(ctx context.Context, payload []byte) error {
+		var message addBlacklistMessage
+		if err := json.Unmarshal(payload, &message); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		_, err := token_client.AddBlacklist(ctx, &proto.AddBlacklistRequest{TokenAddresses: message.TokenAddresses})
+		return err
+	},
+}
+
+var outboxWake = make(chan struct{}, 1)
+
+// enqueueOutbox stores a call to tokendata for the dispatcher, which retries it until tokendata
+// takes it, and wakes the dispatcher.
+func enqueueOutbox(kind string, message any) {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("Error encoding %s outbox message: %+v", kind, err)
+		return
+	}
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	if err := outboxStore.EnqueueOutbox(ctx, kind, string(payload)); err != nil {
+		log.Printf("Error queueing %s for tokendata: %+v", kind, err)
+		return
+	}
+	select {
+	case outboxWake <- struct{}{}:
+	default:
+	}
+}
+
+// retryableOutboxError reports whether a failed call may succeed later, when tokendata is back
+// or less busy. Calls tokendata rejected are not retried.
+func retryableOutboxError(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted, codes.Canceled, codes.Internal, codes.Unknown:
+		return true
+	}
+	return false
+}
+
+// tokendataUnreachable reports whether a call failed because tokendata is down or does not
+// answer, which the other messages would run into as well.
+func tokendataUnreachable(err error) bool {
+	code := status.Code(err)
+	return code == codes.Unavailable || code == codes.DeadlineExceeded
+}
+
+// outboxBackoff is the wait before the next attempt of a message that failed attempts times.
+func outboxBackoff(attempts int) time.Duration {
+	backoff := outboxRetryBackoff
+	for i := 1; i < attempts && backoff < maxOutboxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxOutboxBackoff)
+}
+
+// DispatchOutbox delivers the due outbox messages and returns how many tokendata took. The batch
+// stops at the first message tokendata is unreachable for; the others stay due.
+func DispatchOutbox() int {
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	messages, err := outboxStore.ListDueOutbox(ctx, time.Now(), outboxBatchSize)
+	if err != nil {
+		log.Printf("Error listing outbox messages: %+v", err)
+		return 0
+	}
+	delivered := 0
+	for _, message := range messages {
+		// Each claim runs from its own start; earlier deliveries of the batch take time.
+		claimed, err := outboxStore.ClaimOutbox(ctx, message, time.Now().Add(outboxClaim))
+		if err != nil {
+			log.Printf("Error claiming outbox message %s: %+v", message.ID, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		handler, ok := outboxHandlers[message.Kind]
+		if !ok {
+			// Possibly queued by a newer replica, which can deliver it.
+			err = fmt.Errorf("unknown outbox message kind %q", message.Kind)
+		} else {
+			callCtx, callCancel := context.WithTimeout(context.Background(), 10*time.Second)
+			err = handler(callCtx, []byte(message.Payload))
+			callCancel()
+		}
+		switch {
+		case err == nil:
+			delivered++
+			err = outboxStore.DeleteOutbox(ctx, message.ID)
+		case ok && !retryableOutboxError(err):
+			log.Printf("tokendata rejected %s outbox message %s, dropping it: %+v", message.Kind, message.ID, err)
+			err = outboxStore.DeleteOutbox(ctx, message.ID)
+		case ok && message.Attempts+1 >= maxOutboxAttempts && !tokendataUnreachable(err):
+			log.Printf("Dropping %s outbox message %s after %d attempts: %+v", message.Kind, message.ID, message.Attempts+1, err)
+			err = outboxStore.DeleteOutbox(ctx, message.ID)
+		default:
+			attempts := message.Attempts + 1
+			log.Printf("Error delivering %s outbox message %s (attempt %d): %+v", message.Kind, message.ID, attempts, err)
+			unreachable := tokendataUnreachable(err)
+			if err = outboxStore.RetryOutbox(ctx, message.ID, time.Now().Add(outboxBackoff(attempts)), err.Error()); err != nil {
+				log.Printf("Error updating outbox message %s: %+v", message.ID, err)
+			}
+			if unreachable {
+				return delivered
+			}
+			continue
+		}
+		if err != nil {
+			log.Printf("Error updating outbox message %s: %+v", message.ID, err)
+		}
+	}
+	return delivered
+}
+
+// StartOutboxDispatcher delivers outbox messages in the background as they are queued and
+// retries the failed ones.
+func StartOutboxDispatcher() {
+	go func() {
+		ticker := time.NewTicker(outboxPollInterval)
+		defer ticker.Stop()
+		for {
+			// A batch delivered in full may have left more due messages behind.
+			for DispatchOutbox() == outboxBatchSize {
+				continue
+			}
+			select {
+			case <-ticker.C:
+			case <-outboxWake:
+			}
+		}
+	}()
+}
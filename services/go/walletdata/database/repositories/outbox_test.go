@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+	"walletdata/database/store/mock"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDispatchOutbox(t *testing.T) {
+	outbox := mock.NewOutboxStore()
+	defer SetOutboxStore(outbox)()
+	calls := []addTokensMessage{}
+	var callErr error
+	previous := outboxHandlers[outboxAddTokens]
+	defer func() { outboxHandlers[outboxAddTokens] = previous }()
+	outboxHandlers[outboxAddTokens] = func(ctx context.Context, payload []byte) error {
+		var message addTokensMessage
+		if err := json.Unmarshal(payload, &message); err != nil {
+			return err
+		}
+		calls = append(calls, message)
+		return callErr
+	}
+
+	enqueueOutbox(outboxAddTokens, addTokensMessage{TokenAddresses: []string{"0xa"}, Reason: "wallet_token"})
+
+	// tokendata is down: the message is kept for a later attempt.
+	callErr = status.Error(codes.Unavailable, "connection refused")
+	if delivered := DispatchOutbox(); delivered != 0 {
+		t.Fatalf("delivered = %d while tokendata is down", delivered)
+	}
+	messages := outbox.Messages()
+	if len(messages) != 1 || messages[0].Attempts != 1 || !messages[0].NextAttemptAt.After(time.Now()) {
+		t.Fatalf("messages = %+v, want one rescheduled message", messages)
+	}
+	if DispatchOutbox() != 0 || len(calls) != 1 {
+		t.Errorf("message retried before its backoff, calls = %+v", calls)
+	}
+
+	// tokendata is back once the backoff passed.
+	callErr = nil
+	if _, err := outbox.ClaimOutbox(context.Background(), messages[0], time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if delivered := DispatchOutbox(); delivered != 1 || len(outbox.Messages()) != 0 {
+		t.Errorf("delivered = %d, messages left = %+v", delivered, outbox.Messages())
+	}
+	if len(calls) != 2 || calls[1].Reason != "wallet_token" || calls[1].TokenAddresses[0] != "0xa" {
+		t.Errorf("calls = %+v", calls)
+	}
+
+	// Calls tokendata rejects are not retried.
+	callErr = status.Error(codes.InvalidArgument, "invalid token address")
+	enqueueOutbox(outboxAddTokens, addTokensMessage{TokenAddresses: []string{"bad"}})
+	DispatchOutbox()
+	if messages := outbox.Messages(); len(messages) != 0 {
+		t.Errorf("rejected message kept: %+v", messages)
+	}
+
+	// An outage stops the batch: the messages behind the first one are not tried.
+	callErr = status.Error(codes.DeadlineExceeded, "timeout")
+	calls = calls[:0]
+	for _, address := range []string{"0x1", "0x2", "0x3"} {
+		enqueueOutbox(outboxAddTokens, addTokensMessage{TokenAddresses: []string{address}})
+	}
+	DispatchOutbox()
+	if len(calls) != 1 || len(outbox.Messages()) != 3 {
+		t.Errorf("calls = %+v during an outage, messages = %+v", calls, outbox.Messages())
+	}
+	for _, message := range outbox.Messages() {
+		if err := outbox.DeleteOutbox(context.Background(), message.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Messages tokendata keeps failing on are dropped after maxOutboxAttempts.
+	callErr = status.Error(codes.Internal, "boom")
+	enqueueOutbox(outboxAddTokens, addTokensMessage{TokenAddresses: []string{"0xb"}})
+	for attempt := 0; attempt < maxOutboxAttempts; attempt++ {
+		messages := outbox.Messages()
+		if len(messages) != 1 {
+			t.Fatalf("attempt %d: messages = %+v", attempt, messages)
+		}
+		if _, err := outbox.ClaimOutbox(context.Background(), messages[0], time.Now()); err != nil {
+			t.Fatal(err)
+		}
+		DispatchOutbox()
+	}
+	if messages := outbox.Messages(); len(messages) != 0 {
+		t.Errorf("message kept after %d attempts: %+v", maxOutboxAttempts, messages)
+	}
+}
+
+func TestOutboxBackoff(t *testing.T) {
+	if outboxBackoff(1) != outboxRetryBackoff || outboxBackoff(3) != 4*outboxRetryBackoff {
+		t.Errorf("backoff = %s, %s", outboxBackoff(1), outboxBackoff(3))
+	}
+	if outboxBackoff(100) != maxOutboxBackoff {
+		t.Errorf("backoff after 100 attempts = %s, want %s", outboxBackoff(100), maxOutboxBackoff)
+	}
+}
@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"samterminal/pkg/numeric"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	db "walletdata/generated/prisma"
+	wallet_proto "walletdata/proto/wallet"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	defaultLeaderboardPageSize = 20
+	maxLeaderboardPageSize     = 100
+)
+
+// SaveWalletTrade records a decoded trade, at its timestamp when it has one, so it can be used for
+// PnL. Replayed transactions are ignored.
+func SaveWalletTrade(trade *wallet_proto.WalletTrade) error {
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	tx := getDB()
+
+	side := db.TradeSideBuy
+	if trade.Side == wallet_proto.TradeSide_SELL {
+		side = db.TradeSideSell
+	}
+	tokenAmount := numeric.ParseOrZero(trade.TokenAmount)
+	priceUsd := numeric.ParseOrZero(trade.PriceUsd)
+	usdValue := numeric.ParseOrZero(trade.UsdValue)
+
+	walletAddress := strings.ToLower(trade.WalletAddress)
+	tokenAddress := strings.ToLower(trade.TokenAddress)
+	createdAt := time.Now()
+	if trade.Timestamp > 0 {
+		createdAt = time.Unix(trade.Timestamp, 0)
+	}
+	_, err := tx.Trade.UpsertOne(
+		db.Trade.TxHashWalletAddressTokenAddress(
+			db.Trade.TxHash.Equals(trade.TxHash),
+			db.Trade.WalletAddress.Equals(walletAddress),
+			db.Trade.TokenAddress.Equals(tokenAddress),
+		),
+	).Create(
+		db.Trade.WalletAddress.Set(walletAddress),
+		db.Trade.TokenAddress.Set(tokenAddress),
+		db.Trade.Side.Set(side),
+		db.Trade.TokenAmount.Set(tokenAmount),
+		db.Trade.PriceUsd.Set(priceUsd),
+		db.Trade.UsdValue.Set(usdValue),
+		db.Trade.TxHash.Set(trade.TxHash),
+		db.Trade.CreatedAt.Set(createdAt),
+	).Update().Exec(ctx)
+	return err
+}
+
+type walletPnL struct {
+	walletAddress string
+	realized      decimal.Decimal
+	trades        int32
+	wins          int32
+}
+
+func (p walletPnL) winRate() float64 {
+	if p.trades == 0 {
+		return 0
+	}
+	return float64(p.wins) / float64(p.trades)
+}
+
+type position struct {
+	amount  decimal.Decimal
+	costUsd decimal.Decimal
+}
+
+// realizedPnL replays a wallet's trades in order with average cost basis. Only sells made after
+// since count towards the result, but older buys still set the cost basis.
+func realizedPnL(walletAddress string, trades []db.TradeModel, since time.Time) walletPnL {
+	result := walletPnL{walletAddress: walletAddress}
+	positions := map[string]*position{}
+	for _, trade := range trades {
+		pos, ok := positions[trade.TokenAddress]
+		if !ok {
+			pos = &position{}
+			positions[trade.TokenAddress] = pos
+		}
+		if trade.Side == db.TradeSideBuy {
+			pos.amount = pos.amount.Add(trade.TokenAmount)
+			pos.costUsd = pos.costUsd.Add(trade.UsdValue)
+			continue
+		}
+
+		// Tokens received before the wallet was watched have no known cost and are skipped.
+		sold := decimal.Min(trade.TokenAmount, pos.amount)
+		if !sold.IsPositive() {
+			continue
+		}
+		averageCost := pos.costUsd.Div(pos.amount)
+		pnl := sold.Mul(trade.PriceUsd.Sub(averageCost))
+		pos.costUsd = pos.costUsd.Sub(sold.Mul(averageCost))
+		pos.amount = pos.amount.Sub(sold)
+
+		if trade.CreatedAt.Before(since) {
+			continue
+		}
+		result.realized = result.realized.Add(pnl)
+		result.trades++
+		if pnl.IsPositive() {
+			result.wins++
+		}
+	}
+	return result
+}
+
+func leaderboardSince(period wallet_proto.LeaderboardPeriod) time.Time {
+	days := 7
+	if period == wallet_proto.LeaderboardPeriod_PERIOD_30D {
+		days = 30
+	}
+	return time.Now().AddDate(0, 0, -days)
+}
+
+// GetWalletLeaderboard ranks watched wallets by realized PnL over the period, breaking ties by win
+// rate. Pages start at 1.
+func GetWalletLeaderboard(ctx context.Context, period wallet_proto.LeaderboardPeriod, page int32, pageSize int32) (*wallet_proto.GetWalletLeaderboardResponse, error) {
+	ctx, cancel := getCtx(ctx)
+	defer cancel()
+	tx := getDB()
+
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = defaultLeaderboardPageSize
+	}
+	pageSize = min(pageSize, maxLeaderboardPageSize)
+	since := leaderboardSince(period)
+
+	recentSells, err := tx.Trade.FindMany(
+		db.Trade.Side.Equals(db.TradeSideSell),
+		db.Trade.CreatedAt.Gte(since),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	walletAddresses := []string{}
+	seen := map[string]bool{}
+	for _, trade := range recentSells {
+		if !seen[trade.WalletAddress] {
+			seen[trade.WalletAddress] = true
+			walletAddresses = append(walletAddresses, trade.WalletAddress)
+		}
+	}
+
+	trades, err := tx.Trade.FindMany(
+		db.Trade.WalletAddress.In(walletAddresses),
+	).OrderBy(
+		db.Trade.CreatedAt.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tradesByWallet := map[string][]db.TradeModel{}
+	for _, trade := range trades {
+		tradesByWallet[trade.WalletAddress] = append(tradesByWallet[trade.WalletAddress], trade)
+	}
+
+	ranking := []walletPnL{}
+	for _, walletAddress := range walletAddresses {
+		result := realizedPnL(walletAddress, tradesByWallet[walletAddress], since)
+		if result.trades > 0 {
+			ranking = append(ranking, result)
+		}
+	}
+	sort.SliceStable(ranking, func(i, j int) bool {
+		if !ranking[i].realized.Equal(ranking[j].realized) {
+			return ranking[i].realized.GreaterThan(ranking[j].realized)
+		}
+		return ranking[i].winRate() > ranking[j].winRate()
+	})
+
+	response := &wallet_proto.GetWalletLeaderboardResponse{
+		Page:     page,
+		PageSize: pageSize,
+		Total:    int32(len(ranking)),
+	}
+	start := int((page - 1) * pageSize)
+	if start >= len(ranking) {
+		return response, nil
+	}
+	end := min(start+int(pageSize), len(ranking))
+
+	labels := map[string]string{}
+	pageWallets := []string{}
+	for _, result := range ranking[start:end] {
+		pageWallets = append(pageWallets, result.walletAddress)
+	}
+	wallets, err := tx.Wallet.FindMany(db.Wallet.Address.In(pageWallets)).Exec(ctx)
+	if err != nil {
+		log.Println("Error getting leaderboard wallet labels:", err)
+	}
+	for _, wallet := range wallets {
+		if label, ok := wallet.Label(); ok {
+			labels[wallet.Address] = label
+		}
+	}
+
+	for i, result := range ranking[start:end] {
+		response.Entries = append(response.Entries, &wallet_proto.LeaderboardEntry{
+			Rank:           int32(start + i + 1),
+			WalletAddress:  result.walletAddress,
+			WalletLabel:    labels[result.walletAddress],
+			RealizedPnlUsd: numeric.FormatUSD(result.realized),
+			WinRate:        strconv.FormatFloat(result.winRate(), 'f', 4, 64),
+			TradeCount:     result.trades,
+			WinningTrades:  result.wins,
+		})
+	}
+	return response, nil
+}
@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"samterminal/pkg/numeric"
+	"strings"
+	"time"
+	"walletdata/env"
+	db "walletdata/generated/prisma"
+	wallet_proto "walletdata/proto/wallet"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	defaultSnapshotHourlyAfterDays = 1
+	defaultSnapshotDailyAfterDays  = 30
+	snapshotCompactionInterval     = time.Hour
+	snapshotDeleteChunkSize        = 1000
+)
+
+// portfolioResolutions maps each chart range to its length and the bucket size snapshots are
+// downsampled to, keeping every range at a few hundred points.
+var portfolioResolutions = map[wallet_proto.PortfolioRange]struct {
+	span       time.Duration
+	resolution time.Duration
+}{
+	wallet_proto.PortfolioRange_RANGE_24H: {24 * time.Hour, 5 * time.Minute},
+	wallet_proto.PortfolioRange_RANGE_30D: {30 * 24 * time.Hour, time.Hour},
+	wallet_proto.PortfolioRange_RANGE_1Y:  {365 * 24 * time.Hour, 24 * time.Hour},
+}
+
+// savePortfolioSnapshot records the current value of a wallet for its portfolio history.
+func savePortfolioSnapshot(walletAddress string, dollarValue string, nativeBalance *string) error {
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+
+	valueUsd, err := numeric.Parse(dollarValue)
+	if err != nil {
+		return err
+	}
+	return walletStore.SavePortfolioSnapshot(ctx, strings.ToLower(walletAddress), valueUsd, nativeBalance)
+}
+
+// downsampleSnapshots keeps the last snapshot of every bucket between start and end. Buckets
+// without snapshots repeat the previous value so charts have no gaps; buckets before the first
+// known value are left out. snapshots must be sorted by time.
+func downsampleSnapshots(snapshots []db.PortfolioSnapshotModel, start time.Time, end time.Time, resolution time.Duration) []*wallet_proto.PortfolioPoint {
+	points := []*wallet_proto.PortfolioPoint{}
+	i := 0
+	hasValue := false
+	value := decimal.Zero
+	for bucket := start.Truncate(resolution); !bucket.After(end); bucket = bucket.Add(resolution) {
+		bucketEnd := bucket.Add(resolution)
+		for i < len(snapshots) && snapshots[i].CreatedAt.Before(bucketEnd) {
+			value = snapshots[i].ValueUsd
+			hasValue = true
+			i++
+		}
+		if !hasValue {
+			continue
+		}
+		points = append(points, &wallet_proto.PortfolioPoint{
+			Timestamp: bucket.Unix(),
+			ValueUsd:  numeric.FormatUSD(value),
+		})
+	}
+	return points
+}
+
+// GetPortfolioHistory returns the value of a wallet over a range, downsampled to the resolution
+// of that range.
+func GetPortfolioHistory(ctx context.Context, walletAddress string, portfolioRange wallet_proto.PortfolioRange) (*wallet_proto.GetPortfolioHistoryResponse, error) {
+	ctx, cancel := getCtx(ctx)
+	defer cancel()
+	tx := getDB()
+
+	config, ok := portfolioResolutions[portfolioRange]
+	if !ok {
+		config = portfolioResolutions[wallet_proto.PortfolioRange_RANGE_24H]
+	}
+	walletAddress = strings.ToLower(walletAddress)
+	end := time.Now()
+	start := end.Add(-config.span)
+
+	snapshots := []db.PortfolioSnapshotModel{}
+	// The last snapshot before the range gives the value at its start.
+	previous, err := tx.PortfolioSnapshot.FindFirst(
+		db.PortfolioSnapshot.WalletAddress.Equals(walletAddress),
+		db.PortfolioSnapshot.CreatedAt.Lt(start),
+	).OrderBy(
+		db.PortfolioSnapshot.CreatedAt.Order(db.SortOrderDesc),
+	).Exec(ctx)
+	if err == nil {
+		snapshots = append(snapshots, *previous)
+	}
+	inRange, err := tx.PortfolioSnapshot.FindMany(
+		db.PortfolioSnapshot.WalletAddress.Equals(walletAddress),
+		db.PortfolioSnapshot.CreatedAt.Gte(start),
+	).OrderBy(
+		db.PortfolioSnapshot.CreatedAt.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	snapshots = append(snapshots, inRange...)
+
+	return &wallet_proto.GetPortfolioHistoryResponse{
+		WalletAddress:     walletAddress,
+		Range:             portfolioRange,
+		ResolutionSeconds: int64(config.resolution.Seconds()),
+		Points:            downsampleSnapshots(snapshots, start, end, config.resolution),
+	}, nil
+}
+
+// snapshotRetention controls how portfolio snapshots are thinned out as they age.
+type snapshotRetention struct {
+	hourlyAfter time.Duration
+	dailyAfter  time.Duration
+	// maxAge is zero when snapshots are never deleted.
+	maxAge time.Duration
+}
+
+func loadSnapshotRetention() snapshotRetention {
+	day := 24 * time.Hour
+	retention := snapshotRetention{
+		hourlyAfter: time.Duration(env.SNAPSHOT_HOURLY_AFTER_DAYS.GetEnvAsNumberOrDefault(defaultSnapshotHourlyAfterDays)) * day,
+		dailyAfter:  time.Duration(env.SNAPSHOT_DAILY_AFTER_DAYS.GetEnvAsNumberOrDefault(defaultSnapshotDailyAfterDays)) * day,
+		maxAge:      time.Duration(env.SNAPSHOT_RETENTION_DAYS.GetEnvAsNumberOrDefault(0)) * day,
+	}
+	if retention.hourlyAfter <= 0 || retention.dailyAfter < retention.hourlyAfter {
+		log.Println("Invalid snapshot retention, using defaults")
+		retention.hourlyAfter = defaultSnapshotHourlyAfterDays * day
+		retention.dailyAfter = defaultSnapshotDailyAfterDays * day
+	}
+	if retention.maxAge < 0 || (retention.maxAge > 0 && retention.maxAge < retention.dailyAfter) {
+		log.Println("Snapshot retention is shorter than the daily threshold, keeping snapshots forever")
+		retention.maxAge = 0
+	}
+	return retention
+}
+
+// snapshotsToCompact returns the ids of the snapshots to delete so that only the last snapshot of
+// each hour, or each day for older snapshots, remains. snapshots must be sorted by time.
+func snapshotsToCompact(snapshots []db.PortfolioSnapshotModel, now time.Time, retention snapshotRetention) []string {
+	ids := []string{}
+	for i, snapshot := range snapshots {
+		age := now.Sub(snapshot.CreatedAt)
+		if retention.maxAge > 0 && age > retention.maxAge {
+			ids = append(ids, snapshot.ID)
+			continue
+		}
+		bucket := time.Hour
+		if age > retention.dailyAfter {
+			bucket = 24 * time.Hour
+		}
+		// A snapshot survives when the next one falls into another bucket.
+		if i+1 < len(snapshots) && snapshots[i+1].CreatedAt.Truncate(bucket).Equal(snapshot.CreatedAt.Truncate(bucket)) {
+			ids = append(ids, snapshot.ID)
+		}
+	}
+	return ids
+}
+
+// CompactPortfolioSnapshots thins out old snapshots of every wallet so the table stops growing
+// with the number of updates of long-tracked wallets.
+func CompactPortfolioSnapshots() {
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	tx := getDB()
+
+	retention := loadSnapshotRetention()
+	now := time.Now()
+	wallets, err := tx.Wallet.FindMany().Exec(ctx)
+	if err != nil {
+		log.Println("Error getting wallets for snapshot compaction:", err)
+		return
+	}
+	deleted := 0
+	for _, wallet := range wallets {
+		snapshots, err := tx.PortfolioSnapshot.FindMany(
+			db.PortfolioSnapshot.WalletAddress.Equals(wallet.Address),
+			db.PortfolioSnapshot.CreatedAt.Lt(now.Add(-retention.hourlyAfter)),
+		).OrderBy(
+			db.PortfolioSnapshot.CreatedAt.Order(db.SortOrderAsc),
+		).Exec(ctx)
+		if err != nil {
+			log.Println("Error getting snapshots of", wallet.Address, ":", err)
+			continue
+		}
+		ids := snapshotsToCompact(snapshots, now, retention)
+		for i := 0; i < len(ids); i += snapshotDeleteChunkSize {
+			result, err := tx.PortfolioSnapshot.FindMany(
+				db.PortfolioSnapshot.ID.In(ids[i:min(i+snapshotDeleteChunkSize, len(ids))]),
+			).Delete().Exec(ctx)
+			if err != nil {
+				log.Println("Error deleting snapshots of", wallet.Address, ":", err)
+				break
+			}
+			deleted += result.Count
+		}
+	}
+	if deleted > 0 {
+		log.Println("Compacted portfolio snapshots, deleted", deleted)
+	}
+}
+
+// StartSnapshotCompaction compacts snapshots now and then every hour.
+func StartSnapshotCompaction() {
+	go func() {
+		ticker := time.NewTicker(snapshotCompactionInterval)
+		defer ticker.Stop()
+		for {
+			CompactPortfolioSnapshots()
+			<-ticker.C
+		}
+	}()
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"samterminal/pkg/numeric"
+	"strings"
+	"time"
+	token_client "walletdata/lib/grpc/client/token"
+	proto "walletdata/proto/token"
+
+	"github.com/shopspring/decimal"
+)
+
+// maxPriceQueries is the most prices tokendata looks up in one getTokenPricesAt call.
+const maxPriceQueries = 500
+
+// tokenAt is a token at a time, in unix seconds.
+type tokenAt struct {
+	token     string
+	timestamp int64
+}
+
+// getPricesAt returns the USD prices tokendata recorded for tokens at or before the given times,
+// at most maxAge before them; 0 uses the max age of tokendata. Tokens without a price of that
+// time are left out.
+func getPricesAt(queries []tokenAt, maxAge time.Duration) map[tokenAt]decimal.Decimal {
+	prices := map[tokenAt]decimal.Decimal{}
+	unique := []tokenAt{}
+	seen := map[tokenAt]bool{}
+	for _, query := range queries {
+		query.token = strings.ToLower(query.token)
+		if !seen[query] {
+			seen[query] = true
+			unique = append(unique, query)
+		}
+	}
+	for i := 0; i < len(unique); i += maxPriceQueries {
+		chunk := unique[i:min(i+maxPriceQueries, len(unique))]
+		request := make([]*proto.TokenPriceAtQuery, 0, len(chunk))
+		for _, query := range chunk {
+			request = append(request, &proto.TokenPriceAtQuery{TokenAddress: query.token, Timestamp: query.timestamp * 1000, MaxAge: maxAge.Milliseconds()})
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		response, err := token_client.GetTokenPricesAt(ctx, request)
+		cancel()
+		if err != nil {
+			log.Println("Error getting token prices at transaction times:", err)
+			continue
+		}
+		for j, price := range response.GetPrices() {
+			if j >= len(chunk) || !price.GetFound() {
+				continue
+			}
+			if value, err := numeric.Parse(price.GetPrice()); err == nil {
+				prices[chunk[j]] = value
+			}
+		}
+	}
+	return prices
+}
+
+// txPrices values tokens at the time of transactions, at the price recorded shortly before the
+// transaction. Transactions older than the price history have no price; the current price says
+// nothing about them.
+type txPrices map[tokenAt]decimal.Decimal
+
+// at returns the USD price of a token at a time in unix seconds.
+func (p txPrices) at(token string, timestamp int64) (decimal.Decimal, bool) {
+	price, ok := p[tokenAt{token: strings.ToLower(token), timestamp: timestamp}]
+	return price, ok
+}
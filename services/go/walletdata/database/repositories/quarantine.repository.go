@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	db "walletdata/generated/prisma"
+	"walletdata/lib/api"
+	"walletdata/proto/common"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+var ErrInvalidTokenAddress = errors.New("invalid token address")
+
+// quarantineTokens splits the tokens of a wallet into the ones it lists, the secure tokens and
+// the spam tokens marked safe, and the addresses of the quarantined spam tokens.
+func quarantineTokens(status *api.TokenStatusResponse, safeTokens []string) ([]*common.WalletToken, []string) {
+	listed := slices.Clone(status.SecureTokens)
+	quarantined := []string{}
+	for i := range status.InsecureTokens {
+		address := strings.ToLower(status.InsecureTokens[i].TokenAddress)
+		if slices.Contains(safeTokens, address) {
+			listed = append(listed, status.InsecureTokens[i])
+			continue
+		}
+		quarantined = append(quarantined, address)
+	}
+	return listed, quarantined
+}
+
+// MarkTokenSafe lists a quarantined spam token in the token list of a wallet despite the global
+// blacklist. Revoking quarantines it again from the next update of the wallet.
+func MarkTokenSafe(ctx context.Context, walletAddress string, tokenAddress string, revoke bool) error {
+	if !gethcommon.IsHexAddress(tokenAddress) {
+		return ErrInvalidTokenAddress
+	}
+	tokenAddress = strings.ToLower(tokenAddress)
+	ctx, cancel := getCtx(ctx)
+	defer cancel()
+	wallet, err := walletStore.FindWallet(ctx, strings.ToLower(walletAddress))
+	if err != nil {
+		return err
+	}
+
+	safeTokens := slices.DeleteFunc(slices.Clone(wallet.SafeTokens), func(address string) bool { return address == tokenAddress })
+	params := []db.WalletSetParam{}
+	if !revoke {
+		safeTokens = append(safeTokens, tokenAddress)
+		if slices.Contains(wallet.QuarantinedTokens, tokenAddress) {
+			params = append(params,
+				db.Wallet.QuarantinedTokens.Set(slices.DeleteFunc(slices.Clone(wallet.QuarantinedTokens), func(address string) bool { return address == tokenAddress })),
+				db.Wallet.Tokens.Set(append(slices.Clone(wallet.Tokens), tokenAddress)),
+			)
+		}
+	}
+	params = append(params, db.Wallet.SafeTokens.Set(safeTokens))
+
+	tx := getDB()
+	_, err = tx.Wallet.FindUnique(
+		db.Wallet.Address.Equals(wallet.Address),
+	).Update(params...).Exec(ctx)
+	return err
+}
+
+// WalletSafeTokens returns the tokens a wallet marked safe, none when it is not tracked.
+func WalletSafeTokens(ctx context.Context, walletAddress string) []string {
+	ctx, cancel := getCtx(ctx)
+	defer cancel()
+	wallet, err := walletStore.FindWallet(ctx, strings.ToLower(walletAddress))
+	if err != nil {
+		return []string{}
+	}
+	return wallet.SafeTokens
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"slices"
+	"testing"
+	"walletdata/lib/api"
+	"walletdata/proto/common"
+)
+
+func TestQuarantineTokens(t *testing.T) {
+	status := &api.TokenStatusResponse{
+		SecureTokens:   []*common.WalletToken{{TokenAddress: "0xaaaa"}},
+		InsecureTokens: []*common.WalletToken{{TokenAddress: "0xBBBB", Quarantined: true}, {TokenAddress: "0xcccc", Quarantined: true}},
+	}
+	listed, quarantined := quarantineTokens(status, []string{"0xbbbb"})
+	if len(listed) != 2 || listed[0].TokenAddress != "0xaaaa" || listed[1].TokenAddress != "0xBBBB" {
+		t.Errorf("listed = %v", listed)
+	}
+	if !slices.Equal(quarantined, []string{"0xcccc"}) {
+		t.Errorf("quarantined = %v", quarantined)
+	}
+}
@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"strings"
+	"sync"
+	"time"
+	"walletdata/env"
+	shard_client "walletdata/lib/grpc/client/shard"
+	wallet_proto "walletdata/proto/wallet"
+	"walletdata/rpc"
+)
+
+// walletShards is the shard of this replica among the replicas splitting the wallet
+// subscriptions. It is read from the env on every use, so new settings loaded on SIGHUP apply
+// to the next wallets and to the next rebalance.
+type walletShards struct {
+	id    int
+	count int
+	peers []string
+}
+
+var invalidShardsLogged sync.Map
+
+func currentShards() walletShards {
+	count := int(env.WALLET_SHARD_COUNT.GetEnvAsNumberOrDefault(1))
+	id := int(env.WALLET_SHARD_ID.GetEnvAsNumberOrDefault(0))
+	if count < 1 || id < 0 || id >= count {
+		// Watching every wallet is safer than dropping some: at worst they are watched twice.
+		if _, logged := invalidShardsLogged.LoadOrStore(fmt.Sprintf("%d/%d", id, count), true); !logged {
+			log.Printf("Invalid wallet shard %d of %d, watching every wallet", id, count)
+		}
+		return walletShards{id: 0, count: 1}
+	}
+	shards := walletShards{id: id, count: count}
+	for _, peer := range strings.Split(env.WALLET_SHARD_PEERS.GetEnv(), ",") {
+		shards.peers = append(shards.peers, strings.TrimSpace(peer))
+	}
+	return shards
+}
+
+// shardOf returns the shard of a wallet among count shards.
+func shardOf(walletAddress string, count int) int {
+	hash := fnv.New32a()
+	hash.Write([]byte(strings.ToLower(walletAddress)))
+	return int(hash.Sum32() % uint32(count))
+}
+
+func (s walletShards) owns(walletAddress string) bool {
+	return shardOf(walletAddress, s.count) == s.id
+}
+
+// peer returns the gRPC address of the replica of a shard, empty when it is not configured.
+func (s walletShards) peer(shard int) string {
+	if shard < 0 || shard >= len(s.peers) {
+		return ""
+	}
+	return s.peers[shard]
+}
+
+// sendToShard hands a wallet this replica added to the replica of its shard. Without a peer
+// address, or when the replica cannot be reached, the wallet is watched once that replica
+// rebalances or restarts.
+func sendToShard(walletAddress string) {
+	shards := currentShards()
+	if shards.owns(walletAddress) {
+		return
+	}
+	shard := shardOf(walletAddress, shards.count)
+	peer := shards.peer(shard)
+	if peer == "" {
+		log.Printf("No peer address for wallet shard %d, %s is watched once it rebalances", shard, walletAddress)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shard_client.AddWallet(ctx, peer, walletAddress); err != nil {
+		log.Printf("Error sending %s to wallet shard %d: %v", walletAddress, shard, err)
+	}
+}
+
+// walletWatcher is the subscription of a watched wallet.
+type walletWatcher struct {
+	stop func()
+}
+
+// walletWatchers holds the subscriptions of the wallets this replica watches.
+type walletWatchers struct {
+	mu       sync.Mutex
+	watchers map[string]*walletWatcher
+}
+
+var watchers = &walletWatchers{watchers: map[string]*walletWatcher{}}
+
+// start subscribes to a wallet unless it is watched already.
+func (w *walletWatchers) start(walletAddress string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, ok := w.watchers[walletAddress]; ok {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	subscription, err := rpc.SubscribeWalletTransactions(ctx, walletAddress, func(event rpc.WalletTransaction) {
+		handleWalletTransaction(walletAddress, event)
+	})
+	if err != nil {
+		cancel()
+		return err
+	}
+	watcher := &walletWatcher{stop: cancel}
+	w.watchers[walletAddress] = watcher
+	// Transactions are handled by the callback. The events are drained so the subscription never
+	// blocks on them, and the wallet is dropped once the subscription ends so a rebalance
+	// watches it again.
+	go func() {
+		for range subscription.Events {
+		}
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		if w.watchers[walletAddress] == watcher {
+			delete(w.watchers, walletAddress)
+		}
+	}()
+	return nil
+}
+
+// stop ends the subscription of a wallet and reports whether it was watched.
+func (w *walletWatchers) stop(walletAddress string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	watcher, ok := w.watchers[walletAddress]
+	if !ok {
+		return false
+	}
+	watcher.stop()
+	delete(w.watchers, walletAddress)
+	return true
+}
+
+func (w *walletWatchers) addresses() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	addresses := make([]string, 0, len(w.watchers))
+	for address := range w.watchers {
+		addresses = append(addresses, address)
+	}
+	return addresses
+}
+
+// RebalanceWalletWatchers watches the tracked wallets of the shard of this replica and stops
+// watching the others. In webhook ingestion mode there are no subscriptions to balance.
+func RebalanceWalletWatchers() (*wallet_proto.RebalanceWalletShardsResponse, error) {
+	shards := currentShards()
+	response := &wallet_proto.RebalanceWalletShardsResponse{ShardId: int32(shards.id), ShardCount: int32(shards.count)}
+	if WebhookIngestion() {
+		return response, nil
+	}
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	wallets, err := walletStore.ListWallets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	owned := map[string]bool{}
+	for _, wallet := range wallets {
+		walletAddress := strings.ToLower(wallet.Address)
+		if shards.owns(walletAddress) {
+			owned[walletAddress] = true
+		}
+	}
+	for _, walletAddress := range watchers.addresses() {
+		if !owned[walletAddress] && watchers.stop(walletAddress) {
+			response.Stopped++
+		}
+	}
+	watching := map[string]bool{}
+	for _, walletAddress := range watchers.addresses() {
+		watching[walletAddress] = true
+	}
+	for walletAddress := range owned {
+		if watching[walletAddress] {
+			continue
+		}
+		if err := watchers.start(walletAddress); err != nil {
+			log.Println("Error starting wallet watcher for", walletAddress, ":", err)
+			continue
+		}
+		response.Started++
+	}
+	response.Watching = int32(len(watchers.addresses()))
+	return response, nil
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"fmt"
+	"testing"
+	"walletdata/database/store/mock"
+)
+
+// walletOfShard returns a wallet address of a shard among count shards.
+func walletOfShard(t *testing.T, shard int, count int) string {
+	t.Helper()
+	for i := 0; i < 1000; i++ {
+		address := fmt.Sprintf("0x%040x", i)
+		if shardOf(address, count) == shard {
+			return address
+		}
+	}
+	t.Fatalf("no wallet of shard %d of %d", shard, count)
+	return ""
+}
+
+func TestShardOf(t *testing.T) {
+	counts := make([]int, 4)
+	for i := 0; i < 4000; i++ {
+		counts[shardOf(fmt.Sprintf("0x%040x", i), 4)]++
+	}
+	for shard, count := range counts {
+		if count < 800 || count > 1200 {
+			t.Errorf("shard %d has %d of 4000 wallets", shard, count)
+		}
+	}
+	if shardOf("0xABCDEF0000000000000000000000000000000000", 4) != shardOf("0xabcdef0000000000000000000000000000000000", 4) {
+		t.Error("the shard of a wallet depends on the case of its address")
+	}
+}
+
+func TestCurrentShards(t *testing.T) {
+	t.Setenv("WALLET_SHARD_COUNT", "3")
+	t.Setenv("WALLET_SHARD_ID", "1")
+	t.Setenv("WALLET_SHARD_PEERS", "walletdata-0:50062, walletdata-1:50062")
+	shards := currentShards()
+	if shards.id != 1 || shards.count != 3 {
+		t.Fatalf("shards = %+v, want shard 1 of 3", shards)
+	}
+	if shards.peer(1) != "walletdata-1:50062" || shards.peer(2) != "" {
+		t.Errorf("peers = %q", shards.peers)
+	}
+	if !shards.owns(walletOfShard(t, 1, 3)) || shards.owns(walletOfShard(t, 2, 3)) {
+		t.Error("shard 1 owns the wallets of another shard")
+	}
+
+	// A shard outside the count watches every wallet rather than none.
+	t.Setenv("WALLET_SHARD_ID", "3")
+	if shards := currentShards(); shards.count != 1 || !shards.owns(walletOfShard(t, 2, 3)) {
+		t.Errorf("shards = %+v for an invalid shard id, want every wallet", shards)
+	}
+}
+
+func TestRebalanceWalletWatchers(t *testing.T) {
+	t.Setenv("WALLET_SHARD_COUNT", "2")
+	t.Setenv("WALLET_SHARD_ID", "0")
+	t.Setenv("RPC_WS_URL", "")
+	owned, other := walletOfShard(t, 0, 2), walletOfShard(t, 1, 2)
+	defer SetWalletStore(mock.NewWalletStore(mock.NewWallet(owned), mock.NewWallet(other)))()
+
+	stopped := false
+	watchers.watchers[other] = &walletWatcher{stop: func() { stopped = true }}
+	defer delete(watchers.watchers, other)
+
+	response, err := RebalanceWalletWatchers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stopped || response.Stopped != 1 {
+		t.Errorf("watcher of the other shard stopped = %v, response = %+v", stopped, response)
+	}
+	// Without a websocket endpoint the wallet of this shard cannot be subscribed to.
+	if response.Started != 0 || response.Watching != 0 || response.ShardCount != 2 {
+		t.Errorf("response = %+v", response)
+	}
+}
@@ -0,0 +1,29 @@
+package repository
+
+import "walletdata/database/store"
+
+// walletStore serves wallet lookups, valuations and portfolio snapshots.
+var walletStore store.WalletStore = store.NewPrisma()
+
+// SetWalletStore replaces the store of the repository, e.g. with a mock.WalletStore in tests, and
+// returns a function restoring the previous one.
+func SetWalletStore(s store.WalletStore) (restore func()) {
+	previous := walletStore
+	walletStore = s
+	return func() {
+		walletStore = previous
+	}
+}
+
+// outboxStore holds the calls to tokendata waiting for the outbox dispatcher.
+var outboxStore store.OutboxStore = store.NewPrisma()
+
+// SetOutboxStore replaces the outbox store of the repository and returns a function restoring
+// the previous one.
+func SetOutboxStore(s store.OutboxStore) (restore func()) {
+	previous := outboxStore
+	outboxStore = s
+	return func() {
+		outboxStore = previous
+	}
+}
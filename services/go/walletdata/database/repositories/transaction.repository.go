@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"walletdata/lib/activity"
+	"walletdata/lib/api"
+	api_dto "walletdata/lib/api/dto"
+	"walletdata/lib/trades"
+	wallet_proto "walletdata/proto/wallet"
+	"walletdata/rpc"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	defaultTransactionsPageSize = 20
+	// maxTransactionsPageSize bounds the receipts read for one page.
+	maxTransactionsPageSize = 50
+)
+
+// GetWalletTransactions returns a page of the transactions a wallet sent or received ETH in,
+// newest first, with what each of them did. Pages start at 1. The receipts of the page are read
+// for their logs; transactions whose receipt cannot be read are classified from their input.
+func GetWalletTransactions(walletAddress string, page int32, pageSize int32) (*wallet_proto.GetWalletTransactionsResponse, error) {
+	walletAddress = strings.ToLower(walletAddress)
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = defaultTransactionsPageSize
+	}
+	pageSize = min(pageSize, maxTransactionsPageSize)
+
+	transactions, err := api.GetRecentWalletTransactions(walletAddress, int(page), int(pageSize))
+	if err != nil {
+		return nil, err
+	}
+	logs := make([][]*types.Log, len(transactions))
+	var wg sync.WaitGroup
+	for i, transaction := range transactions {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			receiptLogs, err := rpc.GetTransactionLogs(common.HexToHash(transaction.Hash))
+			if err != nil {
+				log.Println("Error reading receipt of", transaction.Hash, ":", err)
+				return
+			}
+			logs[i] = receiptLogs
+		}()
+	}
+	wg.Wait()
+
+	response := &wallet_proto.GetWalletTransactionsResponse{
+		WalletAddress: walletAddress,
+		Page:          page,
+		PageSize:      pageSize,
+		Transactions:  []*wallet_proto.WalletTransaction{},
+	}
+	for i, transaction := range transactions {
+		response.Transactions = append(response.Transactions, walletTransactionOf(walletAddress, transaction, logs[i]))
+	}
+	setTransactionValuesUsd(response.Transactions, getPricesAt(nativeQueries(response.Transactions), 0))
+	return response, nil
+}
+
+// nativeQueries lists the times the native token price is needed at to value transactions.
+func nativeQueries(transactions []*wallet_proto.WalletTransaction) []tokenAt {
+	nativeToken := strings.ToLower(trades.NativeToken().Hex())
+	queries := []tokenAt{}
+	for _, transaction := range transactions {
+		if !parseWei(transaction.ValueWei).IsZero() {
+			queries = append(queries, tokenAt{token: nativeToken, timestamp: transaction.Timestamp})
+		}
+	}
+	return queries
+}
+
+// setTransactionValuesUsd values the ETH of transactions at the native token price of their time.
+// Transactions moving no ETH are worth 0 and those without a price of their time are left unset.
+func setTransactionValuesUsd(transactions []*wallet_proto.WalletTransaction, prices map[tokenAt]decimal.Decimal) {
+	nativeToken := strings.ToLower(trades.NativeToken().Hex())
+	for _, transaction := range transactions {
+		eth := parseWei(transaction.ValueWei)
+		if eth.IsZero() {
+			transaction.ValueUsd = "0.00"
+			continue
+		}
+		if price, ok := prices[tokenAt{token: nativeToken, timestamp: transaction.Timestamp}]; ok {
+			transaction.ValueUsd = eth.Mul(price).StringFixed(2)
+		}
+	}
+}
+
+// walletTransactionOf classifies an Etherscan transaction of a wallet. Contract creations have no
+// recipient.
+func walletTransactionOf(walletAddress string, transaction api_dto.Transaction, logs []*types.Log) *wallet_proto.WalletTransaction {
+	from, to := strings.ToLower(transaction.From), strings.ToLower(transaction.To)
+	timestamp, _ := strconv.ParseInt(transaction.TimeStamp, 10, 64)
+	input, _ := hexutil.Decode(transaction.Input)
+
+	classified := activity.Transaction{Input: input, Logs: logs}
+	if to != "" {
+		recipient := common.HexToAddress(to)
+		classified.To = &recipient
+	}
+
+	direction := rpc.DirectionIncoming
+	switch {
+	case from == walletAddress && to == walletAddress:
+		direction = rpc.DirectionSelf
+	case from == walletAddress:
+		direction = rpc.DirectionOutgoing
+	}
+	return &wallet_proto.WalletTransaction{
+		TxHash:    strings.ToLower(transaction.Hash),
+		Timestamp: timestamp,
+		From:      from,
+		To:        to,
+		Direction: string(direction),
+		ValueWei:  transaction.Value,
+		Activity:  activity.Classify(classified),
+		Failed:    transaction.IsError == "1",
+	}
+}
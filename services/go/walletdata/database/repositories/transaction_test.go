@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+	"walletdata/lib/trades"
+	wallet_proto "walletdata/proto/wallet"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestSetTransactionValuesUsd(t *testing.T) {
+	native := strings.ToLower(trades.NativeToken().Hex())
+	transactions := []*wallet_proto.WalletTransaction{
+		{TxHash: "0xa", Timestamp: 1700000000, ValueWei: "500000000000000000"},
+		{TxHash: "0xb", Timestamp: 1700000100, ValueWei: "0"},
+		{TxHash: "0xc", Timestamp: 1600000000, ValueWei: "1000000000000000000"},
+	}
+	queries := nativeQueries(transactions)
+	if len(queries) != 2 || queries[0] != (tokenAt{token: native, timestamp: 1700000000}) {
+		t.Errorf("queries = %+v, want the native token at the times of the transactions moving ETH", queries)
+	}
+
+	setTransactionValuesUsd(transactions, map[tokenAt]decimal.Decimal{{token: native, timestamp: 1700000000}: decimal.NewFromInt(2000)})
+	if transactions[0].ValueUsd != "1000.00" {
+		t.Errorf("value = %q, want 1000.00", transactions[0].ValueUsd)
+	}
+	if transactions[1].ValueUsd != "0.00" {
+		t.Errorf("value without ETH = %q, want 0.00", transactions[1].ValueUsd)
+	}
+	if transactions[2].ValueUsd != "" {
+		t.Errorf("value without a price of its time = %q, want none", transactions[2].ValueUsd)
+	}
+}
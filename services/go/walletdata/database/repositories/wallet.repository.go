@@ -2,19 +2,29 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
-	"strconv"
+	"math/big"
+	"samterminal/pkg/numeric"
+	"samterminal/pkg/telemetry"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 	"walletdata/database"
 	"walletdata/database/dto"
+	"walletdata/database/store"
 	db "walletdata/generated/prisma"
 	"walletdata/lib/api"
-	token_client "walletdata/lib/grpc/client/token"
+	"walletdata/lib/events"
+	"walletdata/lib/trades"
 	"walletdata/proto/common"
-	proto "walletdata/proto/token"
 	wallet_proto "walletdata/proto/wallet"
 	"walletdata/rpc"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
 )
 
 func getDB() *db.PrismaClient {
@@ -26,8 +36,8 @@ func getDB() *db.PrismaClient {
 	return client
 }
 
-func getCtx() (context.Context, context.CancelFunc) {
-	return context.WithCancel(context.Background())
+func getCtx(ctx context.Context) (context.Context, context.CancelFunc) {
+	return telemetry.StartDBSpan(ctx)
 }
 
 func GetWalletWithAPI(walletAddress string) (*common.Wallet, error) {
@@ -36,64 +46,137 @@ func GetWalletWithAPI(walletAddress string) (*common.Wallet, error) {
 		return nil, err
 	}
 	tokenAddressList := []string{}
-	for _, token := range *walletTokens {
+	for _, token := range walletTokens {
 		tokenAddressList = append(tokenAddressList, token.TokenAddress)
 	}
-	totalDollarValue := 0.0
-	for _, token := range *walletTokens {
-		tokenDollarValue, err := strconv.ParseFloat(token.TokenDollarValue, 64)
+	totalDollarValue := decimal.Zero
+	for _, token := range walletTokens {
+		tokenDollarValue, err := numeric.Parse(token.TokenDollarValue)
 		if err != nil {
 			return nil, err
 		}
-		totalDollarValue += tokenDollarValue
+		totalDollarValue = totalDollarValue.Add(tokenDollarValue)
 	}
 	return &common.Wallet{
 		WalletAddress:          walletAddress,
 		TokenAddresses:         tokenAddressList,
-		TotalDollarValue:       strconv.FormatFloat(totalDollarValue, 'f', -1, 64),
+		TotalDollarValue:       numeric.FormatUSD(totalDollarValue),
 		NativeBalance:          "0",
 		NativeBalanceFormatted: "0",
+		NativeBalanceUsd:       "0",
 	}, nil
 }
 
-func GetWallet(walletAddress string, dataType wallet_proto.DataType, tokenAddresses []string) (*common.Wallet, error) {
-	ctx, cancel := getCtx()
-	defer cancel()
-	tx := getDB()
-	wallet, err := tx.Wallet.FindUnique(
-		db.Wallet.Address.Equals(strings.ToLower(walletAddress)),
-	).Exec(ctx)
+// Data sources of GetWallet.
+const (
+	WalletSourceAPI = "api"
+	WalletSourceDB  = "db"
+)
+
+// walletStaleAfter is the age past which HYBRID reads refresh a wallet in the background.
+const walletStaleAfter = time.Minute
+
+// WalletFreshness tells where GetWallet read a wallet from and when it was last updated.
+type WalletFreshness struct {
+	DataSource string
+	UpdatedAt  time.Time
+	// Refreshing is set when a background refresh of the wallet was started.
+	Refreshing bool
+}
+
+var walletRefreshes = struct {
+	mu      sync.Mutex
+	running map[string]bool
+}{running: map[string]bool{}}
+
+// GetWallet returns a tracked wallet. API refreshes it from Moralis and the chain first, DB and
+// an unset type return it as stored and HYBRID returns it as stored, refreshing it in the
+// background when it was updated more than walletStaleAfter ago.
+func GetWallet(ctx context.Context, walletAddress string, dataType wallet_proto.DataType, tokenAddresses []string) (*common.Wallet, WalletFreshness, error) {
+	walletAddress = strings.ToLower(walletAddress)
+	wallet, err := findWallet(walletAddress)
 	if err != nil {
-		return nil, err
+		return nil, WalletFreshness{}, err
+	}
+	freshness := WalletFreshness{DataSource: WalletSourceDB}
+	switch dataType {
+	case wallet_proto.DataType_API:
+		if err := UpdateWallet(ctx, walletAddress); err != nil {
+			return nil, WalletFreshness{}, err
+		}
+		if wallet, err = findWallet(walletAddress); err != nil {
+			return nil, WalletFreshness{}, err
+		}
+		freshness.DataSource = WalletSourceAPI
+	case wallet_proto.DataType_HYBRID:
+		if time.Since(wallet.UpdatedAt) > walletStaleAfter {
+			freshness.Refreshing = refreshWalletInBackground(walletAddress)
+		}
 	}
+	freshness.UpdatedAt = wallet.UpdatedAt
+	return walletModelToProto(wallet), freshness, nil
+}
+
+func findWallet(walletAddress string) (*db.WalletModel, error) {
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	return walletStore.FindWallet(ctx, walletAddress)
+}
+
+// refreshWalletInBackground updates a wallet unless it is being updated already, and reports
+// whether a refresh is running.
+func refreshWalletInBackground(walletAddress string) bool {
+	walletRefreshes.mu.Lock()
+	defer walletRefreshes.mu.Unlock()
+	if walletRefreshes.running[walletAddress] {
+		return true
+	}
+	walletRefreshes.running[walletAddress] = true
+	go func() {
+		defer func() {
+			walletRefreshes.mu.Lock()
+			delete(walletRefreshes.running, walletAddress)
+			walletRefreshes.mu.Unlock()
+		}()
+		if err := UpdateWallet(context.Background(), walletAddress); err != nil {
+			log.Println("Error refreshing wallet", walletAddress, ":", err)
+		}
+	}()
+	return true
+}
+
+func walletModelToProto(wallet *db.WalletModel) *common.Wallet {
+	label, _ := wallet.Label()
 	return &common.Wallet{
-		WalletAddress:          wallet.Address,
-		TotalDollarValue:       wallet.Erc20DollarValue,
-		NativeBalance:          wallet.NativeBalance,
-		NativeBalanceFormatted: wallet.NativeBalance,
-		TokenAddresses:         wallet.Tokens,
-	}, nil
+		WalletAddress:             wallet.Address,
+		TotalDollarValue:          wallet.Erc20DollarValue,
+		NativeBalance:             wallet.NativeBalance,
+		NativeBalanceFormatted:    formatNativeBalance(wallet.NativeBalance),
+		NativeBalanceUsd:          nativeBalanceUsd(wallet.NativeBalance),
+		TokenAddresses:            wallet.Tokens,
+		Label:                     label,
+		Tags:                      wallet.Tags,
+		Groups:                    wallet.Groups,
+		QuarantinedTokenAddresses: wallet.QuarantinedTokens,
+	}
 }
 
-func GetOrCreateWallet(walletAddress string, tokenAddresses []string) (*common.Wallet, error) {
-	wallet, err := GetWallet(walletAddress, wallet_proto.DataType_API, tokenAddresses)
+func GetOrCreateWallet(ctx context.Context, walletAddress string, dataType wallet_proto.DataType, tokenAddresses []string) (*common.Wallet, WalletFreshness, error) {
+	wallet, freshness, err := GetWallet(ctx, walletAddress, dataType, tokenAddresses)
+	if errors.Is(err, db.ErrNotFound) {
+		AddWallet(ctx, walletAddress, tokenAddresses)
+		wallet, freshness, err = GetWallet(ctx, walletAddress, dataType, tokenAddresses)
+	}
 	if err != nil {
-		AddWallet(walletAddress, tokenAddresses)
-		wallet, err = GetWallet(walletAddress, wallet_proto.DataType_API, tokenAddresses)
-		if err != nil {
-			return nil, err
-		}
+		return nil, WalletFreshness{}, err
 	}
-	return wallet, nil
+	return wallet, freshness, nil
 }
 
 func WalletExists(walletAddress string) bool {
-	ctx, cancel := getCtx()
+	ctx, cancel := getCtx(context.Background())
 	defer cancel()
-	tx := getDB()
-	wallet, err := tx.Wallet.FindUnique(
-		db.Wallet.Address.Equals(walletAddress),
-	).Exec(ctx)
+	wallet, err := walletStore.FindWallet(ctx, strings.ToLower(walletAddress))
 	if err != nil {
 		return false
 	}
@@ -101,67 +184,232 @@ func WalletExists(walletAddress string) bool {
 }
 
 func StartWalletWatcherForAllWallets() {
-	ctx, cancel := getCtx()
+	ctx, cancel := getCtx(context.Background())
 	defer cancel()
-	tx := getDB()
-	wallets, err := tx.Wallet.FindMany().Exec(ctx)
+	wallets, err := walletStore.ListWallets(ctx)
 	if err != nil {
 		log.Println("Error getting wallets:", err)
 		return
 	}
-	for _, wallet := range wallets {
-		walletAddress := wallet.Address
-		err := StartWalletWatcher(walletAddress)
-		if err != nil {
-			log.Println("Error starting wallet watcher for", walletAddress, ":", err)
-			continue
+	if WebhookIngestion() {
+		addresses := make([]string, 0, len(wallets))
+		for _, wallet := range wallets {
+			addresses = append(addresses, wallet.Address)
 		}
+		registerWebhookAddresses(addresses)
+		return
 	}
+	rebalanced, err := RebalanceWalletWatchers()
+	if err != nil {
+		log.Println("Error starting wallet watchers:", err)
+		return
+	}
+	log.Printf("Watching %d of %d wallets as wallet shard %d of %d", rebalanced.Watching, len(wallets), rebalanced.ShardId, rebalanced.ShardCount)
 }
 
+// StartWalletWatcher has the transactions of a wallet come in: from a subscription of its own,
+// or from the Alchemy webhook in webhook ingestion mode. Wallets of other shards are left to
+// their replica.
 func StartWalletWatcher(walletAddress string) error {
-	err := rpc.WatchWalletForUpdates(walletAddress, func(event rpc.WalletTransaction) {
-		err := UpdateWallet(walletAddress)
-		if err != nil {
+	if WebhookIngestion() {
+		registerWebhookAddresses([]string{walletAddress})
+		return nil
+	}
+	walletAddress = strings.ToLower(walletAddress)
+	if !currentShards().owns(walletAddress) {
+		return nil
+	}
+	return watchers.start(walletAddress)
+}
+
+// handleWalletTransaction updates a wallet for a transaction of it and sends out its events.
+func handleWalletTransaction(walletAddress string, event rpc.WalletTransaction) {
+	ctx, span := telemetry.StartSpan(context.Background(), "wallet transaction")
+	defer span.End()
+	if err := RefreshNativeBalance(walletAddress); err != nil {
+		log.Println("Error refreshing native balance:", err)
+	}
+	// The watch filter, webhook and alert rules of the wallet are read once for the transaction.
+	wallet := findWebhookWallet(walletAddress)
+	updateInFull := func() {
+		if err := UpdateWallet(ctx, walletAddress); err != nil {
 			log.Println("Error updating wallet:", err)
 		}
-	})
+	}
+	// Plain ETH transfers leave the token holdings as they are, and decoded token transfers only
+	// refresh the tokens they moved. Transfers are nil when the receipt could not be read, so the
+	// wallet is updated in full then, as it is when the stored wallet or the balances of the
+	// transferred tokens cannot be read. The watch filter of the wallet skips dust and spam
+	// transfers.
+	switch {
+	case event.TokenTransfers == nil:
+		updateInFull()
+	case len(event.TokenTransfers) == 0 || !acceptsWalletTransaction(wallet, event):
+	case wallet == nil:
+		updateInFull()
+	default:
+		if err := updateWalletTokens(ctx, wallet, event.TokenTransfers); err != nil {
+			log.Println("Error updating transferred tokens of", walletAddress, ":", err)
+			updateInFull()
+		}
+	}
+	if len(event.TokenTransfers) > 0 {
+		updateHolderBalances(walletAddress, event.TokenTransfers)
+	}
+	go publishWalletEvents(walletAddress, event)
+	go notifyTransaction(wallet, event)
+	go checkTransferAlert(wallet, event)
+}
+
+// updateWalletTokens updates a wallet for the token transfers of a transaction without reading
+// all of its holdings.
+func updateWalletTokens(ctx context.Context, wallet *db.WalletModel, transfers []rpc.TokenTransfer) error {
+	tokens := transferredTokens(wallet.Address, transfers)
+	if len(tokens) == 0 {
+		return nil
+	}
+	balances, err := rpc.GetTokenBalances(wallet.Address, tokens)
+	if err != nil {
+		return err
+	}
+	value := walletValueAfterTransfers(wallet, transfers, balances, transferValueUsd)
+	if added := newTokens(wallet.Tokens, value.Tokens); len(added) > 0 {
+		enqueueOutbox(outboxAddTokens, addTokensMessage{TokenAddresses: added, Reason: "wallet_token"})
+	}
+
+	dbCtx, cancel := getCtx(ctx)
+	defer cancel()
+	updated, err := walletStore.UpdateWalletValue(dbCtx, wallet.Address, value)
 	if err != nil {
 		return err
 	}
+	notifyValueChange(updated)
+	checkValueAlert(updated, value.Erc20DollarValue)
+	if err := savePortfolioSnapshot(wallet.Address, value.Erc20DollarValue, nil); err != nil {
+		log.Println("Error saving portfolio snapshot:", err)
+	}
 	return nil
 }
 
-func AddWallet(walletAddress string, tokenAddresses []string) error {
-	ctx, cancel := getCtx()
+// transferredTokens returns the tokens transfers moved into or out of a wallet, lowercased.
+func transferredTokens(walletAddress string, transfers []rpc.TokenTransfer) []string {
+	wallet := gethcommon.HexToAddress(walletAddress)
+	tokens := []string{}
+	for _, transfer := range transfers {
+		token := strings.ToLower(transfer.Token.Hex())
+		if transfer.From != transfer.To && (transfer.From == wallet || transfer.To == wallet) && !slices.Contains(tokens, token) {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// walletValueAfterTransfers applies token transfers to the stored value of a wallet. Tokens whose
+// on-chain balance, from balances, is now zero are dropped from the wallet and the ones it now
+// holds added, and its value moves by the value of the transfers, priced by valueUsd. Quarantined
+// tokens are left out, as they are of the value of a full update.
+func walletValueAfterTransfers(wallet *db.WalletModel, transfers []rpc.TokenTransfer, balances map[string]*big.Int, valueUsd func(transfer rpc.TokenTransfer) decimal.Decimal) store.WalletValue {
+	counted := []rpc.TokenTransfer{}
+	for _, transfer := range transfers {
+		if !slices.Contains(wallet.QuarantinedTokens, strings.ToLower(transfer.Token.Hex())) {
+			counted = append(counted, transfer)
+		}
+	}
+	incoming, outgoing := transferValues(wallet.Address, rpc.WalletTransaction{TokenTransfers: counted}, valueUsd)
+	value := decimal.Max(numeric.ParseOrZero(wallet.Erc20DollarValue).Add(incoming).Sub(outgoing), decimal.Zero)
+
+	tokens := append([]string{}, wallet.Tokens...)
+	for _, token := range transferredTokens(wallet.Address, counted) {
+		balance, ok := balances[token]
+		if !ok {
+			continue
+		}
+		held := slices.IndexFunc(tokens, func(address string) bool { return strings.EqualFold(address, token) })
+		switch {
+		case balance.Sign() > 0 && held < 0:
+			tokens = append(tokens, token)
+		case balance.Sign() == 0 && held >= 0:
+			tokens = slices.Delete(tokens, held, held+1)
+		}
+	}
+	return store.WalletValue{Erc20DollarValue: numeric.FormatUSD(value), Tokens: tokens}
+}
+
+// newTokens returns the tokens of after that are not in before.
+func newTokens(before []string, after []string) []string {
+	added := []string{}
+	for _, token := range after {
+		if !slices.Contains(before, token) {
+			added = append(added, token)
+		}
+	}
+	return added
+}
+
+// publishWalletEvents decodes the trades and flows of a wallet transaction and sends them to
+// wallet event subscribers.
+func publishWalletEvents(walletAddress string, event rpc.WalletTransaction) {
+	label := ""
+	wallet, _, err := GetWallet(context.Background(), walletAddress, wallet_proto.DataType_DB, nil)
+	if err == nil {
+		label = wallet.Label
+	}
+	publishWalletTrades(walletAddress, label, event)
+	publishWalletFlows(walletAddress, label, event)
+}
+
+// publishWalletTrades decodes the swaps in a wallet transaction and records them for PnL.
+func publishWalletTrades(walletAddress string, label string, event rpc.WalletTransaction) {
+	for _, trade := range trades.FromTransaction(walletAddress, event) {
+		trade.WalletLabel = label
+		if contract, ok := LookupKnownContract(trade.Counterparty); ok {
+			trade.CounterpartyLabel = contract.Name
+		}
+		// Unpriced trades would distort cost basis, so they are streamed but not used for PnL.
+		if numeric.ParseOrZero(trade.PriceUsd).IsPositive() {
+			if err := SaveWalletTrade(trade); err != nil {
+				log.Println("Error saving wallet trade:", err)
+			}
+		}
+		events.PublishWalletTrade(trade)
+	}
+}
+
+func AddWallet(ctx context.Context, walletAddress string, tokenAddresses []string) error {
+	ctx, cancel := getCtx(ctx)
 	defer cancel()
-	tx := getDB()
 
+	walletAddress = strings.ToLower(walletAddress)
 	log.Println("adding wallet", walletAddress)
 	exists := WalletExists(walletAddress)
 	if exists {
-		return nil
+		// Another replica sends the wallets it added to the replica of their shard this way.
+		if WebhookIngestion() {
+			return nil
+		}
+		return StartWalletWatcher(walletAddress)
 	}
 	err := StartWalletWatcher(walletAddress)
 	if err != nil {
 		return err
 	}
 
-	wallet, err := tx.Wallet.CreateOne(
-		db.Wallet.Address.Set(walletAddress),
-		db.Wallet.Tokens.Set(tokenAddresses),
-	).Exec(ctx)
+	wallet, err := walletStore.CreateWallet(ctx, walletAddress, tokenAddresses)
 	if err != nil {
 		return err
 	}
 	if wallet == nil {
 		return fmt.Errorf("wallet not created")
 	}
+	QueueWalletBackfill(walletAddress)
+	if !WebhookIngestion() {
+		go sendToShard(walletAddress)
+	}
 
 	return nil
 }
 
-func GetWalletCumulativeData(walletAddress string, tokens []common.WalletToken) (dto.WalletCumulativeData, error) {
+func GetWalletCumulativeData(ctx context.Context, walletAddress string, tokens []*common.WalletToken) (dto.WalletCumulativeData, error) {
 	response := dto.WalletCumulativeData{
 		TotalDollarValue: "0",
 		NativeBalance:    "0",
@@ -171,12 +419,8 @@ func GetWalletCumulativeData(walletAddress string, tokens []common.WalletToken)
 	for _, token := range tokens {
 		tokenAddressList = append(tokenAddressList, token.TokenAddress)
 	}
-	for _, tokenAddress := range tokenAddressList {
-		_, err := token_client.AddToken(context.Background(), &proto.AddTokenRequest{TokenAddress: tokenAddress})
-		if err != nil {
-			log.Println("Error adding token:", err)
-			continue
-		}
+	if len(tokenAddressList) > 0 {
+		enqueueOutbox(outboxAddTokens, addTokensMessage{TokenAddresses: tokenAddressList, Reason: "wallet_token"})
 	}
 	totalDollarValue, err := api.GetTotalDollarValueForAPI(tokens)
 	if err != nil {
@@ -192,49 +436,57 @@ func GetWalletCumulativeData(walletAddress string, tokens []common.WalletToken)
 	return response, nil
 }
 
-func UpdateWalletDollarValue(walletAddress string, dollarValue string) error {
-	ctx, cancel := getCtx()
+func UpdateWalletDollarValue(ctx context.Context, walletAddress string, dollarValue string) error {
+	ctx, cancel := getCtx(ctx)
 	defer cancel()
-	tx := getDB()
-	walletTx := tx.Wallet.FindUnique(
-		db.Wallet.Address.Equals(walletAddress),
-	)
-	_, err := walletTx.Update(
-		db.Wallet.Erc20DollarValue.Set(dollarValue),
-	).Exec(ctx)
+	wallet, err := walletStore.UpdateWalletValue(ctx, strings.ToLower(walletAddress), store.WalletValue{Erc20DollarValue: dollarValue})
 	if err != nil {
 		return err
 	}
+	notifyValueChange(wallet)
+	checkValueAlert(wallet, dollarValue)
+	if err := savePortfolioSnapshot(walletAddress, dollarValue, nil); err != nil {
+		log.Println("Error saving portfolio snapshot:", err)
+	}
 	return nil
 }
 
-func UpdateWallet(walletAddress string) error {
-	ctx, cancel := getCtx()
+func UpdateWallet(ctx context.Context, walletAddress string) error {
+	dbCtx, cancel := getCtx(ctx)
 	defer cancel()
-	tx := getDB()
 	tokenStatus, err := api.GetTokenStatus(walletAddress)
 	if err != nil {
 		return err
 	}
 	if len(tokenStatus.InsecureTokenAddresses) > 0 {
-		_, err = token_client.AddBlacklist(context.Background(), &proto.AddBlacklistRequest{TokenAddresses: tokenStatus.InsecureTokenAddresses})
-		if err != nil {
-			log.Println("error adding blacklist", err)
-		}
+		enqueueOutbox(outboxAddBlacklist, addBlacklistMessage{TokenAddresses: tokenStatus.InsecureTokenAddresses})
 	}
 
-	walletTx := tx.Wallet.FindUnique(
-		db.Wallet.Address.Equals(walletAddress),
-	)
-	walletCumulativeData, err := GetWalletCumulativeData(walletAddress, tokenStatus.SecureTokens)
+	previous := findWebhookWallet(walletAddress)
+	safeTokens := []string{}
+	if previous != nil {
+		safeTokens = previous.SafeTokens
+	}
+	tokens, quarantined := quarantineTokens(tokenStatus, safeTokens)
+	api.ReconcileWalletTokens(walletAddress, tokens)
+	walletCumulativeData, err := GetWalletCumulativeData(ctx, walletAddress, tokens)
 	if err != nil {
 		return err
 	}
 
-	_, err = walletTx.Update(
-		db.Wallet.Erc20DollarValue.Set(walletCumulativeData.TotalDollarValue),
-		db.Wallet.NativeBalance.Set(walletCumulativeData.NativeBalance),
-		db.Wallet.Tokens.Set(tokenStatus.SecureTokenAddresses),
-	).Exec(ctx)
-	return err
+	wallet, err := walletStore.UpdateWalletValue(dbCtx, strings.ToLower(walletAddress), store.WalletValue{
+		Erc20DollarValue:  walletCumulativeData.TotalDollarValue,
+		NativeBalance:     &walletCumulativeData.NativeBalance,
+		Tokens:            walletCumulativeData.TokenAddressList,
+		QuarantinedTokens: quarantined,
+	})
+	if err != nil {
+		return err
+	}
+	notifyValueChange(wallet)
+	checkValueAlert(wallet, walletCumulativeData.TotalDollarValue)
+	if err := savePortfolioSnapshot(walletAddress, walletCumulativeData.TotalDollarValue, &walletCumulativeData.NativeBalance); err != nil {
+		log.Println("Error saving portfolio snapshot:", err)
+	}
+	return nil
 }
@@ -0,0 +1,241 @@
+package repository
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"walletdata/database/store/mock"
+	"walletdata/lib/webhook"
+	wallet_proto "walletdata/proto/wallet"
+	"walletdata/rpc"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+)
+
+const testWallet = "0x1111111111111111111111111111111111111111"
+
+func TestGetWallet(t *testing.T) {
+	wallet := mock.NewWallet(testWallet, "0xtoken")
+	label := "treasury"
+	wallet.InnerWallet.Label = &label
+	defer SetWalletStore(mock.NewWalletStore(wallet))()
+
+	got, freshness, err := GetWallet(context.Background(), testWallet, wallet_proto.DataType_DB, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.WalletAddress != testWallet || got.Label != "treasury" || len(got.TokenAddresses) != 1 {
+		t.Errorf("wallet = %+v", got)
+	}
+	if freshness.DataSource != WalletSourceDB || freshness.Refreshing || freshness.UpdatedAt.IsZero() {
+		t.Errorf("freshness = %+v", freshness)
+	}
+	// An unset type reads the stored wallet rather than calling out to Moralis.
+	if _, freshness, err := GetWallet(context.Background(), testWallet, wallet_proto.DataType_DATA_TYPE_UNSPECIFIED, nil); err != nil || freshness.DataSource != WalletSourceDB {
+		t.Errorf("unset type: freshness = %+v, err = %v", freshness, err)
+	}
+	// A wallet updated just now is fresh, so HYBRID reads it as stored.
+	if _, freshness, _ := GetWallet(context.Background(), testWallet, wallet_proto.DataType_HYBRID, nil); freshness.Refreshing {
+		t.Error("HYBRID read of a fresh wallet started a refresh")
+	}
+	if WalletExists("0x2222222222222222222222222222222222222222") {
+		t.Error("unknown wallet reported as existing")
+	}
+}
+
+func TestUpdateWalletDollarValue(t *testing.T) {
+	wallets := mock.NewWalletStore(mock.NewWallet(testWallet))
+	defer SetWalletStore(wallets)()
+
+	if err := UpdateWalletDollarValue(context.Background(), testWallet, "12.5"); err != nil {
+		t.Fatal(err)
+	}
+	wallet, _ := wallets.Wallet(testWallet)
+	if wallet.Erc20DollarValue != "12.5" {
+		t.Errorf("dollar value = %s, want 12.5", wallet.Erc20DollarValue)
+	}
+	snapshots := wallets.Snapshots()
+	if len(snapshots) != 1 || !snapshots[0].ValueUsd.Equal(decimal.RequireFromString("12.5")) {
+		t.Errorf("snapshots = %+v, want one of 12.5", snapshots)
+	}
+
+	if err := UpdateWalletDollarValue(context.Background(), "0x2222222222222222222222222222222222222222", "1"); err == nil {
+		t.Error("updating an unknown wallet: expected an error")
+	}
+}
+
+func TestWalletValueAfterTransfers(t *testing.T) {
+	wallet := mock.NewWallet(testWallet, "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	wallet.Erc20DollarValue = "100"
+	wallet.QuarantinedTokens = []string{"0xdddddddddddddddddddddddddddddddddddddddd"}
+	self, other := common.HexToAddress(testWallet), common.HexToAddress("0x2222222222222222222222222222222222222222")
+	sold, bought := common.HexToAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"), common.HexToAddress("0xcccccccccccccccccccccccccccccccccccccccc")
+	spam := common.HexToAddress("0xdddddddddddddddddddddddddddddddddddddddd")
+	transfers := []rpc.TokenTransfer{
+		{Token: sold, From: self, To: other, Amount: big.NewInt(40)},
+		{Token: bought, From: other, To: self, Amount: big.NewInt(15)},
+		{Token: spam, From: other, To: self, Amount: big.NewInt(1000)},
+	}
+	balances := map[string]*big.Int{
+		strings.ToLower(sold.Hex()):   big.NewInt(0),
+		strings.ToLower(bought.Hex()): big.NewInt(15),
+		strings.ToLower(spam.Hex()):   big.NewInt(1000),
+	}
+	value := walletValueAfterTransfers(&wallet, transfers, balances, func(transfer rpc.TokenTransfer) decimal.Decimal {
+		return decimal.NewFromBigInt(transfer.Amount, 0)
+	})
+	if value.Erc20DollarValue != "75.00" {
+		t.Errorf("dollar value = %s, want 75.00", value.Erc20DollarValue)
+	}
+	want := []string{"0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", "0xcccccccccccccccccccccccccccccccccccccccc"}
+	if strings.Join(value.Tokens, ",") != strings.Join(want, ",") {
+		t.Errorf("tokens = %v, want %v", value.Tokens, want)
+	}
+	if len(wallet.Tokens) != 2 {
+		t.Errorf("stored tokens changed: %v", wallet.Tokens)
+	}
+}
+
+func TestFormatNativeBalance(t *testing.T) {
+	for balance, want := range map[string]string{
+		"1500000000000000000": "1.5",
+		"1":                   "0.000000000000000001",
+		"0":                   "0",
+		"":                    "0",
+	} {
+		if got := formatNativeBalance(balance); got != want {
+			t.Errorf("formatNativeBalance(%q) = %s, want %s", balance, got, want)
+		}
+	}
+}
+
+func TestWatchFilterAccepts(t *testing.T) {
+	dust := common.HexToAddress("0x3333333333333333333333333333333333333333")
+	token := common.HexToAddress("0x4444444444444444444444444444444444444444")
+	values := map[common.Address]decimal.Decimal{dust: decimal.RequireFromString("0.01"), token: decimal.NewFromInt(500)}
+	valueUsd := func(transfer rpc.TokenTransfer) decimal.Decimal { return values[transfer.Token] }
+	transaction := func(valueWei int64, tokens ...common.Address) rpc.WalletTransaction {
+		event := rpc.WalletTransaction{ValueWei: big.NewInt(valueWei)}
+		for _, token := range tokens {
+			event.TokenTransfers = append(event.TokenTransfers, rpc.TokenTransfer{Token: token})
+		}
+		return event
+	}
+	lower := func(address common.Address) string { return strings.ToLower(address.Hex()) }
+
+	tests := []struct {
+		name   string
+		filter WatchFilter
+		event  rpc.WalletTransaction
+		want   bool
+	}{
+		{"no filter", WatchFilter{}, transaction(0, dust), true},
+		{"below min usd", WatchFilter{MinTokenUsd: decimal.NewFromInt(10)}, transaction(0, dust), false},
+		{"above min usd", WatchFilter{MinTokenUsd: decimal.NewFromInt(10)}, transaction(0, dust, token), true},
+		{"above min native", WatchFilter{MinNative: decimal.RequireFromString("0.5"), MinTokenUsd: decimal.NewFromInt(10)}, transaction(1e18, dust), true},
+		{"denylisted", WatchFilter{TokenDenylist: []string{lower(dust)}}, transaction(0, dust), false},
+		{"not allowlisted", WatchFilter{TokenAllowlist: []string{lower(token)}}, transaction(0, dust), false},
+		{"allowlisted below min", WatchFilter{TokenAllowlist: []string{lower(dust)}, MinTokenUsd: decimal.NewFromInt(10)}, transaction(0, dust, token), false},
+	}
+	for _, test := range tests {
+		if got := test.filter.Accepts(test.event, valueUsd); got != test.want {
+			t.Errorf("%s: accepts = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestWatchFilterOf(t *testing.T) {
+	wallet := mock.NewWallet(testWallet)
+	minimum := "2.5"
+	wallet.InnerWallet.WatchMinTokenUsd = &minimum
+	filter := watchFilterOf(&wallet)
+	if !filter.MinNative.IsZero() || !filter.MinTokenUsd.Equal(decimal.RequireFromString("2.5")) {
+		t.Errorf("filter = %+v", filter)
+	}
+}
+
+func TestWebhookOf(t *testing.T) {
+	wallet := mock.NewWallet(testWallet)
+	if _, ok := webhookOf(&wallet); ok {
+		t.Error("wallet without a webhook has one")
+	}
+	url, secret, minimum := "https://example.com/hook", "s3cret", "10"
+	wallet.InnerWallet.WebhookURL, wallet.InnerWallet.WebhookSecret = &url, &secret
+	wallet.InnerWallet.WebhookEvents = []string{string(webhook.EventValueChange)}
+	hook, ok := webhookOf(&wallet)
+	if !ok || hook.URL != url || hook.Secret != secret || !hook.MinValueChangePct.Equal(defaultMinValueChangePct) {
+		t.Errorf("hook = %+v, %v", hook, ok)
+	}
+	if hook.subscribes(webhook.EventTransaction) || !hook.subscribes(webhook.EventValueChange) {
+		t.Errorf("subscribed events = %v", hook.Events)
+	}
+	wallet.InnerWallet.WebhookMinValueChangePct = &minimum
+	if hook, _ := webhookOf(&wallet); !hook.MinValueChangePct.Equal(decimal.NewFromInt(10)) {
+		t.Errorf("MinValueChangePct = %s, want 10", hook.MinValueChangePct)
+	}
+}
+
+func TestWebhookValueChanged(t *testing.T) {
+	hook := Webhook{MinValueChangePct: decimal.NewFromInt(5)}
+	tests := []struct {
+		previous, current int64
+		want              bool
+	}{
+		{100, 104, false},
+		{100, 105, true},
+		{100, 90, true},
+		{0, 0, false},
+		{0, 1, true},
+	}
+	for _, test := range tests {
+		if got := hook.valueChanged(decimal.NewFromInt(test.previous), decimal.NewFromInt(test.current)); got != test.want {
+			t.Errorf("valueChanged(%d, %d) = %v, want %v", test.previous, test.current, got, test.want)
+		}
+	}
+}
+
+func TestCheckWebhookURL(t *testing.T) {
+	for raw, want := range map[string]bool{
+		"https://192.0.2.1/hook": true,
+		"http://10.0.0.1:8080":   false,
+		"http://127.0.0.1/hook":  false,
+		"ftp://192.0.2.1":        false,
+		"example.com/hook":       false,
+		"https://":               false,
+	} {
+		if got := checkWebhookURL(context.Background(), raw) == nil; got != want {
+			t.Errorf("checkWebhookURL(%q) accepted = %v, want %v", raw, got, want)
+		}
+	}
+}
+
+func TestNotifyValueChange(t *testing.T) {
+	wallet := mock.NewWallet(testWallet)
+	url, secret := "https://192.0.2.1/hook", "secret"
+	wallet.InnerWallet.WebhookURL, wallet.InnerWallet.WebhookSecret = &url, &secret
+	wallets := mock.NewWalletStore(wallet)
+	defer SetWalletStore(wallets)()
+	notified := func() string {
+		wallet, _ := wallets.Wallet(testWallet)
+		value, _ := wallet.WebhookNotifiedValueUsd()
+		return value
+	}
+
+	// The first value is the baseline; small moves from it are not sent, however many there are.
+	for _, value := range []string{"100", "103", "104.9"} {
+		if err := UpdateWalletDollarValue(context.Background(), testWallet, value); err != nil {
+			t.Fatal(err)
+		}
+		if notified() != "100" {
+			t.Fatalf("after %s: notified value = %q, want the baseline of 100", value, notified())
+		}
+	}
+	if err := UpdateWalletDollarValue(context.Background(), testWallet, "105"); err != nil {
+		t.Fatal(err)
+	}
+	if notified() != "105" {
+		t.Errorf("notified value = %q, want 105 after a 5%% move", notified())
+	}
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"slices"
+	"strings"
+	db "walletdata/generated/prisma"
+	"walletdata/lib/trades"
+	"walletdata/rpc"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+)
+
+var ErrInvalidWatchFilter = errors.New("invalid watch filter")
+
+// WatchFilter decides which transactions of a watched wallet update it, so that dust and spam
+// airdrops do not. Zero minimums and empty lists filter nothing.
+type WatchFilter struct {
+	// MinNative is in ETH, MinTokenUsd in USD.
+	MinNative      decimal.Decimal
+	MinTokenUsd    decimal.Decimal
+	TokenAllowlist []string
+	TokenDenylist  []string
+}
+
+func watchFilterOf(wallet *db.WalletModel) WatchFilter {
+	filter := WatchFilter{TokenAllowlist: wallet.WatchTokenAllowlist, TokenDenylist: wallet.WatchTokenDenylist}
+	if value, ok := wallet.WatchMinNative(); ok {
+		filter.MinNative, _ = decimal.NewFromString(value)
+	}
+	if value, ok := wallet.WatchMinTokenUsd(); ok {
+		filter.MinTokenUsd, _ = decimal.NewFromString(value)
+	}
+	return filter
+}
+
+// passesLists reports whether transfers of a token may update the wallet.
+func (f WatchFilter) passesLists(token common.Address) bool {
+	address := strings.ToLower(token.Hex())
+	if len(f.TokenAllowlist) > 0 && !slices.Contains(f.TokenAllowlist, address) {
+		return false
+	}
+	return !slices.Contains(f.TokenDenylist, address)
+}
+
+// Accepts reports whether a transaction with token transfers should update the wallet: a token
+// must pass the lists and, when minimums are set, the ETH value of the transaction or the USD
+// value of a passing transfer must reach its minimum. valueUsd prices a transfer.
+func (f WatchFilter) Accepts(event rpc.WalletTransaction, valueUsd func(transfer rpc.TokenTransfer) decimal.Decimal) bool {
+	passing := []rpc.TokenTransfer{}
+	for _, transfer := range event.TokenTransfers {
+		if f.passesLists(transfer.Token) {
+			passing = append(passing, transfer)
+		}
+	}
+	if len(passing) == 0 {
+		return false
+	}
+	if f.MinNative.IsZero() && f.MinTokenUsd.IsZero() {
+		return true
+	}
+	if f.MinNative.IsPositive() && event.ValueWei != nil && decimal.NewFromBigInt(event.ValueWei, -18).GreaterThanOrEqual(f.MinNative) {
+		return true
+	}
+	if f.MinTokenUsd.IsPositive() {
+		for _, transfer := range passing {
+			if valueUsd(transfer).GreaterThanOrEqual(f.MinTokenUsd) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// transferValueUsd prices a token transfer with tokendata.
+func transferValueUsd(transfer rpc.TokenTransfer) decimal.Decimal {
+	amount := transfer.Amount
+	if amount == nil {
+		amount = new(big.Int)
+	}
+	value, err := decimal.NewFromString(trades.ValueTokenAmount(transfer.Token, amount).USDValue)
+	if err != nil {
+		return decimal.Zero
+	}
+	return value
+}
+
+// acceptsWalletTransaction applies the watch filter of a wallet to one of its transactions.
+// wallet is nil when it could not be read, and every transaction is accepted then.
+func acceptsWalletTransaction(wallet *db.WalletModel, event rpc.WalletTransaction) bool {
+	if wallet == nil {
+		return true
+	}
+	return watchFilterOf(wallet).Accepts(event, transferValueUsd)
+}
+
+// SetWalletWatchFilter replaces the watch filter of a tracked wallet. Minimums are decimal
+// strings, empty for none.
+func SetWalletWatchFilter(ctx context.Context, walletAddress string, minNative string, minTokenUsd string, allowlist []string, denylist []string) error {
+	native, err := normalizeMinimum(minNative)
+	if err != nil {
+		return err
+	}
+	tokenUsd, err := normalizeMinimum(minTokenUsd)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := getCtx(ctx)
+	defer cancel()
+	tx := getDB()
+	_, err = tx.Wallet.FindUnique(
+		db.Wallet.Address.Equals(strings.ToLower(walletAddress)),
+	).Update(
+		db.Wallet.WatchMinNative.SetOptional(native),
+		db.Wallet.WatchMinTokenUsd.SetOptional(tokenUsd),
+		db.Wallet.WatchTokenAllowlist.Set(normalizeAddresses(allowlist)),
+		db.Wallet.WatchTokenDenylist.Set(normalizeAddresses(denylist)),
+	).Exec(ctx)
+	return err
+}
+
+// normalizeMinimum validates a minimum of a watch filter, returning nil for none.
+func normalizeMinimum(minimum string) (*string, error) {
+	if minimum == "" {
+		return nil, nil
+	}
+	value, err := decimal.NewFromString(minimum)
+	if err != nil || value.IsNegative() {
+		return nil, ErrInvalidWatchFilter
+	}
+	normalized := value.String()
+	return &normalized, nil
+}
+
+func normalizeAddresses(addresses []string) []string {
+	normalized := []string{}
+	for _, address := range addresses {
+		if address = strings.ToLower(strings.TrimSpace(address)); address != "" && !slices.Contains(normalized, address) {
+			normalized = append(normalized, address)
+		}
+	}
+	return normalized
+}
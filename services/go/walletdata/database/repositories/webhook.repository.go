@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"samterminal/pkg/httpclient"
+	"slices"
+	"strings"
+	db "walletdata/generated/prisma"
+	"walletdata/lib/webhook"
+	"walletdata/rpc"
+
+	"github.com/shopspring/decimal"
+)
+
+var ErrInvalidWebhook = errors.New("invalid webhook")
+
+// checkWebhookURL rejects webhook URLs that are not http(s) or reach a host that is not public.
+var checkWebhookURL = httpclient.CheckPublicURL
+
+// defaultMinValueChangePct is the portfolio value change, in percent, reported to webhooks that
+// do not set their own.
+var defaultMinValueChangePct = decimal.NewFromInt(5)
+
+// Webhook is where the events of a wallet are POSTed.
+type Webhook struct {
+	URL    string
+	Secret string
+	// Events are the subscribed events, all of them when empty.
+	Events            []string
+	MinValueChangePct decimal.Decimal
+}
+
+// webhookOf returns the webhook of a wallet, if it registered one.
+func webhookOf(wallet *db.WalletModel) (Webhook, bool) {
+	webhookURL, ok := wallet.WebhookURL()
+	if !ok || webhookURL == "" {
+		return Webhook{}, false
+	}
+	secret, _ := wallet.WebhookSecret()
+	hook := Webhook{URL: webhookURL, Secret: secret, Events: wallet.WebhookEvents, MinValueChangePct: defaultMinValueChangePct}
+	if value, ok := wallet.WebhookMinValueChangePct(); ok {
+		hook.MinValueChangePct, _ = decimal.NewFromString(value)
+	}
+	return hook, true
+}
+
+func (w Webhook) subscribes(event webhook.Event) bool {
+	return len(w.Events) == 0 || slices.Contains(w.Events, string(event))
+}
+
+// valueChanged reports whether a portfolio value moved from previous to current by at least
+// MinValueChangePct percent. Any value of a wallet that was worth nothing is a change.
+func (w Webhook) valueChanged(previous decimal.Decimal, current decimal.Decimal) bool {
+	if previous.IsZero() {
+		return !current.IsZero()
+	}
+	changePct := current.Sub(previous).Div(previous).Abs().Mul(decimal.NewFromInt(100))
+	return changePct.GreaterThanOrEqual(w.MinValueChangePct)
+}
+
+// SetWalletWebhook registers the webhook of a tracked wallet, replacing any previous one. An
+// empty url removes it. URLs must resolve to public addresses only.
+func SetWalletWebhook(ctx context.Context, walletAddress string, webhookURL string, secret string, events []webhook.Event, minValueChangePct string) error {
+	params := []db.WalletSetParam{
+		db.Wallet.WebhookURL.SetOptional(nil),
+		db.Wallet.WebhookSecret.SetOptional(nil),
+		db.Wallet.WebhookEvents.Set([]string{}),
+		db.Wallet.WebhookMinValueChangePct.SetOptional(nil),
+		db.Wallet.WebhookNotifiedValueUsd.SetOptional(nil),
+	}
+	if webhookURL != "" {
+		if secret == "" {
+			return ErrInvalidWebhook
+		}
+		if err := checkWebhookURL(ctx, webhookURL); err != nil {
+			log.Println("Rejected webhook URL", webhookURL, ":", err)
+			return ErrInvalidWebhook
+		}
+		minimum, err := normalizeMinimum(minValueChangePct)
+		if err != nil {
+			return ErrInvalidWebhook
+		}
+		subscribed := []string{}
+		for _, event := range events {
+			if !slices.Contains(subscribed, string(event)) {
+				subscribed = append(subscribed, string(event))
+			}
+		}
+		params = []db.WalletSetParam{
+			db.Wallet.WebhookURL.Set(webhookURL),
+			db.Wallet.WebhookSecret.Set(secret),
+			db.Wallet.WebhookEvents.Set(subscribed),
+			db.Wallet.WebhookMinValueChangePct.SetOptional(minimum),
+			db.Wallet.WebhookNotifiedValueUsd.SetOptional(nil),
+		}
+	}
+
+	ctx, cancel := getCtx(ctx)
+	defer cancel()
+	tx := getDB()
+	_, err := tx.Wallet.FindUnique(
+		db.Wallet.Address.Equals(strings.ToLower(walletAddress)),
+	).Update(params...).Exec(ctx)
+	return err
+}
+
+// transactionPayload is the data of a transaction event.
+type transactionPayload struct {
+	Hash           string                 `json:"hash"`
+	Direction      string                 `json:"direction"`
+	Counterparty   string                 `json:"counterparty,omitempty"`
+	ValueWei       string                 `json:"valueWei"`
+	TokenTransfers []tokenTransferPayload `json:"tokenTransfers"`
+}
+
+type tokenTransferPayload struct {
+	Token  string `json:"token"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Amount string `json:"amount"`
+}
+
+// notifyTransaction sends a mined transaction of a wallet to its webhook.
+func notifyTransaction(wallet *db.WalletModel, event rpc.WalletTransaction) {
+	if wallet == nil {
+		return
+	}
+	hook, ok := webhookOf(wallet)
+	if !ok || !hook.subscribes(webhook.EventTransaction) {
+		return
+	}
+
+	payload := transactionPayload{
+		Hash:           event.Hash.Hex(),
+		Direction:      string(event.Direction),
+		ValueWei:       "0",
+		TokenTransfers: []tokenTransferPayload{},
+	}
+	if event.Counterparty != nil {
+		payload.Counterparty = strings.ToLower(event.Counterparty.Hex())
+	}
+	if event.ValueWei != nil {
+		payload.ValueWei = event.ValueWei.String()
+	}
+	for _, transfer := range event.TokenTransfers {
+		amount := "0"
+		if transfer.Amount != nil {
+			amount = transfer.Amount.String()
+		}
+		payload.TokenTransfers = append(payload.TokenTransfers, tokenTransferPayload{
+			Token:  strings.ToLower(transfer.Token.Hex()),
+			From:   strings.ToLower(transfer.From.Hex()),
+			To:     strings.ToLower(transfer.To.Hex()),
+			Amount: amount,
+		})
+	}
+	webhook.Send(hook.URL, hook.Secret, wallet.Address, webhook.EventTransaction, payload)
+}
+
+// valueChangePayload is the data of a value change event, in USD.
+type valueChangePayload struct {
+	PreviousValueUsd string `json:"previousValueUsd"`
+	ValueUsd         string `json:"valueUsd"`
+	ChangePct        string `json:"changePct,omitempty"`
+}
+
+// notifyValueChange sends the portfolio value of a wallet that was just updated to its webhook when
+// it moved past the minimum change from the value last sent. The first value after the webhook
+// was set is the baseline and is not sent.
+func notifyValueChange(wallet *db.WalletModel) {
+	hook, ok := webhookOf(wallet)
+	if !ok || !hook.subscribes(webhook.EventValueChange) {
+		return
+	}
+	current, err := decimal.NewFromString(wallet.Erc20DollarValue)
+	if err != nil {
+		return
+	}
+	notified, ok := wallet.WebhookNotifiedValueUsd()
+	previous, err := decimal.NewFromString(notified)
+	if ok && err == nil {
+		if !hook.valueChanged(previous, current) {
+			return
+		}
+		payload := valueChangePayload{PreviousValueUsd: previous.String(), ValueUsd: current.String()}
+		if !previous.IsZero() {
+			payload.ChangePct = current.Sub(previous).Div(previous).Mul(decimal.NewFromInt(100)).StringFixed(2)
+		}
+		webhook.Send(hook.URL, hook.Secret, wallet.Address, webhook.EventValueChange, payload)
+	}
+
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	if err := walletStore.SetWebhookNotifiedValue(ctx, wallet.Address, current.String()); err != nil {
+		log.Println("Error saving notified value of", wallet.Address, ":", err)
+	}
+}
+
+// findWebhookWallet reads a wallet for its webhook, returning nil when it cannot be read.
+func findWebhookWallet(walletAddress string) *db.WalletModel {
+	ctx, cancel := getCtx(context.Background())
+	defer cancel()
+	wallet, err := walletStore.FindWallet(ctx, strings.ToLower(walletAddress))
+	if err != nil {
+		log.Println("Error getting webhook of", walletAddress, ":", err)
+		return nil
+	}
+	return wallet
+}
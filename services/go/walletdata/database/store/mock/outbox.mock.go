@@ -0,0 +1,112 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+	"walletdata/database/store"
+	db "walletdata/generated/prisma"
+)
+
+// OutboxStore keeps outbox messages in memory. Err, when set, is returned by every call.
+type OutboxStore struct {
+	mu       sync.Mutex
+	messages map[string]db.OutboxMessageModel
+	next     int
+	Err      error
+}
+
+var _ store.OutboxStore = (*OutboxStore)(nil)
+
+func NewOutboxStore() *OutboxStore {
+	return &OutboxStore{messages: map[string]db.OutboxMessageModel{}}
+}
+
+// Messages returns the stored messages, oldest first.
+func (s *OutboxStore) Messages() []db.OutboxMessageModel {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	messages := make([]db.OutboxMessageModel, 0, len(s.messages))
+	for _, message := range s.messages {
+		messages = append(messages, message)
+	}
+	sort.Slice(messages, func(i, j int) bool { return messages[i].ID < messages[j].ID })
+	return messages
+}
+
+func (s *OutboxStore) EnqueueOutbox(ctx context.Context, kind string, payload string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return s.Err
+	}
+	s.next++
+	now := time.Now()
+	id := fmt.Sprintf("%08d", s.next)
+	s.messages[id] = db.OutboxMessageModel{InnerOutboxMessage: db.InnerOutboxMessage{
+		ID:            id,
+		Kind:          kind,
+		Payload:       payload,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}}
+	return nil
+}
+
+func (s *OutboxStore) ListDueOutbox(ctx context.Context, now time.Time, limit int) ([]db.OutboxMessageModel, error) {
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	due := []db.OutboxMessageModel{}
+	for _, message := range s.Messages() {
+		if !message.NextAttemptAt.After(now) {
+			due = append(due, message)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool { return due[i].NextAttemptAt.Before(due[j].NextAttemptAt) })
+	return due[:min(limit, len(due))], nil
+}
+
+func (s *OutboxStore) ClaimOutbox(ctx context.Context, message db.OutboxMessageModel, until time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return false, s.Err
+	}
+	stored, ok := s.messages[message.ID]
+	if !ok || !stored.NextAttemptAt.Equal(message.NextAttemptAt) {
+		return false, nil
+	}
+	stored.NextAttemptAt = until
+	s.messages[message.ID] = stored
+	return true, nil
+}
+
+func (s *OutboxStore) DeleteOutbox(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return s.Err
+	}
+	delete(s.messages, id)
+	return nil
+}
+
+func (s *OutboxStore) RetryOutbox(ctx context.Context, id string, next time.Time, lastError string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return s.Err
+	}
+	message, ok := s.messages[id]
+	if !ok {
+		return db.ErrNotFound
+	}
+	message.Attempts++
+	message.NextAttemptAt = next
+	message.InnerOutboxMessage.LastError = &lastError
+	s.messages[id] = message
+	return nil
+}
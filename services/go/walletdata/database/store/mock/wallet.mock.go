@@ -0,0 +1,204 @@
+// Package mock provides in-memory stores for unit tests.
+package mock
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"walletdata/database/store"
+	db "walletdata/generated/prisma"
+
+	"github.com/shopspring/decimal"
+)
+
+// WalletStore keeps wallets and portfolio snapshots in memory. Err, when set, is returned by
+// every call.
+type WalletStore struct {
+	mu        sync.Mutex
+	wallets   map[string]db.WalletModel
+	snapshots []db.PortfolioSnapshotModel
+	Err       error
+}
+
+var _ store.WalletStore = (*WalletStore)(nil)
+
+func NewWalletStore(wallets ...db.WalletModel) *WalletStore {
+	s := &WalletStore{wallets: map[string]db.WalletModel{}}
+	for _, wallet := range wallets {
+		s.Put(wallet)
+	}
+	return s
+}
+
+// NewWallet returns a wallet holding tokens, as AddWallet would store it.
+func NewWallet(address string, tokens ...string) db.WalletModel {
+	now := time.Now()
+	return db.WalletModel{InnerWallet: db.InnerWallet{
+		ID:                  strings.ToLower(address),
+		Address:             strings.ToLower(address),
+		CreatedAt:           now,
+		UpdatedAt:           now,
+		Erc20DollarValue:    "0",
+		NativeBalance:       "0",
+		Tokens:              tokens,
+		Tags:                []string{},
+		Groups:              []string{},
+		WatchTokenAllowlist: []string{},
+		WatchTokenDenylist:  []string{},
+		WebhookEvents:       []string{},
+		QuarantinedTokens:   []string{},
+		SafeTokens:          []string{},
+	}}
+}
+
+// Put adds or replaces a wallet.
+func (s *WalletStore) Put(wallet db.WalletModel) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	wallet.Address = strings.ToLower(wallet.Address)
+	s.wallets[wallet.Address] = wallet
+}
+
+// Wallet returns a copy of a stored wallet.
+func (s *WalletStore) Wallet(address string) (db.WalletModel, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	wallet, ok := s.wallets[strings.ToLower(address)]
+	return wallet, ok
+}
+
+// Snapshots returns the portfolio snapshots saved so far, oldest first.
+func (s *WalletStore) Snapshots() []db.PortfolioSnapshotModel {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]db.PortfolioSnapshotModel{}, s.snapshots...)
+}
+
+func (s *WalletStore) FindWallet(ctx context.Context, address string) (*db.WalletModel, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	wallet, ok := s.wallets[strings.ToLower(address)]
+	if !ok {
+		return nil, db.ErrNotFound
+	}
+	return &wallet, nil
+}
+
+func (s *WalletStore) ListWallets(ctx context.Context) ([]db.WalletModel, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	wallets := make([]db.WalletModel, 0, len(s.wallets))
+	for _, wallet := range s.wallets {
+		wallets = append(wallets, wallet)
+	}
+	sort.Slice(wallets, func(i, j int) bool { return wallets[i].Address < wallets[j].Address })
+	return wallets, nil
+}
+
+func (s *WalletStore) CreateWallet(ctx context.Context, address string, tokens []string) (*db.WalletModel, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	wallet := NewWallet(address, tokens...)
+	s.wallets[wallet.Address] = wallet
+	return &wallet, nil
+}
+
+func (s *WalletStore) UpdateWalletValue(ctx context.Context, address string, value store.WalletValue) (*db.WalletModel, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	wallet, ok := s.wallets[strings.ToLower(address)]
+	if !ok {
+		return nil, db.ErrNotFound
+	}
+	wallet.Erc20DollarValue = value.Erc20DollarValue
+	if value.NativeBalance != nil {
+		wallet.NativeBalance = *value.NativeBalance
+	}
+	if value.Tokens != nil {
+		wallet.Tokens = value.Tokens
+	}
+	if value.QuarantinedTokens != nil {
+		wallet.QuarantinedTokens = value.QuarantinedTokens
+	}
+	wallet.UpdatedAt = time.Now()
+	s.wallets[wallet.Address] = wallet
+	return &wallet, nil
+}
+
+func (s *WalletStore) SetWebhookNotifiedValue(ctx context.Context, address string, valueUsd string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return s.Err
+	}
+	wallet, ok := s.wallets[strings.ToLower(address)]
+	if !ok {
+		return db.ErrNotFound
+	}
+	wallet.InnerWallet.WebhookNotifiedValueUsd = &valueUsd
+	s.wallets[wallet.Address] = wallet
+	return nil
+}
+
+func (s *WalletStore) UpdateNativeBalance(ctx context.Context, address string, balance string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return s.Err
+	}
+	wallet, ok := s.wallets[strings.ToLower(address)]
+	if !ok {
+		return db.ErrNotFound
+	}
+	wallet.NativeBalance = balance
+	wallet.UpdatedAt = time.Now()
+	s.wallets[wallet.Address] = wallet
+	return nil
+}
+
+func (s *WalletStore) SavePortfolioSnapshot(ctx context.Context, address string, valueUsd decimal.Decimal, nativeBalance *string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return s.Err
+	}
+	snapshot := db.PortfolioSnapshotModel{InnerPortfolioSnapshot: db.InnerPortfolioSnapshot{
+		WalletAddress: strings.ToLower(address),
+		ValueUsd:      valueUsd,
+		NativeBalance: "0",
+		CreatedAt:     time.Now(),
+	}}
+	if nativeBalance != nil {
+		snapshot.NativeBalance = *nativeBalance
+	}
+	s.snapshots = append(s.snapshots, snapshot)
+	return nil
+}
+
+func (s *WalletStore) FirstPortfolioSnapshotSince(ctx context.Context, address string, since time.Time) (*db.PortfolioSnapshotModel, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	for _, snapshot := range s.snapshots {
+		if snapshot.WalletAddress == strings.ToLower(address) && !snapshot.CreatedAt.Before(since) {
+			return &snapshot, nil
+		}
+	}
+	return nil, db.ErrNotFound
+}
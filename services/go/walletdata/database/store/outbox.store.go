@@ -0,0 +1,66 @@
+package store
+
+import (
+	"context"
+	"time"
+	db "walletdata/generated/prisma"
+)
+
+// OutboxStore holds the calls to tokendata that wait to be delivered.
+type OutboxStore interface {
+	EnqueueOutbox(ctx context.Context, kind string, payload string) error
+	// ListDueOutbox returns up to limit messages due at now, the longest waiting first.
+	ListDueOutbox(ctx context.Context, now time.Time, limit int) ([]db.OutboxMessageModel, error)
+	// ClaimOutbox moves a listed message to until unless another dispatcher moved it first,
+	// reporting whether it did.
+	ClaimOutbox(ctx context.Context, message db.OutboxMessageModel, until time.Time) (bool, error)
+	// DeleteOutbox removes a delivered message.
+	DeleteOutbox(ctx context.Context, id string) error
+	// RetryOutbox counts a failed attempt of a message and schedules the next one.
+	RetryOutbox(ctx context.Context, id string, next time.Time, lastError string) error
+}
+
+var _ OutboxStore = (*Prisma)(nil)
+
+func (p *Prisma) EnqueueOutbox(ctx context.Context, kind string, payload string) error {
+	_, err := p.client().OutboxMessage.CreateOne(
+		db.OutboxMessage.Kind.Set(kind),
+		db.OutboxMessage.Payload.Set(payload),
+	).Exec(ctx)
+	return err
+}
+
+func (p *Prisma) ListDueOutbox(ctx context.Context, now time.Time, limit int) ([]db.OutboxMessageModel, error) {
+	return p.client().OutboxMessage.FindMany(
+		db.OutboxMessage.NextAttemptAt.Lte(now),
+	).OrderBy(
+		db.OutboxMessage.NextAttemptAt.Order(db.SortOrderAsc),
+	).Take(limit).Exec(ctx)
+}
+
+func (p *Prisma) ClaimOutbox(ctx context.Context, message db.OutboxMessageModel, until time.Time) (bool, error) {
+	result, err := p.client().OutboxMessage.FindMany(
+		db.OutboxMessage.ID.Equals(message.ID),
+		db.OutboxMessage.NextAttemptAt.Equals(message.NextAttemptAt),
+	).Update(
+		db.OutboxMessage.NextAttemptAt.Set(until),
+	).Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	return result.Count > 0, nil
+}
+
+func (p *Prisma) DeleteOutbox(ctx context.Context, id string) error {
+	_, err := p.client().OutboxMessage.FindMany(db.OutboxMessage.ID.Equals(id)).Delete().Exec(ctx)
+	return err
+}
+
+func (p *Prisma) RetryOutbox(ctx context.Context, id string, next time.Time, lastError string) error {
+	_, err := p.client().OutboxMessage.FindUnique(db.OutboxMessage.ID.Equals(id)).Update(
+		db.OutboxMessage.Attempts.Increment(1),
+		db.OutboxMessage.NextAttemptAt.Set(next),
+		db.OutboxMessage.LastError.Set(lastError),
+	).Exec(ctx)
+	return err
+}
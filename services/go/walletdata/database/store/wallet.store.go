@@ -0,0 +1,121 @@
+// Package store defines the storage operations the repositories depend on, so that they can run
+// against the database or against the in-memory implementation in store/mock.
+package store
+
+import (
+	"context"
+	"strings"
+	"time"
+	"walletdata/database"
+	db "walletdata/generated/prisma"
+
+	"github.com/shopspring/decimal"
+)
+
+// WalletValue is the valuation of a wallet. NativeBalance, Tokens and QuarantinedTokens are left
+// unchanged when nil.
+type WalletValue struct {
+	Erc20DollarValue  string
+	NativeBalance     *string
+	Tokens            []string
+	QuarantinedTokens []string
+}
+
+// WalletStore holds the tracked wallets and their portfolio history. Addresses are lowercased.
+type WalletStore interface {
+	// FindWallet returns db.ErrNotFound when the wallet is not tracked.
+	FindWallet(ctx context.Context, address string) (*db.WalletModel, error)
+	ListWallets(ctx context.Context) ([]db.WalletModel, error)
+	CreateWallet(ctx context.Context, address string, tokens []string) (*db.WalletModel, error)
+	// UpdateWalletValue sets the valuation of a wallet and returns the updated wallet.
+	UpdateWalletValue(ctx context.Context, address string, value WalletValue) (*db.WalletModel, error)
+	// SetWebhookNotifiedValue sets the portfolio value last sent to the webhook of a wallet.
+	SetWebhookNotifiedValue(ctx context.Context, address string, valueUsd string) error
+	// UpdateNativeBalance sets the native balance of a wallet, in wei, leaving its valuation as is.
+	UpdateNativeBalance(ctx context.Context, address string, balance string) error
+	SavePortfolioSnapshot(ctx context.Context, address string, valueUsd decimal.Decimal, nativeBalance *string) error
+	// FirstPortfolioSnapshotSince returns the oldest snapshot of a wallet taken at or after since,
+	// db.ErrNotFound when there is none.
+	FirstPortfolioSnapshotSince(ctx context.Context, address string, since time.Time) (*db.PortfolioSnapshotModel, error)
+}
+
+// Prisma is the WalletStore backed by the database.
+type Prisma struct{}
+
+func NewPrisma() *Prisma {
+	return &Prisma{}
+}
+
+func (p *Prisma) client() *db.PrismaClient {
+	if database.Client == nil {
+		database.CreateClient()
+	}
+	return database.Client
+}
+
+func (p *Prisma) FindWallet(ctx context.Context, address string) (*db.WalletModel, error) {
+	return p.client().Wallet.FindUnique(db.Wallet.Address.Equals(strings.ToLower(address))).Exec(ctx)
+}
+
+func (p *Prisma) ListWallets(ctx context.Context) ([]db.WalletModel, error) {
+	return p.client().Wallet.FindMany().Exec(ctx)
+}
+
+func (p *Prisma) CreateWallet(ctx context.Context, address string, tokens []string) (*db.WalletModel, error) {
+	return p.client().Wallet.CreateOne(
+		db.Wallet.Address.Set(strings.ToLower(address)),
+		db.Wallet.Tokens.Set(tokens),
+	).Exec(ctx)
+}
+
+func (p *Prisma) UpdateWalletValue(ctx context.Context, address string, value WalletValue) (*db.WalletModel, error) {
+	params := []db.WalletSetParam{db.Wallet.Erc20DollarValue.Set(value.Erc20DollarValue)}
+	if value.NativeBalance != nil {
+		params = append(params, db.Wallet.NativeBalance.Set(*value.NativeBalance))
+	}
+	if value.Tokens != nil {
+		params = append(params, db.Wallet.Tokens.Set(value.Tokens))
+	}
+	if value.QuarantinedTokens != nil {
+		params = append(params, db.Wallet.QuarantinedTokens.Set(value.QuarantinedTokens))
+	}
+	return p.client().Wallet.FindUnique(
+		db.Wallet.Address.Equals(strings.ToLower(address)),
+	).Update(params...).Exec(ctx)
+}
+
+func (p *Prisma) SetWebhookNotifiedValue(ctx context.Context, address string, valueUsd string) error {
+	_, err := p.client().Wallet.FindUnique(
+		db.Wallet.Address.Equals(strings.ToLower(address)),
+	).Update(db.Wallet.WebhookNotifiedValueUsd.Set(valueUsd)).Exec(ctx)
+	return err
+}
+
+func (p *Prisma) UpdateNativeBalance(ctx context.Context, address string, balance string) error {
+	_, err := p.client().Wallet.FindUnique(
+		db.Wallet.Address.Equals(strings.ToLower(address)),
+	).Update(db.Wallet.NativeBalance.Set(balance)).Exec(ctx)
+	return err
+}
+
+func (p *Prisma) SavePortfolioSnapshot(ctx context.Context, address string, valueUsd decimal.Decimal, nativeBalance *string) error {
+	params := []db.PortfolioSnapshotSetParam{}
+	if nativeBalance != nil {
+		params = append(params, db.PortfolioSnapshot.NativeBalance.Set(*nativeBalance))
+	}
+	_, err := p.client().PortfolioSnapshot.CreateOne(
+		db.PortfolioSnapshot.WalletAddress.Set(strings.ToLower(address)),
+		db.PortfolioSnapshot.ValueUsd.Set(valueUsd),
+		params...,
+	).Exec(ctx)
+	return err
+}
+
+func (p *Prisma) FirstPortfolioSnapshotSince(ctx context.Context, address string, since time.Time) (*db.PortfolioSnapshotModel, error) {
+	return p.client().PortfolioSnapshot.FindFirst(
+		db.PortfolioSnapshot.WalletAddress.Equals(strings.ToLower(address)),
+		db.PortfolioSnapshot.CreatedAt.Gte(since),
+	).OrderBy(
+		db.PortfolioSnapshot.CreatedAt.Order(db.SortOrderAsc),
+	).Exec(ctx)
+}
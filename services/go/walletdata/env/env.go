@@ -1,42 +1,87 @@
 package env
 
 import (
-	"log"
 	"os"
-	"path/filepath"
-	"strconv"
-
-	"github.com/joho/godotenv"
+	"samterminal/pkg/config"
 )
 
-type EnvKey string
+// EnvKey is the name of an environment variable.
+type EnvKey = config.Key
 
+// DATABASE_URL, the DB_* pool settings, OTEL_EXPORTER_OTLP_ENDPOINT, API_DAILY_BUDGETS and the
+// CHAIN_* keys are read by the shared packages and declared in samterminal/pkg/config.
 const (
 	RPC_URL         EnvKey = "RPC_URL"
 	RPC_WS_URL      EnvKey = "RPC_WS_URL"
 	ES_API_KEY      EnvKey = "ES_API_KEY"
 	MORALIS_API_KEY EnvKey = "MORALIS_API_KEY"
 	PORT            EnvKey = "PORT"
+	HTTP_PORT       EnvKey = "HTTP_PORT"
 	TOKEN_GRPC_URL  EnvKey = "TOKEN_GRPC_URL"
-)
+	// Shared with tokendata: the tokens others are bought with, wrapped native token first.
+	ANCHOR_TOKENS EnvKey = "ANCHOR_TOKENS"
 
-// mapPrefixedEnvVars maps root .env prefixed variables to standard names
-func mapPrefixedEnvVars() {
-	// Map WALLETDATA_ prefixed variables to standard names
-	prefixMappings := map[string]string{
-		"WALLETDATA_PORT":         "PORT",
-		"WALLETDATA_DATABASE_URL": "DATABASE_URL",
-		"RPC_URL_BASE":            "RPC_URL",
-		"RPC_WS_URL_BASE":         "RPC_WS_URL",
-		"ETHERSCAN_API_KEY":       "ES_API_KEY",
-	}
+	// Portfolio snapshots are kept as recorded for SNAPSHOT_HOURLY_AFTER_DAYS, then one per hour
+	// until SNAPSHOT_DAILY_AFTER_DAYS, then one per day until SNAPSHOT_RETENTION_DAYS (0 keeps
+	// them forever).
+	SNAPSHOT_HOURLY_AFTER_DAYS EnvKey = "SNAPSHOT_HOURLY_AFTER_DAYS"
+	SNAPSHOT_DAILY_AFTER_DAYS  EnvKey = "SNAPSHOT_DAILY_AFTER_DAYS"
+	SNAPSHOT_RETENTION_DAYS    EnvKey = "SNAPSHOT_RETENTION_DAYS"
 
-	for prefixed, standard := range prefixMappings {
-		if val := os.Getenv(prefixed); val != "" && os.Getenv(standard) == "" {
-			os.Setenv(standard, val)
-		}
-	}
+	// Failed webhook deliveries are retried up to WEBHOOK_MAX_ATTEMPTS attempts in all, waiting
+	// WEBHOOK_RETRY_BACKOFF before the first retry and twice as long before each next one.
+	WEBHOOK_MAX_ATTEMPTS  EnvKey = "WEBHOOK_MAX_ATTEMPTS"
+	WEBHOOK_RETRY_BACKOFF EnvKey = "WEBHOOK_RETRY_BACKOFF"
+
+	// Moralis balances further than WALLET_BALANCE_TOLERANCE (a fraction, 0.01 by default) from
+	// the on-chain balanceOf are replaced by the on-chain one.
+	WALLET_BALANCE_TOLERANCE EnvKey = "WALLET_BALANCE_TOLERANCE"
+
+	// Base URLs of the external APIs. They default to the public endpoints and are only set to
+	// point the service at stubs, e.g. in the integration tests.
+	MORALIS_API_URL   EnvKey = "MORALIS_API_URL"
+	ETHERSCAN_API_URL EnvKey = "ETHERSCAN_API_URL"
+	BASESCAN_API_URL  EnvKey = "BASESCAN_API_URL"
+
+	// Basescan is called when Etherscan is rate limited or unreachable, if a key is set. Both
+	// ES_API_KEY and BASESCAN_API_KEY take several comma separated keys that are rotated.
+	BASESCAN_API_KEY EnvKey = "BASESCAN_API_KEY"
 
+	// WALLET_INGESTION is how wallet transactions come in: "websocket" (the default) subscribes
+	// to each wallet on RPC_WS_URL, "alchemy_webhook" takes Alchemy Notify address activity
+	// webhooks on POST /webhooks/alchemy of the HTTP server. Webhooks are verified with
+	// ALCHEMY_WEBHOOK_SIGNING_KEY; with ALCHEMY_WEBHOOK_ID and ALCHEMY_AUTH_TOKEN set, tracked
+	// wallets are added to the addresses of the webhook.
+	WALLET_INGESTION            EnvKey = "WALLET_INGESTION"
+	ALCHEMY_WEBHOOK_SIGNING_KEY EnvKey = "ALCHEMY_WEBHOOK_SIGNING_KEY"
+	ALCHEMY_WEBHOOK_ID          EnvKey = "ALCHEMY_WEBHOOK_ID"
+	ALCHEMY_AUTH_TOKEN          EnvKey = "ALCHEMY_AUTH_TOKEN"
+	ALCHEMY_DASHBOARD_API_URL   EnvKey = "ALCHEMY_DASHBOARD_API_URL"
+
+	// Wallet subscriptions are split between WALLET_SHARD_COUNT replicas (1 by default) by the
+	// hash of the wallet address; a replica watches the wallets of shard WALLET_SHARD_ID.
+	// WALLET_SHARD_PEERS lists the gRPC addresses of the replicas by shard id, comma separated,
+	// so wallets added on one replica are sent to the replica of their shard. Webhook ingestion
+	// has no subscriptions to split and ignores them.
+	WALLET_SHARD_COUNT EnvKey = "WALLET_SHARD_COUNT"
+	WALLET_SHARD_ID    EnvKey = "WALLET_SHARD_ID"
+	WALLET_SHARD_PEERS EnvKey = "WALLET_SHARD_PEERS"
+)
+
+// prefixMappings maps the WALLETDATA_ prefixed variables of the root .env, and a few provider
+// variables, to the standard names.
+var prefixMappings = map[string]string{
+	"WALLETDATA_PORT":         "PORT",
+	"WALLETDATA_HTTP_PORT":    "HTTP_PORT",
+	"WALLETDATA_DATABASE_URL": "DATABASE_URL",
+	"RPC_URL_BASE":            "RPC_URL",
+	"RPC_WS_URL_BASE":         "RPC_WS_URL",
+	"ETHERSCAN_API_KEY":       "ES_API_KEY",
+}
+
+// LoadEnv loads the env file with config.Load and sets the gRPC address of the other service.
+func LoadEnv(path string) {
+	config.Load(path, prefixMappings)
 	// Set TOKEN_GRPC_URL from TOKENDATA_PORT if not set
 	if os.Getenv("TOKEN_GRPC_URL") == "" {
 		host := os.Getenv("MICROSERVICES_HOST")
@@ -50,51 +95,3 @@ func mapPrefixedEnvVars() {
 		os.Setenv("TOKEN_GRPC_URL", host+":"+port)
 	}
 }
-
-func LoadEnv(path string) {
-	if os.Getenv("DOCKER") == "true" {
-		mapPrefixedEnvVars()
-		return
-	}
-
-	// Try to load root .env first (single source of truth)
-	// Get the executable's directory and navigate to root
-	execPath, err := os.Executable()
-	if err == nil {
-		rootEnv := filepath.Join(filepath.Dir(execPath), "..", "..", "..", "..", ".env")
-		if err := godotenv.Load(rootEnv); err == nil {
-			log.Println("root .env file loaded")
-			mapPrefixedEnvVars()
-			return
-		}
-	}
-
-	// Fallback: try relative path from working directory
-	rootEnv := filepath.Join("..", "..", "..", ".env")
-	if err := godotenv.Load(rootEnv); err == nil {
-		log.Println("root .env file loaded (relative path)")
-		mapPrefixedEnvVars()
-		return
-	}
-
-	// Fallback: try local .env
-	if err := godotenv.Load(path); err != nil {
-		log.Println("env file not found, will read from environment variables")
-	} else {
-		log.Println("local env file loaded")
-	}
-	mapPrefixedEnvVars()
-}
-
-func (key EnvKey) GetEnv() string {
-	return os.Getenv(string(key))
-}
-
-func (key EnvKey) GetEnvAsNumber() int64 {
-	val, err := strconv.ParseInt(key.GetEnv(), 10, 64)
-	if err != nil {
-		log.Fatal(err)
-		return 0
-	}
-	return val
-}
@@ -0,0 +1,138 @@
+// Package activity tells what a wallet transaction did from its input and the logs it emitted.
+package activity
+
+import (
+	"slices"
+	proto "walletdata/proto/wallet"
+	"walletdata/rpc"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Transaction is what a transaction is classified from.
+type Transaction struct {
+	// To is nil for contract creations.
+	To    *common.Address
+	Input []byte
+	// Logs are nil when the receipt was not read; the input alone decides then.
+	Logs []*types.Log
+}
+
+type selector [4]byte
+
+func selectorOf(signature string) selector {
+	return selector(crypto.Keccak256([]byte(signature))[:4])
+}
+
+func selectorsOf(signatures ...string) []selector {
+	selectors := make([]selector, len(signatures))
+	for i, signature := range signatures {
+		selectors[i] = selectorOf(signature)
+	}
+	return selectors
+}
+
+func topicsOf(signatures ...string) []common.Hash {
+	topics := make([]common.Hash, len(signatures))
+	for i, signature := range signatures {
+		topics[i] = crypto.Keccak256Hash([]byte(signature))
+	}
+	return topics
+}
+
+var (
+	// swapTopics are the Swap events of Uniswap V2, V3 and V4 pools and of Aerodrome pools.
+	swapTopics = topicsOf(
+		"Swap(address,uint256,uint256,uint256,uint256,address)",
+		"Swap(address,address,int256,int256,uint160,uint128,int24)",
+		"Swap(bytes32,address,int128,int128,uint160,uint128,int24,uint24)",
+		"Swap(address,address,uint256,uint256,uint256,uint256)",
+	)
+	transferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
+	// swapSelectors are the swaps of the Universal Router and the Uniswap V2 and V3 routers.
+	swapSelectors = selectorsOf(
+		"execute(bytes,bytes[],uint256)",
+		"execute(bytes,bytes[])",
+		"swapExactTokensForTokens(uint256,uint256,address[],address,uint256)",
+		"swapTokensForExactTokens(uint256,uint256,address[],address,uint256)",
+		"swapExactETHForTokens(uint256,address[],address,uint256)",
+		"swapETHForExactTokens(uint256,address[],address,uint256)",
+		"swapExactTokensForETH(uint256,uint256,address[],address,uint256)",
+		"swapTokensForExactETH(uint256,uint256,address[],address,uint256)",
+		"swapExactTokensForTokensSupportingFeeOnTransferTokens(uint256,uint256,address[],address,uint256)",
+		"swapExactETHForTokensSupportingFeeOnTransferTokens(uint256,address[],address,uint256)",
+		"swapExactTokensForETHSupportingFeeOnTransferTokens(uint256,uint256,address[],address,uint256)",
+		"exactInputSingle((address,address,uint24,address,uint256,uint256,uint256,uint160))",
+		"exactInputSingle((address,address,uint24,address,uint256,uint256,uint160))",
+		"exactInput((bytes,address,uint256,uint256,uint256))",
+		"exactInput((bytes,address,uint256,uint256))",
+		"exactOutputSingle((address,address,uint24,address,uint256,uint256,uint256,uint160))",
+		"exactOutputSingle((address,address,uint24,address,uint256,uint256,uint160))",
+		"exactOutput((bytes,address,uint256,uint256,uint256))",
+		"exactOutput((bytes,address,uint256,uint256))",
+	)
+	approvalSelectors = selectorsOf(
+		"approve(address,uint256)",
+		"increaseAllowance(address,uint256)",
+		"setApprovalForAll(address,bool)",
+		// Permit2
+		"approve(address,address,uint160,uint48)",
+	)
+	mintSelectors = selectorsOf(
+		"mint()",
+		"mint(uint256)",
+		"mint(address)",
+		"mint(address,uint256)",
+		"safeMint(address)",
+	)
+	transferSelectors = selectorsOf(
+		"transfer(address,uint256)",
+		"transferFrom(address,address,uint256)",
+		"safeTransferFrom(address,address,uint256)",
+		"safeTransferFrom(address,address,uint256,bytes)",
+	)
+)
+
+// Classify tells what a transaction did. A pool Swap event or a router swap call is a swap, an
+// allowance call an approval, a token created from the zero address or a mint call a mint, and
+// plain ETH and token transfers are transfers. Any other call is a contract interaction.
+func Classify(tx Transaction) proto.ActivityType {
+	var called selector
+	if len(tx.Input) >= 4 {
+		called = selector(tx.Input[:4])
+	}
+	switch {
+	case tx.To == nil:
+		return proto.ActivityType_ACTIVITY_CONTRACT_INTERACTION
+	case emits(tx.Logs, swapTopics) || slices.Contains(swapSelectors, called):
+		return proto.ActivityType_ACTIVITY_SWAP
+	case slices.Contains(approvalSelectors, called):
+		return proto.ActivityType_ACTIVITY_APPROVAL
+	case mints(tx.Logs) || slices.Contains(mintSelectors, called):
+		return proto.ActivityType_ACTIVITY_MINT
+	case len(tx.Input) == 0 || slices.Contains(transferSelectors, called):
+		return proto.ActivityType_ACTIVITY_TRANSFER
+	}
+	return proto.ActivityType_ACTIVITY_CONTRACT_INTERACTION
+}
+
+// Of classifies a mined wallet transaction.
+func Of(event rpc.WalletTransaction) proto.ActivityType {
+	return Classify(Transaction{To: event.Raw.To, Input: event.Raw.Input, Logs: event.Logs})
+}
+
+func emits(logs []*types.Log, topics []common.Hash) bool {
+	return slices.ContainsFunc(logs, func(log *types.Log) bool {
+		return len(log.Topics) > 0 && slices.Contains(topics, log.Topics[0])
+	})
+}
+
+// mints reports whether a Transfer from the zero address, ERC-20 or ERC-721, was emitted.
+func mints(logs []*types.Log) bool {
+	return slices.ContainsFunc(logs, func(log *types.Log) bool {
+		return len(log.Topics) >= 3 && log.Topics[0] == transferTopic && log.Topics[1] == (common.Hash{})
+	})
+}
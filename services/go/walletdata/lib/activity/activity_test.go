@@ -0,0 +1,48 @@
+package activity
+
+import (
+	"testing"
+	proto "walletdata/proto/wallet"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func call(signature string) []byte {
+	input := crypto.Keccak256([]byte(signature))[:4]
+	return append(input, make([]byte, 64)...)
+}
+
+func event(signature string, topics ...common.Hash) *types.Log {
+	return &types.Log{Topics: append([]common.Hash{crypto.Keccak256Hash([]byte(signature))}, topics...)}
+}
+
+func TestClassify(t *testing.T) {
+	to := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	wallet := common.BytesToHash(common.HexToAddress("0x2222222222222222222222222222222222222222").Bytes())
+	v3Swap := event("Swap(address,address,int256,int256,uint160,uint128,int24)")
+	mint := event("Transfer(address,address,uint256)", common.Hash{}, wallet)
+	transfer := event("Transfer(address,address,uint256)", wallet, wallet)
+
+	tests := []struct {
+		name string
+		tx   Transaction
+		want proto.ActivityType
+	}{
+		{"native transfer", Transaction{To: &to}, proto.ActivityType_ACTIVITY_TRANSFER},
+		{"token transfer", Transaction{To: &to, Input: call("transfer(address,uint256)"), Logs: []*types.Log{transfer}}, proto.ActivityType_ACTIVITY_TRANSFER},
+		{"router swap", Transaction{To: &to, Input: call("execute(bytes,bytes[],uint256)")}, proto.ActivityType_ACTIVITY_SWAP},
+		{"aggregator swap", Transaction{To: &to, Input: call("proxiedSwap(bytes)"), Logs: []*types.Log{transfer, v3Swap}}, proto.ActivityType_ACTIVITY_SWAP},
+		{"approval", Transaction{To: &to, Input: call("approve(address,uint256)")}, proto.ActivityType_ACTIVITY_APPROVAL},
+		{"mint call", Transaction{To: &to, Input: call("mint(uint256)")}, proto.ActivityType_ACTIVITY_MINT},
+		{"mint logs", Transaction{To: &to, Input: call("claim(uint256)"), Logs: []*types.Log{mint}}, proto.ActivityType_ACTIVITY_MINT},
+		{"contract call", Transaction{To: &to, Input: call("claim(uint256)"), Logs: []*types.Log{transfer}}, proto.ActivityType_ACTIVITY_CONTRACT_INTERACTION},
+		{"contract creation", Transaction{Input: call("constructor()")}, proto.ActivityType_ACTIVITY_CONTRACT_INTERACTION},
+	}
+	for _, test := range tests {
+		if got := Classify(test.tx); got != test.want {
+			t.Errorf("%s: Classify = %s, want %s", test.name, got, test.want)
+		}
+	}
+}
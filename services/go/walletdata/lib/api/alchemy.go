@@ -0,0 +1,43 @@
+package api
+
+import (
+	"fmt"
+	"samterminal/pkg/httpclient"
+	"strings"
+	"walletdata/env"
+)
+
+const alchemyDashboardAPI = "https://dashboard.alchemy.com/api"
+
+// alchemyAddressBatchSize bounds the addresses of one webhook update.
+const alchemyAddressBatchSize = 500
+
+// AlchemyWebhookConfigured reports whether the addresses of the Alchemy webhook can be managed.
+func AlchemyWebhookConfigured() bool {
+	return env.ALCHEMY_WEBHOOK_ID.GetEnv() != "" && env.ALCHEMY_AUTH_TOKEN.GetEnv() != ""
+}
+
+// AddAlchemyWebhookAddresses adds addresses to the address activity webhook, which ignores the
+// ones it already has.
+func AddAlchemyWebhookAddresses(addresses []string) error {
+	url := strings.TrimRight(env.ALCHEMY_DASHBOARD_API_URL.GetEnvOrDefault(alchemyDashboardAPI), "/") + "/update-webhook-addresses"
+	client := httpclient.New()
+	for i := 0; i < len(addresses); i += alchemyAddressBatchSize {
+		batch := addresses[i:min(i+alchemyAddressBatchSize, len(addresses))]
+		resp, err := client.R().
+			SetHeader("X-Alchemy-Token", env.ALCHEMY_AUTH_TOKEN.GetEnv()).
+			SetBody(map[string]any{
+				"webhook_id":          env.ALCHEMY_WEBHOOK_ID.GetEnv(),
+				"addresses_to_add":    batch,
+				"addresses_to_remove": []string{},
+			}).
+			Patch(url)
+		if err != nil {
+			return err
+		}
+		if resp.IsError() {
+			return fmt.Errorf("alchemy webhook update failed: %s", resp.Status())
+		}
+	}
+	return nil
+}
@@ -2,92 +2,101 @@ package api
 
 import (
 	"context"
-	"encoding/json"
 	"log"
-	"math"
+	"samterminal/pkg/numeric"
 	"strconv"
-	"walletdata/env"
+	"strings"
 	api_dto "walletdata/lib/api/dto"
 	token_client "walletdata/lib/grpc/client/token"
 	"walletdata/proto/common"
 
-	"github.com/go-resty/resty/v2"
+	"github.com/shopspring/decimal"
 )
 
-var apiKey string
-
-func init() {
-	env.LoadEnv("./.env")
-	apiKey = env.ES_API_KEY.GetEnv()
-}
-
-var apiUrl = "https://api.etherscan.io/v2/api"
-
-type Options struct {
-	chainid string
-	module  string
-	action  string
-	address string
-	page    string
-	offset  string
-	apikey  string
-}
-
+// GetWalletERC20Tokens lists the ERC-20 balances of a wallet on Base.
 func GetWalletERC20Tokens(walletAddress string) ([]api_dto.WalletERC20Token, error) {
-	client := resty.New()
-
 	var response = []api_dto.WalletERC20Token{}
-
-	var options Options = Options{
-		chainid: string(api_dto.ChainIdBase),
-		module:  "account",
-		action:  "addresstokenbalance",
-		address: walletAddress,
-		page:    "0",
-		offset:  "10000",
-		apikey:  apiKey,
-	}
-	resp, err := client.R().
-		SetQueryParams(map[string]string{
-			"action":  options.action,
-			"address": options.address,
-			"page":    options.page,
-			"offset":  options.offset,
-			"apikey":  options.apikey,
-			"chainid": options.chainid,
-			"module":  options.module,
-		}).
-		Get(apiUrl)
-	if err != nil || resp.StatusCode() != 200 {
-		return response, err
-	}
-	var responseData api_dto.WalletERC20TokensResponse
-	err = json.Unmarshal(resp.Body(), &responseData)
+	err := etherscanGet(map[string]string{
+		"module":  "account",
+		"action":  "addresstokenbalance",
+		"address": walletAddress,
+		"page":    "0",
+		"offset":  "10000",
+	}, &response)
 	if err != nil {
-		return response, err
+		return []api_dto.WalletERC20Token{}, err
 	}
-	response = append(response, responseData.Result...)
 	return response, nil
 }
 
-func Erc20TokensToWalletTokens(erc20Tokens []api_dto.WalletERC20Token) []common.WalletToken {
+// etherscanHistoryMax is the most records read from an Etherscan history endpoint, oldest first.
+const etherscanHistoryMax = "10000"
+
+// GetWalletTokenTransfers lists the ERC-20 transfers in and out of a wallet, oldest first.
+func GetWalletTokenTransfers(walletAddress string) ([]api_dto.TokenTransfer, error) {
+	transfers := []api_dto.TokenTransfer{}
+	err := etherscanGet(map[string]string{
+		"module":  "account",
+		"action":  "tokentx",
+		"address": walletAddress,
+		"sort":    "asc",
+		"page":    "1",
+		"offset":  etherscanHistoryMax,
+	}, &transfers)
+	return transfers, err
+}
+
+// GetWalletTransactions lists the transactions a wallet sent or received, oldest first. Internal
+// transactions are the ETH contracts sent it, e.g. when a router pays out a sale.
+func GetWalletTransactions(walletAddress string, internal bool) ([]api_dto.Transaction, error) {
+	action := "txlist"
+	if internal {
+		action = "txlistinternal"
+	}
+	transactions := []api_dto.Transaction{}
+	err := etherscanGet(map[string]string{
+		"module":  "account",
+		"action":  action,
+		"address": walletAddress,
+		"sort":    "asc",
+		"page":    "1",
+		"offset":  etherscanHistoryMax,
+	}, &transactions)
+	return transactions, err
+}
+
+// GetRecentWalletTransactions lists a page of the transactions a wallet sent or received, newest
+// first. Pages start at 1.
+func GetRecentWalletTransactions(walletAddress string, page int, pageSize int) ([]api_dto.Transaction, error) {
+	transactions := []api_dto.Transaction{}
+	err := etherscanGet(map[string]string{
+		"module":  "account",
+		"action":  "txlist",
+		"address": walletAddress,
+		"sort":    "desc",
+		"page":    strconv.Itoa(page),
+		"offset":  strconv.Itoa(pageSize),
+	}, &transactions)
+	return transactions, err
+}
 
-	walletTokens := []common.WalletToken{}
+func Erc20TokensToWalletTokens(erc20Tokens []api_dto.WalletERC20Token) []*common.WalletToken {
+
+	walletTokens := []*common.WalletToken{}
 	for _, erc20Token := range erc20Tokens {
-		tokenPrice, err := strconv.ParseFloat(erc20Token.TokenPriceUSD, 64)
+		tokenPrice, err := numeric.Parse(erc20Token.TokenPriceUSD)
 		if err != nil {
 			continue
 		}
-		tokenQuantity, err := strconv.ParseFloat(erc20Token.TokenQuantity, 64)
+		tokenQuantity, err := numeric.Parse(erc20Token.TokenQuantity)
 		if err != nil {
 			continue
 		}
-		tokenDollarValue := tokenPrice * tokenQuantity
-		walletTokens = append(walletTokens, common.WalletToken{
+		walletTokens = append(walletTokens, &common.WalletToken{
 			TokenAddress:          erc20Token.TokenAddress,
 			TokenName:             erc20Token.TokenName,
-			TokenPrice:            erc20Token.TokenPriceUSD,
-			TokenDollarValue:      strconv.FormatFloat(tokenDollarValue, 'f', -1, 64),
+			TokenPrice:            numeric.FormatPrice(tokenPrice),
+			TokenDollarValue:      numeric.FormatUSD(tokenPrice.Mul(tokenQuantity)),
 			TokenBalance:          erc20Token.TokenQuantity,
 			TokenBalanceFormatted: erc20Token.TokenQuantity,
 		})
@@ -95,10 +104,10 @@ func Erc20TokensToWalletTokens(erc20Tokens []api_dto.WalletERC20Token) []common.
 	return walletTokens
 }
 
-func GetWalletTokensFromEtherscan(walletAddress string) ([]common.WalletToken, error) {
+func GetWalletTokensFromEtherscan(walletAddress string) ([]*common.WalletToken, error) {
 	response, err := GetWalletERC20Tokens(walletAddress)
 	if err != nil {
-		return []common.WalletToken{}, err
+		return []*common.WalletToken{}, err
 	}
 	return Erc20TokensToWalletTokens(response), nil
 }
@@ -116,50 +125,98 @@ func GetWalletERC20TokenAddressList(walletAddress string) ([]string, error) {
 	return tokenAddressList, nil
 }
 
-func GetTotalDollarValueForAPI(tokensData []common.WalletToken) (string, error) {
-	totalDollarValue := 0.0
+// getDelistedTokens returns the tokens tokendata marked as delisted. Their price is frozen at the
+// last known value, so wallet values leave them out. Lookup errors leave every token counted.
+func getDelistedTokens(tokenAddresses []string) map[string]bool {
+	delisted := map[string]bool{}
+	// An empty request would return every tracked token.
+	if len(tokenAddresses) == 0 {
+		return delisted
+	}
+	tokensResponse, err := token_client.GetTokens(context.Background(), tokenAddresses, "delisted")
+	if err != nil {
+		log.Println("Error getting delisted tokens:", err)
+		return delisted
+	}
+	for _, token := range tokensResponse.Tokens {
+		if token.Delisted {
+			delisted[strings.ToLower(token.Address)] = true
+		}
+	}
+	return delisted
+}
+
+func GetTotalDollarValueForAPI(tokensData []*common.WalletToken) (string, error) {
+	tokenAddresses := make([]string, 0, len(tokensData))
 	for _, token := range tokensData {
-		tokenDollarValue, err := strconv.ParseFloat(token.TokenDollarValue, 64)
+		tokenAddresses = append(tokenAddresses, token.TokenAddress)
+	}
+	delisted := getDelistedTokens(tokenAddresses)
+
+	totalDollarValue := decimal.Zero
+	for _, token := range tokensData {
+		if delisted[strings.ToLower(token.TokenAddress)] {
+			continue
+		}
+		tokenDollarValue, err := numeric.Parse(token.TokenDollarValue)
 		if err != nil {
 			return "0", err
 		}
-		totalDollarValue += tokenDollarValue
+		totalDollarValue = totalDollarValue.Add(tokenDollarValue)
 	}
-	return strconv.FormatFloat(totalDollarValue, 'f', -1, 64), nil
+	return numeric.FormatUSD(totalDollarValue), nil
 }
 
 func GetTotalDollarValue(tokensData []api_dto.WalletERC20Token) (string, error) {
-	totalDollarValue := 0.0
-	prices := make(map[string]float64)
+	totalDollarValue := decimal.Zero
+	prices := make(map[string]decimal.Decimal)
 	for _, token := range tokensData {
-		price, err := strconv.ParseFloat(token.TokenPriceUSD, 64)
+		price, err := numeric.Parse(token.TokenPriceUSD)
 		if err != nil {
-			prices[token.TokenAddress] = 0.0
+			prices[token.TokenAddress] = decimal.Zero
 			continue
 		}
 		prices[token.TokenAddress] = price
 	}
+	tokenAddresses := make([]string, 0, len(prices))
+	for tokenAddress := range prices {
+		tokenAddresses = append(tokenAddresses, tokenAddress)
+	}
+	delisted := getDelistedTokens(tokenAddresses)
+
 	tokensForPrice := []string{}
 	for tokenAddress, price := range prices {
 		log.Println("tokenAddress", tokenAddress, "price", price)
-		if price == 0 {
+		if price.IsZero() {
 			tokensForPrice = append(tokensForPrice, tokenAddress)
 		}
 	}
 
-	tokensResponse, _ := token_client.GetTokens(context.Background(), tokensForPrice)
-	log.Println("tokensResponse", tokensResponse)
-	for _, token := range tokensResponse.Tokens {
-		price, err := strconv.ParseFloat(token.Price, 64)
+	// An empty request would return every tracked token.
+	if len(tokensForPrice) > 0 {
+		tokensResponse, err := token_client.GetTokens(context.Background(), tokensForPrice, "price")
 		if err != nil {
-			log.Println("error", err)
-			continue
+			log.Println("error getting token prices", err)
+		} else {
+			if tokensResponse.Partial {
+				log.Println("no token data for", tokensResponse.MissingAddresses)
+			}
+			for _, token := range tokensResponse.Tokens {
+				price, err := numeric.Parse(token.Price)
+				if err != nil {
+					log.Println("error", err)
+					continue
+				}
+				prices[token.Address] = price
+			}
 		}
-		prices[token.Address] = price
 	}
 
 	for _, token := range tokensData {
-		tokenQuantity, err := strconv.ParseFloat(token.TokenQuantity, 64)
+		if delisted[strings.ToLower(token.TokenAddress)] {
+			continue
+		}
+		tokenQuantity, err := numeric.Parse(token.TokenQuantity)
 		if err != nil {
 			log.Println("error", err)
 			return "0", err
@@ -169,10 +226,10 @@ func GetTotalDollarValue(tokensData []api_dto.WalletERC20Token) (string, error)
 			log.Println("error", err)
 			return "0", err
 		}
-		tokenQuantity = tokenQuantity / math.Pow10(int(tokenDivisor))
+		tokenQuantity = tokenQuantity.Shift(-int32(tokenDivisor))
 		price := prices[token.TokenAddress]
 
-		totalDollarValue += price * tokenQuantity
+		totalDollarValue = totalDollarValue.Add(price.Mul(tokenQuantity))
 	}
-	return strconv.FormatFloat(totalDollarValue, 'f', -1, 64), nil
+	return numeric.FormatUSD(totalDollarValue), nil
 }
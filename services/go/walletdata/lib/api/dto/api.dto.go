@@ -14,13 +14,6 @@ type WalletERC20TokensResponse struct {
 	Result  []WalletERC20Token `json:"result"`
 }
 
-type ChainId string
-
-const (
-	ChainIdEthereum ChainId = "1"
-	ChainIdBase     ChainId = "8453"
-)
-
 func WalletERC20TokensToTokenAddressList(tokensData []WalletERC20Token) []string {
 	var tokenAddressList = []string{}
 	for _, token := range tokensData {
@@ -28,3 +21,27 @@ func WalletERC20TokensToTokenAddressList(tokensData []WalletERC20Token) []string
 	}
 	return tokenAddressList
 }
+
+// TokenTransfer is an ERC-20 transfer from the Etherscan tokentx endpoint. Value is the raw amount.
+type TokenTransfer struct {
+	Hash            string `json:"hash"`
+	TimeStamp       string `json:"timeStamp"`
+	From            string `json:"from"`
+	To              string `json:"to"`
+	ContractAddress string `json:"contractAddress"`
+	Value           string `json:"value"`
+	TokenDecimal    string `json:"tokenDecimal"`
+	TokenSymbol     string `json:"tokenSymbol"`
+}
+
+// Transaction is a normal or internal transaction from the Etherscan txlist endpoints. Value is
+// in wei; Input is the hex calldata of normal transactions.
+type Transaction struct {
+	Hash      string `json:"hash"`
+	TimeStamp string `json:"timeStamp"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Value     string `json:"value"`
+	IsError   string `json:"isError"`
+	Input     string `json:"input"`
+}
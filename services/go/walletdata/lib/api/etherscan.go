@@ -0,0 +1,172 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"samterminal/pkg/chain"
+	"samterminal/pkg/httpclient"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+	"walletdata/env"
+)
+
+const (
+	apiUrl      = "https://api.etherscan.io/v2/api"
+	basescanAPI = "https://api.basescan.org/api"
+	// Rounds over every key of an endpoint before falling back to the next one.
+	etherscanRateLimitRounds = 3
+)
+
+// etherscanRateLimitWait is the pause between rounds, past the per second limit of a key.
+var etherscanRateLimitWait = 1100 * time.Millisecond
+
+var ErrEtherscanRateLimited = errors.New("etherscan rate limit reached")
+
+var etherscanClient = httpclient.New().
+	SetTimeout(10 * time.Second)
+
+// etherscanKeyOffset rotates the first key tried, spreading calls over the keys of an endpoint.
+var etherscanKeyOffset atomic.Uint64
+
+// etherscanEndpoint is an Etherscan compatible API and the keys it is called with.
+type etherscanEndpoint struct {
+	name string
+	url  string
+	keys []string
+}
+
+// etherscanEndpoints returns Etherscan and, when a Basescan key is set, Basescan as its fallback.
+// Both take several comma separated keys.
+func etherscanEndpoints() []etherscanEndpoint {
+	endpoints := []etherscanEndpoint{{
+		name: "etherscan",
+		url:  env.ETHERSCAN_API_URL.GetEnvOrDefault(apiUrl),
+		keys: splitKeys(env.ES_API_KEY.GetEnv()),
+	}}
+	if keys := env.BASESCAN_API_KEY.GetEnv(); keys != "" {
+		endpoints = append(endpoints, etherscanEndpoint{
+			name: "basescan",
+			url:  env.BASESCAN_API_URL.GetEnvOrDefault(basescanAPI),
+			keys: splitKeys(keys),
+		})
+	}
+	return endpoints
+}
+
+func splitKeys(raw string) []string {
+	keys := []string{}
+	for _, key := range strings.Split(raw, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	// Calls without a key still work at the lowest rate limit.
+	if len(keys) == 0 {
+		keys = append(keys, "")
+	}
+	return keys
+}
+
+type etherscanResponse struct {
+	Status  string          `json:"status"`
+	Message string          `json:"message"`
+	Result  json.RawMessage `json:"result"`
+}
+
+// etherscanError is a NOTOK response. Its result holds the reason instead of data.
+type etherscanError struct {
+	message string
+	result  string
+}
+
+func (e *etherscanError) Error() string {
+	return fmt.Sprintf("etherscan error: %s %s", e.message, e.result)
+}
+
+// retryable reports whether another key or endpoint may succeed where this one failed.
+func (e *etherscanError) retryable() bool {
+	result := strings.ToLower(e.result)
+	return strings.Contains(result, "rate limit") || strings.Contains(result, "invalid api key")
+}
+
+// isEmptyResult reports whether a status "0" response only means that there is nothing to list.
+func isEmptyResult(message string) bool {
+	return strings.HasPrefix(message, "No ") && strings.HasSuffix(message, " found")
+}
+
+// etherscanGet calls the endpoints in order until one answers and decodes its result into out.
+// Rate limited keys are skipped for the next one and retried a few times before the next
+// endpoint is tried, as is the next endpoint when one is unreachable. Other NOTOK responses are
+// returned as they are.
+func etherscanGet(params map[string]string, out any) error {
+	var lastErr error
+	for _, endpoint := range etherscanEndpoints() {
+		err := etherscanGetFrom(endpoint, params, out)
+		var notOK *etherscanError
+		if err == nil || (errors.As(err, &notOK) && !notOK.retryable()) {
+			return err
+		}
+		log.Printf("%s unavailable: %v", endpoint.name, err)
+		lastErr = err
+	}
+	return lastErr
+}
+
+func etherscanGetFrom(endpoint etherscanEndpoint, params map[string]string, out any) error {
+	var err error
+	offset := int(etherscanKeyOffset.Add(1))
+	for round := 0; round < etherscanRateLimitRounds; round++ {
+		if round > 0 {
+			time.Sleep(etherscanRateLimitWait)
+		}
+		for i := range endpoint.keys {
+			key := endpoint.keys[(offset+i)%len(endpoint.keys)]
+			err = etherscanCall(endpoint.url, key, params, out)
+			var notOK *etherscanError
+			if !errors.Is(err, ErrEtherscanRateLimited) && !(errors.As(err, &notOK) && notOK.retryable()) {
+				if err != nil {
+					return fmt.Errorf("%s: %w", endpoint.name, err)
+				}
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("%s: %w", endpoint.name, err)
+}
+
+func etherscanCall(url string, key string, params map[string]string, out any) error {
+	resp, err := etherscanClient.R().
+		SetQueryParams(params).
+		SetQueryParam("chainid", strconv.FormatInt(chain.Get().ID, 10)).
+		SetQueryParam("apikey", key).
+		Get(url)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() == http.StatusTooManyRequests {
+		return ErrEtherscanRateLimited
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return fmt.Errorf("etherscan returned status %d", resp.StatusCode())
+	}
+	var response etherscanResponse
+	if err := json.Unmarshal(resp.Body(), &response); err != nil {
+		return err
+	}
+	if response.Status != "1" {
+		if isEmptyResult(response.Message) {
+			return nil
+		}
+		var reason string
+		if err := json.Unmarshal(response.Result, &reason); err != nil {
+			reason = string(response.Result)
+		}
+		return &etherscanError{message: response.Message, result: reason}
+	}
+	return json.Unmarshal(response.Result, out)
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func etherscanStub(t *testing.T, respond func(key string) any) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(respond(r.URL.Query().Get("apikey")))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+var rateLimited = map[string]any{"status": "0", "message": "NOTOK", "result": "Max calls per sec rate limit reached (5/sec)"}
+
+func TestGetWalletERC20TokensRotatesKeysAndFallsBack(t *testing.T) {
+	etherscanRateLimitWait = 0
+	calls := map[string]int{}
+	etherscan := etherscanStub(t, func(key string) any {
+		calls[key]++
+		return rateLimited
+	})
+	basescan := etherscanStub(t, func(key string) any {
+		calls[key]++
+		return map[string]any{"status": "1", "message": "OK", "result": []map[string]string{{"TokenAddress": "0xaaaa"}}}
+	})
+	t.Setenv("ETHERSCAN_API_URL", etherscan.URL)
+	t.Setenv("ES_API_KEY", "a, b")
+	t.Setenv("BASESCAN_API_URL", basescan.URL)
+	t.Setenv("BASESCAN_API_KEY", "c")
+
+	tokens, err := GetWalletERC20Tokens("0x1111111111111111111111111111111111111111")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens) != 1 || tokens[0].TokenAddress != "0xaaaa" {
+		t.Errorf("tokens = %+v", tokens)
+	}
+	if calls["a"] != etherscanRateLimitRounds || calls["b"] != etherscanRateLimitRounds || calls["c"] != 1 {
+		t.Errorf("calls = %v", calls)
+	}
+}
+
+func TestGetWalletERC20TokensStatus(t *testing.T) {
+	etherscanRateLimitWait = 0
+	responses := map[string]any{
+		"empty":       map[string]any{"status": "0", "message": "No tokens found", "result": []any{}},
+		"invalid":     map[string]any{"status": "0", "message": "NOTOK", "result": "Error! Invalid address format"},
+		"ratelimited": rateLimited,
+	}
+	for name, response := range responses {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("ETHERSCAN_API_URL", etherscanStub(t, func(string) any { return response }).URL)
+			t.Setenv("BASESCAN_API_KEY", "")
+
+			tokens, err := GetWalletERC20Tokens("0x1111111111111111111111111111111111111111")
+			if name == "empty" && (err != nil || len(tokens) != 0) {
+				t.Errorf("tokens = %+v, err = %v", tokens, err)
+			}
+			if name != "empty" && err == nil {
+				t.Error("NOTOK response read as an empty wallet")
+			}
+		})
+	}
+}
@@ -1,53 +1,121 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"log"
+	"samterminal/pkg/chain"
+	"samterminal/pkg/httpclient"
+	"samterminal/pkg/numeric"
+	"samterminal/pkg/usage"
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 	"walletdata/env"
+	token_client "walletdata/lib/grpc/client/token"
 	"walletdata/proto/common"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/shopspring/decimal"
 )
 
 type WalletTokensResponse struct {
 	Result []struct {
-		Balance          string  `json:"balance"`
-		BalanceFormatted string  `json:"balance_formatted"`
-		TokenAddress     string  `json:"token_address"`
-		TokenName        string  `json:"name"`
-		TokenSymbol      string  `json:"symbol"`
-		TokenDecimals    int     `json:"decimals"`
-		Price            float64 `json:"usd_price"`
-		DollarValue      float64 `json:"usd_value"`
-		Image            string  `json:"logo"`
+		Balance          string          `json:"balance"`
+		BalanceFormatted string          `json:"balance_formatted"`
+		TokenAddress     string          `json:"token_address"`
+		TokenName        string          `json:"name"`
+		TokenSymbol      string          `json:"symbol"`
+		TokenDecimals    int             `json:"decimals"`
+		Price            decimal.Decimal `json:"usd_price"`
+		DollarValue      decimal.Decimal `json:"usd_value"`
+		Image            string          `json:"logo"`
+		PossibleSpam     bool            `json:"possible_spam"`
 	} `json:"result"`
 }
 
 type TokenStatusResponse struct {
-	SecureTokenAddresses   []string             `json:"secureTokenAddresses"`
-	InsecureTokenAddresses []string             `json:"insecureTokenAddresses"`
-	SecureTokens           []common.WalletToken `json:"secureTokens"`
+	SecureTokenAddresses   []string              `json:"secureTokenAddresses"`
+	InsecureTokenAddresses []string              `json:"insecureTokenAddresses"`
+	SecureTokens           []*common.WalletToken `json:"secureTokens"`
+	InsecureTokens         []*common.WalletToken `json:"insecureTokens"`
 }
 
-func init() {
-	env.LoadEnv("./.env")
-	apiKey = env.MORALIS_API_KEY.GetEnv()
+const moralisAPI = "https://deep-index.moralis.io/api/v2.2"
+
+// moralisClient returns a client whose requests count towards the Moralis budget.
+func moralisClient() *resty.Client {
+	return usage.Track(httpclient.New(), usage.Moralis)
 }
 
-func GetWalletTokens(walletAddress string, excludeSpam bool) (*[]common.WalletToken, error) {
-	response := []common.WalletToken{}
-	url := "https://deep-index.moralis.io/api/v2.2/wallets/" + walletAddress + "/tokens"
+// blacklistTTL is how long the blacklist of tokendata is reused to flag spam without Moralis.
+const blacklistTTL = time.Minute
+
+var (
+	blacklistMu sync.Mutex
+	blacklist   map[string]bool
+	blacklistAt time.Time
+	// getBlacklist reads the blacklist from tokendata; tests replace it.
+	getBlacklist = token_client.GetBlacklist
+)
+
+// blacklistedTokens returns the tokens tokendata blacklisted, which stand in for the spam flag of
+// Moralis.
+func blacklistedTokens() (map[string]bool, error) {
+	blacklistMu.Lock()
+	defer blacklistMu.Unlock()
+	if blacklist != nil && time.Since(blacklistAt) < blacklistTTL {
+		return blacklist, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	addresses, err := getBlacklist(ctx)
+	if err != nil {
+		return nil, err
+	}
+	blacklist = make(map[string]bool, len(addresses))
+	for _, address := range addresses {
+		blacklist[strings.ToLower(address)] = true
+	}
+	blacklistAt = time.Now()
+	return blacklist, nil
+}
+
+// GetWalletTokens lists the tokens of a wallet with their balances and prices from Moralis. Over
+// the Moralis budget they are read from Etherscan instead, which has no spam flag: the tokens
+// tokendata blacklisted are flagged as spam then.
+func GetWalletTokens(walletAddress string, excludeSpam bool) ([]*common.WalletToken, error) {
+	if usage.OverBudget(usage.Moralis) {
+		tokens, err := GetWalletTokensFromEtherscan(walletAddress)
+		if err != nil {
+			return nil, err
+		}
+		spam, err := blacklistedTokens()
+		if err != nil {
+			return nil, fmt.Errorf("error getting blacklist: %w", err)
+		}
+		listed := []*common.WalletToken{}
+		for _, token := range tokens {
+			token.Quarantined = spam[strings.ToLower(token.TokenAddress)]
+			if !token.Quarantined || !excludeSpam {
+				listed = append(listed, token)
+			}
+		}
+		return listed, nil
+	}
+	response := []*common.WalletToken{}
+	url := strings.TrimRight(env.MORALIS_API_URL.GetEnvOrDefault(moralisAPI), "/") + "/wallets/" + walletAddress + "/tokens"
 
-	client := resty.New()
+	client := moralisClient()
 	var walletTokens WalletTokensResponse
 	resp, err := client.R().
-		SetHeader("X-API-Key", apiKey).
+		SetHeader("X-API-Key", env.MORALIS_API_KEY.GetEnv()).
 		SetQueryParam("exclude_spam", strconv.FormatBool(excludeSpam)).
 		SetQueryParam("limit", "100").
-		SetQueryParam("chain", "base").
+		SetQueryParam("chain", chain.Get().MoralisID).
 		Get(url)
 
 	if err != nil {
@@ -55,68 +123,72 @@ func GetWalletTokens(walletAddress string, excludeSpam bool) (*[]common.WalletTo
 	}
 	err = json.Unmarshal(resp.Body(), &walletTokens)
 	for _, token := range walletTokens.Result {
-		response = append(response, common.WalletToken{
+		response = append(response, &common.WalletToken{
 			TokenAddress:          token.TokenAddress,
 			TokenName:             token.TokenName,
 			TokenSymbol:           token.TokenSymbol,
 			TokenBalance:          token.Balance,
 			TokenBalanceFormatted: token.BalanceFormatted,
-			TokenPrice:            strconv.FormatFloat(token.Price, 'f', -1, 64),
-			TokenDollarValue:      strconv.FormatFloat(token.DollarValue, 'f', -1, 64),
+			TokenPrice:            numeric.FormatPrice(token.Price),
+			TokenDollarValue:      numeric.FormatUSD(token.DollarValue),
 			TokenImage:            token.Image,
+			DataSource:            DataSourceMoralis,
+			Quarantined:           token.PossibleSpam,
 		})
 	}
 	if err != nil {
 		return nil, err
 	}
 
-	return &response, nil
+	return response, nil
 }
 
-func GetWalletSecureTokenAddresses(walletAddress string) ([]string, []common.WalletToken, error) {
+func GetWalletSecureTokenAddresses(walletAddress string) ([]string, []*common.WalletToken, error) {
 	response := []string{}
 	secureTokens, err := GetWalletTokens(walletAddress, true)
 	if err != nil {
 		return nil, nil, err
 	}
-	for _, token := range *secureTokens {
+	for _, token := range secureTokens {
 		response = append(response, token.TokenAddress)
 	}
-	return response, *secureTokens, nil
+	return response, secureTokens, nil
 }
 
-func GetWalletAllTokenAddresses(walletAddress string) ([]string, []common.WalletToken, error) {
+func GetWalletAllTokenAddresses(walletAddress string) ([]string, []*common.WalletToken, error) {
 	response := []string{}
 	secureTokens, err := GetWalletTokens(walletAddress, false)
 	if err != nil {
 		return nil, nil, err
 	}
-	for _, token := range *secureTokens {
+	for _, token := range secureTokens {
 		response = append(response, token.TokenAddress)
 	}
-	return response, *secureTokens, nil
+	return response, secureTokens, nil
 }
 
 func GetTokenStatus(walletAddress string) (*TokenStatusResponse, error) {
 	response := TokenStatusResponse{
 		SecureTokenAddresses:   []string{},
 		InsecureTokenAddresses: []string{},
-		SecureTokens:           []common.WalletToken{},
+		SecureTokens:           []*common.WalletToken{},
+		InsecureTokens:         []*common.WalletToken{},
 	}
 	secureTokenAddresses, secureTokens, err := GetWalletSecureTokenAddresses(walletAddress)
 	log.Println("secureTokens", secureTokenAddresses)
 	if err != nil {
 		return nil, err
 	}
-	allTokenAddresses, _, err := GetWalletAllTokenAddresses(walletAddress)
+	allTokenAddresses, allTokens, err := GetWalletAllTokenAddresses(walletAddress)
 	if err != nil {
 		log.Println("error getting all tokens", err)
 		return nil, err
 	}
 	insecureTokens := []string{}
-	for _, token := range allTokenAddresses {
+	for i, token := range allTokenAddresses {
 		if !slices.Contains(secureTokenAddresses, strings.ToLower(token)) {
 			insecureTokens = append(insecureTokens, strings.ToLower(token))
+			response.InsecureTokens = append(response.InsecureTokens, allTokens[i])
 		}
 	}
 	log.Println("insecureTokens", insecureTokens)
@@ -125,3 +197,63 @@ func GetTokenStatus(walletAddress string) (*TokenStatusResponse, error) {
 	response.SecureTokens = secureTokens
 	return &response, nil
 }
+
+// WalletApproval is an ERC-20 approval the Moralis indexer reports as still active. Value and
+// CurrentBalance are raw token amounts.
+type WalletApproval struct {
+	Value           string `json:"value"`
+	TransactionHash string `json:"transaction_hash"`
+	BlockTimestamp  string `json:"block_timestamp"`
+	Token           struct {
+		Address        string `json:"address"`
+		Name           string `json:"name"`
+		Symbol         string `json:"symbol"`
+		CurrentBalance string `json:"current_balance"`
+	} `json:"token"`
+	Spender struct {
+		Address      string `json:"address"`
+		AddressLabel string `json:"address_label"`
+	} `json:"spender"`
+}
+
+type walletApprovalsResponse struct {
+	Cursor string           `json:"cursor"`
+	Result []WalletApproval `json:"result"`
+}
+
+// approvalPages caps how many pages of 100 approvals are read for one wallet.
+const approvalPages = 5
+
+func GetWalletApprovals(walletAddress string) ([]WalletApproval, error) {
+	url := strings.TrimRight(env.MORALIS_API_URL.GetEnvOrDefault(moralisAPI), "/") + "/wallets/" + walletAddress + "/approvals"
+
+	client := moralisClient()
+	approvals := []WalletApproval{}
+	cursor := ""
+	for page := 0; page < approvalPages; page++ {
+		request := client.R().
+			SetHeader("X-API-Key", env.MORALIS_API_KEY.GetEnv()).
+			SetQueryParam("limit", "100").
+			SetQueryParam("chain", chain.Get().MoralisID)
+		if cursor != "" {
+			request.SetQueryParam("cursor", cursor)
+		}
+		resp, err := request.Get(url)
+		if err != nil {
+			return nil, err
+		}
+		if resp.IsError() {
+			return nil, fmt.Errorf("moralis approvals: %s", resp.Status())
+		}
+		var response walletApprovalsResponse
+		if err := json.Unmarshal(resp.Body(), &response); err != nil {
+			return nil, err
+		}
+		approvals = append(approvals, response.Result...)
+		if response.Cursor == "" {
+			break
+		}
+		cursor = response.Cursor
+	}
+	return approvals, nil
+}
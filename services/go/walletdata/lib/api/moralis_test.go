@@ -0,0 +1,49 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	token_client "walletdata/lib/grpc/client/token"
+)
+
+func TestGetWalletTokensOverMoralisBudget(t *testing.T) {
+	moralisCalls := 0
+	moralis := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		moralisCalls++
+		json.NewEncoder(w).Encode(map[string]any{"result": []map[string]any{{"token_address": "0xaaaa", "usd_price": "2"}}})
+	}))
+	t.Cleanup(moralis.Close)
+	etherscan := etherscanStub(t, func(key string) any {
+		return map[string]any{"status": "1", "message": "OK", "result": []map[string]string{
+			{"TokenAddress": "0xbbbb", "TokenPriceUSD": "3", "TokenQuantity": "1"},
+			{"TokenAddress": "0xCCCC", "TokenPriceUSD": "1", "TokenQuantity": "1"},
+		}}
+	})
+	getBlacklist = func(ctx context.Context) ([]string, error) { return []string{"0xcccc"}, nil }
+	defer func() { getBlacklist, blacklist = token_client.GetBlacklist, nil }()
+	t.Setenv("MORALIS_API_URL", moralis.URL)
+	t.Setenv("ETHERSCAN_API_URL", etherscan.URL)
+	t.Setenv("ES_API_KEY", "a")
+	t.Setenv("API_DAILY_BUDGETS", "moralis=1")
+
+	wallet := "0x1111111111111111111111111111111111111111"
+	for i := 0; i < 2; i++ {
+		if tokens, err := GetWalletTokens(wallet, true); err != nil || tokens[0].TokenAddress != "0xaaaa" {
+			t.Fatalf("call %d: tokens = %+v, err = %v, want the Moralis tokens", i, tokens, err)
+		}
+	}
+	// The budget is soft: calls go to Moralis until they exceed it, then to Etherscan.
+	tokens, err := GetWalletTokens(wallet, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if moralisCalls != 2 || len(tokens) != 1 || tokens[0].TokenAddress != "0xbbbb" {
+		t.Errorf("moralis calls = %d, tokens = %+v, want the Etherscan tokens without the blacklisted one", moralisCalls, tokens)
+	}
+	if tokens, err := GetWalletTokens(wallet, false); err != nil || len(tokens) != 2 || !tokens[1].Quarantined {
+		t.Errorf("tokens = %+v, err = %v, want the blacklisted token flagged as spam", tokens, err)
+	}
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"log"
+	"math/big"
+	"samterminal/pkg/numeric"
+	"strings"
+	"walletdata/env"
+	"walletdata/proto/common"
+	"walletdata/rpc"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+)
+
+// Sources of wallet token balances.
+const (
+	DataSourceStored  = "stored"
+	DataSourceMoralis = "moralis"
+	DataSourceOnChain = "onchain"
+)
+
+// defaultBalanceTolerance is the relative difference between the Moralis and on-chain balances of
+// a token past which the on-chain one is used.
+const defaultBalanceTolerance = 0.01
+
+// ReconcileWalletTokens checks the balances Moralis reported for a wallet against the chain and
+// returns the data source of the result. Balances off by more than WALLET_BALANCE_TOLERANCE are
+// replaced by the on-chain balance, valued at the Moralis price. Tokens are left as reported when
+// the chain cannot be read.
+func ReconcileWalletTokens(walletAddress string, tokens []*common.WalletToken) string {
+	if len(tokens) == 0 {
+		return DataSourceMoralis
+	}
+	addresses := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		addresses = append(addresses, token.TokenAddress)
+	}
+	balances, err := rpc.GetTokenBalances(walletAddress, addresses)
+	if err != nil {
+		log.Println("Error reading on-chain balances of", walletAddress, ":", err)
+		return DataSourceMoralis
+	}
+
+	tolerance := decimal.NewFromFloat(env.WALLET_BALANCE_TOLERANCE.GetEnvAsFloatOrDefault(defaultBalanceTolerance))
+	source := DataSourceMoralis
+	for _, token := range tokens {
+		onChain, ok := balances[strings.ToLower(token.TokenAddress)]
+		if !ok || !balanceDiffers(token.TokenBalance, onChain, tolerance) {
+			continue
+		}
+		decimals, err := rpc.GetTokenDecimals(ethcommon.HexToAddress(token.TokenAddress))
+		if err != nil {
+			log.Println("Error reading decimals of", token.TokenAddress, ":", err)
+			continue
+		}
+		log.Printf("Moralis balance of %s for %s is %s, on-chain %s", token.TokenAddress, walletAddress, token.TokenBalance, onChain)
+		setOnChainBalance(token, onChain, decimals)
+		source = DataSourceOnChain
+	}
+	return source
+}
+
+// balanceDiffers reports whether a reported raw balance is off from the on-chain one by more than
+// tolerance, relative to the larger of the two.
+func balanceDiffers(reported string, onChain *big.Int, tolerance decimal.Decimal) bool {
+	reportedBalance, err := decimal.NewFromString(reported)
+	if err != nil {
+		return true
+	}
+	actual := decimal.NewFromBigInt(onChain, 0)
+	larger := decimal.Max(reportedBalance, actual)
+	if larger.IsZero() {
+		return false
+	}
+	return reportedBalance.Sub(actual).Abs().Div(larger).GreaterThan(tolerance)
+}
+
+// setOnChainBalance replaces the balance of a token, revaluing it at its price.
+func setOnChainBalance(token *common.WalletToken, balance *big.Int, decimals int) {
+	formatted := decimal.NewFromBigInt(balance, -int32(decimals))
+	token.TokenBalance = balance.String()
+	token.TokenBalanceFormatted = formatted.String()
+	if price, err := decimal.NewFromString(token.TokenPrice); err == nil {
+		token.TokenDollarValue = numeric.FormatUSD(formatted.Mul(price))
+	}
+	token.DataSource = DataSourceOnChain
+}
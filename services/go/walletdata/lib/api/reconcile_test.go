@@ -0,0 +1,45 @@
+package api
+
+import (
+	"math/big"
+	"testing"
+	"walletdata/proto/common"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestBalanceDiffers(t *testing.T) {
+	tolerance := decimal.NewFromFloat(0.01)
+	cases := []struct {
+		reported string
+		onChain  int64
+		want     bool
+	}{
+		{"1000", 1000, false},
+		{"1000", 995, false},
+		{"1000", 900, true},
+		{"0", 500, true},
+		{"0", 0, false},
+		{"not a number", 1, true},
+	}
+	for _, c := range cases {
+		if got := balanceDiffers(c.reported, big.NewInt(c.onChain), tolerance); got != c.want {
+			t.Errorf("balanceDiffers(%q, %d) = %v, want %v", c.reported, c.onChain, got, c.want)
+		}
+	}
+}
+
+func TestSetOnChainBalance(t *testing.T) {
+	token := &common.WalletToken{TokenBalance: "1000000", TokenBalanceFormatted: "1", TokenPrice: "2", DataSource: DataSourceMoralis}
+	setOnChainBalance(token, big.NewInt(2500000), 6)
+
+	if token.TokenBalance != "2500000" || token.TokenBalanceFormatted != "2.5" {
+		t.Fatalf("balance = %s (%s), want 2500000 (2.5)", token.TokenBalance, token.TokenBalanceFormatted)
+	}
+	if token.TokenDollarValue != "5.00" {
+		t.Fatalf("dollar value = %s, want 5.00", token.TokenDollarValue)
+	}
+	if token.DataSource != DataSourceOnChain {
+		t.Fatalf("data source = %s, want %s", token.DataSource, DataSourceOnChain)
+	}
+}
@@ -0,0 +1,76 @@
+package events
+
+import (
+	"log"
+	"sync"
+	proto "walletdata/proto/wallet"
+)
+
+// subscriberBuffer is how many events a slow subscriber may fall behind before events are dropped
+// for it.
+const subscriberBuffer = 64
+
+var (
+	mu          sync.RWMutex
+	nextID      int
+	subscribers = map[int]chan *proto.WalletEvent{}
+)
+
+// Subscribe returns a channel receiving every published wallet event and a function that
+// unsubscribes and closes it.
+func Subscribe() (<-chan *proto.WalletEvent, func()) {
+	mu.Lock()
+	defer mu.Unlock()
+	id := nextID
+	nextID++
+	ch := make(chan *proto.WalletEvent, subscriberBuffer)
+	subscribers[id] = ch
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			mu.Lock()
+			defer mu.Unlock()
+			delete(subscribers, id)
+			close(ch)
+		})
+	}
+}
+
+// WalletAddress returns the wallet an event belongs to.
+func WalletAddress(event *proto.WalletEvent) string {
+	switch e := event.Event.(type) {
+	case *proto.WalletEvent_Trade:
+		return e.Trade.WalletAddress
+	case *proto.WalletEvent_Flow:
+		return e.Flow.WalletAddress
+	case *proto.WalletEvent_Alert:
+		return e.Alert.WalletAddress
+	}
+	return ""
+}
+
+// publish fans an event out to all subscribers without blocking the publisher.
+func publish(event *proto.WalletEvent, txHash string) {
+	mu.RLock()
+	defer mu.RUnlock()
+	for id, ch := range subscribers {
+		select {
+		case ch <- event:
+		default:
+			log.Println("wallet event subscriber", id, "is full, dropping event", txHash)
+		}
+	}
+}
+
+func PublishWalletTrade(trade *proto.WalletTrade) {
+	publish(&proto.WalletEvent{Event: &proto.WalletEvent_Trade{Trade: trade}}, trade.TxHash)
+}
+
+func PublishWalletFlow(flow *proto.WalletFlow) {
+	publish(&proto.WalletEvent{Event: &proto.WalletEvent_Flow{Flow: flow}}, flow.TxHash)
+}
+
+func PublishWalletAlert(alert *proto.WalletAlert) {
+	publish(&proto.WalletEvent{Event: &proto.WalletEvent_Alert{Alert: alert}}, alert.TxHash)
+}
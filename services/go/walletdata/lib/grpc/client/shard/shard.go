@@ -0,0 +1,44 @@
+// Package shard_client calls the walletdata replicas of other wallet shards.
+package shard_client
+
+import (
+	"context"
+	"sync"
+
+	"samterminal/pkg/telemetry"
+	proto "walletdata/proto/wallet"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var (
+	mu      sync.Mutex
+	clients = map[string]proto.ScannerWalletClient{}
+)
+
+func getClient(address string) (proto.ScannerWalletClient, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if client, ok := clients[address]; ok {
+		return client, nil
+	}
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()), telemetry.GRPCDialOption())
+	if err != nil {
+		return nil, err
+	}
+	client := proto.NewScannerWalletClient(conn)
+	clients[address] = client
+	return client, nil
+}
+
+// AddWallet adds a wallet on the replica at address. A replica starts watching a wallet it
+// already tracks when the wallet is of its shard, so this also hands a wallet to its shard.
+func AddWallet(ctx context.Context, address string, walletAddress string) error {
+	client, err := getClient(address)
+	if err != nil {
+		return err
+	}
+	_, err = client.AddWallet(ctx, &proto.AddWalletRequest{WalletAddress: walletAddress})
+	return err
+}
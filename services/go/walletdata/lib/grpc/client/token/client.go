@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 
+	"samterminal/pkg/telemetry"
 	"walletdata/env"
 	proto "walletdata/proto/token"
 
@@ -16,7 +17,7 @@ var grpcConn *grpc.ClientConn
 
 func init() {
 	env.LoadEnv("./.env")
-	conn, err := grpc.NewClient(env.TOKEN_GRPC_URL.GetEnv(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.NewClient(env.TOKEN_GRPC_URL.GetEnv(), grpc.WithTransportCredentials(insecure.NewCredentials()), telemetry.GRPCDialOption())
 	if err != nil {
 		log.Println("error creating grpc client", err)
 		return
@@ -36,15 +37,50 @@ func GetToken(ctx context.Context, tokenAddress string) (*proto.GetTokenResponse
 	return grpcClient.GetToken(ctx, &proto.GetTokenRequest{TokenAddress: tokenAddress})
 }
 
-func GetTokens(ctx context.Context, tokenAddresses []string) (*proto.GetTokensResponse, error) {
-	return grpcClient.GetTokens(ctx, &proto.GetTokensRequest{TokenAddresses: tokenAddresses})
+// GetTokens reads tokens from tokendata. fields limits the token fields returned, e.g. "price";
+// none returns every field.
+func GetTokens(ctx context.Context, tokenAddresses []string, fields ...string) (*proto.GetTokensResponse, error) {
+	return grpcClient.GetTokens(ctx, &proto.GetTokensRequest{TokenAddresses: tokenAddresses, Fields: fields})
 }
 
 func AddToken(ctx context.Context, request *proto.AddTokenRequest) (*proto.AddTokenResponse, error) {
 	return grpcClient.AddToken(ctx, &proto.AddTokenRequest{TokenAddress: request.TokenAddress})
 }
 
+// AddTokens asks tokendata to track many tokens in one call.
+func AddTokens(ctx context.Context, tokenAddresses []string, reason string) (*proto.AddTokensResponse, error) {
+	request := &proto.AddTokensRequest{}
+	for _, tokenAddress := range tokenAddresses {
+		request.Tokens = append(request.Tokens, &proto.AddTokenRequest{TokenAddress: tokenAddress, Reason: &reason})
+	}
+	return grpcClient.AddTokens(ctx, request)
+}
+
+// GetBlacklist returns every token tokendata blacklisted.
+func GetBlacklist(ctx context.Context) ([]string, error) {
+	response, err := grpcClient.GetBlacklist(ctx, &proto.GetBlacklistRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return response.TokenAddresses, nil
+}
+
 func AddBlacklist(ctx context.Context, request *proto.AddBlacklistRequest) (*proto.AddBlacklistResponse, error) {
 	log.Println("adding blacklist", request.TokenAddresses)
 	return grpcClient.AddBlacklist(ctx, request)
 }
+
+func GetTokenHolders(ctx context.Context, tokenAddress string, limit int32) (*proto.GetTokenHoldersResponse, error) {
+	return grpcClient.GetTokenHolders(ctx, &proto.GetTokenHoldersRequest{TokenAddress: tokenAddress, Limit: &limit})
+}
+
+// GetOrAddToken returns a token, asking tokendata to start tracking it if it is unknown.
+func GetOrAddToken(ctx context.Context, tokenAddress string) (*proto.GetTokenResponse, error) {
+	return grpcClient.GetToken(ctx, &proto.GetTokenRequest{TokenAddress: tokenAddress, AddIfNotExist: true})
+}
+
+// GetTokenPricesAt returns the prices tokendata recorded for tokens at past times, in the order
+// of the queries.
+func GetTokenPricesAt(ctx context.Context, queries []*proto.TokenPriceAtQuery) (*proto.GetTokenPricesAtResponse, error) {
+	return grpcClient.GetTokenPricesAt(ctx, &proto.GetTokenPricesAtRequest{Queries: queries})
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"samterminal/pkg/telemetry"
 	"walletdata/env"
 	"walletdata/lib/grpc/server"
 	proto "walletdata/proto/wallet"
@@ -20,7 +21,7 @@ func StartServer() {
 	} else {
 		log.Printf("Server started at: %d", env.PORT.GetEnvAsNumber())
 	}
-	var opts []grpc.ServerOption
+	opts := []grpc.ServerOption{telemetry.GRPCServerOption()}
 	grpcServer = grpc.NewServer(opts...)
 	proto.RegisterScannerWalletServer(grpcServer, server.NewWalletServer())
 	err = grpcServer.Serve(lis)
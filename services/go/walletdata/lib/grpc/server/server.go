@@ -2,11 +2,22 @@ package server
 
 import (
 	"context"
+	"errors"
 	"log"
+	"samterminal/pkg/usage"
+	"slices"
 	"strings"
 	repository "walletdata/database/repositories"
+	db "walletdata/generated/prisma"
+	"walletdata/lib/api"
+	"walletdata/lib/events"
+	"walletdata/lib/webhook"
 	"walletdata/proto/common"
 	proto "walletdata/proto/wallet"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type Server struct {
@@ -18,45 +29,415 @@ func NewWalletServer() *Server {
 }
 
 func (s *Server) AddWallet(ctx context.Context, req *proto.AddWalletRequest) (*proto.AddWalletResponse, error) {
-	err := repository.AddWallet(strings.ToLower(req.WalletAddress), []string{})
+	err := repository.AddWallet(ctx, strings.ToLower(req.WalletAddress), []string{})
 	if err != nil {
 		return nil, err
 	}
+	if len(req.Tags) > 0 {
+		if err := repository.AddWalletTags(ctx, req.WalletAddress, req.Tags); err != nil {
+			return nil, err
+		}
+	}
 	return &proto.AddWalletResponse{Success: true}, nil
 }
 
 func (s *Server) GetWallet(ctx context.Context, req *proto.GetWalletRequest) (*proto.GetWalletResponse, error) {
-	var wallet *common.Wallet
-	var err error
-
-	wallet, err = repository.GetOrCreateWallet(strings.ToLower(req.WalletAddress), req.TokenAddresses)
+	wallet, freshness, err := repository.GetOrCreateWallet(ctx, strings.ToLower(req.WalletAddress), req.Type, req.TokenAddresses)
 	if err != nil {
 		return nil, err
 	}
-	return &proto.GetWalletResponse{WalletData: wallet}, nil
+	return &proto.GetWalletResponse{
+		WalletData: wallet,
+		DataSource: freshness.DataSource,
+		UpdatedAt:  freshness.UpdatedAt.Unix(),
+		Refreshing: freshness.Refreshing,
+	}, nil
 }
 
 func (s *Server) GetWalletTokens(ctx context.Context, req *proto.GetWalletTokensRequest) (*proto.GetWalletTokensResponse, error) {
-	walletTokens := []string{}
-	response := &proto.GetWalletTokensResponse{}
-	wallet, err := repository.GetOrCreateWallet(strings.ToLower(req.WalletAddress), req.TokenAddresses)
+	if req.WithBalances {
+		return getWalletTokenBalances(ctx, strings.ToLower(req.WalletAddress), req.IncludeSpam)
+	}
+	response := &proto.GetWalletTokensResponse{DataSource: api.DataSourceStored}
+	wallet, _, err := repository.GetOrCreateWallet(ctx, strings.ToLower(req.WalletAddress), proto.DataType_DB, req.TokenAddresses)
 	if err != nil {
 		return nil, err
 	}
-	walletTokens = wallet.TokenAddresses
-	for _, token := range walletTokens {
+	for _, token := range wallet.TokenAddresses {
 		response.Tokens = append(response.Tokens, &common.WalletToken{TokenAddress: token})
 	}
-	response.NumberOfTokens = int32(len(walletTokens))
+	if req.IncludeSpam {
+		for _, token := range wallet.QuarantinedTokenAddresses {
+			response.Tokens = append(response.Tokens, &common.WalletToken{TokenAddress: token, Quarantined: true})
+		}
+	}
+	response.NumberOfTokens = int32(len(response.Tokens))
 
 	return response, nil
 }
 
+// getWalletTokenBalances returns the tokens of a wallet with their balances from Moralis, checked
+// against the chain. Spam tokens the wallet did not mark safe are only listed with includeSpam.
+func getWalletTokenBalances(ctx context.Context, walletAddress string, includeSpam bool) (*proto.GetWalletTokensResponse, error) {
+	tokens, err := api.GetWalletTokens(walletAddress, false)
+	if err != nil {
+		log.Println("error getting wallet tokens", err)
+		return nil, status.Error(codes.Unavailable, "could not get wallet tokens")
+	}
+	safeTokens := repository.WalletSafeTokens(ctx, walletAddress)
+	for _, token := range tokens {
+		if token.Quarantined && slices.Contains(safeTokens, strings.ToLower(token.TokenAddress)) {
+			token.Quarantined = false
+		}
+	}
+	response := &proto.GetWalletTokensResponse{DataSource: api.ReconcileWalletTokens(walletAddress, tokens)}
+	for _, token := range tokens {
+		if !token.Quarantined || includeSpam {
+			response.Tokens = append(response.Tokens, token)
+		}
+	}
+	response.NumberOfTokens = int32(len(response.Tokens))
+	return response, nil
+}
+
+func (s *Server) MarkTokenSafe(ctx context.Context, req *proto.MarkTokenSafeRequest) (*proto.MarkTokenSafeResponse, error) {
+	if req.WalletAddress == "" {
+		return nil, status.Error(codes.InvalidArgument, "walletAddress is required")
+	}
+	err := repository.MarkTokenSafe(ctx, req.WalletAddress, req.TokenAddress, req.Revoke)
+	if errors.Is(err, repository.ErrInvalidTokenAddress) {
+		return nil, status.Error(codes.InvalidArgument, "invalid tokenAddress")
+	}
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "wallet not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &proto.MarkTokenSafeResponse{Success: true}, nil
+}
+
 func (s *Server) UpdateWalletPortfolio(ctx context.Context, req *proto.UpdateWalletPortfolioRequest) (*proto.UpdateWalletPortfolioResponse, error) {
-	err := repository.UpdateWalletDollarValue(strings.ToLower(req.WalletAddress), req.TotalDollarValue)
+	err := repository.UpdateWalletDollarValue(ctx, strings.ToLower(req.WalletAddress), req.TotalDollarValue)
 	if err != nil {
 		log.Println("error updating wallet portfolio", err)
 		return nil, err
 	}
 	return &proto.UpdateWalletPortfolioResponse{Success: true}, nil
 }
+
+func (s *Server) WatchTokenHolders(ctx context.Context, req *proto.WatchTokenHoldersRequest) (*proto.WatchTokenHoldersResponse, error) {
+	if req.TokenAddress == "" {
+		return nil, status.Error(codes.InvalidArgument, "tokenAddress is required")
+	}
+	walletAddresses, err := repository.WatchTokenHolders(ctx, req.TokenAddress, req.GetLimit())
+	if err != nil {
+		log.Println("error watching token holders", err)
+		return nil, err
+	}
+	return &proto.WatchTokenHoldersResponse{Success: true, WalletAddresses: walletAddresses}, nil
+}
+
+func (s *Server) GetHolderFlows(ctx context.Context, req *proto.GetHolderFlowsRequest) (*proto.GetHolderFlowsResponse, error) {
+	if req.TokenAddress == "" {
+		return nil, status.Error(codes.InvalidArgument, "tokenAddress is required")
+	}
+	return repository.GetHolderFlows(ctx, req.TokenAddress)
+}
+
+func (s *Server) SetWalletLabel(ctx context.Context, req *proto.SetWalletLabelRequest) (*proto.SetWalletLabelResponse, error) {
+	if req.WalletAddress == "" {
+		return nil, status.Error(codes.InvalidArgument, "walletAddress is required")
+	}
+	// Empty lists decode as nil, which leaves them unchanged; clearing is asked for explicitly.
+	tags, groups := req.Tags, req.Groups
+	if req.ClearTags {
+		tags = []string{}
+	}
+	if req.ClearGroups {
+		groups = []string{}
+	}
+	wallet, err := repository.SetWalletLabel(ctx, req.WalletAddress, req.Label, tags, groups)
+	if err != nil {
+		log.Println("error setting wallet label", err)
+		return nil, err
+	}
+	return &proto.SetWalletLabelResponse{Success: true, WalletData: wallet}, nil
+}
+
+func (s *Server) ListWalletsByTag(ctx context.Context, req *proto.ListWalletsByTagRequest) (*proto.ListWalletsByTagResponse, error) {
+	if strings.TrimSpace(req.Tag) == "" {
+		return nil, status.Error(codes.InvalidArgument, "tag is required")
+	}
+	wallets, err := repository.ListWalletsByTag(ctx, req.Tag, req.Group)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.ListWalletsByTagResponse{Wallets: wallets}, nil
+}
+
+// streamWalletEvents sends published wallet events to send until the client goes away. An empty
+// wallet list streams events from every watched wallet.
+func streamWalletEvents(ctx context.Context, walletAddresses []string, send func(*proto.WalletEvent) error) error {
+	wallets := map[string]bool{}
+	for _, walletAddress := range walletAddresses {
+		wallets[strings.ToLower(walletAddress)] = true
+	}
+
+	walletEvents, unsubscribe := events.Subscribe()
+	defer unsubscribe()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-walletEvents:
+			if !ok {
+				return nil
+			}
+			if len(wallets) > 0 && !wallets[events.WalletAddress(event)] {
+				continue
+			}
+			if err := send(event); err != nil {
+				log.Println("Error sending wallet event:", err)
+				return err
+			}
+		}
+	}
+}
+
+// StreamWalletTrades streams buys and sells made by watched wallets.
+func (s *Server) StreamWalletTrades(req *proto.StreamWalletTradesRequest, stream grpc.ServerStreamingServer[proto.WalletTrade]) error {
+	return streamWalletEvents(stream.Context(), req.WalletAddresses, func(event *proto.WalletEvent) error {
+		trade := event.GetTrade()
+		if trade == nil {
+			return nil
+		}
+		return stream.Send(trade)
+	})
+}
+
+// StreamWalletEvents streams trades as well as bridge and exchange flows of watched wallets.
+func (s *Server) StreamWalletEvents(req *proto.StreamWalletEventsRequest, stream grpc.ServerStreamingServer[proto.WalletEvent]) error {
+	return streamWalletEvents(stream.Context(), req.WalletAddresses, stream.Send)
+}
+
+func (s *Server) GetWalletLeaderboard(ctx context.Context, req *proto.GetWalletLeaderboardRequest) (*proto.GetWalletLeaderboardResponse, error) {
+	return repository.GetWalletLeaderboard(ctx, req.Period, req.GetPage(), req.GetPageSize())
+}
+
+func (s *Server) GetDailyLeaderboard(ctx context.Context, req *proto.GetDailyLeaderboardRequest) (*proto.GetDailyLeaderboardResponse, error) {
+	return repository.GetDailyLeaderboard(req.GetPage(), req.GetPageSize()), nil
+}
+
+func (s *Server) SetWalletLeaderboardOptIn(ctx context.Context, req *proto.SetWalletLeaderboardOptInRequest) (*proto.SetWalletLeaderboardOptInResponse, error) {
+	if req.WalletAddress == "" {
+		return nil, status.Error(codes.InvalidArgument, "walletAddress is required")
+	}
+	err := repository.SetWalletLeaderboardOptIn(ctx, req.WalletAddress, req.OptIn)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "wallet not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &proto.SetWalletLeaderboardOptInResponse{Success: true}, nil
+}
+
+func (s *Server) SetWalletWatchFilter(ctx context.Context, req *proto.SetWalletWatchFilterRequest) (*proto.SetWalletWatchFilterResponse, error) {
+	if req.WalletAddress == "" {
+		return nil, status.Error(codes.InvalidArgument, "walletAddress is required")
+	}
+	err := repository.SetWalletWatchFilter(ctx, req.WalletAddress, req.MinNative, req.MinTokenUsd, req.TokenAllowlist, req.TokenDenylist)
+	if errors.Is(err, repository.ErrInvalidWatchFilter) {
+		return nil, status.Error(codes.InvalidArgument, "minimums must be non-negative decimals")
+	}
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "wallet not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &proto.SetWalletWatchFilterResponse{Success: true}, nil
+}
+
+func (s *Server) SetWalletAlerts(ctx context.Context, req *proto.SetWalletAlertsRequest) (*proto.SetWalletAlertsResponse, error) {
+	if req.WalletAddress == "" {
+		return nil, status.Error(codes.InvalidArgument, "walletAddress is required")
+	}
+	err := repository.SetWalletAlerts(ctx, req.WalletAddress, req.ValueChangePct, req.WindowMinutes, req.TransferMinUsd)
+	if errors.Is(err, repository.ErrInvalidAlerts) {
+		return nil, status.Error(codes.InvalidArgument, "thresholds must be non-negative decimals and windowMinutes non-negative")
+	}
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "wallet not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &proto.SetWalletAlertsResponse{Success: true}, nil
+}
+
+var webhookEvents = map[proto.WebhookEvent]webhook.Event{
+	proto.WebhookEvent_WEBHOOK_TRANSACTION:  webhook.EventTransaction,
+	proto.WebhookEvent_WEBHOOK_VALUE_CHANGE: webhook.EventValueChange,
+	proto.WebhookEvent_WEBHOOK_ALERT:        webhook.EventAlert,
+}
+
+func (s *Server) SetWalletWebhook(ctx context.Context, req *proto.SetWalletWebhookRequest) (*proto.SetWalletWebhookResponse, error) {
+	if req.WalletAddress == "" {
+		return nil, status.Error(codes.InvalidArgument, "walletAddress is required")
+	}
+	events := []webhook.Event{}
+	for _, event := range req.Events {
+		e, ok := webhookEvents[event]
+		if !ok {
+			return nil, status.Error(codes.InvalidArgument, "unknown webhook event")
+		}
+		events = append(events, e)
+	}
+	err := repository.SetWalletWebhook(ctx, req.WalletAddress, strings.TrimSpace(req.Url), req.Secret, events, req.MinValueChangePct)
+	if errors.Is(err, repository.ErrInvalidWebhook) {
+		return nil, status.Error(codes.InvalidArgument, "url must be a public http(s) URL with a secret and minValueChangePct a non-negative decimal")
+	}
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "wallet not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &proto.SetWalletWebhookResponse{Success: true}, nil
+}
+
+func (s *Server) AddKnownContract(ctx context.Context, req *proto.AddKnownContractRequest) (*proto.AddKnownContractResponse, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	contract, err := repository.AddKnownContract(ctx, req.Address, req.Name, req.Category)
+	if errors.Is(err, repository.ErrInvalidContractAddress) || errors.Is(err, repository.ErrInvalidContractCategory) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &proto.AddKnownContractResponse{Success: true, Contract: contract}, nil
+}
+
+func (s *Server) RemoveKnownContract(ctx context.Context, req *proto.RemoveKnownContractRequest) (*proto.RemoveKnownContractResponse, error) {
+	err := repository.RemoveKnownContract(ctx, req.Address)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "contract not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &proto.RemoveKnownContractResponse{Success: true}, nil
+}
+
+func (s *Server) ListKnownContracts(ctx context.Context, req *proto.ListKnownContractsRequest) (*proto.ListKnownContractsResponse, error) {
+	contracts, err := repository.ListKnownContracts(ctx, req.Category)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.ListKnownContractsResponse{Contracts: contracts}, nil
+}
+
+func (s *Server) GetPortfolioHistory(ctx context.Context, req *proto.GetPortfolioHistoryRequest) (*proto.GetPortfolioHistoryResponse, error) {
+	if req.WalletAddress == "" {
+		return nil, status.Error(codes.InvalidArgument, "walletAddress is required")
+	}
+	return repository.GetPortfolioHistory(ctx, req.WalletAddress, req.Range)
+}
+
+func (s *Server) GetWalletApprovals(ctx context.Context, req *proto.GetWalletApprovalsRequest) (*proto.GetWalletApprovalsResponse, error) {
+	if req.WalletAddress == "" {
+		return nil, status.Error(codes.InvalidArgument, "walletAddress is required")
+	}
+	approvals, err := repository.GetWalletApprovals(req.WalletAddress)
+	if err != nil {
+		log.Println("error getting wallet approvals", err)
+		return nil, err
+	}
+	return approvals, nil
+}
+
+func (s *Server) GetAggregatedPortfolio(ctx context.Context, req *proto.GetAggregatedPortfolioRequest) (*proto.GetAggregatedPortfolioResponse, error) {
+	portfolio, err := repository.GetAggregatedPortfolio(ctx, req.WalletAddresses)
+	if errors.Is(err, repository.ErrInvalidPortfolioWallets) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return portfolio, nil
+}
+
+func (s *Server) ImportWallets(ctx context.Context, req *proto.ImportWalletsRequest) (*proto.ImportWalletsResponse, error) {
+	job, err := repository.ImportWallets(req.WalletAddresses)
+	if errors.Is(err, repository.ErrEmptyImport) || errors.Is(err, repository.ErrTooManyWallets) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, repository.ErrImportQueueFull) {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &proto.ImportWalletsResponse{JobId: job.JobId, Total: job.Total}, nil
+}
+
+func (s *Server) GetImportJob(ctx context.Context, req *proto.GetImportJobRequest) (*proto.GetImportJobResponse, error) {
+	job, err := repository.GetImportJob(req.JobId)
+	if errors.Is(err, repository.ErrImportJobNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &proto.GetImportJobResponse{Job: job}, nil
+}
+
+func (s *Server) GetWalletTransactions(ctx context.Context, req *proto.GetWalletTransactionsRequest) (*proto.GetWalletTransactionsResponse, error) {
+	if req.WalletAddress == "" {
+		return nil, status.Error(codes.InvalidArgument, "walletAddress is required")
+	}
+	transactions, err := repository.GetWalletTransactions(req.WalletAddress, req.GetPage(), req.GetPageSize())
+	if err != nil {
+		log.Println("error getting wallet transactions", err)
+		return nil, status.Error(codes.Unavailable, "could not get wallet transactions")
+	}
+	return transactions, nil
+}
+
+// RebalanceWalletShards has this replica watch the wallets of its shard after the WALLET_SHARD_*
+// settings changed and were reloaded.
+func (s *Server) RebalanceWalletShards(ctx context.Context, req *proto.RebalanceWalletShardsRequest) (*proto.RebalanceWalletShardsResponse, error) {
+	response, err := repository.RebalanceWalletWatchers()
+	if err != nil {
+		log.Println("error rebalancing wallet shards", err)
+		return nil, status.Error(codes.Unavailable, "could not rebalance wallet shards")
+	}
+	return response, nil
+}
+
+func toProtoApiUsage(report usage.Report) *common.ApiUsage {
+	response := &common.ApiUsage{Day: report.Day}
+	for _, provider := range report.Providers {
+		providerUsage := &common.ProviderApiUsage{
+			Provider:   provider.Provider,
+			Calls:      provider.Calls,
+			Budget:     provider.Budget,
+			OverBudget: provider.OverBudget,
+		}
+		for _, path := range provider.Paths {
+			providerUsage.Paths = append(providerUsage.Paths, &common.ApiCallPath{Endpoint: path.Endpoint, Caller: path.Caller, Calls: path.Calls})
+		}
+		response.Providers = append(response.Providers, providerUsage)
+	}
+	return response
+}
+
+// GetApiUsage returns the calls all replicas made to external APIs today against their budgets,
+// and the code paths of the calls of this replica.
+func (s *Server) GetApiUsage(ctx context.Context, req *proto.GetApiUsageRequest) (*proto.GetApiUsageResponse, error) {
+	return &proto.GetApiUsageResponse{Usage: toProtoApiUsage(usage.Current())}, nil
+}
@@ -0,0 +1,24 @@
+package server
+
+import (
+	"context"
+	"testing"
+	repository "walletdata/database/repositories"
+	"walletdata/database/store/mock"
+	proto "walletdata/proto/wallet"
+)
+
+func TestGetWalletTokens(t *testing.T) {
+	wallet := mock.NewWallet("0x1111111111111111111111111111111111111111", "0xaaaa", "0xbbbb")
+	defer repository.SetWalletStore(mock.NewWalletStore(wallet))()
+
+	response, err := NewWalletServer().GetWalletTokens(context.Background(), &proto.GetWalletTokensRequest{
+		WalletAddress: "0x1111111111111111111111111111111111111111",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.NumberOfTokens != 2 || response.Tokens[0].TokenAddress != "0xaaaa" {
+		t.Errorf("response = %+v", response)
+	}
+}
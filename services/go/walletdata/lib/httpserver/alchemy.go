@@ -0,0 +1,39 @@
+package httpserver
+
+import (
+	"io"
+	"log"
+	"net/http"
+	repository "walletdata/database/repositories"
+	"walletdata/env"
+	"walletdata/rpc"
+)
+
+// maxWebhookBodyBytes bounds the body of an Alchemy webhook.
+const maxWebhookBodyBytes = 1 << 20
+
+// serveAlchemyWebhook takes Alchemy Notify address activity webhooks. The transactions are
+// handled after the response, as Alchemy retries deliveries that are not answered quickly.
+func serveAlchemyWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodyBytes))
+	if err != nil {
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		return
+	}
+	if !rpc.VerifyAlchemySignature(body, r.Header.Get(rpc.AlchemySignatureHeader), env.ALCHEMY_WEBHOOK_SIGNING_KEY.GetEnv()) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	activities, err := rpc.ParseAlchemyWebhook(body)
+	if err != nil {
+		log.Printf("Error parsing Alchemy webhook: %+v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	go repository.IngestAlchemyActivity(activities)
+	w.WriteHeader(http.StatusOK)
+}
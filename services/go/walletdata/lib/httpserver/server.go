@@ -0,0 +1,112 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	repository "walletdata/database/repositories"
+	proto "walletdata/proto/wallet"
+
+	grpc_lib "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func originAllowed(origin string) bool {
+	for _, o := range strings.Split(os.Getenv("ALLOWED_ORIGINS"), ",") {
+		if strings.TrimSpace(o) == origin && origin != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func withCORS(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if originAllowed(origin) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Vary", "Origin")
+		}
+		w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Max-Age", "86400")
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		h(w, r)
+	}
+}
+
+// queryInt32 parses an optional integer query parameter.
+func queryInt32(r *http.Request, name string) (*int32, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	result := int32(parsed)
+	return &result, nil
+}
+
+// Start serves the read-only HTTP endpoints by calling the local gRPC server, and the Alchemy
+// webhook in webhook ingestion mode. It does nothing when no HTTP port is configured.
+func Start(grpcPort int64, httpPort int64) {
+	if httpPort == 0 {
+		if repository.WebhookIngestion() {
+			log.Println("WALLET_INGESTION is alchemy_webhook but HTTP_PORT is not set; no wallet transactions will come in")
+		}
+		return
+	}
+	addr := fmt.Sprintf("127.0.0.1:%d", grpcPort)
+	conn, err := grpc_lib.Dial(addr, grpc_lib.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Printf("grpc connection creation error: %v", err)
+		return
+	}
+	client := proto.NewScannerWalletClient(conn)
+
+	http.HandleFunc("/leaderboard/daily", withCORS(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		page, err := queryInt32(r, "page")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		pageSize, err := queryInt32(r, "pageSize")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		res, err := client.GetDailyLeaderboard(r.Context(), &proto.GetDailyLeaderboardRequest{Page: page, PageSize: pageSize})
+		if err != nil {
+			log.Printf("Error getting daily leaderboard: %+v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Cache-Control", "public, max-age=60")
+		json.NewEncoder(w).Encode(res)
+	}))
+
+	endpoints := "GET /leaderboard/daily"
+	if repository.WebhookIngestion() {
+		http.HandleFunc("/webhooks/alchemy", serveAlchemyWebhook)
+		endpoints += ", POST /webhooks/alchemy"
+	}
+
+	srvAddr := fmt.Sprintf(":%d", httpPort)
+	log.Printf("HTTP endpoint started: %s (%s)", srvAddr, endpoints)
+	if err := http.ListenAndServe(srvAddr, nil); err != nil {
+		log.Printf("HTTP server error: %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package trades
+
+import (
+	"context"
+	"log"
+	"math/big"
+	"samterminal/pkg/chain"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"walletdata/env"
+	"walletdata/lib/activity"
+	token_client "walletdata/lib/grpc/client/token"
+	proto "walletdata/proto/wallet"
+	"walletdata/rpc"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	quoteTokensOnce sync.Once
+	quoteTokens     []common.Address
+)
+
+// QuoteTokens returns what tokens are usually bought with; they are never reported as traded.
+// They are the anchor tokens tokendata is configured with in ANCHOR_TOKENS, as SYMBOL=address
+// entries with an optional @price; only the addresses matter here.
+func QuoteTokens() []common.Address {
+	quoteTokensOnce.Do(func() {
+		for _, entry := range strings.Split(env.ANCHOR_TOKENS.GetEnv(), ",") {
+			_, rest, _ := strings.Cut(entry, "=")
+			address, _, _ := strings.Cut(rest, "@")
+			if address = strings.TrimSpace(address); common.IsHexAddress(address) {
+				quoteTokens = append(quoteTokens, common.HexToAddress(address))
+			}
+		}
+		if len(quoteTokens) == 0 {
+			// WETH and USDC of the chain, as tokendata defaults its anchors to.
+			config := chain.Get()
+			quoteTokens = []common.Address{common.HexToAddress(config.WETH), common.HexToAddress(config.USDC)}
+		}
+	})
+	return quoteTokens
+}
+
+// NativeToken is the wrapped native token, used to price native ETH.
+func NativeToken() common.Address {
+	return QuoteTokens()[0]
+}
+
+// FromTransaction decodes the swaps a wallet made in a transaction from the token transfers it
+// caused. A token the wallet gained is a buy, a token it lost is a sell. Transactions sent by
+// someone else, or sent straight to the token contract (plain transfers), are not trades.
+func FromTransaction(walletAddress string, event rpc.WalletTransaction) []*proto.WalletTrade {
+	wallet := common.HexToAddress(walletAddress)
+	if event.Raw.From != wallet || event.Raw.To == nil || len(event.TokenTransfers) == 0 {
+		return nil
+	}
+
+	net := map[common.Address]*big.Int{}
+	for _, transfer := range event.TokenTransfers {
+		if _, ok := net[transfer.Token]; !ok {
+			net[transfer.Token] = new(big.Int)
+		}
+		if transfer.To == wallet {
+			net[transfer.Token].Add(net[transfer.Token], transfer.Amount)
+		}
+		if transfer.From == wallet {
+			net[transfer.Token].Sub(net[transfer.Token], transfer.Amount)
+		}
+	}
+
+	kind := activity.Of(event)
+	trades := []*proto.WalletTrade{}
+	for token, amount := range net {
+		if amount.Sign() == 0 || slices.Contains(QuoteTokens(), token) || *event.Raw.To == token {
+			continue
+		}
+		side := proto.TradeSide_BUY
+		if amount.Sign() < 0 {
+			side = proto.TradeSide_SELL
+		}
+		trade := enrich(walletAddress, token, new(big.Int).Abs(amount), side)
+		trade.TxHash = event.Hash.Hex()
+		trade.Counterparty = strings.ToLower(event.Raw.To.Hex())
+		trade.Activity = kind
+		trades = append(trades, trade)
+	}
+	return trades
+}
+
+// TokenValue is a raw token amount converted to token units and priced in USD.
+type TokenValue struct {
+	Amount   string
+	PriceUSD string
+	USDValue string
+	Name     string
+	Symbol   string
+}
+
+// ValueTokenAmount converts a raw token amount using the token decimals and prices it with
+// tokendata. Prices default to zero when the token cannot be priced.
+func ValueTokenAmount(token common.Address, amount *big.Int) TokenValue {
+	tokenAddress := strings.ToLower(token.Hex())
+	value := TokenValue{PriceUSD: "0", USDValue: "0"}
+
+	decimals, err := rpc.GetTokenDecimals(token)
+	if err != nil {
+		log.Println("error getting token decimals", tokenAddress, err)
+		decimals = 18
+	}
+	tokenAmount := new(big.Float).Quo(new(big.Float).SetInt(amount), new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)))
+	value.Amount = tokenAmount.Text('f', -1)
+
+	tokenResponse, err := token_client.GetOrAddToken(context.Background(), tokenAddress)
+	if err != nil || tokenResponse.Token == nil {
+		log.Println("error getting token", tokenAddress, err)
+		return value
+	}
+	value.Name = tokenResponse.Token.Name
+	value.Symbol = tokenResponse.Token.Symbol
+	value.PriceUSD = tokenResponse.Token.Price
+
+	price, ok := new(big.Float).SetString(tokenResponse.Token.Price)
+	if ok {
+		value.USDValue = new(big.Float).Mul(price, tokenAmount).Text('f', 2)
+	}
+	return value
+}
+
+// enrich fills in token metadata and USD value using tokendata.
+func enrich(walletAddress string, token common.Address, amount *big.Int, side proto.TradeSide) *proto.WalletTrade {
+	value := ValueTokenAmount(token, amount)
+	return &proto.WalletTrade{
+		WalletAddress: strings.ToLower(walletAddress),
+		Side:          side,
+		TokenAddress:  strings.ToLower(token.Hex()),
+		TokenName:     value.Name,
+		TokenSymbol:   value.Symbol,
+		TokenAmount:   value.Amount,
+		PriceUsd:      value.PriceUSD,
+		UsdValue:      value.USDValue,
+		Timestamp:     time.Now().Unix(),
+	}
+}
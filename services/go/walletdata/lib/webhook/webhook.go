@@ -0,0 +1,154 @@
+// Package webhook delivers wallet notifications to the URLs registered for them. Bodies are JSON,
+// signed with the secret of the webhook, and deliveries that fail are retried with exponential
+// backoff. Webhooks are only delivered to public addresses and redirects are not followed.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"samterminal/pkg/httpclient"
+	"sync"
+	"time"
+	"walletdata/env"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	// SignatureHeader carries the hex HMAC-SHA256 of the body under the secret of the webhook.
+	SignatureHeader = "X-Samterminal-Signature"
+	EventHeader     = "X-Samterminal-Event"
+
+	defaultMaxAttempts  = 5
+	defaultRetryBackoff = time.Second
+	maxRetryBackoff     = 5 * time.Minute
+	deliveryQueueSize   = 256
+	deliveryWorkers     = 4
+)
+
+type Event string
+
+const (
+	EventTransaction Event = "transaction"
+	EventValueChange Event = "value_change"
+	EventAlert       Event = "alert"
+)
+
+// Events are the events webhooks can subscribe to.
+var Events = []Event{EventTransaction, EventValueChange, EventAlert}
+
+// Payload is the body POSTed for an event.
+type Payload struct {
+	Event         Event  `json:"event"`
+	WalletAddress string `json:"walletAddress"`
+	Timestamp     int64  `json:"timestamp"`
+	Data          any    `json:"data"`
+}
+
+type delivery struct {
+	url     string
+	secret  string
+	event   Event
+	body    []byte
+	attempt int
+}
+
+var (
+	client = httpclient.NewPublic().
+		SetTimeout(10 * time.Second).
+		SetRedirectPolicy(noRedirects)
+
+	queue      = make(chan delivery, deliveryQueueSize)
+	workerOnce sync.Once
+
+	maxAttempts  = sync.OnceValue(func() int { return int(max(env.WEBHOOK_MAX_ATTEMPTS.GetEnvAsNumberOrDefault(defaultMaxAttempts), 1)) })
+	retryBackoff = sync.OnceValue(func() time.Duration { return env.WEBHOOK_RETRY_BACKOFF.GetEnvAsDurationOrDefault(defaultRetryBackoff) })
+)
+
+// noRedirects answers a redirect as the response instead of following it. Webhook URLs are set by
+// users, and a redirect could lead the request somewhere the URL check did not look.
+var noRedirects = resty.RedirectPolicyFunc(func(*http.Request, []*http.Request) error {
+	return http.ErrUseLastResponse
+})
+
+// Sign returns the hex HMAC-SHA256 of a body under a secret, as sent in SignatureHeader.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Send queues the delivery of an event of a wallet to a webhook. Deliveries are dropped when the
+// queue is full.
+func Send(url string, secret string, walletAddress string, event Event, data any) {
+	body, err := json.Marshal(Payload{Event: event, WalletAddress: walletAddress, Timestamp: time.Now().Unix(), Data: data})
+	if err != nil {
+		log.Println("Error encoding webhook payload:", err)
+		return
+	}
+	workerOnce.Do(func() {
+		for range deliveryWorkers {
+			go deliveryWorker()
+		}
+	})
+	enqueue(delivery{url: url, secret: secret, event: event, body: body})
+}
+
+func enqueue(d delivery) {
+	select {
+	case queue <- d:
+	default:
+		log.Println("Webhook queue is full, dropping", d.event, "for", d.url)
+	}
+}
+
+func deliveryWorker() {
+	for d := range queue {
+		retry, err := deliver(d)
+		if err == nil {
+			continue
+		}
+		d.attempt++
+		if !retry || d.attempt >= maxAttempts() {
+			log.Println("Giving up on webhook", d.event, "for", d.url, "after", d.attempt, "attempts:", err)
+			continue
+		}
+		// Retries wait off the workers, so that one failing endpoint does not hold up the others.
+		time.AfterFunc(backoff(retryBackoff(), d.attempt), func() { enqueue(d) })
+	}
+}
+
+// deliver POSTs a delivery, reporting whether a failure is worth retrying: network errors, rate
+// limits and server errors are; other client errors, redirects and hosts that resolve to
+// addresses that are not public are not.
+func deliver(d delivery) (bool, error) {
+	resp, err := client.R().
+		SetHeader("Content-Type", "application/json").
+		SetHeader(SignatureHeader, Sign(d.secret, d.body)).
+		SetHeader(EventHeader, string(d.event)).
+		SetBody(d.body).
+		Post(d.url)
+	if err != nil {
+		return !errors.Is(err, httpclient.ErrNotPublic), err
+	}
+	if resp.IsSuccess() {
+		return false, nil
+	}
+	err = fmt.Errorf("webhook answered %s", resp.Status())
+	return resp.StatusCode() == http.StatusTooManyRequests || resp.StatusCode() >= http.StatusInternalServerError, err
+}
+
+// backoff is the wait before retry attempt, doubling from base up to maxRetryBackoff.
+func backoff(base time.Duration, attempt int) time.Duration {
+	wait := base
+	for i := 1; i < attempt && wait < maxRetryBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, maxRetryBackoff)
+}
@@ -0,0 +1,75 @@
+package webhook
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"samterminal/pkg/httpclient"
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	// RFC 4231 test case 2.
+	got := Sign("Jefe", []byte("what do ya want for nothing?"))
+	if want := "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843"; got != want {
+		t.Errorf("Sign = %s, want %s", got, want)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for attempt, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 4: 8 * time.Second, 20: maxRetryBackoff} {
+		if got := backoff(time.Second, attempt); got != want {
+			t.Errorf("backoff(%d) = %s, want %s", attempt, got, want)
+		}
+	}
+}
+
+func TestDeliver(t *testing.T) {
+	statusCode := http.StatusOK
+	var signature string
+	var body []byte
+	redirected := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/internal" {
+			redirected = true
+			return
+		}
+		if statusCode == http.StatusFound {
+			http.Redirect(w, r, "/internal", statusCode)
+			return
+		}
+		signature = r.Header.Get(SignatureHeader)
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(statusCode)
+	}))
+	defer server.Close()
+
+	// The test server listens on loopback, which the webhook client refuses.
+	d := delivery{url: server.URL, secret: "secret", event: EventTransaction, body: []byte(`{"event":"transaction"}`)}
+	if retry, err := deliver(d); !errors.Is(err, httpclient.ErrNotPublic) || retry {
+		t.Fatalf("delivery to loopback: retry = %v, err = %v", retry, err)
+	}
+	publicClient := client
+	defer func() { client = publicClient }()
+	client = httpclient.New().SetRedirectPolicy(noRedirects)
+
+	if _, err := deliver(d); err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != string(d.body) || signature != Sign("secret", d.body) {
+		t.Errorf("received %s signed %s", body, signature)
+	}
+
+	for code, wantRetry := range map[int]bool{http.StatusInternalServerError: true, http.StatusTooManyRequests: true, http.StatusGone: false, http.StatusFound: false} {
+		statusCode = code
+		retry, err := deliver(d)
+		if err == nil || retry != wantRetry {
+			t.Errorf("status %d: retry = %v, err = %v", code, retry, err)
+		}
+	}
+	if redirected {
+		t.Error("a redirect was followed")
+	}
+}
@@ -4,11 +4,15 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"samterminal/pkg/config"
+	"samterminal/pkg/telemetry"
 	"syscall"
 	"walletdata/database"
 	repository "walletdata/database/repositories"
 	"walletdata/env"
 	"walletdata/lib/grpc"
+	"walletdata/lib/httpserver"
+	"walletdata/rpc"
 )
 
 func init() {
@@ -16,12 +20,36 @@ func init() {
 }
 
 func main() {
+	defer telemetry.Init("walletdata")()
 	database.InitDatabase()
 	defer database.DisconnectFromDB()
+	rpc.Connect(!repository.WebhookIngestion())
+
+	repository.SeedKnownContracts()
 
 	repository.StartWalletWatcherForAllWallets()
+	repository.StartSnapshotCompaction()
+	repository.StartDailyLeaderboard()
+	repository.StartOutboxDispatcher()
+	repository.StartUsageSync()
 
 	go grpc.StartServer()
+	go httpserver.Start(env.PORT.GetEnvAsNumber(), env.HTTP_PORT.GetEnvAsNumberOrDefault(0))
+
+	// SIGHUP reloads the env file: rotated API keys and ALLOWED_ORIGINS apply without dropping
+	// the watchers a restart would. In Docker only ENV_FILE can be reloaded.
+	reloadCh := make(chan os.Signal, 1)
+	signal.Notify(reloadCh, syscall.SIGHUP)
+	go func() {
+		for range reloadCh {
+			if !config.Reloadable() {
+				log.Println("SIGHUP received, not reloading env: DOCKER=true without ENV_FILE, restart the container instead")
+				continue
+			}
+			log.Println("SIGHUP received, reloading env")
+			env.LoadEnv(".env")
+		}
+	}()
 
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
@@ -0,0 +1,19 @@
+-- Normalize existing rows: keep one row per lowercased address, preferring the
+-- row that is already lowercase and then the most recently updated one.
+DELETE FROM "Wallet"
+WHERE "id" IN (
+    SELECT "id" FROM (
+        SELECT "id", ROW_NUMBER() OVER (
+            PARTITION BY lower("address")
+            ORDER BY ("address" = lower("address")) DESC, "updatedAt" DESC
+        ) AS rn
+        FROM "Wallet"
+    ) ranked
+    WHERE ranked.rn > 1
+);
+
+UPDATE "Wallet" SET "address" = lower("address") WHERE "address" <> lower("address");
+UPDATE "Wallet" SET "tokens" = ARRAY(SELECT lower(t) FROM unnest("tokens") AS t);
+
+-- AddCheckConstraint
+ALTER TABLE "Wallet" ADD CONSTRAINT "Wallet_address_lowercase" CHECK ("address" = lower("address"));
@@ -0,0 +1,18 @@
+-- CreateTable
+CREATE TABLE "HolderWatch" (
+    "id" TEXT NOT NULL,
+    "tokenAddress" TEXT NOT NULL,
+    "walletAddress" TEXT NOT NULL,
+    "initialBalance" TEXT NOT NULL DEFAULT '0',
+    "balance" TEXT NOT NULL DEFAULT '0',
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL,
+
+    CONSTRAINT "HolderWatch_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE INDEX "HolderWatch_walletAddress_idx" ON "HolderWatch"("walletAddress");
+
+-- CreateIndex
+CREATE UNIQUE INDEX "HolderWatch_tokenAddress_walletAddress_key" ON "HolderWatch"("tokenAddress", "walletAddress");
@@ -0,0 +1,7 @@
+-- AlterTable
+ALTER TABLE "Wallet" ADD COLUMN     "groups" TEXT[],
+ADD COLUMN     "label" TEXT,
+ADD COLUMN     "tags" TEXT[];
+
+-- CreateIndex
+CREATE INDEX "Wallet_tags_idx" ON "Wallet" USING GIN ("tags");
@@ -0,0 +1,23 @@
+-- CreateEnum
+CREATE TYPE "TradeSide" AS ENUM ('BUY', 'SELL');
+
+-- CreateTable
+CREATE TABLE "Trade" (
+    "id" TEXT NOT NULL,
+    "walletAddress" TEXT NOT NULL,
+    "tokenAddress" TEXT NOT NULL,
+    "side" "TradeSide" NOT NULL,
+    "tokenAmount" DOUBLE PRECISION NOT NULL,
+    "priceUsd" DOUBLE PRECISION NOT NULL,
+    "usdValue" DOUBLE PRECISION NOT NULL,
+    "txHash" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "Trade_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE INDEX "Trade_walletAddress_createdAt_idx" ON "Trade"("walletAddress", "createdAt");
+
+-- CreateIndex
+CREATE UNIQUE INDEX "Trade_txHash_walletAddress_tokenAddress_key" ON "Trade"("txHash", "walletAddress", "tokenAddress");
@@ -0,0 +1,17 @@
+-- CreateEnum
+CREATE TYPE "ContractCategory" AS ENUM ('ROUTER', 'LOCKER', 'BRIDGE', 'CEX', 'OTHER');
+
+-- CreateTable
+CREATE TABLE "KnownContract" (
+    "id" TEXT NOT NULL,
+    "address" TEXT NOT NULL,
+    "name" TEXT NOT NULL,
+    "category" "ContractCategory" NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updatedAt" TIMESTAMP(3) NOT NULL,
+
+    CONSTRAINT "KnownContract_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE UNIQUE INDEX "KnownContract_address_key" ON "KnownContract"("address");
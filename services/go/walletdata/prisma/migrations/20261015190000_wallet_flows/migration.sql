@@ -0,0 +1,23 @@
+-- CreateEnum
+CREATE TYPE "WalletFlowType" AS ENUM ('BRIDGE_DEPOSIT', 'BRIDGE_WITHDRAWAL', 'CEX_DEPOSIT', 'CEX_WITHDRAWAL');
+
+-- CreateTable
+CREATE TABLE "WalletFlow" (
+    "id" TEXT NOT NULL,
+    "walletAddress" TEXT NOT NULL,
+    "type" "WalletFlowType" NOT NULL,
+    "counterparty" TEXT NOT NULL,
+    "tokenAddress" TEXT NOT NULL,
+    "amount" TEXT NOT NULL,
+    "usdValue" DOUBLE PRECISION NOT NULL,
+    "txHash" TEXT NOT NULL,
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "WalletFlow_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE INDEX "WalletFlow_walletAddress_type_createdAt_idx" ON "WalletFlow"("walletAddress", "type", "createdAt");
+
+-- CreateIndex
+CREATE UNIQUE INDEX "WalletFlow_txHash_walletAddress_tokenAddress_key" ON "WalletFlow"("txHash", "walletAddress", "tokenAddress");
@@ -0,0 +1,13 @@
+-- CreateTable
+CREATE TABLE "PortfolioSnapshot" (
+    "id" TEXT NOT NULL,
+    "walletAddress" TEXT NOT NULL,
+    "valueUsd" DOUBLE PRECISION NOT NULL,
+    "nativeBalance" TEXT NOT NULL DEFAULT '0',
+    "createdAt" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "PortfolioSnapshot_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE INDEX "PortfolioSnapshot_walletAddress_createdAt_idx" ON "PortfolioSnapshot"("walletAddress", "createdAt");
@@ -0,0 +1,2 @@
+-- AlterTable
+ALTER TABLE "Wallet" ADD COLUMN     "leaderboardOptIn" BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,5 @@
+-- AlterTable
+ALTER TABLE "Wallet" ADD COLUMN     "watchMinNative" TEXT,
+ADD COLUMN     "watchMinTokenUsd" TEXT,
+ADD COLUMN     "watchTokenAllowlist" TEXT[] DEFAULT ARRAY[]::TEXT[],
+ADD COLUMN     "watchTokenDenylist" TEXT[] DEFAULT ARRAY[]::TEXT[];
@@ -0,0 +1,5 @@
+-- AlterTable
+ALTER TABLE "Wallet" ADD COLUMN     "webhookURL" TEXT,
+ADD COLUMN     "webhookSecret" TEXT,
+ADD COLUMN     "webhookEvents" TEXT[] DEFAULT ARRAY[]::TEXT[],
+ADD COLUMN     "webhookMinValueChangePct" TEXT;
@@ -0,0 +1,3 @@
+-- AlterTable
+ALTER TABLE "Wallet" ADD COLUMN     "quarantinedTokens" TEXT[] DEFAULT ARRAY[]::TEXT[],
+ADD COLUMN     "safeTokens" TEXT[] DEFAULT ARRAY[]::TEXT[];
@@ -0,0 +1,4 @@
+-- AlterTable
+ALTER TABLE "Wallet" ADD COLUMN     "alertTransferMinUsd" TEXT,
+ADD COLUMN     "alertValueChangePct" TEXT,
+ADD COLUMN     "alertWindowMinutes" TEXT;
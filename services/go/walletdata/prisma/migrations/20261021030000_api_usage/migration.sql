@@ -0,0 +1,8 @@
+-- CreateTable
+CREATE TABLE "ApiUsage" (
+    "day" TEXT NOT NULL,
+    "provider" TEXT NOT NULL,
+    "calls" INTEGER NOT NULL DEFAULT 0,
+
+    CONSTRAINT "ApiUsage_pkey" PRIMARY KEY ("day","provider")
+);
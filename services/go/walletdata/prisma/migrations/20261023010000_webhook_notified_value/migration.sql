@@ -0,0 +1,2 @@
+-- AlterTable
+ALTER TABLE "Wallet" ADD COLUMN     "webhookNotifiedValueUsd" TEXT;
@@ -0,0 +1,2 @@
+-- AlterTable
+ALTER TABLE "Wallet" ALTER COLUMN "alertWindowMinutes" SET DATA TYPE INTEGER USING "alertWindowMinutes"::integer;
@@ -0,0 +1,10 @@
+-- AlterTable
+ALTER TABLE "Trade" ALTER COLUMN "tokenAmount" SET DATA TYPE DECIMAL(65,30),
+ALTER COLUMN "priceUsd" SET DATA TYPE DECIMAL(65,30),
+ALTER COLUMN "usdValue" SET DATA TYPE DECIMAL(65,30);
+
+-- AlterTable
+ALTER TABLE "WalletFlow" ALTER COLUMN "usdValue" SET DATA TYPE DECIMAL(65,30);
+
+-- AlterTable
+ALTER TABLE "PortfolioSnapshot" ALTER COLUMN "valueUsd" SET DATA TYPE DECIMAL(65,30);
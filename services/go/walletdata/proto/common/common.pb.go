@@ -24,19 +24,16 @@ const (
 type CHAIN int32
 
 const (
-	CHAIN_BASE   CHAIN = 0
-	CHAIN_SOLANA CHAIN = 1
+	CHAIN_BASE CHAIN = 0
 )
 
 // Enum value maps for CHAIN.
 var (
 	CHAIN_name = map[int32]string{
 		0: "BASE",
-		1: "SOLANA",
 	}
 	CHAIN_value = map[string]int32{
-		"BASE":   0,
-		"SOLANA": 1,
+		"BASE": 0,
 	}
 )
 
@@ -67,22 +64,83 @@ func (CHAIN) EnumDescriptor() ([]byte, []int) {
 	return file_common_common_proto_rawDescGZIP(), []int{0}
 }
 
+// Token is the v1 token message, with numbers as strings. It is served alongside TokenV2 until
+// clients have moved over, and gets no new fields.
 type Token struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	Name             string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Symbol           string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Price            string                 `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
-	Volume           string                 `protobuf:"bytes,4,opt,name=volume,proto3" json:"volume,omitempty"`
-	CalculatedVolume string                 `protobuf:"bytes,5,opt,name=calculatedVolume,proto3" json:"calculatedVolume,omitempty"`
-	ImageUrl         string                 `protobuf:"bytes,6,opt,name=imageUrl,proto3" json:"imageUrl,omitempty"`
-	Address          string                 `protobuf:"bytes,7,opt,name=address,proto3" json:"address,omitempty"`
-	PoolAddress      string                 `protobuf:"bytes,8,opt,name=poolAddress,proto3" json:"poolAddress,omitempty"`
-	Supply           string                 `protobuf:"bytes,9,opt,name=supply,proto3" json:"supply,omitempty"`
-	CirculatedSupply string                 `protobuf:"bytes,10,opt,name=circulatedSupply,proto3" json:"circulatedSupply,omitempty"`
-	PairAddress      string                 `protobuf:"bytes,11,opt,name=pairAddress,proto3" json:"pairAddress,omitempty"`
-	Reason           string                 `protobuf:"bytes,12,opt,name=reason,proto3" json:"reason,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Name                string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Symbol              string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Price               string                 `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
+	Volume              string                 `protobuf:"bytes,4,opt,name=volume,proto3" json:"volume,omitempty"`
+	CalculatedVolume    string                 `protobuf:"bytes,5,opt,name=calculatedVolume,proto3" json:"calculatedVolume,omitempty"`
+	ImageUrl            string                 `protobuf:"bytes,6,opt,name=imageUrl,proto3" json:"imageUrl,omitempty"`
+	Address             string                 `protobuf:"bytes,7,opt,name=address,proto3" json:"address,omitempty"`
+	PoolAddress         string                 `protobuf:"bytes,8,opt,name=poolAddress,proto3" json:"poolAddress,omitempty"`
+	Supply              string                 `protobuf:"bytes,9,opt,name=supply,proto3" json:"supply,omitempty"`
+	CirculatedSupply    string                 `protobuf:"bytes,10,opt,name=circulatedSupply,proto3" json:"circulatedSupply,omitempty"`
+	PairAddress         string                 `protobuf:"bytes,11,opt,name=pairAddress,proto3" json:"pairAddress,omitempty"`
+	Reason              string                 `protobuf:"bytes,12,opt,name=reason,proto3" json:"reason,omitempty"`
+	Archived            bool                   `protobuf:"varint,13,opt,name=archived,proto3" json:"archived,omitempty"`
+	Website             string                 `protobuf:"bytes,14,opt,name=website,proto3" json:"website,omitempty"`
+	Twitter             string                 `protobuf:"bytes,15,opt,name=twitter,proto3" json:"twitter,omitempty"`
+	Telegram            string                 `protobuf:"bytes,16,opt,name=telegram,proto3" json:"telegram,omitempty"`
+	Description         string                 `protobuf:"bytes,17,opt,name=description,proto3" json:"description,omitempty"`
+	HeaderImageUrl      string                 `protobuf:"bytes,18,opt,name=headerImageUrl,proto3" json:"headerImageUrl,omitempty"`
+	DeployerAddress     string                 `protobuf:"bytes,19,opt,name=deployerAddress,proto3" json:"deployerAddress,omitempty"`
+	DeployerRiskScore   *int32                 `protobuf:"varint,20,opt,name=deployerRiskScore,proto3,oneof" json:"deployerRiskScore,omitempty"`
+	DeployerLaunchCount int32                  `protobuf:"varint,21,opt,name=deployerLaunchCount,proto3" json:"deployerLaunchCount,omitempty"`
+	DeployerRugCount    int32                  `protobuf:"varint,22,opt,name=deployerRugCount,proto3" json:"deployerRugCount,omitempty"`
+	// Locale of the translated name and description, empty when the defaults are used.
+	Locale         string `protobuf:"bytes,23,opt,name=locale,proto3" json:"locale,omitempty"`
+	CachedImageUrl string `protobuf:"bytes,24,opt,name=cachedImageUrl,proto3" json:"cachedImageUrl,omitempty"`
+	// Delisted tokens keep the last price they had; it is no longer updated.
+	Delisted bool `protobuf:"varint,25,opt,name=delisted,proto3" json:"delisted,omitempty"`
+	// Unix milliseconds, zero when the token is listed.
+	DelistedAt int64 `protobuf:"varint,26,opt,name=delistedAt,proto3" json:"delistedAt,omitempty"`
+	// Supply priced at the token price in USD: circulating (or total when unknown) and total.
+	MarketCap string `protobuf:"bytes,27,opt,name=marketCap,proto3" json:"marketCap,omitempty"`
+	Fdv       string `protobuf:"bytes,28,opt,name=fdv,proto3" json:"fdv,omitempty"`
+	// What kind of token it is, e.g. "clanker", "meme" or "stable".
+	Tags []string `protobuf:"bytes,29,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Set when the V4 pool runs a hook on swaps; a dynamic fee hook sets the fee of every swap.
+	PoolHooks      string `protobuf:"bytes,30,opt,name=poolHooks,proto3" json:"poolHooks,omitempty"`
+	PoolDynamicFee bool   `protobuf:"varint,31,opt,name=poolDynamicFee,proto3" json:"poolDynamicFee,omitempty"`
+	// Share of every transfer the token keeps, unset until a transfer was simulated.
+	TransferTaxBps *int32 `protobuf:"varint,32,opt,name=transferTaxBps,proto3,oneof" json:"transferTaxBps,omitempty"`
+	// Whether the source is verified on Etherscan, unset until the contract was inspected.
+	ContractVerified *bool  `protobuf:"varint,33,opt,name=contractVerified,proto3,oneof" json:"contractVerified,omitempty"`
+	ContractName     string `protobuf:"bytes,34,opt,name=contractName,proto3" json:"contractName,omitempty"`
+	CompilerVersion  string `protobuf:"bytes,35,opt,name=compilerVersion,proto3" json:"compilerVersion,omitempty"`
+	// keccak256 of the verified source, shared by tokens deployed from the same code.
+	SourceHash string `protobuf:"bytes,36,opt,name=sourceHash,proto3" json:"sourceHash,omitempty"`
+	// Proxies delegate to an implementation their admin can swap.
+	ContractIsProxy bool   `protobuf:"varint,37,opt,name=contractIsProxy,proto3" json:"contractIsProxy,omitempty"`
+	Implementation  string `protobuf:"bytes,38,opt,name=implementation,proto3" json:"implementation,omitempty"`
+	// Where the price came from: swap, dexscreener, coingecko or fixed; empty for prices stored
+	// before sources were recorded.
+	PriceSource string `protobuf:"bytes,39,opt,name=priceSource,proto3" json:"priceSource,omitempty"`
+	// Unix milliseconds of when the price was last set.
+	PriceUpdatedAt int64 `protobuf:"varint,40,opt,name=priceUpdatedAt,proto3" json:"priceUpdatedAt,omitempty"`
+	// From 0 to 1: the trust in the source of the price, halved for every stale price period of
+	// age.
+	PriceConfidence float64 `protobuf:"fixed64,41,opt,name=priceConfidence,proto3" json:"priceConfidence,omitempty"`
+	// From 0 (no red flags) to 100, combining spam, transfer tax, deployer, liquidity, contract
+	// and holder concentration checks.
+	RiskScore int32 `protobuf:"varint,42,opt,name=riskScore,proto3" json:"riskScore,omitempty"`
+	// Position in the Dexscreener trending list, 1 first; unset when the token is not trending.
+	TrendingRank *int32 `protobuf:"varint,43,opt,name=trendingRank,proto3,oneof" json:"trendingRank,omitempty"`
+	// Farcaster casts mentioning a launch in the hour before its last check and the distinct
+	// accounts that cast them; unset until checked.
+	FarcasterCasts1H   *int32 `protobuf:"varint,44,opt,name=farcasterCasts1h,proto3,oneof" json:"farcasterCasts1h,omitempty"`
+	FarcasterCasters1H *int32 `protobuf:"varint,45,opt,name=farcasterCasters1h,proto3,oneof" json:"farcasterCasters1h,omitempty"`
+	// Unix milliseconds of the on-chain deployment of the contract and the seconds since then;
+	// both 0 until the deployment was looked up.
+	DeployedAt int64 `protobuf:"varint,46,opt,name=deployedAt,proto3" json:"deployedAt,omitempty"`
+	TokenAge   int64 `protobuf:"varint,47,opt,name=tokenAge,proto3" json:"tokenAge,omitempty"`
+	// Risk signals that were not checked yet and count as neutral in riskScore.
+	UncheckedRiskSignals []string `protobuf:"bytes,48,rep,name=uncheckedRiskSignals,proto3" json:"uncheckedRiskSignals,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
 func (x *Token) Reset() {
@@ -199,113 +257,363 @@ func (x *Token) GetReason() string {
 	return ""
 }
 
-type Wallet struct {
-	state                  protoimpl.MessageState `protogen:"open.v1"`
-	WalletAddress          string                 `protobuf:"bytes,1,opt,name=walletAddress,proto3" json:"walletAddress,omitempty"`
-	TotalDollarValue       string                 `protobuf:"bytes,2,opt,name=totalDollarValue,proto3" json:"totalDollarValue,omitempty"`
-	NativeBalance          string                 `protobuf:"bytes,3,opt,name=nativeBalance,proto3" json:"nativeBalance,omitempty"`
-	NativeBalanceFormatted string                 `protobuf:"bytes,4,opt,name=nativeBalanceFormatted,proto3" json:"nativeBalanceFormatted,omitempty"`
-	TokenAddresses         []string               `protobuf:"bytes,5,rep,name=tokenAddresses,proto3" json:"tokenAddresses,omitempty"`
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+func (x *Token) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
 }
 
-func (x *Wallet) Reset() {
-	*x = Wallet{}
-	mi := &file_common_common_proto_msgTypes[1]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Token) GetWebsite() string {
+	if x != nil {
+		return x.Website
+	}
+	return ""
 }
 
-func (x *Wallet) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Token) GetTwitter() string {
+	if x != nil {
+		return x.Twitter
+	}
+	return ""
 }
 
-func (*Wallet) ProtoMessage() {}
+func (x *Token) GetTelegram() string {
+	if x != nil {
+		return x.Telegram
+	}
+	return ""
+}
 
-func (x *Wallet) ProtoReflect() protoreflect.Message {
-	mi := &file_common_common_proto_msgTypes[1]
+func (x *Token) GetDescription() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Description
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use Wallet.ProtoReflect.Descriptor instead.
-func (*Wallet) Descriptor() ([]byte, []int) {
-	return file_common_common_proto_rawDescGZIP(), []int{1}
+func (x *Token) GetHeaderImageUrl() string {
+	if x != nil {
+		return x.HeaderImageUrl
+	}
+	return ""
 }
 
-func (x *Wallet) GetWalletAddress() string {
+func (x *Token) GetDeployerAddress() string {
 	if x != nil {
-		return x.WalletAddress
+		return x.DeployerAddress
 	}
 	return ""
 }
 
-func (x *Wallet) GetTotalDollarValue() string {
+func (x *Token) GetDeployerRiskScore() int32 {
+	if x != nil && x.DeployerRiskScore != nil {
+		return *x.DeployerRiskScore
+	}
+	return 0
+}
+
+func (x *Token) GetDeployerLaunchCount() int32 {
 	if x != nil {
-		return x.TotalDollarValue
+		return x.DeployerLaunchCount
+	}
+	return 0
+}
+
+func (x *Token) GetDeployerRugCount() int32 {
+	if x != nil {
+		return x.DeployerRugCount
+	}
+	return 0
+}
+
+func (x *Token) GetLocale() string {
+	if x != nil {
+		return x.Locale
 	}
 	return ""
 }
 
-func (x *Wallet) GetNativeBalance() string {
+func (x *Token) GetCachedImageUrl() string {
 	if x != nil {
-		return x.NativeBalance
+		return x.CachedImageUrl
 	}
 	return ""
 }
 
-func (x *Wallet) GetNativeBalanceFormatted() string {
+func (x *Token) GetDelisted() bool {
 	if x != nil {
-		return x.NativeBalanceFormatted
+		return x.Delisted
+	}
+	return false
+}
+
+func (x *Token) GetDelistedAt() int64 {
+	if x != nil {
+		return x.DelistedAt
+	}
+	return 0
+}
+
+func (x *Token) GetMarketCap() string {
+	if x != nil {
+		return x.MarketCap
 	}
 	return ""
 }
 
-func (x *Wallet) GetTokenAddresses() []string {
+func (x *Token) GetFdv() string {
 	if x != nil {
-		return x.TokenAddresses
+		return x.Fdv
+	}
+	return ""
+}
+
+func (x *Token) GetTags() []string {
+	if x != nil {
+		return x.Tags
 	}
 	return nil
 }
 
-type WalletToken struct {
-	state                 protoimpl.MessageState `protogen:"open.v1"`
-	TokenAddress          string                 `protobuf:"bytes,1,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
-	TokenBalance          string                 `protobuf:"bytes,2,opt,name=tokenBalance,proto3" json:"tokenBalance,omitempty"`
-	TokenBalanceFormatted string                 `protobuf:"bytes,3,opt,name=tokenBalanceFormatted,proto3" json:"tokenBalanceFormatted,omitempty"`
-	TokenPrice            string                 `protobuf:"bytes,4,opt,name=tokenPrice,proto3" json:"tokenPrice,omitempty"`
-	TokenDollarValue      string                 `protobuf:"bytes,5,opt,name=tokenDollarValue,proto3" json:"tokenDollarValue,omitempty"`
-	TokenImage            string                 `protobuf:"bytes,6,opt,name=tokenImage,proto3" json:"tokenImage,omitempty"`
-	TokenName             string                 `protobuf:"bytes,7,opt,name=tokenName,proto3" json:"tokenName,omitempty"`
-	TokenSymbol           string                 `protobuf:"bytes,8,opt,name=tokenSymbol,proto3" json:"tokenSymbol,omitempty"`
-	TokenVolume           string                 `protobuf:"bytes,9,opt,name=tokenVolume,proto3" json:"tokenVolume,omitempty"`
-	TokenSupply           string                 `protobuf:"bytes,10,opt,name=tokenSupply,proto3" json:"tokenSupply,omitempty"`
-	TokenPairAddress      string                 `protobuf:"bytes,11,opt,name=tokenPairAddress,proto3" json:"tokenPairAddress,omitempty"`
-	unknownFields         protoimpl.UnknownFields
-	sizeCache             protoimpl.SizeCache
+func (x *Token) GetPoolHooks() string {
+	if x != nil {
+		return x.PoolHooks
+	}
+	return ""
 }
 
-func (x *WalletToken) Reset() {
-	*x = WalletToken{}
+func (x *Token) GetPoolDynamicFee() bool {
+	if x != nil {
+		return x.PoolDynamicFee
+	}
+	return false
+}
+
+func (x *Token) GetTransferTaxBps() int32 {
+	if x != nil && x.TransferTaxBps != nil {
+		return *x.TransferTaxBps
+	}
+	return 0
+}
+
+func (x *Token) GetContractVerified() bool {
+	if x != nil && x.ContractVerified != nil {
+		return *x.ContractVerified
+	}
+	return false
+}
+
+func (x *Token) GetContractName() string {
+	if x != nil {
+		return x.ContractName
+	}
+	return ""
+}
+
+func (x *Token) GetCompilerVersion() string {
+	if x != nil {
+		return x.CompilerVersion
+	}
+	return ""
+}
+
+func (x *Token) GetSourceHash() string {
+	if x != nil {
+		return x.SourceHash
+	}
+	return ""
+}
+
+func (x *Token) GetContractIsProxy() bool {
+	if x != nil {
+		return x.ContractIsProxy
+	}
+	return false
+}
+
+func (x *Token) GetImplementation() string {
+	if x != nil {
+		return x.Implementation
+	}
+	return ""
+}
+
+func (x *Token) GetPriceSource() string {
+	if x != nil {
+		return x.PriceSource
+	}
+	return ""
+}
+
+func (x *Token) GetPriceUpdatedAt() int64 {
+	if x != nil {
+		return x.PriceUpdatedAt
+	}
+	return 0
+}
+
+func (x *Token) GetPriceConfidence() float64 {
+	if x != nil {
+		return x.PriceConfidence
+	}
+	return 0
+}
+
+func (x *Token) GetRiskScore() int32 {
+	if x != nil {
+		return x.RiskScore
+	}
+	return 0
+}
+
+func (x *Token) GetTrendingRank() int32 {
+	if x != nil && x.TrendingRank != nil {
+		return *x.TrendingRank
+	}
+	return 0
+}
+
+func (x *Token) GetFarcasterCasts1H() int32 {
+	if x != nil && x.FarcasterCasts1H != nil {
+		return *x.FarcasterCasts1H
+	}
+	return 0
+}
+
+func (x *Token) GetFarcasterCasters1H() int32 {
+	if x != nil && x.FarcasterCasters1H != nil {
+		return *x.FarcasterCasters1H
+	}
+	return 0
+}
+
+func (x *Token) GetDeployedAt() int64 {
+	if x != nil {
+		return x.DeployedAt
+	}
+	return 0
+}
+
+func (x *Token) GetTokenAge() int64 {
+	if x != nil {
+		return x.TokenAge
+	}
+	return 0
+}
+
+func (x *Token) GetUncheckedRiskSignals() []string {
+	if x != nil {
+		return x.UncheckedRiskSignals
+	}
+	return nil
+}
+
+// Decimal is an exact number in fixed-point notation, e.g. "0.0000001234", so prices of micro
+// cap tokens and large supplies keep every digit a double would round away.
+type Decimal struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Decimal) Reset() {
+	*x = Decimal{}
+	mi := &file_common_common_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Decimal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Decimal) ProtoMessage() {}
+
+func (x *Decimal) ProtoReflect() protoreflect.Message {
+	mi := &file_common_common_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Decimal.ProtoReflect.Descriptor instead.
+func (*Decimal) Descriptor() ([]byte, []int) {
+	return file_common_common_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Decimal) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// TokenV2 is a token with typed numbers. Values that are not known yet are unset rather than 0
+// or empty.
+type TokenV2 struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Address string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	ChainId int64                  `protobuf:"varint,2,opt,name=chainId,proto3" json:"chainId,omitempty"`
+	Name    string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Symbol  string                 `protobuf:"bytes,4,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	// Unset until the supply was read on-chain.
+	Decimals    *int32  `protobuf:"varint,5,opt,name=decimals,proto3,oneof" json:"decimals,omitempty"`
+	Description *string `protobuf:"bytes,6,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// Locale of the translated name and description, unset when the defaults are used.
+	Locale         *string `protobuf:"bytes,7,opt,name=locale,proto3,oneof" json:"locale,omitempty"`
+	ImageUrl       string  `protobuf:"bytes,8,opt,name=imageUrl,proto3" json:"imageUrl,omitempty"`
+	CachedImageUrl *string `protobuf:"bytes,9,opt,name=cachedImageUrl,proto3,oneof" json:"cachedImageUrl,omitempty"`
+	// What kind of token it is, e.g. "clanker", "meme" or "stable".
+	Tags []string `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Why the token is tracked, e.g. "clanker" or "wallet_token".
+	Reason   *string           `protobuf:"bytes,11,opt,name=reason,proto3,oneof" json:"reason,omitempty"`
+	Market   *TokenV2_Market   `protobuf:"bytes,12,opt,name=market,proto3" json:"market,omitempty"`
+	Pool     *TokenV2_Pool     `protobuf:"bytes,13,opt,name=pool,proto3" json:"pool,omitempty"`
+	Links    *TokenV2_Links    `protobuf:"bytes,14,opt,name=links,proto3" json:"links,omitempty"`
+	Contract *TokenV2_Contract `protobuf:"bytes,15,opt,name=contract,proto3" json:"contract,omitempty"`
+	Deployer *TokenV2_Deployer `protobuf:"bytes,16,opt,name=deployer,proto3" json:"deployer,omitempty"`
+	Flags    *TokenV2_Flags    `protobuf:"bytes,17,opt,name=flags,proto3" json:"flags,omitempty"`
+	// Unix milliseconds, unset while the token is listed.
+	DelistedAt *int64 `protobuf:"varint,18,opt,name=delistedAt,proto3,oneof" json:"delistedAt,omitempty"`
+	// From 0 (no red flags) to 100.
+	RiskScore int32 `protobuf:"varint,19,opt,name=riskScore,proto3" json:"riskScore,omitempty"`
+	// Position in the Dexscreener trending list, 1 first; unset when the token is not trending.
+	TrendingRank *int32 `protobuf:"varint,20,opt,name=trendingRank,proto3,oneof" json:"trendingRank,omitempty"`
+	// Farcaster casts mentioning a launch in the hour before its last check and the distinct
+	// accounts that cast them; unset until checked.
+	FarcasterCasts1H   *int32 `protobuf:"varint,21,opt,name=farcasterCasts1h,proto3,oneof" json:"farcasterCasts1h,omitempty"`
+	FarcasterCasters1H *int32 `protobuf:"varint,22,opt,name=farcasterCasters1h,proto3,oneof" json:"farcasterCasters1h,omitempty"`
+	// Unix milliseconds of the on-chain deployment of the contract and the seconds since then;
+	// unset until the deployment was looked up.
+	DeployedAt *int64 `protobuf:"varint,23,opt,name=deployedAt,proto3,oneof" json:"deployedAt,omitempty"`
+	TokenAge   *int64 `protobuf:"varint,24,opt,name=tokenAge,proto3,oneof" json:"tokenAge,omitempty"`
+	// Risk signals that were not checked yet and count as neutral in riskScore.
+	UncheckedRiskSignals []string `protobuf:"bytes,25,rep,name=uncheckedRiskSignals,proto3" json:"uncheckedRiskSignals,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *TokenV2) Reset() {
+	*x = TokenV2{}
 	mi := &file_common_common_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WalletToken) String() string {
+func (x *TokenV2) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WalletToken) ProtoMessage() {}
+func (*TokenV2) ProtoMessage() {}
 
-func (x *WalletToken) ProtoReflect() protoreflect.Message {
+func (x *TokenV2) ProtoReflect() protoreflect.Message {
 	mi := &file_common_common_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -317,113 +625,1484 @@ func (x *WalletToken) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WalletToken.ProtoReflect.Descriptor instead.
-func (*WalletToken) Descriptor() ([]byte, []int) {
+// Deprecated: Use TokenV2.ProtoReflect.Descriptor instead.
+func (*TokenV2) Descriptor() ([]byte, []int) {
 	return file_common_common_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *WalletToken) GetTokenAddress() string {
+func (x *TokenV2) GetAddress() string {
 	if x != nil {
-		return x.TokenAddress
+		return x.Address
 	}
 	return ""
 }
 
-func (x *WalletToken) GetTokenBalance() string {
+func (x *TokenV2) GetChainId() int64 {
 	if x != nil {
-		return x.TokenBalance
+		return x.ChainId
 	}
-	return ""
+	return 0
 }
 
-func (x *WalletToken) GetTokenBalanceFormatted() string {
+func (x *TokenV2) GetName() string {
 	if x != nil {
-		return x.TokenBalanceFormatted
+		return x.Name
 	}
 	return ""
 }
 
-func (x *WalletToken) GetTokenPrice() string {
+func (x *TokenV2) GetSymbol() string {
 	if x != nil {
-		return x.TokenPrice
+		return x.Symbol
 	}
 	return ""
 }
 
-func (x *WalletToken) GetTokenDollarValue() string {
-	if x != nil {
-		return x.TokenDollarValue
+func (x *TokenV2) GetDecimals() int32 {
+	if x != nil && x.Decimals != nil {
+		return *x.Decimals
+	}
+	return 0
+}
+
+func (x *TokenV2) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
 	}
 	return ""
 }
 
-func (x *WalletToken) GetTokenImage() string {
-	if x != nil {
-		return x.TokenImage
+func (x *TokenV2) GetLocale() string {
+	if x != nil && x.Locale != nil {
+		return *x.Locale
 	}
 	return ""
 }
 
-func (x *WalletToken) GetTokenName() string {
+func (x *TokenV2) GetImageUrl() string {
 	if x != nil {
-		return x.TokenName
+		return x.ImageUrl
 	}
 	return ""
 }
 
-func (x *WalletToken) GetTokenSymbol() string {
-	if x != nil {
-		return x.TokenSymbol
+func (x *TokenV2) GetCachedImageUrl() string {
+	if x != nil && x.CachedImageUrl != nil {
+		return *x.CachedImageUrl
 	}
 	return ""
 }
 
-func (x *WalletToken) GetTokenVolume() string {
+func (x *TokenV2) GetTags() []string {
 	if x != nil {
-		return x.TokenVolume
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *TokenV2) GetReason() string {
+	if x != nil && x.Reason != nil {
+		return *x.Reason
 	}
 	return ""
 }
 
-func (x *WalletToken) GetTokenSupply() string {
+func (x *TokenV2) GetMarket() *TokenV2_Market {
 	if x != nil {
-		return x.TokenSupply
+		return x.Market
 	}
-	return ""
+	return nil
 }
 
-func (x *WalletToken) GetTokenPairAddress() string {
+func (x *TokenV2) GetPool() *TokenV2_Pool {
 	if x != nil {
-		return x.TokenPairAddress
+		return x.Pool
 	}
-	return ""
+	return nil
 }
 
-var File_common_common_proto protoreflect.FileDescriptor
+func (x *TokenV2) GetLinks() *TokenV2_Links {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
 
-const file_common_common_proto_rawDesc = "" +
-	"\n" +
-	"\x13common/common.proto\x12\x06common\"\xe3\x02\n" +
-	"\x05Token\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
-	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x14\n" +
-	"\x05price\x18\x03 \x01(\tR\x05price\x12\x16\n" +
-	"\x06volume\x18\x04 \x01(\tR\x06volume\x12*\n" +
-	"\x10calculatedVolume\x18\x05 \x01(\tR\x10calculatedVolume\x12\x1a\n" +
-	"\bimageUrl\x18\x06 \x01(\tR\bimageUrl\x12\x18\n" +
-	"\aaddress\x18\a \x01(\tR\aaddress\x12 \n" +
-	"\vpoolAddress\x18\b \x01(\tR\vpoolAddress\x12\x16\n" +
+func (x *TokenV2) GetContract() *TokenV2_Contract {
+	if x != nil {
+		return x.Contract
+	}
+	return nil
+}
+
+func (x *TokenV2) GetDeployer() *TokenV2_Deployer {
+	if x != nil {
+		return x.Deployer
+	}
+	return nil
+}
+
+func (x *TokenV2) GetFlags() *TokenV2_Flags {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+func (x *TokenV2) GetDelistedAt() int64 {
+	if x != nil && x.DelistedAt != nil {
+		return *x.DelistedAt
+	}
+	return 0
+}
+
+func (x *TokenV2) GetRiskScore() int32 {
+	if x != nil {
+		return x.RiskScore
+	}
+	return 0
+}
+
+func (x *TokenV2) GetTrendingRank() int32 {
+	if x != nil && x.TrendingRank != nil {
+		return *x.TrendingRank
+	}
+	return 0
+}
+
+func (x *TokenV2) GetFarcasterCasts1H() int32 {
+	if x != nil && x.FarcasterCasts1H != nil {
+		return *x.FarcasterCasts1H
+	}
+	return 0
+}
+
+func (x *TokenV2) GetFarcasterCasters1H() int32 {
+	if x != nil && x.FarcasterCasters1H != nil {
+		return *x.FarcasterCasters1H
+	}
+	return 0
+}
+
+func (x *TokenV2) GetDeployedAt() int64 {
+	if x != nil && x.DeployedAt != nil {
+		return *x.DeployedAt
+	}
+	return 0
+}
+
+func (x *TokenV2) GetTokenAge() int64 {
+	if x != nil && x.TokenAge != nil {
+		return *x.TokenAge
+	}
+	return 0
+}
+
+func (x *TokenV2) GetUncheckedRiskSignals() []string {
+	if x != nil {
+		return x.UncheckedRiskSignals
+	}
+	return nil
+}
+
+// Orderflow counts the decoded swaps of a token within a window.
+type Orderflow struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Buys  int32                  `protobuf:"varint,1,opt,name=buys,proto3" json:"buys,omitempty"`
+	Sells int32                  `protobuf:"varint,2,opt,name=sells,proto3" json:"sells,omitempty"`
+	// Distinct wallets that bought and that sold.
+	Buyers  int32 `protobuf:"varint,3,opt,name=buyers,proto3" json:"buyers,omitempty"`
+	Sellers int32 `protobuf:"varint,4,opt,name=sellers,proto3" json:"sellers,omitempty"`
+	// USD.
+	BuyVolume     float64 `protobuf:"fixed64,5,opt,name=buyVolume,proto3" json:"buyVolume,omitempty"`
+	SellVolume    float64 `protobuf:"fixed64,6,opt,name=sellVolume,proto3" json:"sellVolume,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Orderflow) Reset() {
+	*x = Orderflow{}
+	mi := &file_common_common_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Orderflow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Orderflow) ProtoMessage() {}
+
+func (x *Orderflow) ProtoReflect() protoreflect.Message {
+	mi := &file_common_common_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Orderflow.ProtoReflect.Descriptor instead.
+func (*Orderflow) Descriptor() ([]byte, []int) {
+	return file_common_common_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Orderflow) GetBuys() int32 {
+	if x != nil {
+		return x.Buys
+	}
+	return 0
+}
+
+func (x *Orderflow) GetSells() int32 {
+	if x != nil {
+		return x.Sells
+	}
+	return 0
+}
+
+func (x *Orderflow) GetBuyers() int32 {
+	if x != nil {
+		return x.Buyers
+	}
+	return 0
+}
+
+func (x *Orderflow) GetSellers() int32 {
+	if x != nil {
+		return x.Sellers
+	}
+	return 0
+}
+
+func (x *Orderflow) GetBuyVolume() float64 {
+	if x != nil {
+		return x.BuyVolume
+	}
+	return 0
+}
+
+func (x *Orderflow) GetSellVolume() float64 {
+	if x != nil {
+		return x.SellVolume
+	}
+	return 0
+}
+
+// TokenOrderflow is the orderflow of a token over the last 5 minutes and the last hour, taken
+// from the swaps tokendata decoded since it started.
+type TokenOrderflow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	M5            *Orderflow             `protobuf:"bytes,1,opt,name=m5,proto3" json:"m5,omitempty"`
+	H1            *Orderflow             `protobuf:"bytes,2,opt,name=h1,proto3" json:"h1,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TokenOrderflow) Reset() {
+	*x = TokenOrderflow{}
+	mi := &file_common_common_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenOrderflow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenOrderflow) ProtoMessage() {}
+
+func (x *TokenOrderflow) ProtoReflect() protoreflect.Message {
+	mi := &file_common_common_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenOrderflow.ProtoReflect.Descriptor instead.
+func (*TokenOrderflow) Descriptor() ([]byte, []int) {
+	return file_common_common_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TokenOrderflow) GetM5() *Orderflow {
+	if x != nil {
+		return x.M5
+	}
+	return nil
+}
+
+func (x *TokenOrderflow) GetH1() *Orderflow {
+	if x != nil {
+		return x.H1
+	}
+	return nil
+}
+
+type Wallet struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddress    string                 `protobuf:"bytes,1,opt,name=walletAddress,proto3" json:"walletAddress,omitempty"`
+	TotalDollarValue string                 `protobuf:"bytes,2,opt,name=totalDollarValue,proto3" json:"totalDollarValue,omitempty"`
+	// In wei; the formatted balance is in ETH.
+	NativeBalance          string   `protobuf:"bytes,3,opt,name=nativeBalance,proto3" json:"nativeBalance,omitempty"`
+	NativeBalanceFormatted string   `protobuf:"bytes,4,opt,name=nativeBalanceFormatted,proto3" json:"nativeBalanceFormatted,omitempty"`
+	TokenAddresses         []string `protobuf:"bytes,5,rep,name=tokenAddresses,proto3" json:"tokenAddresses,omitempty"`
+	Label                  string   `protobuf:"bytes,6,opt,name=label,proto3" json:"label,omitempty"`
+	Tags                   []string `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	Groups                 []string `protobuf:"bytes,8,rep,name=groups,proto3" json:"groups,omitempty"`
+	// Native balance priced at the tokendata price of the wrapped native token.
+	NativeBalanceUsd string `protobuf:"bytes,9,opt,name=nativeBalanceUsd,proto3" json:"nativeBalanceUsd,omitempty"`
+	// Spam tokens held by the wallet, left out of tokenAddresses.
+	QuarantinedTokenAddresses []string `protobuf:"bytes,10,rep,name=quarantinedTokenAddresses,proto3" json:"quarantinedTokenAddresses,omitempty"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *Wallet) Reset() {
+	*x = Wallet{}
+	mi := &file_common_common_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Wallet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Wallet) ProtoMessage() {}
+
+func (x *Wallet) ProtoReflect() protoreflect.Message {
+	mi := &file_common_common_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Wallet.ProtoReflect.Descriptor instead.
+func (*Wallet) Descriptor() ([]byte, []int) {
+	return file_common_common_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Wallet) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *Wallet) GetTotalDollarValue() string {
+	if x != nil {
+		return x.TotalDollarValue
+	}
+	return ""
+}
+
+func (x *Wallet) GetNativeBalance() string {
+	if x != nil {
+		return x.NativeBalance
+	}
+	return ""
+}
+
+func (x *Wallet) GetNativeBalanceFormatted() string {
+	if x != nil {
+		return x.NativeBalanceFormatted
+	}
+	return ""
+}
+
+func (x *Wallet) GetTokenAddresses() []string {
+	if x != nil {
+		return x.TokenAddresses
+	}
+	return nil
+}
+
+func (x *Wallet) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *Wallet) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Wallet) GetGroups() []string {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *Wallet) GetNativeBalanceUsd() string {
+	if x != nil {
+		return x.NativeBalanceUsd
+	}
+	return ""
+}
+
+func (x *Wallet) GetQuarantinedTokenAddresses() []string {
+	if x != nil {
+		return x.QuarantinedTokenAddresses
+	}
+	return nil
+}
+
+type WalletToken struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	TokenAddress          string                 `protobuf:"bytes,1,opt,name=tokenAddress,proto3" json:"tokenAddress,omitempty"`
+	TokenBalance          string                 `protobuf:"bytes,2,opt,name=tokenBalance,proto3" json:"tokenBalance,omitempty"`
+	TokenBalanceFormatted string                 `protobuf:"bytes,3,opt,name=tokenBalanceFormatted,proto3" json:"tokenBalanceFormatted,omitempty"`
+	TokenPrice            string                 `protobuf:"bytes,4,opt,name=tokenPrice,proto3" json:"tokenPrice,omitempty"`
+	TokenDollarValue      string                 `protobuf:"bytes,5,opt,name=tokenDollarValue,proto3" json:"tokenDollarValue,omitempty"`
+	TokenImage            string                 `protobuf:"bytes,6,opt,name=tokenImage,proto3" json:"tokenImage,omitempty"`
+	TokenName             string                 `protobuf:"bytes,7,opt,name=tokenName,proto3" json:"tokenName,omitempty"`
+	TokenSymbol           string                 `protobuf:"bytes,8,opt,name=tokenSymbol,proto3" json:"tokenSymbol,omitempty"`
+	TokenVolume           string                 `protobuf:"bytes,9,opt,name=tokenVolume,proto3" json:"tokenVolume,omitempty"`
+	TokenSupply           string                 `protobuf:"bytes,10,opt,name=tokenSupply,proto3" json:"tokenSupply,omitempty"`
+	TokenPairAddress      string                 `protobuf:"bytes,11,opt,name=tokenPairAddress,proto3" json:"tokenPairAddress,omitempty"`
+	// Where the balance came from: moralis, or onchain when the chain disagreed with Moralis.
+	DataSource string `protobuf:"bytes,12,opt,name=dataSource,proto3" json:"dataSource,omitempty"`
+	// Set for spam tokens, which are only listed when spam is asked for.
+	Quarantined   bool `protobuf:"varint,13,opt,name=quarantined,proto3" json:"quarantined,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WalletToken) Reset() {
+	*x = WalletToken{}
+	mi := &file_common_common_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WalletToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WalletToken) ProtoMessage() {}
+
+func (x *WalletToken) ProtoReflect() protoreflect.Message {
+	mi := &file_common_common_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WalletToken.ProtoReflect.Descriptor instead.
+func (*WalletToken) Descriptor() ([]byte, []int) {
+	return file_common_common_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *WalletToken) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *WalletToken) GetTokenBalance() string {
+	if x != nil {
+		return x.TokenBalance
+	}
+	return ""
+}
+
+func (x *WalletToken) GetTokenBalanceFormatted() string {
+	if x != nil {
+		return x.TokenBalanceFormatted
+	}
+	return ""
+}
+
+func (x *WalletToken) GetTokenPrice() string {
+	if x != nil {
+		return x.TokenPrice
+	}
+	return ""
+}
+
+func (x *WalletToken) GetTokenDollarValue() string {
+	if x != nil {
+		return x.TokenDollarValue
+	}
+	return ""
+}
+
+func (x *WalletToken) GetTokenImage() string {
+	if x != nil {
+		return x.TokenImage
+	}
+	return ""
+}
+
+func (x *WalletToken) GetTokenName() string {
+	if x != nil {
+		return x.TokenName
+	}
+	return ""
+}
+
+func (x *WalletToken) GetTokenSymbol() string {
+	if x != nil {
+		return x.TokenSymbol
+	}
+	return ""
+}
+
+func (x *WalletToken) GetTokenVolume() string {
+	if x != nil {
+		return x.TokenVolume
+	}
+	return ""
+}
+
+func (x *WalletToken) GetTokenSupply() string {
+	if x != nil {
+		return x.TokenSupply
+	}
+	return ""
+}
+
+func (x *WalletToken) GetTokenPairAddress() string {
+	if x != nil {
+		return x.TokenPairAddress
+	}
+	return ""
+}
+
+func (x *WalletToken) GetDataSource() string {
+	if x != nil {
+		return x.DataSource
+	}
+	return ""
+}
+
+func (x *WalletToken) GetQuarantined() bool {
+	if x != nil {
+		return x.Quarantined
+	}
+	return false
+}
+
+// ApiUsage is the calls a service made to external APIs today (UTC). Calls per provider are the
+// total of all replicas of the service, paths those of the replica that answers.
+type ApiUsage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Day           string                 `protobuf:"bytes,1,opt,name=day,proto3" json:"day,omitempty"`
+	Providers     []*ProviderApiUsage    `protobuf:"bytes,2,rep,name=providers,proto3" json:"providers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApiUsage) Reset() {
+	*x = ApiUsage{}
+	mi := &file_common_common_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApiUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApiUsage) ProtoMessage() {}
+
+func (x *ApiUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_common_common_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApiUsage.ProtoReflect.Descriptor instead.
+func (*ApiUsage) Descriptor() ([]byte, []int) {
+	return file_common_common_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ApiUsage) GetDay() string {
+	if x != nil {
+		return x.Day
+	}
+	return ""
+}
+
+func (x *ApiUsage) GetProviders() []*ProviderApiUsage {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+type ProviderApiUsage struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Provider string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	// Calls of all replicas, synced every few seconds.
+	Calls int64 `protobuf:"varint,2,opt,name=calls,proto3" json:"calls,omitempty"`
+	// Soft daily budget from API_DAILY_BUDGETS, 0 when the provider has none. Over it, callers
+	// with a cheaper provider switch to it.
+	Budget     int64 `protobuf:"varint,3,opt,name=budget,proto3" json:"budget,omitempty"`
+	OverBudget bool  `protobuf:"varint,4,opt,name=overBudget,proto3" json:"overBudget,omitempty"`
+	// Calls of the replica that answers, ordered by calls, most first.
+	Paths         []*ApiCallPath `protobuf:"bytes,5,rep,name=paths,proto3" json:"paths,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProviderApiUsage) Reset() {
+	*x = ProviderApiUsage{}
+	mi := &file_common_common_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProviderApiUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderApiUsage) ProtoMessage() {}
+
+func (x *ProviderApiUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_common_common_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderApiUsage.ProtoReflect.Descriptor instead.
+func (*ProviderApiUsage) Descriptor() ([]byte, []int) {
+	return file_common_common_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ProviderApiUsage) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *ProviderApiUsage) GetCalls() int64 {
+	if x != nil {
+		return x.Calls
+	}
+	return 0
+}
+
+func (x *ProviderApiUsage) GetBudget() int64 {
+	if x != nil {
+		return x.Budget
+	}
+	return 0
+}
+
+func (x *ProviderApiUsage) GetOverBudget() bool {
+	if x != nil {
+		return x.OverBudget
+	}
+	return false
+}
+
+func (x *ProviderApiUsage) GetPaths() []*ApiCallPath {
+	if x != nil {
+		return x.Paths
+	}
+	return nil
+}
+
+// ApiCallPath is the calls made through one client function from one calling function.
+type ApiCallPath struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Endpoint      string                 `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Caller        string                 `protobuf:"bytes,2,opt,name=caller,proto3" json:"caller,omitempty"`
+	Calls         int64                  `protobuf:"varint,3,opt,name=calls,proto3" json:"calls,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApiCallPath) Reset() {
+	*x = ApiCallPath{}
+	mi := &file_common_common_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApiCallPath) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApiCallPath) ProtoMessage() {}
+
+func (x *ApiCallPath) ProtoReflect() protoreflect.Message {
+	mi := &file_common_common_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApiCallPath.ProtoReflect.Descriptor instead.
+func (*ApiCallPath) Descriptor() ([]byte, []int) {
+	return file_common_common_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ApiCallPath) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *ApiCallPath) GetCaller() string {
+	if x != nil {
+		return x.Caller
+	}
+	return ""
+}
+
+func (x *ApiCallPath) GetCalls() int64 {
+	if x != nil {
+		return x.Calls
+	}
+	return 0
+}
+
+type TokenV2_Market struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// USD per whole token, unset until priced.
+	Price *Decimal `protobuf:"bytes,1,opt,name=price,proto3" json:"price,omitempty"`
+	// Where the price came from: swap, dexscreener, coingecko or fixed.
+	PriceSource *string `protobuf:"bytes,2,opt,name=priceSource,proto3,oneof" json:"priceSource,omitempty"`
+	// Unix milliseconds of when the price was last set.
+	PriceUpdatedAt *int64 `protobuf:"varint,3,opt,name=priceUpdatedAt,proto3,oneof" json:"priceUpdatedAt,omitempty"`
+	// From 0 to 1: the trust in the source of the price, halved for every stale price period
+	// of age.
+	PriceConfidence float64 `protobuf:"fixed64,4,opt,name=priceConfidence,proto3" json:"priceConfidence,omitempty"`
+	// Change of the price since the token was added, in percent.
+	PriceChangePct *Decimal `protobuf:"bytes,5,opt,name=priceChangePct,proto3" json:"priceChangePct,omitempty"`
+	// USD volume of the last 24 hours, as reported and as calculated from decoded swaps.
+	Volume24H           *Decimal `protobuf:"bytes,6,opt,name=volume24h,proto3" json:"volume24h,omitempty"`
+	CalculatedVolume24H *Decimal `protobuf:"bytes,7,opt,name=calculatedVolume24h,proto3" json:"calculatedVolume24h,omitempty"`
+	// USD liquidity of the best pair, unset until the risk check ran.
+	LiquidityUsd *Decimal `protobuf:"bytes,8,opt,name=liquidityUsd,proto3" json:"liquidityUsd,omitempty"`
+	// In whole tokens; the circulating supply is unset when it is not known.
+	Supply            *Decimal `protobuf:"bytes,9,opt,name=supply,proto3" json:"supply,omitempty"`
+	CirculatingSupply *Decimal `protobuf:"bytes,10,opt,name=circulatingSupply,proto3" json:"circulatingSupply,omitempty"`
+	// USD, unset when the price or supply is unknown.
+	MarketCap     *Decimal `protobuf:"bytes,11,opt,name=marketCap,proto3" json:"marketCap,omitempty"`
+	Fdv           *Decimal `protobuf:"bytes,12,opt,name=fdv,proto3" json:"fdv,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TokenV2_Market) Reset() {
+	*x = TokenV2_Market{}
+	mi := &file_common_common_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenV2_Market) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenV2_Market) ProtoMessage() {}
+
+func (x *TokenV2_Market) ProtoReflect() protoreflect.Message {
+	mi := &file_common_common_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenV2_Market.ProtoReflect.Descriptor instead.
+func (*TokenV2_Market) Descriptor() ([]byte, []int) {
+	return file_common_common_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (x *TokenV2_Market) GetPrice() *Decimal {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *TokenV2_Market) GetPriceSource() string {
+	if x != nil && x.PriceSource != nil {
+		return *x.PriceSource
+	}
+	return ""
+}
+
+func (x *TokenV2_Market) GetPriceUpdatedAt() int64 {
+	if x != nil && x.PriceUpdatedAt != nil {
+		return *x.PriceUpdatedAt
+	}
+	return 0
+}
+
+func (x *TokenV2_Market) GetPriceConfidence() float64 {
+	if x != nil {
+		return x.PriceConfidence
+	}
+	return 0
+}
+
+func (x *TokenV2_Market) GetPriceChangePct() *Decimal {
+	if x != nil {
+		return x.PriceChangePct
+	}
+	return nil
+}
+
+func (x *TokenV2_Market) GetVolume24H() *Decimal {
+	if x != nil {
+		return x.Volume24H
+	}
+	return nil
+}
+
+func (x *TokenV2_Market) GetCalculatedVolume24H() *Decimal {
+	if x != nil {
+		return x.CalculatedVolume24H
+	}
+	return nil
+}
+
+func (x *TokenV2_Market) GetLiquidityUsd() *Decimal {
+	if x != nil {
+		return x.LiquidityUsd
+	}
+	return nil
+}
+
+func (x *TokenV2_Market) GetSupply() *Decimal {
+	if x != nil {
+		return x.Supply
+	}
+	return nil
+}
+
+func (x *TokenV2_Market) GetCirculatingSupply() *Decimal {
+	if x != nil {
+		return x.CirculatingSupply
+	}
+	return nil
+}
+
+func (x *TokenV2_Market) GetMarketCap() *Decimal {
+	if x != nil {
+		return x.MarketCap
+	}
+	return nil
+}
+
+func (x *TokenV2_Market) GetFdv() *Decimal {
+	if x != nil {
+		return x.Fdv
+	}
+	return nil
+}
+
+type TokenV2_Pool struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	PoolAddress string                 `protobuf:"bytes,1,opt,name=poolAddress,proto3" json:"poolAddress,omitempty"`
+	PairAddress string                 `protobuf:"bytes,2,opt,name=pairAddress,proto3" json:"pairAddress,omitempty"`
+	// Set when the V4 pool runs a hook on swaps.
+	Hooks         *string `protobuf:"bytes,3,opt,name=hooks,proto3,oneof" json:"hooks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TokenV2_Pool) Reset() {
+	*x = TokenV2_Pool{}
+	mi := &file_common_common_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenV2_Pool) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenV2_Pool) ProtoMessage() {}
+
+func (x *TokenV2_Pool) ProtoReflect() protoreflect.Message {
+	mi := &file_common_common_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenV2_Pool.ProtoReflect.Descriptor instead.
+func (*TokenV2_Pool) Descriptor() ([]byte, []int) {
+	return file_common_common_proto_rawDescGZIP(), []int{2, 1}
+}
+
+func (x *TokenV2_Pool) GetPoolAddress() string {
+	if x != nil {
+		return x.PoolAddress
+	}
+	return ""
+}
+
+func (x *TokenV2_Pool) GetPairAddress() string {
+	if x != nil {
+		return x.PairAddress
+	}
+	return ""
+}
+
+func (x *TokenV2_Pool) GetHooks() string {
+	if x != nil && x.Hooks != nil {
+		return *x.Hooks
+	}
+	return ""
+}
+
+type TokenV2_Links struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Website        *string                `protobuf:"bytes,1,opt,name=website,proto3,oneof" json:"website,omitempty"`
+	Twitter        *string                `protobuf:"bytes,2,opt,name=twitter,proto3,oneof" json:"twitter,omitempty"`
+	Telegram       *string                `protobuf:"bytes,3,opt,name=telegram,proto3,oneof" json:"telegram,omitempty"`
+	HeaderImageUrl *string                `protobuf:"bytes,4,opt,name=headerImageUrl,proto3,oneof" json:"headerImageUrl,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TokenV2_Links) Reset() {
+	*x = TokenV2_Links{}
+	mi := &file_common_common_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenV2_Links) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenV2_Links) ProtoMessage() {}
+
+func (x *TokenV2_Links) ProtoReflect() protoreflect.Message {
+	mi := &file_common_common_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenV2_Links.ProtoReflect.Descriptor instead.
+func (*TokenV2_Links) Descriptor() ([]byte, []int) {
+	return file_common_common_proto_rawDescGZIP(), []int{2, 2}
+}
+
+func (x *TokenV2_Links) GetWebsite() string {
+	if x != nil && x.Website != nil {
+		return *x.Website
+	}
+	return ""
+}
+
+func (x *TokenV2_Links) GetTwitter() string {
+	if x != nil && x.Twitter != nil {
+		return *x.Twitter
+	}
+	return ""
+}
+
+func (x *TokenV2_Links) GetTelegram() string {
+	if x != nil && x.Telegram != nil {
+		return *x.Telegram
+	}
+	return ""
+}
+
+func (x *TokenV2_Links) GetHeaderImageUrl() string {
+	if x != nil && x.HeaderImageUrl != nil {
+		return *x.HeaderImageUrl
+	}
+	return ""
+}
+
+type TokenV2_Contract struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unset until the contract was inspected.
+	Verified        *bool   `protobuf:"varint,1,opt,name=verified,proto3,oneof" json:"verified,omitempty"`
+	Name            *string `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	CompilerVersion *string `protobuf:"bytes,3,opt,name=compilerVersion,proto3,oneof" json:"compilerVersion,omitempty"`
+	// keccak256 of the verified source, shared by tokens deployed from the same code.
+	SourceHash     *string `protobuf:"bytes,4,opt,name=sourceHash,proto3,oneof" json:"sourceHash,omitempty"`
+	Implementation *string `protobuf:"bytes,5,opt,name=implementation,proto3,oneof" json:"implementation,omitempty"`
+	// Share of every transfer the token keeps, unset until a transfer was simulated.
+	TransferTaxBps *int32 `protobuf:"varint,6,opt,name=transferTaxBps,proto3,oneof" json:"transferTaxBps,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TokenV2_Contract) Reset() {
+	*x = TokenV2_Contract{}
+	mi := &file_common_common_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenV2_Contract) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenV2_Contract) ProtoMessage() {}
+
+func (x *TokenV2_Contract) ProtoReflect() protoreflect.Message {
+	mi := &file_common_common_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenV2_Contract.ProtoReflect.Descriptor instead.
+func (*TokenV2_Contract) Descriptor() ([]byte, []int) {
+	return file_common_common_proto_rawDescGZIP(), []int{2, 3}
+}
+
+func (x *TokenV2_Contract) GetVerified() bool {
+	if x != nil && x.Verified != nil {
+		return *x.Verified
+	}
+	return false
+}
+
+func (x *TokenV2_Contract) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *TokenV2_Contract) GetCompilerVersion() string {
+	if x != nil && x.CompilerVersion != nil {
+		return *x.CompilerVersion
+	}
+	return ""
+}
+
+func (x *TokenV2_Contract) GetSourceHash() string {
+	if x != nil && x.SourceHash != nil {
+		return *x.SourceHash
+	}
+	return ""
+}
+
+func (x *TokenV2_Contract) GetImplementation() string {
+	if x != nil && x.Implementation != nil {
+		return *x.Implementation
+	}
+	return ""
+}
+
+func (x *TokenV2_Contract) GetTransferTaxBps() int32 {
+	if x != nil && x.TransferTaxBps != nil {
+		return *x.TransferTaxBps
+	}
+	return 0
+}
+
+type TokenV2_Deployer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	RiskScore     *int32                 `protobuf:"varint,2,opt,name=riskScore,proto3,oneof" json:"riskScore,omitempty"`
+	LaunchCount   int32                  `protobuf:"varint,3,opt,name=launchCount,proto3" json:"launchCount,omitempty"`
+	RugCount      int32                  `protobuf:"varint,4,opt,name=rugCount,proto3" json:"rugCount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TokenV2_Deployer) Reset() {
+	*x = TokenV2_Deployer{}
+	mi := &file_common_common_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenV2_Deployer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenV2_Deployer) ProtoMessage() {}
+
+func (x *TokenV2_Deployer) ProtoReflect() protoreflect.Message {
+	mi := &file_common_common_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenV2_Deployer.ProtoReflect.Descriptor instead.
+func (*TokenV2_Deployer) Descriptor() ([]byte, []int) {
+	return file_common_common_proto_rawDescGZIP(), []int{2, 4}
+}
+
+func (x *TokenV2_Deployer) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *TokenV2_Deployer) GetRiskScore() int32 {
+	if x != nil && x.RiskScore != nil {
+		return *x.RiskScore
+	}
+	return 0
+}
+
+func (x *TokenV2_Deployer) GetLaunchCount() int32 {
+	if x != nil {
+		return x.LaunchCount
+	}
+	return 0
+}
+
+func (x *TokenV2_Deployer) GetRugCount() int32 {
+	if x != nil {
+		return x.RugCount
+	}
+	return 0
+}
+
+type TokenV2_Flags struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Archived bool                   `protobuf:"varint,1,opt,name=archived,proto3" json:"archived,omitempty"`
+	// Delisted tokens keep the last price they had; it is no longer updated.
+	Delisted       bool `protobuf:"varint,2,opt,name=delisted,proto3" json:"delisted,omitempty"`
+	PoolDynamicFee bool `protobuf:"varint,3,opt,name=poolDynamicFee,proto3" json:"poolDynamicFee,omitempty"`
+	// Proxies delegate to an implementation their admin can swap.
+	ContractIsProxy bool `protobuf:"varint,4,opt,name=contractIsProxy,proto3" json:"contractIsProxy,omitempty"`
+	// On the unsecure tokens blacklist; the pool is not watched.
+	Blacklisted   bool `protobuf:"varint,5,opt,name=blacklisted,proto3" json:"blacklisted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TokenV2_Flags) Reset() {
+	*x = TokenV2_Flags{}
+	mi := &file_common_common_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenV2_Flags) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenV2_Flags) ProtoMessage() {}
+
+func (x *TokenV2_Flags) ProtoReflect() protoreflect.Message {
+	mi := &file_common_common_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenV2_Flags.ProtoReflect.Descriptor instead.
+func (*TokenV2_Flags) Descriptor() ([]byte, []int) {
+	return file_common_common_proto_rawDescGZIP(), []int{2, 5}
+}
+
+func (x *TokenV2_Flags) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+func (x *TokenV2_Flags) GetDelisted() bool {
+	if x != nil {
+		return x.Delisted
+	}
+	return false
+}
+
+func (x *TokenV2_Flags) GetPoolDynamicFee() bool {
+	if x != nil {
+		return x.PoolDynamicFee
+	}
+	return false
+}
+
+func (x *TokenV2_Flags) GetContractIsProxy() bool {
+	if x != nil {
+		return x.ContractIsProxy
+	}
+	return false
+}
+
+func (x *TokenV2_Flags) GetBlacklisted() bool {
+	if x != nil {
+		return x.Blacklisted
+	}
+	return false
+}
+
+var File_common_common_proto protoreflect.FileDescriptor
+
+const file_common_common_proto_rawDesc = "" +
+	"\n" +
+	"\x13common/common.proto\x12\x06common\"\x84\x0e\n" +
+	"\x05Token\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\tR\x05price\x12\x16\n" +
+	"\x06volume\x18\x04 \x01(\tR\x06volume\x12*\n" +
+	"\x10calculatedVolume\x18\x05 \x01(\tR\x10calculatedVolume\x12\x1a\n" +
+	"\bimageUrl\x18\x06 \x01(\tR\bimageUrl\x12\x18\n" +
+	"\aaddress\x18\a \x01(\tR\aaddress\x12 \n" +
+	"\vpoolAddress\x18\b \x01(\tR\vpoolAddress\x12\x16\n" +
 	"\x06supply\x18\t \x01(\tR\x06supply\x12*\n" +
 	"\x10circulatedSupply\x18\n" +
 	" \x01(\tR\x10circulatedSupply\x12 \n" +
 	"\vpairAddress\x18\v \x01(\tR\vpairAddress\x12\x16\n" +
-	"\x06reason\x18\f \x01(\tR\x06reason\"\xe0\x01\n" +
+	"\x06reason\x18\f \x01(\tR\x06reason\x12\x1a\n" +
+	"\barchived\x18\r \x01(\bR\barchived\x12\x18\n" +
+	"\awebsite\x18\x0e \x01(\tR\awebsite\x12\x18\n" +
+	"\atwitter\x18\x0f \x01(\tR\atwitter\x12\x1a\n" +
+	"\btelegram\x18\x10 \x01(\tR\btelegram\x12 \n" +
+	"\vdescription\x18\x11 \x01(\tR\vdescription\x12&\n" +
+	"\x0eheaderImageUrl\x18\x12 \x01(\tR\x0eheaderImageUrl\x12(\n" +
+	"\x0fdeployerAddress\x18\x13 \x01(\tR\x0fdeployerAddress\x121\n" +
+	"\x11deployerRiskScore\x18\x14 \x01(\x05H\x00R\x11deployerRiskScore\x88\x01\x01\x120\n" +
+	"\x13deployerLaunchCount\x18\x15 \x01(\x05R\x13deployerLaunchCount\x12*\n" +
+	"\x10deployerRugCount\x18\x16 \x01(\x05R\x10deployerRugCount\x12\x16\n" +
+	"\x06locale\x18\x17 \x01(\tR\x06locale\x12&\n" +
+	"\x0ecachedImageUrl\x18\x18 \x01(\tR\x0ecachedImageUrl\x12\x1a\n" +
+	"\bdelisted\x18\x19 \x01(\bR\bdelisted\x12\x1e\n" +
+	"\n" +
+	"delistedAt\x18\x1a \x01(\x03R\n" +
+	"delistedAt\x12\x1c\n" +
+	"\tmarketCap\x18\x1b \x01(\tR\tmarketCap\x12\x10\n" +
+	"\x03fdv\x18\x1c \x01(\tR\x03fdv\x12\x12\n" +
+	"\x04tags\x18\x1d \x03(\tR\x04tags\x12\x1c\n" +
+	"\tpoolHooks\x18\x1e \x01(\tR\tpoolHooks\x12&\n" +
+	"\x0epoolDynamicFee\x18\x1f \x01(\bR\x0epoolDynamicFee\x12+\n" +
+	"\x0etransferTaxBps\x18  \x01(\x05H\x01R\x0etransferTaxBps\x88\x01\x01\x12/\n" +
+	"\x10contractVerified\x18! \x01(\bH\x02R\x10contractVerified\x88\x01\x01\x12\"\n" +
+	"\fcontractName\x18\" \x01(\tR\fcontractName\x12(\n" +
+	"\x0fcompilerVersion\x18# \x01(\tR\x0fcompilerVersion\x12\x1e\n" +
+	"\n" +
+	"sourceHash\x18$ \x01(\tR\n" +
+	"sourceHash\x12(\n" +
+	"\x0fcontractIsProxy\x18% \x01(\bR\x0fcontractIsProxy\x12&\n" +
+	"\x0eimplementation\x18& \x01(\tR\x0eimplementation\x12 \n" +
+	"\vpriceSource\x18' \x01(\tR\vpriceSource\x12&\n" +
+	"\x0epriceUpdatedAt\x18( \x01(\x03R\x0epriceUpdatedAt\x12(\n" +
+	"\x0fpriceConfidence\x18) \x01(\x01R\x0fpriceConfidence\x12\x1c\n" +
+	"\triskScore\x18* \x01(\x05R\triskScore\x12'\n" +
+	"\ftrendingRank\x18+ \x01(\x05H\x03R\ftrendingRank\x88\x01\x01\x12/\n" +
+	"\x10farcasterCasts1h\x18, \x01(\x05H\x04R\x10farcasterCasts1h\x88\x01\x01\x123\n" +
+	"\x12farcasterCasters1h\x18- \x01(\x05H\x05R\x12farcasterCasters1h\x88\x01\x01\x12\x1e\n" +
+	"\n" +
+	"deployedAt\x18. \x01(\x03R\n" +
+	"deployedAt\x12\x1a\n" +
+	"\btokenAge\x18/ \x01(\x03R\btokenAge\x122\n" +
+	"\x14uncheckedRiskSignals\x180 \x03(\tR\x14uncheckedRiskSignalsB\x14\n" +
+	"\x12_deployerRiskScoreB\x11\n" +
+	"\x0f_transferTaxBpsB\x13\n" +
+	"\x11_contractVerifiedB\x0f\n" +
+	"\r_trendingRankB\x13\n" +
+	"\x11_farcasterCasts1hB\x15\n" +
+	"\x13_farcasterCasters1h\"\x1f\n" +
+	"\aDecimal\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\"\xae\x15\n" +
+	"\aTokenV2\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x18\n" +
+	"\achainId\x18\x02 \x01(\x03R\achainId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x16\n" +
+	"\x06symbol\x18\x04 \x01(\tR\x06symbol\x12\x1f\n" +
+	"\bdecimals\x18\x05 \x01(\x05H\x00R\bdecimals\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x06 \x01(\tH\x01R\vdescription\x88\x01\x01\x12\x1b\n" +
+	"\x06locale\x18\a \x01(\tH\x02R\x06locale\x88\x01\x01\x12\x1a\n" +
+	"\bimageUrl\x18\b \x01(\tR\bimageUrl\x12+\n" +
+	"\x0ecachedImageUrl\x18\t \x01(\tH\x03R\x0ecachedImageUrl\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\n" +
+	" \x03(\tR\x04tags\x12\x1b\n" +
+	"\x06reason\x18\v \x01(\tH\x04R\x06reason\x88\x01\x01\x12.\n" +
+	"\x06market\x18\f \x01(\v2\x16.common.TokenV2.MarketR\x06market\x12(\n" +
+	"\x04pool\x18\r \x01(\v2\x14.common.TokenV2.PoolR\x04pool\x12+\n" +
+	"\x05links\x18\x0e \x01(\v2\x15.common.TokenV2.LinksR\x05links\x124\n" +
+	"\bcontract\x18\x0f \x01(\v2\x18.common.TokenV2.ContractR\bcontract\x124\n" +
+	"\bdeployer\x18\x10 \x01(\v2\x18.common.TokenV2.DeployerR\bdeployer\x12+\n" +
+	"\x05flags\x18\x11 \x01(\v2\x15.common.TokenV2.FlagsR\x05flags\x12#\n" +
+	"\n" +
+	"delistedAt\x18\x12 \x01(\x03H\x05R\n" +
+	"delistedAt\x88\x01\x01\x12\x1c\n" +
+	"\triskScore\x18\x13 \x01(\x05R\triskScore\x12'\n" +
+	"\ftrendingRank\x18\x14 \x01(\x05H\x06R\ftrendingRank\x88\x01\x01\x12/\n" +
+	"\x10farcasterCasts1h\x18\x15 \x01(\x05H\aR\x10farcasterCasts1h\x88\x01\x01\x123\n" +
+	"\x12farcasterCasters1h\x18\x16 \x01(\x05H\bR\x12farcasterCasters1h\x88\x01\x01\x12#\n" +
+	"\n" +
+	"deployedAt\x18\x17 \x01(\x03H\tR\n" +
+	"deployedAt\x88\x01\x01\x12\x1f\n" +
+	"\btokenAge\x18\x18 \x01(\x03H\n" +
+	"R\btokenAge\x88\x01\x01\x122\n" +
+	"\x14uncheckedRiskSignals\x18\x19 \x03(\tR\x14uncheckedRiskSignals\x1a\xea\x04\n" +
+	"\x06Market\x12%\n" +
+	"\x05price\x18\x01 \x01(\v2\x0f.common.DecimalR\x05price\x12%\n" +
+	"\vpriceSource\x18\x02 \x01(\tH\x00R\vpriceSource\x88\x01\x01\x12+\n" +
+	"\x0epriceUpdatedAt\x18\x03 \x01(\x03H\x01R\x0epriceUpdatedAt\x88\x01\x01\x12(\n" +
+	"\x0fpriceConfidence\x18\x04 \x01(\x01R\x0fpriceConfidence\x127\n" +
+	"\x0epriceChangePct\x18\x05 \x01(\v2\x0f.common.DecimalR\x0epriceChangePct\x12-\n" +
+	"\tvolume24h\x18\x06 \x01(\v2\x0f.common.DecimalR\tvolume24h\x12A\n" +
+	"\x13calculatedVolume24h\x18\a \x01(\v2\x0f.common.DecimalR\x13calculatedVolume24h\x123\n" +
+	"\fliquidityUsd\x18\b \x01(\v2\x0f.common.DecimalR\fliquidityUsd\x12'\n" +
+	"\x06supply\x18\t \x01(\v2\x0f.common.DecimalR\x06supply\x12=\n" +
+	"\x11circulatingSupply\x18\n" +
+	" \x01(\v2\x0f.common.DecimalR\x11circulatingSupply\x12-\n" +
+	"\tmarketCap\x18\v \x01(\v2\x0f.common.DecimalR\tmarketCap\x12!\n" +
+	"\x03fdv\x18\f \x01(\v2\x0f.common.DecimalR\x03fdvB\x0e\n" +
+	"\f_priceSourceB\x11\n" +
+	"\x0f_priceUpdatedAt\x1ao\n" +
+	"\x04Pool\x12 \n" +
+	"\vpoolAddress\x18\x01 \x01(\tR\vpoolAddress\x12 \n" +
+	"\vpairAddress\x18\x02 \x01(\tR\vpairAddress\x12\x19\n" +
+	"\x05hooks\x18\x03 \x01(\tH\x00R\x05hooks\x88\x01\x01B\b\n" +
+	"\x06_hooks\x1a\xcb\x01\n" +
+	"\x05Links\x12\x1d\n" +
+	"\awebsite\x18\x01 \x01(\tH\x00R\awebsite\x88\x01\x01\x12\x1d\n" +
+	"\atwitter\x18\x02 \x01(\tH\x01R\atwitter\x88\x01\x01\x12\x1f\n" +
+	"\btelegram\x18\x03 \x01(\tH\x02R\btelegram\x88\x01\x01\x12+\n" +
+	"\x0eheaderImageUrl\x18\x04 \x01(\tH\x03R\x0eheaderImageUrl\x88\x01\x01B\n" +
+	"\n" +
+	"\b_websiteB\n" +
+	"\n" +
+	"\b_twitterB\v\n" +
+	"\t_telegramB\x11\n" +
+	"\x0f_headerImageUrl\x1a\xd1\x02\n" +
+	"\bContract\x12\x1f\n" +
+	"\bverified\x18\x01 \x01(\bH\x00R\bverified\x88\x01\x01\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x01R\x04name\x88\x01\x01\x12-\n" +
+	"\x0fcompilerVersion\x18\x03 \x01(\tH\x02R\x0fcompilerVersion\x88\x01\x01\x12#\n" +
+	"\n" +
+	"sourceHash\x18\x04 \x01(\tH\x03R\n" +
+	"sourceHash\x88\x01\x01\x12+\n" +
+	"\x0eimplementation\x18\x05 \x01(\tH\x04R\x0eimplementation\x88\x01\x01\x12+\n" +
+	"\x0etransferTaxBps\x18\x06 \x01(\x05H\x05R\x0etransferTaxBps\x88\x01\x01B\v\n" +
+	"\t_verifiedB\a\n" +
+	"\x05_nameB\x12\n" +
+	"\x10_compilerVersionB\r\n" +
+	"\v_sourceHashB\x11\n" +
+	"\x0f_implementationB\x11\n" +
+	"\x0f_transferTaxBps\x1a\x93\x01\n" +
+	"\bDeployer\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12!\n" +
+	"\triskScore\x18\x02 \x01(\x05H\x00R\triskScore\x88\x01\x01\x12 \n" +
+	"\vlaunchCount\x18\x03 \x01(\x05R\vlaunchCount\x12\x1a\n" +
+	"\brugCount\x18\x04 \x01(\x05R\brugCountB\f\n" +
+	"\n" +
+	"_riskScore\x1a\xb3\x01\n" +
+	"\x05Flags\x12\x1a\n" +
+	"\barchived\x18\x01 \x01(\bR\barchived\x12\x1a\n" +
+	"\bdelisted\x18\x02 \x01(\bR\bdelisted\x12&\n" +
+	"\x0epoolDynamicFee\x18\x03 \x01(\bR\x0epoolDynamicFee\x12(\n" +
+	"\x0fcontractIsProxy\x18\x04 \x01(\bR\x0fcontractIsProxy\x12 \n" +
+	"\vblacklisted\x18\x05 \x01(\bR\vblacklistedB\v\n" +
+	"\t_decimalsB\x0e\n" +
+	"\f_descriptionB\t\n" +
+	"\a_localeB\x11\n" +
+	"\x0f_cachedImageUrlB\t\n" +
+	"\a_reasonB\r\n" +
+	"\v_delistedAtB\x0f\n" +
+	"\r_trendingRankB\x13\n" +
+	"\x11_farcasterCasts1hB\x15\n" +
+	"\x13_farcasterCasters1hB\r\n" +
+	"\v_deployedAtB\v\n" +
+	"\t_tokenAge\"\xa5\x01\n" +
+	"\tOrderflow\x12\x12\n" +
+	"\x04buys\x18\x01 \x01(\x05R\x04buys\x12\x14\n" +
+	"\x05sells\x18\x02 \x01(\x05R\x05sells\x12\x16\n" +
+	"\x06buyers\x18\x03 \x01(\x05R\x06buyers\x12\x18\n" +
+	"\asellers\x18\x04 \x01(\x05R\asellers\x12\x1c\n" +
+	"\tbuyVolume\x18\x05 \x01(\x01R\tbuyVolume\x12\x1e\n" +
+	"\n" +
+	"sellVolume\x18\x06 \x01(\x01R\n" +
+	"sellVolume\"V\n" +
+	"\x0eTokenOrderflow\x12!\n" +
+	"\x02m5\x18\x01 \x01(\v2\x11.common.OrderflowR\x02m5\x12!\n" +
+	"\x02h1\x18\x02 \x01(\v2\x11.common.OrderflowR\x02h1\"\x8c\x03\n" +
 	"\x06Wallet\x12$\n" +
 	"\rwalletAddress\x18\x01 \x01(\tR\rwalletAddress\x12*\n" +
 	"\x10totalDollarValue\x18\x02 \x01(\tR\x10totalDollarValue\x12$\n" +
 	"\rnativeBalance\x18\x03 \x01(\tR\rnativeBalance\x126\n" +
 	"\x16nativeBalanceFormatted\x18\x04 \x01(\tR\x16nativeBalanceFormatted\x12&\n" +
-	"\x0etokenAddresses\x18\x05 \x03(\tR\x0etokenAddresses\"\xa7\x03\n" +
+	"\x0etokenAddresses\x18\x05 \x03(\tR\x0etokenAddresses\x12\x14\n" +
+	"\x05label\x18\x06 \x01(\tR\x05label\x12\x12\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\x12\x16\n" +
+	"\x06groups\x18\b \x03(\tR\x06groups\x12*\n" +
+	"\x10nativeBalanceUsd\x18\t \x01(\tR\x10nativeBalanceUsd\x12<\n" +
+	"\x19quarantinedTokenAddresses\x18\n" +
+	" \x03(\tR\x19quarantinedTokenAddresses\"\xe9\x03\n" +
 	"\vWalletToken\x12\"\n" +
 	"\ftokenAddress\x18\x01 \x01(\tR\ftokenAddress\x12\"\n" +
 	"\ftokenBalance\x18\x02 \x01(\tR\ftokenBalance\x124\n" +
@@ -440,11 +2119,28 @@ const file_common_common_proto_rawDesc = "" +
 	"\vtokenVolume\x18\t \x01(\tR\vtokenVolume\x12 \n" +
 	"\vtokenSupply\x18\n" +
 	" \x01(\tR\vtokenSupply\x12*\n" +
-	"\x10tokenPairAddress\x18\v \x01(\tR\x10tokenPairAddress*\x1d\n" +
-	"\x05CHAIN\x12\b\n" +
-	"\x04BASE\x10\x00\x12\n" +
+	"\x10tokenPairAddress\x18\v \x01(\tR\x10tokenPairAddress\x12\x1e\n" +
 	"\n" +
-	"\x06SOLANA\x10\x01B\x18Z\x16tokendata/proto/commonb\x06proto3"
+	"dataSource\x18\f \x01(\tR\n" +
+	"dataSource\x12 \n" +
+	"\vquarantined\x18\r \x01(\bR\vquarantined\"T\n" +
+	"\bApiUsage\x12\x10\n" +
+	"\x03day\x18\x01 \x01(\tR\x03day\x126\n" +
+	"\tproviders\x18\x02 \x03(\v2\x18.common.ProviderApiUsageR\tproviders\"\xa7\x01\n" +
+	"\x10ProviderApiUsage\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x14\n" +
+	"\x05calls\x18\x02 \x01(\x03R\x05calls\x12\x16\n" +
+	"\x06budget\x18\x03 \x01(\x03R\x06budget\x12\x1e\n" +
+	"\n" +
+	"overBudget\x18\x04 \x01(\bR\n" +
+	"overBudget\x12)\n" +
+	"\x05paths\x18\x05 \x03(\v2\x13.common.ApiCallPathR\x05paths\"W\n" +
+	"\vApiCallPath\x12\x1a\n" +
+	"\bendpoint\x18\x01 \x01(\tR\bendpoint\x12\x16\n" +
+	"\x06caller\x18\x02 \x01(\tR\x06caller\x12\x14\n" +
+	"\x05calls\x18\x03 \x01(\x03R\x05calls*\x11\n" +
+	"\x05CHAIN\x12\b\n" +
+	"\x04BASE\x10\x00B\x0eZ\fproto/commonb\x06proto3"
 
 var (
 	file_common_common_proto_rawDescOnce sync.Once
@@ -459,19 +2155,51 @@ func file_common_common_proto_rawDescGZIP() []byte {
 }
 
 var file_common_common_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_common_common_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_common_common_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_common_common_proto_goTypes = []any{
-	(CHAIN)(0),          // 0: common.CHAIN
-	(*Token)(nil),       // 1: common.Token
-	(*Wallet)(nil),      // 2: common.Wallet
-	(*WalletToken)(nil), // 3: common.WalletToken
+	(CHAIN)(0),               // 0: common.CHAIN
+	(*Token)(nil),            // 1: common.Token
+	(*Decimal)(nil),          // 2: common.Decimal
+	(*TokenV2)(nil),          // 3: common.TokenV2
+	(*Orderflow)(nil),        // 4: common.Orderflow
+	(*TokenOrderflow)(nil),   // 5: common.TokenOrderflow
+	(*Wallet)(nil),           // 6: common.Wallet
+	(*WalletToken)(nil),      // 7: common.WalletToken
+	(*ApiUsage)(nil),         // 8: common.ApiUsage
+	(*ProviderApiUsage)(nil), // 9: common.ProviderApiUsage
+	(*ApiCallPath)(nil),      // 10: common.ApiCallPath
+	(*TokenV2_Market)(nil),   // 11: common.TokenV2.Market
+	(*TokenV2_Pool)(nil),     // 12: common.TokenV2.Pool
+	(*TokenV2_Links)(nil),    // 13: common.TokenV2.Links
+	(*TokenV2_Contract)(nil), // 14: common.TokenV2.Contract
+	(*TokenV2_Deployer)(nil), // 15: common.TokenV2.Deployer
+	(*TokenV2_Flags)(nil),    // 16: common.TokenV2.Flags
 }
 var file_common_common_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	11, // 0: common.TokenV2.market:type_name -> common.TokenV2.Market
+	12, // 1: common.TokenV2.pool:type_name -> common.TokenV2.Pool
+	13, // 2: common.TokenV2.links:type_name -> common.TokenV2.Links
+	14, // 3: common.TokenV2.contract:type_name -> common.TokenV2.Contract
+	15, // 4: common.TokenV2.deployer:type_name -> common.TokenV2.Deployer
+	16, // 5: common.TokenV2.flags:type_name -> common.TokenV2.Flags
+	4,  // 6: common.TokenOrderflow.m5:type_name -> common.Orderflow
+	4,  // 7: common.TokenOrderflow.h1:type_name -> common.Orderflow
+	9,  // 8: common.ApiUsage.providers:type_name -> common.ProviderApiUsage
+	10, // 9: common.ProviderApiUsage.paths:type_name -> common.ApiCallPath
+	2,  // 10: common.TokenV2.Market.price:type_name -> common.Decimal
+	2,  // 11: common.TokenV2.Market.priceChangePct:type_name -> common.Decimal
+	2,  // 12: common.TokenV2.Market.volume24h:type_name -> common.Decimal
+	2,  // 13: common.TokenV2.Market.calculatedVolume24h:type_name -> common.Decimal
+	2,  // 14: common.TokenV2.Market.liquidityUsd:type_name -> common.Decimal
+	2,  // 15: common.TokenV2.Market.supply:type_name -> common.Decimal
+	2,  // 16: common.TokenV2.Market.circulatingSupply:type_name -> common.Decimal
+	2,  // 17: common.TokenV2.Market.marketCap:type_name -> common.Decimal
+	2,  // 18: common.TokenV2.Market.fdv:type_name -> common.Decimal
+	19, // [19:19] is the sub-list for method output_type
+	19, // [19:19] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
 }
 
 func init() { file_common_common_proto_init() }
@@ -479,13 +2207,20 @@ func file_common_common_proto_init() {
 	if File_common_common_proto != nil {
 		return
 	}
+	file_common_common_proto_msgTypes[0].OneofWrappers = []any{}
+	file_common_common_proto_msgTypes[2].OneofWrappers = []any{}
+	file_common_common_proto_msgTypes[10].OneofWrappers = []any{}
+	file_common_common_proto_msgTypes[11].OneofWrappers = []any{}
+	file_common_common_proto_msgTypes[12].OneofWrappers = []any{}
+	file_common_common_proto_msgTypes[13].OneofWrappers = []any{}
+	file_common_common_proto_msgTypes[14].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_common_common_proto_rawDesc), len(file_common_common_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   3,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -24,42 +24,162 @@ var File_wallet_wallet_proto protoreflect.FileDescriptor
 
 const file_wallet_wallet_proto_rawDesc = "" +
 	"\n" +
-	"\x13wallet/wallet.proto\x12\x0escanner_wallet\x1a\x15wallet/messages.proto2\xa4\x03\n" +
+	"\x13wallet/wallet.proto\x12\x0escanner_wallet\x1a\x15wallet/messages.proto2\x8f\x14\n" +
 	"\rScannerWallet\x12@\n" +
 	"\taddWallet\x12\x18.wallet.AddWalletRequest\x1a\x19.wallet.AddWalletResponse\x12@\n" +
 	"\tgetWallet\x12\x18.wallet.GetWalletRequest\x1a\x19.wallet.GetWalletResponse\x12R\n" +
 	"\x0fgetWalletTokens\x12\x1e.wallet.GetWalletTokensRequest\x1a\x1f.wallet.GetWalletTokensResponse\x12U\n" +
 	"\x10getWalletDetails\x12\x1f.wallet.GetWalletDetailsRequest\x1a .wallet.GetWalletDetailsResponse\x12d\n" +
-	"\x15updateWalletPortfolio\x12$.wallet.UpdateWalletPortfolioRequest\x1a%.wallet.UpdateWalletPortfolioResponseB\x19Z\x17walletdata/proto/walletb\x06proto3"
+	"\x15updateWalletPortfolio\x12$.wallet.UpdateWalletPortfolioRequest\x1a%.wallet.UpdateWalletPortfolioResponse\x12X\n" +
+	"\x11watchTokenHolders\x12 .wallet.WatchTokenHoldersRequest\x1a!.wallet.WatchTokenHoldersResponse\x12O\n" +
+	"\x0egetHolderFlows\x12\x1d.wallet.GetHolderFlowsRequest\x1a\x1e.wallet.GetHolderFlowsResponse\x12O\n" +
+	"\x0esetWalletLabel\x12\x1d.wallet.SetWalletLabelRequest\x1a\x1e.wallet.SetWalletLabelResponse\x12U\n" +
+	"\x10listWalletsByTag\x12\x1f.wallet.ListWalletsByTagRequest\x1a .wallet.ListWalletsByTagResponse\x12N\n" +
+	"\x12streamWalletTrades\x12!.wallet.StreamWalletTradesRequest\x1a\x13.wallet.WalletTrade0\x01\x12N\n" +
+	"\x12streamWalletEvents\x12!.wallet.StreamWalletEventsRequest\x1a\x13.wallet.WalletEvent0\x01\x12^\n" +
+	"\x13getPortfolioHistory\x12\".wallet.GetPortfolioHistoryRequest\x1a#.wallet.GetPortfolioHistoryResponse\x12[\n" +
+	"\x12getWalletApprovals\x12!.wallet.GetWalletApprovalsRequest\x1a\".wallet.GetWalletApprovalsResponse\x12g\n" +
+	"\x16getAggregatedPortfolio\x12%.wallet.GetAggregatedPortfolioRequest\x1a&.wallet.GetAggregatedPortfolioResponse\x12a\n" +
+	"\x14getWalletLeaderboard\x12#.wallet.GetWalletLeaderboardRequest\x1a$.wallet.GetWalletLeaderboardResponse\x12^\n" +
+	"\x13getDailyLeaderboard\x12\".wallet.GetDailyLeaderboardRequest\x1a#.wallet.GetDailyLeaderboardResponse\x12p\n" +
+	"\x19setWalletLeaderboardOptIn\x12(.wallet.SetWalletLeaderboardOptInRequest\x1a).wallet.SetWalletLeaderboardOptInResponse\x12a\n" +
+	"\x14setWalletWatchFilter\x12#.wallet.SetWalletWatchFilterRequest\x1a$.wallet.SetWalletWatchFilterResponse\x12U\n" +
+	"\x10setWalletWebhook\x12\x1f.wallet.SetWalletWebhookRequest\x1a .wallet.SetWalletWebhookResponse\x12R\n" +
+	"\x0fsetWalletAlerts\x12\x1e.wallet.SetWalletAlertsRequest\x1a\x1f.wallet.SetWalletAlertsResponse\x12U\n" +
+	"\x10addKnownContract\x12\x1f.wallet.AddKnownContractRequest\x1a .wallet.AddKnownContractResponse\x12^\n" +
+	"\x13removeKnownContract\x12\".wallet.RemoveKnownContractRequest\x1a#.wallet.RemoveKnownContractResponse\x12[\n" +
+	"\x12listKnownContracts\x12!.wallet.ListKnownContractsRequest\x1a\".wallet.ListKnownContractsResponse\x12L\n" +
+	"\rimportWallets\x12\x1c.wallet.ImportWalletsRequest\x1a\x1d.wallet.ImportWalletsResponse\x12I\n" +
+	"\fgetImportJob\x12\x1b.wallet.GetImportJobRequest\x1a\x1c.wallet.GetImportJobResponse\x12d\n" +
+	"\x15getWalletTransactions\x12$.wallet.GetWalletTransactionsRequest\x1a%.wallet.GetWalletTransactionsResponse\x12L\n" +
+	"\rmarkTokenSafe\x12\x1c.wallet.MarkTokenSafeRequest\x1a\x1d.wallet.MarkTokenSafeResponse\x12d\n" +
+	"\x15rebalanceWalletShards\x12$.wallet.RebalanceWalletShardsRequest\x1a%.wallet.RebalanceWalletShardsResponse\x12F\n" +
+	"\vgetApiUsage\x12\x1a.wallet.GetApiUsageRequest\x1a\x1b.wallet.GetApiUsageResponseB\x19Z\x17walletdata/proto/walletb\x06proto3"
 
 var file_wallet_wallet_proto_goTypes = []any{
-	(*AddWalletRequest)(nil),              // 0: wallet.AddWalletRequest
-	(*GetWalletRequest)(nil),              // 1: wallet.GetWalletRequest
-	(*GetWalletTokensRequest)(nil),        // 2: wallet.GetWalletTokensRequest
-	(*GetWalletDetailsRequest)(nil),       // 3: wallet.GetWalletDetailsRequest
-	(*UpdateWalletPortfolioRequest)(nil),  // 4: wallet.UpdateWalletPortfolioRequest
-	(*AddWalletResponse)(nil),             // 5: wallet.AddWalletResponse
-	(*GetWalletResponse)(nil),             // 6: wallet.GetWalletResponse
-	(*GetWalletTokensResponse)(nil),       // 7: wallet.GetWalletTokensResponse
-	(*GetWalletDetailsResponse)(nil),      // 8: wallet.GetWalletDetailsResponse
-	(*UpdateWalletPortfolioResponse)(nil), // 9: wallet.UpdateWalletPortfolioResponse
+	(*AddWalletRequest)(nil),                  // 0: wallet.AddWalletRequest
+	(*GetWalletRequest)(nil),                  // 1: wallet.GetWalletRequest
+	(*GetWalletTokensRequest)(nil),            // 2: wallet.GetWalletTokensRequest
+	(*GetWalletDetailsRequest)(nil),           // 3: wallet.GetWalletDetailsRequest
+	(*UpdateWalletPortfolioRequest)(nil),      // 4: wallet.UpdateWalletPortfolioRequest
+	(*WatchTokenHoldersRequest)(nil),          // 5: wallet.WatchTokenHoldersRequest
+	(*GetHolderFlowsRequest)(nil),             // 6: wallet.GetHolderFlowsRequest
+	(*SetWalletLabelRequest)(nil),             // 7: wallet.SetWalletLabelRequest
+	(*ListWalletsByTagRequest)(nil),           // 8: wallet.ListWalletsByTagRequest
+	(*StreamWalletTradesRequest)(nil),         // 9: wallet.StreamWalletTradesRequest
+	(*StreamWalletEventsRequest)(nil),         // 10: wallet.StreamWalletEventsRequest
+	(*GetPortfolioHistoryRequest)(nil),        // 11: wallet.GetPortfolioHistoryRequest
+	(*GetWalletApprovalsRequest)(nil),         // 12: wallet.GetWalletApprovalsRequest
+	(*GetAggregatedPortfolioRequest)(nil),     // 13: wallet.GetAggregatedPortfolioRequest
+	(*GetWalletLeaderboardRequest)(nil),       // 14: wallet.GetWalletLeaderboardRequest
+	(*GetDailyLeaderboardRequest)(nil),        // 15: wallet.GetDailyLeaderboardRequest
+	(*SetWalletLeaderboardOptInRequest)(nil),  // 16: wallet.SetWalletLeaderboardOptInRequest
+	(*SetWalletWatchFilterRequest)(nil),       // 17: wallet.SetWalletWatchFilterRequest
+	(*SetWalletWebhookRequest)(nil),           // 18: wallet.SetWalletWebhookRequest
+	(*SetWalletAlertsRequest)(nil),            // 19: wallet.SetWalletAlertsRequest
+	(*AddKnownContractRequest)(nil),           // 20: wallet.AddKnownContractRequest
+	(*RemoveKnownContractRequest)(nil),        // 21: wallet.RemoveKnownContractRequest
+	(*ListKnownContractsRequest)(nil),         // 22: wallet.ListKnownContractsRequest
+	(*ImportWalletsRequest)(nil),              // 23: wallet.ImportWalletsRequest
+	(*GetImportJobRequest)(nil),               // 24: wallet.GetImportJobRequest
+	(*GetWalletTransactionsRequest)(nil),      // 25: wallet.GetWalletTransactionsRequest
+	(*MarkTokenSafeRequest)(nil),              // 26: wallet.MarkTokenSafeRequest
+	(*RebalanceWalletShardsRequest)(nil),      // 27: wallet.RebalanceWalletShardsRequest
+	(*GetApiUsageRequest)(nil),                // 28: wallet.GetApiUsageRequest
+	(*AddWalletResponse)(nil),                 // 29: wallet.AddWalletResponse
+	(*GetWalletResponse)(nil),                 // 30: wallet.GetWalletResponse
+	(*GetWalletTokensResponse)(nil),           // 31: wallet.GetWalletTokensResponse
+	(*GetWalletDetailsResponse)(nil),          // 32: wallet.GetWalletDetailsResponse
+	(*UpdateWalletPortfolioResponse)(nil),     // 33: wallet.UpdateWalletPortfolioResponse
+	(*WatchTokenHoldersResponse)(nil),         // 34: wallet.WatchTokenHoldersResponse
+	(*GetHolderFlowsResponse)(nil),            // 35: wallet.GetHolderFlowsResponse
+	(*SetWalletLabelResponse)(nil),            // 36: wallet.SetWalletLabelResponse
+	(*ListWalletsByTagResponse)(nil),          // 37: wallet.ListWalletsByTagResponse
+	(*WalletTrade)(nil),                       // 38: wallet.WalletTrade
+	(*WalletEvent)(nil),                       // 39: wallet.WalletEvent
+	(*GetPortfolioHistoryResponse)(nil),       // 40: wallet.GetPortfolioHistoryResponse
+	(*GetWalletApprovalsResponse)(nil),        // 41: wallet.GetWalletApprovalsResponse
+	(*GetAggregatedPortfolioResponse)(nil),    // 42: wallet.GetAggregatedPortfolioResponse
+	(*GetWalletLeaderboardResponse)(nil),      // 43: wallet.GetWalletLeaderboardResponse
+	(*GetDailyLeaderboardResponse)(nil),       // 44: wallet.GetDailyLeaderboardResponse
+	(*SetWalletLeaderboardOptInResponse)(nil), // 45: wallet.SetWalletLeaderboardOptInResponse
+	(*SetWalletWatchFilterResponse)(nil),      // 46: wallet.SetWalletWatchFilterResponse
+	(*SetWalletWebhookResponse)(nil),          // 47: wallet.SetWalletWebhookResponse
+	(*SetWalletAlertsResponse)(nil),           // 48: wallet.SetWalletAlertsResponse
+	(*AddKnownContractResponse)(nil),          // 49: wallet.AddKnownContractResponse
+	(*RemoveKnownContractResponse)(nil),       // 50: wallet.RemoveKnownContractResponse
+	(*ListKnownContractsResponse)(nil),        // 51: wallet.ListKnownContractsResponse
+	(*ImportWalletsResponse)(nil),             // 52: wallet.ImportWalletsResponse
+	(*GetImportJobResponse)(nil),              // 53: wallet.GetImportJobResponse
+	(*GetWalletTransactionsResponse)(nil),     // 54: wallet.GetWalletTransactionsResponse
+	(*MarkTokenSafeResponse)(nil),             // 55: wallet.MarkTokenSafeResponse
+	(*RebalanceWalletShardsResponse)(nil),     // 56: wallet.RebalanceWalletShardsResponse
+	(*GetApiUsageResponse)(nil),               // 57: wallet.GetApiUsageResponse
 }
 var file_wallet_wallet_proto_depIdxs = []int32{
-	0, // 0: scanner_wallet.ScannerWallet.addWallet:input_type -> wallet.AddWalletRequest
-	1, // 1: scanner_wallet.ScannerWallet.getWallet:input_type -> wallet.GetWalletRequest
-	2, // 2: scanner_wallet.ScannerWallet.getWalletTokens:input_type -> wallet.GetWalletTokensRequest
-	3, // 3: scanner_wallet.ScannerWallet.getWalletDetails:input_type -> wallet.GetWalletDetailsRequest
-	4, // 4: scanner_wallet.ScannerWallet.updateWalletPortfolio:input_type -> wallet.UpdateWalletPortfolioRequest
-	5, // 5: scanner_wallet.ScannerWallet.addWallet:output_type -> wallet.AddWalletResponse
-	6, // 6: scanner_wallet.ScannerWallet.getWallet:output_type -> wallet.GetWalletResponse
-	7, // 7: scanner_wallet.ScannerWallet.getWalletTokens:output_type -> wallet.GetWalletTokensResponse
-	8, // 8: scanner_wallet.ScannerWallet.getWalletDetails:output_type -> wallet.GetWalletDetailsResponse
-	9, // 9: scanner_wallet.ScannerWallet.updateWalletPortfolio:output_type -> wallet.UpdateWalletPortfolioResponse
-	5, // [5:10] is the sub-list for method output_type
-	0, // [0:5] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: scanner_wallet.ScannerWallet.addWallet:input_type -> wallet.AddWalletRequest
+	1,  // 1: scanner_wallet.ScannerWallet.getWallet:input_type -> wallet.GetWalletRequest
+	2,  // 2: scanner_wallet.ScannerWallet.getWalletTokens:input_type -> wallet.GetWalletTokensRequest
+	3,  // 3: scanner_wallet.ScannerWallet.getWalletDetails:input_type -> wallet.GetWalletDetailsRequest
+	4,  // 4: scanner_wallet.ScannerWallet.updateWalletPortfolio:input_type -> wallet.UpdateWalletPortfolioRequest
+	5,  // 5: scanner_wallet.ScannerWallet.watchTokenHolders:input_type -> wallet.WatchTokenHoldersRequest
+	6,  // 6: scanner_wallet.ScannerWallet.getHolderFlows:input_type -> wallet.GetHolderFlowsRequest
+	7,  // 7: scanner_wallet.ScannerWallet.setWalletLabel:input_type -> wallet.SetWalletLabelRequest
+	8,  // 8: scanner_wallet.ScannerWallet.listWalletsByTag:input_type -> wallet.ListWalletsByTagRequest
+	9,  // 9: scanner_wallet.ScannerWallet.streamWalletTrades:input_type -> wallet.StreamWalletTradesRequest
+	10, // 10: scanner_wallet.ScannerWallet.streamWalletEvents:input_type -> wallet.StreamWalletEventsRequest
+	11, // 11: scanner_wallet.ScannerWallet.getPortfolioHistory:input_type -> wallet.GetPortfolioHistoryRequest
+	12, // 12: scanner_wallet.ScannerWallet.getWalletApprovals:input_type -> wallet.GetWalletApprovalsRequest
+	13, // 13: scanner_wallet.ScannerWallet.getAggregatedPortfolio:input_type -> wallet.GetAggregatedPortfolioRequest
+	14, // 14: scanner_wallet.ScannerWallet.getWalletLeaderboard:input_type -> wallet.GetWalletLeaderboardRequest
+	15, // 15: scanner_wallet.ScannerWallet.getDailyLeaderboard:input_type -> wallet.GetDailyLeaderboardRequest
+	16, // 16: scanner_wallet.ScannerWallet.setWalletLeaderboardOptIn:input_type -> wallet.SetWalletLeaderboardOptInRequest
+	17, // 17: scanner_wallet.ScannerWallet.setWalletWatchFilter:input_type -> wallet.SetWalletWatchFilterRequest
+	18, // 18: scanner_wallet.ScannerWallet.setWalletWebhook:input_type -> wallet.SetWalletWebhookRequest
+	19, // 19: scanner_wallet.ScannerWallet.setWalletAlerts:input_type -> wallet.SetWalletAlertsRequest
+	20, // 20: scanner_wallet.ScannerWallet.addKnownContract:input_type -> wallet.AddKnownContractRequest
+	21, // 21: scanner_wallet.ScannerWallet.removeKnownContract:input_type -> wallet.RemoveKnownContractRequest
+	22, // 22: scanner_wallet.ScannerWallet.listKnownContracts:input_type -> wallet.ListKnownContractsRequest
+	23, // 23: scanner_wallet.ScannerWallet.importWallets:input_type -> wallet.ImportWalletsRequest
+	24, // 24: scanner_wallet.ScannerWallet.getImportJob:input_type -> wallet.GetImportJobRequest
+	25, // 25: scanner_wallet.ScannerWallet.getWalletTransactions:input_type -> wallet.GetWalletTransactionsRequest
+	26, // 26: scanner_wallet.ScannerWallet.markTokenSafe:input_type -> wallet.MarkTokenSafeRequest
+	27, // 27: scanner_wallet.ScannerWallet.rebalanceWalletShards:input_type -> wallet.RebalanceWalletShardsRequest
+	28, // 28: scanner_wallet.ScannerWallet.getApiUsage:input_type -> wallet.GetApiUsageRequest
+	29, // 29: scanner_wallet.ScannerWallet.addWallet:output_type -> wallet.AddWalletResponse
+	30, // 30: scanner_wallet.ScannerWallet.getWallet:output_type -> wallet.GetWalletResponse
+	31, // 31: scanner_wallet.ScannerWallet.getWalletTokens:output_type -> wallet.GetWalletTokensResponse
+	32, // 32: scanner_wallet.ScannerWallet.getWalletDetails:output_type -> wallet.GetWalletDetailsResponse
+	33, // 33: scanner_wallet.ScannerWallet.updateWalletPortfolio:output_type -> wallet.UpdateWalletPortfolioResponse
+	34, // 34: scanner_wallet.ScannerWallet.watchTokenHolders:output_type -> wallet.WatchTokenHoldersResponse
+	35, // 35: scanner_wallet.ScannerWallet.getHolderFlows:output_type -> wallet.GetHolderFlowsResponse
+	36, // 36: scanner_wallet.ScannerWallet.setWalletLabel:output_type -> wallet.SetWalletLabelResponse
+	37, // 37: scanner_wallet.ScannerWallet.listWalletsByTag:output_type -> wallet.ListWalletsByTagResponse
+	38, // 38: scanner_wallet.ScannerWallet.streamWalletTrades:output_type -> wallet.WalletTrade
+	39, // 39: scanner_wallet.ScannerWallet.streamWalletEvents:output_type -> wallet.WalletEvent
+	40, // 40: scanner_wallet.ScannerWallet.getPortfolioHistory:output_type -> wallet.GetPortfolioHistoryResponse
+	41, // 41: scanner_wallet.ScannerWallet.getWalletApprovals:output_type -> wallet.GetWalletApprovalsResponse
+	42, // 42: scanner_wallet.ScannerWallet.getAggregatedPortfolio:output_type -> wallet.GetAggregatedPortfolioResponse
+	43, // 43: scanner_wallet.ScannerWallet.getWalletLeaderboard:output_type -> wallet.GetWalletLeaderboardResponse
+	44, // 44: scanner_wallet.ScannerWallet.getDailyLeaderboard:output_type -> wallet.GetDailyLeaderboardResponse
+	45, // 45: scanner_wallet.ScannerWallet.setWalletLeaderboardOptIn:output_type -> wallet.SetWalletLeaderboardOptInResponse
+	46, // 46: scanner_wallet.ScannerWallet.setWalletWatchFilter:output_type -> wallet.SetWalletWatchFilterResponse
+	47, // 47: scanner_wallet.ScannerWallet.setWalletWebhook:output_type -> wallet.SetWalletWebhookResponse
+	48, // 48: scanner_wallet.ScannerWallet.setWalletAlerts:output_type -> wallet.SetWalletAlertsResponse
+	49, // 49: scanner_wallet.ScannerWallet.addKnownContract:output_type -> wallet.AddKnownContractResponse
+	50, // 50: scanner_wallet.ScannerWallet.removeKnownContract:output_type -> wallet.RemoveKnownContractResponse
+	51, // 51: scanner_wallet.ScannerWallet.listKnownContracts:output_type -> wallet.ListKnownContractsResponse
+	52, // 52: scanner_wallet.ScannerWallet.importWallets:output_type -> wallet.ImportWalletsResponse
+	53, // 53: scanner_wallet.ScannerWallet.getImportJob:output_type -> wallet.GetImportJobResponse
+	54, // 54: scanner_wallet.ScannerWallet.getWalletTransactions:output_type -> wallet.GetWalletTransactionsResponse
+	55, // 55: scanner_wallet.ScannerWallet.markTokenSafe:output_type -> wallet.MarkTokenSafeResponse
+	56, // 56: scanner_wallet.ScannerWallet.rebalanceWalletShards:output_type -> wallet.RebalanceWalletShardsResponse
+	57, // 57: scanner_wallet.ScannerWallet.getApiUsage:output_type -> wallet.GetApiUsageResponse
+	29, // [29:58] is the sub-list for method output_type
+	0,  // [0:29] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
 }
 
 func init() { file_wallet_wallet_proto_init() }
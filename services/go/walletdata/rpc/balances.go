@@ -0,0 +1,70 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"samterminal/pkg/multicall"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const erc20BalanceOfABI = `[{"inputs":[{"name":"account","type":"address"}],"name":"balanceOf","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"}]`
+
+var erc20 = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(erc20BalanceOfABI))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// GetTokenBalances reads the raw ERC-20 balances of a wallet through Multicall3. Tokens whose
+// balanceOf fails are left out.
+func GetTokenBalances(walletAddress string, tokenAddresses []string) (map[string]*big.Int, error) {
+	if !common.IsHexAddress(walletAddress) {
+		return nil, fmt.Errorf("invalid address")
+	}
+	client, ctx, err := getEthClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	wallet := common.HexToAddress(walletAddress)
+	calls := make([]multicall.Call, 0, len(tokenAddresses))
+	for _, token := range tokenAddresses {
+		call, err := multicall.NewCall(erc20, common.HexToAddress(token), "balanceOf", wallet)
+		if err != nil {
+			return nil, err
+		}
+		calls = append(calls, call)
+	}
+	results, err := multicall.Aggregate(ctx, client, calls)
+	if err != nil {
+		return nil, err
+	}
+	return balancesOf(tokenAddresses, results), nil
+}
+
+// balancesOf maps tokens to the balances their balanceOf calls returned.
+func balancesOf(tokens []string, results []multicall.Result) map[string]*big.Int {
+	balances := map[string]*big.Int{}
+	for i, result := range results {
+		if i >= len(tokens) {
+			break
+		}
+		values, err := result.Unpack(erc20, "balanceOf")
+		if err != nil {
+			continue
+		}
+		if balance, ok := values[0].(*big.Int); ok {
+			balances[strings.ToLower(tokens[i])] = balance
+		}
+	}
+	return balances
+}
@@ -0,0 +1,21 @@
+package rpc
+
+import (
+	"math/big"
+	"samterminal/pkg/multicall"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestBalancesOf(t *testing.T) {
+	results := []multicall.Result{
+		{Success: true, ReturnData: common.LeftPadBytes(big.NewInt(1500).Bytes(), 32)},
+		{Success: false, ReturnData: []byte{}},
+		{Success: true, ReturnData: []byte{0x01}},
+	}
+	balances := balancesOf([]string{"0xAAAA", "0xbbbb", "0xcccc"}, results)
+	if len(balances) != 1 || balances["0xaaaa"].Int64() != 1500 {
+		t.Errorf("balances = %v, want only 0xaaaa = 1500", balances)
+	}
+}
@@ -10,8 +10,11 @@ import (
 	"time"
 	"walletdata/env"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 )
@@ -25,13 +28,27 @@ const (
 )
 
 type WalletTransaction struct {
-	Hash         common.Hash
-	Direction    TransactionDirection
-	Counterparty *common.Address
-	ValueWei     *big.Int
-	Raw          PendingTransactionPayload
+	Hash           common.Hash
+	Direction      TransactionDirection
+	Counterparty   *common.Address
+	ValueWei       *big.Int
+	TokenTransfers []TokenTransfer
+	// Logs are the logs the transaction emitted, nil when its receipt could not be read.
+	Logs []*types.Log
+	Raw  PendingTransactionPayload
 }
 
+// TokenTransfer is an ERC-20 Transfer log, emitted by the transaction, that involves the watched wallet.
+type TokenTransfer struct {
+	Token  common.Address
+	From   common.Address
+	To     common.Address
+	Amount *big.Int
+}
+
+// transferEventTopic is keccak256("Transfer(address,address,uint256)").
+var transferEventTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
 // Alchemy Pending Payload Structure
 type PendingTransactionPayload struct {
 	Hash                 common.Hash     `json:"hash"`
@@ -55,14 +72,18 @@ type WalletSubscription struct {
 var client *ethclient.Client
 var socketClient *gethrpc.Client
 
-func init() {
-	env.LoadEnv("./.env")
-
+// Connect dials the RPC endpoints, exiting when they cannot be reached. The websocket endpoint
+// is only dialed when wallets are subscribed to. The clients are dialed on first use otherwise,
+// so packages using them can be imported without a node.
+func Connect(subscribe bool) {
 	var err error
 	client, _, err = getEthClient()
 	if err != nil {
 		log.Fatalf("Failed to create eth client: %v", err)
 	}
+	if !subscribe {
+		return
+	}
 	socketClient, _, err = getRpcClient()
 	if err != nil {
 		log.Fatalf("Failed to create rpc client: %v", err)
@@ -103,11 +124,6 @@ func getRpcClient() (*gethrpc.Client, context.Context, error) {
 	return socketClient, ctx, nil
 }
 
-func WatchWalletForUpdates(walletAddress string, onEvent func(event WalletTransaction)) error {
-	_, err := SubscribeWalletTransactions(context.Background(), walletAddress, onEvent)
-	return err
-}
-
 func SubscribeWalletTransactions(ctx context.Context, walletAddress string, onEvent func(event WalletTransaction)) (*WalletSubscription, error) {
 
 	if !common.IsHexAddress(walletAddress) {
@@ -239,19 +255,83 @@ func buildWalletTransaction(payload PendingTransactionPayload, wallet common.Add
 		event.Counterparty = &cp
 	}
 
+	logs, err := GetTransactionLogs(payload.Hash)
+	if err != nil {
+		log.Printf("Error decoding token transfers for %s: %v", payload.Hash.Hex(), err)
+	} else {
+		event.Logs = logs
+		event.TokenTransfers = tokenTransfersOf(logs, wallet)
+	}
+
 	if onEvent != nil {
 		onEvent(event)
 	}
 
-	// var tokenAddress hexutil.Bytes
-	// // panic: runtime error: slice bounds out of range [:42] with capacity 0
-	// if len(payload.Input) > 42 {
-	// 	tokenAddress = payload.Input[0:42]
-	// }
-
 	return event
 }
 
+// GetTransactionLogs reads the receipt of a mined transaction and returns the logs it emitted.
+func GetTransactionLogs(hash common.Hash) ([]*types.Log, error) {
+	client, ctx, err := getEthClient()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	receipt, err := client.TransactionReceipt(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	return receipt.Logs, nil
+}
+
+// tokenTransfersOf decodes the ERC-20 transfers that move tokens in or out of wallet.
+func tokenTransfersOf(logs []*types.Log, wallet common.Address) []TokenTransfer {
+	transfers := []TokenTransfer{}
+	for _, vLog := range logs {
+		// ERC-721 transfers index the token id as a fourth topic and carry no data.
+		if len(vLog.Topics) != 3 || vLog.Topics[0] != transferEventTopic || len(vLog.Data) != 32 {
+			continue
+		}
+		from := common.BytesToAddress(vLog.Topics[1].Bytes())
+		to := common.BytesToAddress(vLog.Topics[2].Bytes())
+		if from != wallet && to != wallet {
+			continue
+		}
+		transfers = append(transfers, TokenTransfer{
+			Token:  vLog.Address,
+			From:   from,
+			To:     to,
+			Amount: new(big.Int).SetBytes(vLog.Data),
+		})
+	}
+	return transfers
+}
+
+// decimalsSelector is the 4 byte selector of ERC-20 decimals().
+var decimalsSelector = crypto.Keccak256([]byte("decimals()"))[:4]
+
+func GetTokenDecimals(tokenAddress common.Address) (int, error) {
+	client, ctx, err := getEthClient()
+	if err != nil {
+		return 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	res, err := client.CallContract(ctx, ethereum.CallMsg{To: &tokenAddress, Data: decimalsSelector}, nil)
+	if err != nil {
+		return 0, err
+	}
+	if len(res) != 32 {
+		return 0, fmt.Errorf("unexpected decimals() response for %s", tokenAddress.Hex())
+	}
+	return int(new(big.Int).SetBytes(res).Int64()), nil
+}
+
 func GetNativeBalance(walletAddress string) (string, error) {
 	if !common.IsHexAddress(walletAddress) {
 		return "0", fmt.Errorf("invalid address")
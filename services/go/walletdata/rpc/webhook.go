@@ -0,0 +1,99 @@
+package rpc
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// AlchemySignatureHeader carries the hex HMAC-SHA256 of an Alchemy Notify body under the signing
+// key of the webhook.
+const AlchemySignatureHeader = "X-Alchemy-Signature"
+
+const addressActivityType = "ADDRESS_ACTIVITY"
+
+var ErrInvalidAlchemyWebhook = errors.New("invalid alchemy webhook")
+
+// AlchemyActivity is a transfer of an Alchemy address activity webhook. A transaction moving
+// several assets is reported as several activities with the same hash.
+type AlchemyActivity struct {
+	FromAddress string `json:"fromAddress"`
+	ToAddress   string `json:"toAddress"`
+	Hash        string `json:"hash"`
+}
+
+type alchemyWebhook struct {
+	Type  string `json:"type"`
+	Event struct {
+		Activity []AlchemyActivity `json:"activity"`
+	} `json:"event"`
+}
+
+// VerifyAlchemySignature reports whether signature is the signature of body under signingKey.
+func VerifyAlchemySignature(body []byte, signature string, signingKey string) bool {
+	expected, err := hex.DecodeString(strings.TrimSpace(signature))
+	if err != nil || signingKey == "" {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(signingKey))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// ParseAlchemyWebhook returns the activities of an address activity webhook. Webhooks of other
+// types carry none.
+func ParseAlchemyWebhook(body []byte) ([]AlchemyActivity, error) {
+	var webhook alchemyWebhook
+	if err := json.Unmarshal(body, &webhook); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAlchemyWebhook, err)
+	}
+	if webhook.Type != addressActivityType {
+		return []AlchemyActivity{}, nil
+	}
+	return webhook.Event.Activity, nil
+}
+
+// WalletTransactionByHash reads a mined transaction and builds the event a wallet subscription
+// would have delivered for it.
+func WalletTransactionByHash(hash common.Hash, wallet common.Address) (WalletTransaction, error) {
+	client, ctx, err := getEthClient()
+	if err != nil {
+		return WalletTransaction{}, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	tx, _, err := client.TransactionByHash(ctx, hash)
+	if err != nil {
+		return WalletTransaction{}, err
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return WalletTransaction{}, err
+	}
+	payload := PendingTransactionPayload{
+		Hash:     tx.Hash(),
+		From:     from,
+		To:       tx.To(),
+		Value:    (*hexutil.Big)(tx.Value()),
+		Nonce:    hexutil.Uint64(tx.Nonce()),
+		Gas:      hexutil.Uint64(tx.Gas()),
+		GasPrice: (*hexutil.Big)(tx.GasPrice()),
+		Input:    tx.Data(),
+	}
+	if tx.Type() == types.DynamicFeeTxType {
+		payload.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		payload.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	}
+	return buildWalletTransaction(payload, wallet, nil), nil
+}
@@ -0,0 +1,41 @@
+package rpc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+func TestVerifyAlchemySignature(t *testing.T) {
+	body := []byte(`{"type":"ADDRESS_ACTIVITY"}`)
+	mac := hmac.New(sha256.New, []byte("key"))
+	mac.Write(body)
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	if !VerifyAlchemySignature(body, signature, "key") {
+		t.Error("valid signature rejected")
+	}
+	if VerifyAlchemySignature(body, signature, "other") {
+		t.Error("signature under another key accepted")
+	}
+	if VerifyAlchemySignature([]byte(`{}`), signature, "key") {
+		t.Error("signature of another body accepted")
+	}
+	if VerifyAlchemySignature(body, signature, "") {
+		t.Error("signature accepted without a signing key")
+	}
+}
+
+func TestParseAlchemyWebhook(t *testing.T) {
+	activities, err := ParseAlchemyWebhook([]byte(`{"type":"ADDRESS_ACTIVITY","event":{"network":"BASE_MAINNET","activity":[{"fromAddress":"0xa","toAddress":"0xb","hash":"0x1","asset":"ETH"}]}}`))
+	if err != nil || len(activities) != 1 || activities[0].Hash != "0x1" || activities[0].ToAddress != "0xb" {
+		t.Errorf("activities = %+v, %v", activities, err)
+	}
+	if activities, err := ParseAlchemyWebhook([]byte(`{"type":"MINED_TRANSACTION"}`)); err != nil || len(activities) != 0 {
+		t.Errorf("activities of another webhook type = %+v, %v", activities, err)
+	}
+	if _, err := ParseAlchemyWebhook([]byte(`not json`)); err == nil {
+		t.Error("invalid body parsed")
+	}
+}